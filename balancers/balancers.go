@@ -141,6 +141,15 @@ func PreferWithFallback(balancer *balancerConfig.Config, filter func(endpoint En
 	return balancer
 }
 
+// WithMaxStreamsPerConn limits count of active streams of connection (gRPC servers usually
+// limit count of concurrent streams of connection, so streams over the limit are queued).
+// New streams over the limit are routed to other connections with free stream budget;
+// if all connections are at the limit, stream is opened on connection chosen by balancer
+func WithMaxStreamsPerConn(balancer *balancerConfig.Config, maxStreams int) *balancerConfig.Config {
+	balancer.MaxStreamsPerConn = maxStreams
+	return balancer
+}
+
 // Default balancer used by default
 func Default() *balancerConfig.Config {
 	return RandomChoice()
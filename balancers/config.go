@@ -29,6 +29,8 @@ type balancersConfig struct {
 	Prefer    preferType   `json:"prefer,omitempty"`
 	Fallback  bool         `json:"fallback,omitempty"`
 	Locations []string     `json:"locations,omitempty"`
+
+	MaxStreamsPerConn int `json:"max_streams_per_conn,omitempty"`
 }
 
 type fromConfigOptionsHolder struct {
@@ -86,6 +88,9 @@ func CreateFromConfig(s string) (*balancerConfig.Config, error) {
 	if err != nil {
 		return nil, xerrors.WithStackTrace(err)
 	}
+	if c.MaxStreamsPerConn > 0 {
+		b = WithMaxStreamsPerConn(b, c.MaxStreamsPerConn)
+	}
 
 	switch c.Prefer {
 	case preferTypeLocalDC:
@@ -56,6 +56,14 @@ func TestFromConfig(t *testing.T) {
 			config: `random_choice`,
 			res:    balancerConfig.Config{},
 		},
+		{
+			name: "random_choice/JSON/max_streams_per_conn",
+			config: `{
+				"type": "random_choice",
+				"max_streams_per_conn": 100
+			}`,
+			res: balancerConfig.Config{MaxStreamsPerConn: 100},
+		},
 		{
 			name: "random_choice/JSON",
 			config: `{
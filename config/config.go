@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"time"
 
+	"github.com/jonboulle/clockwork"
 	"google.golang.org/grpc"
 	grpcCodes "google.golang.org/grpc/codes"
 
@@ -32,7 +33,11 @@ type Config struct {
 	tlsConfig      *tls.Config
 	meta           *meta.Meta
 
+	defaultTimeouts map[OperationKind]time.Duration
+
 	excludeGRPCCodesForPessimization []grpcCodes.Code
+
+	databaseUnavailableAsWarning bool
 }
 
 func (c *Config) Credentials() credentials.Credentials {
@@ -92,6 +97,12 @@ func (c *Config) Database() string {
 	return c.database
 }
 
+// DatabaseUnavailableAsWarning reports whether failed check of database on driver start
+// is a warning trace event instead of error
+func (c *Config) DatabaseUnavailableAsWarning() bool {
+	return c.databaseUnavailableAsWarning
+}
+
 // Trace contains driver tracing options.
 func (c *Config) Trace() *trace.Driver {
 	return c.trace
@@ -210,6 +221,14 @@ func WithNoAutoRetry() Option {
 	}
 }
 
+// WithDatabaseUnavailableAsWarning downgrades failed check of database on driver start
+// to warning trace event trace.Driver.OnDatabaseUnavailable
+func WithDatabaseUnavailableAsWarning() Option {
+	return func(c *Config) {
+		c.databaseUnavailableAsWarning = true
+	}
+}
+
 // WithPanicCallback applies panic callback to config
 func WithPanicCallback(panicCallback func(e interface{})) Option {
 	return func(c *Config) {
@@ -217,6 +236,23 @@ func WithPanicCallback(panicCallback func(e interface{})) Option {
 	}
 }
 
+// WithClock defines source of current time for all clients of driver
+// (scheduling of static credentials refresh, session pool and validation of timestamps)
+func WithClock(clock clockwork.Clock) Option {
+	return func(c *Config) {
+		config.SetClock(&c.Common, clock)
+	}
+}
+
+// WithClockSkew defines allowance of skew between local clock and clock of YDB server.
+// Allowance is used for scheduling of static credentials refresh and for validation of
+// timestamps of data queries parameters (see trace.Table.OnSessionQueryTimestampWarning)
+func WithClockSkew(skew time.Duration) Option {
+	return func(c *Config) {
+		config.SetClockSkew(&c.Common, skew)
+	}
+}
+
 func WithDialTimeout(timeout time.Duration) Option {
 	return func(c *Config) {
 		c.dialTimeout = timeout
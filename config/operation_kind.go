@@ -0,0 +1,68 @@
+package config
+
+import (
+	"time"
+)
+
+// OperationKind is a kind of YDB call which defines default timeout of call
+type OperationKind int
+
+const (
+	// OperationKindOther is a kind of unary calls which are not DDL and not data queries
+	// (sessions management, describes, discovery, etc.)
+	OperationKindOther OperationKind = iota
+	// OperationKindDDL is a kind of schema changing calls (create/alter/drop tables,
+	// topics, directories, coordination nodes, etc.)
+	OperationKindDDL
+	// OperationKindDataQuery is a kind of data queries and transactions control calls
+	OperationKindDataQuery
+	// OperationKindStream is a kind of streaming calls (scan queries, read table,
+	// topic reading and writing, etc.)
+	OperationKindStream
+)
+
+func (k OperationKind) String() string {
+	switch k {
+	case OperationKindDDL:
+		return "ddl"
+	case OperationKindDataQuery:
+		return "data"
+	case OperationKindStream:
+		return "stream"
+	default:
+		return "other"
+	}
+}
+
+// DefaultTimeout returns default timeout for calls of given kind
+//
+// Default timeout applies only to calls with context without deadline.
+func (c *Config) DefaultTimeout(kind OperationKind) (time.Duration, bool) {
+	d, has := c.defaultTimeouts[kind]
+	if !has || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// WithDefaultTimeouts defines default timeouts for calls by kind of operation
+//
+// Default timeout is applied as deadline of call context only if context has no
+// deadline. Explicit deadline of context (context.WithTimeout, context.WithDeadline)
+// always overrides default timeout, even if explicit deadline is later.
+// Zero or absent timeout for kind means no default deadline for calls of this kind.
+//
+// Default timeout is a client-side deadline and applied after operation params
+// of request were derived from context. Therefore, default timeout does not
+// change server-side operation timeout, which is still defined by
+// WithOperationTimeout and operation.WithTimeout context values.
+func WithDefaultTimeouts(timeouts map[OperationKind]time.Duration) Option {
+	return func(c *Config) {
+		if c.defaultTimeouts == nil {
+			c.defaultTimeouts = make(map[OperationKind]time.Duration, len(timeouts))
+		}
+		for kind, d := range timeouts {
+			c.defaultTimeouts[kind] = d
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package credentials
 
 import (
+	"time"
+
+	"github.com/jonboulle/clockwork"
 	"google.golang.org/grpc"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/credentials"
@@ -15,3 +18,14 @@ func WithSourceInfo(sourceInfo string) credentials.SourceInfoOption {
 func WithGrpcDialOptions(opts ...grpc.DialOption) credentials.StaticCredentialsOption {
 	return credentials.WithGrpcDialOptions(opts...)
 }
+
+// WithClockSkew option defines allowance of skew between local clock and clock of YDB server
+// for scheduling of static credentials token refresh
+func WithClockSkew(skew time.Duration) credentials.StaticCredentialsOption {
+	return credentials.WithClockSkew(skew)
+}
+
+// WithClock option defines source of current time for scheduling of static credentials token refresh
+func WithClock(clock clockwork.Clock) credentials.StaticCredentialsOption {
+	return credentials.WithClock(clock)
+}
@@ -0,0 +1,84 @@
+package ydb //nolint:testpackage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/scheme"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+type describePathStub struct {
+	scheme.Client
+
+	err   error
+	paths []string
+}
+
+func (s *describePathStub) DescribePath(ctx context.Context, path string) (scheme.Entry, error) {
+	s.paths = append(s.paths, path)
+	if s.err != nil {
+		return scheme.Entry{}, s.err
+	}
+
+	return scheme.Entry{Name: path, Type: scheme.EntryDatabase}, nil
+}
+
+func TestCheckDatabase(t *testing.T) {
+	const database = "/local/prod"
+	for _, tt := range []struct {
+		name string
+		err  error
+		code Ydb.StatusIds_StatusCode
+	}{
+		{
+			name: "MissingPath",
+			err:  xerrors.Operation(xerrors.WithStatusCode(Ydb.StatusIds_SCHEME_ERROR)),
+			code: Ydb.StatusIds_SCHEME_ERROR,
+		},
+		{
+			name: "PermissionDenied",
+			err:  xerrors.Operation(xerrors.WithStatusCode(Ydb.StatusIds_UNAUTHORIZED)),
+			code: Ydb.StatusIds_UNAUTHORIZED,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var warnings []trace.DriverDatabaseUnavailableInfo
+			driverTrace := &trace.Driver{
+				OnDatabaseUnavailable: func(info trace.DriverDatabaseUnavailableInfo) {
+					warnings = append(warnings, info)
+				},
+			}
+
+			c := &describePathStub{err: tt.err}
+			err := checkDatabase(context.Background(), c, database, false, driverTrace)
+			require.ErrorIs(t, err, ErrDatabaseUnavailable)
+			require.True(t, IsOperationError(err, tt.code))
+			var unavailable *DatabaseUnavailableError
+			require.True(t, errors.As(err, &unavailable))
+			require.Equal(t, database, unavailable.Database)
+			require.ErrorContains(t, err, database)
+			require.Equal(t, []string{database}, c.paths)
+			require.Empty(t, warnings)
+
+			t.Run("AsWarning", func(t *testing.T) {
+				err := checkDatabase(context.Background(), c, database, true, driverTrace)
+				require.NoError(t, err)
+				require.Len(t, warnings, 1)
+				require.Equal(t, database, warnings[0].Database)
+				require.ErrorIs(t, warnings[0].Error, ErrDatabaseUnavailable)
+				require.True(t, IsOperationError(warnings[0].Error, tt.code))
+			})
+		})
+	}
+	t.Run("Available", func(t *testing.T) {
+		c := &describePathStub{}
+		require.NoError(t, checkDatabase(context.Background(), c, database, false, &trace.Driver{}))
+		require.Equal(t, []string{database}, c.paths)
+	})
+}
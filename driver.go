@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"sync"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -20,6 +21,7 @@ import (
 	discoveryConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/discovery/config"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/dsn"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/endpoint"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/inflight"
 	internalRatelimiter "github.com/ydb-platform/ydb-go-sdk/v3/internal/ratelimiter"
 	ratelimiterConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/ratelimiter/config"
 	internalScheme "github.com/ydb-platform/ydb-go-sdk/v3/internal/scheme"
@@ -210,6 +212,49 @@ func (d *Driver) Topic() topic.Client {
 	return d.topic
 }
 
+// Stats provides in-process statistics of driver calls
+type Stats interface {
+	// Quantile returns q-quantile (q in [0, 1]) of call attempts latencies of operation kind
+	//
+	// Latencies are collected in lock-free histograms with fixed exponential buckets
+	// (four buckets per power of two, ~2.5KB of memory per operation kind). Each call
+	// attempt costs single atomic increment. Only attempts with server response are
+	// observed. For streams latency is a duration of stream opening.
+	// Histograms are rotated each minute, so quantile reflects latencies of attempts
+	// of last one or two minutes.
+	// Result is an upper bound of histogram bucket and exceeds exact quantile by not more than 25%.
+	// Quantile returns false if there are no observed attempts of operation kind.
+	Quantile(kind config.OperationKind, q float64) (time.Duration, bool)
+
+	// ActiveStreams returns counts of active streams of connections by addresses of endpoints
+	ActiveStreams() map[string]int
+}
+
+// Stats returns in-process statistics of driver calls
+func (d *Driver) Stats() Stats {
+	return d.balancer
+}
+
+// ActiveOperation is a snapshot of in-flight operation of driver
+type ActiveOperation = inflight.Operation
+
+// ActiveOperations returns snapshot of in-flight operations of driver (unary calls and streams)
+// for debugging of stuck requests
+//
+// Each call attempt is a separate operation, so retried operation appears with new ID.
+func (d *Driver) ActiveOperations() []ActiveOperation {
+	return d.balancer.ActiveOperations()
+}
+
+// CancelOperation cancels internal context of in-flight operation with given ID
+//
+// Caller of cancelled operation receives error which wraps context.Canceled.
+// Cancelled operation is not retried by retryers of driver.
+// CancelOperation returns false if operation is already finished.
+func (d *Driver) CancelOperation(id uint64) bool {
+	return d.balancer.CancelOperation(id)
+}
+
 // Open connects to database by DSN and return driver runtime holder
 //
 // DSN accept Driver string like
@@ -370,6 +415,8 @@ func (d *Driver) connect(ctx context.Context) (err error) {
 				d.userInfo.User, d.userInfo.Password,
 				d.config.Endpoint(),
 				credentials.WithGrpcDialOptions(d.config.GrpcDialOptions()...),
+				credentials.WithClock(d.config.Clock()),
+				credentials.WithClockSkew(d.config.ClockSkew()),
 			),
 		))
 	}
@@ -500,6 +547,33 @@ func (d *Driver) connect(ctx context.Context) (err error) {
 		return xerrors.WithStackTrace(err)
 	}
 
+	err = checkDatabase(ctx, d.scheme, d.Name(), d.config.DatabaseUnavailableAsWarning(), d.trace())
+	if err != nil {
+		_ = d.Close(ctx)
+
+		return xerrors.WithStackTrace(err)
+	}
+
+	return nil
+}
+
+// checkDatabase describes root of database for early detection of misconfigured path of database
+// (otherwise each request fails with SCHEME_ERROR without a hint about root cause)
+func checkDatabase(ctx context.Context, c scheme.Client, database string, asWarning bool, t *trace.Driver) error {
+	if _, err := c.DescribePath(ctx, database); err != nil {
+		err = &DatabaseUnavailableError{
+			Database: database,
+			Err:      err,
+		}
+		if asWarning {
+			trace.DriverOnDatabaseUnavailable(t, database, err)
+
+			return nil
+		}
+
+		return xerrors.WithStackTrace(err)
+	}
+
 	return nil
 }
 
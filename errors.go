@@ -1,6 +1,9 @@
 package ydb
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	grpcCodes "google.golang.org/grpc/codes"
 
@@ -99,3 +102,27 @@ func IsRatelimiterAcquireError(err error) bool {
 func ToRatelimiterAcquireError(err error) ratelimiter.AcquireError {
 	return ratelimiterErrors.ToAcquireError(err)
 }
+
+// ErrDatabaseUnavailable reports that configured database does not exist or is not accessible
+// on driver start. Use errors.As with *DatabaseUnavailableError for details
+var ErrDatabaseUnavailable = errors.New("database unavailable")
+
+// DatabaseUnavailableError is an error of check of configured database on driver start
+type DatabaseUnavailableError struct {
+	// Database is a configured path of database
+	Database string
+	// Err is an underlying error of describing of database (such as SCHEME_ERROR or UNAUTHORIZED)
+	Err error
+}
+
+func (e *DatabaseUnavailableError) Error() string {
+	return fmt.Sprintf("database '%s' is unavailable: %v", e.Database, e.Err)
+}
+
+func (e *DatabaseUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+func (e *DatabaseUnavailableError) Is(target error) bool {
+	return target == ErrDatabaseUnavailable //nolint:errorlint
+}
@@ -15,7 +15,7 @@ import (
 func seriesData(id string, released time.Time, title, info, comment string) types.Value {
 	var commentv types.Value
 	if comment == "" {
-		commentv = types.NullValue(types.TypeUTF8)
+		commentv = types.NullValue(types.TypeText)
 	} else {
 		commentv = types.OptionalValue(types.TextValue(comment))
 	}
@@ -9,7 +9,7 @@ import (
 func seriesData(id uint64, released time.Time, title, info, comment string) types.Value {
 	var commentv types.Value
 	if comment == "" {
-		commentv = types.NullValue(types.TypeUTF8)
+		commentv = types.NullValue(types.TypeText)
 	} else {
 		commentv = types.OptionalValue(types.TextValue(comment))
 	}
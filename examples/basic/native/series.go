@@ -349,10 +349,10 @@ func createTables(ctx context.Context, c table.Client, prefix string) (err error
 		func(ctx context.Context, s table.Session) error {
 			return s.CreateTable(ctx, path.Join(prefix, "series"),
 				options.WithColumn("series_id", types.Optional(types.TypeUint64)),
-				options.WithColumn("title", types.Optional(types.TypeUTF8)),
-				options.WithColumn("series_info", types.Optional(types.TypeUTF8)),
+				options.WithColumn("title", types.Optional(types.TypeText)),
+				options.WithColumn("series_info", types.Optional(types.TypeText)),
 				options.WithColumn("release_date", types.Optional(types.TypeUint64)),
-				options.WithColumn("comment", types.Optional(types.TypeUTF8)),
+				options.WithColumn("comment", types.Optional(types.TypeText)),
 				options.WithPrimaryKeyColumn("series_id"),
 			)
 		},
@@ -366,7 +366,7 @@ func createTables(ctx context.Context, c table.Client, prefix string) (err error
 			return s.CreateTable(ctx, path.Join(prefix, "seasons"),
 				options.WithColumn("series_id", types.Optional(types.TypeUint64)),
 				options.WithColumn("season_id", types.Optional(types.TypeUint64)),
-				options.WithColumn("title", types.Optional(types.TypeUTF8)),
+				options.WithColumn("title", types.Optional(types.TypeText)),
 				options.WithColumn("first_aired", types.Optional(types.TypeUint64)),
 				options.WithColumn("last_aired", types.Optional(types.TypeUint64)),
 				options.WithPrimaryKeyColumn("series_id", "season_id"),
@@ -383,7 +383,7 @@ func createTables(ctx context.Context, c table.Client, prefix string) (err error
 				options.WithColumn("series_id", types.Optional(types.TypeUint64)),
 				options.WithColumn("season_id", types.Optional(types.TypeUint64)),
 				options.WithColumn("episode_id", types.Optional(types.TypeUint64)),
-				options.WithColumn("title", types.Optional(types.TypeUTF8)),
+				options.WithColumn("title", types.Optional(types.TypeText)),
 				options.WithColumn("air_date", types.Optional(types.TypeUint64)),
 				options.WithPrimaryKeyColumn("series_id", "season_id", "episode_id"),
 			)
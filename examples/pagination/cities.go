@@ -121,9 +121,9 @@ func createTable(ctx context.Context, c table.Client, path string) (err error) {
 	err = c.Do(ctx,
 		func(ctx context.Context, s table.Session) error {
 			return s.CreateTable(ctx, path,
-				options.WithColumn("city", types.Optional(types.TypeUTF8)),
+				options.WithColumn("city", types.Optional(types.TypeText)),
 				options.WithColumn("number", types.Optional(types.TypeUint32)),
-				options.WithColumn("address", types.Optional(types.TypeUTF8)),
+				options.WithColumn("address", types.Optional(types.TypeText)),
 				options.WithPrimaryKeyColumn("city", "number"),
 			)
 		},
@@ -41,7 +41,7 @@ func createTable(ctx context.Context, c table.Client, path string) (err error) {
 				options.WithColumn("customer_id", types.Optional(types.TypeUint64)),
 				options.WithColumn("order_id", types.Optional(types.TypeUint64)),
 				options.WithColumn("order_date", types.Optional(types.TypeDate)),
-				options.WithColumn("description", types.Optional(types.TypeUTF8)),
+				options.WithColumn("description", types.Optional(types.TypeText)),
 				options.WithPrimaryKeyColumn("customer_id", "order_id"),
 			)
 		},
@@ -117,7 +117,7 @@ func (s *server) getFreeSeatsTx(ctx context.Context, tx table.TransactionActor,
 DECLARE $id AS Text;
 
 SELECT freeSeats FROM bus WHERE id=$id;
-`, table.NewQueryParameters(table.ValueParam("$id", types.UTF8Value(id))))
+`, table.NewQueryParameters(table.ValueParam("$id", types.TextValue(id))))
 	if err != nil {
 		return 0, err
 	}
@@ -156,7 +156,7 @@ func (s *server) sellTicket(ctx context.Context, id string) (int64, error) {
 DECLARE $id AS Text;
 
 UPDATE bus SET freeSeats = freeSeats - 1 WHERE id=$id;
-`, table.NewQueryParameters(table.ValueParam("$id", types.UTF8Value(id))))
+`, table.NewQueryParameters(table.ValueParam("$id", types.TextValue(id))))
 		return err
 	})
 	if err == nil {
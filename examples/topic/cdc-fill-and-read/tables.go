@@ -37,7 +37,7 @@ func createTable(ctx context.Context, c table.Client, prefix, tableName string)
 		func(ctx context.Context, s table.Session) error {
 			return s.CreateTable(ctx, path.Join(prefix, tableName),
 				options.WithColumn("id", types.Optional(types.TypeUint64)),
-				options.WithColumn("value", types.Optional(types.TypeUTF8)),
+				options.WithColumn("value", types.Optional(types.TypeText)),
 				options.WithPrimaryKeyColumn("id"),
 			)
 		},
@@ -86,7 +86,7 @@ VALUES
 		val := "val-" + strconv.Itoa(rand.Intn(10)) //nolint:gosec
 		params := table.NewQueryParameters(
 			table.ValueParam("$id", types.Uint64Value(id)),
-			table.ValueParam("$value", types.UTF8Value(val)),
+			table.ValueParam("$value", types.TextValue(val)),
 		)
 		_ = c.DoTx(ctx, func(ctx context.Context, tx table.TransactionActor) error {
 			_, err := tx.Execute(ctx, query, params)
@@ -250,8 +250,8 @@ func createTables(ctx context.Context, c table.Client, prefix string) (err error
 		func(ctx context.Context, s table.Session) error {
 			return s.CreateTable(ctx, path.Join(prefix, "documents"),
 				options.WithColumn("doc_id", types.Optional(types.TypeUint64)),
-				options.WithColumn("url", types.Optional(types.TypeUTF8)),
-				options.WithColumn("html", types.Optional(types.TypeUTF8)),
+				options.WithColumn("url", types.Optional(types.TypeText)),
+				options.WithColumn("html", types.Optional(types.TypeText)),
 				options.WithColumn("ts", types.Optional(types.TypeUint64)),
 				options.WithPrimaryKeyColumn("doc_id"),
 				options.WithPartitions(options.WithUniformPartitions(uint64(docTablePartitionCount))),
@@ -70,6 +70,22 @@ func (a *Allocator) TypeOptional() (v *Ydb.Type_OptionalType) {
 	return new(Ydb.Type_OptionalType)
 }
 
+func (a *Allocator) TypeTagged() (v *Ydb.Type_TaggedType) {
+	return new(Ydb.Type_TaggedType)
+}
+
+func (a *Allocator) Tagged() (v *Ydb.TaggedType) {
+	return new(Ydb.TaggedType)
+}
+
+func (a *Allocator) TypePg() (v *Ydb.Type_PgType) {
+	return new(Ydb.Type_PgType)
+}
+
+func (a *Allocator) Pg() (v *Ydb.PgType) {
+	return new(Ydb.PgType)
+}
+
 func (a *Allocator) Bool() (v *Ydb.Value_BoolValue) {
 	return new(Ydb.Value_BoolValue)
 }
@@ -31,6 +31,10 @@ type (
 		structMemberAllocator
 		typeOptionalAllocator
 		optionalAllocator
+		typeTaggedAllocator
+		taggedAllocator
+		typePgAllocator
+		pgAllocator
 		bytesAllocator
 		textAllocator
 		uint32Allocator
@@ -80,6 +84,10 @@ func (a *Allocator) Free() {
 	a.structMemberAllocator.free()
 	a.typeOptionalAllocator.free()
 	a.optionalAllocator.free()
+	a.typeTaggedAllocator.free()
+	a.taggedAllocator.free()
+	a.typePgAllocator.free()
+	a.pgAllocator.free()
 	a.bytesAllocator.free()
 	a.textAllocator.free()
 	a.uint32Allocator.free()
@@ -340,6 +348,42 @@ func (a *optionalAllocator) free() {
 	a.allocations = a.allocations[:0]
 }
 
+type taggedAllocator struct {
+	allocations []*Ydb.TaggedType
+}
+
+func (a *taggedAllocator) Tagged() (v *Ydb.TaggedType) {
+	v = taggedPool.Get()
+	a.allocations = append(a.allocations, v)
+	return v
+}
+
+func (a *taggedAllocator) free() {
+	for _, v := range a.allocations {
+		v.Reset()
+		taggedPool.Put(v)
+	}
+	a.allocations = a.allocations[:0]
+}
+
+type pgAllocator struct {
+	allocations []*Ydb.PgType
+}
+
+func (a *pgAllocator) Pg() (v *Ydb.PgType) {
+	v = pgPool.Get()
+	a.allocations = append(a.allocations, v)
+	return v
+}
+
+func (a *pgAllocator) free() {
+	for _, v := range a.allocations {
+		v.Reset()
+		pgPool.Put(v)
+	}
+	a.allocations = a.allocations[:0]
+}
+
 type pairAllocator struct {
 	allocations []*Ydb.ValuePair
 }
@@ -569,6 +613,42 @@ func (a *typeOptionalAllocator) free() {
 	a.allocations = a.allocations[:0]
 }
 
+type typeTaggedAllocator struct {
+	allocations []*Ydb.Type_TaggedType
+}
+
+func (a *typeTaggedAllocator) TypeTagged() (v *Ydb.Type_TaggedType) {
+	v = typeTaggedPool.Get()
+	a.allocations = append(a.allocations, v)
+	return v
+}
+
+func (a *typeTaggedAllocator) free() {
+	for _, v := range a.allocations {
+		*v = Ydb.Type_TaggedType{}
+		typeTaggedPool.Put(v)
+	}
+	a.allocations = a.allocations[:0]
+}
+
+type typePgAllocator struct {
+	allocations []*Ydb.Type_PgType
+}
+
+func (a *typePgAllocator) TypePg() (v *Ydb.Type_PgType) {
+	v = typePgPool.Get()
+	a.allocations = append(a.allocations, v)
+	return v
+}
+
+func (a *typePgAllocator) free() {
+	for _, v := range a.allocations {
+		*v = Ydb.Type_PgType{}
+		typePgPool.Put(v)
+	}
+	a.allocations = a.allocations[:0]
+}
+
 type typeStructAllocator struct {
 	allocations []*Ydb.Type_StructType
 }
@@ -902,6 +982,10 @@ var (
 	structMemberPool                 Pool[Ydb.StructMember]
 	typeOptionalPool                 Pool[Ydb.Type_OptionalType]
 	optionalPool                     Pool[Ydb.OptionalType]
+	typeTaggedPool                   Pool[Ydb.Type_TaggedType]
+	taggedPool                       Pool[Ydb.TaggedType]
+	typePgPool                       Pool[Ydb.Type_PgType]
+	pgPool                           Pool[Ydb.PgType]
 	typedValuePool                   Pool[Ydb.TypedValue]
 	boolPool                         Pool[Ydb.Value_BoolValue]
 	bytesPool                        Pool[Ydb.Value_BytesValue]
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -15,6 +16,8 @@ import (
 	internalDiscovery "github.com/ydb-platform/ydb-go-sdk/v3/internal/discovery"
 	discoveryConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/discovery/config"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/endpoint"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/inflight"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/latency"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/repeater"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/stack"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xcontext"
@@ -40,10 +43,19 @@ type Balancer struct {
 	discoveryRepeater repeater.Repeater
 	localDCDetector   func(ctx context.Context, endpoints []endpoint.Endpoint) (string, error)
 
-	mu               xsync.RWMutex
+	mu xsync.RWMutex
+	// connectionsState is an immutable snapshot of connections: updates of endpoints build
+	// new snapshot outside of lock and only swap pointer under lock, so getConn never
+	// observes partially updated state and is not blocked by building of snapshot
 	connectionsState *connectionsState
 
 	onApplyDiscoveredEndpoints []func(ctx context.Context, endpoints []endpoint.Info)
+
+	latencies [config.OperationKindStream + 1]latency.Histogram
+
+	operations inflight.Registry
+
+	streams streamsCounter
 }
 
 func (b *Balancer) HasNode(id uint32) bool {
@@ -141,6 +153,10 @@ func endpointsDiff(newestEndpoints []endpoint.Endpoint, previousConns []conn.Con
 		newestMap   = make(map[string]struct{}, len(newestEndpoints))
 		previousMap = make(map[string]struct{}, len(previousConns))
 	)
+	// arguments are sorted as copies: previousConns is a slice of connections state
+	// which may be still used concurrently by getConn
+	newestEndpoints = append(make([]endpoint.Endpoint, 0, len(newestEndpoints)), newestEndpoints...)
+	previousConns = append(make([]conn.Conn, 0, len(previousConns)), previousConns...)
 	sort.Slice(newestEndpoints, func(i, j int) bool {
 		return newestEndpoints[i].Address() < newestEndpoints[j].Address()
 	})
@@ -297,7 +313,17 @@ func (b *Balancer) Invoke(
 	reply interface{},
 	opts ...grpc.CallOption,
 ) error {
-	return b.wrapCall(ctx, func(ctx context.Context, cc conn.Conn) error {
+	kind := unaryOperationKind(method)
+	ctx, cancel := b.withDefaultDeadline(ctx, method, kind)
+	defer cancel()
+
+	return b.wrapCall(ctx, b.getConn, func(ctx context.Context, cc conn.Conn) (err error) {
+		defer b.observeLatency(kind, time.Now(), &err)
+		ctx, call := b.operations.Start(ctx, kind, method, cc.Endpoint().Address())
+		defer func() {
+			err = call.Done(err)
+		}()
+		call.Describe(args)
 		return cc.Invoke(ctx, method, args, reply, opts...)
 	})
 }
@@ -308,19 +334,48 @@ func (b *Balancer) NewStream(
 	method string,
 	opts ...grpc.CallOption,
 ) (_ grpc.ClientStream, err error) {
-	var client grpc.ClientStream
-	err = b.wrapCall(ctx, func(ctx context.Context, cc conn.Conn) error {
+	ctx, cancel := b.withDefaultDeadline(ctx, method, config.OperationKindStream)
+
+	var (
+		client grpc.ClientStream
+		stream = &streamWithCancel{cancel: cancel}
+	)
+	// grpc calls finish callback on every exit path of stream (including streams
+	// which are abandoned by caller and finished by cancellation of context)
+	opts = append(opts[:len(opts):len(opts)], grpc.OnFinish(stream.finish))
+	err = b.wrapCall(ctx, func(ctx context.Context) (conn.Conn, error) {
+		cc, err := b.getStreamConn(ctx)
+		if err == nil {
+			// slot of stream is acquired by getStreamConn and released on finish of stream
+			address := cc.Endpoint().Address()
+			stream.release = func() {
+				b.streams.release(address)
+			}
+		}
+		return cc, err
+	}, func(ctx context.Context, cc conn.Conn) (err error) {
+		defer b.observeLatency(config.OperationKindStream, time.Now(), &err)
+		ctx, stream.call = b.operations.Start(ctx, config.OperationKindStream, method, cc.Endpoint().Address())
 		client, err = cc.NewStream(ctx, desc, method, opts...)
-		return err
+		if err != nil {
+			return stream.call.Done(err)
+		}
+		return nil
 	})
 	if err == nil {
-		return client, nil
+		stream.ClientStream = client
+		return stream, nil
 	}
+	stream.finish(err)
 	return nil, err
 }
 
-func (b *Balancer) wrapCall(ctx context.Context, f func(ctx context.Context, cc conn.Conn) error) (err error) {
-	cc, err := b.getConn(ctx)
+func (b *Balancer) wrapCall(
+	ctx context.Context,
+	getConn func(ctx context.Context) (conn.Conn, error),
+	f func(ctx context.Context, cc conn.Conn) error,
+) (err error) {
+	cc, err := getConn(ctx)
 	if err != nil {
 		return xerrors.WithStackTrace(err)
 	}
@@ -330,7 +385,8 @@ func (b *Balancer) wrapCall(ctx context.Context, f func(ctx context.Context, cc
 			if cc.GetState() == conn.Banned {
 				b.pool.Allow(ctx, cc)
 			}
-		} else if xerrors.MustPessimizeEndpoint(err, b.driverConfig.ExcludeGRPCCodesForPessimization()...) {
+		} else if !xerrors.IsCanceledByCaller(ctx, err) &&
+			xerrors.MustPessimizeEndpoint(err, b.driverConfig.ExcludeGRPCCodesForPessimization()...) {
 			b.pool.Ban(ctx, cc, err)
 		}
 	}()
@@ -0,0 +1,113 @@
+package balancer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Table_V1"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/conn"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/endpoint"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/stub"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xatomic"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+func TestBalancerCallerCancellationDoesNotPessimize(t *testing.T) {
+	// nodes of cluster hold all requests until they are canceled by client
+	cluster := stub.New(stub.WithNodes(3))
+	defer cluster.Close()
+	for _, n := range cluster.Nodes() {
+		n.SetDelay(time.Hour)
+	}
+	var bans xatomic.Int64
+	cfg := config.New(
+		config.WithGrpcOptions(cluster.DialOptions()...),
+		config.WithTrace(trace.Driver{
+			OnConnBan: func(trace.DriverConnBanStartInfo) func(trace.DriverConnBanDoneInfo) {
+				bans.Add(1)
+				return nil
+			},
+		}),
+	)
+	ctx := context.Background()
+	b := &Balancer{
+		driverConfig: cfg,
+		config:       *cfg.Balancer(),
+		pool:         conn.NewPool(ctx, cfg),
+	}
+	t.Cleanup(func() {
+		_ = b.pool.Release(ctx)
+	})
+	received := func() (calls int) {
+		for _, n := range cluster.Nodes() {
+			calls += n.Calls(Ydb_Table_V1.TableService_ExecuteDataQuery_FullMethodName)
+		}
+		return calls
+	}
+	endpoints := make([]endpoint.Endpoint, 0, 3)
+	for _, n := range cluster.Nodes() {
+		endpoints = append(endpoints, endpoint.New(n.Address(), endpoint.WithID(n.ID())))
+	}
+	b.applyDiscoveredEndpoints(ctx, endpoints, "")
+	states := func() map[string]conn.State {
+		states := make(map[string]conn.State)
+		for _, c := range b.connections().all {
+			states[c.Endpoint().Address()] = c.GetState()
+		}
+		return states
+	}
+	// connections are dialed before requests, so requests must not change states of connections
+	for _, c := range b.connections().all {
+		_ = c.Ping(ctx)
+	}
+	before := states()
+	for _, state := range before {
+		require.Equal(t, conn.Online, state)
+	}
+
+	call := func(ctx context.Context) error {
+		return b.Invoke(ctx,
+			Ydb_Table_V1.TableService_ExecuteDataQuery_FullMethodName,
+			&Ydb_Table.ExecuteDataQueryRequest{},
+			&Ydb_Table.ExecuteDataQueryResponse{},
+		)
+	}
+
+	const requests = 1000
+	cancelCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				// in-flight requests canceled by caller (such as on restart of application)
+				errs <- call(cancelCtx)
+			} else {
+				// in-flight requests with expired deadline of caller
+				ctx, cancel := context.WithTimeout(ctx, time.Second)
+				defer cancel()
+				errs <- call(ctx)
+			}
+		}(i)
+	}
+	require.Eventually(t, func() bool {
+		return received() == requests
+	}, 10*time.Second, time.Millisecond)
+	cancel()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.Error(t, err)
+	}
+
+	require.Zero(t, bans.Load())
+	require.Equal(t, before, states())
+}
@@ -0,0 +1,160 @@
+package balancer
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/conn"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/endpoint"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xatomic"
+)
+
+func newChurnBalancer(ctx context.Context, tb testing.TB, nodes int) (*Balancer, []endpoint.Endpoint) {
+	tb.Helper()
+	cfg := config.New()
+	b := &Balancer{
+		driverConfig: cfg,
+		config:       *cfg.Balancer(),
+		pool:         conn.NewPool(ctx, cfg),
+	}
+	tb.Cleanup(func() {
+		_ = b.pool.Release(ctx)
+	})
+	endpoints := make([]endpoint.Endpoint, nodes)
+	for i := range endpoints {
+		endpoints[i] = endpoint.New("node"+strconv.Itoa(i)+":2135", endpoint.WithID(uint32(i+1)))
+	}
+	b.applyDiscoveredEndpoints(ctx, endpoints, "")
+	return b, endpoints
+}
+
+// churn applies random non-empty subsets of endpoints to balancer until ctx is done
+func churn(ctx context.Context, b *Balancer, endpoints []endpoint.Endpoint, seed int64) (updates int) {
+	r := rand.New(rand.NewSource(seed)) //nolint:gosec
+	for ; ctx.Err() == nil; updates++ {
+		subset := make([]endpoint.Endpoint, 0, len(endpoints))
+		for _, e := range endpoints {
+			if r.Intn(2) == 0 {
+				subset = append(subset, e)
+			}
+		}
+		if len(subset) == 0 {
+			subset = append(subset, endpoints[r.Intn(len(endpoints))])
+		}
+		b.applyDiscoveredEndpoints(ctx, subset, "")
+	}
+	return updates
+}
+
+func TestBalancerConcurrentGetConnWithUpdates(t *testing.T) {
+	duration := 3 * time.Second
+	if testing.Short() {
+		duration = 300 * time.Millisecond
+	}
+	if runtime.GOMAXPROCS(0) < 4 {
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	b, endpoints := newChurnBalancer(ctx, t, 16)
+
+	var (
+		wg      sync.WaitGroup
+		calls   xatomic.Int64
+		updates int
+		errs    = make(chan error, runtime.GOMAXPROCS(0))
+	)
+	for i := 0; i < runtime.GOMAXPROCS(0); i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed)) //nolint:gosec
+			for ctx.Err() == nil {
+				c, err := b.getConn(ctx)
+				if err != nil {
+					if ctx.Err() == nil {
+						errs <- err
+					}
+					return
+				}
+				if id := c.Endpoint().NodeID(); id < 1 || int(id) > len(endpoints) {
+					errs <- errors.New("unknown node " + strconv.Itoa(int(id)))
+					return
+				}
+				// pessimization and unbanning of connections concurrently with updates
+				if r.Intn(10) == 0 {
+					b.pool.Ban(ctx, c, errors.New("test"))
+				} else if r.Intn(10) == 0 {
+					b.pool.Allow(ctx, c)
+				}
+				_ = b.HasNode(uint32(r.Intn(len(endpoints)) + 1))
+				calls.Add(1)
+			}
+		}(int64(i))
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		updates = churn(ctx, b, endpoints, time.Now().UnixNano())
+	}()
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Positive(t, calls.Load())
+	require.Positive(t, updates)
+	t.Logf("%d calls of getConn with %d concurrent updates of endpoints", calls.Load(), updates)
+}
+
+// BenchmarkBalancerGetConn guards latency of choosing of connection.
+//
+// getConn takes read lock only for copying of pointer to immutable snapshot of connections,
+// so getConn with concurrent updates of endpoints ("Churn") must stay within the same order
+// of latency as getConn with stable endpoints ("Stable"): updates hold write lock only for
+// swapping of snapshots which are built outside of lock.
+func BenchmarkBalancerGetConn(b *testing.B) {
+	ctx := context.Background()
+	for _, churning := range []bool{false, true} {
+		name := "Stable"
+		if churning {
+			name = "Churn"
+		}
+		b.Run(name, func(b *testing.B) {
+			balancer, endpoints := newChurnBalancer(ctx, b, 16)
+			if churning {
+				churnCtx, cancel := context.WithCancel(ctx)
+				done := make(chan struct{})
+				go func() {
+					defer close(done)
+					churn(churnCtx, balancer, endpoints, 0)
+				}()
+				defer func() {
+					cancel()
+					<-done
+				}()
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := balancer.getConn(ctx); err != nil {
+						b.Error(err)
+					}
+				}
+			})
+		})
+	}
+}
@@ -14,6 +14,9 @@ type Config struct {
 	AllowFallback bool
 	SingleConn    bool
 	DetectLocalDC bool
+	// MaxStreamsPerConn is a maximum count of active streams of connection (0 means no limit).
+	// New streams over the limit are routed to other connections with free stream budget
+	MaxStreamsPerConn int
 }
 
 func (c Config) String() string {
@@ -32,6 +35,11 @@ func (c Config) String() string {
 	buffer.WriteString(",AllowFallback=")
 	fmt.Fprintf(buffer, "%t", c.AllowFallback)
 
+	if c.MaxStreamsPerConn > 0 {
+		buffer.WriteString(",MaxStreamsPerConn=")
+		fmt.Fprintf(buffer, "%d", c.MaxStreamsPerConn)
+	}
+
 	if c.Filter != nil {
 		buffer.WriteString(",Filter=")
 		fmt.Fprint(buffer, c.Filter.String())
@@ -8,6 +8,9 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xrand"
 )
 
+// connectionsState is a snapshot of connections of balancer.
+// connectionsState is not modified after construction and is safe for concurrent use
+// (states of connections are changed atomically by pool)
 type connectionsState struct {
 	connByNodeID map[uint32]conn.Conn
 
@@ -0,0 +1,118 @@
+package balancer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Coordination_V1"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_RateLimiter_V1"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Scheme_V1"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Scripting_V1"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Table_V1"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Topic_V1"
+	"google.golang.org/grpc"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/inflight"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xcontext"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+var unaryOperationKinds = map[string]config.OperationKind{
+	Ydb_Table_V1.TableService_CreateTable_FullMethodName:                config.OperationKindDDL,
+	Ydb_Table_V1.TableService_DropTable_FullMethodName:                  config.OperationKindDDL,
+	Ydb_Table_V1.TableService_AlterTable_FullMethodName:                 config.OperationKindDDL,
+	Ydb_Table_V1.TableService_CopyTable_FullMethodName:                  config.OperationKindDDL,
+	Ydb_Table_V1.TableService_CopyTables_FullMethodName:                 config.OperationKindDDL,
+	Ydb_Table_V1.TableService_RenameTables_FullMethodName:               config.OperationKindDDL,
+	Ydb_Table_V1.TableService_ExecuteSchemeQuery_FullMethodName:         config.OperationKindDDL,
+	Ydb_Scheme_V1.SchemeService_MakeDirectory_FullMethodName:            config.OperationKindDDL,
+	Ydb_Scheme_V1.SchemeService_RemoveDirectory_FullMethodName:          config.OperationKindDDL,
+	Ydb_Scheme_V1.SchemeService_ModifyPermissions_FullMethodName:        config.OperationKindDDL,
+	Ydb_Topic_V1.TopicService_CreateTopic_FullMethodName:                config.OperationKindDDL,
+	Ydb_Topic_V1.TopicService_AlterTopic_FullMethodName:                 config.OperationKindDDL,
+	Ydb_Topic_V1.TopicService_DropTopic_FullMethodName:                  config.OperationKindDDL,
+	Ydb_Coordination_V1.CoordinationService_CreateNode_FullMethodName:   config.OperationKindDDL,
+	Ydb_Coordination_V1.CoordinationService_AlterNode_FullMethodName:    config.OperationKindDDL,
+	Ydb_Coordination_V1.CoordinationService_DropNode_FullMethodName:     config.OperationKindDDL,
+	Ydb_RateLimiter_V1.RateLimiterService_CreateResource_FullMethodName: config.OperationKindDDL,
+	Ydb_RateLimiter_V1.RateLimiterService_AlterResource_FullMethodName:  config.OperationKindDDL,
+	Ydb_RateLimiter_V1.RateLimiterService_DropResource_FullMethodName:   config.OperationKindDDL,
+
+	Ydb_Table_V1.TableService_ExecuteDataQuery_FullMethodName:    config.OperationKindDataQuery,
+	Ydb_Table_V1.TableService_PrepareDataQuery_FullMethodName:    config.OperationKindDataQuery,
+	Ydb_Table_V1.TableService_ExplainDataQuery_FullMethodName:    config.OperationKindDataQuery,
+	Ydb_Table_V1.TableService_BeginTransaction_FullMethodName:    config.OperationKindDataQuery,
+	Ydb_Table_V1.TableService_CommitTransaction_FullMethodName:   config.OperationKindDataQuery,
+	Ydb_Table_V1.TableService_RollbackTransaction_FullMethodName: config.OperationKindDataQuery,
+	Ydb_Table_V1.TableService_ReadRows_FullMethodName:            config.OperationKindDataQuery,
+	Ydb_Table_V1.TableService_BulkUpsert_FullMethodName:          config.OperationKindDataQuery,
+	Ydb_Scripting_V1.ScriptingService_ExecuteYql_FullMethodName:  config.OperationKindDataQuery,
+	Ydb_Scripting_V1.ScriptingService_ExplainYql_FullMethodName:  config.OperationKindDataQuery,
+}
+
+func unaryOperationKind(method string) config.OperationKind {
+	if kind, has := unaryOperationKinds[method]; has {
+		return kind
+	}
+	return config.OperationKindOther
+}
+
+// withDefaultDeadline applies default timeout for calls of given kind if ctx has no deadline.
+// Returned cancel func must be called after call is done.
+func (b *Balancer) withDefaultDeadline(
+	ctx context.Context, method string, kind config.OperationKind,
+) (context.Context, context.CancelFunc) {
+	if _, has := ctx.Deadline(); has {
+		return ctx, func() {}
+	}
+	timeout, has := b.driverConfig.DefaultTimeout(kind)
+	if !has {
+		return ctx, func() {}
+	}
+	trace.DriverOnDefaultDeadlineApplied(b.driverConfig.Trace(), trace.Method(method), kind.String(), timeout)
+	return xcontext.WithTimeout(ctx, timeout)
+}
+
+// streamWithCancel cancels context of stream, unregisters in-flight operation
+// of stream and releases slot of stream (if any) after stream is finished
+type streamWithCancel struct {
+	grpc.ClientStream
+
+	cancel  context.CancelFunc
+	call    *inflight.Call
+	release func()
+	once    sync.Once
+}
+
+func (s *streamWithCancel) SendMsg(m interface{}) error {
+	if s.call != nil {
+		s.call.Describe(m)
+	}
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *streamWithCancel) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		if s.call != nil {
+			err = s.call.Done(err)
+		}
+		s.finish(err)
+	}
+	return err
+}
+
+// finish releases resources of stream. finish is called by grpc on every exit path
+// of stream and by RecvMsg (for connections which don't pass call options to grpc)
+func (s *streamWithCancel) finish(err error) {
+	s.once.Do(func() {
+		if s.call != nil {
+			_ = s.call.Done(err)
+		}
+		if s.release != nil {
+			s.release()
+		}
+		s.cancel()
+	})
+}
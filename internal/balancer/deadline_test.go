@@ -0,0 +1,172 @@
+package balancer
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Scheme_V1"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Table_V1"
+	"google.golang.org/grpc"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+func TestUnaryOperationKind(t *testing.T) {
+	for _, tt := range []struct {
+		method string
+		kind   config.OperationKind
+	}{
+		{
+			method: Ydb_Table_V1.TableService_CreateTable_FullMethodName,
+			kind:   config.OperationKindDDL,
+		},
+		{
+			method: Ydb_Scheme_V1.SchemeService_MakeDirectory_FullMethodName,
+			kind:   config.OperationKindDDL,
+		},
+		{
+			method: Ydb_Table_V1.TableService_ExecuteDataQuery_FullMethodName,
+			kind:   config.OperationKindDataQuery,
+		},
+		{
+			method: Ydb_Table_V1.TableService_CreateSession_FullMethodName,
+			kind:   config.OperationKindOther,
+		},
+		{
+			method: Ydb_Scheme_V1.SchemeService_DescribePath_FullMethodName,
+			kind:   config.OperationKindOther,
+		},
+	} {
+		t.Run(tt.method, func(t *testing.T) {
+			require.Equal(t, tt.kind, unaryOperationKind(tt.method))
+		})
+	}
+}
+
+func TestWithDefaultDeadline(t *testing.T) {
+	var applied []trace.DriverDefaultDeadlineAppliedInfo
+	b := &Balancer{
+		driverConfig: config.New(
+			config.WithDefaultTimeouts(map[config.OperationKind]time.Duration{
+				config.OperationKindDDL:       30 * time.Second,
+				config.OperationKindDataQuery: 5 * time.Second,
+			}),
+			config.WithTrace(trace.Driver{
+				OnDefaultDeadlineApplied: func(info trace.DriverDefaultDeadlineAppliedInfo) {
+					applied = append(applied, info)
+				},
+			}),
+		),
+	}
+	for _, tt := range []struct {
+		name     string
+		ctx      func() (context.Context, context.CancelFunc)
+		kind     config.OperationKind
+		timeout  time.Duration
+		deadline bool
+		applied  bool
+	}{
+		{
+			name:     "DDL",
+			ctx:      func() (context.Context, context.CancelFunc) { return context.Background(), func() {} },
+			kind:     config.OperationKindDDL,
+			timeout:  30 * time.Second,
+			deadline: true,
+			applied:  true,
+		},
+		{
+			name:     "DataQuery",
+			ctx:      func() (context.Context, context.CancelFunc) { return context.Background(), func() {} },
+			kind:     config.OperationKindDataQuery,
+			timeout:  5 * time.Second,
+			deadline: true,
+			applied:  true,
+		},
+		{
+			name:     "Stream",
+			ctx:      func() (context.Context, context.CancelFunc) { return context.Background(), func() {} },
+			kind:     config.OperationKindStream,
+			deadline: false,
+			applied:  false,
+		},
+		{
+			name:     "Other",
+			ctx:      func() (context.Context, context.CancelFunc) { return context.Background(), func() {} },
+			kind:     config.OperationKindOther,
+			deadline: false,
+			applied:  false,
+		},
+		{
+			name: "ExplicitDeadlineOverridesDefault",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Minute)
+			},
+			kind:     config.OperationKindDataQuery,
+			timeout:  time.Minute,
+			deadline: true,
+			applied:  false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			applied = nil
+			parentCtx, parentCancel := tt.ctx()
+			defer parentCancel()
+			start := time.Now()
+			ctx, cancel := b.withDefaultDeadline(parentCtx, "method", tt.kind)
+			defer cancel()
+			deadline, has := ctx.Deadline()
+			require.Equal(t, tt.deadline, has)
+			if has {
+				require.WithinDuration(t, start.Add(tt.timeout), deadline, time.Second)
+			}
+			if tt.applied {
+				require.Len(t, applied, 1)
+				require.Equal(t, trace.Method("method"), applied[0].Method)
+				require.Equal(t, tt.kind.String(), applied[0].Kind)
+				require.Equal(t, tt.timeout, applied[0].Timeout)
+			} else {
+				require.Empty(t, applied)
+			}
+		})
+	}
+}
+
+type clientStreamStub struct {
+	grpc.ClientStream
+
+	err error
+}
+
+func (s clientStreamStub) RecvMsg(interface{}) error {
+	return s.err
+}
+
+func TestStreamWithCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &streamWithCancel{
+		ClientStream: clientStreamStub{},
+		cancel:       cancel,
+	}
+	require.NoError(t, s.RecvMsg(nil))
+	require.NoError(t, ctx.Err())
+	s.ClientStream = clientStreamStub{err: io.EOF}
+	require.ErrorIs(t, s.RecvMsg(nil), io.EOF)
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestStreamWithCancelFinish(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &streamWithCancel{
+		ClientStream: clientStreamStub{},
+		cancel:       cancel,
+	}
+	// stream is finished by grpc without reading of error by caller (such as dropped stream)
+	s.finish(nil)
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+	s.ClientStream = clientStreamStub{err: io.EOF}
+	require.ErrorIs(t, s.RecvMsg(nil), io.EOF)
+}
@@ -0,0 +1,34 @@
+package balancer
+
+import (
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+// observeLatency adds latency of call attempt to histogram of operation kind.
+// Only attempts with server response (success or operation error) are observed.
+// Attempts failed with transport or context errors are skipped because their
+// latency is not a latency of server response.
+func (b *Balancer) observeLatency(kind config.OperationKind, start time.Time, err *error) {
+	if *err != nil && !xerrors.IsOperationError(*err) {
+		return
+	}
+	if int(kind) < 0 || int(kind) >= len(b.latencies) {
+		kind = config.OperationKindOther
+	}
+	b.latencies[kind].Observe(time.Since(start))
+}
+
+// Quantile returns q-quantile of call attempts latencies of operation kind
+//
+// For streams latency is a duration of stream opening. Result is an upper bound
+// of histogram bucket and exceeds exact quantile by not more than 25%.
+// Quantile returns false if there are no observed attempts of operation kind.
+func (b *Balancer) Quantile(kind config.OperationKind, q float64) (time.Duration, bool) {
+	if int(kind) < 0 || int(kind) >= len(b.latencies) {
+		return 0, false
+	}
+	return b.latencies[kind].Quantile(q)
+}
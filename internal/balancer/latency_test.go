@@ -0,0 +1,49 @@
+package balancer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	grpcCodes "google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+func TestObserveLatency(t *testing.T) {
+	b := &Balancer{}
+	observe := func(kind config.OperationKind, d time.Duration, err error) {
+		b.observeLatency(kind, time.Now().Add(-d), &err)
+	}
+	for i := 0; i < 99; i++ {
+		observe(config.OperationKindDataQuery, 10*time.Millisecond, nil)
+	}
+	observe(config.OperationKindDataQuery, time.Second,
+		xerrors.Operation(xerrors.WithStatusCode(Ydb.StatusIds_OVERLOADED)),
+	)
+	observe(config.OperationKindDataQuery, time.Hour,
+		xerrors.Transport(grpcStatus.Error(grpcCodes.DeadlineExceeded, "")),
+	)
+	observe(config.OperationKindDataQuery, time.Hour, context.Canceled)
+
+	require.EqualValues(t, 100, b.latencies[config.OperationKindDataQuery].Count())
+
+	p50, ok := b.Quantile(config.OperationKindDataQuery, 0.5)
+	require.True(t, ok)
+	require.GreaterOrEqual(t, p50, 10*time.Millisecond)
+	require.Less(t, p50, 13*time.Millisecond)
+
+	p100, ok := b.Quantile(config.OperationKindDataQuery, 1)
+	require.True(t, ok)
+	require.GreaterOrEqual(t, p100, time.Second)
+	require.Less(t, p100, 2*time.Second)
+
+	_, ok = b.Quantile(config.OperationKindDDL, 0.99)
+	require.False(t, ok)
+	_, ok = b.Quantile(config.OperationKind(100), 0.99)
+	require.False(t, ok)
+}
@@ -0,0 +1,17 @@
+package balancer
+
+import (
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/inflight"
+)
+
+// ActiveOperations returns snapshot of in-flight calls of balancer ordered by start
+func (b *Balancer) ActiveOperations() []inflight.Operation {
+	return b.operations.Operations()
+}
+
+// CancelOperation cancels context of in-flight call with given id
+//
+// CancelOperation returns false if call with id is already finished.
+func (b *Balancer) CancelOperation(id uint64) bool {
+	return b.operations.Cancel(id)
+}
@@ -0,0 +1,113 @@
+package balancer
+
+import (
+	"context"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/conn"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xsync"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+// streamsCounter counts active streams of connections by addresses of endpoints
+type streamsCounter struct {
+	mu      xsync.Mutex
+	streams map[string]int
+}
+
+// acquire increments count of active streams of address if count is less than limit
+// (limit <= 0 means no limit) and returns count of active streams before increment
+func (c *streamsCounter) acquire(address string, limit int) (streams int, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	streams = c.streams[address]
+	if limit > 0 && streams >= limit {
+		return streams, false
+	}
+	if c.streams == nil {
+		c.streams = make(map[string]int)
+	}
+	c.streams[address] = streams + 1
+	return streams, true
+}
+
+// acquireLeast increments count of active streams of the least loaded connection from conns
+// which count of active streams is less than limit
+func (c *streamsCounter) acquireLeast(conns []conn.Conn, limit int) conn.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var (
+		least   conn.Conn
+		streams int
+	)
+	for _, cc := range conns {
+		if n := c.streams[cc.Endpoint().Address()]; n < limit && (least == nil || n < streams) {
+			least, streams = cc, n
+		}
+	}
+	if least != nil {
+		if c.streams == nil {
+			c.streams = make(map[string]int)
+		}
+		c.streams[least.Endpoint().Address()] = streams + 1
+	}
+	return least
+}
+
+func (c *streamsCounter) release(address string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.streams[address] <= 1 {
+		delete(c.streams, address)
+	} else {
+		c.streams[address]--
+	}
+}
+
+func (c *streamsCounter) snapshot() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	streams := make(map[string]int, len(c.streams))
+	for address, n := range c.streams {
+		streams[address] = n
+	}
+	return streams
+}
+
+// ActiveStreams returns counts of active streams of connections by addresses of endpoints
+func (b *Balancer) ActiveStreams() map[string]int {
+	return b.streams.snapshot()
+}
+
+// getStreamConn returns connection for new stream with acquired stream slot (caller must
+// release it after stream is finished). If connection chosen by balancer has maximum count
+// of active streams, stream is routed to the least loaded connection with free stream budget
+func (b *Balancer) getStreamConn(ctx context.Context) (conn.Conn, error) {
+	cc, err := b.getConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limit := b.config.MaxStreamsPerConn
+	streams, ok := b.streams.acquire(cc.Endpoint().Address(), limit)
+	if ok {
+		return cc, nil
+	}
+	if e, has := ContextEndpoint(ctx); !has || e.NodeID() != cc.Endpoint().NodeID() {
+		state := b.connections()
+		for _, conns := range [][]conn.Conn{state.prefer, state.fallback} {
+			candidates := make([]conn.Conn, 0, len(conns))
+			for _, c := range conns {
+				if c != cc && isOkConnection(c, false) {
+					candidates = append(candidates, c)
+				}
+			}
+			if c := b.streams.acquireLeast(candidates, limit); c != nil {
+				trace.DriverOnBalancerStreamsOverflow(b.driverConfig.Trace(), cc.Endpoint(), c.Endpoint(), streams, limit)
+				return c, nil
+			}
+		}
+	}
+	// all connections are at the limit (or stream must be opened on preferred endpoint),
+	// so stream is queued on connection chosen by balancer
+	_, _ = b.streams.acquire(cc.Endpoint().Address(), 0)
+	return cc, nil
+}
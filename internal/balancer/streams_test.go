@@ -0,0 +1,184 @@
+package balancer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Table_V1"
+	"google.golang.org/grpc"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/config"
+	balancerConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/balancer/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/conn"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/endpoint"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/mock"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/stub"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+// preferAddress is a filter of balancer which prefers connection with given address
+type preferAddress string
+
+func (f preferAddress) Allow(_ balancerConfig.Info, c conn.Conn) bool {
+	return c.Endpoint().Address() == string(f)
+}
+
+func (f preferAddress) String() string {
+	return "Address{" + string(f) + "}"
+}
+
+// newStubBalancer makes balancer over connections to all nodes of stub cluster
+//
+// Nodes of cluster hold all requests, so streams are active until they are canceled by client.
+func newStubBalancer(
+	t *testing.T,
+	cluster *stub.Cluster,
+	cfg *config.Config,
+	balancerCfg balancerConfig.Config,
+) *Balancer {
+	t.Helper()
+	ctx := context.Background()
+	for _, n := range cluster.Nodes() {
+		n.SetDelay(time.Hour)
+	}
+	b := &Balancer{
+		driverConfig: cfg,
+		config:       balancerCfg,
+		pool:         conn.NewPool(ctx, cfg),
+	}
+	t.Cleanup(func() {
+		_ = b.pool.Release(ctx)
+	})
+	endpoints := make([]endpoint.Endpoint, 0, len(cluster.Nodes()))
+	for _, n := range cluster.Nodes() {
+		endpoints = append(endpoints, endpoint.New(n.Address(), endpoint.WithID(n.ID())))
+	}
+	b.applyDiscoveredEndpoints(ctx, endpoints, "")
+	return b
+}
+
+func TestBalancerStreamsOverflow(t *testing.T) {
+	cluster := stub.New(stub.WithNodes(2))
+	defer cluster.Close()
+	var (
+		a         = cluster.Node(0).Address()
+		b         = cluster.Node(1).Address()
+		overflows []trace.DriverBalancerStreamsOverflowInfo
+	)
+	balancer := newStubBalancer(t, cluster,
+		config.New(
+			config.WithGrpcOptions(cluster.DialOptions()...),
+			config.WithTrace(trace.Driver{
+				OnBalancerStreamsOverflow: func(info trace.DriverBalancerStreamsOverflowInfo) {
+					overflows = append(overflows, info)
+				},
+			}),
+		),
+		balancerConfig.Config{
+			Filter:            preferAddress(a),
+			AllowFallback:     true,
+			MaxStreamsPerConn: 2,
+		},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	openStreams := func(ctx context.Context, count int) {
+		for i := 0; i < count; i++ {
+			_, err := balancer.NewStream(ctx,
+				&grpc.StreamDesc{ServerStreams: true},
+				Ydb_Table_V1.TableService_StreamExecuteScanQuery_FullMethodName,
+			)
+			require.NoError(t, err)
+		}
+	}
+
+	// streams within budget of preferred connection
+	openStreams(ctx, 2)
+	require.Equal(t, map[string]int{a: 2}, balancer.ActiveStreams())
+	require.Empty(t, overflows)
+
+	// streams over budget of preferred connection are routed to another connection
+	openStreams(ctx, 2)
+	require.Equal(t, map[string]int{a: 2, b: 2}, balancer.ActiveStreams())
+	require.Len(t, overflows, 2)
+	for _, overflow := range overflows {
+		require.Equal(t, a, overflow.From.Address())
+		require.Equal(t, b, overflow.To.Address())
+		require.Equal(t, 2, overflow.Streams)
+		require.Equal(t, 2, overflow.Limit)
+	}
+
+	// all connections are at the limit, so stream is queued on connection chosen by balancer
+	openStreams(ctx, 1)
+	require.Equal(t, map[string]int{a: 3, b: 2}, balancer.ActiveStreams())
+	require.Len(t, overflows, 2)
+
+	// stream with preferred endpoint is not routed to another connection
+	streamCtx, streamCancel := context.WithCancel(ctx)
+	openStreams(WithEndpoint(streamCtx, &mock.Endpoint{AddrField: a, NodeIDField: cluster.Node(0).ID()}), 1)
+	require.Equal(t, map[string]int{a: 4, b: 2}, balancer.ActiveStreams())
+	require.Len(t, overflows, 2)
+
+	// finished streams release budget
+	streamCancel()
+	require.Eventually(t, func() bool {
+		return balancer.ActiveStreams()[a] == 3
+	}, time.Second, time.Millisecond)
+	cancel()
+	require.Eventually(t, func() bool {
+		return len(balancer.ActiveStreams()) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestBalancerActiveStreamsWithoutLimit(t *testing.T) {
+	cluster := stub.New()
+	defer cluster.Close()
+	b := newStubBalancer(t, cluster,
+		config.New(config.WithGrpcOptions(cluster.DialOptions()...)),
+		balancerConfig.Config{},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for i := 0; i < 10; i++ {
+		_, err := b.NewStream(ctx,
+			&grpc.StreamDesc{ServerStreams: true},
+			Ydb_Table_V1.TableService_StreamReadTable_FullMethodName,
+		)
+		require.NoError(t, err)
+	}
+	require.Equal(t, map[string]int{cluster.Node(0).Address(): 10}, b.ActiveStreams())
+	cancel()
+	require.Eventually(t, func() bool {
+		return len(b.ActiveStreams()) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestBalancerStreamOperations(t *testing.T) {
+	cluster := stub.New()
+	defer cluster.Close()
+	b := newStubBalancer(t, cluster,
+		config.New(config.WithGrpcOptions(cluster.DialOptions()...)),
+		balancerConfig.Config{},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := b.NewStream(ctx,
+		&grpc.StreamDesc{ServerStreams: true},
+		Ydb_Table_V1.TableService_StreamReadTable_FullMethodName,
+	)
+	require.NoError(t, err)
+	operations := b.ActiveOperations()
+	require.Len(t, operations, 1)
+	require.Equal(t, config.OperationKindStream, operations[0].Kind)
+
+	// stream is abandoned by caller without reading, so operation is unregistered
+	// when grpc finishes stream after cancellation of context
+	cancel()
+	require.Eventually(t, func() bool {
+		return len(b.ActiveOperations()) == 0
+	}, time.Second, time.Millisecond)
+}
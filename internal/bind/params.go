@@ -21,8 +21,10 @@ var (
 	errMultipleQueryParameters = errors.New("only one query arg *table.QueryParameters allowed")
 )
 
+// ToValue converts go value v (such as query arg) to YDB value
+//
 //nolint:gocyclo
-func toValue(v interface{}) (_ types.Value, err error) {
+func ToValue(v interface{}) (_ types.Value, err error) {
 	if valuer, ok := v.(driver.Valuer); ok {
 		v, err = valuer.Value()
 		if err != nil {
@@ -156,7 +158,7 @@ func toYdbParam(name string, value interface{}) (table.ParameterOption, error) {
 	if v, ok := value.(table.ParameterOption); ok {
 		return v, nil
 	}
-	v, err := toValue(value)
+	v, err := ToValue(value)
 	if err != nil {
 		return nil, xerrors.WithStackTrace(err)
 	}
@@ -326,7 +326,7 @@ func TestToValue(t *testing.T) {
 		},
 	} {
 		t.Run(fmt.Sprintf("%T(%v)", tt.src, tt.src), func(t *testing.T) {
-			dst, err := toValue(tt.src)
+			dst, err := ToValue(tt.src)
 			if tt.err != nil {
 				require.ErrorIs(t, err, tt.err)
 			} else {
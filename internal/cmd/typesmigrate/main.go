@@ -0,0 +1,95 @@
+// typesmigrate rewrites deprecated identifiers of package table/types (such as types.UTF8Value
+// or types.TypeString) to stable identifiers (such as types.TextValue or types.TypeBytes).
+//
+// Deprecated identifiers still compile and forward to stable identifiers with the same signatures,
+// so rewriting is safe and does not change behavior of code.
+//
+// Usage:
+//
+//	typesmigrate [-w] [-l] [paths...]
+//
+// Paths are Go files or directories (walked recursively, vendor and testdata directories are skipped).
+// Without -w rewritten sources are printed to stdout.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		write bool
+		list  bool
+	)
+	flag.BoolVar(&write, "w", false, "write result to source files instead of stdout")
+	flag.BoolVar(&list, "l", false, "list files which are rewritten")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(),
+			"Usage:\n%s [-w] [-l] [paths...]\n\nOptions:\n", os.Args[0],
+		)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("[typesmigrate] ")
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != root && (d.Name() == "vendor" || d.Name() == "testdata" || strings.HasPrefix(d.Name(), ".")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+			return rewriteFile(path, write, list)
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func rewriteFile(path string, write, list bool) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	dst, changed, err := Rewrite(path, src)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+	if list {
+		fmt.Println(path)
+	}
+	if write {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, dst, info.Mode().Perm())
+	}
+	if !list {
+		_, err = os.Stdout.Write(dst)
+	}
+	return err
+}
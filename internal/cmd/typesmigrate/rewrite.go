@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strconv"
+)
+
+// typesPackage is an import path of stable public package of types and values constructors
+const typesPackage = "github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+// renames maps deprecated identifiers of package types to stable identifiers.
+// Deprecated identifiers forward to stable identifiers with the same signatures,
+// so rewriting of identifier does not change behavior of code
+var renames = map[string]string{
+	"TypeString":                    "TypeBytes",
+	"TypeUTF8":                      "TypeText",
+	"StringValue":                   "BytesValue",
+	"StringValueFromString":         "BytesValueFromString",
+	"UTF8Value":                     "TextValue",
+	"IntervalValue":                 "IntervalValueFromMicroseconds",
+	"NullableStringValue":           "NullableBytesValue",
+	"NullableStringValueFromString": "NullableBytesValueFromString",
+	"NullableUTF8Value":             "NullableTextValue",
+	"NullableIntervalValue":         "NullableIntervalValueFromMicroseconds",
+	"DictFields":                    "DictValues",
+}
+
+// Rewrite replaces deprecated identifiers of package types in Go source src with stable identifiers.
+// Rewrite returns formatted source and true if source is changed, or src and false otherwise
+func Rewrite(filename string, src []byte) ([]byte, bool, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, false, err
+	}
+	names := typesImportNames(f)
+	if len(names) == 0 {
+		return src, false, nil
+	}
+	changed := false
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		x, ok := sel.X.(*ast.Ident)
+		// x.Obj is not nil for local declarations which shadow name of imported package
+		if !ok || !names[x.Name] || x.Obj != nil {
+			return true
+		}
+		if name, has := renames[sel.Sel.Name]; has {
+			sel.Sel.Name = name
+			changed = true
+		}
+		return true
+	})
+	if !changed {
+		return src, false, nil
+	}
+	var buf bytes.Buffer
+	if err = format.Node(&buf, fset, f); err != nil {
+		return nil, false, err
+	}
+	return buf.Bytes(), true, nil
+}
+
+// typesImportNames returns local names of imports of package types in file f
+func typesImportNames(f *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || path != typesPackage {
+			continue
+		}
+		switch {
+		case spec.Name == nil:
+			names["types"] = true
+		case spec.Name.Name != "_" && spec.Name.Name != ".":
+			names[spec.Name.Name] = true
+		}
+	}
+	return names
+}
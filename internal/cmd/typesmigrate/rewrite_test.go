@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewrite(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		src     string
+		dst     string
+		changed bool
+	}{
+		{
+			name: "Import",
+			src: `package p
+
+import "github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+var (
+	t = types.Optional(types.TypeUTF8)
+	v = types.NullableUTF8Value(nil)
+	s = types.StringValue([]byte("a"))
+)
+`,
+			dst: `package p
+
+import "github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+var (
+	t = types.Optional(types.TypeText)
+	v = types.NullableTextValue(nil)
+	s = types.BytesValue([]byte("a"))
+)
+`,
+			changed: true,
+		},
+		{
+			name: "NamedImport",
+			src: `package p
+
+import ydbTypes "github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+// v is a types.UTF8Value (comments are not changed)
+var v = ydbTypes.UTF8Value("a")
+`,
+			dst: `package p
+
+import ydbTypes "github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+// v is a types.UTF8Value (comments are not changed)
+var v = ydbTypes.TextValue("a")
+`,
+			changed: true,
+		},
+		{
+			name: "ShadowedImport",
+			src: `package p
+
+import "github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+func f() {
+	types := struct{ UTF8Value string }{}
+	_ = types.UTF8Value
+}
+`,
+		},
+		{
+			name: "OtherPackage",
+			src: `package p
+
+import "example.com/types"
+
+var v = types.UTF8Value("a")
+`,
+		},
+		{
+			name: "StableNames",
+			src: `package p
+
+import "github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+var v = types.TextValue("a")
+`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dst, changed, err := Rewrite("p.go", []byte(tt.src))
+			require.NoError(t, err)
+			require.Equal(t, tt.changed, changed)
+			if tt.changed {
+				require.Equal(t, tt.dst, string(dst))
+			} else {
+				require.Equal(t, tt.src, string(dst))
+			}
+		})
+	}
+}
+
+// TestRenamesResolve checks that each deprecated identifier of mapping still resolves
+// in package types, is marked as deprecated and has the same signature as stable identifier
+func TestRenamesResolve(t *testing.T) {
+	dir := filepath.Join("..", "..", "..", "table", "types")
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	require.NoError(t, err)
+	pkg, has := pkgs["types"]
+	require.True(t, has)
+
+	type decl struct {
+		doc       string
+		signature string
+	}
+	decls := make(map[string]decl)
+	for _, f := range pkg.Files {
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				if d.Recv != nil {
+					continue
+				}
+				var signature bytes.Buffer
+				require.NoError(t, printer.Fprint(&signature, fset, d.Type))
+				decls[d.Name.Name] = decl{doc: d.Doc.Text(), signature: signature.String()}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch spec := spec.(type) {
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							decls[name.Name] = decl{doc: spec.Doc.Text()}
+						}
+					case *ast.TypeSpec:
+						decls[spec.Name.Name] = decl{doc: spec.Doc.Text()}
+					}
+				}
+			}
+		}
+	}
+
+	for old, name := range renames {
+		t.Run(old, func(t *testing.T) {
+			oldDecl, has := decls[old]
+			require.True(t, has, "deprecated identifier '%s' not found", old)
+			newDecl, has := decls[name]
+			require.True(t, has, "stable identifier '%s' not found", name)
+			require.Contains(t, oldDecl.doc, "Deprecated: use "+name+" instead")
+			require.NotContains(t, newDecl.doc, "Deprecated")
+			require.Equal(t, newDecl.signature, oldDecl.signature)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// typesPkg is a package of go types of YDB values which have no native go types (such as decimals)
+const typesPkg = "github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+var (
+	primitiveGoTypes = map[string]goType{
+		"Bool":         {name: "bool"},
+		"Int8":         {name: "int8"},
+		"Uint8":        {name: "uint8"},
+		"Int16":        {name: "int16"},
+		"Uint16":       {name: "uint16"},
+		"Int32":        {name: "int32"},
+		"Uint32":       {name: "uint32"},
+		"Int64":        {name: "int64"},
+		"Uint64":       {name: "uint64"},
+		"Float":        {name: "float32"},
+		"Double":       {name: "float64"},
+		"Date":         {name: "time.Time", pkg: "time"},
+		"Datetime":     {name: "time.Time", pkg: "time"},
+		"Timestamp":    {name: "time.Time", pkg: "time"},
+		"Interval":     {name: "time.Duration", pkg: "time"},
+		"TzDate":       {name: "time.Time", pkg: "time"},
+		"TzDatetime":   {name: "time.Time", pkg: "time"},
+		"TzTimestamp":  {name: "time.Time", pkg: "time"},
+		"String":       {name: "[]byte"},
+		"Utf8":         {name: "string"},
+		"Yson":         {name: "[]byte"},
+		"Json":         {name: "string"},
+		"JsonDocument": {name: "string"},
+		"Uuid":         {name: "[16]byte"},
+		"DyNumber":     {name: "string"},
+	}
+
+	decimalRe = regexp.MustCompile(`^Decimal\((\d+),(\d+)\)$`)
+
+	// commonInitialisms is a set of words which must be upper-cased in Go identifiers
+	commonInitialisms = map[string]bool{
+		"API": true, "DB": true, "DNS": true, "HTTP": true, "HTTPS": true, "ID": true,
+		"IP": true, "JSON": true, "SQL": true, "TTL": true, "UID": true, "URI": true,
+		"URL": true, "UTF8": true, "UUID": true, "XML": true, "YQL": true,
+	}
+)
+
+type goType struct {
+	name string
+	// pkg is a package which must be imported for use type
+	pkg string
+	// tag is an additional option of `ydb` field tag with YDB type which is not defined by Go type
+	// (such as precision and scale of decimal)
+	tag string
+}
+
+func goTypeOf(yql string) (goType, error) {
+	if t, has := primitiveGoTypes[yql]; has {
+		return t, nil
+	}
+	if strings.HasPrefix(yql, "Optional<") && strings.HasSuffix(yql, ">") {
+		t, err := goTypeOf(yql[len("Optional<") : len(yql)-1])
+		if err != nil {
+			return t, err
+		}
+		t.name = "*" + t.name
+		return t, nil
+	}
+	if m := decimalRe.FindStringSubmatch(yql); m != nil {
+		return goType{
+			name: "types.Decimal",
+			pkg:  typesPkg,
+			tag:  "decimal(" + m[1] + "," + m[2] + ")",
+		}, nil
+	}
+	return goType{}, fmt.Errorf("unsupported column type '%s'", yql)
+}
+
+// goName converts table or column name (such as `series_id`) to exported Go identifier (such as `SeriesID`)
+func goName(name string) (string, error) {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, w := range words {
+		if u := strings.ToUpper(w); commonInitialisms[u] {
+			b.WriteString(u)
+			continue
+		}
+		runes := []rune(w)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	s := b.String()
+	if s == "" || !unicode.IsLetter([]rune(s)[0]) {
+		return "", fmt.Errorf("cannot make Go identifier from name '%s'", name)
+	}
+	return s, nil
+}
+
+// Generate writes Go source with structs definitions for given tables to w.
+// Output depends only on package name and tables, so generation is deterministic.
+func Generate(w io.Writer, pkg string, tables []Table) error {
+	var (
+		body    bytes.Buffer
+		imports = make(map[string]struct{})
+		structs = make(map[string]string, len(tables))
+	)
+	for _, t := range tables {
+		name, err := goName(path.Base(t.Path))
+		if err != nil {
+			return fmt.Errorf("table '%s': %w", t.Path, err)
+		}
+		if other, has := structs[name]; has {
+			return fmt.Errorf("tables '%s' and '%s' have same struct name '%s'", other, t.Path, name)
+		}
+		structs[name] = t.Path
+		pk := make(map[string]bool, len(t.PrimaryKey))
+		for _, c := range t.PrimaryKey {
+			pk[c] = true
+		}
+		fields := make(map[string]string, len(t.Columns))
+		fmt.Fprintf(&body, "\n// %s is a row of table `%s`\n", name, t.Path)
+		fmt.Fprintf(&body, "type %s struct {\n", name)
+		for _, c := range t.Columns {
+			field, err := goName(c.Name)
+			if err != nil {
+				return fmt.Errorf("table '%s': %w", t.Path, err)
+			}
+			if other, has := fields[field]; has {
+				return fmt.Errorf("table '%s': columns '%s' and '%s' have same field name '%s'",
+					t.Path, other, c.Name, field,
+				)
+			}
+			fields[field] = c.Name
+			typ, err := goTypeOf(c.Type)
+			if err != nil {
+				return fmt.Errorf("table '%s', column '%s': %w", t.Path, c.Name, err)
+			}
+			if typ.pkg != "" {
+				imports[typ.pkg] = struct{}{}
+			}
+			tag := []string{c.Name}
+			if pk[c.Name] {
+				tag = append(tag, "pk")
+			}
+			if typ.tag != "" {
+				tag = append(tag, typ.tag)
+			}
+			fmt.Fprintf(&body, "\t%s %s `ydb:\"%s\"`\n", field, typ.name, strings.Join(tag, ","))
+		}
+		body.WriteString("}\n")
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by ydbgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n", pkg)
+	if len(imports) > 0 {
+		pkgs := make([]string, 0, len(imports))
+		for p := range imports {
+			pkgs = append(pkgs, p)
+		}
+		// standard packages go first and are separated from other packages
+		sort.Slice(pkgs, func(i, j int) bool {
+			if a, b := isStdPkg(pkgs[i]), isStdPkg(pkgs[j]); a != b {
+				return a
+			}
+			return pkgs[i] < pkgs[j]
+		})
+		src.WriteString("\nimport (\n")
+		for i, p := range pkgs {
+			if i > 0 && isStdPkg(pkgs[i-1]) && !isStdPkg(p) {
+				src.WriteString("\n")
+			}
+			fmt.Fprintf(&src, "\t%q\n", p)
+		}
+		src.WriteString(")\n")
+	}
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return fmt.Errorf("format generated source failed: %w", err)
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+func isStdPkg(pkg string) bool {
+	return !strings.Contains(strings.SplitN(pkg, "/", 2)[0], ".")
+}
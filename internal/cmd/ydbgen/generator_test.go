@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/scanner"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result/named"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func loadFixtureSchema(t *testing.T) Schema {
+	t.Helper()
+	s, err := readSchemaFile(filepath.Join("testdata", "schema.json"))
+	require.NoError(t, err)
+	return s
+}
+
+func TestGenerateGolden(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		golden string
+		paths  []string
+	}{
+		{
+			name:   "AllTables",
+			golden: "all.go.golden",
+		},
+		{
+			name:   "SelectedTables",
+			golden: "selected.go.golden",
+			paths:  []string{"/local/series", "/local/audit/raw_events"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tables, err := loadFixtureSchema(t).Select(tt.paths...)
+			require.NoError(t, err)
+			var buf bytes.Buffer
+			require.NoError(t, Generate(&buf, "models", tables))
+			golden := filepath.Join("testdata", tt.golden)
+			if *update {
+				require.NoError(t, os.WriteFile(golden, buf.Bytes(), 0o600))
+			}
+			exp, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(exp), buf.String())
+		})
+	}
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	s := loadFixtureSchema(t)
+	tables, err := s.Select()
+	require.NoError(t, err)
+	var exp bytes.Buffer
+	require.NoError(t, Generate(&exp, "models", tables))
+	r := rand.New(rand.NewSource(0)) //nolint:gosec
+	for i := 0; i < 10; i++ {
+		r.Shuffle(len(s.Tables), func(i, j int) {
+			s.Tables[i], s.Tables[j] = s.Tables[j], s.Tables[i]
+		})
+		tables, err = s.Select()
+		require.NoError(t, err)
+		var act bytes.Buffer
+		require.NoError(t, Generate(&act, "models", tables))
+		require.Equal(t, exp.String(), act.String())
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		tables []Table
+	}{
+		{
+			name: "UnsupportedType",
+			tables: []Table{{
+				Path:    "/local/t",
+				Columns: []Column{{Name: "l", Type: "List<Int32>"}},
+			}},
+		},
+		{
+			name: "SameFieldName",
+			tables: []Table{{
+				Path:    "/local/t",
+				Columns: []Column{{Name: "a_b", Type: "Int32"}, {Name: "a-b", Type: "Int32"}},
+			}},
+		},
+		{
+			name: "SameStructName",
+			tables: []Table{
+				{Path: "/local/a/t", Columns: []Column{{Name: "id", Type: "Int32"}}},
+				{Path: "/local/b/t", Columns: []Column{{Name: "id", Type: "Int32"}}},
+			},
+		},
+		{
+			name: "InvalidIdentifier",
+			tables: []Table{{
+				Path:    "/local/t",
+				Columns: []Column{{Name: "1st", Type: "Int32"}},
+			}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, Generate(&bytes.Buffer{}, "models", tt.tables))
+		})
+	}
+}
+
+func TestSelectUnknownTable(t *testing.T) {
+	_, err := loadFixtureSchema(t).Select("/local/unknown")
+	require.Error(t, err)
+}
+
+func TestGoTypeOf(t *testing.T) {
+	for _, tt := range []struct {
+		yql string
+		exp goType
+	}{
+		{yql: "Uint64", exp: goType{name: "uint64"}},
+		{yql: "Optional<Utf8>", exp: goType{name: "*string"}},
+		{yql: "Timestamp", exp: goType{name: "time.Time", pkg: "time"}},
+		{yql: "Optional<Optional<Bool>>", exp: goType{name: "**bool"}},
+		{yql: "Decimal(22,9)", exp: goType{name: "types.Decimal", pkg: typesPkg, tag: "decimal(22,9)"}},
+		{yql: "Optional<Decimal(35,0)>", exp: goType{name: "*types.Decimal", pkg: typesPkg, tag: "decimal(35,0)"}},
+	} {
+		t.Run(tt.yql, func(t *testing.T) {
+			act, err := goTypeOf(tt.yql)
+			require.NoError(t, err)
+			require.Equal(t, tt.exp, act)
+		})
+	}
+}
+
+// episodes is a copy of struct Episodes from testdata/all.go.golden
+type episodes struct {
+	SeriesID  uint64         `ydb:"series_id,pk"`
+	SeasonID  uint64         `ydb:"season_id,pk"`
+	EpisodeID uint64         `ydb:"episode_id,pk"`
+	Title     string         `ydb:"title"`
+	AirDate   time.Time      `ydb:"air_date"`
+	Duration  *time.Duration `ydb:"duration"`
+	Rating    *float64       `ydb:"rating"`
+	Budget    types.Decimal  `ydb:"budget,decimal(22,9)"`
+	Revenue   *types.Decimal `ydb:"revenue,decimal(35,0)"`
+}
+
+func TestGeneratedStructRoundTrip(t *testing.T) {
+	a := allocator.New()
+	defer a.Free()
+
+	revenue := types.Decimal{Bytes: decimal.BigIntToByte(big.NewInt(123456789), 35, 0), Precision: 35}
+	src := episodes{
+		SeriesID:  1,
+		SeasonID:  2,
+		EpisodeID: 3,
+		Title:     "Pilot",
+		AirDate:   time.Date(2006, time.February, 3, 0, 0, 0, 0, time.UTC),
+		Budget:    types.Decimal{Bytes: decimal.BigIntToByte(big.NewInt(1500000000), 22, 9), Precision: 22, Scale: 9},
+		Revenue:   &revenue,
+	}
+	v, err := types.ValueFromGo(src)
+	require.NoError(t, err)
+
+	tv := value.ToYDB(v, a)
+	set := &Ydb.ResultSet{
+		Rows: []*Ydb.Value{tv.GetValue()},
+	}
+	for _, m := range tv.GetType().GetStructType().GetMembers() {
+		set.Columns = append(set.Columns, &Ydb.Column{Name: m.GetName(), Type: m.GetType()})
+	}
+
+	res := scanner.NewUnary([]*Ydb.ResultSet{set}, nil)
+	require.NoError(t, res.NextResultSetErr(context.Background()))
+	require.True(t, res.NextRow())
+	var dst episodes
+	rv := reflect.ValueOf(&dst).Elem()
+	fields := value.GoStructFields(rv.Type())
+	values := make([]named.Value, 0, len(fields))
+	for _, f := range fields {
+		field := rv.Field(f.Index)
+		if field.Kind() == reflect.Ptr {
+			values = append(values, named.Optional(f.Name, field.Addr().Interface()))
+		} else {
+			values = append(values, named.OptionalWithDefault(f.Name, field.Addr().Interface()))
+		}
+	}
+	require.NoError(t, res.ScanNamed(values...))
+	require.NoError(t, res.Err())
+	// dates are scanned in local timezone
+	require.True(t, src.AirDate.Equal(dst.AirDate))
+	dst.AirDate = src.AirDate
+	require.Equal(t, src, dst)
+}
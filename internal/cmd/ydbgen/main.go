@@ -0,0 +1,124 @@
+// ydbgen generates Go structs definitions from existing tables.
+//
+// Tables descriptions are taken from database (with -ydb connection string) or from schema dump file (with -schema).
+// Each table produces struct with fields of column types (Optional<T> as pointer to T, Decimal as types.Decimal,
+// Timestamp as time.Time, etc.) and `ydb` field tags with column names, primary key markers and precision
+// and scale of decimal columns (such as `ydb:"price,decimal(22,9)"`). Generated structs are converted to
+// query parameters with types.ValueFromGo.
+// Methods for scanning of rows and for making of query parameters are not generated.
+//
+// Usage:
+//
+//	ydbgen -ydb grpc://localhost:2136/local -package models -output models.go series seasons episodes
+//	ydbgen -schema schema.json -package models -output models.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+)
+
+func main() {
+	var (
+		dsn        string
+		schemaPath string
+		pkg        string
+		output     string
+	)
+	flag.StringVar(&dsn, "ydb", "", "YDB connection string for describe tables")
+	flag.StringVar(&schemaPath, "schema", "", "path to schema dump file (json)")
+	flag.StringVar(&pkg, "package", "models", "package name of generated file")
+	flag.StringVar(&output, "output", "", "path to output file (stdout if empty)")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(),
+			"Usage:\n%s (-ydb <dsn> | -schema <file>) [options] [table paths...]\n\nOptions:\n", os.Args[0],
+		)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("[ydbgen] ")
+
+	var (
+		schema Schema
+		err    error
+	)
+	switch {
+	case dsn != "" && schemaPath != "":
+		log.Fatal("only one of -ydb or -schema must be defined")
+	case dsn != "":
+		if flag.NArg() == 0 {
+			log.Fatal("table paths required for describe tables from database")
+		}
+		schema, err = describe(dsn, flag.Args()...)
+	case schemaPath != "":
+		schema, err = readSchemaFile(schemaPath)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tables, err := schema.Select(selectPaths(dsn, flag.Args())...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err = Generate(w, pkg, tables); err != nil {
+		log.Fatal(err) //nolint:gocritic
+	}
+}
+
+// selectPaths returns paths for select tables from schema.
+// Described from database schema contains requested tables only
+func selectPaths(dsn string, args []string) []string {
+	if dsn != "" {
+		return nil
+	}
+	return args
+}
+
+func describe(dsn string, paths ...string) (Schema, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var opts []ydb.Option
+	if token, has := os.LookupEnv("YDB_ACCESS_TOKEN_CREDENTIALS"); has {
+		opts = append(opts, ydb.WithAccessTokenCredentials(token))
+	}
+	db, err := ydb.Open(ctx, dsn, opts...)
+	if err != nil {
+		return Schema{}, fmt.Errorf("connect error: %w", err)
+	}
+	defer func() { _ = db.Close(ctx) }()
+
+	return describeTables(ctx, db, paths...)
+}
+
+func readSchemaFile(name string) (Schema, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return Schema{}, err
+	}
+	defer f.Close()
+
+	return readSchema(f)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+
+	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+)
+
+// Schema is a set of tables descriptions.
+// Schema dump file is a json encoding of Schema:
+//
+//	{
+//	  "tables": [
+//	    {
+//	      "path": "/local/series",
+//	      "columns": [
+//	        {"name": "series_id", "type": "Uint64"},
+//	        {"name": "title", "type": "Optional<Utf8>"}
+//	      ],
+//	      "primary_key": ["series_id"]
+//	    }
+//	  ]
+//	}
+type Schema struct {
+	Tables []Table `json:"tables"`
+}
+
+// Table describes table columns in order of table description
+type Table struct {
+	Path       string   `json:"path"`
+	Columns    []Column `json:"columns"`
+	PrimaryKey []string `json:"primary_key"`
+}
+
+// Column contains column name and YQL type of column (such as `Optional<Utf8>` or `Decimal(22,9)`)
+type Column struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func readSchema(r io.Reader) (s Schema, err error) {
+	if err = json.NewDecoder(r).Decode(&s); err != nil {
+		return s, fmt.Errorf("decode schema dump failed: %w", err)
+	}
+	return s, nil
+}
+
+// Select returns tables with given paths sorted by path.
+// All tables of schema returns if paths is empty.
+func (s Schema) Select(paths ...string) ([]Table, error) {
+	tables := make([]Table, 0, len(s.Tables))
+	if len(paths) == 0 {
+		tables = append(tables, s.Tables...)
+	} else {
+		byPath := make(map[string]Table, len(s.Tables))
+		for _, t := range s.Tables {
+			byPath[t.Path] = t
+		}
+		for _, p := range paths {
+			t, has := byPath[p]
+			if !has {
+				return nil, fmt.Errorf("table '%s' not found in schema", p)
+			}
+			tables = append(tables, t)
+		}
+	}
+	sort.SliceStable(tables, func(i, j int) bool {
+		return tables[i].Path < tables[j].Path
+	})
+	return tables, nil
+}
+
+func describeTables(ctx context.Context, db *ydb.Driver, paths ...string) (s Schema, err error) {
+	for _, p := range paths {
+		if !path.IsAbs(p) {
+			p = path.Join(db.Name(), p)
+		}
+		var desc options.Description
+		err = db.Table().Do(ctx, func(ctx context.Context, s table.Session) (err error) {
+			desc, err = s.DescribeTable(ctx, p)
+			return err
+		}, table.WithIdempotent())
+		if err != nil {
+			return s, fmt.Errorf("describe table '%s' failed: %w", p, err)
+		}
+		t := Table{
+			Path:       p,
+			Columns:    make([]Column, 0, len(desc.Columns)),
+			PrimaryKey: desc.PrimaryKey,
+		}
+		for _, c := range desc.Columns {
+			t.Columns = append(t.Columns, Column{
+				Name: c.Name,
+				Type: c.Type.Yql(),
+			})
+		}
+		s.Tables = append(s.Tables, t)
+	}
+	return s, nil
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"time"
 
+	"github.com/jonboulle/clockwork"
+
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
 
@@ -12,9 +14,27 @@ type Common struct {
 	disableAutoRetry     bool
 	traceRetry           trace.Retry
 
+	clock     clockwork.Clock
+	clockSkew time.Duration
+
 	panicCallback func(e interface{})
 }
 
+// Clock returns source of current time
+// If clock not defined - real clock is used
+func (c *Common) Clock() clockwork.Clock {
+	if c.clock == nil {
+		return clockwork.NewRealClock()
+	}
+	return c.clock
+}
+
+// ClockSkew returns allowance of skew between local clock and clock of YDB server.
+// If ClockSkew is zero then timestamps of query parameters are not validated
+func (c *Common) ClockSkew() time.Duration {
+	return c.clockSkew
+}
+
 // AutoRetry defines auto-retry flag
 func (c *Common) AutoRetry() bool {
 	return !c.disableAutoRetry
@@ -78,6 +98,16 @@ func SetAutoRetry(c *Common, autoRetry bool) {
 	c.disableAutoRetry = !autoRetry
 }
 
+// SetClock defines source of current time
+func SetClock(c *Common, clock clockwork.Clock) {
+	c.clock = clock
+}
+
+// SetClockSkew defines allowance of skew between local clock and clock of YDB server
+func SetClockSkew(c *Common, skew time.Duration) {
+	c.clockSkew = skew
+}
+
 func SetTraceRetry(c *Common, t *trace.Retry, opts ...trace.RetryComposeOption) {
 	c.traceRetry = *c.traceRetry.Compose(t, opts...)
 }
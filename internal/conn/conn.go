@@ -229,6 +229,10 @@ func (c *conn) realConn(ctx context.Context) (cc *grpc.ClientConn, err error) {
 }
 
 func (c *conn) onTransportError(ctx context.Context, cause error) {
+	// requests aborted by caller (such as on shutdown of application) say nothing about connection
+	if xerrors.IsCanceledByCaller(ctx, cause) {
+		return
+	}
 	for _, onTransportError := range c.onTransportErrors {
 		onTransportError(ctx, c, cause)
 	}
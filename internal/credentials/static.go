@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/jonboulle/clockwork"
 	"github.com/ydb-platform/ydb-go-genproto/Ydb_Auth_V1"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Auth"
@@ -23,6 +24,8 @@ var (
 	_ Credentials             = (*Static)(nil)
 	_ fmt.Stringer            = (*Static)(nil)
 	_ StaticCredentialsOption = grpcDialOptionsOption(nil)
+	_ StaticCredentialsOption = clockOption{}
+	_ StaticCredentialsOption = clockSkewOption(0)
 )
 
 type grpcDialOptionsOption []grpc.DialOption
@@ -39,12 +42,39 @@ func WithGrpcDialOptions(opts ...grpc.DialOption) grpcDialOptionsOption {
 	return opts
 }
 
+type clockOption struct {
+	clock clockwork.Clock
+}
+
+func (opt clockOption) ApplyStaticCredentialsOption(c *Static) {
+	c.clock = opt.clock
+}
+
+// WithClock defines source of current time for scheduling of token refresh
+func WithClock(clock clockwork.Clock) clockOption {
+	return clockOption{clock: clock}
+}
+
+type clockSkewOption time.Duration
+
+func (skew clockSkewOption) ApplyStaticCredentialsOption(c *Static) {
+	c.clockSkew = time.Duration(skew)
+}
+
+// WithClockSkew defines allowance of skew between local clock and clock of YDB server.
+// Token which looks like expired (or nearly expired) by skewed local clock is used
+// at least for tenth of skew allowance instead of refreshing token on each call
+func WithClockSkew(skew time.Duration) clockSkewOption {
+	return clockSkewOption(skew)
+}
+
 func NewStaticCredentials(user, password, endpoint string, opts ...StaticCredentialsOption) *Static {
 	c := &Static{
 		user:       user,
 		password:   password,
 		endpoint:   endpoint,
 		sourceInfo: stack.Record(1),
+		clock:      clockwork.NewRealClock(),
 	}
 	for _, opt := range opts {
 		opt.ApplyStaticCredentialsOption(c)
@@ -68,12 +98,14 @@ type Static struct {
 	requestAt  time.Time
 	mu         sync.Mutex
 	sourceInfo string
+	clock      clockwork.Clock
+	clockSkew  time.Duration
 }
 
 func (c *Static) Token(ctx context.Context) (token string, err error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if time.Until(c.requestAt) > 0 {
+	if c.clock.Now().Before(c.requestAt) {
 		return c.token, nil
 	}
 	cc, err := grpc.DialContext(ctx, c.endpoint, c.opts...)
@@ -125,23 +157,47 @@ func (c *Static) Token(ctx context.Context) (token string, err error) {
 		return "", xerrors.WithStackTrace(err)
 	}
 
-	expiresAt, err := parseExpiresAt(result.GetToken())
+	claims, err := parseClaims(result.GetToken())
 	if err != nil {
 		return "", xerrors.WithStackTrace(err)
 	}
 
-	c.requestAt = time.Now().Add(time.Until(expiresAt) / 10)
+	c.requestAt = c.refreshAt(c.clock.Now(), claims)
 	c.token = result.GetToken()
 
 	return c.token, nil
 }
 
-func parseExpiresAt(raw string) (expiresAt time.Time, err error) {
+// refreshAt returns time of next token refresh (after tenth of token lifetime)
+//
+// If token has issued-at claim, lifetime of token computed by server clock only,
+// so it is independent of local clock skew. Otherwise, lifetime computed by local
+// clock and lifetime less than clock skew allowance is replaced with allowance
+func (c *Static) refreshAt(now time.Time, claims *jwt.RegisteredClaims) time.Time {
+	var lifetime time.Duration
+	if claims.IssuedAt != nil {
+		lifetime = claims.ExpiresAt.Sub(claims.IssuedAt.Time)
+	} else {
+		lifetime = claims.ExpiresAt.Sub(now)
+		if lifetime < c.clockSkew {
+			lifetime = c.clockSkew
+		}
+	}
+	if lifetime < 0 {
+		lifetime = 0
+	}
+	return now.Add(lifetime / 10)
+}
+
+func parseClaims(raw string) (*jwt.RegisteredClaims, error) {
 	var claims jwt.RegisteredClaims
-	if _, _, err = jwt.NewParser().ParseUnverified(raw, &claims); err != nil {
-		return expiresAt, xerrors.WithStackTrace(err)
+	if _, _, err := jwt.NewParser().ParseUnverified(raw, &claims); err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	if claims.ExpiresAt == nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("token has no expiration time claim"))
 	}
-	return claims.ExpiresAt.Time, nil
+	return &claims, nil
 }
 
 func (c *Static) String() string {
@@ -0,0 +1,138 @@
+package decimal
+
+import (
+	"math/big"
+)
+
+// MaxPrecision is a maximal precision of YDB decimal types
+const MaxPrecision = 35
+
+// RoundingMode is a mode of rounding of decimal values on decreasing of scale
+type RoundingMode uint8
+
+const (
+	// RoundHalfEven rounds to the nearest value and ties to even value
+	// (as YDB rounds decimals on parsing and casting)
+	RoundHalfEven = RoundingMode(iota)
+
+	// RoundHalfUp rounds to the nearest value and ties away from zero
+	RoundHalfUp
+
+	// RoundDown rounds toward zero (truncates fraction digits)
+	RoundDown
+
+	// RoundUnnecessary requires exact result: rounding with this mode fails
+	// if any non-zero digit of fraction must be dropped
+	RoundUnnecessary
+)
+
+// Neg returns -x.
+// Negation of nan is nan.
+func Neg(x *big.Int) *big.Int {
+	if IsNaN(x) {
+		return NaN()
+	}
+	return big.NewInt(0).Neg(x)
+}
+
+// Add returns x + y with given precision. x and y must have the same scale.
+//
+// As YDB does, Add returns infinity of sign of sum if sum does not fit into precision,
+// nan if x or y is nan, and nan for sum of infinities of different signs.
+func Add(x, y *big.Int, precision uint32) *big.Int {
+	switch {
+	case IsNaN(x) || IsNaN(y):
+		return NaN()
+	case IsInf(x) && IsInf(y) && x.Sign() != y.Sign():
+		return NaN()
+	case IsInf(x):
+		return big.NewInt(0).Set(x)
+	case IsInf(y):
+		return big.NewInt(0).Set(y)
+	}
+	return bound(big.NewInt(0).Add(x, y), precision)
+}
+
+// Sub returns x - y with given precision. x and y must have the same scale.
+//
+// Special values are processed as in Add.
+func Sub(x, y *big.Int, precision uint32) *big.Int {
+	return Add(x, Neg(y), precision)
+}
+
+// Cmp compares x and y with the same scale and returns -1, 0 or +1.
+//
+// Special values are ordered as -inf < finite values < inf < nan.
+func Cmp(x, y *big.Int) int {
+	switch xNaN, yNaN := IsNaN(x), IsNaN(y); {
+	case xNaN && yNaN:
+		return 0
+	case xNaN:
+		return 1
+	case yNaN:
+		return -1
+	}
+	return x.Cmp(y)
+}
+
+// Rescale returns x with scale changed from scale from to scale to with given precision.
+// Digits of fraction are rounded with mode on decreasing of scale.
+//
+// Rescale returns infinity of sign of x if result does not fit into precision
+// and "error" value if mode is RoundUnnecessary and result is not exact.
+// Special values are returned as is.
+func Rescale(x *big.Int, from, to, precision uint32, mode RoundingMode) *big.Int {
+	if IsNaN(x) || IsInf(x) {
+		return big.NewInt(0).Set(x)
+	}
+	if to >= from {
+		v := big.NewInt(0).Mul(x, pow(ten, to-from))
+		return bound(v, precision)
+	}
+	var (
+		d    = pow(ten, from-to)
+		q, r = big.NewInt(0).QuoRem(x, d, big.NewInt(0))
+	)
+	if mode == RoundUnnecessary && r.Sign() != 0 {
+		return Err()
+	}
+	if roundAway(q, r, d, mode) {
+		if x.Sign() < 0 {
+			q.Sub(q, one)
+		} else {
+			q.Add(q, one)
+		}
+	}
+	return bound(q, precision)
+}
+
+// roundAway reports whether truncated quotient q must be rounded away from zero
+// by remainder r of division by d
+func roundAway(q, r, d *big.Int, mode RoundingMode) bool {
+	if r.Sign() == 0 || mode == RoundDown {
+		return false
+	}
+	half := big.NewInt(0).Abs(r)
+	switch half.Lsh(half, 1).Cmp(d) {
+	case 1:
+		return true
+	case -1:
+		return false
+	default:
+		if mode == RoundHalfUp {
+			return true
+		}
+		return q.Bit(0) != 0
+	}
+}
+
+// bound returns x or infinity of sign of x if x does not fit into precision
+func bound(x *big.Int, precision uint32) *big.Int {
+	if x.CmpAbs(pow(ten, precision)) < 0 {
+		return x
+	}
+	if x.Sign() < 0 {
+		return x.Set(neginf)
+	}
+	return x.Set(inf)
+}
@@ -0,0 +1,66 @@
+//go:build go1.18
+// +build go1.18
+
+package decimal
+
+import (
+	"math/big"
+	"testing"
+)
+
+// FuzzAdd checks consistency of Add and Sub with big.Int math
+func FuzzAdd(f *testing.F) {
+	f.Add(int64(1500000000), int64(-1))
+	f.Add(int64(-9223372036854775808), int64(9223372036854775807))
+	f.Fuzz(func(t *testing.T, a, b int64) {
+		const precision = 19
+		var (
+			x   = big.NewInt(a)
+			y   = big.NewInt(b)
+			exp = big.NewInt(0).Add(x, y)
+		)
+		if exp.CmpAbs(pow(ten, precision)) >= 0 {
+			exp = Inf()
+			if x.Sign() < 0 {
+				exp.Neg(exp)
+			}
+		}
+		if sum := Add(x, y, precision); sum.Cmp(exp) != 0 {
+			t.Fatalf("%v + %v = %v, want %v", x, y, sum, exp)
+		}
+		if !IsInf(exp) {
+			if diff := Sub(exp, y, precision); diff.Cmp(x) != 0 {
+				t.Fatalf("%v - %v = %v, want %v", exp, y, diff, x)
+			}
+		}
+	})
+}
+
+// FuzzRescale checks round-trip of Rescale and consistency of rounding
+// half to even with parsing of decimals
+func FuzzRescale(f *testing.F) {
+	f.Add(int64(2125), uint8(3), uint8(2))
+	f.Add(int64(-15), uint8(1), uint8(0))
+	f.Add(int64(123456789), uint8(9), uint8(4))
+	f.Add(int64(0), uint8(0), uint8(0))
+	f.Fuzz(func(t *testing.T, a int64, from, to uint8) {
+		const precision = MaxPrecision
+		from, to = from%20, to%20
+		x := big.NewInt(a)
+		if from > to {
+			from, to = to, from
+		}
+		up := Rescale(x, uint32(from), uint32(to), precision, RoundHalfEven)
+		if back := Rescale(up, uint32(to), uint32(from), precision, RoundDown); back.Cmp(x) != 0 {
+			t.Fatalf("rescaling of %v from %d to %d and back gives %v", x, from, to, back)
+		}
+		down := Rescale(x, uint32(to), uint32(from), precision, RoundHalfEven)
+		parsed, err := Parse(Format(x, precision, uint32(to)), precision, uint32(from))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if down.Cmp(parsed) != 0 {
+			t.Fatalf("rescaling of %v from %d to %d gives %v, parsing gives %v", x, to, from, down, parsed)
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package decimal
+
+import (
+	"math/big"
+	"testing"
+)
+
+func mustParse(t testing.TB, s string, precision, scale uint32) *big.Int {
+	x, err := Parse(s, precision, scale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return x
+}
+
+func TestArith(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		f     func(t *testing.T) *big.Int
+		scale uint32
+		exp   string
+	}{
+		{
+			name: "Add",
+			f: func(t *testing.T) *big.Int {
+				return Add(mustParse(t, "1.5", 22, 9), mustParse(t, "-0.000000001", 22, 9), 22)
+			},
+			scale: 9,
+			exp:   "1.499999999",
+		},
+		{
+			name: "AddOverflow",
+			f: func(t *testing.T) *big.Int {
+				return Add(mustParse(t, "9999999999999.999999999", 22, 9), mustParse(t, "0.000000001", 22, 9), 22)
+			},
+			scale: 9,
+			exp:   "inf",
+		},
+		{
+			name: "SubOverflow",
+			f: func(t *testing.T) *big.Int {
+				return Sub(mustParse(t, "-9999999999999", 22, 9), mustParse(t, "1", 22, 9), 22)
+			},
+			scale: 9,
+			exp:   "-inf",
+		},
+		{
+			name: "AddInf",
+			f: func(t *testing.T) *big.Int {
+				return Add(Inf(), mustParse(t, "-1", 22, 9), 22)
+			},
+			scale: 9,
+			exp:   "inf",
+		},
+		{
+			name: "AddInfinitiesOfDifferentSigns",
+			f: func(t *testing.T) *big.Int {
+				return Add(Inf(), Neg(Inf()), 22)
+			},
+			scale: 9,
+			exp:   "nan",
+		},
+		{
+			name: "SubInfinities",
+			f: func(t *testing.T) *big.Int {
+				return Sub(Inf(), Inf(), 22)
+			},
+			scale: 9,
+			exp:   "nan",
+		},
+		{
+			name: "AddNaN",
+			f: func(t *testing.T) *big.Int {
+				return Add(mustParse(t, "1", 22, 9), NaN(), 22)
+			},
+			scale: 9,
+			exp:   "nan",
+		},
+		{
+			name: "NegNaN",
+			f: func(t *testing.T) *big.Int {
+				return Neg(NaN())
+			},
+			scale: 9,
+			exp:   "nan",
+		},
+		{
+			name: "RescaleHalfEvenDown",
+			f: func(t *testing.T) *big.Int {
+				return Rescale(mustParse(t, "2.125", 22, 9), 9, 2, 22, RoundHalfEven)
+			},
+			scale: 2,
+			exp:   "2.12",
+		},
+		{
+			name: "RescaleHalfEvenUp",
+			f: func(t *testing.T) *big.Int {
+				return Rescale(mustParse(t, "-2.135", 22, 9), 9, 2, 22, RoundHalfEven)
+			},
+			scale: 2,
+			exp:   "-2.14",
+		},
+		{
+			name: "RescaleHalfUp",
+			f: func(t *testing.T) *big.Int {
+				return Rescale(mustParse(t, "-2.125", 22, 9), 9, 2, 22, RoundHalfUp)
+			},
+			scale: 2,
+			exp:   "-2.13",
+		},
+		{
+			name: "RescaleDown",
+			f: func(t *testing.T) *big.Int {
+				return Rescale(mustParse(t, "2.129999999", 22, 9), 9, 2, 22, RoundDown)
+			},
+			scale: 2,
+			exp:   "2.12",
+		},
+		{
+			name: "RescaleRoundingOverflow",
+			f: func(t *testing.T) *big.Int {
+				return Rescale(mustParse(t, "99999999999999999999.5", 22, 1), 1, 0, 20, RoundHalfUp)
+			},
+			scale: 9,
+			exp:   "inf",
+		},
+		{
+			name: "RescaleUpOverflow",
+			f: func(t *testing.T) *big.Int {
+				return Rescale(mustParse(t, "1000", 22, 0), 0, 20, 22, RoundHalfEven)
+			},
+			scale: 9,
+			exp:   "inf",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if s := Format(tt.f(t), MaxPrecision, tt.scale); s != tt.exp {
+				t.Errorf("unexpected result: %s, want %s", s, tt.exp)
+			}
+		})
+	}
+}
+
+func TestCmp(t *testing.T) {
+	ordered := []*big.Int{
+		Neg(Inf()),
+		big.NewInt(-1),
+		big.NewInt(0),
+		big.NewInt(1),
+		Inf(),
+		NaN(),
+	}
+	for i, x := range ordered {
+		for j, y := range ordered {
+			exp := 0
+			switch {
+			case i < j:
+				exp = -1
+			case i > j:
+				exp = 1
+			}
+			if c := Cmp(x, y); c != exp {
+				t.Errorf("Cmp(%v, %v) = %d, want %d", x, y, c, exp)
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package decimal
 import (
 	"math/big"
 	"math/bits"
+	"strings"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xstring"
 )
@@ -83,8 +84,15 @@ func FromInt128(p [16]byte, precision, scale uint32) *big.Int {
 }
 
 // Parse interprets a string s with the given precision and scale and returns
-// the corresponding big integer.
+// the corresponding big integer. Excess digits of fraction are rounded half to even.
 func Parse(s string, precision, scale uint32) (*big.Int, error) {
+	return ParseWithRounding(s, precision, scale, RoundHalfEven)
+}
+
+// ParseWithRounding is like Parse but rounds excess digits of fraction with given mode.
+// With RoundUnnecessary it returns ParseError wrapping ErrInexact if any dropped digit is not zero.
+func ParseWithRounding(s string, precision, scale uint32, mode RoundingMode) (*big.Int, error) {
+	input := s
 	if scale > precision {
 		return nil, precisionError(s, precision, scale)
 	}
@@ -146,25 +154,16 @@ func Parse(s string, precision, scale uint32) (*big.Int, error) {
 		}
 		integral--
 	}
-	//nolint:nestif
 	if len(s) > 0 { // Characters remaining.
-		c := s[0]
-		if !isDigit(c) {
-			return nil, syntaxError(s)
-		}
-		plus := c > '5'
-		if !plus && c == '5' {
-			var x big.Int
-			plus = x.And(v, one).Cmp(zero) != 0 // Last digit is not a zero.
-			for !plus && len(s) > 1 {
-				s = s[1:]
-				c := s[0]
-				if !isDigit(c) {
-					return nil, syntaxError(s)
-				}
-				plus = c != '0'
+		for i := 0; i < len(s); i++ {
+			if !isDigit(s[i]) {
+				return nil, syntaxError(s[i:])
 			}
 		}
+		plus, exact := roundDigits(v, s, mode)
+		if !exact && mode == RoundUnnecessary {
+			return nil, inexactError(input, scale)
+		}
 		if plus {
 			v.Add(v, one)
 			if v.Cmp(pow(ten, precision)) >= 0 {
@@ -179,6 +178,25 @@ func Parse(s string, precision, scale uint32) (*big.Int, error) {
 	return v, nil
 }
 
+// roundDigits reports whether truncated absolute value v must be incremented by dropped
+// digits with given mode and whether all dropped digits are zeros
+func roundDigits(v *big.Int, digits string, mode RoundingMode) (plus, exact bool) {
+	rest := strings.TrimRight(digits[1:], "0")
+	if digits[0] == '0' && rest == "" {
+		return false, true
+	}
+	switch {
+	case mode == RoundDown || mode == RoundUnnecessary:
+		return false, false
+	case digits[0] != '5':
+		return digits[0] > '5', false
+	case rest != "" || mode == RoundHalfUp:
+		return true, false
+	default: // tie
+		return v.Bit(0) != 0, false
+	}
+}
+
 // Format returns the string representation of x with the given precision and
 // scale.
 func Format(x *big.Int, precision, scale uint32) string {
@@ -246,6 +264,11 @@ func Format(x *big.Int, precision, scale uint32) string {
 		pos--
 		bts[pos] = '.'
 	}
+	if pos == len(bts) {
+		// zero with zero scale
+		pos--
+		bts[pos] = '0'
+	}
 	if bts[pos] == '.' {
 		pos--
 		bts[pos] = '0'
@@ -274,6 +297,17 @@ func BigIntToByte(x *big.Int, precision, scale uint32) (p [16]byte) {
 	return p
 }
 
+// BigIntToByteE returns the 16-byte array representation of finite x and returns
+// error wrapping ErrOverflow if x has more digits than precision (instead of
+// saturation to infinity as BigIntToByte does). Special values are encoded with BigIntToByte
+func BigIntToByteE(x *big.Int, precision, scale uint32) (p [16]byte, _ error) {
+	if x.CmpAbs(pow(ten, precision)) >= 0 {
+		return p, overflowError(x, precision)
+	}
+	put(x, p[:])
+	return p, nil
+}
+
 func put(x *big.Int, p []byte) {
 	neg := x.Sign() < 0
 	if neg {
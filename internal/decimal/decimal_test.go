@@ -2,6 +2,10 @@ package decimal
 
 import (
 	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 )
 
@@ -67,3 +71,88 @@ func uint128(hi, lo uint64) []byte {
 func uint128s(lo uint64) []byte {
 	return uint128(0, lo)
 }
+
+func TestParseWithRounding(t *testing.T) {
+	const inexact = "inexact"
+	modes := []RoundingMode{RoundHalfEven, RoundHalfUp, RoundDown, RoundUnnecessary}
+	for _, tt := range []struct {
+		s     string
+		scale uint32
+		exp   [4]string // results with modes in order of modes
+	}{
+		{s: "0.5", scale: 0, exp: [4]string{"0", "1", "0", inexact}},
+		{s: "1.5", scale: 0, exp: [4]string{"2", "2", "1", inexact}},
+		{s: "2.5", scale: 0, exp: [4]string{"2", "3", "2", inexact}},
+		{s: "-2.5", scale: 0, exp: [4]string{"-2", "-3", "-2", inexact}},
+		{s: "-3.5", scale: 0, exp: [4]string{"-4", "-4", "-3", inexact}},
+		{s: "0.25", scale: 1, exp: [4]string{"0.2", "0.3", "0.2", inexact}},
+		{s: "0.35", scale: 1, exp: [4]string{"0.4", "0.4", "0.3", inexact}},
+		{s: "2.5000000001", scale: 0, exp: [4]string{"3", "3", "2", inexact}},
+		{s: "2.4999999999", scale: 0, exp: [4]string{"2", "2", "2", inexact}},
+		{s: "1.0000000005", scale: 9, exp: [4]string{"1.000000000", "1.000000001", "1.000000000", inexact}},
+		{s: "1.0000000015", scale: 9, exp: [4]string{"1.000000002", "1.000000002", "1.000000001", inexact}},
+		{s: "-1.0000000015", scale: 9, exp: [4]string{"-1.000000002", "-1.000000002", "-1.000000001", inexact}},
+		{s: "2.5000", scale: 1, exp: [4]string{"2.5", "2.5", "2.5", "2.5"}},
+		{s: "9.5", scale: 0, exp: [4]string{"inf", "inf", "9", inexact}},
+	} {
+		for i, mode := range modes {
+			x, err := ParseWithRounding(tt.s, 1+tt.scale, tt.scale, mode)
+			if tt.exp[i] == inexact {
+				if !errors.Is(err, ErrInexact) {
+					t.Errorf("ParseWithRounding(%q, mode %d): got error %v; want ErrInexact", tt.s, mode, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("ParseWithRounding(%q, mode %d): unexpected error %v", tt.s, mode, err)
+				continue
+			}
+			if act := Format(x, 1+tt.scale, tt.scale); act != tt.exp[i] {
+				t.Errorf("ParseWithRounding(%q, mode %d) = %s; want %s", tt.s, mode, act, tt.exp[i])
+			}
+		}
+	}
+}
+
+func TestParseWithRoundingSyntaxError(t *testing.T) {
+	for _, mode := range []RoundingMode{RoundHalfEven, RoundHalfUp, RoundDown, RoundUnnecessary} {
+		for _, s := range []string{"1.23x", "1.25 ", "1.2.5"} {
+			if _, err := ParseWithRounding(s, 22, 1, mode); !errors.Is(err, errSyntax) {
+				t.Errorf("ParseWithRounding(%q, mode %d): got error %v; want syntax error", s, mode, err)
+			}
+		}
+	}
+}
+
+func TestBigIntToByteE(t *testing.T) {
+	for _, precision := range []uint32{1, 9, 22, 35} {
+		limit := pow(ten, precision)
+		for _, x := range []*big.Int{
+			big.NewInt(0).Sub(limit, one),
+			big.NewInt(0).Neg(big.NewInt(0).Sub(limit, one)),
+		} {
+			p, err := BigIntToByteE(x, precision, 0)
+			if err != nil {
+				t.Errorf("BigIntToByteE(%v, %d): unexpected error %v", x, precision, err)
+				continue
+			}
+			if y := FromInt128(p, precision, 0); y.Cmp(x) != 0 {
+				t.Errorf("BigIntToByteE(%v, %d) encoded %v", x, precision, y)
+			}
+		}
+		for _, x := range []*big.Int{
+			big.NewInt(0).Set(limit),
+			big.NewInt(0).Neg(limit),
+		} {
+			_, err := BigIntToByteE(x, precision, 0)
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("BigIntToByteE(%v, %d): got error %v; want ErrOverflow", x, precision, err)
+				continue
+			}
+			exp := fmt.Sprintf("%d digits of %v exceed precision %d", precision+1, x, precision)
+			if !strings.Contains(err.Error(), exp) {
+				t.Errorf("BigIntToByteE(%v, %d): error %q does not contain %q", x, precision, err, exp)
+			}
+		}
+	}
+}
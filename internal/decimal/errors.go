@@ -1,12 +1,23 @@
 package decimal
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
 )
 
-var errSyntax = xerrors.Wrap(fmt.Errorf("invalid syntax"))
+var (
+	errSyntax = xerrors.Wrap(fmt.Errorf("invalid syntax"))
+
+	// ErrInexact reports that value cannot be represented with given scale
+	// without rounding (see RoundUnnecessary)
+	ErrInexact = errors.New("inexact decimal value")
+
+	// ErrOverflow reports that value does not fit into precision of decimal type
+	ErrOverflow = errors.New("decimal overflow")
+)
 
 type ParseError struct {
 	Err   error
@@ -36,3 +47,15 @@ func precisionError(s string, precision, scale uint32) *ParseError {
 		Input: s,
 	}
 }
+
+func inexactError(s string, scale uint32) *ParseError {
+	return &ParseError{
+		Err:   fmt.Errorf("%w: more than %d digits of fraction", ErrInexact, scale),
+		Input: s,
+	}
+}
+
+func overflowError(x *big.Int, precision uint32) error {
+	digits := len(new(big.Int).Abs(x).String())
+	return fmt.Errorf("%w: %d digits of %s exceed precision %d", ErrOverflow, digits, x, precision)
+}
@@ -0,0 +1,196 @@
+// Package inflight provides registry of in-flight driver operations
+// for listing and cancelling stuck requests
+package inflight
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xatomic"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+var errCanceled = fmt.Errorf("operation canceled from registry of in-flight operations: %w", context.Canceled)
+
+// Operation is a snapshot of in-flight operation
+type Operation struct {
+	// ID is an identifier of operation for Registry.Cancel
+	ID uint64
+
+	// Kind is a kind of operation (streams are always of OperationKindStream kind)
+	Kind config.OperationKind
+
+	// Method is a full name of grpc method
+	Method string
+
+	// QueryDigest is a hex-encoded FNV-1a hash of query text (or id of prepared query).
+	// Empty if operation has no query or query is not sent yet (for streams).
+	QueryDigest string
+
+	// Endpoint is an address of endpoint which serves operation
+	Endpoint string
+
+	// SessionID is an identifier of session (empty if operation has no session)
+	SessionID string
+
+	// StartedAt is a start time of operation
+	StartedAt time.Time
+
+	// Deadline is a deadline of context of operation (zero if context has no deadline)
+	Deadline time.Time
+}
+
+// Registry is a registry of in-flight operations
+//
+// Zero value of Registry is ready for use. Registering of operation costs one atomic
+// increment, one sync.Map store and one cancellable context. Describing of operation
+// keeps references to session id and query text of first request only, query digest
+// is computed lazily by Operations.
+type Registry struct {
+	lastID     xatomic.Uint64
+	operations sync.Map // map[uint64]*Call
+}
+
+// Call is a registered in-flight operation
+type Call struct {
+	registry  *Registry
+	cancel    context.CancelFunc
+	canceled  xatomic.Bool
+	described xatomic.Bool
+
+	mu sync.Mutex
+	op Operation
+	// query is a text (or id) of query which digest is not computed yet
+	query string
+}
+
+// Start registers new operation and returns context of operation which is cancelled by Cancel
+//
+// Call.Done must be called after operation is finished.
+func (r *Registry) Start(
+	ctx context.Context, kind config.OperationKind, method, endpoint string,
+) (context.Context, *Call) {
+	ctx, cancel := context.WithCancel(ctx)
+	c := &Call{
+		registry: r,
+		cancel:   cancel,
+		op: Operation{
+			ID:        r.lastID.Add(1),
+			Kind:      kind,
+			Method:    method,
+			Endpoint:  endpoint,
+			StartedAt: time.Now(),
+		},
+	}
+	c.op.Deadline, _ = ctx.Deadline()
+	r.operations.Store(c.op.ID, c)
+	return ctx, c
+}
+
+// Operations returns snapshot of in-flight operations ordered by ID
+func (r *Registry) Operations() (operations []Operation) {
+	r.operations.Range(func(_, value interface{}) bool {
+		c := value.(*Call) //nolint:forcetypeassert
+		c.mu.Lock()
+		if c.query != "" {
+			c.op.QueryDigest = Digest(c.query)
+			c.query = ""
+		}
+		operations = append(operations, c.op)
+		c.mu.Unlock()
+		return true
+	})
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].ID < operations[j].ID
+	})
+	return operations
+}
+
+// Cancel cancels context of in-flight operation with given id
+//
+// Cancel returns false if operation is not found (already finished).
+func (r *Registry) Cancel(id uint64) bool {
+	value, has := r.operations.Load(id)
+	if !has {
+		return false
+	}
+	c := value.(*Call) //nolint:forcetypeassert
+	c.canceled.Store(true)
+	c.cancel()
+	return true
+}
+
+// Describe fills session id and query of operation from request message
+//
+// Only first request of operation is described (such as first message of stream),
+// next calls of Describe are no-op.
+func (c *Call) Describe(request interface{}) {
+	if c.described.Swap(true) {
+		return
+	}
+	var sessionID, query string
+	if r, ok := request.(interface{ GetSessionId() string }); ok {
+		sessionID = r.GetSessionId()
+	}
+	switch r := request.(type) {
+	case interface{ GetQuery() *Ydb_Table.Query }:
+		query = r.GetQuery().GetYqlText()
+		if query == "" {
+			query = r.GetQuery().GetId()
+		}
+	case interface{ GetYqlText() string }:
+		query = r.GetYqlText()
+	case interface{ GetScript() string }:
+		query = r.GetScript()
+	}
+	if sessionID == "" && query == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.op.SessionID = sessionID
+	c.query = query
+}
+
+// Done unregisters operation and releases context of operation
+//
+// If operation was cancelled with Registry.Cancel, Done replaces err with
+// non-retryable error which wraps context.Canceled, so retryers does not repeat
+// cancelled operation. Otherwise, Done returns err as is.
+//
+// Done may be called more than once (for example, on every error of stream).
+func (c *Call) Done(err error) error {
+	c.registry.operations.Delete(c.op.ID)
+	c.cancel()
+	if err != nil && c.canceled.Load() {
+		return xerrors.WithStackTrace(fmt.Errorf("%w (id=%d, method=%s, cause: %v)",
+			errCanceled, c.op.ID, c.op.Method, err,
+		))
+	}
+	return err
+}
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Digest returns hex-encoded FNV-1a hash of query text
+//
+// Hash is computed over bytes of string without conversion into []byte
+// (hashes of hash/fnv don't implement io.StringWriter, so io.WriteString copies string).
+func Digest(query string) string {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(query); i++ {
+		h ^= uint64(query[i])
+		h *= fnvPrime64
+	}
+	return strconv.FormatUint(h, 16)
+}
@@ -0,0 +1,132 @@
+package inflight
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Scripting"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	grpcCodes "google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+func TestRegistry(t *testing.T) {
+	var r Registry
+	require.Empty(t, r.Operations())
+
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	_, first := r.Start(ctx, config.OperationKindDataQuery, "ExecuteDataQuery", "127.0.0.1:2135")
+	first.Describe(&Ydb_Table.ExecuteDataQueryRequest{
+		SessionId: "session",
+		Query: &Ydb_Table.Query{
+			Query: &Ydb_Table.Query_YqlText{YqlText: "SELECT 1;"},
+		},
+	})
+	_, second := r.Start(context.Background(), config.OperationKindDataQuery, "ExecuteYql", "127.0.0.1:2136")
+	second.Describe(&Ydb_Scripting.ExecuteYqlRequest{Script: "SELECT 1;"})
+	_, third := r.Start(context.Background(), config.OperationKindStream, "StreamExecuteScanQuery", "127.0.0.1:2136")
+	third.Describe(&Ydb_Table.ExecuteScanQueryRequest{
+		Query: &Ydb_Table.Query{
+			Query: &Ydb_Table.Query_YqlText{YqlText: "SELECT 1;"},
+		},
+	})
+
+	operations := r.Operations()
+	require.Len(t, operations, 3)
+	require.Equal(t, uint64(1), operations[0].ID)
+	require.Equal(t, config.OperationKindDataQuery, operations[0].Kind)
+	require.Equal(t, "ExecuteDataQuery", operations[0].Method)
+	require.Equal(t, "127.0.0.1:2135", operations[0].Endpoint)
+	require.Equal(t, "session", operations[0].SessionID)
+	require.Equal(t, Digest("SELECT 1;"), operations[0].QueryDigest)
+	require.NotEmpty(t, operations[0].QueryDigest)
+	require.True(t, deadline.Equal(operations[0].Deadline))
+	require.False(t, operations[0].StartedAt.IsZero())
+	require.Equal(t, uint64(2), operations[1].ID)
+	require.Empty(t, operations[1].SessionID)
+	require.Equal(t, operations[0].QueryDigest, operations[1].QueryDigest)
+	require.True(t, operations[1].Deadline.IsZero())
+	require.Equal(t, operations[0].QueryDigest, operations[2].QueryDigest)
+
+	require.NoError(t, first.Done(nil))
+	err := errors.New("test")
+	require.Equal(t, err, second.Done(err))
+	require.Len(t, r.Operations(), 1)
+	require.NoError(t, third.Done(nil))
+	require.Empty(t, r.Operations())
+}
+
+func TestRegistryCancel(t *testing.T) {
+	var r Registry
+	ctx, call := r.Start(context.Background(), config.OperationKindDataQuery, "ExecuteDataQuery", "")
+	require.False(t, r.Cancel(call.op.ID+1))
+	require.True(t, r.Cancel(call.op.ID))
+	<-ctx.Done()
+
+	err := call.Done(xerrors.Transport(grpcStatus.Error(grpcCodes.Canceled, "")))
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, err, errCanceled)
+	require.False(t, xerrors.IsTransportError(err))
+	_, errType, _, _ := xerrors.Check(err)
+	require.Equal(t, xerrors.TypeNonRetryable, errType)
+
+	require.False(t, r.Cancel(call.op.ID))
+	require.Empty(t, r.Operations())
+}
+
+func TestCallDescribeOnce(t *testing.T) {
+	var r Registry
+	_, call := r.Start(context.Background(), config.OperationKindStream, "StreamExecuteScanQuery", "")
+	call.Describe(&Ydb_Table.ExecuteScanQueryRequest{
+		Query: &Ydb_Table.Query{
+			Query: &Ydb_Table.Query_YqlText{YqlText: "SELECT 1;"},
+		},
+	})
+	require.Empty(t, call.op.QueryDigest, "digest is computed lazily")
+	call.Describe(&Ydb_Table.ExecuteScanQueryRequest{
+		Query: &Ydb_Table.Query{
+			Query: &Ydb_Table.Query_YqlText{YqlText: "SELECT 2;"},
+		},
+	})
+	operations := r.Operations()
+	require.Len(t, operations, 1)
+	require.Equal(t, Digest("SELECT 1;"), operations[0].QueryDigest)
+	require.Equal(t, operations, r.Operations())
+	require.NoError(t, call.Done(nil))
+}
+
+func TestDigest(t *testing.T) {
+	for _, query := range []string{"", "SELECT 1;", "SELECT * FROM `table` WHERE id = $id;"} {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(query))
+		require.Equal(t, strconv.FormatUint(h.Sum64(), 16), Digest(query))
+	}
+}
+
+func BenchmarkCallDescribe(b *testing.B) {
+	var r Registry
+	request := &Ydb_Table.ExecuteDataQueryRequest{
+		SessionId: "session",
+		Query: &Ydb_Table.Query{
+			Query: &Ydb_Table.Query_YqlText{YqlText: strings.Repeat("SELECT 1;", 1000)},
+		},
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, call := r.Start(context.Background(), config.OperationKindDataQuery, "ExecuteDataQuery", "")
+		call.Describe(request)
+		_ = call.Done(nil)
+	}
+}
@@ -0,0 +1,175 @@
+package latency
+
+import (
+	"math"
+	"math/bits"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xatomic"
+)
+
+const (
+	// subBucketsBits defines count of buckets per each power of two (1 << subBucketsBits)
+	subBucketsBits = 2
+	subBuckets     = 1 << subBucketsBits
+
+	// maxBits defines upper limit of histogram (1 << maxBits microseconds, ~12.7 days)
+	maxBits = 40
+
+	bucketsCount = subBuckets * (maxBits - subBucketsBits + 1)
+
+	// Window is a length of period of histogram counters rotation
+	Window = time.Minute
+)
+
+// Histogram is a lock-free histogram of durations with fixed exponential buckets layout
+//
+// Durations are measured in microseconds. Each power of two is split to 4 buckets,
+// so relative width of bucket is not greater than 25% (durations less than 8µs are counted exactly).
+//
+// Histogram keeps counters of two last periods of Window length and rotates them on
+// start of next period, so Count and Quantile reflect durations observed from Window
+// to 2*Window ago up to now (not whole history). Durations observed concurrently with
+// rotation may be lost.
+// Histogram takes ~2.5KB of memory, Observe costs reading of clock and single atomic increment
+// (and reset of stale counters once per period), Quantile costs one pass over buckets.
+//
+// Zero value of Histogram is ready for use.
+type Histogram struct {
+	// windows holds counters of period p in windows[p%2]
+	windows [2][bucketsCount]xatomic.Uint64
+	// period is a number of last period with observed durations
+	period xatomic.Int64
+
+	clock clockwork.Clock
+}
+
+func bucketIndex(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	us := uint64(d / time.Microsecond)
+	if us < subBuckets {
+		return int(us)
+	}
+	n := bits.Len64(us) - 1 - subBucketsBits
+	idx := subBuckets*(n+1) + int((us>>n)&(subBuckets-1))
+	if idx >= bucketsCount {
+		return bucketsCount - 1
+	}
+	return idx
+}
+
+// bucketBounds returns duration bounds [lower, upper) of bucket with index idx
+func bucketBounds(idx int) (lower, upper time.Duration) {
+	if idx < subBuckets {
+		return time.Duration(idx) * time.Microsecond, time.Duration(idx+1) * time.Microsecond
+	}
+	n := idx/subBuckets - 1
+	sub := uint64(idx % subBuckets)
+	lowerUs := (subBuckets + sub) << n
+	upperUs := lowerUs + 1<<n
+	if idx == bucketsCount-1 {
+		return time.Duration(lowerUs) * time.Microsecond, time.Duration(math.MaxInt64)
+	}
+	return time.Duration(lowerUs) * time.Microsecond, time.Duration(upperUs) * time.Microsecond
+}
+
+func (h *Histogram) currentPeriod() int64 {
+	now := time.Now()
+	if h.clock != nil {
+		now = h.clock.Now()
+	}
+	return now.UnixNano() / int64(Window)
+}
+
+// rotate makes period p current and resets counters of stale periods
+func (h *Histogram) rotate(p int64) {
+	for {
+		last := h.period.Load()
+		if last >= p {
+			return
+		}
+		if h.period.CompareAndSwap(last, p) {
+			h.reset(p)
+			if p-last > 1 {
+				// previous period has no observed durations
+				h.reset(p - 1)
+			}
+			return
+		}
+	}
+}
+
+func (h *Histogram) reset(p int64) {
+	w := &h.windows[p&1]
+	for i := range w {
+		w[i].Store(0)
+	}
+}
+
+// load sums counters of current and previous periods into counts
+func (h *Histogram) load(counts *[bucketsCount]uint64) (total uint64) {
+	var (
+		p    = h.currentPeriod()
+		last = h.period.Load()
+	)
+	for _, q := range [...]int64{p, p - 1} {
+		// windows[q%2] holds counters of period q only if q is last period or previous to it
+		if q != last && q != last-1 {
+			continue
+		}
+		w := &h.windows[q&1]
+		for i := range w {
+			n := w[i].Load()
+			counts[i] += n
+			total += n
+		}
+	}
+	return total
+}
+
+// Observe adds duration to histogram
+func (h *Histogram) Observe(d time.Duration) {
+	p := h.currentPeriod()
+	h.rotate(p)
+	h.windows[p&1][bucketIndex(d)].Add(1)
+}
+
+// Count returns count of durations observed in current and previous periods
+func (h *Histogram) Count() uint64 {
+	var counts [bucketsCount]uint64
+	return h.load(&counts)
+}
+
+// Quantile returns upper bound of bucket which contains q-quantile of durations observed
+// in current and previous periods
+//
+// Returned value is not less than exact quantile and greater than exact quantile by not more
+// than bucket width. Quantile returns false if histogram is empty or q is not in [0, 1].
+func (h *Histogram) Quantile(q float64) (time.Duration, bool) {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return 0, false
+	}
+	var counts [bucketsCount]uint64
+	total := h.load(&counts)
+	if total == 0 {
+		return 0, false
+	}
+	rank := uint64(math.Ceil(q * float64(total)))
+	if rank == 0 {
+		rank = 1
+	}
+	var cumulative uint64
+	for i := range counts {
+		cumulative += counts[i]
+		if cumulative >= rank {
+			_, upper := bucketBounds(i)
+			return upper, true
+		}
+	}
+	_, upper := bucketBounds(bucketsCount - 1)
+	return upper, true
+}
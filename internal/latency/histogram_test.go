@@ -0,0 +1,191 @@
+package latency
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketIndex(t *testing.T) {
+	for _, tt := range []struct {
+		d   time.Duration
+		idx int
+	}{
+		{d: 0, idx: 0},
+		{d: -time.Second, idx: 0},
+		{d: 999 * time.Nanosecond, idx: 0},
+		{d: time.Microsecond, idx: 1},
+		{d: 7 * time.Microsecond, idx: 7},
+		{d: 8 * time.Microsecond, idx: 8},
+		{d: 9 * time.Microsecond, idx: 8},
+		{d: 10 * time.Microsecond, idx: 9},
+		{d: 16 * time.Microsecond, idx: 12},
+		{d: 1 << 50, idx: bucketsCount - 1},
+	} {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			idx := bucketIndex(tt.d)
+			require.Equal(t, tt.idx, idx)
+			lower, upper := bucketBounds(idx)
+			if tt.d >= time.Microsecond && idx < bucketsCount-1 {
+				require.LessOrEqual(t, lower, tt.d)
+				require.Less(t, tt.d, upper)
+			}
+		})
+	}
+}
+
+func TestBucketBoundsContinuous(t *testing.T) {
+	for i := 1; i < bucketsCount; i++ {
+		_, prevUpper := bucketBounds(i - 1)
+		lower, upper := bucketBounds(i)
+		require.Equal(t, prevUpper, lower, i)
+		require.Less(t, lower, upper, i)
+		require.Equal(t, i, bucketIndex(lower), i)
+	}
+}
+
+func TestQuantileEmpty(t *testing.T) {
+	var h Histogram
+	_, ok := h.Quantile(0.99)
+	require.False(t, ok)
+	h.Observe(time.Millisecond)
+	_, ok = h.Quantile(1.5)
+	require.False(t, ok)
+	_, ok = h.Quantile(-0.1)
+	require.False(t, ok)
+}
+
+func TestQuantileAccuracy(t *testing.T) {
+	r := rand.New(rand.NewSource(0)) //nolint:gosec
+	for _, tt := range []struct {
+		name string
+		gen  func() time.Duration
+	}{
+		{
+			name: "Uniform",
+			gen: func() time.Duration {
+				return time.Millisecond + time.Duration(r.Int63n(int64(100*time.Millisecond)))
+			},
+		},
+		{
+			name: "Exponential",
+			gen: func() time.Duration {
+				return time.Duration(r.ExpFloat64() * float64(10*time.Millisecond))
+			},
+		},
+		{
+			name: "Constant",
+			gen: func() time.Duration {
+				return 42 * time.Millisecond
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				h         Histogram
+				durations = make([]time.Duration, 10000)
+			)
+			for i := range durations {
+				durations[i] = tt.gen()
+				h.Observe(durations[i])
+			}
+			require.EqualValues(t, len(durations), h.Count())
+			sort.Slice(durations, func(i, j int) bool {
+				return durations[i] < durations[j]
+			})
+			for _, q := range []float64{0, 0.5, 0.9, 0.99, 0.999, 1} {
+				act, ok := h.Quantile(q)
+				require.True(t, ok)
+				rank := int(q * float64(len(durations)))
+				if rank > 0 && float64(rank) == q*float64(len(durations)) {
+					rank--
+				}
+				exp := durations[rank]
+				lower, upper := bucketBounds(bucketIndex(exp))
+				require.GreaterOrEqual(t, act, exp, q)
+				require.Equal(t, upper, act, q)
+				// bucket width is not greater than 25% of lower bound
+				require.LessOrEqual(t, float64(upper-lower), 0.25*float64(lower)+float64(time.Microsecond), q)
+			}
+		})
+	}
+}
+
+func TestObserveConcurrent(t *testing.T) {
+	var (
+		h  Histogram
+		wg sync.WaitGroup
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				h.Observe(time.Duration(j) * time.Microsecond)
+			}
+		}()
+	}
+	wg.Wait()
+	require.EqualValues(t, 8000, h.Count())
+}
+
+func TestQuantileLatencyShift(t *testing.T) {
+	clock := clockwork.NewFakeClockAt(time.Unix(0, 0))
+	h := Histogram{clock: clock}
+	observe := func(d time.Duration, n int) {
+		for i := 0; i < n; i++ {
+			h.Observe(d)
+		}
+	}
+	quantile := func(q float64) time.Duration {
+		d, ok := h.Quantile(q)
+		require.True(t, ok)
+		return d
+	}
+	_, fastUpper := bucketBounds(bucketIndex(time.Millisecond))
+	_, slowUpper := bucketBounds(bucketIndex(100 * time.Millisecond))
+
+	observe(time.Millisecond, 1000)
+	require.Equal(t, fastUpper, quantile(0.99))
+
+	// latency shift is visible in the next period
+	clock.Advance(Window)
+	observe(100*time.Millisecond, 1000)
+	require.EqualValues(t, 2000, h.Count())
+	require.Equal(t, fastUpper, quantile(0.25))
+	require.Equal(t, slowUpper, quantile(0.99))
+
+	// durations of periods before previous one are forgotten
+	clock.Advance(Window)
+	observe(100*time.Millisecond, 10)
+	require.EqualValues(t, 1010, h.Count())
+	require.Equal(t, slowUpper, quantile(0))
+
+	// histogram without observations for two periods is empty
+	clock.Advance(2 * Window)
+	require.Zero(t, h.Count())
+	_, ok := h.Quantile(0.99)
+	require.False(t, ok)
+
+	// stale counters are reset on next observation
+	observe(time.Millisecond, 1)
+	require.EqualValues(t, 1, h.Count())
+	require.Equal(t, fastUpper, quantile(1))
+}
+
+func BenchmarkObserve(b *testing.B) {
+	var h Histogram
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		d := time.Duration(0)
+		for pb.Next() {
+			d += time.Microsecond
+			h.Observe(d)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+// Package paramscache provides cache of serialized query parameters which is scoped
+// by logical operation (retry loop), so parameters are serialized once for all attempts
+package paramscache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+)
+
+type ctxCacheKey struct{}
+
+type cache struct {
+	mu       sync.Mutex
+	a        *allocator.Allocator
+	released bool
+	params   map[interface{}]map[string]*Ydb.TypedValue
+}
+
+// WithCache returns a copy of parent context with cache of serialized parameters and
+// function which releases cache after logical operation is finished.
+// If parent context already has cache, WithCache returns parent context and no-op release
+// function (nested retry loops share cache of head retry loop)
+func WithCache(ctx context.Context) (_ context.Context, release func()) {
+	if _, has := ctx.Value(ctxCacheKey{}).(*cache); has {
+		return ctx, func() {}
+	}
+	c := &cache{}
+	return context.WithValue(ctx, ctxCacheKey{}, c), c.release
+}
+
+func (c *cache) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.released = true
+	c.params = nil
+	if c.a != nil {
+		c.a.Free()
+		c.a = nil
+	}
+}
+
+// ToYDB returns parameters serialized by toYDB.
+//
+// If ctx has cache, parameters are serialized once per key (with allocator of cache which is
+// freed on release of cache) and serialized parameters are shared between calls with the same key,
+// so parameters must not be changed until logical operation is finished.
+// Otherwise, parameters are serialized with allocator a of caller.
+func ToYDB(
+	ctx context.Context,
+	key interface{},
+	a *allocator.Allocator,
+	toYDB func(a *allocator.Allocator) map[string]*Ydb.TypedValue,
+) map[string]*Ydb.TypedValue {
+	c, has := ctx.Value(ctxCacheKey{}).(*cache)
+	if !has {
+		return toYDB(a)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.released {
+		// context of finished operation is used
+		return toYDB(a)
+	}
+	if params, has := c.params[key]; has {
+		return params
+	}
+	if c.a == nil {
+		c.a = allocator.New()
+		c.params = make(map[interface{}]map[string]*Ydb.TypedValue)
+	}
+	params := toYDB(c.a)
+	c.params[key] = params
+	return params
+}
@@ -0,0 +1,57 @@
+package paramscache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+)
+
+func TestToYDB(t *testing.T) {
+	var serializations int
+	toYDB := func(a *allocator.Allocator) map[string]*Ydb.TypedValue {
+		serializations++
+		tv := a.TypedValue()
+		tv.Value = a.Value()
+		return map[string]*Ydb.TypedValue{"$a": tv}
+	}
+	key := new(int)
+
+	t.Run("WithoutCache", func(t *testing.T) {
+		serializations = 0
+		a := allocator.New()
+		defer a.Free()
+		first := ToYDB(context.Background(), key, a, toYDB)
+		second := ToYDB(context.Background(), key, a, toYDB)
+		require.Equal(t, 2, serializations)
+		require.NotSame(t, first["$a"], second["$a"])
+	})
+	t.Run("WithCache", func(t *testing.T) {
+		serializations = 0
+		ctx, release := WithCache(context.Background())
+		first := ToYDB(ctx, key, nil, toYDB)
+		second := ToYDB(ctx, key, nil, toYDB)
+		require.Equal(t, 1, serializations)
+		require.Same(t, first["$a"], second["$a"])
+		// other parameters of the same operation
+		_ = ToYDB(ctx, new(int), nil, toYDB)
+		require.Equal(t, 2, serializations)
+
+		// nested retry loop shares cache of head retry loop
+		nestedCtx, nestedRelease := WithCache(ctx)
+		require.Same(t, first["$a"], ToYDB(nestedCtx, key, nil, toYDB)["$a"])
+		nestedRelease()
+		require.Same(t, first["$a"], ToYDB(ctx, key, nil, toYDB)["$a"])
+		require.Equal(t, 2, serializations)
+
+		release()
+		// context of finished operation doesn't use released cache
+		a := allocator.New()
+		defer a.Free()
+		_ = ToYDB(ctx, key, a, toYDB)
+		require.Equal(t, 3, serializations)
+	})
+}
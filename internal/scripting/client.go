@@ -3,6 +3,7 @@ package scripting
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/ydb-platform/ydb-go-genproto/Ydb_Scripting_V1"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/operation"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/paramscache"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/scripting/config"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/stack"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/scanner"
@@ -73,7 +75,7 @@ func (c *Client) execute(
 		a       = allocator.New()
 		request = &Ydb_Scripting.ExecuteYqlRequest{
 			Script:     query,
-			Parameters: params.Params().ToYDB(a),
+			Parameters: paramscache.ToYDB(ctx, params, a, params.Params().ToYDB),
 			OperationParams: operation.Params(
 				ctx,
 				c.config.OperationTimeout(),
@@ -177,7 +179,11 @@ func (c *Client) explain(
 		ParameterTypes: make(map[string]types.Type, len(result.GetParametersTypes())),
 	}
 	for k, v := range result.GetParametersTypes() {
-		e.ParameterTypes[k] = value.TypeFromYDB(v)
+		t, err := value.TypeFromYDBWithError(v)
+		if err != nil {
+			return e, xerrors.WithStackTrace(fmt.Errorf("type of parameter %q: %w", k, err))
+		}
+		e.ParameterTypes[k] = t
 	}
 	return e, nil
 }
@@ -218,7 +224,7 @@ func (c *Client) streamExecute(
 		a       = allocator.New()
 		request = &Ydb_Scripting.ExecuteYqlRequest{
 			Script:     query,
-			Parameters: params.Params().ToYDB(a),
+			Parameters: paramscache.ToYDB(ctx, params, a, params.Params().ToYDB),
 			OperationParams: operation.Params(
 				ctx,
 				c.config.OperationTimeout(),
@@ -0,0 +1,130 @@
+// Package stub implements in-process stub of YDB cluster with scriptable fault injection
+//
+// Stub cluster serves discovery and table services over in-memory connections (bufconn).
+// Public wrapper of package is testutil/stub.
+//
+// Stub table service does not store any data: data queries returns empty results.
+package stub
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	defaultDatabase = "/local"
+
+	// firstNodePort is a port of first node address. Addresses of nodes are fake
+	// and used only for routing of in-memory connections.
+	firstNodePort = 21350
+)
+
+type (
+	clusterConfig struct {
+		nodes    int
+		database string
+	}
+	// Option is an option of stub cluster
+	Option func(c *clusterConfig)
+)
+
+// WithNodes defines count of cluster nodes (one node by default)
+func WithNodes(n int) Option {
+	return func(c *clusterConfig) {
+		c.nodes = n
+	}
+}
+
+// WithDatabase defines database name (`/local` by default)
+func WithDatabase(database string) Option {
+	return func(c *clusterConfig) {
+		c.database = database
+	}
+}
+
+// Cluster is an in-process stub of YDB cluster
+type Cluster struct {
+	database string
+	nodes    []*Node
+	byAddr   map[string]*Node
+}
+
+// New starts stub cluster
+//
+// Cluster must be closed with Close after use.
+func New(opts ...Option) *Cluster {
+	cfg := clusterConfig{
+		nodes:    1,
+		database: defaultDatabase,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	if cfg.nodes < 1 {
+		cfg.nodes = 1
+	}
+	c := &Cluster{
+		database: cfg.database,
+		nodes:    make([]*Node, 0, cfg.nodes),
+		byAddr:   make(map[string]*Node, cfg.nodes),
+	}
+	for i := 0; i < cfg.nodes; i++ {
+		n := newNode(c, uint32(i+1), net.JoinHostPort("127.0.0.1", strconv.Itoa(firstNodePort+i)))
+		c.nodes = append(c.nodes, n)
+		c.byAddr[n.address] = n
+	}
+	for _, n := range c.nodes {
+		n.serve()
+	}
+	return c
+}
+
+// Nodes returns all nodes of cluster
+func (c *Cluster) Nodes() []*Node {
+	return append([]*Node(nil), c.nodes...)
+}
+
+// Node returns node with index i (starts from zero)
+func (c *Cluster) Node(i int) *Node {
+	return c.nodes[i]
+}
+
+// Database returns database name
+func (c *Cluster) Database() string {
+	return c.database
+}
+
+// ConnectionString returns connection string for ydb.Open
+//
+// Connection string is usable only with dial options of cluster (see DialOptions).
+func (c *Cluster) ConnectionString() string {
+	return "grpc://" + c.nodes[0].address + c.database
+}
+
+// DialOptions returns grpc dial options which route connections to in-memory nodes of cluster
+func (c *Cluster) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			n, has := c.byAddr[address]
+			if !has {
+				return nil, fmt.Errorf("stub: unknown node address '%s'", address)
+			}
+			return n.listener.DialContext(ctx)
+		}),
+	}
+}
+
+// Close stops all nodes of cluster
+func (c *Cluster) Close() {
+	for _, n := range c.nodes {
+		n.stop()
+	}
+}
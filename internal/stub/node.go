@@ -0,0 +1,263 @@
+package stub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Discovery_V1"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Scheme_V1"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Table_V1"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/testutil"
+)
+
+const bufferSize = 1 << 20
+
+// Node is a node of stub cluster
+//
+// Faults of node are applied to calls which are started after fault injection.
+type Node struct {
+	id       uint32
+	address  string
+	cluster  *Cluster
+	listener *bufconn.Listener
+	server   *grpc.Server
+
+	mu              sync.Mutex
+	overloadedUntil time.Time
+	outageUntil     time.Time
+	dropStreamsTill time.Time
+	delay           time.Duration
+	sessions        map[string]struct{}
+	sessionsCreated int
+	calls           map[string]int
+}
+
+func newNode(c *Cluster, id uint32, address string) *Node {
+	n := &Node{
+		id:       id,
+		address:  address,
+		cluster:  c,
+		listener: bufconn.Listen(bufferSize),
+		sessions: make(map[string]struct{}),
+		calls:    make(map[string]int),
+	}
+	n.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(n.unaryInterceptor),
+		grpc.ChainStreamInterceptor(n.streamInterceptor),
+	)
+	Ydb_Discovery_V1.RegisterDiscoveryServiceServer(n.server, &discoveryService{node: n})
+	Ydb_Scheme_V1.RegisterSchemeServiceServer(n.server, &schemeService{node: n})
+	Ydb_Table_V1.RegisterTableServiceServer(n.server, &tableService{node: n})
+	return n
+}
+
+func (n *Node) serve() {
+	go func() {
+		_ = n.server.Serve(n.listener)
+	}()
+}
+
+func (n *Node) stop() {
+	n.server.Stop()
+}
+
+// ID returns node id
+func (n *Node) ID() uint32 {
+	return n.id
+}
+
+// Address returns fake network address of node
+func (n *Node) Address() string {
+	return n.address
+}
+
+// Overload makes node overloaded for duration d
+//
+// Overloaded node responds to table service operations with OVERLOADED status.
+func (n *Node) Overload(d time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.overloadedUntil = time.Now().Add(d)
+}
+
+// Outage makes node unavailable for duration d
+//
+// Unavailable node responds to all calls with grpc Unavailable code.
+func (n *Node) Outage(d time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.outageUntil = time.Now().Add(d)
+}
+
+// SetDelay defines delay of all responses of node. Zero delay disables delaying.
+func (n *Node) SetDelay(d time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.delay = d
+}
+
+// DropStreams makes node drop streams for duration d
+//
+// Dropped stream sends first part of result and breaks with grpc Unavailable code.
+func (n *Node) DropStreams(d time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.dropStreamsTill = time.Now().Add(d)
+}
+
+// KillSessions invalidates all sessions of node
+//
+// Calls with invalidated sessions fails with BAD_SESSION status.
+func (n *Node) KillSessions() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sessions = make(map[string]struct{})
+}
+
+// Reset disables all faults of node
+func (n *Node) Reset() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.overloadedUntil = time.Time{}
+	n.outageUntil = time.Time{}
+	n.dropStreamsTill = time.Time{}
+	n.delay = 0
+}
+
+// Calls returns count of calls of grpc method (such as Ydb_Table_V1.TableService_ExecuteDataQuery_FullMethodName)
+// which were received by node, including failed calls
+func (n *Node) Calls(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls[method]
+}
+
+// Sessions returns count of alive sessions on node
+func (n *Node) Sessions() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.sessions)
+}
+
+// SessionsCreated returns count of created sessions on node
+func (n *Node) SessionsCreated() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.sessionsCreated
+}
+
+// call registers call and applies transport faults (outage and delay)
+func (n *Node) call(ctx context.Context, method string) error {
+	n.mu.Lock()
+	n.calls[method]++
+	outage := time.Now().Before(n.outageUntil)
+	delay := n.delay
+	n.mu.Unlock()
+
+	if outage {
+		return status.Error(codes.Unavailable, "stub: node outage")
+	}
+	if delay > 0 {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-time.After(delay):
+		}
+	}
+	return nil
+}
+
+func (n *Node) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if err := n.call(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (n *Node) streamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := n.call(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func (n *Node) streamDropped() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return time.Now().Before(n.dropStreamsTill)
+}
+
+func (n *Node) createSession() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	id := testutil.SessionID(testutil.WithNodeID(n.id))
+	n.sessions[id] = struct{}{}
+	n.sessionsCreated++
+	return id
+}
+
+func (n *Node) deleteSession(id string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.sessions, id)
+}
+
+// operationStatus returns status of table service operation with session id (if not empty)
+func (n *Node) operationStatus(sessionID string) (Ydb.StatusIds_StatusCode, string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if time.Now().Before(n.overloadedUntil) {
+		return Ydb.StatusIds_OVERLOADED, "stub: node overloaded"
+	}
+	if sessionID != "" {
+		if _, has := n.sessions[sessionID]; !has {
+			return Ydb.StatusIds_BAD_SESSION, "stub: session not found"
+		}
+	}
+	return Ydb.StatusIds_SUCCESS, ""
+}
+
+// operation makes ready operation with result of table service call
+func (n *Node) operation(sessionID string, result proto.Message) (*Ydb_Operations.Operation, error) {
+	code, message := n.operationStatus(sessionID)
+	if code != Ydb.StatusIds_SUCCESS {
+		return &Ydb_Operations.Operation{
+			Ready:  true,
+			Status: code,
+			Issues: []*Ydb_Issue.IssueMessage{{Message: message}},
+		}, nil
+	}
+	op := &Ydb_Operations.Operation{
+		Ready:  true,
+		Status: Ydb.StatusIds_SUCCESS,
+	}
+	if result != nil {
+		anyResult, err := anypb.New(result)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		op.Result = anyResult
+	}
+	return op, nil
+}
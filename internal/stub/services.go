@@ -0,0 +1,260 @@
+package stub
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Discovery_V1"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Scheme_V1"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Table_V1"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Discovery"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Scheme"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xrand"
+)
+
+type discoveryService struct {
+	Ydb_Discovery_V1.UnimplementedDiscoveryServiceServer
+
+	node *Node
+}
+
+func (s *discoveryService) ListEndpoints(
+	ctx context.Context, request *Ydb_Discovery.ListEndpointsRequest,
+) (*Ydb_Discovery.ListEndpointsResponse, error) {
+	result := &Ydb_Discovery.ListEndpointsResult{
+		SelfLocation: "stub",
+	}
+	for _, n := range s.node.cluster.nodes {
+		host, port, err := net.SplitHostPort(n.address)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		p, err := strconv.ParseUint(port, 10, 32)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		result.Endpoints = append(result.Endpoints, &Ydb_Discovery.EndpointInfo{
+			Address:  host,
+			Port:     uint32(p),
+			Location: "stub",
+			NodeId:   n.id,
+		})
+	}
+	op, err := s.node.operation("", result)
+	if err != nil {
+		return nil, err
+	}
+	return &Ydb_Discovery.ListEndpointsResponse{Operation: op}, nil
+}
+
+func (s *discoveryService) WhoAmI(
+	ctx context.Context, request *Ydb_Discovery.WhoAmIRequest,
+) (*Ydb_Discovery.WhoAmIResponse, error) {
+	op, err := s.node.operation("", &Ydb_Discovery.WhoAmIResult{User: "stub"})
+	if err != nil {
+		return nil, err
+	}
+	return &Ydb_Discovery.WhoAmIResponse{Operation: op}, nil
+}
+
+type schemeService struct {
+	Ydb_Scheme_V1.UnimplementedSchemeServiceServer
+
+	node *Node
+}
+
+// DescribePath describes only root of database (other paths are not found)
+func (s *schemeService) DescribePath(
+	ctx context.Context, request *Ydb_Scheme.DescribePathRequest,
+) (*Ydb_Scheme.DescribePathResponse, error) {
+	if request.GetPath() != s.node.cluster.database {
+		return &Ydb_Scheme.DescribePathResponse{Operation: &Ydb_Operations.Operation{
+			Ready:  true,
+			Status: Ydb.StatusIds_SCHEME_ERROR,
+			Issues: []*Ydb_Issue.IssueMessage{{Message: "stub: path not found"}},
+		}}, nil
+	}
+	op, err := s.node.operation("", &Ydb_Scheme.DescribePathResult{
+		Self: &Ydb_Scheme.Entry{
+			Name: request.GetPath(),
+			Type: Ydb_Scheme.Entry_DATABASE,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Ydb_Scheme.DescribePathResponse{Operation: op}, nil
+}
+
+type tableService struct {
+	Ydb_Table_V1.UnimplementedTableServiceServer
+
+	node *Node
+}
+
+func (s *tableService) CreateSession(
+	ctx context.Context, request *Ydb_Table.CreateSessionRequest,
+) (*Ydb_Table.CreateSessionResponse, error) {
+	if code, _ := s.node.operationStatus(""); code != Ydb.StatusIds_SUCCESS {
+		op, err := s.node.operation("", nil)
+		return &Ydb_Table.CreateSessionResponse{Operation: op}, err
+	}
+	op, err := s.node.operation("", &Ydb_Table.CreateSessionResult{
+		SessionId: s.node.createSession(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Ydb_Table.CreateSessionResponse{Operation: op}, nil
+}
+
+func (s *tableService) DeleteSession(
+	ctx context.Context, request *Ydb_Table.DeleteSessionRequest,
+) (*Ydb_Table.DeleteSessionResponse, error) {
+	op, err := s.node.operation(request.GetSessionId(), nil)
+	if err != nil {
+		return nil, err
+	}
+	s.node.deleteSession(request.GetSessionId())
+	return &Ydb_Table.DeleteSessionResponse{Operation: op}, nil
+}
+
+func (s *tableService) KeepAlive(
+	ctx context.Context, request *Ydb_Table.KeepAliveRequest,
+) (*Ydb_Table.KeepAliveResponse, error) {
+	op, err := s.node.operation(request.GetSessionId(), &Ydb_Table.KeepAliveResult{
+		SessionStatus: Ydb_Table.KeepAliveResult_SESSION_STATUS_READY,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Ydb_Table.KeepAliveResponse{Operation: op}, nil
+}
+
+func (s *tableService) ExecuteSchemeQuery(
+	ctx context.Context, request *Ydb_Table.ExecuteSchemeQueryRequest,
+) (*Ydb_Table.ExecuteSchemeQueryResponse, error) {
+	op, err := s.node.operation(request.GetSessionId(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Ydb_Table.ExecuteSchemeQueryResponse{Operation: op}, nil
+}
+
+func (s *tableService) PrepareDataQuery(
+	ctx context.Context, request *Ydb_Table.PrepareDataQueryRequest,
+) (*Ydb_Table.PrepareDataQueryResponse, error) {
+	op, err := s.node.operation(request.GetSessionId(), &Ydb_Table.PrepareQueryResult{
+		QueryId: "query-" + strconv.FormatInt(xrand.New().Int64(1<<62), 16),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Ydb_Table.PrepareDataQueryResponse{Operation: op}, nil
+}
+
+func (s *tableService) ExecuteDataQuery(
+	ctx context.Context, request *Ydb_Table.ExecuteDataQueryRequest,
+) (*Ydb_Table.ExecuteDataQueryResponse, error) {
+	result := &Ydb_Table.ExecuteQueryResult{}
+	if !request.GetTxControl().GetCommitTx() {
+		result.TxMeta = &Ydb_Table.TransactionMeta{Id: txID()}
+	}
+	op, err := s.node.operation(request.GetSessionId(), result)
+	if err != nil {
+		return nil, err
+	}
+	return &Ydb_Table.ExecuteDataQueryResponse{Operation: op}, nil
+}
+
+func (s *tableService) BeginTransaction(
+	ctx context.Context, request *Ydb_Table.BeginTransactionRequest,
+) (*Ydb_Table.BeginTransactionResponse, error) {
+	op, err := s.node.operation(request.GetSessionId(), &Ydb_Table.BeginTransactionResult{
+		TxMeta: &Ydb_Table.TransactionMeta{Id: txID()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Ydb_Table.BeginTransactionResponse{Operation: op}, nil
+}
+
+func (s *tableService) CommitTransaction(
+	ctx context.Context, request *Ydb_Table.CommitTransactionRequest,
+) (*Ydb_Table.CommitTransactionResponse, error) {
+	op, err := s.node.operation(request.GetSessionId(), &Ydb_Table.CommitTransactionResult{})
+	if err != nil {
+		return nil, err
+	}
+	return &Ydb_Table.CommitTransactionResponse{Operation: op}, nil
+}
+
+func (s *tableService) RollbackTransaction(
+	ctx context.Context, request *Ydb_Table.RollbackTransactionRequest,
+) (*Ydb_Table.RollbackTransactionResponse, error) {
+	op, err := s.node.operation(request.GetSessionId(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Ydb_Table.RollbackTransactionResponse{Operation: op}, nil
+}
+
+func (s *tableService) StreamExecuteScanQuery(
+	request *Ydb_Table.ExecuteScanQueryRequest, stream Ydb_Table_V1.TableService_StreamExecuteScanQueryServer,
+) error {
+	code, message := s.node.operationStatus("")
+	if code != Ydb.StatusIds_SUCCESS {
+		return stream.Send(&Ydb_Table.ExecuteScanQueryPartialResponse{
+			Status: code,
+			Issues: []*Ydb_Issue.IssueMessage{{Message: message}},
+		})
+	}
+	if err := stream.Send(&Ydb_Table.ExecuteScanQueryPartialResponse{
+		Status: Ydb.StatusIds_SUCCESS,
+		Result: &Ydb_Table.ExecuteScanQueryPartialResult{
+			ResultSet: &Ydb.ResultSet{},
+		},
+	}); err != nil {
+		return err
+	}
+	if s.node.streamDropped() {
+		return status.Error(codes.Unavailable, "stub: stream dropped")
+	}
+	return nil
+}
+
+func (s *tableService) StreamReadTable(
+	request *Ydb_Table.ReadTableRequest, stream Ydb_Table_V1.TableService_StreamReadTableServer,
+) error {
+	code, message := s.node.operationStatus(request.GetSessionId())
+	if code != Ydb.StatusIds_SUCCESS {
+		return stream.Send(&Ydb_Table.ReadTableResponse{
+			Status: code,
+			Issues: []*Ydb_Issue.IssueMessage{{Message: message}},
+		})
+	}
+	if err := stream.Send(&Ydb_Table.ReadTableResponse{
+		Status: Ydb.StatusIds_SUCCESS,
+		Result: &Ydb_Table.ReadTableResult{
+			ResultSet: &Ydb.ResultSet{},
+		},
+	}); err != nil {
+		return err
+	}
+	if s.node.streamDropped() {
+		return status.Error(codes.Unavailable, "stub: stream dropped")
+	}
+	return nil
+}
+
+func txID() string {
+	return "tx-" + strconv.FormatInt(xrand.New().Int64(1<<62), 16)
+}
@@ -0,0 +1,193 @@
+package table
+
+import (
+	"context"
+	"math"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+const (
+	// adaptiveGrowAfter is a count of sequential evaluations with session waiters
+	// which are required for growing of session pool limit
+	adaptiveGrowAfter = 3
+
+	// adaptiveShrinkAfter is a count of sequential evaluations with low utilization
+	// which are required for shrinking of session pool limit
+	adaptiveShrinkAfter = 10
+
+	// adaptiveLowUtilization defines hysteresis band between growing and shrinking:
+	// utilization is low if it is less than target utilization multiplied by adaptiveLowUtilization
+	adaptiveLowUtilization = 0.5
+
+	// adaptiveShrinkCooldown is a count of evaluations after growing of session pool limit
+	// while shrinking is forbidden (flapping guard)
+	adaptiveShrinkCooldown = 3 * adaptiveShrinkAfter
+
+	// adaptiveShrinkAfterMax is a max count of sequential evaluations with low utilization
+	// which are required for shrinking of session pool limit. Required count is doubled
+	// each time session pool limit grows soon after shrinking (flapping guard)
+	adaptiveShrinkAfterMax = 32 * adaptiveShrinkAfter
+)
+
+// poolSample is a snapshot of session pool state for evaluation of adaptive limit
+type poolSample struct {
+	limit   int
+	size    int
+	busy    int
+	waiters int
+}
+
+func (s poolSample) utilization() float64 {
+	if s.limit == 0 {
+		return 0
+	}
+	return float64(s.busy) / float64(s.limit)
+}
+
+// adaptiveSizer evaluates limit of session pool from samples of session pool state
+//
+// adaptiveSizer is not goroutine-safe.
+type adaptiveSizer struct {
+	config config.AdaptiveSize
+
+	growStreak         int // count of sequential evaluations with waiters
+	shrinkStreak       int // count of sequential evaluations with low utilization
+	shrinkAfter        int // required count of sequential evaluations with low utilization
+	sinceLastGrowing   int // count of evaluations since last growing of limit
+	sinceLastShrinking int // count of evaluations since last shrinking of limit
+}
+
+func newAdaptiveSizer(cfg config.AdaptiveSize) *adaptiveSizer {
+	return &adaptiveSizer{
+		config:             cfg,
+		shrinkAfter:        adaptiveShrinkAfter,
+		sinceLastGrowing:   adaptiveShrinkCooldown,
+		sinceLastShrinking: adaptiveShrinkCooldown,
+	}
+}
+
+// evaluate returns new limit of session pool and reason of changing (empty if limit is not changed)
+func (a *adaptiveSizer) evaluate(s poolSample) (limit int, reason string) {
+	a.sinceLastGrowing++
+	a.sinceLastShrinking++
+
+	switch {
+	case s.waiters > 0 && s.limit < a.config.Max:
+		a.growStreak++
+		a.shrinkStreak = 0
+		if a.growStreak < adaptiveGrowAfter {
+			return s.limit, ""
+		}
+		a.growStreak = 0
+		a.sinceLastGrowing = 0
+		if a.sinceLastShrinking < adaptiveShrinkCooldown && a.shrinkAfter < adaptiveShrinkAfterMax {
+			// limit was shrunk too early, load is periodic
+			a.shrinkAfter *= 2
+		}
+		// new limit fits all busy sessions and waiters with target utilization
+		limit = a.clamp(a.desired(s.busy + s.waiters))
+		if limit <= s.limit {
+			limit = s.limit + 1
+		}
+		return limit, "waiters"
+
+	case s.waiters == 0 && s.limit > a.config.Min &&
+		s.utilization() < a.config.TargetUtilization*adaptiveLowUtilization:
+		a.growStreak = 0
+		if a.sinceLastGrowing < adaptiveShrinkCooldown {
+			a.shrinkStreak = 0
+			return s.limit, ""
+		}
+		a.shrinkStreak++
+		if a.shrinkStreak < a.shrinkAfter {
+			return s.limit, ""
+		}
+		a.shrinkStreak = 0
+		a.sinceLastShrinking = 0
+		return a.clamp(a.desired(s.busy)), "low utilization"
+
+	default:
+		a.growStreak = 0
+		a.shrinkStreak = 0
+		return s.limit, ""
+	}
+}
+
+// desired returns limit which provides target utilization for count of busy sessions
+func (a *adaptiveSizer) desired(busy int) int {
+	return int(math.Ceil(float64(busy) / a.config.TargetUtilization))
+}
+
+func (a *adaptiveSizer) clamp(limit int) int {
+	if limit < a.config.Min {
+		return a.config.Min
+	}
+	if limit > a.config.Max {
+		return a.config.Max
+	}
+	return limit
+}
+
+func (c *Client) internalPoolAdaptiveSizeTick(ctx context.Context, sizer *adaptiveSizer) {
+	c.mu.WithLock(func() {
+		if c.isClosed() {
+			return
+		}
+		sample := poolSample{
+			limit:   c.limit,
+			size:    len(c.index),
+			busy:    len(c.index) - c.idle.Len(),
+			waiters: c.waitQ.Len(),
+		}
+		limit, reason := sizer.evaluate(sample)
+		if limit == c.limit {
+			return
+		}
+		trace.TableOnPoolResize(c.config.Trace(),
+			c.limit, limit, sample.size, sample.busy, sample.waiters, sample.utilization(), reason,
+		)
+		prevLimit := c.limit
+		c.limit = limit
+
+		// wake up waiters for creating of new sessions
+		for i := prevLimit; i < limit && c.waitQ.Len() > 0; i++ {
+			c.internalPoolNotify(nil)
+		}
+
+		// delete excess idle sessions, busy excess sessions are deleted on Put
+		for size := len(c.index); size > limit && c.idle.Len() > 0; size-- {
+			s := c.internalPoolRemoveFirstIdle()
+			s.SetStatus(table.SessionClosing)
+			c.wg.Add(1)
+			go func() {
+				defer c.wg.Done()
+				c.internalPoolSyncCloseSession(ctx, s)
+			}()
+		}
+	})
+}
+
+func (c *Client) internalPoolAdaptiveSize(ctx context.Context, cfg config.AdaptiveSize) {
+	defer c.wg.Done()
+
+	sizer := newAdaptiveSizer(cfg)
+
+	ticker := c.clock.NewTicker(cfg.EvaluationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+
+		case <-ctx.Done():
+			return
+
+		case <-ticker.Chan():
+			c.internalPoolAdaptiveSizeTick(ctx, sizer)
+		}
+	}
+}
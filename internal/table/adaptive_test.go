@@ -0,0 +1,251 @@
+package table
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+// simulatedPool is a model of session pool under load for simulation of adaptive sizing
+type simulatedPool struct {
+	sizer   *adaptiveSizer
+	clock   clockwork.FakeClock
+	limit   int
+	size    int
+	resizes []int
+}
+
+func newSimulatedPool(cfg config.AdaptiveSize) *simulatedPool {
+	return &simulatedPool{
+		sizer: newAdaptiveSizer(cfg),
+		clock: clockwork.NewFakeClock(),
+		limit: cfg.Min,
+	}
+}
+
+// step simulates evaluation interval with demand of concurrent sessions
+func (p *simulatedPool) step(demand int) {
+	p.clock.Advance(p.sizer.config.EvaluationInterval)
+	busy := demand
+	if busy > p.limit {
+		busy = p.limit
+	}
+	if busy > p.size {
+		p.size = busy
+	}
+	limit, reason := p.sizer.evaluate(poolSample{
+		limit:   p.limit,
+		size:    p.size,
+		busy:    busy,
+		waiters: demand - busy,
+	})
+	if limit != p.limit {
+		if reason == "" {
+			panic("empty reason of resize")
+		}
+		p.limit = limit
+		p.resizes = append(p.resizes, limit)
+		if p.size > limit {
+			p.size = limit
+		}
+	}
+}
+
+// run simulates load pattern during duration
+func (p *simulatedPool) run(duration time.Duration, demand func(elapsed time.Duration) int) {
+	start := p.clock.Now()
+	for p.clock.Since(start) < duration {
+		p.step(demand(p.clock.Since(start)))
+	}
+}
+
+func constantDemand(n int) func(time.Duration) int {
+	return func(time.Duration) int {
+		return n
+	}
+}
+
+func TestAdaptiveSizerConvergence(t *testing.T) {
+	cfg := config.AdaptiveSize{
+		Min:                5,
+		Max:                100,
+		TargetUtilization:  0.7,
+		EvaluationInterval: time.Second,
+	}
+	p := newSimulatedPool(cfg)
+
+	t.Run("Grow", func(t *testing.T) {
+		p.run(time.Minute, constantDemand(40))
+		require.Equal(t, int(math.Ceil(40/cfg.TargetUtilization)), p.limit)
+		resizes := len(p.resizes)
+		p.run(10*time.Minute, constantDemand(40))
+		require.Len(t, p.resizes, resizes, "limit must be stable under stable load")
+	})
+
+	t.Run("Shrink", func(t *testing.T) {
+		p.run(5*time.Minute, constantDemand(10))
+		require.Equal(t, int(math.Ceil(10/cfg.TargetUtilization)), p.limit)
+		resizes := len(p.resizes)
+		p.run(10*time.Minute, constantDemand(10))
+		require.Len(t, p.resizes, resizes, "limit must be stable under stable load")
+	})
+
+	t.Run("Max", func(t *testing.T) {
+		p.run(time.Minute, constantDemand(1000))
+		require.Equal(t, cfg.Max, p.limit)
+	})
+
+	t.Run("Min", func(t *testing.T) {
+		p.run(10*time.Minute, constantDemand(0))
+		require.Equal(t, cfg.Min, p.limit)
+	})
+}
+
+func TestAdaptiveSizerSustainedWaiters(t *testing.T) {
+	p := newSimulatedPool(config.AdaptiveSize{
+		Min:                5,
+		Max:                100,
+		TargetUtilization:  0.7,
+		EvaluationInterval: time.Second,
+	})
+	// short bursts of waiters (shorter than adaptiveGrowAfter evaluations) do not grow limit
+	p.run(10*time.Minute, func(elapsed time.Duration) int {
+		if elapsed/time.Second%adaptiveGrowAfter == 0 {
+			return 20
+		}
+		return 3
+	})
+	require.Empty(t, p.resizes)
+	require.Equal(t, 5, p.limit)
+}
+
+func TestAdaptiveSizerFlappingGuard(t *testing.T) {
+	p := newSimulatedPool(config.AdaptiveSize{
+		Min:                5,
+		Max:                100,
+		TargetUtilization:  0.7,
+		EvaluationInterval: time.Second,
+	})
+	// periodic load: 10 seconds of high demand, 20 seconds of low demand
+	p.run(time.Hour, func(elapsed time.Duration) int {
+		if elapsed%(30*time.Second) < 10*time.Second {
+			return 40
+		}
+		return 2
+	})
+	require.NotEmpty(t, p.resizes)
+	require.LessOrEqual(t, len(p.resizes), 5, "limit flapped: %v", p.resizes)
+	require.Equal(t, 58, p.limit)
+}
+
+func TestAdaptiveSizerShortLowLoad(t *testing.T) {
+	p := newSimulatedPool(config.AdaptiveSize{
+		Min:                5,
+		Max:                100,
+		TargetUtilization:  0.7,
+		EvaluationInterval: time.Second,
+	})
+	// periodic load with phases of low demand shorter than adaptiveShrinkAfter evaluations
+	p.run(time.Hour, func(elapsed time.Duration) int {
+		if elapsed%(15*time.Second) < 7*time.Second {
+			return 40
+		}
+		return 2
+	})
+	require.Equal(t, []int{58}, p.resizes)
+}
+
+func TestAdaptiveSizerHysteresis(t *testing.T) {
+	cfg := config.AdaptiveSize{
+		Min:                5,
+		Max:                100,
+		TargetUtilization:  0.7,
+		EvaluationInterval: time.Second,
+	}
+	p := newSimulatedPool(cfg)
+	p.run(time.Minute, constantDemand(40))
+	limit := p.limit
+	// utilization between low threshold and target utilization does not shrink limit
+	busy := int(float64(limit)*cfg.TargetUtilization*adaptiveLowUtilization) + 1
+	p.run(time.Hour, constantDemand(busy))
+	require.Equal(t, limit, p.limit)
+}
+
+func TestSessionPoolAdaptiveSize(t *testing.T) {
+	var (
+		fakeClock = clockwork.NewFakeClock()
+		resizes   = make(chan trace.TablePoolResizeInfo, 10)
+	)
+	p := newClientWithStubBuilder(
+		t,
+		stubBalancer(t, newStubCluster(t)),
+		0,
+		config.WithAdaptiveSize(1, 4, 0.7, time.Second),
+		config.WithIdleThreshold(-1),
+		config.WithClock(fakeClock),
+		config.WithTrace(&trace.Table{
+			OnPoolResize: func(info trace.TablePoolResizeInfo) {
+				resizes <- info
+			},
+		}),
+	)
+	defer func() {
+		_ = p.Close(context.Background())
+	}()
+
+	// wait for creating of ticker of adaptive sizing
+	fakeClock.BlockUntil(1)
+
+	// wait for resize with advancing of fake clock by evaluation intervals
+	waitResize := func() trace.TablePoolResizeInfo {
+		for i := 0; i < 1000; i++ {
+			fakeClock.Advance(time.Second)
+			select {
+			case info := <-resizes:
+				return info
+			case <-time.After(time.Millisecond):
+			}
+		}
+		t.Fatal("no resize")
+		return trace.TablePoolResizeInfo{}
+	}
+
+	s1 := mustGetSession(t, p)
+
+	got := make(chan *session)
+	waitCh := whenWantWaitCh(p)
+	go func() {
+		s, err := p.Get(context.Background())
+		require.NoError(t, err)
+		got <- s
+	}()
+	<-waitCh
+
+	info := waitResize()
+	require.Equal(t, 1, info.PrevLimit)
+	require.Equal(t, 3, info.Limit)
+	require.Equal(t, 1, info.Waiters)
+	require.Equal(t, "waiters", info.Reason)
+
+	s2 := <-got
+	mustPutSession(t, p, s1)
+	mustPutSession(t, p, s2)
+
+	info = waitResize()
+	require.Equal(t, 3, info.PrevLimit)
+	require.Equal(t, 1, info.Limit)
+	require.Equal(t, "low utilization", info.Reason)
+
+	require.Eventually(t, func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return len(p.index) == 1 && p.idle.Len() == 1
+	}, time.Second, time.Millisecond)
+}
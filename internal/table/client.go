@@ -53,6 +53,7 @@ func newClient(
 	c = &Client{
 		clock:       config.Clock(),
 		config:      config,
+		limiter:     newSessionLimiter(config.Clock(), config.SessionLimitCooldown()),
 		cc:          balancer,
 		nodeChecker: balancer,
 		build:       builder,
@@ -68,14 +69,26 @@ func newClient(
 		},
 		done: make(chan struct{}),
 	}
+	if config.SingleSession() {
+		c.singleSessionLock = make(chan struct{}, 1)
+
+		return c, nil
+	}
 	if idleThreshold := config.IdleThreshold(); idleThreshold > 0 {
 		c.wg.Add(1)
 		go c.internalPoolGC(ctx, idleThreshold)
 	}
+	if adaptiveSize := config.AdaptiveSize(); adaptiveSize != nil {
+		c.limit = adaptiveSize.Min
+		c.wg.Add(1)
+		go c.internalPoolAdaptiveSize(ctx, *adaptiveSize)
+	}
 
 	return c, nil
 }
 
+var _ table.Client = (*Client)(nil)
+
 // Client is a set of session instances that may be reused.
 // A Client is safe for use by multiple goroutines simultaneously.
 type Client struct {
@@ -85,6 +98,7 @@ type Client struct {
 	cc          grpc.ClientConnInterface
 	nodeChecker nodeChecker
 	clock       clockwork.Clock
+	limiter     *sessionLimiter // shared cooldown of sessions creation after server session limit exceeded
 
 	// read-write fields
 	mu                xsync.Mutex
@@ -97,6 +111,10 @@ type Client struct {
 	testHookGetWaitCh func() // nil except some tests.
 	wg                sync.WaitGroup
 	done              chan struct{}
+
+	// single session mode fields
+	singleSession     *session      // guarded by mu
+	singleSessionLock chan struct{} // locks singleSession while it is in use
 }
 
 type createSessionOptions struct {
@@ -221,6 +239,15 @@ func (c *Client) createSession(ctx context.Context, opts ...createSessionOption)
 	}
 }
 
+// KeepInCache returns default keep-in-cache flag of data queries.
+// ok is false if default keep-in-cache flag is not defined
+func (c *Client) KeepInCache() (keepInCache, ok bool) {
+	if c == nil {
+		return false, false
+	}
+	return c.config.KeepInCache()
+}
+
 func (c *Client) CreateSession(ctx context.Context, opts ...table.Option) (_ table.ClosableSession, err error) {
 	if c == nil {
 		return nil, xerrors.WithStackTrace(errNilClient)
@@ -304,6 +331,10 @@ func (c *Client) internalPoolCreateSession(ctx context.Context) (s *session, err
 		})
 	}()
 
+	if wait, ok := c.limiter.acquire(); !ok {
+		return nil, serverSessionLimitError(wait)
+	}
+
 	s, err = c.createSession(
 		meta.WithAllowFeatures(ctx,
 			metaHeaders.HintSessionBalancer,
@@ -338,9 +369,16 @@ func (c *Client) internalPoolCreateSession(ctx context.Context) (s *session, err
 			})
 		}))
 	if err != nil {
+		if isServerSessionLimitExceeded(err, c.config.SessionLimitMatcher()) {
+			cooldown, interval := c.limiter.exceeded()
+			trace.TableOnPoolSessionLimit(c.config.Trace(), cooldown, interval, err)
+			return nil, serverSessionLimitError(cooldown)
+		}
 		return nil, xerrors.WithStackTrace(err)
 	}
 
+	c.limiter.created()
+
 	return s, nil
 }
 
@@ -362,7 +400,7 @@ func (c *Client) internalPoolGet(ctx context.Context, opts ...getOption) (s *ses
 	}
 
 	var (
-		start = time.Now()
+		start = c.clock.Now()
 		i     = 0
 		o     = getOptions{t: c.config.Trace()}
 	)
@@ -440,7 +478,7 @@ func (c *Client) internalPoolGet(ctx context.Context, opts ...getOption) (s *ses
 		return s, xerrors.WithStackTrace(
 			fmt.Errorf("failed to get session from pool ("+
 				"attempts: %d, latency: %v, pool have %d sessions (%d busy, %d idle, %d create_in_progress): %w",
-				i, time.Since(start), index, index-idle, idle, createInProgress, err,
+				i, c.clock.Since(start), index, index-idle, idle, createInProgress, err,
 			),
 		)
 	}
@@ -450,6 +488,9 @@ func (c *Client) internalPoolGet(ctx context.Context, opts ...getOption) (s *ses
 // Get returns first idle session from the Client and removes it from
 // there. If no items stored in Client it creates new one returns it.
 func (c *Client) Get(ctx context.Context) (s *session, err error) {
+	if c.config.SingleSession() {
+		return c.singleSessionGet(ctx)
+	}
 	return c.internalPoolGet(ctx)
 }
 
@@ -522,6 +563,10 @@ func (c *Client) internalPoolWaitFromCh(ctx context.Context, t *trace.Table) (s
 // Get() or Take() calls. In other way it will produce unexpected behavior or
 // panic.
 func (c *Client) Put(ctx context.Context, s *session) (err error) {
+	if c.config.SingleSession() {
+		return c.singleSessionPut(ctx, s)
+	}
+
 	onDone := trace.TableOnPoolPut(c.config.Trace(), &ctx,
 		stack.FunctionID(""),
 		s,
@@ -558,6 +603,10 @@ func (c *Client) Put(ctx context.Context, s *session) (err error) {
 		}
 
 		if !c.internalPoolNotify(s) {
+			// session pool limit may be decreased with adaptive sizing
+			if len(c.index) > c.limit {
+				return xerrors.WithStackTrace(errSessionPoolOverflow)
+			}
 			c.internalPoolPushIdle(s, c.clock.Now())
 		}
 
@@ -608,6 +657,10 @@ func (c *Client) Close(ctx context.Context) (err error) {
 
 	c.wg.Wait()
 
+	if c.config.SingleSession() {
+		c.singleSessionClose(ctx)
+	}
+
 	return nil
 }
 
@@ -627,17 +680,28 @@ func (c *Client) Do(ctx context.Context, op table.Operation, opts ...table.Optio
 
 	config := c.retryOptions(opts...)
 
+	ctx, operationID, operationIDCreated := xcontext.WithOperationID(ctx)
+	defer func() {
+		if finalErr != nil && operationIDCreated && operationID != "" {
+			finalErr = &retry.OperationIDError{
+				OperationID: operationID,
+				Err:         finalErr,
+			}
+		}
+	}()
+
 	attempts, onIntermediate := 0, trace.TableOnDo(config.Trace, &ctx,
 		stack.FunctionID(""),
 		config.Label, config.Label, config.Idempotent, xcontext.IsNestedCall(ctx),
+		operationID,
 	)
 	defer func() {
-		onIntermediate(finalErr)(attempts, finalErr)
+		onIntermediate(operationID, finalErr)(attempts, operationID, finalErr)
 	}()
 
 	err := do(ctx, c, c.config, op, func(err error) {
 		attempts++
-		onIntermediate(err)
+		onIntermediate(operationID, err)
 	}, config.RetryOptions...)
 	if err != nil {
 		return xerrors.WithStackTrace(err)
@@ -657,12 +721,23 @@ func (c *Client) DoTx(ctx context.Context, op table.TxOperation, opts ...table.O
 
 	config := c.retryOptions(opts...)
 
+	ctx, operationID, operationIDCreated := xcontext.WithOperationID(ctx)
+	defer func() {
+		if finalErr != nil && operationIDCreated && operationID != "" {
+			finalErr = &retry.OperationIDError{
+				OperationID: operationID,
+				Err:         finalErr,
+			}
+		}
+	}()
+
 	attempts, onIntermediate := 0, trace.TableOnDoTx(config.Trace, &ctx,
 		stack.FunctionID(""),
 		config.Label, config.Label, config.Idempotent, xcontext.IsNestedCall(ctx),
+		operationID,
 	)
 	defer func() {
-		onIntermediate(finalErr)(attempts, finalErr)
+		onIntermediate(operationID, finalErr)(attempts, operationID, finalErr)
 	}()
 
 	return retryBackoff(ctx, c,
@@ -670,7 +745,7 @@ func (c *Client) DoTx(ctx context.Context, op table.TxOperation, opts ...table.O
 			attempts++
 
 			defer func() {
-				onIntermediate(err)
+				onIntermediate(operationID, err)
 			}()
 
 			tx, err := s.BeginTransaction(ctx, config.TxSettings)
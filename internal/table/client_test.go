@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"os"
 	"path"
 	"runtime"
 	"sync"
@@ -13,11 +14,16 @@ import (
 
 	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Table_V1"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	ydbConfig "github.com/ydb-platform/ydb-go-sdk/v3/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/conn"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/endpoint"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/stub"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xatomic"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xcontext"
@@ -25,6 +31,7 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xrand"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xsync"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 	"github.com/ydb-platform/ydb-go-sdk/v3/testutil"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
@@ -40,18 +47,7 @@ func TestSessionPoolCreateAbnormalResult(t *testing.T) {
 		defer cancel()
 		p := newClientWithStubBuilder(
 			t,
-			testutil.NewBalancer(
-				testutil.WithInvokeHandlers(
-					testutil.InvokeHandlers{
-						testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
-							return &Ydb_Table.CreateSessionResult{
-								SessionId: testutil.SessionID(),
-							}, nil
-						},
-						testutil.TableDeleteSession: okHandler,
-					},
-				),
-			),
+			stubBalancer(t, newStubCluster(t)),
 			limit,
 			config.WithSizeLimit(limit),
 		)
@@ -109,13 +105,7 @@ func TestSessionPoolCloseWhenWaiting(t *testing.T) {
 			)
 			p := newClientWithStubBuilder(
 				t,
-				testutil.NewBalancer(testutil.WithInvokeHandlers(testutil.InvokeHandlers{
-					testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
-						return &Ydb_Table.CreateSessionResult{
-							SessionId: testutil.SessionID(),
-						}, nil
-					},
-				})),
+				stubBalancer(t, newStubCluster(t)),
 				1,
 				config.WithSizeLimit(1),
 				config.WithTrace(
@@ -194,16 +184,7 @@ func TestSessionPoolClose(t *testing.T) {
 
 		p := newClientWithStubBuilder(
 			t,
-			testutil.NewBalancer(testutil.WithInvokeHandlers(testutil.InvokeHandlers{
-				testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
-					return &Ydb_Table.CreateSessionResult{
-						SessionId: testutil.SessionID(),
-					}, nil
-				},
-				testutil.TableDeleteSession: func(interface{}) (proto.Message, error) {
-					return &Ydb_Table.DeleteSessionResponse{}, nil
-				},
-			})),
+			stubBalancer(t, newStubCluster(t)),
 			3,
 			config.WithSizeLimit(3),
 			config.WithIdleThreshold(time.Hour),
@@ -279,13 +260,7 @@ func TestRaceWgClosed(t *testing.T) {
 
 		wg := sync.WaitGroup{}
 		p := newClientWithStubBuilder(t,
-			testutil.NewBalancer(testutil.WithInvokeHandlers(testutil.InvokeHandlers{
-				testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
-					return &Ydb_Table.CreateSessionResult{
-						SessionId: testutil.SessionID(),
-					}, nil
-				},
-			})),
+			stubBalancer(t, newStubCluster(t)),
 			limit,
 			config.WithSizeLimit(limit),
 		)
@@ -332,13 +307,7 @@ func TestSessionPoolDeleteReleaseWait(t *testing.T) {
 			)
 			p := newClientWithStubBuilder(
 				t,
-				testutil.NewBalancer(testutil.WithInvokeHandlers(testutil.InvokeHandlers{
-					testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
-						return &Ydb_Table.CreateSessionResult{
-							SessionId: testutil.SessionID(),
-						}, nil
-					},
-				})),
+				stubBalancer(t, newStubCluster(t)),
 				2,
 				config.WithSizeLimit(1),
 				config.WithIdleThreshold(time.Hour),
@@ -476,13 +445,7 @@ func TestSessionPoolRacyGet(t *testing.T) {
 func TestSessionPoolPutInFull(t *testing.T) {
 	p := newClientWithStubBuilder(
 		t,
-		testutil.NewBalancer(testutil.WithInvokeHandlers(testutil.InvokeHandlers{
-			testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
-				return &Ydb_Table.CreateSessionResult{
-					SessionId: testutil.SessionID(),
-				}, nil
-			},
-		})),
+		stubBalancer(t, newStubCluster(t)),
 		1,
 		config.WithSizeLimit(1),
 		config.WithIdleThreshold(-1),
@@ -523,13 +486,7 @@ func TestSessionPoolSizeLimitOverflow(t *testing.T) {
 			)
 			p := newClientWithStubBuilder(
 				t,
-				testutil.NewBalancer(testutil.WithInvokeHandlers(testutil.InvokeHandlers{
-					testutil.TableCreateSession: func(interface{}) (result proto.Message, _ error) {
-						return &Ydb_Table.CreateSessionResult{
-							SessionId: testutil.SessionID(),
-						}, nil
-					},
-				})),
+				stubBalancer(t, newStubCluster(t)),
 				1,
 				config.WithSizeLimit(1),
 			)
@@ -602,11 +559,11 @@ func TestSessionPoolSizeLimitOverflow(t *testing.T) {
 
 func TestSessionPoolGetPut(t *testing.T) {
 	var (
-		created int
-		deleted int
+		cluster = newStubCluster(t)
+		node    = cluster.Node(0)
 	)
 	assertCreated := func(exp int) {
-		if act := created; act != exp {
+		if act := node.SessionsCreated(); act != exp {
 			t.Errorf(
 				"unexpected number of created sessions: %v; want %v",
 				act, exp,
@@ -614,7 +571,7 @@ func TestSessionPoolGetPut(t *testing.T) {
 		}
 	}
 	assertDeleted := func(exp int) {
-		if act := deleted; act != exp {
+		if act := node.Calls(Ydb_Table_V1.TableService_DeleteSession_FullMethodName); act != exp {
 			t.Errorf(
 				"unexpected number of deleted sessions: %v; want %v",
 				act, exp,
@@ -623,22 +580,7 @@ func TestSessionPoolGetPut(t *testing.T) {
 	}
 	p := newClientWithStubBuilder(
 		t,
-		testutil.NewBalancer(
-			testutil.WithInvokeHandlers(
-				testutil.InvokeHandlers{
-					testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
-						created++
-						return &Ydb_Table.CreateSessionResult{
-							SessionId: testutil.SessionID(),
-						}, nil
-					},
-					testutil.TableDeleteSession: func(interface{}) (proto.Message, error) {
-						deleted++
-						return nil, nil
-					},
-				},
-			),
-		),
+		stubBalancer(t, cluster),
 		0,
 		config.WithSizeLimit(1),
 	)
@@ -726,13 +668,7 @@ func TestSessionPoolCloseIdleSessions(t *testing.T) {
 func TestSessionPoolDoublePut(t *testing.T) {
 	p := newClientWithStubBuilder(
 		t,
-		testutil.NewBalancer(testutil.WithInvokeHandlers(testutil.InvokeHandlers{
-			testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
-				return &Ydb_Table.CreateSessionResult{
-					SessionId: testutil.SessionID(),
-				}, nil
-			},
-		})),
+		stubBalancer(t, newStubCluster(t)),
 		1,
 		config.WithSizeLimit(2),
 		config.WithIdleThreshold(-1),
@@ -787,50 +723,54 @@ var okHandler = func(interface{}) (proto.Message, error) {
 	return &emptypb.Empty{}, nil
 }
 
-var simpleCluster = testutil.NewBalancer(
-	testutil.WithInvokeHandlers(
-		testutil.InvokeHandlers{
-			testutil.TableExecuteDataQuery: func(interface{}) (proto.Message, error) {
-				return &Ydb_Table.ExecuteQueryResult{
-					TxMeta: &Ydb_Table.TransactionMeta{
-						Id: "",
-					},
-				}, nil
-			},
-			testutil.TableBeginTransaction: func(interface{}) (proto.Message, error) {
-				return &Ydb_Table.BeginTransactionResult{
-					TxMeta: &Ydb_Table.TransactionMeta{
-						Id: "",
-					},
-				}, nil
-			},
-			testutil.TableExplainDataQuery: func(interface{}) (proto.Message, error) {
-				return &Ydb_Table.ExecuteQueryResult{}, nil
-			},
-			testutil.TablePrepareDataQuery: func(interface{}) (proto.Message, error) {
-				return &Ydb_Table.PrepareQueryResult{}, nil
-			},
-			testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
-				return &Ydb_Table.CreateSessionResult{
-					SessionId: testutil.SessionID(),
-				}, nil
-			},
-			testutil.TableDeleteSession: func(interface{}) (proto.Message, error) {
-				return &Ydb_Table.DeleteSessionResponse{}, nil
-			},
-			testutil.TableCommitTransaction: func(interface{}) (proto.Message, error) {
-				return &Ydb_Table.CommitTransactionResponse{}, nil
-			},
-			testutil.TableRollbackTransaction: func(interface{}) (proto.Message, error) {
-				return &Ydb_Table.RollbackTransactionResponse{}, nil
-			},
-			testutil.TableKeepAlive: func(interface{}) (proto.Message, error) {
-				return &Ydb_Table.KeepAliveResult{}, nil
-			},
-		},
-	),
-)
+// stubBalancerConn is a balancer over connection to node of stub cluster
+type stubBalancerConn struct {
+	conn.Conn
+}
+
+func (b stubBalancerConn) HasNode(uint32) bool {
+	return true
+}
+
+// newStubCluster starts stub cluster which is stopped on cleanup of test
+func newStubCluster(t testing.TB, opts ...stub.Option) *stub.Cluster {
+	c := stub.New(opts...)
+	t.Cleanup(c.Close)
+	return c
+}
+
+// stubBalancer returns balancer over connection to first node of stub cluster
+func stubBalancer(t testing.TB, c *stub.Cluster) balancer {
+	b, release := dialStub(c)
+	t.Cleanup(release)
+	return b
+}
+
+func dialStub(c *stub.Cluster) (b balancer, release func()) {
+	pool := conn.NewPool(context.Background(), ydbConfig.New(
+		ydbConfig.WithGrpcOptions(c.DialOptions()...),
+	))
+	n := c.Node(0)
+	return stubBalancerConn{pool.Get(endpoint.New(n.Address(), endpoint.WithID(n.ID())))}, func() {
+		_ = pool.Release(context.Background())
+	}
+}
+
+// simpleCluster is a balancer over stub cluster which is shared between tests
+// (see simpleSession). Stub cluster is started and stopped by TestMain
+var simpleCluster balancer
+
+func TestMain(m *testing.M) {
+	c := stub.New()
+	b, release := dialStub(c)
+	simpleCluster = b
+	code := m.Run()
+	release()
+	c.Close()
+	os.Exit(code)
+}
 
+// simpleSession makes session on stub cluster which is shared between tests
 func simpleSession(t *testing.T) *session {
 	s, err := newSession(context.Background(), simpleCluster, config.New())
 	if err != nil {
@@ -839,6 +779,114 @@ func simpleSession(t *testing.T) *session {
 	return s
 }
 
+func TestClientDoOperationID(t *testing.T) {
+	ctx := context.Background()
+	c := newClientWithStubBuilder(t,
+		stubBalancer(t, newStubCluster(t)),
+		0,
+	)
+	defer func() {
+		_ = c.Close(ctx)
+	}()
+	var (
+		doIDs           []string
+		retryIDs        []string
+		attemptIDs      []string
+		errNotRetryable = errors.New("not retryable")
+	)
+	err := c.Do(ctx,
+		func(ctx context.Context, s table.Session) error {
+			attemptIDs = append(attemptIDs, retry.OperationID(ctx))
+			if len(attemptIDs) < 3 {
+				return retry.RetryableError(errors.New("retryable"))
+			}
+			return errNotRetryable
+		},
+		table.WithTrace(trace.Table{
+			OnDo: func(info trace.TableDoStartInfo) func(trace.TableDoIntermediateInfo) func(trace.TableDoDoneInfo) {
+				doIDs = append(doIDs, info.OperationID)
+				return func(info trace.TableDoIntermediateInfo) func(trace.TableDoDoneInfo) {
+					doIDs = append(doIDs, info.OperationID)
+					return func(info trace.TableDoDoneInfo) {
+						doIDs = append(doIDs, info.OperationID)
+					}
+				}
+			},
+		}),
+		table.WithRetryOptions([]retry.Option{
+			retry.WithTrace(&trace.Retry{
+				OnRetry: func(
+					info trace.RetryLoopStartInfo,
+				) func(trace.RetryLoopIntermediateInfo) func(trace.RetryLoopDoneInfo) {
+					retryIDs = append(retryIDs, info.OperationID)
+					return func(info trace.RetryLoopIntermediateInfo) func(trace.RetryLoopDoneInfo) {
+						retryIDs = append(retryIDs, info.OperationID)
+						return func(info trace.RetryLoopDoneInfo) {
+							retryIDs = append(retryIDs, info.OperationID)
+						}
+					}
+				},
+			}),
+		}),
+	)
+	require.ErrorIs(t, err, errNotRetryable)
+	var operationIDErr *retry.OperationIDError
+	require.ErrorAs(t, err, &operationIDErr)
+	require.Len(t, attemptIDs, 3)
+	// start, intermediate events of 3 attempts, final intermediate and done events
+	require.Len(t, doIDs, 6)
+	// start, 2 intermediate and done events (last attempt fails without intermediate event),
+	// retries of sessions creation inherit options and operation too
+	require.GreaterOrEqual(t, len(retryIDs), 4)
+	for _, ids := range [][]string{doIDs, retryIDs, attemptIDs} {
+		for _, id := range ids {
+			require.Equal(t, operationIDErr.OperationID, id)
+		}
+	}
+}
+
+func TestClientDoTxOperationID(t *testing.T) {
+	ctx := context.Background()
+	c := newClientWithStubBuilder(t,
+		stubBalancer(t, newStubCluster(t)),
+		0,
+	)
+	defer func() {
+		_ = c.Close(ctx)
+	}()
+	var (
+		ids             []string
+		errNotRetryable = errors.New("not retryable")
+	)
+	err := c.DoTx(ctx,
+		func(ctx context.Context, tx table.TransactionActor) error {
+			return errNotRetryable
+		},
+		table.WithTrace(trace.Table{
+			OnDoTx: func(
+				info trace.TableDoTxStartInfo,
+			) func(trace.TableDoTxIntermediateInfo) func(trace.TableDoTxDoneInfo) {
+				ids = append(ids, info.OperationID)
+				return func(info trace.TableDoTxIntermediateInfo) func(trace.TableDoTxDoneInfo) {
+					ids = append(ids, info.OperationID)
+					return func(info trace.TableDoTxDoneInfo) {
+						ids = append(ids, info.OperationID)
+					}
+				}
+			},
+		}),
+	)
+	require.ErrorIs(t, err, errNotRetryable)
+	var operationIDErr *retry.OperationIDError
+	require.ErrorAs(t, err, &operationIDErr)
+	require.NotEmpty(t, operationIDErr.OperationID)
+	// start, intermediate event of attempt, final intermediate and done events
+	require.Len(t, ids, 4)
+	for _, id := range ids {
+		require.Equal(t, operationIDErr.OperationID, id)
+	}
+}
+
 type StubBuilder struct {
 	OnCreateSession func(ctx context.Context) (*session, error)
 
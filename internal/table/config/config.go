@@ -15,6 +15,11 @@ const (
 	DefaultSessionPoolSizeLimit            = 50
 	DefaultSessionPoolIdleThreshold        = 5 * time.Minute
 
+	DefaultSessionLimitCooldown = 5 * time.Second
+
+	DefaultAdaptiveSizeTargetUtilization  = 0.7
+	DefaultAdaptiveSizeEvaluationInterval = time.Second
+
 	// Deprecated: table client do not supports background session keep-aliving now
 	DefaultKeepAliveMinSize = 10
 
@@ -55,6 +60,43 @@ func WithSizeLimit(sizeLimit int) Option {
 	}
 }
 
+// WithAdaptiveSize enables adaptive sizing of session pool
+//
+// With adaptive sizing limit of session pool starts from min and changes between min and max:
+// limit grows while session waiters are observed during several sequential evaluations and
+// shrinks (with deleting of excess idle sessions) while utilization of pool (busy sessions
+// to limit ratio) stays low. Evaluations are made every evaluationInterval.
+//
+// If min is less than or equal to zero then 1 is used as a min.
+// If max is less than min then max equals to min.
+// If targetUtilization is not in (0, 1] then DefaultAdaptiveSizeTargetUtilization is used.
+// If evaluationInterval is less than or equal to zero then DefaultAdaptiveSizeEvaluationInterval is used.
+//
+// WithAdaptiveSize overrides WithSizeLimit option (max is a hard limit of session pool).
+func WithAdaptiveSize(min, max int, targetUtilization float64, evaluationInterval time.Duration) Option {
+	return func(c *Config) {
+		if min <= 0 {
+			min = 1
+		}
+		if max < min {
+			max = min
+		}
+		if targetUtilization <= 0 || targetUtilization > 1 {
+			targetUtilization = DefaultAdaptiveSizeTargetUtilization
+		}
+		if evaluationInterval <= 0 {
+			evaluationInterval = DefaultAdaptiveSizeEvaluationInterval
+		}
+		c.sizeLimit = max
+		c.adaptiveSize = &AdaptiveSize{
+			Min:                min,
+			Max:                max,
+			TargetUtilization:  targetUtilization,
+			EvaluationInterval: evaluationInterval,
+		}
+	}
+}
+
 // WithKeepAliveMinSize defines lower bound for sessions in the pool. If there are more sessions open, then
 // the excess idle ones will be closed and removed after IdleKeepAliveThreshold is reached for each of them.
 // If keepAliveMinSize is less than zero, then no sessions will be preserved
@@ -111,6 +153,41 @@ func WithCreateSessionTimeout(createSessionTimeout time.Duration) Option {
 	}
 }
 
+// WithSingleSession enables single session mode of table client
+//
+// In single session mode table client does not keep a pool of sessions: it lazily creates
+// exactly one session on first request and reuses it in all operations (concurrent operations
+// wait for each other). Session is recreated if it was invalidated (for example, with BAD_SESSION)
+// and deleted on closing of table client with DeleteTimeout. Table client does not start
+// any background goroutines in this mode, which is useful for short-living CLI tools.
+//
+// WithSingleSession overrides WithSizeLimit and WithAdaptiveSize options.
+func WithSingleSession() Option {
+	return func(c *Config) {
+		c.singleSession = true
+	}
+}
+
+// WithSessionLimitCooldown defines duration of suspending of sessions creation after
+// exceeding of server limit of sessions.
+// If cooldown is less than or equal to zero then the DefaultSessionLimitCooldown is used.
+func WithSessionLimitCooldown(cooldown time.Duration) Option {
+	return func(c *Config) {
+		if cooldown > 0 {
+			c.sessionLimitCooldown = cooldown
+		}
+	}
+}
+
+// WithSessionLimitMatcher defines matcher of errors of exceeding of server limit of sessions.
+// Matcher is called for create session errors with OVERLOADED status only.
+// If matcher is nil then errors are matched by issue message which contains "too many sessions".
+func WithSessionLimitMatcher(matcher func(err error) bool) Option {
+	return func(c *Config) {
+		c.sessionLimitMatcher = matcher
+	}
+}
+
 // WithDeleteTimeout limits maximum time spent on Delete request
 // If deleteTimeout is less than or equal to zero then the DefaultSessionPoolDeleteTimeout is used.
 func WithDeleteTimeout(deleteTimeout time.Duration) Option {
@@ -135,28 +212,110 @@ func WithIgnoreTruncated() Option {
 	}
 }
 
+// WithStrictTypes makes results with columns of unknown primitive types (for example, types
+// of newer version of YDB) broken. By default, values of unknown types are decoded as opaque values
+func WithStrictTypes() Option {
+	return func(c *Config) {
+		c.strictTypes = true
+	}
+}
+
+// WithQueryParametersCheck enables client-side check of parameters of data queries against
+// parameters of query text (DECLARE statements or references of parameters). Queries with
+// mismatched parameters fail before sending to YDB
+func WithQueryParametersCheck() Option {
+	return func(c *Config) {
+		c.queryParametersCheck = true
+	}
+}
+
+// WithKeepInCache defines default keep-in-cache flag of query cache policy of data queries.
+// By default, keep-in-cache flag is enabled only for queries with parameters.
+// Per-call option options.WithKeepInCache overrides this default
+func WithKeepInCache(keepInCache bool) Option {
+	return func(c *Config) {
+		c.keepInCache = &keepInCache
+	}
+}
+
+// WithResultSizeWarning defines thresholds of rows count and bytes size of data query
+// result above which trace.Table.OnSessionQueryResultSizeWarning event fires.
+// Zero threshold is disabled.
+func WithResultSizeWarning(rows, bytes int) Option {
+	return func(c *Config) {
+		c.resultSizeWarning = ResultSize{Rows: rows, Bytes: bytes}
+	}
+}
+
+// WithResultSizeLimit defines hard limits of rows count and bytes size of data query
+// result above which decoding of result is aborted with result.ErrResultTooLarge.
+// Zero limit is disabled.
+func WithResultSizeLimit(rows, bytes int) Option {
+	return func(c *Config) {
+		c.resultSizeLimit = ResultSize{Rows: rows, Bytes: bytes}
+	}
+}
+
 // WithClock replaces default clock
 func WithClock(clock clockwork.Clock) Option {
 	return func(c *Config) {
-		c.clock = clock
+		config.SetClock(&c.Common, clock)
 	}
 }
 
+// AdaptiveSize is a configuration of adaptive sizing of session pool
+type AdaptiveSize struct {
+	// Min is a lower bound of session pool limit
+	Min int
+
+	// Max is a hard upper bound of session pool limit
+	Max int
+
+	// TargetUtilization is a desired ratio of busy sessions to session pool limit
+	TargetUtilization float64
+
+	// EvaluationInterval is an interval between evaluations of session pool limit
+	EvaluationInterval time.Duration
+}
+
+// ResultSize is a threshold of data query result size. Zero fields are disabled
+type ResultSize struct {
+	// Rows is a count of rows in all result sets
+	Rows int
+
+	// Bytes is a size of encoded result
+	Bytes int
+}
+
+// Exceeded checks that result with given count of rows and size exceeds threshold
+func (s ResultSize) Exceeded(rows, bytes int) bool {
+	return (s.Rows > 0 && rows > s.Rows) || (s.Bytes > 0 && bytes > s.Bytes)
+}
+
 // Config is a configuration of table client
 type Config struct {
 	config.Common
 
-	sizeLimit int
+	sizeLimit     int
+	adaptiveSize  *AdaptiveSize
+	singleSession bool
 
 	createSessionTimeout time.Duration
 	deleteTimeout        time.Duration
 	idleThreshold        time.Duration
+	sessionLimitCooldown time.Duration
+	sessionLimitMatcher  func(err error) bool
 
-	ignoreTruncated bool
+	ignoreTruncated      bool
+	strictTypes          bool
+	queryParametersCheck bool
 
-	trace *trace.Table
+	keepInCache *bool
+
+	resultSizeWarning ResultSize
+	resultSizeLimit   ResultSize
 
-	clock clockwork.Clock
+	trace *trace.Table
 }
 
 // Trace defines trace over table client calls
@@ -164,18 +323,30 @@ func (c *Config) Trace() *trace.Table {
 	return c.trace
 }
 
-// Clock defines clock
-func (c *Config) Clock() clockwork.Clock {
-	return c.clock
-}
-
 // SizeLimit is an upper bound of pooled sessions.
 // If SizeLimit is less than or equal to zero then the
 // DefaultSessionPoolSizeLimit variable is used as a limit.
 func (c *Config) SizeLimit() int {
+	if c.singleSession {
+		return 1
+	}
 	return c.sizeLimit
 }
 
+// SingleSession reports whether single session mode of table client is enabled
+func (c *Config) SingleSession() bool {
+	return c.singleSession
+}
+
+// AdaptiveSize returns configuration of adaptive sizing of session pool
+// or nil if adaptive sizing is disabled
+func (c *Config) AdaptiveSize() *AdaptiveSize {
+	if c.singleSession {
+		return nil
+	}
+	return c.adaptiveSize
+}
+
 // KeepAliveMinSize is a lower bound for sessions in the pool. If there are more sessions open, then
 // the excess idle ones will be closed and removed after IdleKeepAliveThreshold is reached for each of them.
 // If KeepAliveMinSize is less than zero, then no sessions will be preserved
@@ -191,6 +362,35 @@ func (c *Config) IgnoreTruncated() bool {
 	return c.ignoreTruncated
 }
 
+// StrictTypes reports whether results with columns of unknown primitive types are broken
+func (c *Config) StrictTypes() bool {
+	return c.strictTypes
+}
+
+// QueryParametersCheck reports whether parameters of data queries are checked against query text
+func (c *Config) QueryParametersCheck() bool {
+	return c.queryParametersCheck
+}
+
+// KeepInCache returns default keep-in-cache flag of data queries.
+// ok is false if default keep-in-cache flag is not defined
+func (c *Config) KeepInCache() (keepInCache, ok bool) {
+	if c.keepInCache == nil {
+		return false, false
+	}
+	return *c.keepInCache, true
+}
+
+// ResultSizeWarning returns warning thresholds of data query result size
+func (c *Config) ResultSizeWarning() ResultSize {
+	return c.resultSizeWarning
+}
+
+// ResultSizeLimit returns hard limits of data query result size
+func (c *Config) ResultSizeLimit() ResultSize {
+	return c.resultSizeLimit
+}
+
 // IdleKeepAliveThreshold is a number of keepAlive messages to call before the
 // session is removed if it is an excess session (see KeepAliveMinSize)
 // This means that session will be deleted after the expiration of lifetime = IdleThreshold * IdleKeepAliveThreshold
@@ -225,6 +425,18 @@ func (c *Config) CreateSessionTimeout() time.Duration {
 	return c.createSessionTimeout
 }
 
+// SessionLimitCooldown returns duration of suspending of sessions creation after
+// exceeding of server limit of sessions
+func (c *Config) SessionLimitCooldown() time.Duration {
+	return c.sessionLimitCooldown
+}
+
+// SessionLimitMatcher returns matcher of errors of exceeding of server limit of sessions
+// (nil if errors are matched by default matcher of table client which checks issue message)
+func (c *Config) SessionLimitMatcher() func(err error) bool {
+	return c.sessionLimitMatcher
+}
+
 // DeleteTimeout limits maximum time spent on Delete request
 //
 // If DeleteTimeout is less than or equal to zero then the DefaultSessionPoolDeleteTimeout is used.
@@ -238,7 +450,7 @@ func defaults() *Config {
 		createSessionTimeout: DefaultSessionPoolCreateSessionTimeout,
 		deleteTimeout:        DefaultSessionPoolDeleteTimeout,
 		idleThreshold:        DefaultSessionPoolIdleThreshold,
-		clock:                clockwork.NewRealClock(),
+		sessionLimitCooldown: DefaultSessionLimitCooldown,
 		trace:                &trace.Table{},
 	}
 }
@@ -4,8 +4,19 @@ import (
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 )
 
+// keepInCache returns default keep-in-cache flag of data query with params.
+// Without defined in config default keep-in-cache flag enabled only for queries with params
+func keepInCache(c *config.Config, params *table.QueryParameters) bool {
+	if keepInCache, ok := c.KeepInCache(); ok {
+		return keepInCache
+	}
+	return params.Count() > 0
+}
+
 type (
 	query interface {
 		String() string
@@ -37,6 +37,18 @@ var (
 
 	// errParamsRequired returned by a Client instance to indicate that required params is not defined
 	errParamsRequired = xerrors.Wrap(errors.New("params required"))
+
+	// errUnknownParameter returned by a prepared statement to indicate that
+	// query parameter is not declared in prepared query
+	errUnknownParameter = xerrors.Wrap(errors.New("unknown parameter"))
+
+	// errQueryParameters returned by a session to indicate that parameters of data query
+	// mismatch parameters of query text (see config.WithQueryParametersCheck)
+	errQueryParameters = xerrors.Wrap(errors.New("query parameters mismatch"))
+
+	// errReadTableCheckpoint returned by a session to indicate that
+	// read table request cannot be resumed from checkpoint
+	errReadTableCheckpoint = xerrors.Wrap(errors.New("read table checkpoint"))
 )
 
 func isCreateSessionErrorRetriable(err error) bool {
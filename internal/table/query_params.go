@@ -0,0 +1,220 @@
+package table
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xstring"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// queryParams are names of parameters of query text (with '$' prefix)
+type queryParams struct {
+	// declared are names of parameters of DECLARE statements
+	declared map[string]struct{}
+	// optional are names of declared parameters with optional types (which may be not passed)
+	optional map[string]struct{}
+	// referenced are names of all $identifier tokens (parameters, named expressions,
+	// arguments of lambdas and so on)
+	referenced map[string]struct{}
+}
+
+// scanQueryParams scans query text for DECLARE statements and $identifier tokens.
+// String literals, identifiers in backticks, comments and @@ blocks are skipped
+func scanQueryParams(query string) queryParams {
+	var (
+		params = queryParams{
+			declared:   make(map[string]struct{}),
+			optional:   make(map[string]struct{}),
+			referenced: make(map[string]struct{}),
+		}
+		// declare is true after DECLARE keyword until the next token
+		declare bool
+	)
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			i = skipQuoted(query, i)
+			declare = false
+		case strings.HasPrefix(query[i:], "--"):
+			i = skipUntil(query, i+2, "\n")
+		case strings.HasPrefix(query[i:], "/*"):
+			i = skipUntil(query, i+2, "*/")
+		case strings.HasPrefix(query[i:], "@@"):
+			i = skipAtAt(query, i)
+			declare = false
+		case c == '$' && i+1 < len(query) && isIdentifierStart(query[i+1]):
+			end := scanIdentifier(query, i+1)
+			params.referenced[query[i:end]] = struct{}{}
+			if declare {
+				params.declared[query[i:end]] = struct{}{}
+				if isOptionalType(declaredType(query, end)) {
+					params.optional[query[i:end]] = struct{}{}
+				}
+			}
+			i = end
+			declare = false
+		case isIdentifierStart(c):
+			end := scanIdentifier(query, i)
+			declare = strings.EqualFold(query[i:end], "DECLARE")
+			i = end
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		default:
+			i++
+			declare = false
+		}
+	}
+	return params
+}
+
+// declaredType returns text of type of DECLARE statement with parameter which ends at position i
+// (text between AS keyword and semicolon)
+func declaredType(query string, i int) string {
+	typ := strings.TrimSpace(query[i:skipUntil(query, i, ";")])
+	typ = strings.TrimSuffix(typ, ";")
+	if len(typ) < 2 || !strings.EqualFold(typ[:2], "AS") {
+		return ""
+	}
+	return strings.TrimSpace(typ[2:])
+}
+
+// isOptionalType checks text of type for Optional<T> or T? forms
+func isOptionalType(typ string) bool {
+	const optional = "optional<"
+	return strings.HasSuffix(typ, "?") ||
+		(len(typ) > len(optional) && strings.EqualFold(typ[:len(optional)], optional))
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// scanIdentifier returns end of identifier which starts at position i
+func scanIdentifier(query string, i int) int {
+	for i < len(query) && (isIdentifierStart(query[i]) || (query[i] >= '0' && query[i] <= '9')) {
+		i++
+	}
+	return i
+}
+
+// skipQuoted returns end of string literal or quoted identifier which starts at position i.
+// Quote is escaped with backslash or with doubled quote
+func skipQuoted(query string, i int) int {
+	quote := query[i]
+	for i++; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			i++
+		case quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+// skipUntil returns position after end which is found from position i
+func skipUntil(query string, i int, end string) int {
+	if n := strings.Index(query[i:], end); n >= 0 {
+		return i + n + len(end)
+	}
+	return len(query)
+}
+
+// skipAtAt returns end of @@ block which starts at position i ('@@@@' is an escaped '@@' in block)
+func skipAtAt(query string, i int) int {
+	for i += 2; i < len(query); {
+		n := strings.Index(query[i:], "@@")
+		if n < 0 {
+			return len(query)
+		}
+		i += n
+		if strings.HasPrefix(query[i:], "@@@@") {
+			i += 4
+			continue
+		}
+		return i + 2
+	}
+	return len(query)
+}
+
+// checkQueryParams compares params with parameters of query text.
+//
+// If query declares parameters, params must be exactly declared parameters (parameters with
+// optional types may be not passed). Otherwise
+// (for example, with automatic declaring of parameters) each of params must be referenced
+// in query. Error lists all mismatched parameters with suggestions of close names
+func checkQueryParams(query string, params *table.QueryParameters) error {
+	var (
+		scanned  = scanQueryParams(query)
+		provided = make(map[string]struct{}, params.Count())
+		problems []string
+	)
+	params.Each(func(name string, _ types.Value) {
+		provided[name] = struct{}{}
+	})
+	if len(scanned.declared) > 0 {
+		missing := difference(scanned.declared, provided)
+		for _, name := range difference(provided, scanned.declared) {
+			problems = append(problems, "not declared "+withSuggestion(name, missing))
+		}
+		for _, name := range missing {
+			if _, optional := scanned.optional[name]; !optional {
+				problems = append(problems, fmt.Sprintf("not passed '%s'", name))
+			}
+		}
+	} else {
+		candidates := difference(scanned.referenced, provided)
+		for _, name := range difference(provided, scanned.referenced) {
+			problems = append(problems, "not used "+withSuggestion(name, candidates))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return xerrors.WithStackTrace(fmt.Errorf("%w: %s", errQueryParameters, strings.Join(problems, ", ")))
+}
+
+// difference returns sorted names of a which are absent in b
+func difference(a, b map[string]struct{}) (names []string) {
+	for name := range a {
+		if _, has := b[name]; !has {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// maxParamSuggestionDistance is a max edit distance between unknown and declared
+// parameter names for suggestion of declared parameter name
+const maxParamSuggestionDistance = 3
+
+// suggestParam returns the closest to name of candidates names (the least of names
+// with the same distance) or empty string if all of candidates are too far from name
+func suggestParam(name string, candidates []string) (suggestion string) {
+	best := maxParamSuggestionDistance + 1
+	for _, candidate := range candidates {
+		d := xstring.EditDistance(name, candidate)
+		if d < best || (d == best && candidate < suggestion) {
+			best, suggestion = d, candidate
+		}
+	}
+	return suggestion
+}
+
+// withSuggestion returns quoted name with the closest of candidates names
+func withSuggestion(name string, candidates []string) string {
+	if suggestion := suggestParam(name, candidates); suggestion != "" {
+		return fmt.Sprintf("'%s' (did you mean '%s'?)", name, suggestion)
+	}
+	return "'" + name + "'"
+}
@@ -0,0 +1,384 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"github.com/ydb-platform/ydb-go-sdk/v3/testutil"
+)
+
+func TestScanQueryParams(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		query      string
+		declared   []string
+		optional   []string
+		referenced []string
+	}{
+		{
+			name:       "Declare",
+			query:      "DECLARE $id AS Uint64;\nSELECT * FROM t WHERE id = $id;",
+			declared:   []string{"$id"},
+			referenced: []string{"$id"},
+		},
+		{
+			name:       "DeclareCaseInsensitive",
+			query:      "declare $a as Int32; Declare\n\t$b AS Int32; SELECT $a + $b;",
+			declared:   []string{"$a", "$b"},
+			referenced: []string{"$a", "$b"},
+		},
+		{
+			name:       "DeclareWithComment",
+			query:      "DECLARE /* id of user */ $id AS Uint64; SELECT $id;",
+			declared:   []string{"$id"},
+			referenced: []string{"$id"},
+		},
+		{
+			name: "DeclareOptional",
+			query: "DECLARE $a AS Optional<List<Int32>>; DECLARE $b AS Utf8?; DECLARE $c AS optional<Int32>;\n" +
+				"DECLARE $d AS List<Int32?>; DECLARE $e AS Int32; SELECT $a, $b, $c, $d, $e;",
+			declared:   []string{"$a", "$b", "$c", "$d", "$e"},
+			optional:   []string{"$a", "$b", "$c"},
+			referenced: []string{"$a", "$b", "$c", "$d", "$e"},
+		},
+		{
+			name:       "NamedExpression",
+			query:      "DECLARE $id AS Uint64; $users = SELECT * FROM users WHERE id = $id; SELECT * FROM $users;",
+			declared:   []string{"$id"},
+			referenced: []string{"$id", "$users"},
+		},
+		{
+			name:       "SingleQuotedString",
+			query:      `SELECT '$a', 'it''s $b', 'escaped \' $c', $d;`,
+			referenced: []string{"$d"},
+		},
+		{
+			name:       "DoubleQuotedString",
+			query:      `SELECT "$a", "say \"$b\"", $c;`,
+			referenced: []string{"$c"},
+		},
+		{
+			name:       "BacktickIdentifier",
+			query:      "SELECT `$a`, `weird``$b` FROM `dir/$c` WHERE x = $d;",
+			referenced: []string{"$d"},
+		},
+		{
+			name:       "LineComment",
+			query:      "-- DECLARE $a AS Int32;\nSELECT $b; -- $c",
+			referenced: []string{"$b"},
+		},
+		{
+			name:       "BlockComment",
+			query:      "/* DECLARE $a AS Int32;\n $b */ SELECT $c; /* unterminated $d",
+			referenced: []string{"$c"},
+		},
+		{
+			name:       "AtAtBlock",
+			query:      "SELECT @@multiline $a\n@@@@ $b@@, $c, @@$d@@;",
+			referenced: []string{"$c"},
+		},
+		{
+			name:       "DeclareInString",
+			query:      "SELECT 'DECLARE $a AS Int32;'; DECLARE $b AS Int32; SELECT $b;",
+			declared:   []string{"$b"},
+			referenced: []string{"$b"},
+		},
+		{
+			name:       "DeclareKeywordPrefix",
+			query:      "SELECT declared $a, x_DECLARE $b;",
+			referenced: []string{"$a", "$b"},
+		},
+		{
+			name:       "NotIdentifier",
+			query:      "SELECT $, $1, $_a1, $a$b;",
+			referenced: []string{"$_a1", "$a", "$b"},
+		},
+		{
+			name:       "Unicode",
+			query:      "SELECT 'привет $a', $b; -- комментарий $c",
+			referenced: []string{"$b"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := scanQueryParams(tt.query)
+			require.Equal(t, tt.declared, sortedNames(params.declared))
+			require.Equal(t, tt.optional, sortedNames(params.optional))
+			require.Equal(t, tt.referenced, sortedNames(params.referenced))
+		})
+	}
+}
+
+func TestCheckQueryParams(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		query  string
+		params *table.QueryParameters
+		err    string
+	}{
+		{
+			name:   "Declared",
+			query:  "DECLARE $user_id AS Uint64; SELECT $user_id;",
+			params: table.NewQueryParameters(table.ValueParam("$user_id", types.Uint64Value(1))),
+		},
+		{
+			name:  "Misspelled",
+			query: "DECLARE $user_id AS Uint64; SELECT $user_id;",
+			params: table.NewQueryParameters(
+				table.ValueParam("$userId", types.Uint64Value(1)),
+			),
+			err: "query parameters mismatch: not declared '$userId' (did you mean '$user_id'?), not passed '$user_id'",
+		},
+		{
+			name:  "UndeclaredAndMissing",
+			query: "DECLARE $a AS Int32; DECLARE $b AS Int32; SELECT $a, $b;",
+			params: table.NewQueryParameters(
+				table.ValueParam("$a", types.Int32Value(1)),
+				table.ValueParam("$something_else", types.Int32Value(1)),
+			),
+			err: "query parameters mismatch: not declared '$something_else', not passed '$b'",
+		},
+		{
+			name:  "OptionalNotPassed",
+			query: "DECLARE $a AS Int32; DECLARE $b AS Optional<Int32>; DECLARE $c AS Int32?; SELECT $a, $b, $c;",
+			params: table.NewQueryParameters(
+				table.ValueParam("$a", types.Int32Value(1)),
+			),
+		},
+		{
+			name:  "OptionalMisspelled",
+			query: "DECLARE $user_id AS Optional<Uint64>; SELECT $user_id;",
+			params: table.NewQueryParameters(
+				table.ValueParam("$userId", types.Uint64Value(1)),
+			),
+			err: "query parameters mismatch: not declared '$userId' (did you mean '$user_id'?)",
+		},
+		{
+			name:   "NoParams",
+			query:  "DECLARE $a AS Int32; SELECT $a;",
+			params: nil,
+			err:    "query parameters mismatch: not passed '$a'",
+		},
+		{
+			name:   "WithoutDeclare",
+			query:  "SELECT * FROM t WHERE id = $id;",
+			params: table.NewQueryParameters(table.ValueParam("$id", types.Int32Value(1))),
+		},
+		{
+			name:   "WithoutDeclareUnused",
+			query:  "SELECT * FROM t WHERE id = $id; -- $ids",
+			params: table.NewQueryParameters(table.ValueParam("$ids", types.Int32Value(1))),
+			err:    "query parameters mismatch: not used '$ids' (did you mean '$id'?)",
+		},
+		{
+			name:  "DeclaredInComment",
+			query: "/* DECLARE $a AS Int32; */ SELECT 1;",
+			params: table.NewQueryParameters(
+				table.ValueParam("$a", types.Int32Value(1)),
+			),
+			err: "query parameters mismatch: not used '$a'",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkQueryParams(tt.query, tt.params)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, errQueryParameters)
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSessionExecuteQueryParametersCheck(t *testing.T) {
+	var executes int
+	b := testutil.NewBalancer(
+		testutil.WithInvokeHandlers(
+			testutil.InvokeHandlers{
+				testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+					return &Ydb_Table.CreateSessionResult{
+						SessionId: testutil.SessionID(),
+					}, nil
+				},
+				testutil.TableExecuteDataQuery: func(interface{}) (proto.Message, error) {
+					executes++
+					return &Ydb_Table.ExecuteQueryResult{
+						TxMeta: &Ydb_Table.TransactionMeta{Id: "tx"},
+					}, nil
+				},
+			},
+		),
+	)
+	var (
+		query  = "DECLARE $user_id AS Uint64; SELECT $user_id;"
+		params = table.NewQueryParameters(table.ValueParam("$userId", types.Uint64Value(1)))
+	)
+	t.Run("Disabled", func(t *testing.T) {
+		s, err := newSession(context.Background(), b, config.New())
+		require.NoError(t, err)
+		_, _, err = s.Execute(context.Background(), table.DefaultTxControl(), query, params)
+		require.NoError(t, err)
+		require.Equal(t, 1, executes)
+	})
+	t.Run("Driver", func(t *testing.T) {
+		s, err := newSession(context.Background(), b, config.New(config.WithQueryParametersCheck()))
+		require.NoError(t, err)
+		_, _, err = s.Execute(context.Background(), table.DefaultTxControl(), query, params)
+		require.ErrorIs(t, err, errQueryParameters)
+		require.Equal(t, 1, executes)
+	})
+	t.Run("Call", func(t *testing.T) {
+		s, err := newSession(context.Background(), b, config.New())
+		require.NoError(t, err)
+		_, _, err = s.Execute(context.Background(), table.DefaultTxControl(), query, params,
+			options.WithQueryParametersCheck(),
+		)
+		require.ErrorIs(t, err, errQueryParameters)
+		require.Equal(t, 1, executes)
+	})
+}
+
+func TestSuggestParam(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		candidates []string
+		exp        string
+	}{
+		{
+			name:       "$userId",
+			candidates: []string{"$users", "$user_id"},
+			exp:        "$user_id",
+		},
+		{
+			name:       "$ab",
+			candidates: []string{"$b", "$a"},
+			exp:        "$a",
+		},
+		{
+			name:       "$something_else",
+			candidates: []string{"$b"},
+			exp:        "",
+		},
+		{
+			name: "$a",
+			exp:  "",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.exp, suggestParam(tt.name, tt.candidates))
+		})
+	}
+}
+
+func sortedNames(names map[string]struct{}) (sorted []string) {
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func TestSessionExecuteRetriedParametersSerializedOnce(t *testing.T) {
+	var (
+		parameters []*Ydb.TypedValue
+		values     []uint64
+	)
+	b := testutil.NewBalancer(
+		testutil.WithInvokeHandlers(
+			testutil.InvokeHandlers{
+				testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+					return &Ydb_Table.CreateSessionResult{
+						SessionId: testutil.SessionID(),
+					}, nil
+				},
+				testutil.TableExecuteDataQuery: func(request interface{}) (proto.Message, error) {
+					r, ok := request.(*Ydb_Table.ExecuteDataQueryRequest)
+					require.True(t, ok)
+					parameters = append(parameters, r.GetParameters()["$a"])
+					values = append(values, r.GetParameters()["$a"].GetValue().GetUint64Value())
+					return &Ydb_Table.ExecuteQueryResult{}, nil
+				},
+			},
+		),
+	)
+	s, err := newSession(context.Background(), b, config.New())
+	require.NoError(t, err)
+	params := table.NewQueryParameters(table.ValueParam("$a", types.Uint64Value(1)))
+	execute := func(ctx context.Context) error {
+		_, _, err := s.Execute(ctx, table.DefaultTxControl(), "SELECT $a;", params)
+		return err
+	}
+
+	attempts := 0
+	err = retry.Retry(context.Background(), func(ctx context.Context) error {
+		attempts++
+		if err := execute(ctx); err != nil {
+			return err
+		}
+		if attempts < 3 {
+			return retry.RetryableError(errors.New("retry"), retry.WithBackoff(retry.TypeNoBackoff))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, parameters, 3)
+	require.Same(t, parameters[0], parameters[1])
+	require.Same(t, parameters[0], parameters[2])
+
+	// parameters are serialized with allocator of call outside of retry loop
+	require.NoError(t, execute(context.Background()))
+	require.Equal(t, []uint64{1, 1, 1, 1}, values)
+}
+
+// BenchmarkSessionExecuteRetried measures retried Execute (3 attempts) with large parameters
+func BenchmarkSessionExecuteRetried(b *testing.B) {
+	balancer := testutil.NewBalancer(
+		testutil.WithInvokeHandlers(
+			testutil.InvokeHandlers{
+				testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+					return &Ydb_Table.CreateSessionResult{
+						SessionId: testutil.SessionID(),
+					}, nil
+				},
+				testutil.TableExecuteDataQuery: func(interface{}) (proto.Message, error) {
+					return &Ydb_Table.ExecuteQueryResult{}, nil
+				},
+			},
+		),
+	)
+	s, err := newSession(context.Background(), balancer, config.New())
+	require.NoError(b, err)
+	items := make([]types.Value, 0, 1<<14)
+	for i := 0; i < cap(items); i++ {
+		items = append(items, types.BytesValue(make([]byte, 160)))
+	}
+	params := table.NewQueryParameters(table.ValueParam("$list", types.ListValue(items...)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		attempts := 0
+		err := retry.Retry(context.Background(), func(ctx context.Context) error {
+			attempts++
+			if _, _, err := s.Execute(ctx, table.DefaultTxControl(), "SELECT 1;", params); err != nil {
+				return err
+			}
+			if attempts < 3 {
+				return retry.RetryableError(errors.New("retry"), retry.WithBackoff(retry.TypeNoBackoff))
+			}
+			return nil
+		})
+		require.NoError(b, err)
+	}
+}
@@ -0,0 +1,98 @@
+package table
+
+import (
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/inflight"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+var resultSetsFieldNumber = (&Ydb_Table.ExecuteQueryResult{}).ProtoReflect().Descriptor().
+	Fields().ByName("result_sets").Number()
+
+// splitQueryResult decodes encoded result of data query into dst except of result sets
+// and returns encoded result sets (sub-slices of data) for decoding on demand
+func splitQueryResult(data []byte, dst *Ydb_Table.ExecuteQueryResult) (sets [][]byte, _ error) {
+	var other []byte
+	sets = [][]byte{}
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, xerrors.WithStackTrace(protowire.ParseError(n))
+		}
+		m := protowire.ConsumeFieldValue(num, typ, data[n:])
+		if m < 0 {
+			return nil, xerrors.WithStackTrace(protowire.ParseError(m))
+		}
+		if num != resultSetsFieldNumber || typ != protowire.BytesType {
+			other = append(other, data[:n+m]...)
+			data = data[n+m:]
+			continue
+		}
+		b, _ := protowire.ConsumeBytes(data[n:])
+		sets = append(sets, b)
+		data = data[n+m:]
+	}
+	if err := proto.Unmarshal(other, dst); err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return sets, nil
+}
+
+// unmarshalQueryResult decodes encoded result of data query into dst with checking of
+// result size limit. Result sets are decoded one by one, so decoding is aborted
+// as soon as limit is exceeded (without decoding of remaining result sets).
+// unmarshalQueryResult returns count of rows in result.
+func unmarshalQueryResult(data []byte, dst *Ydb_Table.ExecuteQueryResult, limit config.ResultSize) (rows int, _ error) {
+	if limit.Exceeded(0, len(data)) {
+		return 0, xerrors.WithStackTrace(fmt.Errorf("%w: %d bytes (limit %d bytes)",
+			result.ErrResultTooLarge, len(data), limit.Bytes,
+		))
+	}
+	encoded, err := splitQueryResult(data, dst)
+	if err != nil {
+		return 0, xerrors.WithStackTrace(err)
+	}
+	sets := make([]*Ydb.ResultSet, 0, len(encoded))
+	for _, b := range encoded {
+		set := &Ydb.ResultSet{}
+		if err := proto.Unmarshal(b, set); err != nil {
+			return rows, xerrors.WithStackTrace(err)
+		}
+		rows += len(set.GetRows())
+		if limit.Exceeded(rows, 0) {
+			return rows, xerrors.WithStackTrace(fmt.Errorf("%w: more than %d rows (limit %d rows)",
+				result.ErrResultTooLarge, rows, limit.Rows,
+			))
+		}
+		sets = append(sets, set)
+	}
+	dst.ResultSets = sets
+	return rows, nil
+}
+
+// checkedQueryResult decodes result of data query with checking of result size thresholds
+func (s *session) checkedQueryResult(
+	data []byte, query *Ydb_Table.Query, dst *Ydb_Table.ExecuteQueryResult,
+) error {
+	rows, err := unmarshalQueryResult(data, dst, s.config.ResultSizeLimit())
+	if err != nil {
+		return xerrors.WithStackTrace(err)
+	}
+	if s.config.ResultSizeWarning().Exceeded(rows, len(data)) {
+		q := query.GetYqlText()
+		if q == "" {
+			q = query.GetId()
+		}
+		trace.TableOnSessionQueryResultSizeWarning(s.config.Trace(), s, inflight.Digest(q), rows, len(data))
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package table
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/inflight"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/scanner"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/testutil"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+// largeQueryResult returns result of data query with sets of result sets with rows of rows
+func largeQueryResult(sets, rows int) *Ydb_Table.ExecuteQueryResult {
+	res := &Ydb_Table.ExecuteQueryResult{
+		TxMeta: &Ydb_Table.TransactionMeta{Id: "tx"},
+	}
+	for i := 0; i < sets; i++ {
+		set := &Ydb.ResultSet{
+			Columns: []*Ydb.Column{{
+				Name: "value",
+				Type: &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_UTF8}},
+			}},
+		}
+		for j := 0; j < rows; j++ {
+			set.Rows = append(set.Rows, &Ydb.Value{
+				Items: []*Ydb.Value{{
+					Value: &Ydb.Value_TextValue{TextValue: "row of large result set"},
+				}},
+			})
+		}
+		res.ResultSets = append(res.ResultSets, set)
+	}
+	return res
+}
+
+func TestUnmarshalQueryResult(t *testing.T) {
+	data, err := proto.Marshal(largeQueryResult(3, 100))
+	require.NoError(t, err)
+
+	t.Run("Unlimited", func(t *testing.T) {
+		var dst Ydb_Table.ExecuteQueryResult
+		rows, err := unmarshalQueryResult(data, &dst, config.ResultSize{})
+		require.NoError(t, err)
+		require.Equal(t, 300, rows)
+		require.Len(t, dst.GetResultSets(), 3)
+		require.Equal(t, "tx", dst.GetTxMeta().GetId())
+		require.True(t, proto.Equal(largeQueryResult(3, 100), &dst))
+	})
+
+	t.Run("RowsLimit", func(t *testing.T) {
+		var dst Ydb_Table.ExecuteQueryResult
+		rows, err := unmarshalQueryResult(data, &dst, config.ResultSize{Rows: 150})
+		require.ErrorIs(t, err, result.ErrResultTooLarge)
+		// decoding aborted after second result set, third result set is not decoded
+		require.Equal(t, 200, rows)
+		require.Empty(t, dst.GetResultSets())
+	})
+
+	t.Run("BytesLimit", func(t *testing.T) {
+		var dst Ydb_Table.ExecuteQueryResult
+		rows, err := unmarshalQueryResult(data, &dst, config.ResultSize{Bytes: len(data) / 2})
+		require.ErrorIs(t, err, result.ErrResultTooLarge)
+		// nothing decoded
+		require.Zero(t, rows)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		var dst Ydb_Table.ExecuteQueryResult
+		_, err := unmarshalQueryResult(data[:len(data)-1], &dst, config.ResultSize{Rows: 1000})
+		require.Error(t, err)
+		require.NotErrorIs(t, err, result.ErrResultTooLarge)
+	})
+}
+
+func TestSessionExecuteResultSize(t *testing.T) {
+	const query = "SELECT * FROM large_table;"
+	b := testutil.NewBalancer(
+		testutil.WithInvokeHandlers(
+			testutil.InvokeHandlers{
+				testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+					return &Ydb_Table.CreateSessionResult{
+						SessionId: testutil.SessionID(),
+					}, nil
+				},
+				testutil.TableExecuteDataQuery: func(interface{}) (proto.Message, error) {
+					return largeQueryResult(10, 1000), nil
+				},
+			},
+		),
+	)
+	execute := func(t *testing.T, opts ...config.Option) (result.Result, error) {
+		s, err := newSession(context.Background(), b, config.New(opts...))
+		require.NoError(t, err)
+		_, res, err := s.Execute(context.Background(), table.DefaultTxControl(), query, nil)
+		return res, err
+	}
+
+	t.Run("Warning", func(t *testing.T) {
+		var warnings []trace.TableSessionQueryResultSizeWarningInfo
+		res, err := execute(t,
+			config.WithResultSizeWarning(5000, 0),
+			config.WithTrace(&trace.Table{
+				OnSessionQueryResultSizeWarning: func(info trace.TableSessionQueryResultSizeWarningInfo) {
+					warnings = append(warnings, info)
+				},
+			}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, 10, res.ResultSetCount())
+		require.Len(t, warnings, 1)
+		require.Equal(t, inflight.Digest(query), warnings[0].QueryDigest)
+		require.Equal(t, 10000, warnings[0].Rows)
+		require.Positive(t, warnings[0].Bytes)
+	})
+
+	t.Run("NoWarning", func(t *testing.T) {
+		res, err := execute(t,
+			config.WithResultSizeWarning(10000, 0),
+			config.WithTrace(&trace.Table{
+				OnSessionQueryResultSizeWarning: func(info trace.TableSessionQueryResultSizeWarningInfo) {
+					t.Fatalf("unexpected warning: %+v", info)
+				},
+			}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, 10, res.ResultSetCount())
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		_, err := execute(t, config.WithResultSizeLimit(2500, 0))
+		require.ErrorIs(t, err, result.ErrResultTooLarge)
+		require.Contains(t, err.Error(), "more than 3000 rows (limit 2500 rows)")
+		require.False(t, retry.Check(err).MustRetry(true))
+	})
+}
+
+func TestSessionExecuteLazyResultSets(t *testing.T) {
+	b := testutil.NewBalancer(
+		testutil.WithInvokeHandlers(
+			testutil.InvokeHandlers{
+				testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+					return &Ydb_Table.CreateSessionResult{
+						SessionId: testutil.SessionID(),
+					}, nil
+				},
+				testutil.TableExecuteDataQuery: func(interface{}) (proto.Message, error) {
+					return largeQueryResult(5, 10), nil
+				},
+			},
+		),
+	)
+	s, err := newSession(context.Background(), b, config.New())
+	require.NoError(t, err)
+	tx, res, err := s.Execute(context.Background(), table.DefaultTxControl(), "SELECT 1; SELECT 2;", nil)
+	require.NoError(t, err)
+	require.Equal(t, "tx", tx.ID())
+	require.Equal(t, 5, res.ResultSetCount())
+	var sets, rows int
+	for res.NextResultSet(context.Background()) {
+		sets++
+		for res.NextRow() {
+			var v string
+			require.NoError(t, res.Scan(&v))
+			require.Equal(t, "row of large result set", v)
+			rows++
+		}
+	}
+	require.NoError(t, res.Err())
+	require.Equal(t, 5, sets)
+	require.Equal(t, 50, rows)
+}
+
+// BenchmarkQueryResultFirstSet measures memory for reading of first result set only from
+// result of multi-statement query with decoding of all result sets up front and on demand
+func BenchmarkQueryResultFirstSet(b *testing.B) {
+	data, err := proto.Marshal(largeQueryResult(5, 10000))
+	require.NoError(b, err)
+	readFirstSet := func(b *testing.B, res result.Result) {
+		require.NoError(b, res.NextResultSetErr(context.Background()))
+		for res.NextRow() {
+			var v string
+			require.NoError(b, res.Scan(&v))
+		}
+	}
+	b.Run("Eager", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var dst Ydb_Table.ExecuteQueryResult
+			require.NoError(b, proto.Unmarshal(data, &dst))
+			readFirstSet(b, scanner.NewUnary(dst.GetResultSets(), dst.GetQueryStats()))
+		}
+	})
+	b.Run("Lazy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var dst Ydb_Table.ExecuteQueryResult
+			sets, err := splitQueryResult(data, &dst)
+			require.NoError(b, err)
+			readFirstSet(b, scanner.NewLazyUnary(sets, dst.GetQueryStats()))
+		}
+	})
+}
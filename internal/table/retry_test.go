@@ -77,10 +77,17 @@ func TestRetryerBackoffRetryCancelation(t *testing.T) {
 }
 
 func TestRetryerBadSession(t *testing.T) {
-	closed := make(map[table.Session]bool)
+	var (
+		cluster = newStubCluster(t)
+		b       = stubBalancer(t, cluster)
+		closed  = make(map[table.Session]bool)
+	)
 	p := SessionProviderFunc{
 		OnGet: func(ctx context.Context) (*session, error) {
-			s := simpleSession(t)
+			s, err := newSession(ctx, b, config.New())
+			if err != nil {
+				return nil, err
+			}
 			s.onClose = append(s.onClose, func(s *session) {
 				closed[s] = true
 			})
@@ -102,13 +109,14 @@ func TestRetryerBadSession(t *testing.T) {
 	err := do(ctx, p, config.New(),
 		func(ctx context.Context, s table.Session) error {
 			sessions = append(sessions, s)
+			// server invalidates session, so query fails with BAD_SESSION
+			cluster.Node(0).KillSessions()
+			_, _, err := s.Execute(ctx, table.DefaultTxControl(), "SELECT 1", nil)
 			i++
 			if i > maxRetryes {
 				cancel()
 			}
-			return xerrors.Operation(
-				xerrors.WithStatusCode(Ydb.StatusIds_BAD_SESSION),
-			)
+			return err
 		},
 		func(err error) {},
 	)
@@ -0,0 +1,114 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+)
+
+// checkpoint keeps position of stream of read table result
+type checkpoint struct {
+	// keyColumns is a names of primary key columns of table
+	keyColumns []string
+	// last is a checkpoint after last fully consumed result set part
+	last string
+}
+
+// WithCheckpoint enables checkpoints of read table result with primary key columns.
+// start is a checkpoint of beginning of stream.
+// Checkpoints are not enabled if keyColumns is empty
+func WithCheckpoint(keyColumns []string, start string) option {
+	if len(keyColumns) == 0 {
+		return nil
+	}
+	return func(r *baseResult) {
+		r.checkpoint = &checkpoint{
+			keyColumns: keyColumns,
+			last:       start,
+		}
+	}
+}
+
+// Checkpoint returns checkpoint after last fully consumed result set part
+func (r *baseResult) Checkpoint() (string, error) {
+	if r.checkpoint == nil {
+		return "", xerrors.WithStackTrace(result.ErrCheckpointNotSupported)
+	}
+	if r.consumed() {
+		return encodeCheckpoint(r.set, r.set.Rows[len(r.set.Rows)-1], r.checkpoint.keyColumns)
+	}
+	return r.checkpoint.last, nil
+}
+
+// consumed reports whether all rows of non-empty current result set were selected
+func (r *baseResult) consumed() bool {
+	return r.set != nil && len(r.set.Rows) > 0 && r.nextRow == len(r.set.Rows)
+}
+
+// saveCheckpoint saves checkpoint of current result set if it is fully consumed
+func (r *baseResult) saveCheckpoint() {
+	if r.checkpoint == nil || !r.consumed() {
+		return
+	}
+	if c, err := encodeCheckpoint(r.set, r.set.Rows[len(r.set.Rows)-1], r.checkpoint.keyColumns); err == nil {
+		r.checkpoint.last = c
+	}
+}
+
+// encodeCheckpoint encodes primary key of row as a checkpoint
+func encodeCheckpoint(set *Ydb.ResultSet, row *Ydb.Value, keyColumns []string) (string, error) {
+	var (
+		items = make([]*Ydb.Type, 0, len(keyColumns))
+		key   = &Ydb.Value{Items: make([]*Ydb.Value, 0, len(keyColumns))}
+	)
+	for _, name := range keyColumns {
+		i := columnIndex(set, name)
+		if i < 0 || i >= len(row.GetItems()) {
+			return "", xerrors.WithStackTrace(fmt.Errorf("key column %q not found in result set", name))
+		}
+		items = append(items, set.Columns[i].GetType())
+		key.Items = append(key.Items, row.GetItems()[i])
+	}
+	data, err := proto.Marshal(&Ydb.TypedValue{
+		Type: &Ydb.Type{
+			Type: &Ydb.Type_TupleType{
+				TupleType: &Ydb.TupleType{Elements: items},
+			},
+		},
+		Value: key,
+	})
+	if err != nil {
+		return "", xerrors.WithStackTrace(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCheckpoint decodes primary key of last consumed row from checkpoint
+func DecodeCheckpoint(c string) (*Ydb.TypedValue, error) {
+	data, err := base64.RawURLEncoding.DecodeString(c)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("malformed checkpoint: %w", err))
+	}
+	key := &Ydb.TypedValue{}
+	if err = proto.Unmarshal(data, key); err != nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("malformed checkpoint: %w", err))
+	}
+	if key.GetType().GetTupleType() == nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("malformed checkpoint: unexpected type %v", key.GetType()))
+	}
+	return key, nil
+}
+
+func columnIndex(set *Ydb.ResultSet, name string) int {
+	for i, c := range set.GetColumns() {
+		if c.GetName() == name {
+			return i
+		}
+	}
+	return -1
+}
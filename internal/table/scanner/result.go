@@ -7,6 +7,7 @@ import (
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xatomic"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
@@ -24,6 +25,11 @@ type baseResult struct {
 	statsMtx             xsync.RWMutex
 	stats                *Ydb_TableStats.QueryStats
 
+	txID          string
+	txConsistency result.Consistency
+
+	checkpoint *checkpoint
+
 	closed xatomic.Bool
 }
 
@@ -40,13 +46,15 @@ func (r *streamResult) Err() error {
 	if err != nil {
 		return xerrors.WithStackTrace(err)
 	}
-	return nil
+	return r.scanner.cellErrorsSummary()
 }
 
 type unaryResult struct {
 	baseResult
 
-	sets    []*Ydb.ResultSet
+	sets []*Ydb.ResultSet
+	// raw contains encoded result sets which are decoded only when NextResultSet reaches them
+	raw     [][]byte
 	nextSet int
 }
 
@@ -56,7 +64,7 @@ func (r *unaryResult) Err() error {
 	if err != nil {
 		return xerrors.WithStackTrace(err)
 	}
-	return nil
+	return r.scanner.cellErrorsSummary()
 }
 
 // Close closes the result, preventing further iteration.
@@ -68,6 +76,9 @@ func (r *unaryResult) Close() error {
 }
 
 func (r *unaryResult) ResultSetCount() int {
+	if r.raw != nil {
+		return len(r.raw)
+	}
 	return len(r.sets)
 }
 
@@ -103,6 +114,39 @@ func WithMarkTruncatedAsRetryable() option {
 	}
 }
 
+// WithAccumulateErrors enables accumulation of cells decoding errors.
+// Cell with decoding error is replaced with zero value and scanning continues.
+// Result becomes broken only if count of errors in result set exceeds limit.
+func WithAccumulateErrors(limit int) option {
+	return func(r *baseResult) {
+		r.scanner.cellErrorsLimit = limit
+	}
+}
+
+// WithMissingColumnsAsZero enables scanning of absent columns with ScanNamed as zero values.
+// Names of absent columns are collected for MissingColumns.
+func WithMissingColumnsAsZero(missingColumnsAsZero bool) option {
+	return func(r *baseResult) {
+		r.scanner.missingColumnsAsZero = missingColumnsAsZero
+	}
+}
+
+// WithStrictTypes makes result broken on result set with columns of unknown primitive types.
+// Otherwise, values of unknown types are scanned as opaque values
+func WithStrictTypes(strictTypes bool) option {
+	return func(r *baseResult) {
+		r.scanner.strictTypes = strictTypes
+	}
+}
+
+// WithTx sets identifier and consistency mode of transaction which result was read with
+func WithTx(txID string, consistency result.Consistency) option {
+	return func(r *baseResult) {
+		r.txID = txID
+		r.txConsistency = consistency
+	}
+}
+
 func NewStream(
 	ctx context.Context,
 	recv func(ctx context.Context) (*Ydb.ResultSet, *Ydb_TableStats.QueryStats, error),
@@ -139,7 +183,30 @@ func NewUnary(sets []*Ydb.ResultSet, stats *Ydb_TableStats.QueryStats, opts ...o
 	return r
 }
 
+// NewLazyUnary makes result from encoded result sets. Result set is decoded only when
+// NextResultSet reaches it and is released after NextResultSet moves to the next one,
+// so peak memory is bounded by the largest decoded result set instead of all of them.
+// Result takes ownership of sets.
+func NewLazyUnary(sets [][]byte, stats *Ydb_TableStats.QueryStats, opts ...option) UnaryResult {
+	if sets == nil {
+		sets = [][]byte{}
+	}
+	r := &unaryResult{
+		baseResult: baseResult{
+			stats: stats,
+		},
+		raw: sets,
+	}
+	for _, o := range opts {
+		if o != nil {
+			o(&r.baseResult)
+		}
+	}
+	return r
+}
+
 func (r *baseResult) Reset(set *Ydb.ResultSet, columnNames ...string) {
+	r.saveCheckpoint()
 	r.reset(set)
 	if set != nil {
 		r.setColumnIndexes(columnNames)
@@ -153,7 +220,18 @@ func (r *unaryResult) NextResultSetErr(ctx context.Context, columns ...string) (
 	if !r.HasNextResultSet() {
 		return io.EOF
 	}
-	r.Reset(r.sets[r.nextSet], columns...)
+	if r.raw == nil {
+		r.Reset(r.sets[r.nextSet], columns...)
+		r.nextSet++
+		return ctx.Err()
+	}
+	set := &Ydb.ResultSet{}
+	if err := proto.Unmarshal(r.raw[r.nextSet], set); err != nil {
+		return xerrors.WithStackTrace(r.errorf(0, "decode result set #%d failed: %w", r.nextSet, err))
+	}
+	// encoded result set isn't needed anymore, decoded one is released by next Reset
+	r.raw[r.nextSet] = nil
+	r.Reset(set, columns...)
 	r.nextSet++
 	return ctx.Err()
 }
@@ -189,7 +267,7 @@ func (r *streamResult) NextResultSetErr(ctx context.Context, columns ...string)
 	if r.isClosed() {
 		return xerrors.WithStackTrace(errAlreadyClosed)
 	}
-	if err = r.Err(); err != nil {
+	if err = r.scanner.Err(); err != nil {
 		return xerrors.WithStackTrace(err)
 	}
 	if err := r.nextResultSetErr(ctx, columns...); err != nil {
@@ -224,6 +302,16 @@ func (r *baseResult) Stats() stats.QueryStats {
 	return &s
 }
 
+// TxID returns identifier of transaction which result was read with
+func (r *baseResult) TxID() string {
+	return r.txID
+}
+
+// TxConsistency returns consistency mode of transaction which result was read with
+func (r *baseResult) TxConsistency() result.Consistency {
+	return r.txConsistency
+}
+
 // Close closes the result, preventing further iteration.
 func (r *streamResult) Close() (err error) {
 	if r.closed.CompareAndSwap(false, true) {
@@ -249,7 +337,7 @@ func (r *streamResult) HasNextResultSet() bool {
 // without advancing the result set.
 // Note that it does not work with sets from stream.
 func (r *unaryResult) HasNextResultSet() bool {
-	if r.inactive() || r.nextSet >= len(r.sets) {
+	if r.inactive() || r.nextSet >= r.ResultSetCount() {
 		return false
 	}
 	return true
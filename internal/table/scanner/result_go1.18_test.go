@@ -0,0 +1,152 @@
+//go:build go1.18
+// +build go1.18
+
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result/named"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// ulid is a user-defined destination type which is scanned from Text values by registered cast
+type ulid [16]byte
+
+// message is a user-defined destination type which is scanned from Bytes values by registered cast
+type message struct {
+	payload string
+	null    bool
+}
+
+func TestResultRegisteredCast(t *testing.T) {
+	require.NoError(t, types.RegisterCast(func(v types.Value, dst *ulid) error {
+		var s string
+		if err := types.CastTo(v, &s); err != nil {
+			return err
+		}
+		if len(s) != len(dst) {
+			return fmt.Errorf("invalid ulid '%s'", s)
+		}
+		copy(dst[:], s)
+		return nil
+	}))
+	require.NoError(t, types.RegisterCast(func(v types.Value, dst *message) error {
+		var b *[]byte
+		if err := types.CastTo(v, &b); err != nil {
+			return err
+		}
+		if b == nil {
+			*dst = message{null: true}
+		} else {
+			*dst = message{payload: string(*b)}
+		}
+		return nil
+	}))
+	t.Cleanup(func() {
+		types.UnregisterCast[ulid]()
+		types.UnregisterCast[message]()
+	})
+	optionalBytes := &Ydb.Type{Type: &Ydb.Type_OptionalType{OptionalType: &Ydb.OptionalType{
+		Item: &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_STRING}},
+	}}}
+	res := NewUnary(
+		[]*Ydb.ResultSet{{
+			Columns: []*Ydb.Column{
+				{Name: "id", Type: &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_UTF8}}},
+				{Name: "seq", Type: &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_INT32}}},
+				{Name: "message", Type: optionalBytes},
+			},
+			Rows: []*Ydb.Value{
+				{Items: []*Ydb.Value{
+					{Value: &Ydb.Value_TextValue{TextValue: "01ARZ3NDEKTSV4RR"}},
+					{Value: &Ydb.Value_Int32Value{Int32Value: 1}},
+					{Value: &Ydb.Value_BytesValue{BytesValue: []byte("hello")}},
+				}},
+				{Items: []*Ydb.Value{
+					{Value: &Ydb.Value_TextValue{TextValue: "01ARZ3NDEKTSV4RS"}},
+					{Value: &Ydb.Value_Int32Value{Int32Value: 2}},
+					{Value: &Ydb.Value_NullFlagValue{}},
+				}},
+				{Items: []*Ydb.Value{
+					{Value: &Ydb.Value_TextValue{TextValue: "short"}},
+					{Value: &Ydb.Value_Int32Value{Int32Value: 3}},
+					{Value: &Ydb.Value_NullFlagValue{}},
+				}},
+			},
+		}},
+		nil,
+	)
+	require.NoError(t, res.NextResultSetErr(context.Background()))
+	var (
+		id  ulid
+		seq int32
+		msg message
+	)
+	require.True(t, res.NextRow())
+	require.NoError(t, res.ScanNamed(
+		named.Required("id", &id),
+		named.Required("seq", &seq),
+		named.Optional("message", &msg),
+	))
+	require.Equal(t, ulid{'0', '1', 'A', 'R', 'Z', '3', 'N', 'D', 'E', 'K', 'T', 'S', 'V', '4', 'R', 'R'}, id)
+	require.Equal(t, int32(1), seq)
+	require.Equal(t, message{payload: "hello"}, msg)
+
+	require.True(t, res.NextRow())
+	require.NoError(t, res.ScanWithDefaults(&id, &seq, &msg))
+	require.Equal(t, ulid{'0', '1', 'A', 'R', 'Z', '3', 'N', 'D', 'E', 'K', 'T', 'S', 'V', '4', 'R', 'S'}, id)
+	require.Equal(t, int32(2), seq)
+	require.Equal(t, message{null: true}, msg)
+
+	require.True(t, res.NextRow())
+	require.ErrorContains(t, res.ScanNamed(
+		named.Required("id", &id),
+		named.Required("seq", &seq),
+		named.Optional("message", &msg),
+	), "invalid ulid 'short'")
+}
+
+func TestLazyUnaryResult(t *testing.T) {
+	newSet := func(v int32) []byte {
+		data, err := proto.Marshal(&Ydb.ResultSet{
+			Columns: []*Ydb.Column{{Name: "v", Type: &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_INT32}}}},
+			Rows: []*Ydb.Value{{
+				Items: []*Ydb.Value{{Value: &Ydb.Value_Int32Value{Int32Value: v}}},
+			}},
+		})
+		require.NoError(t, err)
+		return data
+	}
+	t.Run("Empty", func(t *testing.T) {
+		res := NewLazyUnary(nil, nil)
+		require.Zero(t, res.ResultSetCount())
+		require.False(t, res.HasNextResultSet())
+		require.ErrorIs(t, res.NextResultSetErr(context.Background()), io.EOF)
+	})
+	t.Run("DecodeOnDemand", func(t *testing.T) {
+		// third result set is malformed, but it doesn't break reading of preceding result sets
+		sets := [][]byte{newSet(1), newSet(2), {0xff}}
+		res := NewLazyUnary(sets, nil)
+		require.Equal(t, 3, res.ResultSetCount())
+		for i := int32(1); i <= 2; i++ {
+			require.NoError(t, res.NextResultSetErr(context.Background()))
+			require.Nil(t, sets[i-1])
+			require.True(t, res.NextRow())
+			var v int32
+			require.NoError(t, res.Scan(&v))
+			require.Equal(t, i, v)
+		}
+		require.True(t, res.HasNextResultSet())
+		require.ErrorContains(t, res.NextResultSetErr(context.Background()), "decode result set #2 failed")
+		require.Error(t, res.Err())
+		require.False(t, res.HasNextResultSet())
+	})
+}
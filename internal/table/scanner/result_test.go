@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -15,6 +16,8 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result/named"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 )
 
@@ -275,3 +278,429 @@ func TestNewStreamWithRecvFirstResultSet(t *testing.T) {
 		})
 	}
 }
+
+func TestResultAccumulateErrors(t *testing.T) {
+	a := allocator.New()
+	defer a.Free()
+	newResult := func(limit int) UnaryResult {
+		return NewUnary(
+			[]*Ydb.ResultSet{
+				NewResultSet(a,
+					WithColumns(
+						options.Column{Name: "id", Type: types.TypeInt32},
+						options.Column{Name: "ts", Type: types.TypeTzDatetime},
+						options.Column{Name: "small", Type: types.TypeInt32},
+					),
+					WithValues(
+						types.Int32Value(1), types.TzDatetimeValue("2022-06-17T05:19:20,Europe/Berlin"), types.Int32Value(1),
+						types.Int32Value(2), types.TzDatetimeValue("malformed"), types.Int32Value(2),
+						types.Int32Value(3), types.TzDatetimeValue("2022-06-17T05:19:20,Europe/Berlin"), types.Int32Value(1000),
+						types.Int32Value(4), types.TzDatetimeValue("malformed"), types.Int32Value(4),
+					),
+				),
+			},
+			nil,
+			WithAccumulateErrors(limit),
+		)
+	}
+	t.Run("UnderLimit", func(t *testing.T) {
+		res := newResult(3)
+		var (
+			ids    []int32
+			ts     time.Time
+			small  int8
+			smalls []int8
+		)
+		require.NoError(t, res.NextResultSetErr(context.Background()))
+		for res.NextRow() {
+			var id int32
+			require.NoError(t, res.Scan(&id, &ts, &small))
+			ids = append(ids, id)
+			smalls = append(smalls, small)
+			if id == 2 || id == 4 {
+				require.True(t, ts.IsZero())
+			}
+		}
+		require.Equal(t, []int32{1, 2, 3, 4}, ids)
+		require.Equal(t, []int8{1, 2, 0, 4}, smalls)
+		cellErrors := result.CellErrors(res)
+		require.Len(t, cellErrors, 3)
+		require.Equal(t, 1, cellErrors[0].Row)
+		require.Equal(t, "ts", cellErrors[0].Column)
+		require.Equal(t, 2, cellErrors[1].Row)
+		require.Equal(t, "small", cellErrors[1].Column)
+		require.Equal(t, 3, cellErrors[2].Row)
+		require.Equal(t, "ts", cellErrors[2].Column)
+		err := res.Err()
+		require.ErrorIs(t, err, result.ErrCellErrors)
+		require.Contains(t, err.Error(), "3 cells decoded with errors")
+	})
+	t.Run("LimitExceeded", func(t *testing.T) {
+		res := newResult(2)
+		var (
+			id    int32
+			ts    time.Time
+			small int8
+			rows  int
+			err   error
+		)
+		require.NoError(t, res.NextResultSetErr(context.Background()))
+		for res.NextRow() {
+			rows++
+			if err = res.Scan(&id, &ts, &small); err != nil {
+				break
+			}
+		}
+		require.Error(t, err)
+		require.Equal(t, 4, rows)
+		require.Len(t, result.CellErrors(res), 2)
+		require.NotErrorIs(t, res.Err(), result.ErrCellErrors)
+		require.Error(t, res.Err())
+	})
+	t.Run("ResultSets", func(t *testing.T) {
+		set := func() *Ydb.ResultSet {
+			return NewResultSet(a,
+				WithColumns(options.Column{Name: "ts", Type: types.TypeTzDatetime}),
+				WithValues(
+					types.TzDatetimeValue("malformed"),
+					types.TzDatetimeValue("2022-06-17T05:19:20,Europe/Berlin"),
+					types.TzDatetimeValue("malformed"),
+				),
+			)
+		}
+		res := NewUnary([]*Ydb.ResultSet{set(), set()}, nil, WithAccumulateErrors(2))
+		var ts time.Time
+		for i := 0; i < 2; i++ {
+			require.NoError(t, res.NextResultSetErr(context.Background()))
+			for res.NextRow() {
+				require.NoError(t, res.Scan(&ts))
+			}
+			cellErrors := result.CellErrors(res)
+			require.Len(t, cellErrors, 2)
+			require.Equal(t, i, cellErrors[0].ResultSet)
+			require.Equal(t, 0, cellErrors[0].Row)
+			require.Equal(t, i, cellErrors[1].ResultSet)
+			require.Equal(t, 2, cellErrors[1].Row)
+		}
+		err := res.Err()
+		require.ErrorIs(t, err, result.ErrCellErrors)
+		require.Contains(t, err.Error(), "4 cells decoded with errors")
+		require.Contains(t, err.Error(), "result set 0, row 0")
+	})
+	t.Run("TypeError", func(t *testing.T) {
+		// errors of destination types are not accumulated
+		res := newResult(3)
+		var (
+			id    int32
+			ts    string
+			small int8
+		)
+		require.NoError(t, res.NextResultSetErr(context.Background()))
+		require.True(t, res.NextRow())
+		require.Error(t, res.Scan(&id, &ts, &small))
+		require.Empty(t, result.CellErrors(res))
+		require.NotErrorIs(t, res.Err(), result.ErrCellErrors)
+		require.Error(t, res.Err())
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		res := NewUnary(
+			[]*Ydb.ResultSet{
+				NewResultSet(a,
+					WithColumns(options.Column{Name: "ts", Type: types.TypeTzDatetime}),
+					WithValues(types.TzDatetimeValue("malformed")),
+				),
+			},
+			nil,
+		)
+		var ts time.Time
+		require.NoError(t, res.NextResultSetErr(context.Background()))
+		require.True(t, res.NextRow())
+		require.Error(t, res.Scan(&ts))
+		require.Empty(t, result.CellErrors(res))
+	})
+}
+
+func TestResultDecimalBigInt(t *testing.T) {
+	a := allocator.New()
+	defer a.Free()
+	res := NewUnary(
+		[]*Ydb.ResultSet{
+			NewResultSet(a,
+				WithColumns(
+					options.Column{Name: "amount", Type: types.DecimalType(22, 9)},
+					options.Column{Name: "total", Type: types.Optional(types.DecimalType(35, 2))},
+				),
+				WithValues(
+					types.DecimalValueFromBigInt(big.NewInt(1500000000), 22, 9),
+					types.OptionalValue(types.DecimalValueFromBigInt(big.NewInt(-12345), 35, 2)),
+					types.DecimalValueFromBigInt(big.NewInt(1), 22, 9),
+					types.NullValue(types.DecimalType(35, 2)),
+				),
+			),
+		},
+		nil,
+	)
+	var (
+		amount big.Int
+		total  *big.Int
+	)
+	require.NoError(t, res.NextResultSetErr(context.Background()))
+	require.True(t, res.NextRow())
+	require.NoError(t, res.ScanNamed(
+		named.Required("amount", &amount),
+		named.Optional("total", &total),
+	))
+	require.Equal(t, "1500000000", amount.String())
+	require.NotNil(t, total)
+	require.Equal(t, "-12345", total.String())
+	require.True(t, res.NextRow())
+	require.NoError(t, res.ScanNamed(
+		named.Required("amount", &amount),
+		named.Optional("total", &total),
+	))
+	require.Equal(t, "1", amount.String())
+	require.Nil(t, total)
+	require.NoError(t, res.Err())
+}
+
+func TestResultMissingColumnsAsZero(t *testing.T) {
+	a := allocator.New()
+	defer a.Free()
+	newResult := func(opts ...option) UnaryResult {
+		return NewUnary(
+			[]*Ydb.ResultSet{
+				NewResultSet(a,
+					WithColumns(
+						options.Column{Name: "id", Type: types.TypeInt32},
+						options.Column{Name: "title", Type: types.Optional(types.TypeText)},
+					),
+					WithValues(
+						types.Int32Value(1), types.OptionalValue(types.TextValue("a")),
+						types.Int32Value(2), types.NullValue(types.TypeText),
+					),
+				),
+			},
+			nil,
+			opts...,
+		)
+	}
+	t.Run("Enabled", func(t *testing.T) {
+		res := newResult(WithMissingColumnsAsZero(true))
+		require.NoError(t, res.NextResultSetErr(context.Background()))
+		var (
+			ids    []int32
+			titles []*string
+		)
+		for res.NextRow() {
+			var (
+				id       int32
+				title    *string
+				version  = int64(42)
+				subtitle = new(string)
+			)
+			require.NoError(t, res.ScanNamed(
+				named.Required("id", &id),
+				named.Optional("title", &title),
+				named.Required("version", &version),
+				named.Optional("subtitle", &subtitle),
+			))
+			require.Zero(t, version)
+			require.Nil(t, subtitle)
+			ids = append(ids, id)
+			titles = append(titles, title)
+		}
+		require.NoError(t, res.Err())
+		require.Equal(t, []int32{1, 2}, ids)
+		require.Equal(t, "a", *titles[0])
+		require.Nil(t, titles[1])
+		require.Equal(t, []string{"version", "subtitle"}, result.MissingColumns(res))
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		res := newResult()
+		require.NoError(t, res.NextResultSetErr(context.Background()))
+		require.True(t, res.NextRow())
+		var (
+			id      int32
+			version int64
+		)
+		require.Error(t, res.ScanNamed(
+			named.Required("id", &id),
+			named.Required("version", &version),
+		))
+		require.Empty(t, result.MissingColumns(res))
+	})
+}
+
+func TestResultUnknownPrimitiveType(t *testing.T) {
+	// unknownTypeID is a primitive type id which is not known by SDK (as type of newer version of YDB)
+	const unknownTypeID = 0x7fff
+	newResult := func(opts ...option) UnaryResult {
+		return NewUnary(
+			[]*Ydb.ResultSet{{
+				Columns: []*Ydb.Column{
+					{Name: "id", Type: &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_INT32}}},
+					{Name: "future", Type: &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: unknownTypeID}}},
+				},
+				Rows: []*Ydb.Value{{
+					Items: []*Ydb.Value{
+						{Value: &Ydb.Value_Int32Value{Int32Value: 1}},
+						{Value: &Ydb.Value_BytesValue{BytesValue: []byte("raw")}},
+					},
+				}},
+			}},
+			nil,
+			opts...,
+		)
+	}
+	t.Run("Tolerant", func(t *testing.T) {
+		res := newResult()
+		require.NoError(t, res.NextResultSetErr(context.Background()))
+		var columns []string
+		res.CurrentResultSet().Columns(func(c options.Column) {
+			columns = append(columns, c.Name)
+		})
+		require.Equal(t, []string{"id", "future"}, columns)
+		require.True(t, res.NextRow())
+		var (
+			id     int32
+			future types.Value
+		)
+		require.NoError(t, res.ScanNamed(
+			named.Required("id", &id),
+			named.Required("future", &future),
+		))
+		require.Equal(t, int32(1), id)
+		var raw []byte
+		require.NoError(t, types.CastTo(future, &raw))
+		require.NotEmpty(t, raw)
+		var s string
+		require.Error(t, types.CastTo(future, &s))
+		require.NoError(t, res.Err())
+	})
+	t.Run("Strict", func(t *testing.T) {
+		res := newResult(WithStrictTypes(true))
+		_ = res.NextResultSetErr(context.Background())
+		require.ErrorContains(t, res.Err(), "column 'future'")
+	})
+}
+
+func TestResultMalformedResponse(t *testing.T) {
+	tupleType := &Ydb.Type{Type: &Ydb.Type_TupleType{TupleType: &Ydb.TupleType{Elements: []*Ydb.Type{
+		{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_INT32}},
+		{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_INT32}},
+	}}}}
+	newResult := func(column *Ydb.Type, v *Ydb.Value) UnaryResult {
+		return NewUnary(
+			[]*Ydb.ResultSet{{
+				Columns: []*Ydb.Column{{Name: "a", Type: column}},
+				Rows:    []*Ydb.Value{{Items: []*Ydb.Value{v}}},
+			}},
+			nil,
+		)
+	}
+	t.Run("MissedType", func(t *testing.T) {
+		res := newResult(&Ydb.Type{}, &Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: 1}})
+		require.NoError(t, res.NextResultSetErr(context.Background()))
+		require.NotPanics(t, func() {
+			res.CurrentResultSet().Columns(func(options.Column) {})
+		})
+		require.Error(t, res.Err())
+	})
+	t.Run("MissedItemsOfTuple", func(t *testing.T) {
+		res := newResult(tupleType, &Ydb.Value{Items: []*Ydb.Value{{Value: &Ydb.Value_Int32Value{Int32Value: 1}}}})
+		require.NoError(t, res.NextResultSetErr(context.Background()))
+		require.True(t, res.NextRow())
+		var v types.Value
+		require.NotPanics(t, func() {
+			require.Error(t, res.ScanWithDefaults(&v))
+		})
+		require.Nil(t, v)
+		require.Error(t, res.Err())
+	})
+}
+
+func TestResultPgType(t *testing.T) {
+	pgType := func(oid uint32) *Ydb.Type {
+		return &Ydb.Type{Type: &Ydb.Type_PgType{PgType: &Ydb.PgType{Oid: oid, Typlen: -1, Typmod: -1}}}
+	}
+	res := NewUnary(
+		[]*Ydb.ResultSet{{
+			Columns: []*Ydb.Column{
+				{Name: "id", Type: pgType(23)},
+				{Name: "name", Type: pgType(25)},
+				{Name: "payload", Type: pgType(17)},
+			},
+			Rows: []*Ydb.Value{{
+				Items: []*Ydb.Value{
+					{Value: &Ydb.Value_TextValue{TextValue: "42"}},
+					{Value: &Ydb.Value_NullFlagValue{}},
+					{Value: &Ydb.Value_TextValue{TextValue: "\\x0102"}},
+				},
+			}},
+		}},
+		nil,
+		WithStrictTypes(true),
+	)
+	require.NoError(t, res.NextResultSetErr(context.Background()))
+	require.True(t, res.NextRow())
+	var (
+		id      types.Value
+		name    *string
+		payload []byte
+	)
+	require.NoError(t, res.ScanNamed(
+		named.Required("id", &id),
+		named.Optional("name", &name),
+		named.Required("payload", &payload),
+	))
+	require.Equal(t, "PgConst(\"42\",pgint4)", id.Yql())
+	var text string
+	require.NoError(t, types.CastTo(id, &text))
+	require.Equal(t, "42", text)
+	require.Nil(t, name)
+	require.Equal(t, []byte("\\x0102"), payload)
+}
+
+func TestResultWideTimeTypes(t *testing.T) {
+	primitive := func(id Ydb.Type_PrimitiveTypeId) *Ydb.Type {
+		return &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: id}}
+	}
+	res := NewUnary(
+		[]*Ydb.ResultSet{{
+			Columns: []*Ydb.Column{
+				{Name: "date", Type: primitive(value.TypeIDDate32)},
+				{Name: "datetime", Type: primitive(value.TypeIDDatetime64)},
+				{Name: "timestamp", Type: primitive(value.TypeIDTimestamp64)},
+				{Name: "interval", Type: primitive(value.TypeIDInterval64)},
+			},
+			Rows: []*Ydb.Value{{
+				Items: []*Ydb.Value{
+					{Value: &Ydb.Value_Int32Value{Int32Value: -1}},
+					{Value: &Ydb.Value_Int64Value{Int64Value: -1}},
+					{Value: &Ydb.Value_Int64Value{Int64Value: -1}},
+					{Value: &Ydb.Value_Int64Value{Int64Value: -1}},
+				},
+			}},
+		}},
+		nil,
+		WithStrictTypes(true),
+	)
+	require.NoError(t, res.NextResultSetErr(context.Background()))
+	require.True(t, res.NextRow())
+	var (
+		date      time.Time
+		datetime  time.Time
+		timestamp interface{}
+		interval  time.Duration
+	)
+	require.NoError(t, res.ScanNamed(
+		named.Required("date", &date),
+		named.Required("datetime", &datetime),
+		named.Required("timestamp", &timestamp),
+		named.Required("interval", &interval),
+	))
+	require.True(t, date.Equal(time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC)), date)
+	require.True(t, datetime.Equal(time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC)), datetime)
+	require.True(t, timestamp.(time.Time).Equal(time.Date(1969, 12, 31, 23, 59, 59, 999999000, time.UTC)), timestamp)
+	require.Equal(t, -time.Microsecond, interval)
+}
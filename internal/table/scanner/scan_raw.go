@@ -183,7 +183,7 @@ func (s *rawConverter) TzDate() (v time.Time) {
 	}
 	src, err := value.TzDateToTime(s.text())
 	if err != nil {
-		_ = s.errorf(0, "rawConverter.TzDate(): %w", err)
+		_ = s.cellErrorf(0, "rawConverter.TzDate(): %w", err)
 	}
 	return src
 }
@@ -195,7 +195,7 @@ func (s *rawConverter) TzDatetime() (v time.Time) {
 	}
 	src, err := value.TzDatetimeToTime(s.text())
 	if err != nil {
-		_ = s.errorf(0, "rawConverter.TzDatetime(): %w", err)
+		_ = s.cellErrorf(0, "rawConverter.TzDatetime(): %w", err)
 	}
 	return src
 }
@@ -207,7 +207,7 @@ func (s *rawConverter) TzTimestamp() (v time.Time) {
 	}
 	src, err := value.TzTimestampToTime(s.text())
 	if err != nil {
-		_ = s.errorf(0, "rawConverter.TzTimestamp(): %w", err)
+		_ = s.cellErrorf(0, "rawConverter.TzTimestamp(): %w", err)
 	}
 	return src
 }
@@ -660,7 +660,11 @@ func (s *rawConverter) assertCurrentTypeNullable() bool {
 
 func (s *rawConverter) assertCurrentTypeIs(t types.Type) bool {
 	c := s.stack.current()
-	act := value.TypeFromYDB(c.t)
+	act, err := value.TypeFromYDBWithError(c.t)
+	if err != nil {
+		_ = s.errorf(1, "type at %q: %w", s.Path(), err)
+		return false
+	}
 	if !value.TypesEqual(act, t) {
 		_ = s.errorf(
 			1,
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/big"
 	"reflect"
 	"time"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xstring"
@@ -34,6 +36,26 @@ type scanner struct {
 
 	columnIndexes []int
 
+	// resultSet is a count of result sets passed to reset
+	resultSet int
+
+	// cellErrorsLimit enables accumulation of cells decoding errors if positive
+	cellErrorsLimit int
+	// cellErr is true if err is an error of decoding (or cast) of the current cell value
+	cellErr bool
+	// cellErrors contains cells decoding errors of the current result set
+	cellErrors []result.CellError
+	// cellErrorsTotal and firstCellError are kept over all result sets for summary
+	cellErrorsTotal int
+	firstCellError  result.CellError
+
+	// missingColumnsAsZero enables scanning of absent columns as zero values
+	missingColumnsAsZero bool
+	missingColumns       []string
+
+	// strictTypes breaks scanner on result set with columns of unknown primitive types
+	strictTypes bool
+
 	errMtx xsync.RWMutex
 	err    error
 }
@@ -52,9 +74,14 @@ func (s *scanner) Columns(it func(options.Column)) {
 		return
 	}
 	for _, m := range s.set.Columns {
+		t, err := value.TypeFromYDBWithError(m.Type)
+		if err != nil {
+			_ = s.errorf(0, "type of column %q: %w", m.Name, err)
+			return
+		}
 		it(options.Column{
 			Name: m.Name,
-			Type: value.TypeFromYDB(m.Type),
+			Type: t,
 		})
 	}
 }
@@ -138,6 +165,7 @@ func (s *scanner) ScanWithDefaults(values ...indexed.Required) (err error) {
 		} else {
 			s.scanRequired(values[i])
 		}
+		s.accumulateCellError(values[i])
 	}
 	s.nextItem += len(values)
 	return s.Err()
@@ -165,6 +193,7 @@ func (s *scanner) Scan(values ...indexed.RequiredOrOptional) (err error) {
 		} else {
 			s.scanRequired(values[i])
 		}
+		s.accumulateCellError(values[i])
 	}
 	s.nextItem += len(values)
 	return s.Err()
@@ -174,13 +203,17 @@ func (s *scanner) ScanNamed(namedValues ...named.Value) error {
 	if err := s.Err(); err != nil {
 		return err
 	}
-	if s.ColumnCount() < len(namedValues) {
+	if !s.missingColumnsAsZero && s.ColumnCount() < len(namedValues) {
 		panic(fmt.Sprintf("scan row failed: count of columns less then values (%d < %d)", s.ColumnCount(), len(namedValues)))
 	}
 	if s.nextItem != 0 {
 		panic("scan row failed: double scan per row")
 	}
 	for i := range namedValues {
+		if s.missingColumnsAsZero && !s.hasColumn(namedValues[i].Name) {
+			s.scanMissingColumn(namedValues[i].Name, namedValues[i].Value)
+			continue
+		}
 		if err := s.seekItemByName(namedValues[i].Name); err != nil {
 			return err
 		}
@@ -194,6 +227,7 @@ func (s *scanner) ScanNamed(namedValues ...named.Value) error {
 		default:
 			panic(fmt.Sprintf("unknown type of named.Value: %d", t))
 		}
+		s.accumulateCellError(namedValues[i].Value)
 	}
 	s.nextItem += len(namedValues)
 	return s.Err()
@@ -239,6 +273,8 @@ func (s *scanner) Err() error {
 func (s *scanner) reset(set *Ydb.ResultSet, columnNames ...string) {
 	s.set = set
 	s.row = nil
+	s.resultSet++
+	s.resetCellErrors()
 	s.nextRow = 0
 	s.nextItem = 0
 	s.columnIndexes = nil
@@ -247,6 +283,14 @@ func (s *scanner) reset(set *Ydb.ResultSet, columnNames ...string) {
 	s.converter = &rawConverter{
 		scanner: s,
 	}
+	if s.strictTypes {
+		for _, c := range set.GetColumns() {
+			if err := value.CheckKnownTypes(c.GetType()); err != nil {
+				_ = s.errorf(0, "column '%s': %w", c.GetName(), err)
+				return
+			}
+		}
+	}
 }
 
 func (s *scanner) path() string {
@@ -272,7 +316,12 @@ func (s *scanner) getType() types.Type {
 	if x.isEmpty() {
 		return nil
 	}
-	return value.TypeFromYDB(x.t)
+	t, err := value.TypeFromYDBWithError(x.t)
+	if err != nil {
+		_ = s.errorf(1, "type at %q: %w", s.path(), err)
+		return nil
+	}
+	return t
 }
 
 func (s *scanner) hasItems() bool {
@@ -362,7 +411,11 @@ func (s *scanner) any() interface{} {
 		x = s.stack.current()
 	}
 
-	t := value.TypeFromYDB(x.t)
+	t, err := value.TypeFromYDBWithError(x.t)
+	if err != nil {
+		_ = s.errorf(0, "scanner.any(): %w", err)
+		return nil
+	}
 	p, primitive := t.(value.PrimitiveType)
 	if !primitive {
 		return s.value()
@@ -403,22 +456,30 @@ func (s *scanner) any() interface{} {
 		return s.int64()
 	case value.TypeInterval:
 		return value.IntervalToDuration(s.int64())
+	case value.TypeDate32:
+		return value.Date32ToTime(s.int32())
+	case value.TypeDatetime64:
+		return value.Datetime64ToTime(s.int64())
+	case value.TypeTimestamp64:
+		return value.Timestamp64ToTime(s.int64())
+	case value.TypeInterval64:
+		return value.IntervalToDuration(s.int64())
 	case value.TypeTzDate:
 		src, err := value.TzDateToTime(s.text())
 		if err != nil {
-			_ = s.errorf(0, "scanner.any(): %w", err)
+			_ = s.cellErrorf(0, "scanner.any(): %w", err)
 		}
 		return src
 	case value.TypeTzDatetime:
 		src, err := value.TzDatetimeToTime(s.text())
 		if err != nil {
-			_ = s.errorf(0, "scanner.any(): %w", err)
+			_ = s.cellErrorf(0, "scanner.any(): %w", err)
 		}
 		return src
 	case value.TypeTzTimestamp:
 		src, err := value.TzTimestampToTime(s.text())
 		if err != nil {
-			_ = s.errorf(0, "scanner.any(): %w", err)
+			_ = s.cellErrorf(0, "scanner.any(): %w", err)
 		}
 		return src
 	case value.TypeText, value.TypeDyNumber:
@@ -435,9 +496,15 @@ func (s *scanner) any() interface{} {
 }
 
 // Value returns current item under scan as ydb.Value types.
+// Value returns nil and breaks scanner if current item is malformed.
 func (s *scanner) value() types.Value {
 	x := s.stack.current()
-	return value.FromYDB(x.t, x.v)
+	v, err := value.FromYDBWithError(x.t, x.v)
+	if err != nil {
+		_ = s.cellErrorf(1, "value at %q: %w", s.path(), err)
+		return nil
+	}
+	return v
 }
 
 func (s *scanner) isCurrentTypeOptional() bool {
@@ -493,6 +560,20 @@ func (s *scanner) unwrapDecimal() (v types.Decimal) {
 	}
 }
 
+// setBigInt sets dst to unscaled value of current decimal item (decimal value is equal to dst * 10^(-scale))
+func (s *scanner) setBigInt(dst *big.Int) {
+	d := s.unwrapDecimal()
+	if s.Err() != nil {
+		return
+	}
+	v := d.BigInt()
+	if decimal.IsInf(v) || decimal.IsNaN(v) || decimal.IsErr(v) {
+		_ = s.cellErrorf(0, "scan row failed: decimal %s is not finite", d.String())
+		return
+	}
+	dst.Set(v)
+}
+
 func (s *scanner) assertTypeDecimal(typ *Ydb.Type) (t *Ydb.Type_DecimalType) {
 	x := typ.Type
 	if t, _ = x.(*Ydb.Type_DecimalType); t == nil {
@@ -653,22 +734,28 @@ func (s *scanner) setTime(dst *time.Time) {
 		*dst = value.DatetimeToTime(s.uint32())
 	case Ydb.Type_TIMESTAMP:
 		*dst = value.TimestampToTime(s.uint64())
+	case value.TypeIDDate32:
+		*dst = value.Date32ToTime(s.int32())
+	case value.TypeIDDatetime64:
+		*dst = value.Datetime64ToTime(s.int64())
+	case value.TypeIDTimestamp64:
+		*dst = value.Timestamp64ToTime(s.int64())
 	case Ydb.Type_TZ_DATE:
 		src, err := value.TzDateToTime(s.text())
 		if err != nil {
-			_ = s.errorf(0, "scanner.setTime(): %w", err)
+			_ = s.cellErrorf(0, "scanner.setTime(): %w", err)
 		}
 		*dst = src
 	case Ydb.Type_TZ_DATETIME:
 		src, err := value.TzDatetimeToTime(s.text())
 		if err != nil {
-			_ = s.errorf(0, "scanner.setTime(): %w", err)
+			_ = s.cellErrorf(0, "scanner.setTime(): %w", err)
 		}
 		*dst = src
 	case Ydb.Type_TZ_TIMESTAMP:
 		src, err := value.TzTimestampToTime(s.text())
 		if err != nil {
-			_ = s.errorf(0, "scanner.setTime(): %w", err)
+			_ = s.cellErrorf(0, "scanner.setTime(): %w", err)
 		}
 		*dst = src
 	default:
@@ -677,6 +764,11 @@ func (s *scanner) setTime(dst *time.Time) {
 }
 
 func (s *scanner) setString(dst *string) {
+	if s.stack.current().t.GetPgType() != nil {
+		// values of pg types are sent in PostgreSQL text representation
+		*dst = s.text()
+		return
+	}
 	switch t := s.stack.current().t.GetTypeId(); t {
 	case Ydb.Type_UUID:
 		src := s.uint128()
@@ -691,6 +783,11 @@ func (s *scanner) setString(dst *string) {
 }
 
 func (s *scanner) setByte(dst *[]byte) {
+	if s.stack.current().t.GetPgType() != nil {
+		// values of pg types are sent in PostgreSQL text representation
+		*dst = xstring.ToBytes(s.text())
+		return
+	}
 	switch t := s.stack.current().t.GetTypeId(); t {
 	case Ydb.Type_UUID:
 		src := s.uint128()
@@ -787,15 +884,17 @@ func (s *scanner) scanRequired(v interface{}) {
 		*v = s.value()
 	case *types.Decimal:
 		*v = s.unwrapDecimal()
+	case *big.Int:
+		s.setBigInt(v)
 	case types.Scanner:
 		err := v.UnmarshalYDB(s.converter)
 		if err != nil {
-			_ = s.errorf(0, "ydb.Scanner error: %w", err)
+			_ = s.cellErrorf(0, "ydb.Scanner error: %w", err)
 		}
 	case sql.Scanner:
 		err := v.Scan(s.any())
 		if err != nil {
-			_ = s.errorf(0, "sql.Scanner error: %w", err)
+			_ = s.cellErrorf(0, "sql.Scanner error: %w", err)
 		}
 	case json.Unmarshaler:
 		var err error
@@ -808,9 +907,13 @@ func (s *scanner) scanRequired(v interface{}) {
 			_ = s.errorf(0, "ydb required type %T not unsupported for applying to json.Unmarshaler", s.getType())
 		}
 		if err != nil {
-			_ = s.errorf(0, "json.Unmarshaler error: %w", err)
+			_ = s.cellErrorf(0, "json.Unmarshaler error: %w", err)
 		}
 	default:
+		if f, has := value.RegisteredCast(v); has {
+			s.castRegistered(f, v)
+			return
+		}
 		ok := s.trySetByteArray(v, false, false)
 		if !ok {
 			_ = s.errorf(0, "scan row failed: type %T is unknown", v)
@@ -978,15 +1081,23 @@ func (s *scanner) scanOptional(v interface{}, defaultValueForOptional bool) {
 			src := s.unwrapDecimal()
 			*v = &src
 		}
+	case **big.Int:
+		if s.isNull() {
+			*v = nil
+		} else {
+			src := new(big.Int)
+			s.setBigInt(src)
+			*v = src
+		}
 	case types.Scanner:
 		err := v.UnmarshalYDB(s.converter)
 		if err != nil {
-			_ = s.errorf(0, "ydb.Scanner error: %w", err)
+			_ = s.cellErrorf(0, "ydb.Scanner error: %w", err)
 		}
 	case sql.Scanner:
 		err := v.Scan(s.any())
 		if err != nil {
-			_ = s.errorf(0, "sql.Scanner error: %w", err)
+			_ = s.cellErrorf(0, "sql.Scanner error: %w", err)
 		}
 	case json.Unmarshaler:
 		s.unwrap()
@@ -1008,9 +1119,13 @@ func (s *scanner) scanOptional(v interface{}, defaultValueForOptional bool) {
 			_ = s.errorf(0, "ydb optional type %T not unsupported for applying to json.Unmarshaler", s.getType())
 		}
 		if err != nil {
-			_ = s.errorf(0, "json.Unmarshaler error: %w", err)
+			_ = s.cellErrorf(0, "json.Unmarshaler error: %w", err)
 		}
 	default:
+		if f, has := value.RegisteredCast(v); has {
+			s.castRegistered(f, v)
+			return
+		}
 		s.unwrap()
 		ok := s.trySetByteArray(v, true, false)
 		if !ok {
@@ -1064,22 +1179,28 @@ func (s *scanner) setDefaultValue(dst interface{}) {
 		*v = s.value()
 	case *types.Decimal:
 		*v = types.Decimal{}
+	case *big.Int:
+		v.SetInt64(0)
 	case sql.Scanner:
 		err := v.Scan(nil)
 		if err != nil {
-			_ = s.errorf(0, "sql.Scanner error: %w", err)
+			_ = s.cellErrorf(0, "sql.Scanner error: %w", err)
 		}
 	case types.Scanner:
 		err := v.UnmarshalYDB(s.converter)
 		if err != nil {
-			_ = s.errorf(0, "ydb.Scanner error: %w", err)
+			_ = s.cellErrorf(0, "ydb.Scanner error: %w", err)
 		}
 	case json.Unmarshaler:
 		err := v.UnmarshalJSON(nil)
 		if err != nil {
-			_ = s.errorf(0, "json.Unmarshaler error: %w", err)
+			_ = s.cellErrorf(0, "json.Unmarshaler error: %w", err)
 		}
 	default:
+		if f, has := value.RegisteredCast(v); has {
+			s.castRegistered(f, v)
+			return
+		}
 		ok := s.trySetByteArray(v, false, true)
 		if !ok {
 			_ = s.errorf(0, "scan row failed: type %T is unknown", v)
@@ -1087,6 +1208,113 @@ func (s *scanner) setDefaultValue(dst interface{}) {
 	}
 }
 
+// castRegistered scans current item into destination of user-defined type with cast
+// registered by value.RegisterCast. Optional items are passed to cast as is.
+func (s *scanner) castRegistered(f value.CastFunc, dst interface{}) {
+	v := s.value()
+	if v == nil {
+		return
+	}
+	if err := f(v, dst); err != nil {
+		_ = s.cellErrorf(1, "registered cast to %T error: %w", dst, err)
+	}
+}
+
+// accumulateCellError moves error of decoding (or cast) of current cell value (if any)
+// into the list of cells errors and resets destination to zero value.
+// Errors of destination types and columns are not accumulated and break scanner immediately.
+// Scanner becomes broken only if count of cells errors exceeds the limit.
+func (s *scanner) accumulateCellError(dst interface{}) {
+	if s.cellErrorsLimit <= 0 {
+		return
+	}
+	s.errMtx.Lock()
+	defer s.errMtx.Unlock()
+	if s.err == nil || !s.cellErr {
+		return
+	}
+	s.cellErr = false
+	cellErr := result.CellError{
+		ResultSet: s.resultSet - 1,
+		Row:       s.nextRow - 1,
+		Column:    s.stack.scanItem.name,
+		Err:       s.err,
+	}
+	if len(s.cellErrors) >= s.cellErrorsLimit {
+		s.err = xerrors.WithStackTrace(fmt.Errorf("too many cells decoding errors (more than %d): %w",
+			s.cellErrorsLimit, cellErr,
+		))
+		return
+	}
+	s.cellErrors = append(s.cellErrors, cellErr)
+	if s.cellErrorsTotal == 0 {
+		s.firstCellError = cellErr
+	}
+	s.cellErrorsTotal++
+	s.err = nil
+	if rv := reflect.ValueOf(dst); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+	}
+}
+
+func (s *scanner) hasColumn(name string) bool {
+	for _, c := range s.set.GetColumns() {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// scanMissingColumn sets destination to zero value and remembers name of absent column
+func (s *scanner) scanMissingColumn(name string, dst interface{}) {
+	if rv := reflect.ValueOf(dst); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv.Elem().Set(reflect.Zero(rv.Elem().Type()))
+	}
+	s.errMtx.Lock()
+	defer s.errMtx.Unlock()
+	for _, c := range s.missingColumns {
+		if c == name {
+			return
+		}
+	}
+	s.missingColumns = append(s.missingColumns, name)
+}
+
+// MissingColumns returns names of columns which were scanned as zero values
+// because they are absent in result set
+func (s *scanner) MissingColumns() []string {
+	s.errMtx.RLock()
+	defer s.errMtx.RUnlock()
+	return append([]string(nil), s.missingColumns...)
+}
+
+// resetCellErrors clears cells decoding errors of the previous result set
+func (s *scanner) resetCellErrors() {
+	s.errMtx.Lock()
+	defer s.errMtx.Unlock()
+	s.cellErrors = nil
+}
+
+// CellErrors returns accumulated cells decoding errors of the current result set
+func (s *scanner) CellErrors() []result.CellError {
+	s.errMtx.RLock()
+	defer s.errMtx.RUnlock()
+	return append([]result.CellError(nil), s.cellErrors...)
+}
+
+// cellErrorsSummary returns error which summarize cells decoding errors accumulated over all result sets
+func (s *scanner) cellErrorsSummary() error {
+	s.errMtx.RLock()
+	defer s.errMtx.RUnlock()
+	if s.cellErrorsTotal == 0 {
+		return nil
+	}
+	return xerrors.WithStackTrace(fmt.Errorf("%d %w (first: %v)",
+		s.cellErrorsTotal, result.ErrCellErrors, s.firstCellError,
+	))
+}
+
 func (r *baseResult) SetErr(err error) {
 	r.errMtx.WithLock(func() {
 		r.err = err
@@ -1103,6 +1331,19 @@ func (s *scanner) errorf(depth int, f string, args ...interface{}) error {
 	return s.err
 }
 
+// cellErrorf is like errorf for errors of decoding (or cast) of current cell value,
+// which may be accumulated instead of breaking scanner (see WithAccumulateErrors)
+func (s *scanner) cellErrorf(depth int, f string, args ...interface{}) error {
+	s.errMtx.Lock()
+	defer s.errMtx.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.err = xerrors.WithStackTrace(fmt.Errorf(f, args...), xerrors.WithSkipDepth(depth+1))
+	s.cellErr = true
+	return s.err
+}
+
 func (s *scanner) typeError(act, exp interface{}) {
 	_ = s.errorf(
 		2,
@@ -1151,7 +1392,7 @@ func (s *scanner) noColumnError(name string) error {
 }
 
 func (s *scanner) overflowError(i, n interface{}) error {
-	return s.errorf(
+	return s.cellErrorf(
 		2,
 		"overflow error: %d overflows capacity of %t",
 		i,
@@ -21,6 +21,7 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/feature"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/meta"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/operation"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/paramscache"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/stack"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/scanner"
@@ -36,6 +37,8 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
 
+var _ table.ClosableSession = (*session)(nil)
+
 // session represents a single table API session.
 //
 // session methods are not goroutine safe. Simultaneous execution of requests
@@ -146,7 +149,7 @@ func newSession(ctx context.Context, cc grpc.ClientConnInterface, config *config
 		config: config,
 		status: table.SessionReady,
 	}
-	s.lastUsage.Store(time.Now().Unix())
+	s.lastUsage.Store(config.Clock().Now().Unix())
 
 	s.tableService = Ydb_Table_V1.NewTableServiceClient(
 		conn.WithBeforeFunc(
@@ -154,7 +157,7 @@ func newSession(ctx context.Context, cc grpc.ClientConnInterface, config *config
 				return meta.WithTrailerCallback(balancerContext.WithEndpoint(ctx, s), s.checkCloseHint)
 			}),
 			func() {
-				s.lastUsage.Store(time.Now().Unix())
+				s.lastUsage.Store(s.config.Clock().Now().Unix())
 			},
 		),
 	)
@@ -184,7 +187,7 @@ func (s *session) Close(ctx context.Context) (err error) {
 			onDone(err)
 		}()
 
-		if time.Since(s.LastUsage()) < s.config.IdleThreshold() {
+		if s.config.Clock().Since(s.LastUsage()) < s.config.IdleThreshold() {
 			_, err = s.tableService.DeleteSession(ctx,
 				&Ydb_Table.DeleteSessionRequest{
 					SessionId: s.id,
@@ -336,9 +339,13 @@ func (s *session) DescribeTable(
 		len(result.GetColumns()),
 	)
 	for i, c := range result.Columns {
+		t, err := value.TypeFromYDBWithError(c.GetType())
+		if err != nil {
+			return desc, xerrors.WithStackTrace(fmt.Errorf("type of column %q: %w", c.GetName(), err))
+		}
 		cs[i] = options.Column{
 			Name:   c.GetName(),
-			Type:   value.TypeFromYDB(c.GetType()),
+			Type:   t,
 			Family: c.GetFamily(),
 		}
 	}
@@ -353,7 +360,10 @@ func (s *session) DescribeTable(
 			rs[i].From = last
 		}
 
-		bound := value.FromYDB(b.GetType(), b.GetValue())
+		bound, err := value.FromYDBWithError(b.GetType(), b.GetValue())
+		if err != nil {
+			return desc, xerrors.WithStackTrace(err)
+		}
 		rs[i].To = bound
 
 		last = bound
@@ -688,7 +698,14 @@ func (s *session) Prepare(ctx context.Context, queryText string) (_ table.Statem
 	stmt = &statement{
 		session: s,
 		query:   queryPrepared(result.GetQueryId(), queryText),
-		params:  result.ParametersTypes,
+		params:  make(map[string]types.Type, len(result.GetParametersTypes())),
+	}
+	for name, t := range result.GetParametersTypes() {
+		tt, err := value.TypeFromYDBWithError(t)
+		if err != nil {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("type of parameter %q: %w", name, err))
+		}
+		stmt.params[name] = tt
 	}
 
 	return stmt, nil
@@ -703,6 +720,24 @@ func (s *session) Execute(
 	opts ...options.ExecuteDataQueryOption,
 ) (
 	txr table.Transaction, r result.Result, err error,
+) {
+	return s.execute(ctx, stack.FunctionID(""), txControl,
+		txConsistency(txControl.Desc().GetBeginTx()),
+		query, params, opts...,
+	)
+}
+
+// execute executes data query in transaction with consistency mode and traces it as call
+func (s *session) execute(
+	ctx context.Context,
+	call interface{ FunctionID() string },
+	txControl *table.TransactionControl,
+	consistency result.Consistency,
+	query string,
+	params *table.QueryParameters,
+	opts ...options.ExecuteDataQueryOption,
+) (
+	txr table.Transaction, r result.Result, err error,
 ) {
 	var (
 		a       = allocator.New()
@@ -717,10 +752,10 @@ func (s *session) Execute(
 
 	request.SessionId = s.id
 	request.TxControl = txControl.Desc()
-	request.Parameters = params.Params().ToYDB(a)
+	request.Parameters = paramscache.ToYDB(ctx, params, a, params.Params().ToYDB)
 	request.Query = q.toYDB(a)
 	request.QueryCachePolicy = a.TableQueryCachePolicy()
-	request.QueryCachePolicy.KeepInCache = len(params.Params()) > 0
+	request.QueryCachePolicy.KeepInCache = keepInCache(s.config, params)
 	request.OperationParams = operation.Params(ctx,
 		s.config.OperationTimeout(),
 		s.config.OperationCancelAfter(),
@@ -733,36 +768,47 @@ func (s *session) Execute(
 		}
 	}
 
+	if s.config.QueryParametersCheck() || request.CheckParameters {
+		if err = checkQueryParams(query, params); err != nil {
+			return nil, nil, xerrors.WithStackTrace(err)
+		}
+	}
+	s.checkTimestamps(params)
+
 	onDone := trace.TableOnSessionQueryExecute(
 		s.config.Trace(), &ctx,
-		stack.FunctionID(""),
+		call,
 		s, q, params,
 		request.QueryCachePolicy.GetKeepInCache(),
+		consistency.String(),
 	)
 	defer func() {
 		onDone(txr, false, r, err)
 	}()
 
-	result, err := s.executeDataQuery(ctx, a, request.ExecuteDataQueryRequest, callOptions...)
+	result, sets, err := s.executeDataQuery(ctx, a, request.ExecuteDataQueryRequest, callOptions...)
 	if err != nil {
 		return nil, nil, xerrors.WithStackTrace(err)
 	}
 
-	return s.executeQueryResult(result, request.TxControl, request.IgnoreTruncated)
+	return s.executeQueryResult(result, sets, request.TxControl, consistency, &request)
 }
 
 // executeQueryResult returns Transaction and result built from received
-// result.
+// result. If sets is not nil, result sets are decoded from sets on demand.
 func (s *session) executeQueryResult(
 	res *Ydb_Table.ExecuteQueryResult,
+	sets [][]byte,
 	txControl *Ydb_Table.TransactionControl,
-	ignoreTruncated bool,
+	consistency result.Consistency,
+	request *options.ExecuteDataQueryDesc,
 ) (
 	table.Transaction, result.Result, error,
 ) {
 	tx := &transaction{
-		id: res.GetTxMeta().GetId(),
-		s:  s,
+		id:          res.GetTxMeta().GetId(),
+		s:           s,
+		consistency: consistency,
 	}
 	if txControl.CommitTx {
 		tx.state.Store(txStateCommitted)
@@ -770,19 +816,37 @@ func (s *session) executeQueryResult(
 		tx.state.Store(txStateInitialized)
 		tx.control = table.TxControl(table.WithTxID(tx.id))
 	}
+	if sets != nil {
+		return tx, scanner.NewLazyUnary(
+			sets,
+			res.GetQueryStats(),
+			scanner.WithIgnoreTruncated(request.IgnoreTruncated),
+			scanner.WithAccumulateErrors(request.AccumulateErrorsLimit),
+			scanner.WithMissingColumnsAsZero(request.MissingColumnsAsZero),
+			scanner.WithStrictTypes(s.config.StrictTypes()),
+			scanner.WithTx(tx.id, consistency),
+		), nil
+	}
 	return tx, scanner.NewUnary(
 		res.GetResultSets(),
 		res.GetQueryStats(),
-		scanner.WithIgnoreTruncated(ignoreTruncated),
+		scanner.WithIgnoreTruncated(request.IgnoreTruncated),
+		scanner.WithAccumulateErrors(request.AccumulateErrorsLimit),
+		scanner.WithMissingColumnsAsZero(request.MissingColumnsAsZero),
+		scanner.WithStrictTypes(s.config.StrictTypes()),
+		scanner.WithTx(tx.id, consistency),
 	), nil
 }
 
 // executeDataQuery executes data query.
+// Result sets are returned encoded (for decoding on demand) unless result size thresholds
+// are configured, which require decoding of all result sets for counting of rows.
 func (s *session) executeDataQuery(
 	ctx context.Context, a *allocator.Allocator, request *Ydb_Table.ExecuteDataQueryRequest,
 	callOptions ...grpc.CallOption,
 ) (
 	_ *Ydb_Table.ExecuteQueryResult,
+	sets [][]byte,
 	err error,
 ) {
 	var (
@@ -792,15 +856,19 @@ func (s *session) executeDataQuery(
 
 	response, err = s.tableService.ExecuteDataQuery(ctx, request, callOptions...)
 	if err != nil {
-		return nil, xerrors.WithStackTrace(err)
+		return nil, nil, xerrors.WithStackTrace(err)
 	}
 
-	err = response.GetOperation().GetResult().UnmarshalTo(result)
+	if s.config.ResultSizeLimit() != (config.ResultSize{}) || s.config.ResultSizeWarning() != (config.ResultSize{}) {
+		err = s.checkedQueryResult(response.GetOperation().GetResult().GetValue(), request.GetQuery(), result)
+	} else {
+		sets, err = splitQueryResult(response.GetOperation().GetResult().GetValue(), result)
+	}
 	if err != nil {
-		return nil, xerrors.WithStackTrace(err)
+		return nil, nil, xerrors.WithStackTrace(err)
 	}
 
-	return result, nil
+	return result, sets, nil
 }
 
 // ExecuteSchemeQuery executes scheme query.
@@ -980,8 +1048,11 @@ func (s *session) StreamReadTable(
 			SessionId: s.id,
 			Path:      path,
 		}
-		stream Ydb_Table_V1.TableService_StreamReadTableClient
-		a      = allocator.New()
+		checkpoint      readTableCheckpoint
+		stream          Ydb_Table_V1.TableService_StreamReadTableClient
+		a               = allocator.New()
+		keyColumns      []string
+		cellErrorsLimit int
 	)
 	defer func() {
 		a.Free()
@@ -993,6 +1064,17 @@ func (s *session) StreamReadTable(
 	for _, opt := range opts {
 		if opt != nil {
 			opt.ApplyReadTableOption((*options.ReadTableDesc)(&request), a)
+			checkpoint.apply(opt)
+			if limit, has := accumulateErrorsLimit(opt); has {
+				cellErrorsLimit = limit
+			}
+		}
+	}
+
+	if checkpoint.enabled {
+		keyColumns, err = s.readTableFromCheckpoint(ctx, path, &request, checkpoint.value)
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
 		}
 	}
 
@@ -1032,9 +1114,78 @@ func (s *session) StreamReadTable(
 			return err
 		},
 		scanner.WithIgnoreTruncated(true), // stream read table always returns truncated flag on last result set
+		scanner.WithAccumulateErrors(cellErrorsLimit),
+		scanner.WithStrictTypes(s.config.StrictTypes()),
+		scanner.WithCheckpoint(keyColumns, checkpoint.value),
 	)
 }
 
+// accumulateErrorsLimit returns limit of cells decoding errors of streaming result
+// which is passed with options.WithAccumulateErrors
+func accumulateErrorsLimit(opt interface{}) (int, bool) {
+	if o, ok := opt.(interface{ AccumulateErrorsLimit() int }); ok {
+		return o.AccumulateErrorsLimit(), true
+	}
+	return 0, false
+}
+
+// readTableCheckpoint is a state of checkpoints of stream read table
+// which is passed with options.ReadFromCheckpoint
+type readTableCheckpoint struct {
+	enabled bool
+	value   string
+}
+
+func (c *readTableCheckpoint) apply(opt options.ReadTableOption) {
+	if o, ok := opt.(interface{ ReadTableCheckpoint() string }); ok {
+		c.enabled = true
+		c.value = o.ReadTableCheckpoint()
+	}
+}
+
+// readTableFromCheckpoint makes ordered read table request to read rows after checkpoint
+// and returns primary key columns of table for checkpoints of result
+func (s *session) readTableFromCheckpoint(
+	ctx context.Context, path string, request *Ydb_Table.ReadTableRequest, checkpoint string,
+) (keyColumns []string, _ error) {
+	d, err := s.DescribeTable(ctx, path)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	if len(request.Columns) > 0 {
+		columns := make(map[string]struct{}, len(request.Columns))
+		for _, name := range request.Columns {
+			columns[name] = struct{}{}
+		}
+		for _, name := range d.PrimaryKey {
+			if _, has := columns[name]; !has {
+				return nil, xerrors.WithStackTrace(fmt.Errorf(
+					"%w: key column %q not in read columns", errReadTableCheckpoint, name,
+				))
+			}
+		}
+	}
+	request.Ordered = true
+	if checkpoint != "" {
+		key, err := scanner.DecodeCheckpoint(checkpoint)
+		if err != nil {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %v", errReadTableCheckpoint, err))
+		}
+		if n := len(key.GetType().GetTupleType().GetElements()); n != len(d.PrimaryKey) {
+			return nil, xerrors.WithStackTrace(fmt.Errorf(
+				"%w: checkpoint has %d key columns, table has %d", errReadTableCheckpoint, n, len(d.PrimaryKey),
+			))
+		}
+		if request.KeyRange == nil {
+			request.KeyRange = new(Ydb_Table.KeyRange)
+		}
+		request.KeyRange.FromBound = &Ydb_Table.KeyRange_Greater{
+			Greater: key,
+		}
+	}
+	return d.PrimaryKey, nil
+}
+
 func (s *session) ReadRows(
 	ctx context.Context,
 	path string,
@@ -1077,6 +1228,7 @@ func (s *session) ReadRows(
 		[]*Ydb.ResultSet{response.GetResultSet()},
 		nil,
 		scanner.WithIgnoreTruncated(s.config.IgnoreTruncated()),
+		scanner.WithStrictTypes(s.config.StrictTypes()),
 	), nil
 }
 
@@ -1101,11 +1253,12 @@ func (s *session) StreamExecuteScanQuery(
 		)
 		request = Ydb_Table.ExecuteScanQueryRequest{
 			Query:      q.toYDB(a),
-			Parameters: params.Params().ToYDB(a),
+			Parameters: paramscache.ToYDB(ctx, params, a, params.Params().ToYDB),
 			Mode:       Ydb_Table.ExecuteScanQueryRequest_MODE_EXEC, // set default
 		}
-		stream      Ydb_Table_V1.TableService_StreamExecuteScanQueryClient
-		callOptions []grpc.CallOption
+		stream          Ydb_Table_V1.TableService_StreamExecuteScanQueryClient
+		callOptions     []grpc.CallOption
+		cellErrorsLimit int
 	)
 	defer func() {
 		a.Free()
@@ -1117,6 +1270,9 @@ func (s *session) StreamExecuteScanQuery(
 	for _, opt := range opts {
 		if opt != nil {
 			callOptions = append(callOptions, opt.ApplyExecuteScanQueryOption((*options.ExecuteScanQueryDesc)(&request))...)
+			if limit, has := accumulateErrorsLimit(opt); has {
+				cellErrorsLimit = limit
+			}
 		}
 	}
 
@@ -1157,6 +1313,8 @@ func (s *session) StreamExecuteScanQuery(
 		},
 		scanner.WithIgnoreTruncated(s.config.IgnoreTruncated()),
 		scanner.WithMarkTruncatedAsRetryable(),
+		scanner.WithAccumulateErrors(cellErrorsLimit),
+		scanner.WithStrictTypes(s.config.StrictTypes()),
 	)
 }
 
@@ -1241,9 +1399,10 @@ func (s *session) BeginTransaction(
 		return nil, xerrors.WithStackTrace(err)
 	}
 	tx := &transaction{
-		id:      result.GetTxMeta().GetId(),
-		s:       s,
-		control: table.TxControl(table.WithTxID(result.GetTxMeta().GetId())),
+		id:          result.GetTxMeta().GetId(),
+		s:           s,
+		control:     table.TxControl(table.WithTxID(result.GetTxMeta().GetId())),
+		consistency: txConsistency(txSettings.Settings()),
 	}
 	tx.state.Store(txStateInitialized)
 	return tx, nil
@@ -0,0 +1,146 @@
+package table
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/backoff"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+)
+
+const (
+	// sessionLimitMinInterval is a minimal interval between creations of sessions
+	// after cooldown. Recovery is finished when interval becomes less than it
+	sessionLimitMinInterval = 10 * time.Millisecond
+
+	// sessionLimitIssue is a part of issue message of exceeded server limit of sessions
+	sessionLimitIssue = "too many sessions"
+)
+
+// isServerSessionLimitExceeded checks that err is an error of exceeding of server limit of sessions.
+// Only OVERLOADED errors are checked with matcher (nil matcher checks issue message)
+func isServerSessionLimitExceeded(err error, matcher func(err error) bool) bool {
+	if !xerrors.IsOperationError(err, Ydb.StatusIds_OVERLOADED) {
+		return false
+	}
+	if matcher != nil {
+		return matcher(err)
+	}
+	return hasSessionLimitIssue(err)
+}
+
+// hasSessionLimitIssue checks that err has issue with message about exceeded server limit of sessions.
+// Matching depends on text of server message, other matchers are defined with config.WithSessionLimitMatcher
+func hasSessionLimitIssue(err error) (has bool) {
+	xerrors.IterateByIssues(err, func(message string, _ Ydb.StatusIds_StatusCode, _ uint32) {
+		if strings.Contains(strings.ToLower(message), sessionLimitIssue) {
+			has = true
+		}
+	})
+	return has
+}
+
+// serverSessionLimitError makes retryable (with slow backoff) error of suspended sessions creation
+func serverSessionLimitError(cooldown time.Duration) error {
+	return xerrors.WithStackTrace(xerrors.Retryable(
+		&table.ServerSessionLimitError{Cooldown: cooldown},
+		xerrors.WithBackoff(backoff.TypeSlow),
+		xerrors.WithName("ServerSessionLimit"),
+	))
+}
+
+// sessionLimiter suspends creation of sessions by all of session pool callers after
+// exceeding of server limit of sessions. After cooldown creation of sessions resumes
+// gradually: with half of rate of sessions creation before exceeding of limit
+type sessionLimiter struct {
+	clock    clockwork.Clock
+	cooldown time.Duration
+
+	mu sync.Mutex
+	// until is an end of cooldown
+	until time.Time
+	// interval is a minimal interval between creations of sessions during recovery
+	// (zero if creation of sessions is not limited)
+	interval time.Duration
+	// next is a time of next allowed creation of session during recovery
+	next time.Time
+	// lastCreated is a time of last created session
+	lastCreated time.Time
+	// lastInterval is an interval between two last created sessions
+	lastInterval time.Duration
+}
+
+func newSessionLimiter(clock clockwork.Clock, cooldown time.Duration) *sessionLimiter {
+	return &sessionLimiter{
+		clock:    clock,
+		cooldown: cooldown,
+	}
+}
+
+// acquire checks that creation of session is allowed now.
+// Otherwise, acquire returns remaining time until creation of session will be allowed
+func (l *sessionLimiter) acquire() (wait time.Duration, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if now.Before(l.until) {
+		return l.until.Sub(now), false
+	}
+	if l.interval == 0 {
+		return 0, true
+	}
+	if now.Before(l.next) {
+		return l.next.Sub(now), false
+	}
+	l.next = now.Add(l.interval)
+	return 0, true
+}
+
+// created registers successfully created session and speeds up creation of sessions during recovery
+func (l *sessionLimiter) created() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if !l.lastCreated.IsZero() {
+		l.lastInterval = now.Sub(l.lastCreated)
+	}
+	l.lastCreated = now
+
+	if l.interval > 0 {
+		l.interval -= l.interval / 4
+		if l.interval < sessionLimitMinInterval {
+			l.interval = 0
+		}
+	}
+}
+
+// exceeded registers exceeding of server limit of sessions and suspends creation of sessions
+// for cooldown. After cooldown, sessions are created with half of previous rate
+func (l *sessionLimiter) exceeded() (cooldown, interval time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	previous := l.interval
+	if previous == 0 {
+		previous = l.lastInterval
+	}
+	if previous < sessionLimitMinInterval {
+		previous = sessionLimitMinInterval
+	}
+	l.interval = 2 * previous
+	if l.interval > l.cooldown {
+		l.interval = l.cooldown
+	}
+	l.until = now.Add(l.cooldown)
+	l.next = l.until
+
+	return l.cooldown, l.interval
+}
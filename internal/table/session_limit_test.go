@@ -0,0 +1,295 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/testutil"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+var errTooManySessions = xerrors.Operation(
+	xerrors.WithStatusCode(Ydb.StatusIds_OVERLOADED),
+	xerrors.WithIssues([]*Ydb_Issue.IssueMessage{{
+		Message: "Too many sessions: 1000",
+	}}),
+)
+
+func TestIsServerSessionLimitExceeded(t *testing.T) {
+	require.True(t, isServerSessionLimitExceeded(errTooManySessions, nil))
+	require.True(t, isServerSessionLimitExceeded(xerrors.WithStackTrace(errTooManySessions), nil))
+	require.False(t, isServerSessionLimitExceeded(
+		xerrors.Operation(xerrors.WithStatusCode(Ydb.StatusIds_OVERLOADED)), nil,
+	))
+	require.False(t, isServerSessionLimitExceeded(xerrors.Operation(
+		xerrors.WithStatusCode(Ydb.StatusIds_UNAVAILABLE),
+		xerrors.WithIssues([]*Ydb_Issue.IssueMessage{{
+			Message: "Too many sessions: 1000",
+		}}),
+	), nil))
+	require.False(t, isServerSessionLimitExceeded(errors.New("too many sessions"), nil))
+
+	t.Run("Matcher", func(t *testing.T) {
+		const issueCode = 2029
+		matcher := func(err error) (matched bool) {
+			xerrors.IterateByIssues(err, func(_ string, code Ydb.StatusIds_StatusCode, _ uint32) {
+				if code == issueCode {
+					matched = true
+				}
+			})
+			return matched
+		}
+		errReworded := xerrors.Operation(
+			xerrors.WithStatusCode(Ydb.StatusIds_OVERLOADED),
+			xerrors.WithIssues([]*Ydb_Issue.IssueMessage{{
+				Message:   "Sessions limit is reached",
+				IssueCode: issueCode,
+			}}),
+		)
+		require.False(t, isServerSessionLimitExceeded(errReworded, nil))
+		require.True(t, isServerSessionLimitExceeded(errReworded, matcher))
+		require.False(t, isServerSessionLimitExceeded(errTooManySessions, matcher))
+		// matcher is called for OVERLOADED errors only
+		require.False(t, isServerSessionLimitExceeded(xerrors.Operation(
+			xerrors.WithStatusCode(Ydb.StatusIds_UNAVAILABLE),
+			xerrors.WithIssues([]*Ydb_Issue.IssueMessage{{
+				IssueCode: issueCode,
+			}}),
+		), matcher))
+	})
+}
+
+func TestSessionLimiter(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+	l := newSessionLimiter(clock, 5*time.Second)
+
+	// sessions created with interval of 100ms before exceeding of limit
+	for i := 0; i < 10; i++ {
+		_, ok := l.acquire()
+		require.True(t, ok)
+		l.created()
+		clock.Advance(100 * time.Millisecond)
+	}
+
+	cooldown, interval := l.exceeded()
+	require.Equal(t, 5*time.Second, cooldown)
+	require.Equal(t, 200*time.Millisecond, interval, "half of rate before exceeding of limit")
+
+	t.Run("Cooldown", func(t *testing.T) {
+		wait, ok := l.acquire()
+		require.False(t, ok)
+		require.Equal(t, 5*time.Second, wait)
+
+		clock.Advance(3 * time.Second)
+		wait, ok = l.acquire()
+		require.False(t, ok)
+		require.Equal(t, 2*time.Second, wait)
+
+		clock.Advance(2 * time.Second)
+		_, ok = l.acquire()
+		require.True(t, ok)
+	})
+
+	t.Run("RampUp", func(t *testing.T) {
+		wait, ok := l.acquire()
+		require.False(t, ok)
+		require.Equal(t, 200*time.Millisecond, wait)
+
+		var intervals []time.Duration
+		for l.interval > 0 {
+			intervals = append(intervals, l.interval)
+			wait, ok = l.acquire()
+			if !ok {
+				clock.Advance(wait)
+				_, ok = l.acquire()
+			}
+			require.True(t, ok)
+			l.created()
+		}
+		require.Equal(t, 200*time.Millisecond, intervals[0])
+		for i := 1; i < len(intervals); i++ {
+			require.Less(t, intervals[i], intervals[i-1])
+		}
+
+		// recovered: creation of sessions is not limited
+		for i := 0; i < 10; i++ {
+			_, ok = l.acquire()
+			require.True(t, ok)
+		}
+	})
+
+	t.Run("ExceededDuringRecovery", func(t *testing.T) {
+		_, interval := l.exceeded()
+		clock.Advance(5 * time.Second)
+		_, ok := l.acquire()
+		require.True(t, ok)
+		l.created()
+
+		_, next := l.exceeded()
+		require.Equal(t, 2*(interval-interval/4), next)
+
+		// interval is limited by cooldown
+		for i := 0; i < 10; i++ {
+			_, next = l.exceeded()
+		}
+		require.Equal(t, 5*time.Second, next)
+	})
+}
+
+func TestSessionPoolServerSessionLimit(t *testing.T) {
+	var (
+		clock         = clockwork.NewFakeClock()
+		limitExceeded = true
+		creates       int
+		limits        []trace.TablePoolSessionLimitInfo
+	)
+	cfg := config.New(
+		config.WithClock(clock),
+		config.WithIdleThreshold(-1),
+		config.WithSessionLimitCooldown(time.Second),
+		config.WithTrace(&trace.Table{
+			OnPoolSessionLimit: func(info trace.TablePoolSessionLimitInfo) {
+				limits = append(limits, info)
+			},
+		}),
+	)
+	b := testutil.NewBalancer(
+		testutil.WithInvokeHandlers(
+			testutil.InvokeHandlers{
+				testutil.TableDeleteSession: okHandler,
+				testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+					creates++
+					if limitExceeded {
+						return nil, errTooManySessions
+					}
+					return &Ydb_Table.CreateSessionResult{
+						SessionId: testutil.SessionID(),
+					}, nil
+				},
+			},
+		),
+	)
+	c, err := New(context.Background(), b, cfg)
+	require.NoError(t, err)
+	defer func() {
+		_ = c.Close(context.Background())
+	}()
+
+	requireSessionLimit := func(t *testing.T, cooldown time.Duration) {
+		_, err := c.Get(context.Background())
+		require.ErrorIs(t, err, table.ErrServerSessionLimit)
+		var limitErr *table.ServerSessionLimitError
+		require.ErrorAs(t, err, &limitErr)
+		require.Equal(t, cooldown, limitErr.Cooldown)
+		require.True(t, retry.Check(err).MustRetry(true))
+	}
+
+	t.Run("Exceeded", func(t *testing.T) {
+		requireSessionLimit(t, time.Second)
+		require.Equal(t, 1, creates)
+		require.Len(t, limits, 1)
+		require.Equal(t, time.Second, limits[0].Cooldown)
+		require.ErrorIs(t, limits[0].Error, errTooManySessions)
+	})
+
+	t.Run("Cooldown", func(t *testing.T) {
+		clock.Advance(400 * time.Millisecond)
+		requireSessionLimit(t, 600*time.Millisecond)
+		// cooldown is shared: no create session requests during cooldown
+		require.Equal(t, 1, creates)
+		require.Len(t, limits, 1)
+	})
+
+	t.Run("RampUp", func(t *testing.T) {
+		limitExceeded = false
+		clock.Advance(600 * time.Millisecond)
+
+		s1, err := c.Get(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 2, creates)
+
+		// creation of sessions resumes gradually
+		requireSessionLimit(t, limits[0].Interval)
+		require.Equal(t, 2, creates)
+
+		clock.Advance(limits[0].Interval)
+		s2, err := c.Get(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 3, creates)
+
+		require.NoError(t, c.Put(context.Background(), s1))
+		require.NoError(t, c.Put(context.Background(), s2))
+	})
+}
+
+func TestSessionPoolOverloadedWithoutSessionLimit(t *testing.T) {
+	var (
+		clock   = clockwork.NewFakeClock()
+		creates int
+		limits  []trace.TablePoolSessionLimitInfo
+	)
+	errOverloaded := xerrors.Operation(
+		xerrors.WithStatusCode(Ydb.StatusIds_OVERLOADED),
+		xerrors.WithIssues([]*Ydb_Issue.IssueMessage{{
+			Message: "Throughput limit exceeded",
+		}}),
+	)
+	cfg := config.New(
+		config.WithClock(clock),
+		config.WithIdleThreshold(-1),
+		config.WithTrace(&trace.Table{
+			OnPoolSessionLimit: func(info trace.TablePoolSessionLimitInfo) {
+				limits = append(limits, info)
+			},
+		}),
+	)
+	b := testutil.NewBalancer(
+		testutil.WithInvokeHandlers(
+			testutil.InvokeHandlers{
+				testutil.TableDeleteSession: okHandler,
+				testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+					creates++
+					if creates == 1 {
+						return nil, errOverloaded
+					}
+					return &Ydb_Table.CreateSessionResult{
+						SessionId: testutil.SessionID(),
+					}, nil
+				},
+			},
+		),
+	)
+	c, err := New(context.Background(), b, cfg)
+	require.NoError(t, err)
+	defer func() {
+		_ = c.Close(context.Background())
+	}()
+
+	// OVERLOADED error of session creation is retriable, so Get would wait for session in pool
+	_, err = c.internalPoolCreateSession(context.Background())
+	require.Error(t, err)
+	require.NotErrorIs(t, err, table.ErrServerSessionLimit)
+	require.True(t, xerrors.IsOperationError(err, Ydb.StatusIds_OVERLOADED))
+	require.Empty(t, limits)
+
+	// limiter is untouched: next session is created immediately
+	_, ok := c.limiter.acquire()
+	require.True(t, ok)
+	s, err := c.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, creates)
+	require.NoError(t, c.Put(context.Background(), s))
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Scheme"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -27,6 +30,8 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xtest"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result/named"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 	"github.com/ydb-platform/ydb-go-sdk/v3/testutil"
 )
@@ -759,3 +764,322 @@ func Test_copyTables(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionExecuteKeepInCache(t *testing.T) {
+	var (
+		keepInCache bool
+		fromCache   bool
+	)
+	b := testutil.NewBalancer(
+		testutil.WithInvokeHandlers(
+			testutil.InvokeHandlers{
+				testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+					return &Ydb_Table.CreateSessionResult{
+						SessionId: testutil.SessionID(),
+					}, nil
+				},
+				testutil.TableExecuteDataQuery: func(request interface{}) (proto.Message, error) {
+					r, ok := request.(*Ydb_Table.ExecuteDataQueryRequest)
+					if !ok {
+						return nil, fmt.Errorf("unexpected request type: %T", request)
+					}
+					keepInCache = r.GetQueryCachePolicy().GetKeepInCache()
+					return &Ydb_Table.ExecuteQueryResult{
+						TxMeta: &Ydb_Table.TransactionMeta{Id: "tx"},
+						QueryStats: &Ydb_TableStats.QueryStats{
+							Compilation: &Ydb_TableStats.CompilationStats{
+								FromCache: fromCache,
+							},
+						},
+					}, nil
+				},
+			},
+		),
+	)
+	params := table.NewQueryParameters(table.ValueParam("$a", types.Int32Value(1)))
+	for _, tt := range []struct {
+		name   string
+		config []config.Option
+		params *table.QueryParameters
+		opts   []options.ExecuteDataQueryOption
+		exp    bool
+	}{
+		{
+			name: "DefaultWithoutParams",
+			exp:  false,
+		},
+		{
+			name:   "DefaultWithParams",
+			params: params,
+			exp:    true,
+		},
+		{
+			name:   "DisabledByConfig",
+			config: []config.Option{config.WithKeepInCache(false)},
+			params: params,
+			exp:    false,
+		},
+		{
+			name:   "EnabledByConfig",
+			config: []config.Option{config.WithKeepInCache(true)},
+			exp:    true,
+		},
+		{
+			name:   "DisabledByOption",
+			params: params,
+			opts:   []options.ExecuteDataQueryOption{options.WithKeepInCache(false)},
+			exp:    false,
+		},
+		{
+			name:   "EnabledByOptionOverConfig",
+			config: []config.Option{config.WithKeepInCache(false)},
+			opts:   []options.ExecuteDataQueryOption{options.WithKeepInCache(true)},
+			exp:    true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSession(context.Background(), b, config.New(tt.config...))
+			require.NoError(t, err)
+
+			_, _, err = s.Execute(context.Background(), table.DefaultTxControl(), "SELECT 1;", tt.params, tt.opts...)
+			require.NoError(t, err)
+			require.Equal(t, tt.exp, keepInCache)
+		})
+	}
+
+	t.Run("QueryCacheHit", func(t *testing.T) {
+		s, err := newSession(context.Background(), b, config.New())
+		require.NoError(t, err)
+		for _, fromCache = range []bool{false, true} {
+			_, res, err := s.Execute(context.Background(), table.DefaultTxControl(), "SELECT 1;", nil)
+			require.NoError(t, err)
+			hit, ok := result.QueryCacheHit(res)
+			require.True(t, ok)
+			require.Equal(t, fromCache, hit)
+		}
+	})
+}
+
+// readTableStream is a stream of read table response over table with Uint64 primary key "id"
+type readTableStream struct {
+	grpc.ClientStream
+
+	ctx      context.Context //nolint:containedctx
+	keys     []uint64
+	partSize int
+	requests *[]*Ydb_Table.ReadTableRequest
+}
+
+func (s *readTableStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *readTableStream) CloseSend() error {
+	return nil
+}
+
+func (s *readTableStream) SendMsg(m interface{}) error {
+	request := m.(*Ydb_Table.ReadTableRequest)
+	*s.requests = append(*s.requests, request)
+	if greater := request.GetKeyRange().GetGreater(); greater != nil {
+		from := greater.GetValue().GetItems()[0].GetUint64Value()
+		for len(s.keys) > 0 && s.keys[0] <= from {
+			s.keys = s.keys[1:]
+		}
+	}
+	return nil
+}
+
+func (s *readTableStream) RecvMsg(m interface{}) error {
+	if len(s.keys) == 0 {
+		return io.EOF
+	}
+	n := s.partSize
+	if n > len(s.keys) {
+		n = len(s.keys)
+	}
+	set := &Ydb.ResultSet{
+		Columns: []*Ydb.Column{
+			{Name: "value", Type: &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_UTF8}}},
+			{Name: "id", Type: &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_UINT64}}},
+		},
+	}
+	for _, key := range s.keys[:n] {
+		set.Rows = append(set.Rows, &Ydb.Value{
+			Items: []*Ydb.Value{
+				{Value: &Ydb.Value_TextValue{TextValue: strconv.FormatUint(key, 10)}},
+				{Value: &Ydb.Value_Uint64Value{Uint64Value: key}},
+			},
+		})
+	}
+	s.keys = s.keys[n:]
+	m.(*Ydb_Table.ReadTableResponse).Result = &Ydb_Table.ReadTableResult{ResultSet: set}
+	return nil
+}
+
+// evenID is a destination which fails to decode odd ids
+type evenID uint64
+
+func (id *evenID) Scan(src interface{}) error {
+	v, ok := src.(uint64)
+	if !ok || v%2 != 0 {
+		return fmt.Errorf("not even id: %v", src)
+	}
+	*id = evenID(v)
+	return nil
+}
+
+func TestSessionStreamReadTableCheckpoint(t *testing.T) {
+	const rows = 10
+	var requests []*Ydb_Table.ReadTableRequest
+	b := testutil.NewBalancer(
+		testutil.WithInvokeHandlers(
+			testutil.InvokeHandlers{
+				testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+					return &Ydb_Table.CreateSessionResult{
+						SessionId: testutil.SessionID(),
+					}, nil
+				},
+				testutil.TableDescribeTable: func(interface{}) (proto.Message, error) {
+					return &Ydb_Table.DescribeTableResult{
+						PrimaryKey: []string{"id"},
+					}, nil
+				},
+			},
+		),
+		testutil.WithNewStreamHandlers(
+			testutil.NewStreamHandlers{
+				testutil.TableStreamReadTable: func(*grpc.StreamDesc) (grpc.ClientStream, error) {
+					s := &readTableStream{
+						ctx:      context.Background(),
+						partSize: 3,
+						requests: &requests,
+					}
+					for key := uint64(1); key <= rows; key++ {
+						s.keys = append(s.keys, key)
+					}
+					return s, nil
+				},
+			},
+		),
+	)
+	s, err := newSession(context.Background(), b, config.New())
+	require.NoError(t, err)
+
+	// read ids from stream until limit of rows and returns checkpoint
+	read := func(t *testing.T, checkpoint string, limit int, opts ...options.ReadTableOption) (ids []uint64, _ string) {
+		res, err := s.StreamReadTable(context.Background(), "table",
+			append(opts, options.ReadFromCheckpoint(checkpoint))...,
+		)
+		require.NoError(t, err)
+		defer func() {
+			_ = res.Close()
+		}()
+		for len(ids) < limit && res.NextResultSet(context.Background()) {
+			for len(ids) < limit && res.NextRow() {
+				var id uint64
+				require.NoError(t, res.ScanNamed(named.Required("id", &id)))
+				ids = append(ids, id)
+			}
+		}
+		require.NoError(t, res.Err())
+		checkpoint, err = result.Checkpoint(res)
+		require.NoError(t, err)
+		return ids, checkpoint
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		requests = nil
+		first, checkpoint := read(t, "", 6)
+		require.Equal(t, []uint64{1, 2, 3, 4, 5, 6}, first)
+		require.NotEmpty(t, checkpoint)
+
+		second, last := read(t, checkpoint, rows)
+		require.Equal(t, []uint64{7, 8, 9, 10}, second)
+		require.NotEqual(t, checkpoint, last)
+
+		require.Len(t, requests, 2)
+		for _, r := range requests {
+			require.True(t, r.GetOrdered())
+		}
+		require.Nil(t, requests[0].GetKeyRange())
+		require.NotNil(t, requests[1].GetKeyRange().GetGreater())
+	})
+
+	t.Run("PartiallyConsumedPart", func(t *testing.T) {
+		// rows of partially consumed part are read again
+		first, checkpoint := read(t, "", 5)
+		require.Equal(t, []uint64{1, 2, 3, 4, 5}, first)
+
+		second, _ := read(t, checkpoint, rows)
+		require.Equal(t, []uint64{4, 5, 6, 7, 8, 9, 10}, second)
+	})
+
+	t.Run("NothingConsumed", func(t *testing.T) {
+		first, checkpoint := read(t, "", 6)
+		require.Len(t, first, 6)
+
+		ids, same := read(t, checkpoint, 0)
+		require.Empty(t, ids)
+		require.Equal(t, checkpoint, same)
+	})
+
+	t.Run("KeyColumnNotRead", func(t *testing.T) {
+		_, err := s.StreamReadTable(context.Background(), "table",
+			options.ReadColumns("value"),
+			options.ReadFromCheckpoint(""),
+		)
+		require.ErrorIs(t, err, errReadTableCheckpoint)
+	})
+
+	t.Run("MalformedCheckpoint", func(t *testing.T) {
+		_, err := s.StreamReadTable(context.Background(), "table",
+			options.ReadFromCheckpoint("not a checkpoint"),
+		)
+		require.ErrorIs(t, err, errReadTableCheckpoint)
+	})
+
+	t.Run("AccumulateErrors", func(t *testing.T) {
+		res, err := s.StreamReadTable(context.Background(), "table", options.WithAccumulateErrors(rows))
+		require.NoError(t, err)
+		defer func() {
+			_ = res.Close()
+		}()
+		var ids []evenID
+		for res.NextResultSet(context.Background()) {
+			for res.NextRow() {
+				var id evenID
+				require.NoError(t, res.ScanNamed(named.Required("id", &id)))
+				ids = append(ids, id)
+			}
+		}
+		require.Equal(t, []evenID{0, 2, 0, 4, 0, 6, 0, 8, 0, 10}, ids)
+		err = res.Err()
+		require.ErrorIs(t, err, result.ErrCellErrors)
+		require.Contains(t, err.Error(), "5 cells decoded with errors")
+	})
+
+	t.Run("AccumulateErrorsTypeError", func(t *testing.T) {
+		res, err := s.StreamReadTable(context.Background(), "table", options.WithAccumulateErrors(rows))
+		require.NoError(t, err)
+		defer func() {
+			_ = res.Close()
+		}()
+		require.True(t, res.NextResultSet(context.Background()))
+		require.True(t, res.NextRow())
+		var value uint64
+		require.Error(t, res.ScanNamed(named.Required("value", &value)))
+		require.Empty(t, result.CellErrors(res))
+		require.NotErrorIs(t, res.Err(), result.ErrCellErrors)
+	})
+
+	t.Run("NotSupported", func(t *testing.T) {
+		res, err := s.StreamReadTable(context.Background(), "table")
+		require.NoError(t, err)
+		defer func() {
+			_ = res.Close()
+		}()
+		_, err = result.Checkpoint(res)
+		require.ErrorIs(t, err, result.ErrCheckpointNotSupported)
+	})
+}
@@ -0,0 +1,169 @@
+package table
+
+import (
+	"context"
+
+	metaHeaders "github.com/ydb-platform/ydb-go-sdk/v3/internal/meta"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/stack"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xcontext"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/meta"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+// singleSessionGet returns the single session of client in single session mode.
+// Session is created synchronously on first call and after invalidation of previous session.
+// singleSessionGet locks the single session until singleSessionPut
+func (c *Client) singleSessionGet(ctx context.Context) (s *session, err error) {
+	onDone := trace.TableOnPoolGet(c.config.Trace(), &ctx, stack.FunctionID(""))
+	defer func() {
+		onDone(s, 1, err)
+	}()
+
+	select {
+	case <-c.done:
+		return nil, xerrors.WithStackTrace(errClosedClient)
+
+	case <-ctx.Done():
+		return nil, xerrors.WithStackTrace(ctx.Err())
+
+	case c.singleSessionLock <- struct{}{}:
+	}
+
+	defer func() {
+		if err != nil {
+			<-c.singleSessionLock
+		}
+	}()
+
+	if c.isClosed() {
+		return nil, xerrors.WithStackTrace(errClosedClient)
+	}
+
+	c.mu.WithLock(func() {
+		s = c.singleSession
+	})
+
+	if s != nil && c.nodeChecker != nil && !c.nodeChecker.HasNode(s.NodeID()) {
+		c.singleSessionDrop(ctx, s)
+		s = nil
+	}
+
+	if s != nil {
+		return s, nil
+	}
+
+	return c.singleSessionCreate(ctx)
+}
+
+// singleSessionCreate creates the single session of client in single session mode.
+// c.singleSessionLock must be held.
+func (c *Client) singleSessionCreate(ctx context.Context) (s *session, err error) {
+	if wait, ok := c.limiter.acquire(); !ok {
+		return nil, serverSessionLimitError(wait)
+	}
+
+	createSessionCtx := meta.WithAllowFeatures(ctx, metaHeaders.HintSessionBalancer)
+	if timeout := c.config.CreateSessionTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		createSessionCtx, cancel = xcontext.WithTimeout(createSessionCtx, timeout)
+		defer cancel()
+	}
+
+	s, err = c.build(createSessionCtx)
+	if err != nil {
+		if isServerSessionLimitExceeded(err, c.config.SessionLimitMatcher()) {
+			cooldown, interval := c.limiter.exceeded()
+			trace.TableOnPoolSessionLimit(c.config.Trace(), cooldown, interval, err)
+			return nil, serverSessionLimitError(cooldown)
+		}
+		return nil, xerrors.WithStackTrace(err)
+	}
+
+	c.limiter.created()
+
+	c.mu.WithLock(func() {
+		c.singleSession = s
+		trace.TableOnPoolSessionAdd(c.config.Trace(), s)
+		trace.TableOnPoolStateChange(c.config.Trace(), 1, "append")
+	})
+
+	return s, nil
+}
+
+// singleSessionPut unlocks the single session of client in single session mode.
+// Invalidated session is deleted and will be recreated on next singleSessionGet
+func (c *Client) singleSessionPut(ctx context.Context, s *session) (err error) {
+	onDone := trace.TableOnPoolPut(c.config.Trace(), &ctx,
+		stack.FunctionID(""),
+		s,
+	)
+	defer func() {
+		onDone(err)
+	}()
+
+	defer func() {
+		<-c.singleSessionLock
+	}()
+
+	switch {
+	case c.isClosed():
+		err = xerrors.WithStackTrace(errClosedClient)
+
+	case s.isClosing():
+		err = xerrors.WithStackTrace(errSessionUnderShutdown)
+
+	case s.isClosed():
+		err = xerrors.WithStackTrace(errSessionClosed)
+
+	case c.nodeChecker != nil && !c.nodeChecker.HasNode(s.NodeID()):
+		err = xerrors.WithStackTrace(errNodeIsNotObservable)
+
+	default:
+		return nil
+	}
+
+	c.singleSessionDrop(ctx, s)
+
+	return err
+}
+
+// singleSessionDrop deletes the single session of client in single session mode.
+// c.singleSessionLock must be held.
+func (c *Client) singleSessionDrop(ctx context.Context, s *session) {
+	c.mu.WithLock(func() {
+		if c.singleSession != s {
+			return
+		}
+		c.singleSession = nil
+		trace.TableOnPoolSessionRemove(c.config.Trace(), s)
+		trace.TableOnPoolStateChange(c.config.Trace(), 0, "remove")
+	})
+
+	if !s.isClosed() {
+		c.internalPoolSyncCloseSession(xcontext.WithoutDeadline(ctx), s)
+	}
+}
+
+// singleSessionClose deletes the single session of closed client in single session mode.
+// Busy session is not waited for: client is closed already, so session is deleted
+// on singleSessionPut at the end of operation
+func (c *Client) singleSessionClose(ctx context.Context) {
+	select {
+	case c.singleSessionLock <- struct{}{}:
+	default:
+		return
+	}
+	defer func() {
+		<-c.singleSessionLock
+	}()
+
+	var s *session
+	c.mu.WithLock(func() {
+		s = c.singleSession
+	})
+
+	if s != nil {
+		c.singleSessionDrop(ctx, s)
+	}
+}
@@ -0,0 +1,143 @@
+package table
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/testutil"
+)
+
+func newSingleSessionClient(t *testing.T) (c *Client, created, deleted *int64) {
+	created, deleted = new(int64), new(int64)
+	c = newClientWithStubBuilder(t,
+		testutil.NewBalancer(testutil.WithInvokeHandlers(testutil.InvokeHandlers{
+			testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+				atomic.AddInt64(created, 1)
+				return &Ydb_Table.CreateSessionResult{
+					SessionId: testutil.SessionID(),
+				}, nil
+			},
+			testutil.TableDeleteSession: func(interface{}) (proto.Message, error) {
+				atomic.AddInt64(deleted, 1)
+				return &Ydb_Table.DeleteSessionResponse{}, nil
+			},
+		})),
+		0,
+		config.WithSingleSession(),
+	)
+	return c, created, deleted
+}
+
+// clientGoroutines returns stacks of goroutines which run methods of table client c
+func clientGoroutines(c *Client) (stacks []string) {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		if strings.Contains(stack, "internal/table.(*Client)") && strings.Contains(stack, fmt.Sprintf("%p", c)) {
+			stacks = append(stacks, stack)
+		}
+	}
+	return stacks
+}
+
+func TestSingleSessionNoBackgroundGoroutines(t *testing.T) {
+	ctx := context.Background()
+	c, created, deleted := newSingleSessionClient(t)
+
+	require.Empty(t, clientGoroutines(c))
+
+	var sessions []table.Session
+	for i := 0; i < 3; i++ {
+		require.NoError(t, c.Do(ctx, func(ctx context.Context, s table.Session) error {
+			sessions = append(sessions, s)
+			return nil
+		}))
+		require.Empty(t, clientGoroutines(c))
+	}
+	require.Equal(t, int64(1), atomic.LoadInt64(created))
+	require.Same(t, sessions[0], sessions[1])
+	require.Same(t, sessions[0], sessions[2])
+
+	require.NoError(t, c.Close(ctx))
+	require.Equal(t, int64(1), atomic.LoadInt64(deleted))
+	require.Empty(t, clientGoroutines(c))
+
+	require.ErrorIs(t, c.Do(ctx, func(ctx context.Context, s table.Session) error {
+		return nil
+	}), errClosedClient)
+}
+
+func TestSingleSessionRecreateOnBadSession(t *testing.T) {
+	ctx := context.Background()
+	c, created, deleted := newSingleSessionClient(t)
+	defer func() {
+		_ = c.Close(ctx)
+	}()
+
+	var sessions []table.Session
+	require.NoError(t, c.Do(ctx, func(ctx context.Context, s table.Session) error {
+		sessions = append(sessions, s)
+		if len(sessions) == 1 {
+			return xerrors.WithStackTrace(
+				xerrors.Operation(xerrors.WithStatusCode(Ydb.StatusIds_BAD_SESSION)),
+			)
+		}
+		return nil
+	}))
+	require.Len(t, sessions, 2)
+	require.NotSame(t, sessions[0], sessions[1])
+	require.Equal(t, int64(2), atomic.LoadInt64(created))
+	require.Equal(t, int64(1), atomic.LoadInt64(deleted))
+
+	require.NoError(t, c.Do(ctx, func(ctx context.Context, s table.Session) error {
+		require.Same(t, sessions[1], s)
+		return nil
+	}))
+	require.Equal(t, int64(2), atomic.LoadInt64(created))
+}
+
+func TestSingleSessionCloseDeletesSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("NotCreated", func(t *testing.T) {
+		c, created, deleted := newSingleSessionClient(t)
+		require.NoError(t, c.Close(ctx))
+		require.Equal(t, int64(0), atomic.LoadInt64(created))
+		require.Equal(t, int64(0), atomic.LoadInt64(deleted))
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		c, _, deleted := newSingleSessionClient(t)
+		require.NoError(t, c.Do(ctx, func(ctx context.Context, s table.Session) error {
+			return nil
+		}))
+		closeCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		require.NoError(t, c.Close(closeCtx))
+		require.Equal(t, int64(1), atomic.LoadInt64(deleted))
+	})
+
+	t.Run("BusySession", func(t *testing.T) {
+		c, _, deleted := newSingleSessionClient(t)
+		require.NoError(t, c.Do(ctx, func(ctx context.Context, s table.Session) error {
+			closeCtx, cancel := context.WithCancel(ctx)
+			cancel()
+			require.NoError(t, c.Close(closeCtx))
+			require.Equal(t, int64(0), atomic.LoadInt64(deleted))
+			return nil
+		}))
+		require.Equal(t, int64(1), atomic.LoadInt64(deleted))
+	})
+}
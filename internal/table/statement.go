@@ -2,25 +2,30 @@ package table
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
-	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
 	"google.golang.org/grpc"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/operation"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/paramscache"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/stack"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
 
+var _ table.Statement = (*statement)(nil)
+
 type statement struct {
 	session *session
 	query   query
-	params  map[string]*Ydb.Type
+	params  map[string]types.Type
 }
 
 // Execute executes prepared data query.
@@ -30,6 +35,23 @@ func (s *statement) Execute(
 	opts ...options.ExecuteDataQueryOption,
 ) (
 	txr table.Transaction, r result.Result, err error,
+) {
+	return s.executeTx(ctx, stack.FunctionID(""), txControl,
+		txConsistency(txControl.Desc().GetBeginTx()),
+		params, opts...,
+	)
+}
+
+// executeTx executes prepared query in transaction with consistency mode and traces it as call
+func (s *statement) executeTx(
+	ctx context.Context,
+	call interface{ FunctionID() string },
+	txControl *table.TransactionControl,
+	consistency result.Consistency,
+	params *table.QueryParameters,
+	opts ...options.ExecuteDataQueryOption,
+) (
+	txr table.Transaction, r result.Result, err error,
 ) {
 	var (
 		a       = allocator.New()
@@ -43,10 +65,10 @@ func (s *statement) Execute(
 
 	request.SessionId = s.session.id
 	request.TxControl = txControl.Desc()
-	request.Parameters = params.Params().ToYDB(a)
+	request.Parameters = paramscache.ToYDB(ctx, params, a, params.Params().ToYDB)
 	request.Query = s.query.toYDB(a)
 	request.QueryCachePolicy = a.TableQueryCachePolicy()
-	request.QueryCachePolicy.KeepInCache = len(params.Params()) > 0
+	request.QueryCachePolicy.KeepInCache = keepInCache(s.session.config, params)
 	request.OperationParams = operation.Params(ctx,
 		s.session.config.OperationTimeout(),
 		s.session.config.OperationCancelAfter(),
@@ -59,32 +81,76 @@ func (s *statement) Execute(
 		}
 	}
 
+	if s.session.config.QueryParametersCheck() || request.CheckParameters {
+		if err = s.checkParams(params); err != nil {
+			return nil, nil, xerrors.WithStackTrace(err)
+		}
+	}
+	s.session.checkTimestamps(params)
+
 	onDone := trace.TableOnSessionQueryExecute(
 		s.session.config.Trace(), &ctx,
-		stack.FunctionID(""),
+		call,
 		s.session, s.query, params,
 		request.QueryCachePolicy.GetKeepInCache(),
+		consistency.String(),
 	)
 	defer func() {
 		onDone(txr, true, r, err)
 	}()
 
-	return s.execute(ctx, a, &request, request.TxControl, callOptions...)
+	return s.execute(ctx, a, &request, request.TxControl, consistency, callOptions...)
 }
 
 // execute executes prepared query without any tracing.
 func (s *statement) execute(
 	ctx context.Context, a *allocator.Allocator,
 	request *options.ExecuteDataQueryDesc, txControl *Ydb_Table.TransactionControl,
-	callOptions ...grpc.CallOption,
+	consistency result.Consistency, callOptions ...grpc.CallOption,
 ) (
 	txr table.Transaction, r result.Result, err error,
 ) {
-	res, err := s.session.executeDataQuery(ctx, a, request.ExecuteDataQueryRequest, callOptions...)
+	res, sets, err := s.session.executeDataQuery(ctx, a, request.ExecuteDataQueryRequest, callOptions...)
 	if err != nil {
 		return nil, nil, xerrors.WithStackTrace(err)
 	}
-	return s.session.executeQueryResult(res, txControl, request.IgnoreTruncated)
+	return s.session.executeQueryResult(res, sets, txControl, consistency, request)
+}
+
+// checkParams checks that all of params are declared in prepared query
+func (s *statement) checkParams(params *table.QueryParameters) error {
+	if params.Count() == 0 || s.params == nil {
+		return nil
+	}
+	names := make([]string, 0, params.Count())
+	params.Each(func(name string, _ types.Value) {
+		if _, has := s.params[name]; !has {
+			names = append(names, name)
+		}
+	})
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	declared := make([]string, 0, len(s.params))
+	for name := range s.params {
+		declared = append(declared, name)
+	}
+	if suggestion := suggestParam(names[0], declared); suggestion != "" {
+		return xerrors.WithStackTrace(fmt.Errorf("%w '%s' (did you mean %s?)",
+			errUnknownParameter, names[0], suggestion,
+		))
+	}
+	return xerrors.WithStackTrace(fmt.Errorf("%w '%s'", errUnknownParameter, names[0]))
+}
+
+// ParametersTypes returns types of parameters declared in prepared query
+func (s *statement) ParametersTypes() map[string]types.Type {
+	params := make(map[string]types.Type, len(s.params))
+	for name, t := range s.params {
+		params[name] = t
+	}
+	return params
 }
 
 func (s *statement) NumInput() int {
@@ -0,0 +1,132 @@
+package table
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"github.com/ydb-platform/ydb-go-sdk/v3/testutil"
+)
+
+func prepareRecordedStatement(t *testing.T) (_ table.Statement, executed *int) {
+	return prepareRecordedStatementWithConfig(t, config.New())
+}
+
+func prepareRecordedStatementWithConfig(t *testing.T, cfg *config.Config) (_ table.Statement, executed *int) {
+	data, err := os.ReadFile("testdata/prepare_query_result.textproto")
+	require.NoError(t, err)
+	var recorded Ydb_Table.PrepareQueryResult
+	require.NoError(t, prototext.Unmarshal(data, &recorded))
+
+	executed = new(int)
+	b := testutil.NewBalancer(
+		testutil.WithInvokeHandlers(
+			testutil.InvokeHandlers{
+				testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+					return &Ydb_Table.CreateSessionResult{
+						SessionId: testutil.SessionID(),
+					}, nil
+				},
+				testutil.TablePrepareDataQuery: func(interface{}) (proto.Message, error) {
+					return &recorded, nil
+				},
+				testutil.TableExecuteDataQuery: func(interface{}) (proto.Message, error) {
+					*executed++
+					return &Ydb_Table.ExecuteQueryResult{
+						TxMeta: &Ydb_Table.TransactionMeta{Id: "tx"},
+					}, nil
+				},
+			},
+		),
+	)
+	s, err := newSession(context.Background(), b, cfg)
+	require.NoError(t, err)
+	stmt, err := s.Prepare(context.Background(), "SELECT 1;")
+	require.NoError(t, err)
+	return stmt, executed
+}
+
+func TestStatementParametersTypes(t *testing.T) {
+	stmt, _ := prepareRecordedStatement(t)
+
+	params := table.ParametersTypes(stmt)
+	require.Equal(t, 5, stmt.NumInput())
+	require.Len(t, params, 5)
+	for name, exp := range map[string]types.Type{
+		"$user_id": types.TypeUint64,
+		"$name":    types.Optional(types.TypeText),
+		"$tags":    types.List(types.TypeText),
+		"$attrs":   types.Dict(types.TypeText, types.Optional(types.TypeInt64)),
+		"$items": types.List(types.Struct(
+			types.StructField("id", types.TypeUint64),
+			types.StructField("price", types.Optional(types.DecimalType(22, 9))),
+		)),
+	} {
+		require.Contains(t, params, name)
+		require.True(t, types.Equal(exp, params[name]), "%s: %s != %s", name, exp, params[name])
+	}
+
+	// returned map is a copy
+	delete(params, "$user_id")
+	require.Contains(t, table.ParametersTypes(stmt), "$user_id")
+}
+
+func TestStatementUnknownParameter(t *testing.T) {
+	stmt, executed := prepareRecordedStatement(t)
+
+	t.Run("Known", func(t *testing.T) {
+		_, _, err := stmt.Execute(context.Background(), table.DefaultTxControl(), table.NewQueryParameters(
+			table.ValueParam("$user_id", types.Uint64Value(1)),
+			table.ValueParam("$name", types.NullValue(types.TypeText)),
+		), options.WithQueryParametersCheck())
+		require.NoError(t, err)
+		require.Equal(t, 1, *executed)
+	})
+
+	t.Run("Suggestion", func(t *testing.T) {
+		_, _, err := stmt.Execute(context.Background(), table.DefaultTxControl(), table.NewQueryParameters(
+			table.ValueParam("$usr_id", types.Uint64Value(1)),
+		), options.WithQueryParametersCheck())
+		require.ErrorIs(t, err, errUnknownParameter)
+		require.Contains(t, err.Error(), "unknown parameter '$usr_id' (did you mean $user_id?)")
+		require.Equal(t, 1, *executed)
+	})
+
+	t.Run("NoSuggestion", func(t *testing.T) {
+		_, _, err := stmt.Execute(context.Background(), table.DefaultTxControl(), table.NewQueryParameters(
+			table.ValueParam("$created_at", types.Uint64Value(1)),
+		), options.WithQueryParametersCheck())
+		require.ErrorIs(t, err, errUnknownParameter)
+		require.Contains(t, err.Error(), "unknown parameter '$created_at'")
+		require.NotContains(t, err.Error(), "did you mean")
+		require.Equal(t, 1, *executed)
+	})
+
+	t.Run("WithoutCheck", func(t *testing.T) {
+		// unknown parameters are checked by server
+		_, _, err := stmt.Execute(context.Background(), table.DefaultTxControl(), table.NewQueryParameters(
+			table.ValueParam("$usr_id", types.Uint64Value(1)),
+		))
+		require.NoError(t, err)
+		require.Equal(t, 2, *executed)
+	})
+}
+
+func TestStatementUnknownParameterClientCheck(t *testing.T) {
+	stmt, executed := prepareRecordedStatementWithConfig(t, config.New(config.WithQueryParametersCheck()))
+
+	_, _, err := stmt.Execute(context.Background(), table.DefaultTxControl(), table.NewQueryParameters(
+		table.ValueParam("$usr_id", types.Uint64Value(1)),
+	))
+	require.ErrorIs(t, err, errUnknownParameter)
+	require.Zero(t, *executed)
+}
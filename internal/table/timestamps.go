@@ -0,0 +1,31 @@
+package table
+
+import (
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+// checkTimestamps reports about parameters with timestamps (values of date and time types)
+// which are later than current time with clock skew allowance.
+//
+// Check is warn-only and enabled only with non-zero clock skew allowance
+func (s *session) checkTimestamps(params *table.QueryParameters) {
+	skew := s.config.ClockSkew()
+	if skew <= 0 || params.Count() == 0 {
+		return
+	}
+	now := s.config.Clock().Now()
+	params.Each(func(name string, v types.Value) {
+		var t time.Time
+		if err := value.Cast(v, &t); err != nil {
+			return
+		}
+		if t.After(now.Add(skew)) {
+			trace.TableOnSessionQueryTimestampWarning(s.config.Trace(), s, name, t, now, skew)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package table
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	internalConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+func TestSessionExecuteTimestampWarning(t *testing.T) {
+	var (
+		now   = time.Date(2023, time.March, 26, 0, 30, 0, 0, time.UTC)
+		clock = clockwork.NewFakeClockAt(now)
+		b     = stubBalancer(t, newStubCluster(t))
+	)
+	execute := func(t *testing.T, skew time.Duration, params *table.QueryParameters) (
+		warnings []trace.TableSessionQueryTimestampWarningInfo,
+	) {
+		var common internalConfig.Common
+		internalConfig.SetClockSkew(&common, skew)
+		s, err := newSession(context.Background(), b, config.New(
+			config.With(common),
+			config.WithClock(clock),
+			config.WithTrace(&trace.Table{
+				OnSessionQueryTimestampWarning: func(info trace.TableSessionQueryTimestampWarningInfo) {
+					warnings = append(warnings, info)
+				},
+			}),
+		))
+		require.NoError(t, err)
+		_, _, err = s.Execute(context.Background(), table.DefaultTxControl(), "SELECT 1;", params)
+		require.NoError(t, err)
+		return warnings
+	}
+
+	t.Run("Future", func(t *testing.T) {
+		future := now.Add(time.Hour)
+		warnings := execute(t, time.Minute, table.NewQueryParameters(
+			table.ValueParam("$past", types.TimestampValueFromTime(now.Add(-time.Hour))),
+			table.ValueParam("$skewed", types.TimestampValueFromTime(now.Add(30*time.Second))),
+			table.ValueParam("$future", types.OptionalValue(types.TimestampValueFromTime(future))),
+			table.ValueParam("$null", types.NullValue(types.TypeTimestamp)),
+			table.ValueParam("$today", types.DateValueFromTime(now)),
+			table.ValueParam("$id", types.Uint64Value(uint64(future.Unix()))),
+		))
+		require.Len(t, warnings, 1)
+		require.Equal(t, "$future", warnings[0].Parameter)
+		require.True(t, future.Equal(warnings[0].Timestamp))
+		require.Equal(t, now, warnings[0].Now)
+		require.Equal(t, time.Minute, warnings[0].ClockSkew)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		warnings := execute(t, 0, table.NewQueryParameters(
+			table.ValueParam("$future", types.TimestampValueFromTime(now.Add(time.Hour))),
+		))
+		require.Empty(t, warnings)
+	})
+}
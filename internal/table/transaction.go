@@ -43,11 +43,33 @@ const (
 	txStateRollbacked
 )
 
+var _ table.Transaction = (*transaction)(nil)
+
 type transaction struct {
-	id      string
-	s       *session
-	control *table.TransactionControl
-	state   txState
+	id          string
+	s           *session
+	control     *table.TransactionControl
+	consistency result.Consistency
+	state       txState
+}
+
+// txConsistency returns consistency mode of transaction with settings
+func txConsistency(settings *Ydb_Table.TransactionSettings) result.Consistency {
+	switch mode := settings.GetTxMode().(type) {
+	case *Ydb_Table.TransactionSettings_SerializableReadWrite:
+		return result.ConsistencySerializableReadWrite
+	case *Ydb_Table.TransactionSettings_OnlineReadOnly:
+		if mode.OnlineReadOnly.GetAllowInconsistentReads() {
+			return result.ConsistencyOnlineReadOnlyInconsistent
+		}
+		return result.ConsistencyOnlineReadOnly
+	case *Ydb_Table.TransactionSettings_StaleReadOnly:
+		return result.ConsistencyStaleReadOnly
+	case *Ydb_Table.TransactionSettings_SnapshotReadOnly:
+		return result.ConsistencySnapshotReadOnly
+	default:
+		return result.ConsistencyUnknown
+	}
 }
 
 func (tx *transaction) ID() string {
@@ -75,7 +97,7 @@ func (tx *transaction) Execute(
 	case txStateRollbacked:
 		return nil, xerrors.WithStackTrace(errTxRollbackedEarly)
 	default:
-		_, r, err = tx.s.Execute(ctx, tx.control, query, params, opts...)
+		_, r, err = tx.s.execute(ctx, stack.FunctionID(""), tx.control, tx.consistency, query, params, opts...)
 		if err != nil {
 			return nil, xerrors.WithStackTrace(err)
 		}
@@ -115,7 +137,7 @@ func (tx *transaction) ExecuteStatement(
 	case txStateRollbacked:
 		return nil, xerrors.WithStackTrace(errTxRollbackedEarly)
 	default:
-		_, r, err = stmt.Execute(ctx, tx.control, params, opts...)
+		_, r, err = stmt.(*statement).executeTx(ctx, stack.FunctionID(""), tx.control, tx.consistency, params, opts...)
 		if err != nil {
 			return nil, xerrors.WithStackTrace(err)
 		}
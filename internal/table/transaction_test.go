@@ -4,14 +4,18 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/config"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
 	"github.com/ydb-platform/ydb-go-sdk/v3/testutil"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
 
 func TestTxSkipRollbackForCommitted(t *testing.T) {
@@ -122,3 +126,116 @@ func TestTxSkipRollbackForCommitted(t *testing.T) {
 		}
 	}
 }
+
+func TestTxConsistency(t *testing.T) {
+	var (
+		txID        = "tx"
+		consistency []string
+	)
+	b := testutil.NewBalancer(
+		testutil.WithInvokeHandlers(
+			testutil.InvokeHandlers{
+				testutil.TableCreateSession: func(interface{}) (proto.Message, error) {
+					return &Ydb_Table.CreateSessionResult{
+						SessionId: testutil.SessionID(),
+					}, nil
+				},
+				testutil.TableBeginTransaction: func(interface{}) (proto.Message, error) {
+					return &Ydb_Table.BeginTransactionResult{
+						TxMeta: &Ydb_Table.TransactionMeta{Id: txID},
+					}, nil
+				},
+				testutil.TableExecuteDataQuery: func(interface{}) (proto.Message, error) {
+					return &Ydb_Table.ExecuteQueryResult{
+						TxMeta: &Ydb_Table.TransactionMeta{Id: txID},
+					}, nil
+				},
+			},
+		),
+	)
+	s, err := newSession(context.Background(), b, config.New(
+		config.WithTrace(&trace.Table{
+			OnSessionQueryExecute: func(
+				info trace.TableExecuteDataQueryStartInfo,
+			) func(
+				trace.TableExecuteDataQueryDoneInfo,
+			) {
+				consistency = append(consistency, info.TxConsistency)
+				return nil
+			},
+		}),
+	))
+	require.NoError(t, err)
+
+	t.Run("TxControl", func(t *testing.T) {
+		for _, tt := range []struct {
+			name      string
+			txControl *table.TransactionControl
+			exp       result.Consistency
+		}{
+			{
+				name:      "DefaultTxControl",
+				txControl: table.DefaultTxControl(),
+				exp:       result.ConsistencySerializableReadWrite,
+			},
+			{
+				name:      "SerializableReadWriteTxControl",
+				txControl: table.SerializableReadWriteTxControl(table.CommitTx()),
+				exp:       result.ConsistencySerializableReadWrite,
+			},
+			{
+				name:      "OnlineReadOnlyTxControl",
+				txControl: table.OnlineReadOnlyTxControl(),
+				exp:       result.ConsistencyOnlineReadOnly,
+			},
+			{
+				name:      "OnlineReadOnlyTxControlWithInconsistentReads",
+				txControl: table.OnlineReadOnlyTxControl(table.WithInconsistentReads()),
+				exp:       result.ConsistencyOnlineReadOnlyInconsistent,
+			},
+			{
+				name:      "StaleReadOnlyTxControl",
+				txControl: table.StaleReadOnlyTxControl(),
+				exp:       result.ConsistencyStaleReadOnly,
+			},
+			{
+				name:      "SnapshotReadOnlyTxControl",
+				txControl: table.SnapshotReadOnlyTxControl(),
+				exp:       result.ConsistencySnapshotReadOnly,
+			},
+			{
+				name:      "WithTxID",
+				txControl: table.TxControl(table.WithTxID(txID)),
+				exp:       result.ConsistencyUnknown,
+			},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				consistency = nil
+				_, res, err := s.Execute(context.Background(), tt.txControl, "SELECT 1", nil)
+				require.NoError(t, err)
+				require.Equal(t, tt.exp, result.TxConsistency(res))
+				require.Equal(t, txID, result.TxID(res))
+				require.Equal(t, []string{tt.exp.String()}, consistency)
+			})
+		}
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		tx, err := s.BeginTransaction(context.Background(), table.TxSettings(table.WithSnapshotReadOnly()))
+		require.NoError(t, err)
+		res, err := tx.Execute(context.Background(), "SELECT 1", nil)
+		require.NoError(t, err)
+		require.Equal(t, result.ConsistencySnapshotReadOnly, result.TxConsistency(res))
+		require.Equal(t, txID, result.TxID(res))
+	})
+
+	t.Run("ContinuedTransaction", func(t *testing.T) {
+		tx, _, err := s.Execute(context.Background(), table.SerializableReadWriteTxControl(), "SELECT 1", nil)
+		require.NoError(t, err)
+		consistency = nil
+		res, err := tx.Execute(context.Background(), "SELECT 2", nil)
+		require.NoError(t, err)
+		require.Equal(t, result.ConsistencySerializableReadWrite, result.TxConsistency(res))
+		require.Equal(t, []string{result.ConsistencySerializableReadWrite.String()}, consistency)
+	})
+}
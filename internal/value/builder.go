@@ -0,0 +1,201 @@
+package value
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+// SizeLimitError reports that approximate size of built value exceeds limit
+type SizeLimitError struct {
+	// Limit is a configured limit of size of value in bytes
+	Limit int
+	// Size is an approximate size of value with rejected item in bytes
+	Size int
+}
+
+func (e *SizeLimitError) Error() string {
+	return fmt.Sprintf("size of value %d bytes exceeds limit %d bytes", e.Size, e.Limit)
+}
+
+type builderOptions struct {
+	sizeLimit int
+}
+
+// BuilderOption is an option of ListBuilder and StructListBuilder
+type BuilderOption func(o *builderOptions)
+
+// WithBuilderSizeLimit limits approximate size of built value in bytes.
+// If sizeLimit is less than or equal to zero then size of value is not limited
+func WithBuilderSizeLimit(sizeLimit int) BuilderOption {
+	return func(o *builderOptions) {
+		o.sizeLimit = sizeLimit
+	}
+}
+
+// builderItem checks type of item and wraps item of type T into Optional
+// if itemType is Optional<T> (as ListValueE does)
+func builderItem(item Value, itemType Type) (Value, bool) {
+	switch t := item.Type(); {
+	case TypesEqual(t, itemType):
+		return item, true
+	case isOptionalOf(itemType, t):
+		return OptionalValue(item), true
+	default:
+		return nil, false
+	}
+}
+
+// ListBuilder makes list value of items of known type with incremental checks of types
+// and approximate size of list value
+type ListBuilder struct {
+	opts     builderOptions
+	itemType Type
+	items    []Value
+	size     int
+}
+
+// NewListBuilder makes builder of list value of type List<itemType>.
+// capacityHint is an expected count of items (negative hint is treated as 0)
+func NewListBuilder(itemType Type, capacityHint int, opts ...BuilderOption) *ListBuilder {
+	if capacityHint < 0 {
+		capacityHint = 0
+	}
+	b := &ListBuilder{
+		itemType: itemType,
+		items:    make([]Value, 0, capacityHint),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&b.opts)
+		}
+	}
+	return b
+}
+
+// Append appends item to list value.
+//
+// Append returns error if type of item differs from item type of builder
+// (items of type T are wrapped into Optional for item type Optional<T>)
+// and *SizeLimitError if list value with item exceeds size limit.
+// Rejected item is not appended
+func (b *ListBuilder) Append(item Value) error {
+	v, ok := builderItem(item, b.itemType)
+	if !ok {
+		return xerrors.WithStackTrace(fmt.Errorf(
+			"%w: item %d of type %s differs from type %s of list items",
+			errListItemType, len(b.items), item.Type().Yql(), b.itemType.Yql(),
+		))
+	}
+	size := b.size + sizeOfItem + sizeHint(v)
+	if b.opts.sizeLimit > 0 && size > b.opts.sizeLimit {
+		return xerrors.WithStackTrace(&SizeLimitError{
+			Limit: b.opts.sizeLimit,
+			Size:  size,
+		})
+	}
+	b.items = append(b.items, v)
+	b.size = size
+	return nil
+}
+
+// Len returns count of appended items
+func (b *ListBuilder) Len() int {
+	return len(b.items)
+}
+
+// SizeHint returns approximate size of list value in bytes
+func (b *ListBuilder) SizeHint() int {
+	return b.size
+}
+
+// Build returns list value of appended items without copying of items and resets builder
+func (b *ListBuilder) Build() Value {
+	v := &listValue{
+		t:     List(b.itemType),
+		items: b.items,
+	}
+	b.items, b.size = nil, 0
+	return v
+}
+
+// StructListBuilder makes list of struct values (such as rows for bulk upsert)
+// with incremental checks of types and approximate size of list value
+type StructListBuilder struct {
+	list *ListBuilder
+	// fields are fields of rows in order of appended values
+	fields []StructField
+	// order is an order of appended values in sorted fields of struct values
+	order []int
+}
+
+// NewStructListBuilder makes builder of list value of type List<t>, t must be a struct type.
+// Fields of struct values are sorted by names (as fields of StructValue).
+// capacityHint is an expected count of rows
+func NewStructListBuilder(t Type, capacityHint int, opts ...BuilderOption) (*StructListBuilder, error) {
+	structType, ok := t.(*StructType)
+	if !ok {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("type %s is not a struct type", t.Yql()))
+	}
+	fields := structType.fields
+	order := make([]int, len(fields))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return fields[order[i]].Name < fields[order[j]].Name
+	})
+	sortedFields := make([]StructField, 0, len(fields))
+	for _, i := range order {
+		sortedFields = append(sortedFields, fields[i])
+	}
+	return &StructListBuilder{
+		list:   NewListBuilder(Struct(sortedFields...), capacityHint, opts...),
+		fields: fields,
+		order:  order,
+	}, nil
+}
+
+// Append appends struct value of values of fields in order of fields of struct type of builder.
+//
+// Append returns error if count or types of values differ from fields of struct type
+// (values of type T are wrapped into Optional for fields of type Optional<T>)
+// and *SizeLimitError if list value with row exceeds size limit.
+// Rejected row is not appended
+func (b *StructListBuilder) Append(values ...Value) error {
+	if len(values) != len(b.fields) {
+		return xerrors.WithStackTrace(fmt.Errorf("%w: row %d has %d values instead of %d",
+			errStructListLength, b.list.Len(), len(values), len(b.fields),
+		))
+	}
+	fields := make([]StructValueField, 0, len(values))
+	for _, i := range b.order {
+		v, ok := builderItem(values[i], b.fields[i].T)
+		if !ok {
+			return xerrors.WithStackTrace(fmt.Errorf("%w: value of field '%s' of row %d has type %s instead of %s",
+				errStructListType, b.fields[i].Name, b.list.Len(), values[i].Type().Yql(), b.fields[i].T.Yql(),
+			))
+		}
+		fields = append(fields, StructValueField{Name: b.fields[i].Name, V: v})
+	}
+	return b.list.Append(&structValue{
+		t:      b.list.itemType,
+		fields: fields,
+	})
+}
+
+// Len returns count of appended rows
+func (b *StructListBuilder) Len() int {
+	return b.list.Len()
+}
+
+// SizeHint returns approximate size of list value in bytes
+func (b *StructListBuilder) SizeHint() int {
+	return b.list.SizeHint()
+}
+
+// Build returns list value of appended rows without copying of rows and resets builder
+func (b *StructListBuilder) Build() Value {
+	return b.list.Build()
+}
@@ -0,0 +1,245 @@
+package value
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+)
+
+func TestListBuilder(t *testing.T) {
+	t.Run("NegativeCapacityHint", func(t *testing.T) {
+		b := NewListBuilder(TypeInt32, -1)
+		require.NoError(t, b.Append(Int32Value(1)))
+		require.Equal(t, "[1]", b.Build().Yql())
+	})
+	t.Run("Build", func(t *testing.T) {
+		b := NewListBuilder(Optional(TypeText), 2)
+		require.NoError(t, b.Append(TextValue("a")))
+		require.NoError(t, b.Append(NullValue(TypeText)))
+		require.NoError(t, b.Append(OptionalValue(TextValue("c"))))
+		require.Equal(t, 3, b.Len())
+		v := b.Build()
+		require.Equal(t, "List<Optional<Utf8>>", v.Type().Yql())
+		require.Equal(t, `[Just("a"u),Nothing(Optional<Utf8>),Just("c"u)]`, v.Yql())
+
+		a := allocator.New()
+		defer a.Free()
+		require.True(t, proto.Equal(
+			ToYDB(ListValue(OptionalValue(TextValue("a")), NullValue(TypeText), OptionalValue(TextValue("c"))), a),
+			ToYDB(v, a),
+		))
+
+		require.Equal(t, 0, b.Len())
+		require.Equal(t, 0, b.SizeHint())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		v := NewListBuilder(TypeUint64, 0).Build()
+		require.Equal(t, "List<Uint64>", v.Type().Yql())
+		require.Equal(t, "[]", v.Yql())
+	})
+	t.Run("ItemType", func(t *testing.T) {
+		b := NewListBuilder(TypeUint64, 0)
+		require.NoError(t, b.Append(Uint64Value(1)))
+		require.ErrorIs(t, b.Append(Int64Value(2)), errListItemType)
+		require.ErrorIs(t, b.Append(OptionalValue(Uint64Value(3))), errListItemType)
+		require.Equal(t, "[1ul]", b.Build().Yql())
+	})
+	t.Run("SizeLimit", func(t *testing.T) {
+		b := NewListBuilder(TypeText, 0, WithBuilderSizeLimit(100))
+		for i := 0; i < 4; i++ {
+			require.NoError(t, b.Append(TextValue(string(make([]byte, 20)))))
+		}
+		size := b.SizeHint()
+		require.Equal(t, 4*(sizeOfItem+20), size)
+
+		err := b.Append(TextValue(string(make([]byte, 20))))
+		var sizeLimitErr *SizeLimitError
+		require.True(t, errors.As(err, &sizeLimitErr))
+		require.Equal(t, 100, sizeLimitErr.Limit)
+		require.Equal(t, size+sizeOfItem+20, sizeLimitErr.Size)
+
+		// rejected item is not appended, but smaller item fits into limit
+		require.Equal(t, 4, b.Len())
+		require.Equal(t, size, b.SizeHint())
+		require.NoError(t, b.Append(TextValue("")))
+		require.Equal(t, 5, b.Len())
+	})
+}
+
+func TestStructListBuilder(t *testing.T) {
+	t.Run("Build", func(t *testing.T) {
+		b, err := NewStructListBuilder(Struct(
+			StructField{Name: "id", T: TypeUint64},
+			StructField{Name: "name", T: Optional(TypeText)},
+			StructField{Name: "age", T: TypeUint32},
+		), 2)
+		require.NoError(t, err)
+		require.NoError(t, b.Append(Uint64Value(1), TextValue("a"), Uint32Value(10)))
+		require.NoError(t, b.Append(Uint64Value(2), NullValue(TypeText), Uint32Value(20)))
+		require.Equal(t, 2, b.Len())
+		v := b.Build()
+
+		expected := ListValue(
+			StructValue(
+				StructValueField{Name: "id", V: Uint64Value(1)},
+				StructValueField{Name: "name", V: OptionalValue(TextValue("a"))},
+				StructValueField{Name: "age", V: Uint32Value(10)},
+			),
+			StructValue(
+				StructValueField{Name: "id", V: Uint64Value(2)},
+				StructValueField{Name: "name", V: NullValue(TypeText)},
+				StructValueField{Name: "age", V: Uint32Value(20)},
+			),
+		)
+		require.True(t, TypesEqual(expected.Type(), v.Type()))
+		require.Equal(t, expected.Yql(), v.Yql())
+
+		a := allocator.New()
+		defer a.Free()
+		require.True(t, proto.Equal(ToYDB(expected, a), ToYDB(v, a)))
+	})
+	t.Run("NotStruct", func(t *testing.T) {
+		_, err := NewStructListBuilder(List(TypeText), 0)
+		require.Error(t, err)
+	})
+	t.Run("Values", func(t *testing.T) {
+		b, err := NewStructListBuilder(Struct(
+			StructField{Name: "id", T: TypeUint64},
+			StructField{Name: "name", T: TypeText},
+		), 0)
+		require.NoError(t, err)
+		require.ErrorIs(t, b.Append(Uint64Value(1)), errStructListLength)
+		require.ErrorIs(t, b.Append(Uint64Value(1), BytesValue(nil)), errStructListType)
+		require.Equal(t, 0, b.Len())
+	})
+	t.Run("SizeLimit", func(t *testing.T) {
+		b, err := NewStructListBuilder(Struct(
+			StructField{Name: "id", T: TypeUint64},
+			StructField{Name: "payload", T: TypeBytes},
+		), 0, WithBuilderSizeLimit(1000))
+		require.NoError(t, err)
+		var sizeLimitErr *SizeLimitError
+		for i := 0; ; i++ {
+			err = b.Append(Uint64Value(uint64(i)), BytesValue(make([]byte, 100)))
+			if err != nil {
+				require.True(t, errors.As(err, &sizeLimitErr))
+				break
+			}
+		}
+		require.Equal(t, 1000, sizeLimitErr.Limit)
+		require.Greater(t, sizeLimitErr.Size, 1000)
+		require.LessOrEqual(t, b.SizeHint(), 1000)
+		require.Equal(t, b.Len(), builtListLen(t, b.Build()))
+	})
+}
+
+func builtListLen(t testing.TB, v Value) int {
+	t.Helper()
+	list, ok := v.(*listValue)
+	require.True(t, ok)
+	return list.Len()
+}
+
+func TestSizeHint(t *testing.T) {
+	for _, tt := range []struct {
+		v    Value
+		size int
+	}{
+		{v: Uint64Value(1), size: sizeOfPrimitive},
+		{v: TextValue("test"), size: 4},
+		{v: BytesValue(make([]byte, 10)), size: 10},
+		{v: VoidValue(), size: 0},
+		{v: NullValue(TypeText), size: 1},
+		{v: OptionalValue(TextValue("test")), size: sizeOfItem + 4},
+		{v: ListValue(TextValue("a"), TextValue("bc")), size: 2*sizeOfItem + 3},
+		{v: TupleValue(TextValue("a"), Uint8Value(1)), size: 2*sizeOfItem + 1 + sizeOfPrimitive},
+		{
+			v: StructValue(
+				StructValueField{Name: "a", V: TextValue("abc")},
+			),
+			size: sizeOfItem + 3,
+		},
+		{
+			v: DictValue(
+				DictValueField{K: TextValue("k"), V: TextValue("value")},
+			),
+			size: 2*sizeOfItem + 1 + 5,
+		},
+		{v: SecretValue(TextValue("secret")), size: 6},
+	} {
+		t.Run(tt.v.Yql(), func(t *testing.T) {
+			require.Equal(t, tt.size, sizeHint(tt.v))
+		})
+	}
+}
+
+func BenchmarkListBuilder(b *testing.B) {
+	const count = 10000
+	items := make([]Value, 0, count)
+	for i := 0; i < count; i++ {
+		items = append(items, TextValue(strconv.Itoa(i)))
+	}
+	b.Run("NaiveAppend", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var list []Value
+			for _, item := range items {
+				list = append(list, item)
+			}
+			_ = ListValue(list...)
+		}
+	})
+	b.Run("Builder", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			builder := NewListBuilder(TypeText, count, WithBuilderSizeLimit(1<<20))
+			for _, item := range items {
+				if err := builder.Append(item); err != nil {
+					b.Fatal(err)
+				}
+			}
+			_ = builder.Build()
+		}
+	})
+}
+
+func BenchmarkStructListBuilder(b *testing.B) {
+	const count = 10000
+	fields := []StructField{
+		{Name: "id", T: TypeUint64},
+		{Name: "name", T: TypeText},
+	}
+	b.Run("NaiveAppend", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var rows []Value
+			for j := 0; j < count; j++ {
+				rows = append(rows, StructValue(
+					StructValueField{Name: "id", V: Uint64Value(uint64(j))},
+					StructValueField{Name: "name", V: TextValue("name")},
+				))
+			}
+			_ = ListValue(rows...)
+		}
+	})
+	b.Run("Builder", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			builder, err := NewStructListBuilder(Struct(fields...), count)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for j := 0; j < count; j++ {
+				if err = builder.Append(Uint64Value(uint64(j)), TextValue("name")); err != nil {
+					b.Fatal(err)
+				}
+			}
+			_ = builder.Build()
+		}
+	})
+}
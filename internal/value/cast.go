@@ -1,5 +1,137 @@
 package value
 
-func CastTo(v Value, dst interface{}) error {
-	return v.castTo(dst)
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+var (
+	errValueOutOfRange    = errors.New("value out of range")
+	errValueFractional    = errors.New("value has fractional part")
+	errValueNotFiniteReal = errors.New("value is not a finite number")
+	errNotComparableKey   = errors.New("key is not comparable")
+)
+
+// outOfRangeError is errValueOutOfRange with cause of overflow (such as decimal.ErrOverflow)
+// which is matched by errors.Is too
+type outOfRangeError struct {
+	cause error
+}
+
+func (e outOfRangeError) Error() string {
+	return errValueOutOfRange.Error() + ": " + e.cause.Error()
+}
+
+func (e outOfRangeError) Is(target error) bool {
+	return target == errValueOutOfRange //nolint:errorlint
+}
+
+func (e outOfRangeError) Unwrap() error {
+	return e.cause
+}
+
+// Cast casts value to destination pointer
+//
+// Destination of type *interface{} receives natural Go representation of value (see NativeValue).
+// If built-in conversions fail, Cast uses cast registered for type of destination (see RegisterCast).
+func Cast(v Value, dst interface{}) error {
+	if vv, ok := dst.(*interface{}); ok {
+		return castToInterface(v, vv)
+	}
+	err := v.castTo(dst)
+	if err == nil {
+		return nil
+	}
+	if f, has := RegisteredCast(dst); has {
+		if err = f(v, dst); err != nil {
+			return castError(v.Yql(), v.Type(), dst, err)
+		}
+		return nil
+	}
+	return err
+}
+
+// castError wraps reason of failed cast of raw value v (of ydb type t) to dst
+func castError(v interface{}, t Type, dst interface{}, reason error) error {
+	return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination: %w",
+		v, t.Yql(), dst, reason,
+	))
+}
+
+// checkFloatIsInteger checks that v is integral and lies in [min, max)
+func checkFloatIsInteger(v, min, max float64) error {
+	switch {
+	case math.IsNaN(v) || math.IsInf(v, 0):
+		return errValueNotFiniteReal
+	case v != math.Trunc(v):
+		return errValueFractional
+	case v < min || v >= max:
+		return errValueOutOfRange
+	default:
+		return nil
+	}
+}
+
+// checkIntFits checks that v fits into signed integer of bitSize bits
+// (such as int with size strconv.IntSize)
+func checkIntFits(v int64, bitSize int) error {
+	if bitSize < 64 && (v < -1<<(bitSize-1) || v > 1<<(bitSize-1)-1) {
+		return errValueOutOfRange
+	}
+	return nil
+}
+
+// checkUintFits checks that v fits into unsigned integer of bitSize bits
+// (such as uint with size strconv.IntSize)
+func checkUintFits(v uint64, bitSize int) error {
+	if bitSize < 64 && v > 1<<bitSize-1 {
+		return errValueOutOfRange
+	}
+	return nil
+}
+
+// basicTypes maps kinds of user-defined named types to basic types of casting
+var basicTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.String:  reflect.TypeOf(""),
+	reflect.Int:     reflect.TypeOf(0),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+}
+
+// castToNamedType casts v to destination of user-defined named type (such as `type UserID int64`)
+// through destination of basic type with the same kind
+//
+// castToNamedType returns false if dst is not a pointer to named type of basic kind.
+func castToNamedType(v Value, dst interface{}) (ok bool, _ error) {
+	ptr := reflect.ValueOf(dst)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return false, nil
+	}
+	elem := ptr.Elem()
+	basic, has := basicTypes[elem.Kind()]
+	if !has || elem.Type() == basic {
+		return false, nil
+	}
+	tmp := reflect.New(basic)
+	if err := v.castTo(tmp.Interface()); err != nil {
+		return true, xerrors.WithStackTrace(fmt.Errorf("cannot cast '%s' to '%T' destination: %w",
+			v.Yql(), dst, err,
+		))
+	}
+	elem.Set(tmp.Elem().Convert(elem.Type()))
+	return true, nil
 }
@@ -0,0 +1,30 @@
+//go:build go1.18
+// +build go1.18
+
+package value
+
+import (
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+// CastTo casts value to new value of type T
+//
+// CastTo supports all destinations which are supported by casting with
+// destination pointer (Cast): CastTo[T](v) is equal to Cast(v, new(T))
+func CastTo[T any](v Value) (T, error) {
+	var dst T
+	if err := Cast(v, &dst); err != nil {
+		var zero T
+		return zero, xerrors.WithStackTrace(err)
+	}
+	return dst, nil
+}
+
+// MustCastTo casts value to new value of type T and panics on cast error
+func MustCastTo[T any](v Value) T {
+	dst, err := CastTo[T](v)
+	if err != nil {
+		panic(err)
+	}
+	return dst
+}
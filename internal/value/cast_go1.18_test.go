@@ -0,0 +1,64 @@
+//go:build go1.18
+// +build go1.18
+
+package value
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCastToGeneric(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		v, err := CastTo[string](TextValue("test"))
+		require.NoError(t, err)
+		require.Equal(t, "test", v)
+	})
+	t.Run("int64", func(t *testing.T) {
+		v, err := CastTo[int64](Int64Value(42))
+		require.NoError(t, err)
+		require.Equal(t, int64(42), v)
+	})
+	t.Run("time.Time", func(t *testing.T) {
+		exp := time.Unix(123456789, 0)
+		v, err := CastTo[time.Time](DatetimeValueFromTime(exp))
+		require.NoError(t, err)
+		require.True(t, exp.Equal(v))
+	})
+	t.Run("uuid.UUID", func(t *testing.T) {
+		exp := uuid.New()
+		v, err := CastTo[uuid.UUID](UUIDValue(exp))
+		require.NoError(t, err)
+		require.Equal(t, exp, v)
+	})
+	t.Run("*int32", func(t *testing.T) {
+		v, err := CastTo[*int32](OptionalValue(Int32Value(42)))
+		require.NoError(t, err)
+		require.NotNil(t, v)
+		require.Equal(t, int32(42), *v)
+	})
+	t.Run("*int32(NULL)", func(t *testing.T) {
+		v, err := CastTo[*int32](NullValue(TypeInt32))
+		require.NoError(t, err)
+		require.Nil(t, v)
+	})
+	t.Run("string(NULL)", func(t *testing.T) {
+		_, err := CastTo[string](NullValue(TypeText))
+		require.ErrorIs(t, err, errOptionalNilValue)
+	})
+	t.Run("Error", func(t *testing.T) {
+		v, err := CastTo[time.Duration](TextValue("test"))
+		require.Error(t, err)
+		require.Zero(t, v)
+	})
+}
+
+func TestMustCastTo(t *testing.T) {
+	require.Equal(t, "test", MustCastTo[string](TextValue("test")))
+	require.Panics(t, func() {
+		_ = MustCastTo[time.Duration](TextValue("test"))
+	})
+}
@@ -0,0 +1,72 @@
+package value
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xsync"
+)
+
+var (
+	errCastAlreadyRegistered = errors.New("cast is already registered")
+	errCastDestination       = errors.New("destination type of cast must be a non-pointer type")
+)
+
+// CastFunc casts value v to destination pointer dst. Type of dst is a pointer to destination type
+// of registration, v is a value as is (it may be Optional or NULL value)
+type CastFunc func(v Value, dst interface{}) error
+
+// casts is a registry of user-defined casts by destination types.
+//
+// Registry is safe for concurrent use, but it is intended to be filled on initialization
+// of program (such as in init functions) before values are casted or rows are scanned:
+// casts which are in progress concurrently with registration may not see registered cast.
+var casts = struct {
+	mu    xsync.RWMutex
+	funcs map[reflect.Type]CastFunc
+}{
+	funcs: make(map[reflect.Type]CastFunc),
+}
+
+// RegisterCast registers cast of values to destinations of type *t. Registered cast is used
+// by Cast and by scanning of rows only if built-in conversions don't support destination
+//
+// RegisterCast returns error if cast to t is already registered.
+func RegisterCast(t reflect.Type, f CastFunc) error {
+	if t == nil || t.Kind() == reflect.Ptr {
+		return xerrors.WithStackTrace(fmt.Errorf("%w: %v", errCastDestination, t))
+	}
+	casts.mu.Lock()
+	defer casts.mu.Unlock()
+	if _, has := casts.funcs[t]; has {
+		return xerrors.WithStackTrace(fmt.Errorf("%w: %v", errCastAlreadyRegistered, t))
+	}
+	casts.funcs[t] = f
+	return nil
+}
+
+// UnregisterCast removes registered cast to destinations of type *t (such as in cleanup of tests)
+// and returns false if cast to t was not registered
+func UnregisterCast(t reflect.Type) bool {
+	casts.mu.Lock()
+	defer casts.mu.Unlock()
+	if _, has := casts.funcs[t]; !has {
+		return false
+	}
+	delete(casts.funcs, t)
+	return true
+}
+
+// RegisteredCast returns registered cast for destination pointer dst
+func RegisteredCast(dst interface{}) (CastFunc, bool) {
+	t := reflect.TypeOf(dst)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil, false
+	}
+	casts.mu.RLock()
+	defer casts.mu.RUnlock()
+	f, has := casts.funcs[t.Elem()]
+	return f, has
+}
@@ -0,0 +1,63 @@
+package value
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type upperText struct {
+	s string
+}
+
+func TestRegisterCast(t *testing.T) {
+	dstType := reflect.TypeOf(upperText{})
+	require.NoError(t, RegisterCast(dstType, func(v Value, dst interface{}) error {
+		var s string
+		if err := Cast(v, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			return errors.New("empty text")
+		}
+		dst.(*upperText).s = strings.ToUpper(s)
+		return nil
+	}))
+	t.Cleanup(func() {
+		UnregisterCast(dstType)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		err := RegisterCast(dstType, func(v Value, dst interface{}) error { return nil })
+		require.ErrorIs(t, err, errCastAlreadyRegistered)
+	})
+	t.Run("PointerDestination", func(t *testing.T) {
+		err := RegisterCast(reflect.PtrTo(dstType), func(v Value, dst interface{}) error { return nil })
+		require.ErrorIs(t, err, errCastDestination)
+	})
+	t.Run("Cast", func(t *testing.T) {
+		var dst upperText
+		require.NoError(t, Cast(TextValue("abc"), &dst))
+		require.Equal(t, "ABC", dst.s)
+		require.NoError(t, Cast(OptionalValue(TextValue("def")), &dst))
+		require.Equal(t, "DEF", dst.s)
+	})
+	t.Run("CastError", func(t *testing.T) {
+		var dst upperText
+		require.ErrorContains(t, Cast(TextValue(""), &dst), "empty text")
+	})
+	t.Run("BuiltInFirst", func(t *testing.T) {
+		var s string
+		require.NoError(t, Cast(TextValue("abc"), &s))
+		require.Equal(t, "abc", s)
+	})
+	t.Run("Unregister", func(t *testing.T) {
+		require.True(t, UnregisterCast(dstType))
+		require.False(t, UnregisterCast(dstType))
+		var dst upperText
+		require.Error(t, Cast(TextValue("abc"), &dst))
+	})
+}
@@ -0,0 +1,876 @@
+package value
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCastRealToInteger(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		v     Value
+		dst   interface{}
+		exp   interface{}
+		errIs error
+	}{
+		{
+			name: "Double(42)->int64",
+			v:    DoubleValue(42),
+			dst:  new(int64),
+			exp:  int64(42),
+		},
+		{
+			name: "Double(-42)->int32",
+			v:    DoubleValue(-42),
+			dst:  new(int32),
+			exp:  int32(-42),
+		},
+		{
+			name: "Double(1<<53)->uint64",
+			v:    DoubleValue(1 << 53),
+			dst:  new(uint64),
+			exp:  uint64(1 << 53),
+		},
+		{
+			name: "Double(MinInt64)->int64",
+			v:    DoubleValue(math.MinInt64),
+			dst:  new(int64),
+			exp:  int64(math.MinInt64),
+		},
+		{
+			name:  "Double(MaxInt64+1)->int64",
+			v:     DoubleValue(math.MaxInt64 + 1),
+			dst:   new(int64),
+			errIs: errValueOutOfRange,
+		},
+		{
+			name:  "Double(MaxInt32+1)->int32",
+			v:     DoubleValue(math.MaxInt32 + 1),
+			dst:   new(int32),
+			errIs: errValueOutOfRange,
+		},
+		{
+			name:  "Double(-1)->uint64",
+			v:     DoubleValue(-1),
+			dst:   new(uint64),
+			errIs: errValueOutOfRange,
+		},
+		{
+			name:  "Double(0.5)->int64",
+			v:     DoubleValue(0.5),
+			dst:   new(int64),
+			errIs: errValueFractional,
+		},
+		{
+			name:  "Double(NaN)->int64",
+			v:     DoubleValue(math.NaN()),
+			dst:   new(int64),
+			errIs: errValueNotFiniteReal,
+		},
+		{
+			name:  "Double(+Inf)->uint64",
+			v:     DoubleValue(math.Inf(+1)),
+			dst:   new(uint64),
+			errIs: errValueNotFiniteReal,
+		},
+		{
+			name: "Float(42)->int64",
+			v:    FloatValue(42),
+			dst:  new(int64),
+			exp:  int64(42),
+		},
+		{
+			name: "Float(-42)->int32",
+			v:    FloatValue(-42),
+			dst:  new(int32),
+			exp:  int32(-42),
+		},
+		{
+			name: "Float(42)->uint64",
+			v:    FloatValue(42),
+			dst:  new(uint64),
+			exp:  uint64(42),
+		},
+		{
+			name:  "Float(1.25)->int32",
+			v:     FloatValue(1.25),
+			dst:   new(int32),
+			errIs: errValueFractional,
+		},
+		{
+			name:  "Float(1e10)->int32",
+			v:     FloatValue(1e10),
+			dst:   new(int32),
+			errIs: errValueOutOfRange,
+		},
+		{
+			name:  "Float(-Inf)->int64",
+			v:     FloatValue(float32(math.Inf(-1))),
+			dst:   new(int64),
+			errIs: errValueNotFiniteReal,
+		},
+		{
+			name:  "Float(NaN)->uint64",
+			v:     FloatValue(float32(math.NaN())),
+			dst:   new(uint64),
+			errIs: errValueNotFiniteReal,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Cast(tt.v, tt.dst)
+			if tt.errIs != nil {
+				require.ErrorIs(t, err, tt.errIs)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.exp, reflect.ValueOf(tt.dst).Elem().Interface())
+		})
+	}
+}
+
+func TestCastRealToIntegerErrorMessage(t *testing.T) {
+	var dst int64
+	err := Cast(DoubleValue(1.5), &dst)
+	require.ErrorContains(t, err, "cannot cast '1.5' (type 'Double') to '*int64' destination")
+}
+
+func TestCastIntegerNarrowing(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		v     Value
+		dst   interface{}
+		exp   interface{}
+		errIs error
+	}{
+		{name: "Int64(MaxInt32)->int32", v: Int64Value(math.MaxInt32), dst: new(int32), exp: int32(math.MaxInt32)},
+		{name: "Int64(MinInt32)->int32", v: Int64Value(math.MinInt32), dst: new(int32), exp: int32(math.MinInt32)},
+		{name: "Int64(MaxInt32+1)->int32", v: Int64Value(math.MaxInt32 + 1), dst: new(int32), errIs: errValueOutOfRange},
+		{name: "Int64(MinInt32-1)->int32", v: Int64Value(math.MinInt32 - 1), dst: new(int32), errIs: errValueOutOfRange},
+		{name: "Int64(MaxInt16)->int16", v: Int64Value(math.MaxInt16), dst: new(int16), exp: int16(math.MaxInt16)},
+		{name: "Int64(MinInt16)->int16", v: Int64Value(math.MinInt16), dst: new(int16), exp: int16(math.MinInt16)},
+		{name: "Int64(MaxInt16+1)->int16", v: Int64Value(math.MaxInt16 + 1), dst: new(int16), errIs: errValueOutOfRange},
+		{name: "Int64(MinInt16-1)->int16", v: Int64Value(math.MinInt16 - 1), dst: new(int16), errIs: errValueOutOfRange},
+		{name: "Int64(MaxInt8)->int8", v: Int64Value(math.MaxInt8), dst: new(int8), exp: int8(math.MaxInt8)},
+		{name: "Int64(MinInt8)->int8", v: Int64Value(math.MinInt8), dst: new(int8), exp: int8(math.MinInt8)},
+		{name: "Int64(MaxInt8+1)->int8", v: Int64Value(math.MaxInt8 + 1), dst: new(int8), errIs: errValueOutOfRange},
+		{name: "Int64(MinInt8-1)->int8", v: Int64Value(math.MinInt8 - 1), dst: new(int8), errIs: errValueOutOfRange},
+		{name: "Int64(MaxInt64)->uint64", v: Int64Value(math.MaxInt64), dst: new(uint64), exp: uint64(math.MaxInt64)},
+		{name: "Int64(0)->uint64", v: Int64Value(0), dst: new(uint64), exp: uint64(0)},
+		{name: "Int64(-1)->uint64", v: Int64Value(-1), dst: new(uint64), errIs: errValueOutOfRange},
+		{name: "Int64(MaxUint32)->uint32", v: Int64Value(math.MaxUint32), dst: new(uint32), exp: uint32(math.MaxUint32)},
+		{name: "Int64(MaxUint32+1)->uint32", v: Int64Value(math.MaxUint32 + 1), dst: new(uint32), errIs: errValueOutOfRange},
+		{name: "Int64(-1)->uint32", v: Int64Value(-1), dst: new(uint32), errIs: errValueOutOfRange},
+		{name: "Int32(MaxInt16)->int16", v: Int32Value(math.MaxInt16), dst: new(int16), exp: int16(math.MaxInt16)},
+		{name: "Int32(MinInt16)->int16", v: Int32Value(math.MinInt16), dst: new(int16), exp: int16(math.MinInt16)},
+		{name: "Int32(MaxInt16+1)->int16", v: Int32Value(math.MaxInt16 + 1), dst: new(int16), errIs: errValueOutOfRange},
+		{name: "Int32(MinInt16-1)->int16", v: Int32Value(math.MinInt16 - 1), dst: new(int16), errIs: errValueOutOfRange},
+		{name: "Int32(MaxInt8)->int8", v: Int32Value(math.MaxInt8), dst: new(int8), exp: int8(math.MaxInt8)},
+		{name: "Int32(MinInt8)->int8", v: Int32Value(math.MinInt8), dst: new(int8), exp: int8(math.MinInt8)},
+		{name: "Int32(MaxInt8+1)->int8", v: Int32Value(math.MaxInt8 + 1), dst: new(int8), errIs: errValueOutOfRange},
+		{name: "Int32(MinInt8-1)->int8", v: Int32Value(math.MinInt8 - 1), dst: new(int8), errIs: errValueOutOfRange},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Cast(tt.v, tt.dst)
+			if tt.errIs != nil {
+				require.ErrorIs(t, err, tt.errIs)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.exp, reflect.ValueOf(tt.dst).Elem().Interface())
+		})
+	}
+}
+
+func TestCastIntegerNarrowingErrorMessage(t *testing.T) {
+	var dst int32
+	err := Cast(Int64Value(math.MaxInt32+1), &dst)
+	require.ErrorContains(t, err, "cannot cast '2147483648' (type 'Int64') to '*int32' destination: value out of range")
+}
+
+func TestCastUnsignedToSigned(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		v     Value
+		dst   interface{}
+		exp   interface{}
+		errIs error
+	}{
+		{name: "Uint8(127)->int8", v: Uint8Value(127), dst: new(int8), exp: int8(127)},
+		{name: "Uint8(128)->int8", v: Uint8Value(128), dst: new(int8), errIs: errValueOutOfRange},
+		{name: "Uint8(200)->int8", v: Uint8Value(200), dst: new(int8), errIs: errValueOutOfRange},
+		{name: "Uint16(32767)->int16", v: Uint16Value(32767), dst: new(int16), exp: int16(32767)},
+		{name: "Uint16(32768)->int16", v: Uint16Value(32768), dst: new(int16), errIs: errValueOutOfRange},
+		{name: "Uint32(2147483647)->int32", v: Uint32Value(2147483647), dst: new(int32), exp: int32(2147483647)},
+		{name: "Uint32(2147483648)->int32", v: Uint32Value(2147483648), dst: new(int32), errIs: errValueOutOfRange},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Cast(tt.v, tt.dst)
+			if tt.errIs != nil {
+				require.ErrorIs(t, err, tt.errIs)
+				require.Zero(t, reflect.ValueOf(tt.dst).Elem().Interface())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.exp, reflect.ValueOf(tt.dst).Elem().Interface())
+		})
+	}
+}
+
+func TestCastUnsignedToSignedErrorMessage(t *testing.T) {
+	var dst int8
+	err := Cast(Uint8Value(200), &dst)
+	require.ErrorContains(t, err, "cannot cast '200' (type 'Uint8') to '*int8' destination: value out of range")
+}
+
+func TestCastDyNumber(t *testing.T) {
+	t.Run("Float64", func(t *testing.T) {
+		for _, tt := range []struct {
+			v   string
+			exp float64
+		}{
+			{v: ".1234e4", exp: 1234},
+			{v: "-.15e1", exp: -1.5},
+			{v: ".1e-2", exp: 0.001},
+			{v: "0", exp: 0},
+			{v: "1234.5", exp: 1234.5},
+		} {
+			t.Run(tt.v, func(t *testing.T) {
+				var dst float64
+				require.NoError(t, Cast(DyNumberValue(tt.v), &dst))
+				require.Equal(t, tt.exp, dst)
+			})
+		}
+	})
+	t.Run("BigFloat", func(t *testing.T) {
+		var dst big.Float
+		require.NoError(t, Cast(DyNumberValue(".12345678901234567890123456789e29"), &dst))
+		require.Equal(t, "12345678901234567890123456789", dst.Text('f', 0))
+	})
+	t.Run("Malformed", func(t *testing.T) {
+		var f float64
+		require.ErrorContains(t, Cast(DyNumberValue("1.2.3"), &f), "cannot cast '1.2.3' (type 'DyNumber') to '*float64' destination")
+		var bf big.Float
+		require.ErrorContains(t, Cast(DyNumberValue("abc"), &bf), "cannot cast 'abc' (type 'DyNumber') to '*big.Float' destination")
+	})
+}
+
+func TestDyNumberValueFrom(t *testing.T) {
+	for _, tt := range []struct {
+		v   float64
+		exp string
+	}{
+		{v: 1234, exp: ".1234e4"},
+		{v: -1.5, exp: "-.15e1"},
+		{v: 0.001, exp: ".1e-2"},
+		{v: 1, exp: ".1e1"},
+		{v: 100, exp: ".1e3"},
+		{v: 0, exp: "0"},
+		{v: math.Copysign(0, -1), exp: "0"},
+	} {
+		t.Run(tt.exp, func(t *testing.T) {
+			v, err := DyNumberValueFromFloat64(tt.v)
+			require.NoError(t, err)
+			require.Equal(t, tt.exp, string(v))
+			var back float64
+			require.NoError(t, Cast(v, &back))
+			require.Equal(t, tt.v, back)
+
+			v, err = DyNumberValueFromBigFloat(big.NewFloat(tt.v))
+			require.NoError(t, err)
+			require.Equal(t, tt.exp, string(v))
+		})
+	}
+	t.Run("BigFloat", func(t *testing.T) {
+		f, _, err := big.ParseFloat("12345678901234567890123456789", 10, 128, big.ToNearestEven)
+		require.NoError(t, err)
+		v, err := DyNumberValueFromBigFloat(f)
+		require.NoError(t, err)
+		require.Equal(t, ".12345678901234567890123456789e29", string(v))
+	})
+	t.Run("NotFinite", func(t *testing.T) {
+		_, err := DyNumberValueFromFloat64(math.NaN())
+		require.ErrorIs(t, err, errValueNotFiniteReal)
+		_, err = DyNumberValueFromFloat64(math.Inf(-1))
+		require.ErrorIs(t, err, errValueNotFiniteReal)
+		_, err = DyNumberValueFromBigFloat(new(big.Float).SetInf(false))
+		require.ErrorIs(t, err, errValueNotFiniteReal)
+	})
+}
+
+func TestCastTzTimestampToTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	for _, tt := range []struct {
+		src string
+		exp time.Time
+		err string
+	}{
+		{
+			src: "2020-05-29T11:22:54.123456,Europe/Berlin",
+			exp: time.Date(2020, time.May, 29, 11, 22, 54, 123456000, berlin),
+		},
+		{
+			src: "2020-05-29T11:22:54.1,Europe/Berlin",
+			exp: time.Date(2020, time.May, 29, 11, 22, 54, 100000000, berlin),
+		},
+		{
+			src: "2020-05-29T11:22:54,Europe/Berlin",
+			exp: time.Date(2020, time.May, 29, 11, 22, 54, 0, berlin),
+		},
+		{
+			src: "2020-05-29T11:22:54.123456,Unknown/Zone",
+			err: "unknown timezone",
+		},
+		{
+			src: "2020-05-29T11:22:54.123456",
+			err: "not found timezone location",
+		},
+		{
+			src: "2020-05-29 11:22,Europe/Berlin",
+			err: "parse '2020-05-29 11:22,Europe/Berlin' failed",
+		},
+	} {
+		t.Run(tt.src, func(t *testing.T) {
+			var dst time.Time
+			err := Cast(TzTimestampValue(tt.src), &dst)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				require.ErrorContains(t, err, "to '*time.Time' destination")
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tt.exp.Equal(dst), dst)
+			require.Equal(t, berlin, dst.Location())
+		})
+	}
+}
+
+func TestCastTzDateAndTzDatetimeToTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	for _, tt := range []struct {
+		src Value
+		exp time.Time
+		err string
+	}{
+		{
+			src: TzDateValue("2020-05-29,Europe/Berlin"),
+			exp: time.Date(2020, time.May, 29, 0, 0, 0, 0, berlin),
+		},
+		{
+			src: TzDatetimeValue("2020-05-29T11:22:54,Europe/Berlin"),
+			exp: time.Date(2020, time.May, 29, 11, 22, 54, 0, berlin),
+		},
+		{
+			src: TzDateValue("2020-05-29"),
+			err: "not found timezone location",
+		},
+		{
+			src: TzDatetimeValue("2020-05-29T11:22:54,Unknown/Zone"),
+			err: "unknown timezone",
+		},
+		{
+			src: TzDatetimeValue("2020-05-29,Europe/Berlin"),
+			err: "parse '2020-05-29,Europe/Berlin' failed",
+		},
+	} {
+		t.Run(tt.src.Yql(), func(t *testing.T) {
+			var dst time.Time
+			err := Cast(tt.src, &dst)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				require.ErrorContains(t, err, "to '*time.Time' destination")
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tt.exp.Equal(dst), dst)
+			require.Equal(t, berlin, dst.Location())
+		})
+	}
+}
+
+func TestTzValueFromTimeRoundTrip(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	for _, src := range []time.Time{
+		time.Date(2020, time.May, 29, 11, 22, 54, 123456000, berlin),
+		time.Date(2020, time.December, 31, 23, 59, 59, 999999000, berlin),
+		time.Date(2020, time.May, 29, 11, 22, 54, 123456000, time.UTC),
+	} {
+		t.Run(src.String(), func(t *testing.T) {
+			for _, tt := range []struct {
+				v   Value
+				exp time.Time
+			}{
+				{
+					v:   TzDateValueFromTime(src),
+					exp: time.Date(src.Year(), src.Month(), src.Day(), 0, 0, 0, 0, src.Location()),
+				},
+				{
+					v:   TzDatetimeValueFromTime(src),
+					exp: src.Truncate(time.Second),
+				},
+				{
+					v:   TzTimestampValueFromTime(src),
+					exp: src,
+				},
+			} {
+				t.Run(tt.v.Type().Yql(), func(t *testing.T) {
+					var dst time.Time
+					require.NoError(t, Cast(tt.v, &dst))
+					require.True(t, tt.exp.Equal(dst), "%v != %v", dst, tt.exp)
+					require.Equal(t, src.Location().String(), dst.Location().String())
+				})
+			}
+		})
+	}
+	require.Equal(t,
+		tzDatetimeValue("2020-05-29T11:22:54,Europe/Berlin"),
+		TzDatetimeValueFromTime(time.Date(2020, time.May, 29, 11, 22, 54, 0, berlin)),
+	)
+}
+
+func TestCastIntervalToDuration(t *testing.T) {
+	for _, tt := range []struct {
+		src intervalValue
+		exp time.Duration
+		err bool
+	}{
+		{src: IntervalValue(1), exp: time.Microsecond},
+		{src: IntervalValue(maxDurationMicroseconds), exp: time.Duration(maxDurationMicroseconds) * time.Microsecond},
+		{src: IntervalValue(minDurationMicroseconds), exp: time.Duration(minDurationMicroseconds) * time.Microsecond},
+		{src: IntervalValue(maxDurationMicroseconds + 1), err: true},
+		{src: IntervalValue(minDurationMicroseconds - 1), err: true},
+		{src: IntervalValue(math.MaxInt64), err: true},
+		{src: IntervalValue(-math.MaxInt64), err: true},
+	} {
+		t.Run(tt.src.Yql(), func(t *testing.T) {
+			var dst time.Duration
+			err := Cast(tt.src, &dst)
+			if tt.err {
+				require.ErrorIs(t, err, errValueOutOfRange)
+				require.ErrorContains(t, err, "to '*time.Duration' destination")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.exp, dst)
+			var us int64
+			require.NoError(t, Cast(tt.src, &us))
+			require.Equal(t, int64(tt.src), us)
+		})
+	}
+}
+
+func TestIntervalValueFromDurationChecked(t *testing.T) {
+	for _, tt := range []struct {
+		src     time.Duration
+		exact   bool
+		rounded intervalValue
+	}{
+		{src: 0, exact: true, rounded: 0},
+		{src: 42 * time.Microsecond, exact: true, rounded: 42},
+		{src: 1499 * time.Nanosecond, rounded: 1},
+		{src: 1500 * time.Nanosecond, rounded: 2},
+		{src: -1500 * time.Nanosecond, rounded: -2},
+		{src: -1499 * time.Nanosecond, rounded: -1},
+		{src: math.MaxInt64, rounded: intervalValue(maxDurationMicroseconds + 1)},
+		{src: math.MinInt64, rounded: intervalValue(minDurationMicroseconds - 1)},
+		{
+			src:     time.Duration(maxDurationMicroseconds) * time.Microsecond,
+			exact:   true,
+			rounded: intervalValue(maxDurationMicroseconds),
+		},
+	} {
+		t.Run(tt.src.String(), func(t *testing.T) {
+			v, err := IntervalValueFromDurationChecked(tt.src, false)
+			if tt.exact {
+				require.NoError(t, err)
+				require.Equal(t, tt.rounded, v)
+			} else {
+				require.ErrorIs(t, err, errValueFractional)
+			}
+			v, err = IntervalValueFromDurationChecked(tt.src, true)
+			require.NoError(t, err)
+			require.Equal(t, tt.rounded, v)
+		})
+	}
+}
+
+func TestIntervalYqlOutOfDurationRange(t *testing.T) {
+	require.Equal(t, `Interval("P106751991DT4H54.775807S")`, IntervalValue(math.MaxInt64).Yql())
+	require.Equal(t, `Interval("-P106751991DT4H54.775808S")`, IntervalValue(math.MinInt64).Yql())
+}
+
+func TestCastVoidAndNullToInterface(t *testing.T) {
+	row := StructValue(
+		StructValueField{"id", Uint64Value(1)},
+		StructValueField{"title", OptionalValue(TextValue("test"))},
+		StructValueField{"remove_date", NullValue(TypeDatetime)},
+		StructValueField{"tags", NullValue(List(TypeText))},
+		StructValueField{"nested_null", OptionalValue(NullValue(TypeInt32))},
+		StructValueField{"void", VoidValue()},
+	)
+	exp := map[string]interface{}{
+		"id":          uint64(1),
+		"title":       "test",
+		"remove_date": nil,
+		"tags":        nil,
+		"nested_null": nil,
+		"void":        nil,
+	}
+
+	t.Run("Columns", func(t *testing.T) {
+		fields := row.StructFields()
+		dump := make(map[string]interface{}, len(fields))
+		for name, v := range fields {
+			var dst interface{} = "not assigned"
+			require.NoError(t, Cast(v, &dst), name)
+			dump[name] = dst
+		}
+		require.Equal(t, exp, dump)
+	})
+
+	t.Run("Row", func(t *testing.T) {
+		var dst interface{}
+		require.NoError(t, Cast(row, &dst))
+		require.Equal(t, exp, dst)
+	})
+
+	t.Run("PointerDestinations", func(t *testing.T) {
+		dst := new(int64)
+		require.NoError(t, VoidValue().castTo(&dst))
+		require.Nil(t, dst)
+
+		var iface *interface{}
+		require.NoError(t, OptionalValue(Int32Value(42)).castTo(&iface))
+		require.NotNil(t, iface)
+		require.Equal(t, int64(42), *iface)
+	})
+
+	t.Run("TypedDestinations", func(t *testing.T) {
+		var dst int64
+		require.Error(t, VoidValue().castTo(&dst))
+		require.ErrorIs(t, NullValue(TypeInt64).castTo(&dst), errOptionalNilValue)
+	})
+}
+
+func TestCastToRawMessage(t *testing.T) {
+	const raw = "{ \"a\" :\t[1,  2 ],\n \"b\": null }\n"
+	for _, v := range []Value{
+		JSONValue(raw),
+		JSONDocumentValue(raw),
+		YSONValue([]byte(raw)),
+		OptionalValue(JSONValue(raw)),
+	} {
+		t.Run(v.Type().Yql(), func(t *testing.T) {
+			var dst json.RawMessage
+			require.NoError(t, Cast(v, &dst))
+			require.Equal(t, []byte(raw), []byte(dst))
+		})
+	}
+}
+
+// testDecimal is a minimal decimal destination like wrappers of github.com/shopspring/decimal
+type testDecimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+func (d *testDecimal) SetDecimal(unscaled *big.Int, scale int32) error {
+	d.unscaled, d.scale = unscaled, scale
+	return nil
+}
+
+type testDecimalWithLimit struct {
+	testDecimal
+}
+
+func (d *testDecimalWithLimit) SetDecimal(unscaled *big.Int, scale int32) error {
+	if scale > 2 {
+		return errValueFractional
+	}
+	return d.testDecimal.SetDecimal(unscaled, scale)
+}
+
+func TestCastDecimalToDecimalSetter(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		unscaled  *big.Int
+		scale     int32
+		precision uint32
+		expScale  int32
+		expValue  *big.Int
+	}{
+		{
+			name:      "Positive",
+			unscaled:  big.NewInt(123456789),
+			scale:     9,
+			precision: 22,
+			expScale:  9,
+			expValue:  big.NewInt(123456789),
+		},
+		{
+			name:      "Negative",
+			unscaled:  big.NewInt(-15),
+			scale:     1,
+			precision: 5,
+			expScale:  1,
+			expValue:  big.NewInt(-15),
+		},
+		{
+			name:      "Zero",
+			unscaled:  big.NewInt(0),
+			scale:     3,
+			precision: 10,
+			expScale:  3,
+			expValue:  big.NewInt(0),
+		},
+		{
+			name:      "NegativeScale",
+			unscaled:  big.NewInt(-42),
+			scale:     -3,
+			precision: 10,
+			expScale:  0,
+			expValue:  big.NewInt(-42000),
+		},
+		{
+			name:      "MaxPrecision",
+			unscaled:  new(big.Int).Sub(new(big.Int).Exp(big.NewInt(10), big.NewInt(35), nil), big.NewInt(1)),
+			scale:     10,
+			precision: 35,
+			expScale:  10,
+			expValue:  new(big.Int).Sub(new(big.Int).Exp(big.NewInt(10), big.NewInt(35), nil), big.NewInt(1)),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := DecimalValueFromUnscaled(tt.unscaled, tt.scale, tt.precision)
+			require.NoError(t, err)
+			require.Equal(t, tt.precision, v.Precision())
+			require.Equal(t, uint32(tt.expScale), v.Scale())
+
+			var dst testDecimal
+			require.NoError(t, Cast(v, &dst))
+			require.Equal(t, tt.expScale, dst.scale)
+			require.Equal(t, 0, tt.expValue.Cmp(dst.unscaled), dst.unscaled.String())
+
+			var optional testDecimal
+			require.NoError(t, Cast(OptionalValue(v), &optional))
+			require.Equal(t, dst, optional)
+		})
+	}
+	t.Run("SetterError", func(t *testing.T) {
+		var dst testDecimalWithLimit
+		err := Cast(DecimalValueFromBigInt(big.NewInt(12345), 10, 3), &dst)
+		require.ErrorIs(t, err, errValueFractional)
+		require.NoError(t, Cast(DecimalValueFromBigInt(big.NewInt(12345), 10, 2), &dst))
+		require.Equal(t, int32(2), dst.scale)
+	})
+	t.Run("Inf", func(t *testing.T) {
+		var dst testDecimal
+		err := Cast(DecimalValueFromBigInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(5), nil), 5, 0), &dst)
+		require.ErrorIs(t, err, errValueNotFiniteReal)
+	})
+}
+
+func TestDecimalValueFromUnscaledErrors(t *testing.T) {
+	_, err := DecimalValueFromUnscaled(big.NewInt(1), 6, 5)
+	require.ErrorIs(t, err, errValueOutOfRange)
+	_, err = DecimalValueFromUnscaled(big.NewInt(-100000), 2, 5)
+	require.ErrorIs(t, err, errValueOutOfRange)
+	_, err = DecimalValueFromUnscaled(big.NewInt(1), -5, 5)
+	require.ErrorIs(t, err, errValueOutOfRange)
+}
+
+type (
+	namedBool    bool
+	namedString  string
+	namedInt     int
+	namedInt8    int8
+	namedInt16   int16
+	namedInt32   int32
+	namedInt64   int64
+	namedUint    uint
+	namedUint8   uint8
+	namedUint16  uint16
+	namedUint32  uint32
+	namedUint64  uint64
+	namedFloat32 float32
+	namedFloat64 float64
+)
+
+func TestCastToNamedTypes(t *testing.T) {
+	for _, tt := range []struct {
+		v   Value
+		dst interface{}
+		exp interface{}
+	}{
+		{v: BoolValue(true), dst: new(namedBool), exp: namedBool(true)},
+		{v: TextValue("user@example.com"), dst: new(namedString), exp: namedString("user@example.com")},
+		{v: BytesValue([]byte("bytes")), dst: new(namedString), exp: namedString("bytes")},
+		{v: Int64Value(-42), dst: new(namedString), exp: namedString("-42")},
+		{v: Int64Value(-42), dst: new(namedInt), exp: namedInt(-42)},
+		{v: Int64Value(-42), dst: new(int), exp: -42},
+		{v: Int8Value(-8), dst: new(namedInt8), exp: namedInt8(-8)},
+		{v: Int16Value(-16), dst: new(namedInt16), exp: namedInt16(-16)},
+		{v: Int32Value(-32), dst: new(namedInt32), exp: namedInt32(-32)},
+		{v: Int64Value(-64), dst: new(namedInt64), exp: namedInt64(-64)},
+		{v: Uint64Value(42), dst: new(namedUint), exp: namedUint(42)},
+		{v: Uint8Value(8), dst: new(namedUint8), exp: namedUint8(8)},
+		{v: Uint16Value(16), dst: new(namedUint16), exp: namedUint16(16)},
+		{v: Uint32Value(32), dst: new(namedUint32), exp: namedUint32(32)},
+		{v: Uint64Value(math.MaxUint64), dst: new(namedUint64), exp: namedUint64(math.MaxUint64)},
+		{v: FloatValue(1.5), dst: new(namedFloat32), exp: namedFloat32(1.5)},
+		{v: DoubleValue(2.5), dst: new(namedFloat64), exp: namedFloat64(2.5)},
+		{v: DoubleValue(42), dst: new(namedInt64), exp: namedInt64(42)},
+		{v: DoubleValue(3), dst: new(namedInt), exp: namedInt(3)},
+		{v: DoubleValue(3), dst: new(int), exp: 3},
+		{v: FloatValue(-3), dst: new(namedInt), exp: namedInt(-3)},
+		{v: DoubleValue(3), dst: new(namedUint), exp: namedUint(3)},
+		{v: FloatValue(3), dst: new(uint), exp: uint(3)},
+		{v: OptionalValue(Int32Value(-32)), dst: new(namedInt32), exp: namedInt32(-32)},
+	} {
+		t.Run(tt.v.Yql()+"->"+reflect.TypeOf(tt.dst).String(), func(t *testing.T) {
+			require.NoError(t, Cast(tt.v, tt.dst))
+			require.Equal(t, tt.exp, reflect.ValueOf(tt.dst).Elem().Interface())
+		})
+	}
+	t.Run("PointerToPointer", func(t *testing.T) {
+		var dst *namedInt64
+		require.NoError(t, Cast(OptionalValue(Int64Value(42)), &dst))
+		require.Equal(t, namedInt64(42), *dst)
+		require.NoError(t, Cast(NullValue(TypeInt64), &dst))
+		require.Nil(t, dst)
+	})
+	t.Run("RangeChecks", func(t *testing.T) {
+		err := Cast(DoubleValue(1.5), new(namedInt64))
+		require.ErrorIs(t, err, errValueFractional)
+		require.Contains(t, err.Error(), "namedInt64")
+		require.ErrorIs(t, Cast(DoubleValue(-1), new(namedUint64)), errValueOutOfRange)
+		require.ErrorIs(t, Cast(DoubleValue(1.5), new(namedInt)), errValueFractional)
+		require.ErrorIs(t, Cast(FloatValue(-1), new(uint)), errValueOutOfRange)
+		require.ErrorIs(t, Cast(DoubleValue(math.Inf(1)), new(int)), errValueNotFiniteReal)
+		require.ErrorIs(t, Cast(DoubleValue(math.MaxUint64), new(int)), errValueOutOfRange)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		require.Error(t, Cast(TextValue("42"), new(namedInt64)))
+		require.Error(t, Cast(BoolValue(true), new(namedInt64)))
+	})
+}
+
+func TestCastToIntAndUint(t *testing.T) {
+	for _, tt := range []struct {
+		v      Value
+		expInt interface{} // int or error
+		expUnt interface{} // uint or error
+	}{
+		{v: Int8Value(math.MinInt8), expInt: math.MinInt8, expUnt: errValueOutOfRange},
+		{v: Int8Value(math.MaxInt8), expInt: math.MaxInt8, expUnt: uint(math.MaxInt8)},
+		{v: Int16Value(math.MinInt16), expInt: math.MinInt16, expUnt: errValueOutOfRange},
+		{v: Int16Value(math.MaxInt16), expInt: math.MaxInt16, expUnt: uint(math.MaxInt16)},
+		{v: Int32Value(math.MinInt32), expInt: math.MinInt32, expUnt: errValueOutOfRange},
+		{v: Int32Value(math.MaxInt32), expInt: math.MaxInt32, expUnt: uint(math.MaxInt32)},
+		{v: Int64Value(-1), expInt: -1, expUnt: errValueOutOfRange},
+		{v: Int64Value(0), expInt: 0, expUnt: uint(0)},
+		{v: Uint8Value(math.MaxUint8), expInt: math.MaxUint8, expUnt: uint(math.MaxUint8)},
+		{v: Uint16Value(math.MaxUint16), expInt: math.MaxUint16, expUnt: uint(math.MaxUint16)},
+		{v: Uint32Value(math.MaxInt32), expInt: math.MaxInt32, expUnt: uint(math.MaxInt32)},
+		{v: Uint32Value(math.MaxUint32), expInt: intOrRangeError(math.MaxUint32), expUnt: uint(math.MaxUint32)},
+		{v: Uint64Value(math.MaxInt32), expInt: math.MaxInt32, expUnt: uint(math.MaxInt32)},
+		{v: Uint64Value(math.MaxUint32), expInt: intOrRangeError(math.MaxUint32), expUnt: uint(math.MaxUint32)},
+		{v: Uint64Value(math.MaxUint64), expInt: errValueOutOfRange, expUnt: uintOrRangeError(math.MaxUint64)},
+		{v: DateValue(19000), expInt: 19000, expUnt: uint(19000)},
+		{v: DatetimeValue(math.MaxUint32), expInt: intOrRangeError(math.MaxUint32), expUnt: uint(math.MaxUint32)},
+		{v: TimestampValue(1655443160000000), expInt: intOrRangeError(1655443160000000), expUnt: uintOrRangeError(1655443160000000)},
+	} {
+		t.Run(tt.v.Yql(), func(t *testing.T) {
+			var dstInt int
+			if err, isErr := tt.expInt.(error); isErr {
+				require.ErrorIs(t, Cast(tt.v, &dstInt), err)
+			} else {
+				require.NoError(t, Cast(tt.v, &dstInt))
+				require.Equal(t, tt.expInt, dstInt)
+			}
+			var dstUint uint
+			if err, isErr := tt.expUnt.(error); isErr {
+				require.ErrorIs(t, Cast(tt.v, &dstUint), err)
+			} else {
+				require.NoError(t, Cast(tt.v, &dstUint))
+				require.Equal(t, tt.expUnt, dstUint)
+			}
+		})
+	}
+	t.Run("Int64Boundaries", func(t *testing.T) {
+		for _, v := range []int64{math.MinInt64, math.MinInt32 - 1, math.MinInt32, math.MaxInt32, math.MaxInt32 + 1, math.MaxInt64} {
+			var dst int
+			err := Cast(Int64Value(v), &dst)
+			if exp, fits := intOrRangeError(v).(int); fits {
+				require.NoError(t, err)
+				require.Equal(t, exp, dst)
+			} else {
+				require.ErrorIs(t, err, errValueOutOfRange)
+			}
+		}
+	})
+}
+
+// intOrRangeError returns expected result of casting v to int on current platform
+func intOrRangeError(v int64) interface{} {
+	if checkIntFits(v, strconv.IntSize) != nil {
+		return errValueOutOfRange
+	}
+	return int(v)
+}
+
+// uintOrRangeError returns expected result of casting v to uint on current platform
+func uintOrRangeError(v uint64) interface{} {
+	if checkUintFits(v, strconv.IntSize) != nil {
+		return errValueOutOfRange
+	}
+	return uint(v)
+}
+
+func TestCheckIntFits(t *testing.T) {
+	for _, tt := range []struct {
+		v       int64
+		bitSize int
+		fits    bool
+	}{
+		{v: math.MaxInt32, bitSize: 32, fits: true},
+		{v: math.MaxInt32 + 1, bitSize: 32, fits: false},
+		{v: math.MinInt32, bitSize: 32, fits: true},
+		{v: math.MinInt32 - 1, bitSize: 32, fits: false},
+		{v: math.MaxInt64, bitSize: 64, fits: true},
+		{v: math.MinInt64, bitSize: 64, fits: true},
+	} {
+		t.Run(strconv.FormatInt(tt.v, 10)+"/"+strconv.Itoa(tt.bitSize), func(t *testing.T) {
+			require.Equal(t, tt.fits, checkIntFits(tt.v, tt.bitSize) == nil)
+		})
+	}
+}
+
+func TestCheckUintFits(t *testing.T) {
+	for _, tt := range []struct {
+		v       uint64
+		bitSize int
+		fits    bool
+	}{
+		{v: math.MaxUint32, bitSize: 32, fits: true},
+		{v: math.MaxUint32 + 1, bitSize: 32, fits: false},
+		{v: math.MaxInt32, bitSize: 31, fits: true},
+		{v: math.MaxInt32 + 1, bitSize: 31, fits: false},
+		{v: math.MaxInt64, bitSize: 63, fits: true},
+		{v: math.MaxInt64 + 1, bitSize: 63, fits: false},
+		{v: math.MaxUint64, bitSize: 64, fits: true},
+	} {
+		t.Run(strconv.FormatUint(tt.v, 10)+"/"+strconv.Itoa(tt.bitSize), func(t *testing.T) {
+			require.Equal(t, tt.fits, checkUintFits(tt.v, tt.bitSize) == nil)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package value
+
+import (
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+)
+
+// Clone returns deep copy of v: bytes of byte-backed values and items of containers are copied,
+// so clone doesn't alias memory of caller and is safe to retain after the source slices are reused.
+// Types of values are immutable and are shared between v and its clone
+func Clone(v Value) Value {
+	switch vv := v.(type) {
+	case bytesValue:
+		return bytesValue(cloneBytes(vv))
+	case ysonValue:
+		return ysonValue(cloneBytes(vv))
+	case *optionalValue:
+		if vv == nil {
+			return vv
+		}
+		clone := *vv
+		if vv.value != nil {
+			clone.value = Clone(vv.value)
+		}
+		return &clone
+	case *pgValue:
+		if vv == nil {
+			return vv
+		}
+		clone := *vv
+		return &clone
+	case *taggedValue:
+		if vv == nil {
+			return vv
+		}
+		return &taggedValue{t: vv.t, value: Clone(vv.value)}
+	case *listValue:
+		if vv == nil {
+			return vv
+		}
+		return &listValue{t: vv.t, items: cloneItems(vv.items)}
+	case *setValue:
+		if vv == nil {
+			return vv
+		}
+		return &setValue{t: vv.t, items: cloneItems(vv.items)}
+	case *tupleValue:
+		if vv == nil {
+			return vv
+		}
+		return &tupleValue{t: vv.t, items: cloneItems(vv.items)}
+	case *structValue:
+		if vv == nil {
+			return vv
+		}
+		clone := *vv
+		clone.fields = make([]StructValueField, len(vv.fields))
+		for i := range vv.fields {
+			clone.fields[i] = StructValueField{Name: vv.fields[i].Name, V: Clone(vv.fields[i].V)}
+		}
+		return &clone
+	case *dictValue:
+		if vv == nil {
+			return vv
+		}
+		clone := &dictValue{t: vv.t, values: make([]DictValueField, len(vv.values))}
+		for i := range vv.values {
+			clone.values[i] = DictValueField{K: Clone(vv.values[i].K), V: Clone(vv.values[i].V)}
+		}
+		return clone
+	case *variantValue:
+		if vv == nil {
+			return vv
+		}
+		clone := *vv
+		clone.value = Clone(vv.value)
+		return &clone
+	case *secretValue:
+		return SecretValue(Clone(vv.value()))
+	case *rawValue:
+		return &rawValue{t: vv.t, v: proto.Clone(vv.v).(*Ydb.Value)}
+	default:
+		// values of other types don't refer to memory of caller
+		return v
+	}
+}
+
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append(make([]byte, 0, len(b)), b...)
+}
+
+func cloneItems(items []Value) []Value {
+	if items == nil {
+		return nil
+	}
+	clone := make([]Value, len(items))
+	for i := range items {
+		clone[i] = Clone(items[i])
+	}
+	return clone
+}
@@ -0,0 +1,70 @@
+package value
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+)
+
+func TestClone(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		v    func(b []byte) Value
+	}{
+		{"Bytes", func(b []byte) Value { return BytesValue(b) }},
+		{"YSON", func(b []byte) Value { return YSONValue(b) }},
+		{"Optional", func(b []byte) Value { return OptionalValue(BytesValue(b)) }},
+		{"List", func(b []byte) Value { return ListValue(BytesValue(b), BytesValue([]byte("x"))) }},
+		{"Set", func(b []byte) Value { return SetValue(BytesValue(b)) }},
+		{"Tuple", func(b []byte) Value { return TupleValue(Int32Value(1), BytesValue(b)) }},
+		{"Struct", func(b []byte) Value {
+			return StructValue(StructValueField{"b", BytesValue(b)}, StructValueField{"a", Int32Value(1)})
+		}},
+		{"StructOrdered", func(b []byte) Value {
+			return StructValueOrdered(StructValueField{"b", BytesValue(b)}, StructValueField{"a", Int32Value(1)})
+		}},
+		{"DictKey", func(b []byte) Value { return DictValue(DictValueField{K: BytesValue(b), V: Int32Value(1)}) }},
+		{"DictValue", func(b []byte) Value { return DictValue(DictValueField{K: Int32Value(1), V: YSONValue(b)}) }},
+		{"Variant", func(b []byte) Value {
+			return VariantValueTuple(BytesValue(b), 1, Tuple(TypeInt32, TypeBytes))
+		}},
+		{"Secret", func(b []byte) Value { return SecretValue(BytesValue(b)) }},
+		{"Nested", func(b []byte) Value {
+			return ListValue(OptionalValue(StructValue(StructValueField{"a", ListValue(BytesValue(b))})))
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a := allocator.New()
+			defer a.Free()
+			b := []byte("source")
+			v := tt.v(b)
+			clone := Clone(v)
+			expected := proto.Clone(ToYDB(v, a))
+
+			copy(b, "SOURCE")
+
+			require.True(t, proto.Equal(expected, ToYDB(clone, a)))
+			require.False(t, proto.Equal(expected, ToYDB(v, a)))
+			require.True(t, clone.Type().equalsTo(v.Type()))
+		})
+	}
+	t.Run("Immutable", func(t *testing.T) {
+		for _, v := range []Value{
+			Int32Value(1),
+			TextValue("a"),
+			DoubleValue(0.5),
+			UUIDValue([16]byte{1}),
+			DecimalValue([16]byte{1}, 22, 9),
+			NullValue(TypeBytes),
+		} {
+			require.True(t, Equal(v, Clone(v)), v.Yql())
+		}
+	})
+	t.Run("Nil", func(t *testing.T) {
+		require.Nil(t, Clone(nil))
+		require.Nil(t, Clone(BytesValue(nil)).(bytesValue))
+	})
+}
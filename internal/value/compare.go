@@ -0,0 +1,171 @@
+package value
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+var errIncomparableKeys = errors.New("keys are not comparable")
+
+// CompareKeys compares values of primary keys (such as shard key bounds of table) in order of YDB
+// and returns -1, 0 or +1 if a is less than, equal to or greater than b.
+//
+// Tuples are compared item by item, tuple which is a prefix of another tuple is less than it
+// (missing trailing columns of key prefix are less than any value). NULL is less than any value,
+// non-NULL optional values are compared with inner values. Integer, text, bytes and time values
+// of the same type are comparable, CompareKeys returns error for other types (such as Uuid or Decimal)
+func CompareKeys(a, b Value) (int, error) {
+	a, b = unwrapSecret(a), unwrapSecret(b)
+	if aa, ok := a.(*tupleValue); ok {
+		bb, ok := b.(*tupleValue)
+		if !ok {
+			return 0, incomparableKeysError(a, b)
+		}
+		for i := 0; i < len(aa.items) && i < len(bb.items); i++ {
+			if c, err := CompareKeys(aa.items[i], bb.items[i]); err != nil || c != 0 {
+				return c, err
+			}
+		}
+		return compareInts(int64(len(aa.items)), int64(len(bb.items))), nil
+	}
+	a, aNull := unwrapKey(a)
+	b, bNull := unwrapKey(b)
+	switch {
+	case aNull && bNull:
+		return 0, nil
+	case aNull:
+		return -1, nil
+	case bNull:
+		return 1, nil
+	}
+	switch aa := a.(type) {
+	case boolValue:
+		if bb, ok := b.(boolValue); ok {
+			return compareInts(boolToInt(bool(aa)), boolToInt(bool(bb))), nil
+		}
+	case int8Value:
+		if bb, ok := b.(int8Value); ok {
+			return compareInts(int64(aa), int64(bb)), nil
+		}
+	case int16Value:
+		if bb, ok := b.(int16Value); ok {
+			return compareInts(int64(aa), int64(bb)), nil
+		}
+	case int32Value:
+		if bb, ok := b.(int32Value); ok {
+			return compareInts(int64(aa), int64(bb)), nil
+		}
+	case int64Value:
+		if bb, ok := b.(int64Value); ok {
+			return compareInts(int64(aa), int64(bb)), nil
+		}
+	case intervalValue:
+		if bb, ok := b.(intervalValue); ok {
+			return compareInts(int64(aa), int64(bb)), nil
+		}
+	case interval64Value:
+		if bb, ok := b.(interval64Value); ok {
+			return compareInts(int64(aa), int64(bb)), nil
+		}
+	case date32Value:
+		if bb, ok := b.(date32Value); ok {
+			return compareInts(int64(aa), int64(bb)), nil
+		}
+	case datetime64Value:
+		if bb, ok := b.(datetime64Value); ok {
+			return compareInts(int64(aa), int64(bb)), nil
+		}
+	case timestamp64Value:
+		if bb, ok := b.(timestamp64Value); ok {
+			return compareInts(int64(aa), int64(bb)), nil
+		}
+	case uint8Value:
+		if bb, ok := b.(uint8Value); ok {
+			return compareUints(uint64(aa), uint64(bb)), nil
+		}
+	case uint16Value:
+		if bb, ok := b.(uint16Value); ok {
+			return compareUints(uint64(aa), uint64(bb)), nil
+		}
+	case uint32Value:
+		if bb, ok := b.(uint32Value); ok {
+			return compareUints(uint64(aa), uint64(bb)), nil
+		}
+	case uint64Value:
+		if bb, ok := b.(uint64Value); ok {
+			return compareUints(uint64(aa), uint64(bb)), nil
+		}
+	case dateValue:
+		if bb, ok := b.(dateValue); ok {
+			return compareUints(uint64(aa), uint64(bb)), nil
+		}
+	case datetimeValue:
+		if bb, ok := b.(datetimeValue); ok {
+			return compareUints(uint64(aa), uint64(bb)), nil
+		}
+	case timestampValue:
+		if bb, ok := b.(timestampValue); ok {
+			return compareUints(uint64(aa), uint64(bb)), nil
+		}
+	case textValue:
+		if bb, ok := b.(textValue); ok {
+			return strings.Compare(string(aa), string(bb)), nil
+		}
+	case bytesValue:
+		if bb, ok := b.(bytesValue); ok {
+			return bytes.Compare(aa, bb), nil
+		}
+	}
+	return 0, incomparableKeysError(a, b)
+}
+
+// unwrapKey returns inner value of non-NULL optional value (of any depth) or true for NULL
+func unwrapKey(v Value) (_ Value, null bool) {
+	for {
+		optional, ok := v.(*optionalValue)
+		if !ok {
+			return v, false
+		}
+		if optional.value == nil {
+			return nil, true
+		}
+		v = unwrapSecret(optional.value)
+	}
+}
+
+func incomparableKeysError(a, b Value) error {
+	return xerrors.WithStackTrace(fmt.Errorf("%w: %s and %s", errIncomparableKeys, a.Type().Yql(), b.Type().Yql()))
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareUints(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
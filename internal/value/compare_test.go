@@ -0,0 +1,51 @@
+package value
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareKeys(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		a, b Value
+		c    int
+	}{
+		{"Int32", Int32Value(-1), Int32Value(1), -1},
+		{"Uint64", Uint64Value(1 << 63), Uint64Value(1), 1},
+		{"Text", TextValue("b"), TextValue("ab"), 1},
+		{"Bytes", BytesValue([]byte("a")), BytesValue([]byte("a")), 0},
+		{"Timestamp", TimestampValue(1), TimestampValue(2), -1},
+		{"Optional", OptionalValue(Int32Value(2)), Int32Value(1), 1},
+		{"Null", NullValue(TypeInt32), OptionalValue(Int32Value(-1)), -1},
+		{"Nulls", NullValue(TypeInt32), NullValue(TypeInt32), 0},
+		{"Tuple", TupleValue(Int32Value(1), TextValue("b")), TupleValue(Int32Value(1), TextValue("a")), 1},
+		{"TupleFirst", TupleValue(Int32Value(1), TextValue("b")), TupleValue(Int32Value(2), TextValue("a")), -1},
+		{"TuplePrefix", TupleValue(Int32Value(1)), TupleValue(Int32Value(1), NullValue(TypeText)), -1},
+		{"TupleEqual", TupleValue(Int32Value(1), TextValue("a")), TupleValue(Int32Value(1), TextValue("a")), 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := CompareKeys(tt.a, tt.b)
+			require.NoError(t, err)
+			require.Equal(t, tt.c, c)
+			c, err = CompareKeys(tt.b, tt.a)
+			require.NoError(t, err)
+			require.Equal(t, -tt.c, c)
+		})
+	}
+	for _, tt := range []struct {
+		name string
+		a, b Value
+	}{
+		{"Types", Int32Value(1), Int64Value(1)},
+		{"UUID", UUIDValue([16]byte{1}), UUIDValue([16]byte{2})},
+		{"Decimal", DecimalValueFromBigInt(mustBigInt("1"), 22, 9), DecimalValueFromBigInt(mustBigInt("2"), 22, 9)},
+		{"TupleAndValue", TupleValue(Int32Value(1)), Int32Value(1)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompareKeys(tt.a, tt.b)
+			require.Error(t, err)
+		})
+	}
+}
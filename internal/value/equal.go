@@ -0,0 +1,114 @@
+package value
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+)
+
+// Equal reports whether values a and b have equal types and equal contents.
+//
+// Items of lists, tuples and fields of structs are compared in order, pairs of dicts
+// and items of sets are compared regardless of order. Values of primitive types are
+// compared by wire representation (so bytes and decimals are compared byte-wise and
+// NaN is equal to NaN). Secret values are compared by wrapped values
+func Equal(a, b Value) bool {
+	a, b = unwrapSecret(a), unwrapSecret(b)
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if !TypesEqual(a.Type(), b.Type()) {
+		return false
+	}
+	switch aa := a.(type) {
+	case *optionalValue:
+		if bb, ok := b.(*optionalValue); ok {
+			return Equal(aa.value, bb.value)
+		}
+	case *taggedValue:
+		if bb, ok := b.(*taggedValue); ok {
+			return Equal(aa.value, bb.value)
+		}
+	case *listValue:
+		if bb, ok := b.(*listValue); ok {
+			return itemsEqual(aa.items, bb.items)
+		}
+	case *tupleValue:
+		if bb, ok := b.(*tupleValue); ok {
+			return itemsEqual(aa.items, bb.items)
+		}
+	case *structValue:
+		if bb, ok := b.(*structValue); ok {
+			if len(aa.fields) != len(bb.fields) {
+				return false
+			}
+			for i := range aa.fields {
+				if !Equal(aa.fields[i].V, bb.fields[i].V) {
+					return false
+				}
+			}
+			return true
+		}
+	case *variantValue:
+		if bb, ok := b.(*variantValue); ok {
+			return aa.idx == bb.idx && Equal(aa.value, bb.value)
+		}
+	case *dictValue:
+		if bb, ok := b.(*dictValue); ok {
+			return unorderedEqual(len(aa.values), len(bb.values), func(i, j int) bool {
+				return Equal(aa.values[i].K, bb.values[j].K) && Equal(aa.values[i].V, bb.values[j].V)
+			})
+		}
+	case *setValue:
+		if bb, ok := b.(*setValue); ok {
+			return unorderedEqual(len(aa.items), len(bb.items), func(i, j int) bool {
+				return Equal(aa.items[i], bb.items[j])
+			})
+		}
+	}
+
+	alloc := allocator.New()
+	defer alloc.Free()
+
+	return proto.Equal(a.toYDB(alloc), b.toYDB(alloc))
+}
+
+func itemsEqual(a, b []Value) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// unorderedEqual reports whether each of n items of one container is equal to another
+// item of other container with m items. Containers are usually sorted by constructors,
+// so item with the same index is checked first
+func unorderedEqual(n, m int, equal func(i, j int) bool) bool {
+	if n != m {
+		return false
+	}
+	matched := make([]bool, m)
+	for i := 0; i < n; i++ {
+		if !matched[i] && equal(i, i) {
+			matched[i] = true
+			continue
+		}
+		found := false
+		for j := 0; j < m; j++ {
+			if !matched[j] && equal(i, j) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
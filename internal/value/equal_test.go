@@ -0,0 +1,174 @@
+package value
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+)
+
+func TestEqual(t *testing.T) {
+	decimal := func(lo byte) Value {
+		return DecimalValue([16]byte{15: lo}, 22, 9)
+	}
+	dict := func(pairs ...DictValueField) *dictValue {
+		return DictValueOfTypes(TypeText, TypeInt32, pairs...)
+	}
+	reversed := func(v *dictValue) *dictValue {
+		values := make([]DictValueField, len(v.values))
+		for i := range v.values {
+			values[len(values)-1-i] = v.values[i]
+		}
+		return &dictValue{t: v.t, values: values}
+	}
+	pairs := []DictValueField{
+		{K: TextValue("a"), V: Int32Value(1)},
+		{K: TextValue("b"), V: Int32Value(2)},
+	}
+	tupleType := Tuple(TypeInt32, TypeText)
+	for _, tt := range []struct {
+		name  string
+		a, b  Value
+		equal bool
+	}{
+		{"Bool", BoolValue(true), BoolValue(true), true},
+		{"BoolDiff", BoolValue(true), BoolValue(false), false},
+		{"Int32", Int32Value(1), Int32Value(1), true},
+		{"Int32Diff", Int32Value(1), Int32Value(2), false},
+		{"IntTypes", Int32Value(1), Int64Value(1), false},
+		{"Uint64", Uint64Value(math.MaxUint64), Uint64Value(math.MaxUint64), true},
+		{"Double", DoubleValue(0.5), DoubleValue(0.5), true},
+		{"DoubleDiff", DoubleValue(0.5), DoubleValue(0.25), false},
+		{"DoubleNaN", DoubleValue(math.NaN()), DoubleValue(math.NaN()), true},
+		{"Float", FloatValue(0.5), FloatValue(0.5), true},
+		{"FloatDouble", FloatValue(0.5), DoubleValue(0.5), false},
+		{"Text", TextValue("a"), TextValue("a"), true},
+		{"TextDiff", TextValue("a"), TextValue("b"), false},
+		{"TextBytes", TextValue("a"), BytesValue([]byte("a")), false},
+		{"Bytes", BytesValue([]byte{1, 2}), BytesValue([]byte{1, 2}), true},
+		{"BytesDiff", BytesValue([]byte{1, 2}), BytesValue([]byte{1, 3}), false},
+		{"BytesEmpty", BytesValue(nil), BytesValue([]byte{}), true},
+		{"Decimal", decimal(1), decimal(1), true},
+		{"DecimalDiff", decimal(1), decimal(2), false},
+		{"DecimalPrecision", decimal(1), DecimalValue([16]byte{15: 1}, 22, 8), false},
+		{"UUID", UUIDValue([16]byte{1}), UUIDValue([16]byte{1}), true},
+		{"UUIDDiff", UUIDValue([16]byte{1}), UUIDValue([16]byte{2}), false},
+		{"Date", DateValue(1), DateValue(1), true},
+		{"DateDatetime", DateValue(1), DatetimeValue(1), false},
+		{"Interval", IntervalValue(1), IntervalValue(1), true},
+		{"TzDate", TzDateValue("2020-01-01,Europe/Berlin"), TzDateValue("2020-01-01,Europe/Berlin"), true},
+		{"JSON", JSONValue(`{"a":1}`), JSONValue(`{"a":1}`), true},
+		{"JSONDiff", JSONValue(`{"a":1}`), JSONValue(`{"a":2}`), false},
+		{"Void", VoidValue(), VoidValue(), true},
+		{"Optional", OptionalValue(Int32Value(1)), OptionalValue(Int32Value(1)), true},
+		{"OptionalDiff", OptionalValue(Int32Value(1)), OptionalValue(Int32Value(2)), false},
+		{"OptionalNull", NullValue(TypeInt32), NullValue(TypeInt32), true},
+		{"OptionalNullAndValue", NullValue(TypeInt32), OptionalValue(Int32Value(0)), false},
+		{"OptionalNullTypes", NullValue(TypeInt32), NullValue(TypeInt64), false},
+		{"OptionalAndItem", OptionalValue(Int32Value(1)), Int32Value(1), false},
+		{
+			"NestedOptionalNull",
+			NullValue(Optional(TypeInt32)),
+			OptionalValue(NullValue(TypeInt32)),
+			false,
+		},
+		{
+			"NestedOptional",
+			OptionalValue(NullValue(TypeInt32)),
+			OptionalValue(NullValue(TypeInt32)),
+			true,
+		},
+		{"List", ListValue(Int32Value(1), Int32Value(2)), ListValue(Int32Value(1), Int32Value(2)), true},
+		{"ListOrder", ListValue(Int32Value(1), Int32Value(2)), ListValue(Int32Value(2), Int32Value(1)), false},
+		{"ListLength", ListValue(Int32Value(1)), ListValue(Int32Value(1), Int32Value(1)), false},
+		{"ListEmpty", &listValue{t: List(TypeInt32)}, &listValue{t: List(TypeInt32)}, true},
+		{"Tuple", TupleValue(Int32Value(1), TextValue("a")), TupleValue(Int32Value(1), TextValue("a")), true},
+		{"TupleDiff", TupleValue(Int32Value(1), TextValue("a")), TupleValue(Int32Value(1), TextValue("b")), false},
+		{
+			"Struct",
+			StructValue(StructValueField{"a", Int32Value(1)}, StructValueField{"b", TextValue("b")}),
+			StructValue(StructValueField{"b", TextValue("b")}, StructValueField{"a", Int32Value(1)}),
+			true,
+		},
+		{
+			"StructDiff",
+			StructValue(StructValueField{"a", Int32Value(1)}),
+			StructValue(StructValueField{"a", Int32Value(2)}),
+			false,
+		},
+		{
+			"StructNames",
+			StructValue(StructValueField{"a", Int32Value(1)}),
+			StructValue(StructValueField{"b", Int32Value(1)}),
+			false,
+		},
+		{
+			"StructOrdered",
+			StructValueOrdered(StructValueField{"b", TextValue("b")}, StructValueField{"a", Int32Value(1)}),
+			StructValue(StructValueField{"b", TextValue("b")}, StructValueField{"a", Int32Value(1)}),
+			false,
+		},
+		{"Dict", dict(pairs...), dict(pairs[1], pairs[0]), true},
+		{"DictUnordered", dict(pairs...), reversed(dict(pairs...)), true},
+		{"DictValueDiff", dict(pairs...), dict(pairs[0], DictValueField{K: TextValue("b"), V: Int32Value(3)}), false},
+		{"DictKeyDiff", dict(pairs...), dict(pairs[0], DictValueField{K: TextValue("c"), V: Int32Value(2)}), false},
+		{"DictLength", dict(pairs...), dict(pairs[0]), false},
+		{"DictEmpty", dict(), dict(), true},
+		{"Set", SetValue(Int32Value(1), Int32Value(2)), SetValue(Int32Value(2), Int32Value(1)), true},
+		{
+			"SetUnordered",
+			&setValue{t: Set(TypeInt32), items: []Value{Int32Value(1), Int32Value(2)}},
+			&setValue{t: Set(TypeInt32), items: []Value{Int32Value(2), Int32Value(1)}},
+			true,
+		},
+		{"SetDiff", SetValue(Int32Value(1), Int32Value(2)), SetValue(Int32Value(1), Int32Value(3)), false},
+		{
+			"Variant",
+			VariantValueTuple(Int32Value(1), 0, tupleType),
+			VariantValueTuple(Int32Value(1), 0, tupleType),
+			true,
+		},
+		{
+			"VariantIndex",
+			VariantValueTuple(TextValue("a"), 1, tupleType),
+			VariantValueTuple(TextValue("a"), 0, Tuple(TypeText, TypeText)),
+			false,
+		},
+		{
+			"VariantDiff",
+			VariantValueTuple(TextValue("a"), 1, tupleType),
+			VariantValueTuple(TextValue("b"), 1, tupleType),
+			false,
+		},
+		{"Secret", SecretValue(TextValue("a")), TextValue("a"), true},
+		{"SecretDiff", SecretValue(TextValue("a")), SecretValue(TextValue("b")), false},
+		{
+			"Nested",
+			ListValue(OptionalValue(StructValue(StructValueField{"d", dict(pairs...)}))),
+			ListValue(OptionalValue(StructValue(StructValueField{"d", reversed(dict(pairs...))}))),
+			true,
+		},
+		{
+			"NestedDiff",
+			ListValue(OptionalValue(StructValue(StructValueField{"d", dict(pairs...)}))),
+			ListValue(OptionalValue(StructValue(StructValueField{"d", dict(pairs[0])}))),
+			false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.equal, Equal(tt.a, tt.b))
+			require.Equal(t, tt.equal, Equal(tt.b, tt.a))
+			require.True(t, Equal(tt.a, tt.a))
+
+			// values decoded from YDB are equal to constructed values
+			a := allocator.New()
+			defer a.Free()
+			decoded, err := FromYDBWithError(tt.a.Type().toYDB(a), tt.a.toYDB(a))
+			require.NoError(t, err)
+			require.True(t, Equal(tt.a, decoded))
+			require.Equal(t, tt.equal, Equal(decoded, tt.b))
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package value
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xstring"
+)
+
+// goTypeNames are names of constants of primitive types in package types
+var goTypeNames = map[PrimitiveType]string{
+	TypeBool:         "TypeBool",
+	TypeInt8:         "TypeInt8",
+	TypeUint8:        "TypeUint8",
+	TypeInt16:        "TypeInt16",
+	TypeUint16:       "TypeUint16",
+	TypeInt32:        "TypeInt32",
+	TypeUint32:       "TypeUint32",
+	TypeInt64:        "TypeInt64",
+	TypeUint64:       "TypeUint64",
+	TypeFloat:        "TypeFloat",
+	TypeDouble:       "TypeDouble",
+	TypeDate:         "TypeDate",
+	TypeDatetime:     "TypeDatetime",
+	TypeTimestamp:    "TypeTimestamp",
+	TypeInterval:     "TypeInterval",
+	TypeTzDate:       "TypeTzDate",
+	TypeTzDatetime:   "TypeTzDatetime",
+	TypeTzTimestamp:  "TypeTzTimestamp",
+	TypeBytes:        "TypeBytes",
+	TypeText:         "TypeText",
+	TypeYSON:         "TypeYSON",
+	TypeJSON:         "TypeJSON",
+	TypeUUID:         "TypeUUID",
+	TypeJSONDocument: "TypeJSONDocument",
+	TypeDyNumber:     "TypeDyNumber",
+	TypeDate32:       "TypeDate32",
+	TypeDatetime64:   "TypeDatetime64",
+	TypeTimestamp64:  "TypeTimestamp64",
+	TypeInterval64:   "TypeInterval64",
+}
+
+// formatValue implements fmt.Formatter of values:
+//   - %v and %s print concise representation of value (YQL literal or
+//     bounded dump for binary values)
+//   - %+v prints concise representation annotated with type (such as `1l : Int64`)
+//   - %#v prints go expression which makes equal value with constructors of package types
+//     (values without public constructors are printed as nil with YQL literal in comment)
+//   - %q prints double-quoted concise representation
+//
+// Content of secret values is never printed.
+func formatValue(v Value, s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('#'):
+		buffer := xstring.Buffer()
+		defer buffer.Free()
+		writeGoValue(&buffer.Buffer, v)
+		_, _ = s.Write(buffer.Bytes())
+	case verb == 'v' && s.Flag('+'):
+		_, _ = fmt.Fprintf(s, "%s : %s", conciseString(v), v.Type().Yql())
+	case verb == 'v' || verb == 's':
+		_, _ = fmt.Fprint(s, conciseString(v))
+	case verb == 'q':
+		_, _ = fmt.Fprint(s, strconv.Quote(conciseString(v)))
+	default:
+		_, _ = fmt.Fprintf(s, "%%!%c(%s)", verb, conciseString(v))
+	}
+}
+
+// conciseString returns String of value if value is a fmt.Stringer or YQL literal otherwise
+func conciseString(v Value) string {
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return v.Yql()
+}
+
+func writeGoValues(buffer *bytes.Buffer, values []Value) {
+	for i, v := range values {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		writeGoValue(buffer, v)
+	}
+}
+
+func writeGoCall(buffer *bytes.Buffer, name string, args ...string) {
+	buffer.WriteString("types.")
+	buffer.WriteString(name)
+	buffer.WriteByte('(')
+	for i, arg := range args {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(arg)
+	}
+	buffer.WriteByte(')')
+}
+
+func writeGoFloat(buffer *bytes.Buffer, name string, f float64, bitSize int) {
+	var arg string
+	switch {
+	case math.IsNaN(f):
+		arg = "math.NaN()"
+	case math.IsInf(f, 1):
+		arg = "math.Inf(1)"
+	case math.IsInf(f, -1):
+		arg = "math.Inf(-1)"
+	default:
+		writeGoCall(buffer, name, strconv.FormatFloat(f, 'g', -1, bitSize))
+		return
+	}
+	if bitSize == 32 {
+		arg = "float32(" + arg + ")"
+	}
+	writeGoCall(buffer, name, arg)
+}
+
+func writeGoUnsupported(buffer *bytes.Buffer, yql string) {
+	buffer.WriteString("nil /* ")
+	buffer.WriteString(yql)
+	buffer.WriteString(" */")
+}
+
+//nolint:gocyclo,funlen
+func writeGoValue(buffer *bytes.Buffer, v Value) {
+	switch vv := v.(type) {
+	case boolValue:
+		writeGoCall(buffer, "BoolValue", strconv.FormatBool(bool(vv)))
+	case int8Value:
+		writeGoCall(buffer, "Int8Value", strconv.FormatInt(int64(vv), 10))
+	case int16Value:
+		writeGoCall(buffer, "Int16Value", strconv.FormatInt(int64(vv), 10))
+	case int32Value:
+		writeGoCall(buffer, "Int32Value", strconv.FormatInt(int64(vv), 10))
+	case int64Value:
+		writeGoCall(buffer, "Int64Value", strconv.FormatInt(int64(vv), 10))
+	case uint8Value:
+		writeGoCall(buffer, "Uint8Value", strconv.FormatUint(uint64(vv), 10))
+	case uint16Value:
+		writeGoCall(buffer, "Uint16Value", strconv.FormatUint(uint64(vv), 10))
+	case uint32Value:
+		writeGoCall(buffer, "Uint32Value", strconv.FormatUint(uint64(vv), 10))
+	case uint64Value:
+		writeGoCall(buffer, "Uint64Value", strconv.FormatUint(uint64(vv), 10))
+	case *floatValue:
+		writeGoFloat(buffer, "FloatValue", float64(vv.value), 32)
+	case *doubleValue:
+		writeGoFloat(buffer, "DoubleValue", vv.value, 64)
+	case dateValue:
+		writeGoCall(buffer, "DateValue", strconv.FormatUint(uint64(vv), 10))
+	case datetimeValue:
+		writeGoCall(buffer, "DatetimeValue", strconv.FormatUint(uint64(vv), 10))
+	case timestampValue:
+		writeGoCall(buffer, "TimestampValue", strconv.FormatUint(uint64(vv), 10))
+	case intervalValue:
+		writeGoCall(buffer, "IntervalValueFromMicroseconds", strconv.FormatInt(int64(vv), 10))
+	case date32Value:
+		writeGoCall(buffer, "Date32Value", strconv.FormatInt(int64(vv), 10))
+	case datetime64Value:
+		writeGoCall(buffer, "Datetime64Value", strconv.FormatInt(int64(vv), 10))
+	case timestamp64Value:
+		writeGoCall(buffer, "Timestamp64Value", strconv.FormatInt(int64(vv), 10))
+	case interval64Value:
+		writeGoCall(buffer, "Interval64Value", strconv.FormatInt(int64(vv), 10))
+	case tzDateValue:
+		writeGoCall(buffer, "TzDateValue", strconv.Quote(string(vv)))
+	case tzDatetimeValue:
+		writeGoCall(buffer, "TzDatetimeValue", strconv.Quote(string(vv)))
+	case tzTimestampValue:
+		writeGoCall(buffer, "TzTimestampValue", strconv.Quote(string(vv)))
+	case textValue:
+		writeGoCall(buffer, "TextValue", strconv.Quote(string(vv)))
+	case bytesValue:
+		writeGoCall(buffer, "BytesValue", "[]byte("+strconv.Quote(string(vv))+")")
+	case ysonValue:
+		writeGoCall(buffer, "YSONValueFromBytes", "[]byte("+strconv.Quote(string(vv))+")")
+	case jsonValue:
+		writeGoCall(buffer, "JSONValue", strconv.Quote(string(vv)))
+	case jsonDocumentValue:
+		writeGoCall(buffer, "JSONDocumentValue", strconv.Quote(string(vv)))
+	case dyNumberValue:
+		writeGoCall(buffer, "DyNumberValue", strconv.Quote(string(vv)))
+	case *uuidValue:
+		writeGoCall(buffer, "UUIDValueFromUUID",
+			"uuid.MustParse("+strconv.Quote(uuid.UUID(vv.value).String())+")",
+		)
+	case *decimalValue:
+		buffer.WriteString("types.DecimalValue(&types.Decimal{Bytes: [16]byte{")
+		for i, b := range vv.value {
+			if i > 0 {
+				buffer.WriteString(", ")
+			}
+			buffer.WriteString("0x")
+			buffer.WriteByte(hexDigits[b>>4])
+			buffer.WriteByte(hexDigits[b&0x0f])
+		}
+		fmt.Fprintf(buffer, "}, Precision: %d, Scale: %d})", vv.innerType.Precision, vv.innerType.Scale)
+	case voidValue:
+		writeGoCall(buffer, "VoidValue")
+	case *pgValue:
+		oid := strconv.FormatUint(uint64(vv.t.oid), 10)
+		if vv.null {
+			writeGoCall(buffer, "PgNullValue", oid)
+		} else {
+			writeGoCall(buffer, "PgValue", oid, strconv.Quote(vv.text))
+		}
+	case *optionalValue:
+		if vv.value == nil {
+			t, ok := UnwrapOptional(vv.innerType)
+			if !ok {
+				writeGoUnsupported(buffer, vv.Yql())
+				return
+			}
+			buffer.WriteString("types.NullValue(")
+			writeGoType(buffer, t)
+		} else {
+			buffer.WriteString("types.OptionalValue(")
+			writeGoValue(buffer, vv.value)
+		}
+		buffer.WriteByte(')')
+	case *taggedValue:
+		fmt.Fprintf(buffer, "types.TaggedValue(%s, ", strconv.Quote(vv.t.tag))
+		writeGoValue(buffer, vv.value)
+		buffer.WriteByte(')')
+	case *listValue:
+		if t, ok := vv.t.(*listType); ok && len(vv.items) == 0 {
+			buffer.WriteString("types.ZeroValue(")
+			writeGoType(buffer, t)
+		} else {
+			buffer.WriteString("types.ListValue(")
+			writeGoValues(buffer, vv.items)
+		}
+		buffer.WriteByte(')')
+	case *setValue:
+		if len(vv.items) == 0 {
+			writeGoUnsupported(buffer, vv.Yql()+" : "+vv.Type().Yql())
+			return
+		}
+		buffer.WriteString("types.SetValue(")
+		writeGoValues(buffer, vv.items)
+		buffer.WriteByte(')')
+	case *tupleValue:
+		buffer.WriteString("types.TupleValue(")
+		writeGoValues(buffer, vv.items)
+		buffer.WriteByte(')')
+	case *structValue:
+		ordered := sort.SliceIsSorted(vv.fields, func(i, j int) bool {
+			return vv.fields[i].Name < vv.fields[j].Name
+		})
+		if ordered {
+			buffer.WriteString("types.StructValue(")
+		} else {
+			buffer.WriteString("types.StructValueOrdered(")
+		}
+		for i := range vv.fields {
+			if i > 0 {
+				buffer.WriteString(", ")
+			}
+			fmt.Fprintf(buffer, "types.StructFieldValue(%s, ", strconv.Quote(vv.fields[i].Name))
+			writeGoValue(buffer, vv.fields[i].V)
+			buffer.WriteByte(')')
+		}
+		buffer.WriteByte(')')
+	case *dictValue:
+		if t, ok := vv.t.(*dictType); ok && len(vv.values) == 0 {
+			buffer.WriteString("types.DictValueOfTypes(")
+			writeGoType(buffer, t.keyType)
+			buffer.WriteString(", ")
+			writeGoType(buffer, t.valueType)
+			buffer.WriteByte(')')
+			return
+		}
+		buffer.WriteString("types.DictValue(")
+		for i := range vv.values {
+			if i > 0 {
+				buffer.WriteString(", ")
+			}
+			buffer.WriteString("types.DictFieldValue(")
+			writeGoValue(buffer, vv.values[i].K)
+			buffer.WriteString(", ")
+			writeGoValue(buffer, vv.values[i].V)
+			buffer.WriteByte(')')
+		}
+		buffer.WriteByte(')')
+	case *variantValue:
+		name, idx := vv.Variant()
+		if _, ok := vv.innerType.(*variantStructType); ok {
+			buffer.WriteString("types.VariantValueStruct(")
+			writeGoValue(buffer, vv.value)
+			fmt.Fprintf(buffer, ", %s, ", strconv.Quote(name))
+		} else {
+			buffer.WriteString("types.VariantValueTuple(")
+			writeGoValue(buffer, vv.value)
+			fmt.Fprintf(buffer, ", %d, ", idx)
+		}
+		writeGoType(buffer, vv.innerType)
+		buffer.WriteByte(')')
+	case *secretValue:
+		buffer.WriteString(secretYql)
+	default:
+		writeGoUnsupported(buffer, v.Yql()+" : "+v.Type().Yql())
+	}
+}
+
+func writeGoTypes(buffer *bytes.Buffer, types []Type) {
+	for i, t := range types {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		writeGoType(buffer, t)
+	}
+}
+
+func writeGoStructFields(buffer *bytes.Buffer, fields []StructField) {
+	for i := range fields {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		fmt.Fprintf(buffer, "types.StructField(%s, ", strconv.Quote(fields[i].Name))
+		writeGoType(buffer, fields[i].T)
+		buffer.WriteByte(')')
+	}
+}
+
+// writeGoType writes go expression which makes type t with constructors of package types
+//
+//nolint:gocyclo
+func writeGoType(buffer *bytes.Buffer, t Type) {
+	switch tt := t.(type) {
+	case PrimitiveType:
+		name, has := goTypeNames[tt]
+		if !has {
+			writeGoUnsupported(buffer, tt.Yql())
+			return
+		}
+		buffer.WriteString("types.")
+		buffer.WriteString(name)
+	case *DecimalType:
+		fmt.Fprintf(buffer, "types.DecimalType(%d, %d)", tt.Precision, tt.Scale)
+	case optionalType:
+		buffer.WriteString("types.Optional(")
+		writeGoType(buffer, tt.innerType)
+		buffer.WriteByte(')')
+	case *listType:
+		buffer.WriteString("types.List(")
+		writeGoType(buffer, tt.itemType)
+		buffer.WriteByte(')')
+	case *TupleType:
+		buffer.WriteString("types.Tuple(")
+		writeGoTypes(buffer, tt.items)
+		buffer.WriteByte(')')
+	case *StructType:
+		buffer.WriteString("types.Struct(")
+		writeGoStructFields(buffer, tt.fields)
+		buffer.WriteByte(')')
+	case *dictType:
+		buffer.WriteString("types.Dict(")
+		writeGoType(buffer, tt.keyType)
+		buffer.WriteString(", ")
+		writeGoType(buffer, tt.valueType)
+		buffer.WriteByte(')')
+	case *variantStructType:
+		buffer.WriteString("types.VariantStruct(")
+		writeGoStructFields(buffer, tt.fields)
+		buffer.WriteByte(')')
+	case *variantTupleType:
+		buffer.WriteString("types.VariantTuple(")
+		writeGoTypes(buffer, tt.items)
+		buffer.WriteByte(')')
+	case *taggedType:
+		fmt.Fprintf(buffer, "types.Tagged(%s, ", strconv.Quote(tt.tag))
+		writeGoType(buffer, tt.innerType)
+		buffer.WriteByte(')')
+	case *pgType:
+		fmt.Fprintf(buffer, "types.PgType(%d)", tt.oid)
+	case voidType:
+		buffer.WriteString("types.Void()")
+	case emptyListType:
+		buffer.WriteString("types.EmptyList()")
+	case emptyDictType:
+		buffer.WriteString("types.EmptyDict()")
+	default:
+		writeGoUnsupported(buffer, t.Yql())
+	}
+}
@@ -0,0 +1,188 @@
+package value
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatValue(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		v     Value
+		plain string
+		typed string
+		gos   string
+	}{
+		{
+			name:  "Int64",
+			v:     Int64Value(-1),
+			plain: "-1l",
+			typed: "-1l : Int64",
+			gos:   "types.Int64Value(-1)",
+		},
+		{
+			name:  "Double",
+			v:     DoubleValue(math.Inf(-1)),
+			plain: `Double("-Inf")`,
+			typed: `Double("-Inf") : Double`,
+			gos:   "types.DoubleValue(math.Inf(-1))",
+		},
+		{
+			name:  "Float",
+			v:     FloatValue(1.5),
+			plain: `Float("1.5")`,
+			typed: `Float("1.5") : Float`,
+			gos:   "types.FloatValue(1.5)",
+		},
+		{
+			name:  "Interval",
+			v:     IntervalValue(1500000),
+			plain: `Interval("PT1.5S")`,
+			typed: `Interval("PT1.5S") : Interval`,
+			gos:   "types.IntervalValueFromMicroseconds(1500000)",
+		},
+		{
+			name:  "Text",
+			v:     TextValue(`a"b`),
+			plain: `"a\"b"u`,
+			typed: `"a\"b"u : Utf8`,
+			gos:   `types.TextValue("a\"b")`,
+		},
+		{
+			name:  "Bytes",
+			v:     BytesValue([]byte{0, 1}),
+			plain: "Bytes(0x0001, 2 bytes)",
+			typed: "Bytes(0x0001, 2 bytes) : String",
+			gos:   `types.BytesValue([]byte("\x00\x01"))`,
+		},
+		{
+			name:  "UUID",
+			v:     UUIDValueFromUUID(uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")),
+			plain: `Uuid("6ba7b810-9dad-11d1-80b4-00c04fd430c8")`,
+			typed: `Uuid("6ba7b810-9dad-11d1-80b4-00c04fd430c8") : Uuid`,
+			gos:   `types.UUIDValueFromUUID(uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))`,
+		},
+		{
+			name:  "Decimal",
+			v:     DecimalValueFromBigInt(big.NewInt(1), 22, 9),
+			plain: `Decimal("0.000000001",22,9)`,
+			typed: `Decimal("0.000000001",22,9) : Decimal(22,9)`,
+			gos: "types.DecimalValue(&types.Decimal{Bytes: [16]byte{" +
+				"0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01" +
+				"}, Precision: 22, Scale: 9})",
+		},
+		{
+			name:  "Pg",
+			v:     PgNullValue(25),
+			plain: "PgCast(NULL,pgtext)",
+			typed: "PgCast(NULL,pgtext) : pgtext",
+			gos:   "types.PgNullValue(25)",
+		},
+		{
+			name:  "Null",
+			v:     NullValue(List(TypeText)),
+			plain: "Nothing(Optional<List<Utf8>>)",
+			typed: "Nothing(Optional<List<Utf8>>) : Optional<List<Utf8>>",
+			gos:   "types.NullValue(types.List(types.TypeText))",
+		},
+		{
+			name:  "Optional",
+			v:     OptionalValue(TaggedValue("t", TextValue("x"))),
+			plain: `Just(AsTagged("x"u,"t"))`,
+			typed: `Just(AsTagged("x"u,"t")) : Optional<Tagged<Utf8,"t">>`,
+			gos:   `types.OptionalValue(types.TaggedValue("t", types.TextValue("x")))`,
+		},
+		{
+			name:  "EmptyList",
+			v:     ZeroValue(List(TypeInt32)),
+			plain: "[]",
+			typed: "[] : List<Int32>",
+			gos:   "types.ZeroValue(types.List(types.TypeInt32))",
+		},
+		{
+			name:  "Tuple",
+			v:     TupleValue(Int32Value(1), ListValue(TextValue("a"))),
+			plain: `(1,["a"u])`,
+			typed: `(1,["a"u]) : Tuple<Int32,List<Utf8>>`,
+			gos:   `types.TupleValue(types.Int32Value(1), types.ListValue(types.TextValue("a")))`,
+		},
+		{
+			name: "Struct",
+			v: StructValueOrdered(
+				StructValueField{Name: "b", V: Int32Value(1)},
+				StructValueField{Name: "a", V: VoidValue()},
+			),
+			plain: "<|`b`:1,`a`:Void()|>",
+			typed: "<|`b`:1,`a`:Void()|> : Struct<'b':Int32,'a':Void>",
+			gos:   `types.StructValueOrdered(types.StructFieldValue("b", types.Int32Value(1)), types.StructFieldValue("a", types.VoidValue()))`,
+		},
+		{
+			name:  "Dict",
+			v:     DictValue(DictValueField{K: TextValue("k"), V: Int32Value(1)}),
+			plain: `{"k"u:1}`,
+			typed: `{"k"u:1} : Dict<Utf8,Int32>`,
+			gos:   `types.DictValue(types.DictFieldValue(types.TextValue("k"), types.Int32Value(1)))`,
+		},
+		{
+			name:  "EmptyDict",
+			v:     DictValueOfTypes(TypeText, Optional(TypeInt32)),
+			plain: "{}",
+			typed: "{} : Dict<Utf8,Optional<Int32>>",
+			gos:   "types.DictValueOfTypes(types.TypeText, types.Optional(types.TypeInt32))",
+		},
+		{
+			name: "VariantStruct",
+			v: VariantValueStruct(Int32Value(1), "b", VariantStruct(
+				StructField{Name: "a", T: TypeText},
+				StructField{Name: "b", T: TypeInt32},
+			)),
+			plain: `Variant(1,"b",Variant<'a':Utf8,'b':Int32>)`,
+			typed: `Variant(1,"b",Variant<'a':Utf8,'b':Int32>) : Variant<'a':Utf8,'b':Int32>`,
+			gos: `types.VariantValueStruct(types.Int32Value(1), "b", ` +
+				`types.VariantStruct(types.StructField("a", types.TypeText), types.StructField("b", types.TypeInt32)))`,
+		},
+		{
+			name:  "VariantTuple",
+			v:     VariantValueTuple(TextValue("a"), 0, VariantTuple(TypeText, TypeInt32)),
+			plain: `Variant("a"u,"0",Variant<Utf8,Int32>)`,
+			typed: `Variant("a"u,"0",Variant<Utf8,Int32>) : Variant<Utf8,Int32>`,
+			gos:   `types.VariantValueTuple(types.TextValue("a"), 0, types.VariantTuple(types.TypeText, types.TypeInt32))`,
+		},
+		{
+			name:  "Secret",
+			v:     ListValue(SecretValue(TextValue("password"))),
+			plain: "[***]",
+			typed: "[***] : List<Utf8>",
+			gos:   "types.ListValue(***)",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.plain, fmt.Sprintf("%v", tt.v))
+			require.Equal(t, tt.plain, fmt.Sprintf("%s", tt.v))
+			require.Equal(t, fmt.Sprintf("%q", tt.plain), fmt.Sprintf("%q", tt.v))
+			require.Equal(t, tt.typed, fmt.Sprintf("%+v", tt.v))
+			require.Equal(t, tt.gos, fmt.Sprintf("%#v", tt.v))
+		})
+	}
+	t.Run("UnsupportedVerb", func(t *testing.T) {
+		require.Equal(t, "%!d(1)", fmt.Sprintf("%d", Int32Value(1)))
+	})
+	t.Run("NoPublicConstructor", func(t *testing.T) {
+		require.Equal(t, "nil /* {} : Set<Int32> */", fmt.Sprintf("%#v", &setValue{t: Set(TypeInt32)}))
+	})
+	t.Run("SecretCastError", func(t *testing.T) {
+		var dst int
+		err := SecretValue(TextValue("password")).castTo(&dst)
+		require.Error(t, err)
+		require.NotContains(t, fmt.Sprintf("%+v", err), "password")
+	})
+	t.Run("CastError", func(t *testing.T) {
+		var dst bool
+		require.ErrorContains(t, DoubleValue(1).castTo(&dst), `cannot cast 'Double("1")' (type 'Double') to '*bool' destination`)
+	})
+}
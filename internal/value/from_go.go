@@ -0,0 +1,553 @@
+package value
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+var (
+	errFromGoUnsupportedType = errors.New("unsupported go type")
+	errFromGoUnknownType     = errors.New("cannot derive YDB type from go type")
+	errFromGoStringType      = errors.New("unsupported YDB type of go strings")
+	errFromGoTimeType        = errors.New("unsupported YDB type of go times")
+	errFromGoDurationType    = errors.New("unsupported YDB type of go durations")
+	errFromGoTagType         = errors.New("unsupported YDB type in struct tag")
+	errFromGoPairType        = errors.New("pairs of map have different types")
+	errFromGoDecimalType     = errors.New("unknown precision and scale of go decimals (use tag option decimal(p,s))")
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	valueType    = reflect.TypeOf((*Value)(nil)).Elem()
+	bigIntType   = reflect.TypeOf(big.Int{})
+	// goDecimalType is a go type of decimals which types.Decimal is converted to
+	// (types.Decimal is defined in package types which imports package value)
+	goDecimalType = reflect.TypeOf(goDecimal{})
+)
+
+type goDecimal struct {
+	Bytes     [16]byte
+	Precision uint32
+	Scale     uint32
+}
+
+// fromGoStringTypes are YDB types which go strings can be converted to
+var fromGoStringTypes = map[PrimitiveType]func(s string) Value{
+	TypeText:         func(s string) Value { return TextValue(s) },
+	TypeBytes:        func(s string) Value { return BytesValue([]byte(s)) },
+	TypeJSON:         func(s string) Value { return JSONValue(s) },
+	TypeJSONDocument: func(s string) Value { return JSONDocumentValue(s) },
+	TypeYSON:         func(s string) Value { return YSONValue([]byte(s)) },
+	TypeDyNumber:     func(s string) Value { return DyNumberValue(s) },
+}
+
+// fromGoTimeTypes are YDB types which go times can be converted to
+var fromGoTimeTypes = map[PrimitiveType]func(t time.Time) Value{
+	TypeDate:        func(t time.Time) Value { return DateValueFromTime(t) },
+	TypeDatetime:    func(t time.Time) Value { return DatetimeValueFromTime(t) },
+	TypeTimestamp:   func(t time.Time) Value { return TimestampValueFromTime(t) },
+	TypeDate32:      func(t time.Time) Value { return Date32ValueFromTime(t) },
+	TypeDatetime64:  func(t time.Time) Value { return Datetime64ValueFromTime(t) },
+	TypeTimestamp64: func(t time.Time) Value { return Timestamp64ValueFromTime(t) },
+	TypeTzDate:      func(t time.Time) Value { return TzDateValueFromTime(t) },
+	TypeTzDatetime:  func(t time.Time) Value { return TzDatetimeValueFromTime(t) },
+	TypeTzTimestamp: func(t time.Time) Value { return TzTimestampValueFromTime(t) },
+}
+
+// fromGoDurationTypes are YDB types which go durations can be converted to
+var fromGoDurationTypes = map[PrimitiveType]func(d time.Duration) Value{
+	TypeInterval:   func(d time.Duration) Value { return IntervalValueFromDuration(d) },
+	TypeInterval64: func(d time.Duration) Value { return Interval64ValueFromDuration(d) },
+}
+
+type fromGoOptions struct {
+	stringType   PrimitiveType
+	timeType     PrimitiveType
+	durationType PrimitiveType
+	// decimalType is a type of go decimals from tag option decimal(p,s)
+	decimalType *DecimalType
+}
+
+// FromGoOption is an option of FromGo
+type FromGoOption func(o *fromGoOptions)
+
+// WithStringAs defines YDB type of go strings (Text by default).
+// t must be one of Text, Bytes, JSON, JSONDocument, YSON or DyNumber
+func WithStringAs(t Type) FromGoOption {
+	return func(o *fromGoOptions) {
+		o.stringType, _ = t.(PrimitiveType)
+	}
+}
+
+// WithTimeAs defines YDB type of go times (Timestamp by default).
+// t must be one of date, datetime or timestamp types (including Tz and 64-bit types)
+func WithTimeAs(t Type) FromGoOption {
+	return func(o *fromGoOptions) {
+		o.timeType, _ = t.(PrimitiveType)
+	}
+}
+
+// WithDurationAs defines YDB type of go durations (Interval by default).
+// t must be one of Interval or Interval64
+func WithDurationAs(t Type) FromGoOption {
+	return func(o *fromGoOptions) {
+		o.durationType, _ = t.(PrimitiveType)
+	}
+}
+
+func newFromGoOptions(opts ...FromGoOption) (*fromGoOptions, error) {
+	o := &fromGoOptions{
+		stringType:   TypeText,
+		timeType:     TypeTimestamp,
+		durationType: TypeInterval,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	if _, has := fromGoStringTypes[o.stringType]; !has {
+		return nil, xerrors.WithStackTrace(errFromGoStringType)
+	}
+	if _, has := fromGoTimeTypes[o.timeType]; !has {
+		return nil, xerrors.WithStackTrace(errFromGoTimeType)
+	}
+	if _, has := fromGoDurationTypes[o.durationType]; !has {
+		return nil, xerrors.WithStackTrace(errFromGoDurationType)
+	}
+	return o, nil
+}
+
+// FromGo converts go value v to YDB value.
+//
+// Mapping of go types to YDB types is documented in types.ValueFromGo
+func FromGo(v interface{}, opts ...FromGoOption) (Value, error) {
+	o, err := newFromGoOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return o.value(reflect.ValueOf(v))
+}
+
+// TypeFromGoType returns YDB type of values of go type t which FromGo makes with the same options.
+// Errors of TypeFromGoType name the offending go type and path to it (such as `field Orders[].Price`)
+func TypeFromGoType(t reflect.Type, opts ...FromGoOption) (Type, error) {
+	if t == nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: nil", errFromGoUnknownType))
+	}
+	o, err := newFromGoOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return o.typeOf(t, "")
+}
+
+//nolint:gocyclo
+func (o *fromGoOptions) value(rv reflect.Value) (Value, error) {
+	if !rv.IsValid() {
+		return VoidValue(), nil
+	}
+	if rv.Type().Implements(valueType) {
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: nil %s", errFromGoUnknownType, rv.Type()))
+		}
+		return rv.Interface().(Value), nil //nolint:forcetypeassert
+	}
+	switch rv.Type() {
+	case timeType:
+		return fromGoTimeTypes[o.timeType](rv.Interface().(time.Time)), nil //nolint:forcetypeassert
+	case durationType:
+		return fromGoDurationTypes[o.durationType](time.Duration(rv.Int())), nil
+	case bigIntType:
+		return o.bigIntValue(rv)
+	}
+	if rv.Type().ConvertibleTo(goDecimalType) {
+		return o.decimalValue(rv)
+	}
+	switch rv.Kind() {
+	case reflect.Bool:
+		return BoolValue(rv.Bool()), nil
+	case reflect.Int8:
+		return Int8Value(int8(rv.Int())), nil
+	case reflect.Int16:
+		return Int16Value(int16(rv.Int())), nil
+	case reflect.Int32:
+		return Int32Value(int32(rv.Int())), nil
+	case reflect.Int64, reflect.Int:
+		return Int64Value(rv.Int()), nil
+	case reflect.Uint8:
+		return Uint8Value(uint8(rv.Uint())), nil
+	case reflect.Uint16:
+		return Uint16Value(uint16(rv.Uint())), nil
+	case reflect.Uint32:
+		return Uint32Value(uint32(rv.Uint())), nil
+	case reflect.Uint64, reflect.Uint:
+		return Uint64Value(rv.Uint()), nil
+	case reflect.Float32:
+		return FloatValue(float32(rv.Float())), nil
+	case reflect.Float64:
+		return DoubleValue(rv.Float()), nil
+	case reflect.String:
+		return fromGoStringTypes[o.stringType](rv.String()), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return BytesValue(append([]byte(nil), rv.Bytes()...)), nil
+		}
+		return o.listValue(rv)
+	case reflect.Array:
+		if rv.Len() == 16 && rv.Type().Elem().Kind() == reflect.Uint8 {
+			var uuid [16]byte
+			reflect.Copy(reflect.ValueOf(&uuid).Elem(), rv)
+			return UUIDValue(uuid), nil
+		}
+		return o.listValue(rv)
+	case reflect.Map:
+		return o.dictValue(rv)
+	case reflect.Ptr:
+		if rv.IsNil() {
+			t, err := o.typeOf(rv.Type().Elem(), "")
+			if err != nil {
+				return nil, xerrors.WithStackTrace(err)
+			}
+			return NullValue(t), nil
+		}
+		v, err := o.value(rv.Elem())
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
+		}
+		return OptionalValue(v), nil
+	case reflect.Interface:
+		return o.value(rv.Elem())
+	case reflect.Struct:
+		return o.structValue(rv)
+	default:
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s", errFromGoUnsupportedType, rv.Type()))
+	}
+}
+
+// bigIntValue makes decimal value v * 10^(-scale) from big.Int v with precision and scale from tag
+func (o *fromGoOptions) bigIntValue(rv reflect.Value) (Value, error) {
+	if o.decimalType == nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s", errFromGoDecimalType, rv.Type()))
+	}
+	var v *big.Int
+	if rv.CanAddr() {
+		v = rv.Addr().Interface().(*big.Int) //nolint:forcetypeassert
+	} else {
+		b := rv.Interface().(big.Int) //nolint:forcetypeassert
+		v = &b
+	}
+	d, err := DecimalValueFromBigIntE(v, o.decimalType.Precision, o.decimalType.Scale)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return d, nil
+}
+
+// decimalValue makes decimal value from types.Decimal. Zero decimal takes precision and scale
+// from tag, other decimals must have the same precision and scale as tag (if any)
+func (o *fromGoOptions) decimalValue(rv reflect.Value) (Value, error) {
+	d := rv.Convert(goDecimalType).Interface().(goDecimal) //nolint:forcetypeassert
+	if t := o.decimalType; t != nil {
+		switch {
+		case d == goDecimal{}:
+			d.Precision, d.Scale = t.Precision, t.Scale
+		case d.Precision != t.Precision || d.Scale != t.Scale:
+			return nil, xerrors.WithStackTrace(fmt.Errorf("decimal of type Decimal(%d,%d) in field of type %s",
+				d.Precision, d.Scale, t.Yql(),
+			))
+		}
+	}
+	v, err := DecimalValueE(d.Bytes, d.Precision, d.Scale)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
+func (o *fromGoOptions) listValue(rv reflect.Value) (Value, error) {
+	if rv.Len() == 0 {
+		t, err := o.typeOf(rv.Type(), "")
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
+		}
+		return ZeroValue(t), nil
+	}
+	items := make([]Value, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		item, err := o.value(rv.Index(i))
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
+		}
+		items = append(items, item)
+	}
+	v, err := ListValueE(items...)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
+func (o *fromGoOptions) dictValue(rv reflect.Value) (Value, error) {
+	if rv.Len() == 0 {
+		t, err := o.typeOf(rv.Type(), "")
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
+		}
+		return ZeroValue(t), nil
+	}
+	pairs := make([]DictValueField, 0, rv.Len())
+	for it := rv.MapRange(); it.Next(); {
+		k, err := o.value(it.Key())
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
+		}
+		v, err := o.value(it.Value())
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
+		}
+		if len(pairs) > 0 && (!TypesEqual(k.Type(), pairs[0].K.Type()) || !TypesEqual(v.Type(), pairs[0].V.Type())) {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: (%s,%s) and (%s,%s)", errFromGoPairType,
+				pairs[0].K.Type().Yql(), pairs[0].V.Type().Yql(), k.Type().Yql(), v.Type().Yql(),
+			))
+		}
+		pairs = append(pairs, DictValueField{K: k, V: v})
+	}
+	return DictValue(pairs...), nil
+}
+
+func (o *fromGoOptions) structValue(rv reflect.Value) (Value, error) {
+	fields := GoStructFields(rv.Type())
+	values := make([]StructValueField, 0, len(fields))
+	for _, f := range fields {
+		fo, err := o.withTag(f)
+		if err != nil {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("field '%s': %w", f.Name, err))
+		}
+		v, err := fo.value(rv.Field(f.Index))
+		if err != nil {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("field '%s': %w", f.Name, err))
+		}
+		if f.Secret {
+			v = SecretValue(v)
+		}
+		values = append(values, StructValueField{Name: f.Name, V: v})
+	}
+	return StructValue(values...), nil
+}
+
+// typeOf returns YDB type of values of go type t (required for empty containers and nil pointers).
+// path is a path to t from root type for errors (such as `Orders[].Price`)
+//
+//nolint:gocyclo,funlen
+func (o *fromGoOptions) typeOf(t reflect.Type, path string) (Type, error) {
+	switch t {
+	case timeType:
+		return o.timeType, nil
+	case durationType:
+		return o.durationType, nil
+	}
+	if t == bigIntType || t.ConvertibleTo(goDecimalType) {
+		if o.decimalType == nil {
+			return nil, xerrors.WithStackTrace(fromGoTypeError(errFromGoDecimalType, t, path))
+		}
+		return o.decimalType, nil
+	}
+	if t.Implements(valueType) {
+		// types of values are known only for values
+		return nil, xerrors.WithStackTrace(fromGoTypeError(errFromGoUnknownType, t, path))
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return TypeBool, nil
+	case reflect.Int8:
+		return TypeInt8, nil
+	case reflect.Int16:
+		return TypeInt16, nil
+	case reflect.Int32:
+		return TypeInt32, nil
+	case reflect.Int64, reflect.Int:
+		return TypeInt64, nil
+	case reflect.Uint8:
+		return TypeUint8, nil
+	case reflect.Uint16:
+		return TypeUint16, nil
+	case reflect.Uint32:
+		return TypeUint32, nil
+	case reflect.Uint64, reflect.Uint:
+		return TypeUint64, nil
+	case reflect.Float32:
+		return TypeFloat, nil
+	case reflect.Float64:
+		return TypeDouble, nil
+	case reflect.String:
+		return o.stringType, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if t.Kind() == reflect.Slice {
+				return TypeBytes, nil
+			}
+			if t.Len() == 16 {
+				return TypeUUID, nil
+			}
+		}
+		itemType, err := o.typeOf(t.Elem(), path+"[]")
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
+		}
+		return List(itemType), nil
+	case reflect.Map:
+		keyType, err := o.typeOf(t.Key(), path+"[key]")
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
+		}
+		valueType, err := o.typeOf(t.Elem(), path+"[]")
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
+		}
+		return Dict(keyType, valueType), nil
+	case reflect.Ptr:
+		innerType, err := o.typeOf(t.Elem(), path)
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
+		}
+		return Optional(innerType), nil
+	case reflect.Struct:
+		fields := GoStructFields(t)
+		structFields := make([]StructField, 0, len(fields))
+		for _, f := range fields {
+			fieldPath := t.Field(f.Index).Name
+			if path != "" {
+				fieldPath = path + "." + fieldPath
+			}
+			fo, err := o.withTag(f)
+			if err != nil {
+				return nil, xerrors.WithStackTrace(fmt.Errorf("%w (field %s)", err, fieldPath))
+			}
+			fieldType, err := fo.typeOf(t.Field(f.Index).Type, fieldPath)
+			if err != nil {
+				return nil, xerrors.WithStackTrace(err)
+			}
+			structFields = append(structFields, StructField{Name: f.Name, T: fieldType})
+		}
+		return Struct(structFields...), nil
+	case reflect.Interface:
+		// types of interfaces are known only for their values
+		return nil, xerrors.WithStackTrace(fromGoTypeError(errFromGoUnknownType, t, path))
+	default:
+		return nil, xerrors.WithStackTrace(fromGoTypeError(errFromGoUnsupportedType, t, path))
+	}
+}
+
+func fromGoTypeError(err error, t reflect.Type, path string) error {
+	if path == "" {
+		return fmt.Errorf("%w: %s", err, t)
+	}
+	return fmt.Errorf("%w: %s (field %s)", err, t, path)
+}
+
+// withTag returns options for values of field f with YDB type from tag `ydb:"name,type=Json"`
+// which overrides type of strings, times, durations or decimals of field (including items of containers)
+func (o *fromGoOptions) withTag(f GoStructField) (*fromGoOptions, error) {
+	if f.TypeName == "" {
+		return o, nil
+	}
+	t, err := ParseType(f.TypeName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errFromGoTagType, err)
+	}
+	primitiveType, _ := t.(PrimitiveType)
+	fo := *o
+	if _, has := fromGoStringTypes[primitiveType]; has {
+		fo.stringType = primitiveType
+	} else if _, has = fromGoTimeTypes[primitiveType]; has {
+		fo.timeType = primitiveType
+	} else if _, has = fromGoDurationTypes[primitiveType]; has {
+		fo.durationType = primitiveType
+	} else if decimalType, ok := t.(*DecimalType); ok {
+		fo.decimalType = decimalType
+	} else {
+		return nil, fmt.Errorf("%w: %s", errFromGoTagType, t.Yql())
+	}
+	return &fo, nil
+}
+
+// GoStructField is an exported field of go struct with options from tag `ydb`
+type GoStructField struct {
+	// Name is YDB name of field
+	Name string
+	// Index is index of field in go struct
+	Index int
+	// TypeName is YDB type from tag option `type=` (or `decimal(p,s)` for Decimal(p,s))
+	TypeName string
+	// Secret is set by tag option `secret` (values of field are wrapped into SecretValue)
+	Secret bool
+}
+
+// GoStructFields returns exported fields of go struct type t sorted by YDB names (as fields of StructValue).
+// Name of field is defined by tag `ydb:"name"` or equals to name of go field. Fields with tag `ydb:"-"` are skipped.
+// Tag option `type=` defines YDB type of strings, times or durations of field (such as `ydb:"created,type=Date"`),
+// tag option `decimal(p,s)` defines precision and scale of decimals of field (such as `ydb:"price,decimal(22,9)"`),
+// tag option `secret` hides values of field in logs and errors (such as `ydb:"password,secret"`)
+func GoStructFields(t reflect.Type) []GoStructField {
+	fields := make([]GoStructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			// unexported field
+			continue
+		}
+		field := GoStructField{Name: f.Name, Index: i}
+		if tag, has := f.Tag.Lookup("ydb"); has {
+			options := splitTagOptions(tag)
+			if options[0] == "-" {
+				continue
+			}
+			if options[0] != "" {
+				field.Name = options[0]
+			}
+			for _, option := range options[1:] {
+				if typeName := strings.TrimPrefix(option, "type="); typeName != option {
+					field.TypeName = typeName
+				} else if params := strings.TrimPrefix(option, "decimal("); params != option {
+					field.TypeName = "Decimal(" + params
+				} else if option == "secret" {
+					field.Secret = true
+				}
+			}
+		}
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+	return fields
+}
+
+// splitTagOptions splits tag `ydb` by commas which are not in brackets of types
+// (such as `price,decimal(22,9)` or `tags,type=Dict<Utf8,Yson>`)
+func splitTagOptions(tag string) (options []string) {
+	depth, start := 0, 0
+	for i, r := range tag {
+		switch r {
+		case '(', '<':
+			depth++
+		case ')', '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				options = append(options, tag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(options, tag[start:])
+}
@@ -0,0 +1,392 @@
+package value
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
+)
+
+type fromGoUser struct {
+	ID       uint64 `ydb:"id"`
+	Name     string
+	Email    *string  `ydb:"email,omitempty"`
+	Tags     []string `ydb:"tags"`
+	Password string   `ydb:"-"`
+	internal int
+}
+
+type fromGoNamedString string
+
+type fromGoOrder struct {
+	ID      uint64            `ydb:"id"`
+	Payload string            `ydb:"payload,type=JsonDocument"`
+	Day     *time.Time        `ydb:"day,omitempty,type=Date"`
+	Tags    map[string]string `ydb:"tags,type=Yson"`
+	TTL     time.Duration     `ydb:"ttl,type=Interval64"`
+	Items   []fromGoItem      `ydb:"items"`
+}
+
+type fromGoItem struct {
+	Price float64
+	Note  *string `ydb:"note,type=Yson"`
+}
+
+func TestFromGo(t *testing.T) {
+	var (
+		i     = 42
+		email = "user@example.com"
+		ts    = time.Date(2023, 10, 18, 12, 30, 15, 123456000, time.UTC)
+		id    = uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	)
+	for _, tt := range []struct {
+		name     string
+		src      interface{}
+		opts     []FromGoOption
+		typeYql  string
+		valueYql string
+		// castBack reports whether value of YDB casts back to value of type of src
+		castBack bool
+	}{
+		{name: "Nil", src: nil, typeYql: "Void", valueYql: "Void()"},
+		{name: "Bool", src: true, typeYql: "Bool", valueYql: "true", castBack: true},
+		{name: "Int8", src: int8(-8), typeYql: "Int8", valueYql: "-8t", castBack: true},
+		{name: "Int16", src: int16(-16), typeYql: "Int16", valueYql: "-16s", castBack: true},
+		{name: "Int32", src: int32(-32), typeYql: "Int32", valueYql: "-32", castBack: true},
+		{name: "Int64", src: int64(-64), typeYql: "Int64", valueYql: "-64l", castBack: true},
+		{name: "Int", src: -1, typeYql: "Int64", valueYql: "-1l", castBack: true},
+		{name: "Uint8", src: uint8(8), typeYql: "Uint8", valueYql: "8ut", castBack: true},
+		{name: "Uint16", src: uint16(16), typeYql: "Uint16", valueYql: "16us", castBack: true},
+		{name: "Uint32", src: uint32(32), typeYql: "Uint32", valueYql: "32u", castBack: true},
+		{name: "Uint64", src: uint64(64), typeYql: "Uint64", valueYql: "64ul", castBack: true},
+		{name: "Uint", src: uint(1), typeYql: "Uint64", valueYql: "1ul", castBack: true},
+		{name: "Float", src: float32(1.5), typeYql: "Float", valueYql: `Float("1.5")`, castBack: true},
+		{name: "Double", src: 2.5, typeYql: "Double", valueYql: `Double("2.5")`, castBack: true},
+		{name: "String", src: "test", typeYql: "Utf8", valueYql: `"test"u`, castBack: true},
+		{name: "NamedString", src: fromGoNamedString("test"), typeYql: "Utf8", valueYql: `"test"u`},
+		{
+			name: "StringAsBytes", src: "test", opts: []FromGoOption{WithStringAs(TypeBytes)},
+			typeYql: "String", valueYql: `"test"`, castBack: true,
+		},
+		{
+			name: "StringAsJSON", src: `{"a":1}`, opts: []FromGoOption{WithStringAs(TypeJSON)},
+			typeYql: "Json", valueYql: `Json("{\"a\":1}")`, castBack: true,
+		},
+		{
+			name: "StringAsJSONDocument", src: `{"a":1}`, opts: []FromGoOption{WithStringAs(TypeJSONDocument)},
+			typeYql: "JsonDocument", valueYql: `JsonDocument("{\"a\":1}")`, castBack: true,
+		},
+		{
+			name: "StringsAsYSON", src: []string{"[1]"}, opts: []FromGoOption{WithStringAs(TypeYSON)},
+			typeYql: "List<Yson>", valueYql: `[Yson("[1]")]`,
+		},
+		{name: "Bytes", src: []byte("test"), typeYql: "String", valueYql: `"test"`, castBack: true},
+		{name: "Time", src: ts, typeYql: "Timestamp", valueYql: `Timestamp("2023-10-18T12:30:15.123456Z")`, castBack: true},
+		{name: "Duration", src: time.Second, typeYql: "Interval", valueYql: `Interval("PT1S")`, castBack: true},
+		{
+			name: "TimeAsDate", src: ts, opts: []FromGoOption{WithTimeAs(TypeDate)},
+			typeYql: "Date", valueYql: `Date("2023-10-18")`,
+		},
+		{
+			name: "DurationAsInterval64", src: time.Second, opts: []FromGoOption{WithDurationAs(TypeInterval64)},
+			typeYql: "Interval64", valueYql: `Interval64("PT1S")`,
+		},
+		{
+			name: "StructWithTagTypes", src: struct {
+				Payload string     `ydb:"payload,type=Json"`
+				Day     *time.Time `ydb:",type=Date"`
+			}{Payload: "{}", Day: &ts},
+			typeYql:  "Struct<'Day':Optional<Date>,'payload':Json>",
+			valueYql: "<|`Day`:Just(Date(\"2023-10-18\")),`payload`:Json(\"{}\")|>",
+		},
+		{name: "UUID", src: id, typeYql: "Uuid", valueYql: `Uuid("6ba7b810-9dad-11d1-80b4-00c04fd430c8")`},
+		{name: "Pointer", src: &i, typeYql: "Optional<Int64>", valueYql: "Just(42l)", castBack: true},
+		{name: "NilPointer", src: (*int)(nil), typeYql: "Optional<Int64>", valueYql: "Nothing(Optional<Int64>)"},
+		{
+			name: "NilPointerOfPointer", src: (**string)(nil),
+			typeYql: "Optional<Optional<Utf8>>", valueYql: "Nothing(Optional<Optional<Utf8>>)",
+		},
+		{name: "Slice", src: []int32{1, 2}, typeYql: "List<Int32>", valueYql: "[1,2]"},
+		{name: "NilSlice", src: []int32(nil), typeYql: "List<Int32>", valueYql: "[]"},
+		{name: "Array", src: [2]string{"a", "b"}, typeYql: "List<Utf8>", valueYql: `["a"u,"b"u]`},
+		{
+			name: "SliceOfPointers", src: []*int{&i, nil},
+			typeYql: "List<Optional<Int64>>", valueYql: "[Just(42l),Nothing(Optional<Int64>)]",
+		},
+		{
+			name: "SliceOfInterfaces", src: []interface{}{&i, 1},
+			typeYql: "List<Optional<Int64>>", valueYql: "[Just(42l),Just(1l)]",
+		},
+		{
+			name: "Map", src: map[string]uint64{"b": 2, "a": 1},
+			typeYql: "Dict<Utf8,Uint64>", valueYql: `{"a"u:1ul,"b"u:2ul}`,
+		},
+		{name: "EmptyMap", src: map[int32][]byte{}, typeYql: "Dict<Int32,String>", valueYql: "{}"},
+		{
+			name: "Struct", src: fromGoUser{ID: 1, Name: "user", Email: &email, Tags: []string{"a"}, Password: "qwerty"},
+			typeYql:  "Struct<'Name':Utf8,'email':Optional<Utf8>,'id':Uint64,'tags':List<Utf8>>",
+			valueYql: "<|`Name`:\"user\"u,`email`:Just(\"user@example.com\"u),`id`:1ul,`tags`:[\"a\"u]|>",
+		},
+		{
+			name: "EmptySliceOfStructs", src: []fromGoUser{},
+			typeYql:  "List<Struct<'Name':Utf8,'email':Optional<Utf8>,'id':Uint64,'tags':List<Utf8>>>",
+			valueYql: "[]",
+		},
+		{
+			name: "PointerToStruct", src: &struct{ A int8 }{A: 1},
+			typeYql: "Optional<Struct<'A':Int8>>", valueYql: "Just(<|`A`:1t|>)",
+		},
+		{name: "Value", src: DateValue(1), typeYql: "Date", valueYql: `Date("1970-01-02")`},
+		{
+			name: "ValuesInStruct", src: struct{ V Value }{V: Int64Value(1)},
+			typeYql: "Struct<'V':Int64>", valueYql: "<|`V`:1l|>",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := FromGo(tt.src, tt.opts...)
+			require.NoError(t, err)
+			require.Equal(t, tt.typeYql, v.Type().Yql())
+			require.Equal(t, tt.valueYql, v.Yql())
+
+			a := allocator.New()
+			defer a.Free()
+			tv := ToYDB(v, a)
+			fromYDB, err := FromYDBWithError(tv.GetType(), tv.GetValue())
+			require.NoError(t, err)
+			require.True(t, TypesEqual(v.Type(), fromYDB.Type()))
+			require.Equal(t, v.Yql(), fromYDB.Yql())
+
+			if tt.castBack {
+				dst := reflect.New(reflect.TypeOf(tt.src))
+				require.NoError(t, Cast(fromYDB, dst.Interface()))
+				if ts, ok := tt.src.(time.Time); ok {
+					// location of time is not kept
+					require.True(t, ts.Equal(dst.Elem().Interface().(time.Time)))
+				} else {
+					require.Equal(t, tt.src, dst.Elem().Interface())
+				}
+			}
+		})
+	}
+}
+
+func TestFromGoSecretTag(t *testing.T) {
+	v, err := FromGo(struct {
+		Login    string  `ydb:"login"`
+		Password *string `ydb:"password,secret"`
+	}{Login: "user", Password: func(s string) *string { return &s }("qwerty")})
+	require.NoError(t, err)
+	require.Equal(t, "Struct<'login':Utf8,'password':Optional<Utf8>>", v.Type().Yql())
+	require.Equal(t, "<|`login`:\"user\"u,`password`:***|>", v.Yql())
+	require.NotContains(t, fmt.Sprintf("%+v", v), "qwerty")
+
+	// secret is sent to YDB as is
+	a := allocator.New()
+	defer a.Free()
+	tv := ToYDB(v, a)
+	fromYDB, err := FromYDBWithError(tv.GetType(), tv.GetValue())
+	require.NoError(t, err)
+	require.Equal(t, "<|`login`:\"user\"u,`password`:Just(\"qwerty\"u)|>", fromYDB.Yql())
+}
+
+// fromGoDecimal has the same fields as types.Decimal
+type fromGoDecimal struct {
+	Bytes     [16]byte
+	Precision uint32
+	Scale     uint32
+}
+
+type fromGoPrice struct {
+	Amount   fromGoDecimal  `ydb:"amount,decimal(22,9)"`
+	Discount *fromGoDecimal `ydb:"discount,decimal(22,9)"`
+	Total    *big.Int       `ydb:"total,decimal(35,2)"`
+}
+
+func TestFromGoDecimal(t *testing.T) {
+	amount := fromGoDecimal{
+		Bytes:     BigEndianUint128(0, 1500000000),
+		Precision: 22,
+		Scale:     9,
+	}
+	t.Run("Struct", func(t *testing.T) {
+		v, err := FromGo(fromGoPrice{Amount: amount, Total: big.NewInt(-12345)})
+		require.NoError(t, err)
+		require.Equal(t, "Struct<'amount':Decimal(22,9),'discount':Optional<Decimal(22,9)>,'total':Optional<Decimal(35,2)>>",
+			v.Type().Yql(),
+		)
+		require.Equal(t, "<|`amount`:Decimal(\"1.500000000\",22,9),`discount`:Nothing(Optional<Decimal(22,9)>),"+
+			"`total`:Just(Decimal(\"-123.45\",35,2))|>",
+			v.Yql(),
+		)
+		typ, err := TypeFromGoType(reflect.TypeOf(fromGoPrice{}))
+		require.NoError(t, err)
+		require.True(t, TypesEqual(typ, v.Type()), typ.Yql())
+	})
+	t.Run("ZeroTakesTagType", func(t *testing.T) {
+		v, err := FromGo(fromGoPrice{})
+		require.NoError(t, err)
+		require.Equal(t, "<|`amount`:Decimal(\"0.000000000\",22,9),`discount`:Nothing(Optional<Decimal(22,9)>),"+
+			"`total`:Nothing(Optional<Decimal(35,2)>)|>",
+			v.Yql(),
+		)
+	})
+	t.Run("WithoutTag", func(t *testing.T) {
+		v, err := FromGo(amount)
+		require.NoError(t, err)
+		require.Equal(t, `Decimal("1.500000000",22,9)`, v.Yql())
+		_, err = FromGo(big.NewInt(1))
+		require.ErrorIs(t, err, errFromGoDecimalType)
+		_, err = TypeFromGoType(reflect.TypeOf(amount))
+		require.ErrorIs(t, err, errFromGoDecimalType)
+	})
+	t.Run("TypeMismatch", func(t *testing.T) {
+		_, err := FromGo(fromGoPrice{Amount: fromGoDecimal{Bytes: amount.Bytes, Precision: 35, Scale: 0}})
+		require.Error(t, err)
+	})
+	t.Run("Overflow", func(t *testing.T) {
+		total, _ := new(big.Int).SetString("1"+strings.Repeat("0", 35), 10)
+		_, err := FromGo(fromGoPrice{Total: total})
+		require.ErrorIs(t, err, decimal.ErrOverflow)
+	})
+}
+
+func TestFromGoErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		src  interface{}
+		opts []FromGoOption
+		err  error
+	}{
+		{name: "Channel", src: make(chan int), err: errFromGoUnsupportedType},
+		{name: "Func", src: func() {}, err: errFromGoUnsupportedType},
+		{name: "Complex", src: complex(1, 2), err: errFromGoUnsupportedType},
+		{name: "StructWithChannel", src: struct{ C chan int }{}, err: errFromGoUnsupportedType},
+		{name: "EmptySliceOfInterfaces", src: []interface{}{}, err: errFromGoUnknownType},
+		{name: "NilPointerToInterface", src: (*interface{})(nil), err: errFromGoUnknownType},
+		{name: "EmptyMapOfValues", src: map[string]Value{}, err: errFromGoUnknownType},
+		{name: "NilValue", src: (*optionalValue)(nil), err: errFromGoUnknownType},
+		{name: "DifferentItems", src: []interface{}{1, "a"}, err: errListItemType},
+		{name: "DifferentPairs", src: map[string]interface{}{"a": 1, "b": "b"}, err: errFromGoPairType},
+		{name: "StringAsInt", src: "1", opts: []FromGoOption{WithStringAs(TypeInt64)}, err: errFromGoStringType},
+		{name: "StringAsOptional", src: "1", opts: []FromGoOption{WithStringAs(Optional(TypeText))}, err: errFromGoStringType},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromGo(tt.src, tt.opts...)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestTypeFromGoType(t *testing.T) {
+	for _, tt := range []struct {
+		src     interface{}
+		opts    []FromGoOption
+		typeYql string
+	}{
+		{src: true, typeYql: "Bool"},
+		{src: int8(0), typeYql: "Int8"},
+		{src: int16(0), typeYql: "Int16"},
+		{src: int32(0), typeYql: "Int32"},
+		{src: 0, typeYql: "Int64"},
+		{src: uint8(0), typeYql: "Uint8"},
+		{src: uint16(0), typeYql: "Uint16"},
+		{src: uint32(0), typeYql: "Uint32"},
+		{src: uint(0), typeYql: "Uint64"},
+		{src: float32(0), typeYql: "Float"},
+		{src: float64(0), typeYql: "Double"},
+		{src: "", typeYql: "Utf8"},
+		{src: "", opts: []FromGoOption{WithStringAs(TypeJSON)}, typeYql: "Json"},
+		{src: []byte(nil), typeYql: "String"},
+		{src: time.Time{}, typeYql: "Timestamp"},
+		{src: time.Time{}, opts: []FromGoOption{WithTimeAs(TypeDatetime64)}, typeYql: "Datetime64"},
+		{src: time.Duration(0), typeYql: "Interval"},
+		{src: uuid.UUID{}, typeYql: "Uuid"},
+		{src: (**int32)(nil), typeYql: "Optional<Optional<Int32>>"},
+		{src: [][]string(nil), typeYql: "List<List<Utf8>>"},
+		{src: map[string][]*time.Time(nil), typeYql: "Dict<Utf8,List<Optional<Timestamp>>>"},
+		{
+			src:     fromGoUser{},
+			typeYql: "Struct<'Name':Utf8,'email':Optional<Utf8>,'id':Uint64,'tags':List<Utf8>>",
+		},
+		{
+			src: struct {
+				Order fromGoOrder
+			}{},
+			typeYql: "Struct<'Order':Struct<'day':Optional<Date>,'id':Uint64,'items':List<Struct<" +
+				"'Price':Double,'note':Optional<Yson>>>,'payload':JsonDocument,'tags':Dict<Yson,Yson>,'ttl':Interval64>>",
+		},
+	} {
+		goType := reflect.TypeOf(tt.src)
+		t.Run(goType.String(), func(t *testing.T) {
+			typ, err := TypeFromGoType(goType, tt.opts...)
+			require.NoError(t, err)
+			require.Equal(t, tt.typeYql, typ.Yql())
+			// FromGo makes values of the same type
+			v, err := FromGo(tt.src, tt.opts...)
+			require.NoError(t, err)
+			require.True(t, TypesEqual(typ, v.Type()), v.Type().Yql())
+		})
+	}
+}
+
+func TestTypeFromGoTypeErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		src  reflect.Type
+		opts []FromGoOption
+		err  error
+		text string
+	}{
+		{name: "Nil", src: nil, err: errFromGoUnknownType},
+		{name: "Channel", src: reflect.TypeOf(make(chan int)), err: errFromGoUnsupportedType, text: "chan int"},
+		{name: "Interface", src: reflect.TypeOf([]interface{}{}), err: errFromGoUnknownType, text: "interface {}"},
+		{name: "Value", src: reflect.TypeOf(Int32Value(1)), err: errFromGoUnknownType, text: "value.int32Value"},
+		{
+			name: "NestedField", src: reflect.TypeOf(struct {
+				Orders []struct {
+					Price complex128
+				}
+			}{}), err: errFromGoUnsupportedType,
+			text: "complex128 (field Orders[].Price)",
+		},
+		{
+			name: "NestedPointerField", src: reflect.TypeOf(map[string]*struct {
+				Extra interface{}
+			}{}), err: errFromGoUnknownType,
+			text: "interface {} (field [].Extra)",
+		},
+		{
+			name: "MapKey", src: reflect.TypeOf(map[complex64]int{}), err: errFromGoUnsupportedType,
+			text: "complex64 (field [key])",
+		},
+		{
+			name: "TagType", src: reflect.TypeOf(struct {
+				A string `ydb:"a,type=Int32"`
+			}{}), err: errFromGoTagType, text: "Int32 (field A)",
+		},
+		{
+			name: "MalformedTagType", src: reflect.TypeOf(struct {
+				A string `ydb:"a,type=Jsn"`
+			}{}), err: errFromGoTagType, text: "unknown type 'Jsn'",
+		},
+		{name: "TimeAsInt", src: reflect.TypeOf(time.Time{}), opts: []FromGoOption{WithTimeAs(TypeInt64)}, err: errFromGoTimeType},
+		{
+			name: "DurationAsTime", src: reflect.TypeOf(time.Second),
+			opts: []FromGoOption{WithDurationAs(TypeTimestamp)}, err: errFromGoDurationType,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := TypeFromGoType(tt.src, tt.opts...)
+			require.ErrorIs(t, err, tt.err)
+			require.Contains(t, err.Error(), tt.text)
+		})
+	}
+}
@@ -0,0 +1,738 @@
+package value
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+// maxJSONSafeInteger is a maximum magnitude of integers which are exactly representable
+// as JSON numbers by parsers with float64 numbers (such as JavaScript)
+const maxJSONSafeInteger = 1 << 53
+
+var (
+	errJSONType       = errors.New("JSON value doesn't match type")
+	errJSONTypeHeader = errors.New("type of JSON value differs from given type")
+)
+
+// jsonEnvelope is a self-describing JSON form of value
+type jsonEnvelope struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// MarshalJSON returns self-describing JSON form of value v: object with YQL type of value
+// and natural JSON representation of value (such as {"type":"List<Int32>","value":[1,2]}).
+//
+// Natural representation of values:
+//   - Bool, numbers of Int8..Int32, Uint8..Uint32, Float and Double are JSON literals
+//     (NaN and infinities of Float and Double are strings "NaN", "+Inf" and "-Inf");
+//   - Int64 and Uint64 are numbers if magnitude is not greater than 2^53, otherwise strings;
+//   - Decimal, DyNumber, Text, Json, JsonDocument and UUID are strings;
+//   - Bytes and Yson are base64 strings;
+//   - Date is a string 2006-01-02, Datetime and Timestamp are RFC 3339 strings in UTC,
+//     TzDate, TzDatetime and TzTimestamp are strings with timezone (2006-01-02,Europe/Berlin),
+//     Interval is a string of time.Duration (1h2m3.000004s);
+//   - Date32, Datetime64 and Timestamp64 are strings as Date, Datetime and Timestamp,
+//     Interval64 is a number of microseconds (string if magnitude is greater than 2^53);
+//   - NULL and Void are null; non-null value of Optional<Optional<T>> is an array with single item;
+//   - List, Set and Tuple are arrays, Struct is an object, Dict is an array of [key, value] pairs;
+//   - Variant over struct is an object with single field, Variant over tuple is [index, value].
+//
+// Secret values are represented as "***" and cannot be unmarshaled back
+func MarshalJSON(v Value) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(`{"type":`)
+	writeJSONString(&buffer, v.Type().Yql())
+	buffer.WriteString(`,"value":`)
+	if err := writeJSON(&buffer, v); err != nil {
+		return nil, err
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON parses self-describing JSON form of value (see MarshalJSON) into value of type t.
+// UnmarshalJSON returns error if type of JSON form differs from t or JSON value doesn't match t
+// (including values out of range of type)
+func UnmarshalJSON(t Type, data []byte) (Value, error) {
+	var envelope jsonEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	if envelope.Type != t.Yql() {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s instead of %s", errJSONTypeHeader, envelope.Type, t.Yql()))
+	}
+	if len(envelope.Value) == 0 {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: no value of %s", errJSONType, t.Yql()))
+	}
+	decoder := json.NewDecoder(bytes.NewReader(envelope.Value))
+	decoder.UseNumber()
+	var tree interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return valueFromJSON(t, tree)
+}
+
+func writeJSONString(buffer *bytes.Buffer, s string) {
+	b, _ := json.Marshal(s) // marshaling of string never fails
+	buffer.Write(b)
+}
+
+func writeJSONInt64(buffer *bytes.Buffer, n int64) {
+	if n > maxJSONSafeInteger || n < -maxJSONSafeInteger {
+		writeJSONString(buffer, strconv.FormatInt(n, 10))
+	} else {
+		buffer.WriteString(strconv.FormatInt(n, 10))
+	}
+}
+
+func writeJSONFloat(buffer *bytes.Buffer, f float64, bitSize int) {
+	switch {
+	case math.IsNaN(f):
+		buffer.WriteString(`"NaN"`)
+	case math.IsInf(f, 1):
+		buffer.WriteString(`"+Inf"`)
+	case math.IsInf(f, -1):
+		buffer.WriteString(`"-Inf"`)
+	default:
+		buffer.WriteString(strconv.FormatFloat(f, 'g', -1, bitSize))
+	}
+}
+
+func writeJSONItems(buffer *bytes.Buffer, items []Value) error {
+	buffer.WriteByte('[')
+	for i := range items {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		if err := writeJSON(buffer, items[i]); err != nil {
+			return err
+		}
+	}
+	buffer.WriteByte(']')
+	return nil
+}
+
+//nolint:gocyclo,funlen
+func writeJSON(buffer *bytes.Buffer, v Value) error {
+	switch vv := v.(type) {
+	case boolValue:
+		buffer.WriteString(strconv.FormatBool(bool(vv)))
+	case int8Value:
+		buffer.WriteString(strconv.FormatInt(int64(vv), 10))
+	case int16Value:
+		buffer.WriteString(strconv.FormatInt(int64(vv), 10))
+	case int32Value:
+		buffer.WriteString(strconv.FormatInt(int64(vv), 10))
+	case int64Value:
+		writeJSONInt64(buffer, int64(vv))
+	case uint8Value:
+		buffer.WriteString(strconv.FormatUint(uint64(vv), 10))
+	case uint16Value:
+		buffer.WriteString(strconv.FormatUint(uint64(vv), 10))
+	case uint32Value:
+		buffer.WriteString(strconv.FormatUint(uint64(vv), 10))
+	case uint64Value:
+		if vv > maxJSONSafeInteger {
+			writeJSONString(buffer, strconv.FormatUint(uint64(vv), 10))
+		} else {
+			buffer.WriteString(strconv.FormatUint(uint64(vv), 10))
+		}
+	case *floatValue:
+		writeJSONFloat(buffer, float64(vv.value), 32)
+	case *doubleValue:
+		writeJSONFloat(buffer, vv.value, 64)
+	case *decimalValue:
+		writeJSONString(buffer, decimal.Format(
+			decimal.FromBytes(vv.value[:], vv.innerType.Precision, vv.innerType.Scale),
+			vv.innerType.Precision, vv.innerType.Scale,
+		))
+	case dateValue:
+		writeJSONString(buffer, DateToTime(uint32(vv)).UTC().Format(LayoutDate))
+	case datetimeValue:
+		writeJSONString(buffer, DatetimeToTime(uint32(vv)).UTC().Format(time.RFC3339))
+	case timestampValue:
+		writeJSONString(buffer, TimestampToTime(uint64(vv)).UTC().Format(time.RFC3339Nano))
+	case intervalValue:
+		writeJSONString(buffer, IntervalToDuration(int64(vv)).String())
+	case date32Value:
+		writeJSONString(buffer, Date32ToTime(int32(vv)).UTC().Format(LayoutDate))
+	case datetime64Value:
+		writeJSONString(buffer, Datetime64ToTime(int64(vv)).UTC().Format(time.RFC3339))
+	case timestamp64Value:
+		writeJSONString(buffer, Timestamp64ToTime(int64(vv)).UTC().Format(time.RFC3339Nano))
+	case interval64Value:
+		writeJSONInt64(buffer, int64(vv))
+	case tzDateValue:
+		writeJSONString(buffer, string(vv))
+	case tzDatetimeValue:
+		writeJSONString(buffer, string(vv))
+	case tzTimestampValue:
+		writeJSONString(buffer, string(vv))
+	case textValue:
+		writeJSONString(buffer, string(vv))
+	case jsonValue:
+		writeJSONString(buffer, string(vv))
+	case jsonDocumentValue:
+		writeJSONString(buffer, string(vv))
+	case dyNumberValue:
+		writeJSONString(buffer, string(vv))
+	case bytesValue:
+		writeJSONString(buffer, base64.StdEncoding.EncodeToString(vv))
+	case ysonValue:
+		writeJSONString(buffer, base64.StdEncoding.EncodeToString(vv))
+	case *uuidValue:
+		writeJSONString(buffer, uuid.UUID(vv.value).String())
+	case voidValue:
+		buffer.WriteString("null")
+	case *taggedValue:
+		return writeJSON(buffer, vv.value)
+	case *pgValue:
+		if vv.null {
+			buffer.WriteString("null")
+		} else {
+			writeJSONString(buffer, vv.text)
+		}
+	case *optionalValue:
+		switch {
+		case vv.value == nil:
+			buffer.WriteString("null")
+		case IsOptional(vv.value.Type()):
+			// array distinguishes non-null value with inner NULL from NULL
+			buffer.WriteByte('[')
+			if err := writeJSON(buffer, vv.value); err != nil {
+				return err
+			}
+			buffer.WriteByte(']')
+		default:
+			return writeJSON(buffer, vv.value)
+		}
+	case *listValue:
+		return writeJSONItems(buffer, vv.items)
+	case *setValue:
+		return writeJSONItems(buffer, vv.items)
+	case *tupleValue:
+		return writeJSONItems(buffer, vv.items)
+	case *structValue:
+		buffer.WriteByte('{')
+		for i := range vv.fields {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			writeJSONString(buffer, vv.fields[i].Name)
+			buffer.WriteByte(':')
+			if err := writeJSON(buffer, vv.fields[i].V); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+	case *dictValue:
+		buffer.WriteByte('[')
+		for i := range vv.values {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			buffer.WriteByte('[')
+			if err := writeJSON(buffer, vv.values[i].K); err != nil {
+				return err
+			}
+			buffer.WriteByte(',')
+			if err := writeJSON(buffer, vv.values[i].V); err != nil {
+				return err
+			}
+			buffer.WriteByte(']')
+		}
+		buffer.WriteByte(']')
+	case *variantValue:
+		switch t := vv.innerType.(type) {
+		case *variantStructType:
+			buffer.WriteByte('{')
+			writeJSONString(buffer, t.fields[vv.idx].Name)
+			buffer.WriteByte(':')
+			if err := writeJSON(buffer, vv.value); err != nil {
+				return err
+			}
+			buffer.WriteByte('}')
+		default:
+			buffer.WriteByte('[')
+			buffer.WriteString(strconv.FormatUint(uint64(vv.idx), 10))
+			buffer.WriteByte(',')
+			if err := writeJSON(buffer, vv.value); err != nil {
+				return err
+			}
+			buffer.WriteByte(']')
+		}
+	case *secretValue:
+		writeJSONString(buffer, secretYql)
+	default:
+		return xerrors.WithStackTrace(fmt.Errorf("cannot marshal value of type %s to JSON", v.Type().Yql()))
+	}
+	return nil
+}
+
+func isDecimalSpecial(s string) bool {
+	s = strings.ToLower(strings.TrimLeft(s, "+-"))
+	return s == "inf" || s == "nan"
+}
+
+func jsonTypeError(t Type, v interface{}) error {
+	return xerrors.WithStackTrace(fmt.Errorf("%w: %s cannot be parsed from JSON %T", errJSONType, t.Yql(), v))
+}
+
+// jsonInteger parses integer from JSON number or string within range [lo, hi]
+func jsonInteger(t Type, v interface{}, lo, hi int64) (int64, error) {
+	var s string
+	switch vv := v.(type) {
+	case json.Number:
+		s = string(vv)
+	case string:
+		s = vv
+	default:
+		return 0, jsonTypeError(t, v)
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, xerrors.WithStackTrace(fmt.Errorf("%w: %s from '%s': %v", errJSONType, t.Yql(), s, err))
+	}
+	if n < lo || n > hi {
+		return 0, xerrors.WithStackTrace(fmt.Errorf("%w: %d is out of range of %s", errValueOutOfRange, n, t.Yql()))
+	}
+	return n, nil
+}
+
+// jsonUnsigned parses unsigned integer from JSON number or string not greater than hi
+func jsonUnsigned(t Type, v interface{}, hi uint64) (uint64, error) {
+	var s string
+	switch vv := v.(type) {
+	case json.Number:
+		s = string(vv)
+	case string:
+		s = vv
+	default:
+		return 0, jsonTypeError(t, v)
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, xerrors.WithStackTrace(fmt.Errorf("%w: %s from '%s': %v", errJSONType, t.Yql(), s, err))
+	}
+	if n > hi {
+		return 0, xerrors.WithStackTrace(fmt.Errorf("%w: %d is out of range of %s", errValueOutOfRange, n, t.Yql()))
+	}
+	return n, nil
+}
+
+// jsonFloat parses float from JSON number or from strings "NaN", "+Inf" and "-Inf"
+func jsonFloat(t Type, v interface{}, bitSize int) (float64, error) {
+	switch vv := v.(type) {
+	case json.Number:
+		f, err := strconv.ParseFloat(string(vv), bitSize)
+		if err != nil {
+			return 0, xerrors.WithStackTrace(fmt.Errorf("%w: %s from '%s': %v", errJSONType, t.Yql(), vv, err))
+		}
+		return f, nil
+	case string:
+		switch vv {
+		case "NaN":
+			return math.NaN(), nil
+		case "+Inf":
+			return math.Inf(1), nil
+		case "-Inf":
+			return math.Inf(-1), nil
+		}
+	}
+	return 0, jsonTypeError(t, v)
+}
+
+func jsonString(t Type, v interface{}) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", jsonTypeError(t, v)
+	}
+	return s, nil
+}
+
+func jsonBytes(t Type, v interface{}) ([]byte, error) {
+	s, err := jsonString(t, v)
+	if err != nil {
+		return nil, err
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s from '%s': %v", errJSONType, t.Yql(), s, err))
+	}
+	return b, nil
+}
+
+// jsonTime parses time from JSON string with layout and checks that time is a multiple of precision
+func jsonTime(t Type, v interface{}, layout string, precision time.Duration) (time.Time, error) {
+	s, err := jsonString(t, v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	tt, err := time.Parse(layout, s)
+	if err != nil {
+		return time.Time{}, xerrors.WithStackTrace(fmt.Errorf("%w: %s from '%s': %v", errJSONType, t.Yql(), s, err))
+	}
+	if !tt.Truncate(precision).Equal(tt) {
+		return time.Time{}, xerrors.WithStackTrace(fmt.Errorf("%w: %s from '%s': precision of %s is %v",
+			errJSONType, t.Yql(), s, t.Yql(), precision,
+		))
+	}
+	return tt, nil
+}
+
+func jsonArray(t Type, v interface{}, length int) ([]interface{}, error) {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, jsonTypeError(t, v)
+	}
+	if length >= 0 && len(items) != length {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s from array of %d items", errJSONType, t.Yql(), len(items)))
+	}
+	return items, nil
+}
+
+func jsonItems(t, itemType Type, v interface{}) ([]Value, error) {
+	items, err := jsonArray(t, v, -1)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]Value, len(items))
+	for i := range items {
+		if values[i], err = valueFromJSON(itemType, items[i]); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+//nolint:gocyclo,funlen
+func primitiveValueFromJSON(t PrimitiveType, v interface{}) (Value, error) {
+	switch t {
+	case TypeBool:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, jsonTypeError(t, v)
+		}
+		return BoolValue(b), nil
+	case TypeInt8:
+		n, err := jsonInteger(t, v, math.MinInt8, math.MaxInt8)
+		return Int8Value(int8(n)), err
+	case TypeInt16:
+		n, err := jsonInteger(t, v, math.MinInt16, math.MaxInt16)
+		return Int16Value(int16(n)), err
+	case TypeInt32:
+		n, err := jsonInteger(t, v, math.MinInt32, math.MaxInt32)
+		return Int32Value(int32(n)), err
+	case TypeInt64:
+		n, err := jsonInteger(t, v, math.MinInt64, math.MaxInt64)
+		return Int64Value(n), err
+	case TypeUint8:
+		n, err := jsonUnsigned(t, v, math.MaxUint8)
+		return Uint8Value(uint8(n)), err
+	case TypeUint16:
+		n, err := jsonUnsigned(t, v, math.MaxUint16)
+		return Uint16Value(uint16(n)), err
+	case TypeUint32:
+		n, err := jsonUnsigned(t, v, math.MaxUint32)
+		return Uint32Value(uint32(n)), err
+	case TypeUint64:
+		n, err := jsonUnsigned(t, v, math.MaxUint64)
+		return Uint64Value(n), err
+	case TypeFloat:
+		f, err := jsonFloat(t, v, 32)
+		return FloatValue(float32(f)), err
+	case TypeDouble:
+		f, err := jsonFloat(t, v, 64)
+		return DoubleValue(f), err
+	case TypeDate:
+		tt, err := jsonTime(t, v, LayoutDate, 24*time.Hour)
+		if err != nil {
+			return nil, err
+		}
+		return DateValueFromTimeE(tt)
+	case TypeDatetime:
+		tt, err := jsonTime(t, v, time.RFC3339, time.Second)
+		if err != nil {
+			return nil, err
+		}
+		return DatetimeValueFromTimeE(tt)
+	case TypeTimestamp:
+		tt, err := jsonTime(t, v, time.RFC3339Nano, time.Microsecond)
+		if err != nil {
+			return nil, err
+		}
+		return TimestampValueFromTimeE(tt)
+	case TypeInterval:
+		s, err := jsonString(t, v)
+		if err != nil {
+			return nil, err
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s from '%s': %v", errJSONType, t.Yql(), s, err))
+		}
+		return IntervalValueFromDurationChecked(d, false)
+	case TypeDate32:
+		tt, err := jsonTime(t, v, LayoutDate, 24*time.Hour)
+		if err != nil {
+			return nil, err
+		}
+		return Date32ValueFromTimeE(tt)
+	case TypeDatetime64:
+		tt, err := jsonTime(t, v, time.RFC3339, time.Second)
+		if err != nil {
+			return nil, err
+		}
+		return Datetime64ValueFromTimeE(tt)
+	case TypeTimestamp64:
+		tt, err := jsonTime(t, v, time.RFC3339Nano, time.Microsecond)
+		if err != nil {
+			return nil, err
+		}
+		return Timestamp64ValueFromTimeE(tt)
+	case TypeInterval64:
+		n, err := jsonInteger(t, v, -maxInterval64, maxInterval64)
+		return Interval64Value(n), err
+	case TypeTzDate, TypeTzDatetime, TypeTzTimestamp:
+		s, err := jsonString(t, v)
+		if err != nil {
+			return nil, err
+		}
+		switch t {
+		case TypeTzDate:
+			_, err = TzDateToTime(s)
+			return TzDateValue(s), err
+		case TypeTzDatetime:
+			_, err = TzDatetimeToTime(s)
+			return TzDatetimeValue(s), err
+		default:
+			_, err = TzTimestampToTime(s)
+			return TzTimestampValue(s), err
+		}
+	case TypeText:
+		s, err := jsonString(t, v)
+		return TextValue(s), err
+	case TypeJSON:
+		s, err := jsonString(t, v)
+		return JSONValue(s), err
+	case TypeJSONDocument:
+		s, err := jsonString(t, v)
+		return JSONDocumentValue(s), err
+	case TypeDyNumber:
+		s, err := jsonString(t, v)
+		return DyNumberValue(s), err
+	case TypeBytes:
+		b, err := jsonBytes(t, v)
+		return BytesValue(b), err
+	case TypeYSON:
+		b, err := jsonBytes(t, v)
+		return YSONValue(b), err
+	case TypeUUID:
+		s, err := jsonString(t, v)
+		if err != nil {
+			return nil, err
+		}
+		return UUIDValueFromString(s)
+	default:
+		return nil, xerrors.WithStackTrace(fmt.Errorf("cannot unmarshal value of type %s from JSON", t.Yql()))
+	}
+}
+
+// ValueFromJSON makes value of type t from JSON value v in representation of MarshalJSON.
+// v must be decoded by json.Decoder with UseNumber (numbers are json.Number)
+func ValueFromJSON(t Type, v interface{}) (Value, error) {
+	return valueFromJSON(t, v)
+}
+
+//nolint:gocyclo,funlen
+func valueFromJSON(t Type, v interface{}) (Value, error) {
+	switch tt := t.(type) {
+	case PrimitiveType:
+		vv, err := primitiveValueFromJSON(tt, v)
+		if err != nil {
+			return nil, err
+		}
+		return vv, nil
+	case *DecimalType:
+		s, err := jsonString(t, v)
+		if err != nil {
+			return nil, err
+		}
+		x, err := decimal.Parse(s, tt.Precision, tt.Scale)
+		if err != nil {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s from '%s': %v", errJSONType, t.Yql(), s, err))
+		}
+		// decimal.Parse saturates to infinity on overflow, but infinity is allowed only if it was written explicitly
+		if (decimal.IsInf(x) || decimal.IsNaN(x)) && !isDecimalSpecial(s) {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: '%s' is out of range of %s", errValueOutOfRange, s, t.Yql()))
+		}
+		return DecimalValueFromBigInt(x, tt.Precision, tt.Scale), nil
+	case voidType:
+		if v != nil {
+			return nil, jsonTypeError(t, v)
+		}
+		return VoidValue(), nil
+	case *pgType:
+		if v == nil {
+			return &pgValue{t: tt, null: true}, nil
+		}
+		s, err := jsonString(t, v)
+		if err != nil {
+			return nil, err
+		}
+		return &pgValue{t: tt, text: s}, nil
+	case *taggedType:
+		item, err := valueFromJSON(tt.innerType, v)
+		if err != nil {
+			return nil, err
+		}
+		return &taggedValue{t: tt, value: item}, nil
+	case optionalType:
+		if v == nil {
+			return NullValue(tt.innerType), nil
+		}
+		if IsOptional(tt.innerType) {
+			items, err := jsonArray(t, v, 1)
+			if err != nil {
+				return nil, err
+			}
+			v = items[0]
+		}
+		item, err := valueFromJSON(tt.innerType, v)
+		if err != nil {
+			return nil, err
+		}
+		return &optionalValue{innerType: tt, value: item}, nil
+	case *listType:
+		items, err := jsonItems(t, tt.itemType, v)
+		if err != nil {
+			return nil, err
+		}
+		return &listValue{t: tt, items: items}, nil
+	case emptyListType:
+		if _, err := jsonArray(t, v, 0); err != nil {
+			return nil, err
+		}
+		return &listValue{t: tt}, nil
+	case *setType:
+		items, err := jsonItems(t, tt.itemType, v)
+		if err != nil {
+			return nil, err
+		}
+		set := SetValue(items...)
+		set.t = tt
+		return set, nil
+	case *TupleType:
+		items, err := jsonArray(t, v, len(tt.items))
+		if err != nil {
+			return nil, err
+		}
+		values := make([]Value, len(items))
+		for i := range items {
+			if values[i], err = valueFromJSON(tt.items[i], items[i]); err != nil {
+				return nil, err
+			}
+		}
+		return &tupleValue{t: tt, items: values}, nil
+	case *StructType:
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, jsonTypeError(t, v)
+		}
+		if len(object) != len(tt.fields) {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s from object with %d fields",
+				errJSONType, t.Yql(), len(object),
+			))
+		}
+		fields := make([]StructValueField, len(tt.fields))
+		for i, f := range tt.fields {
+			field, has := object[f.Name]
+			if !has {
+				return nil, xerrors.WithStackTrace(fmt.Errorf("%w: no field '%s' of %s", errJSONType, f.Name, t.Yql()))
+			}
+			fv, err := valueFromJSON(f.T, field)
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = StructValueField{Name: f.Name, V: fv}
+		}
+		return structValueOfType(tt, fields), nil
+	case *dictType:
+		pairs, err := jsonArray(t, v, -1)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]DictValueField, len(pairs))
+		for i := range pairs {
+			pair, err := jsonArray(t, pairs[i], 2)
+			if err != nil {
+				return nil, err
+			}
+			if values[i].K, err = valueFromJSON(tt.keyType, pair[0]); err != nil {
+				return nil, err
+			}
+			if values[i].V, err = valueFromJSON(tt.valueType, pair[1]); err != nil {
+				return nil, err
+			}
+		}
+		dict := DictValue(values...)
+		dict.t = tt
+		return dict, nil
+	case emptyDictType:
+		if _, err := jsonArray(t, v, 0); err != nil {
+			return nil, err
+		}
+		return DictValue(), nil
+	case *variantStructType:
+		object, ok := v.(map[string]interface{})
+		if !ok || len(object) != 1 {
+			return nil, jsonTypeError(t, v)
+		}
+		for name, item := range object {
+			for i, f := range tt.fields {
+				if f.Name == name {
+					vv, err := valueFromJSON(f.T, item)
+					if err != nil {
+						return nil, err
+					}
+					return &variantValue{innerType: tt, value: vv, idx: uint32(i)}, nil
+				}
+			}
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: no field '%s' of %s", errJSONType, name, t.Yql()))
+		}
+		return nil, jsonTypeError(t, v)
+	case *variantTupleType:
+		pair, err := jsonArray(t, v, 2)
+		if err != nil {
+			return nil, err
+		}
+		if len(tt.items) == 0 {
+			return nil, jsonTypeError(t, v)
+		}
+		idx, err := jsonUnsigned(t, pair[0], uint64(len(tt.items)-1))
+		if err != nil {
+			return nil, err
+		}
+		vv, err := valueFromJSON(tt.items[idx], pair[1])
+		if err != nil {
+			return nil, err
+		}
+		return &variantValue{innerType: tt, value: vv, idx: uint32(idx)}, nil
+	default:
+		return nil, xerrors.WithStackTrace(fmt.Errorf("cannot unmarshal value of type %s from JSON", t.Yql()))
+	}
+}
@@ -0,0 +1,210 @@
+package value
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, tt := range []struct {
+		v    Value
+		json string
+	}{
+		{BoolValue(true), `true`},
+		{Int8Value(math.MinInt8), `-128`},
+		{Int16Value(math.MaxInt16), `32767`},
+		{Int32Value(-42), `-42`},
+		{Int64Value(1 << 53), `9007199254740992`},
+		{Int64Value(-(1 << 53) - 1), `"-9007199254740993"`},
+		{Int64Value(math.MaxInt64), `"9223372036854775807"`},
+		{Uint8Value(math.MaxUint8), `255`},
+		{Uint16Value(math.MaxUint16), `65535`},
+		{Uint32Value(math.MaxUint32), `4294967295`},
+		{Uint64Value(1 << 53), `9007199254740992`},
+		{Uint64Value(1<<53 + 1), `"9007199254740993"`},
+		{Uint64Value(math.MaxUint64), `"18446744073709551615"`},
+		{FloatValue(0.1), `0.1`},
+		{FloatValue(float32(math.Inf(-1))), `"-Inf"`},
+		{DoubleValue(1e-300), `1e-300`},
+		{DoubleValue(math.Inf(1)), `"+Inf"`},
+		{DecimalValueFromBigInt(decimal.Inf(), 22, 9), `"inf"`},
+		{DecimalValueFromBigInt(mustBigInt("-12345678901234567890123"), 35, 10), `"-1234567890123.4567890123"`},
+		{DateValueFromTime(time.Date(2023, 5, 17, 0, 0, 0, 0, time.UTC)), `"2023-05-17"`},
+		{DatetimeValueFromTime(time.Date(2023, 5, 17, 10, 20, 30, 0, time.UTC)), `"2023-05-17T10:20:30Z"`},
+		{
+			TimestampValueFromTime(time.Date(2023, 5, 17, 10, 20, 30, 123456000, time.UTC)),
+			`"2023-05-17T10:20:30.123456Z"`,
+		},
+		{IntervalValueFromDuration(-(time.Hour + 2*time.Microsecond)), `"-1h0m0.000002s"`},
+		{Date32ValueFromTime(time.Date(1900, 5, 17, 0, 0, 0, 0, time.UTC)), `"1900-05-17"`},
+		{Datetime64ValueFromTime(time.Date(1900, 5, 17, 10, 20, 30, 0, time.UTC)), `"1900-05-17T10:20:30Z"`},
+		{
+			Timestamp64ValueFromTime(time.Date(2200, 5, 17, 10, 20, 30, 123456000, time.UTC)),
+			`"2200-05-17T10:20:30.123456Z"`,
+		},
+		{Interval64Value(-3600000002), `-3600000002`},
+		{Interval64Value(maxInterval64), `"9223339708799999999"`},
+		{TzDateValue("2023-05-17,Europe/Berlin"), `"2023-05-17,Europe/Berlin"`},
+		{TzDatetimeValue("2023-05-17T10:20:30,Europe/Berlin"), `"2023-05-17T10:20:30,Europe/Berlin"`},
+		{TzTimestampValue("2023-05-17T10:20:30.123456,Europe/Berlin"), `"2023-05-17T10:20:30.123456,Europe/Berlin"`},
+		{TextValue("a \"quoted\" тест"), `"a \"quoted\" тест"`},
+		{JSONValue(`{"a":1}`), `"{\"a\":1}"`},
+		{JSONDocumentValue(`[1]`), `"[1]"`},
+		{DyNumberValue("1E+2"), `"1E+2"`},
+		{BytesValue([]byte{0, 1, 0xff}), `"AAH/"`},
+		{YSONValue([]byte("{a=1}")), `"e2E9MX0="`},
+		{UUIDValue([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), `"01020304-0506-0708-090a-0b0c0d0e0f10"`},
+		{VoidValue(), `null`},
+		{OptionalValue(Int32Value(1)), `1`},
+		{NullValue(TypeInt32), `null`},
+		{OptionalValue(OptionalValue(Int32Value(1))), `[1]`},
+		{OptionalValue(NullValue(TypeInt32)), `[null]`},
+		{NullValue(Optional(TypeInt32)), `null`},
+		{ListValue(Int32Value(1), Int32Value(2)), `[1,2]`},
+		{ListValue(), `[]`},
+		{SetValue(TextValue("b"), TextValue("a")), `["a","b"]`},
+		{TupleValue(Int32Value(1), TextValue("a"), NullValue(TypeBool)), `[1,"a",null]`},
+		{
+			StructValue(StructValueField{"b", TextValue("b")}, StructValueField{"a", Int32Value(1)}),
+			`{"a":1,"b":"b"}`,
+		},
+		{
+			StructValueOrdered(StructValueField{"b", TextValue("b")}, StructValueField{"a", Int32Value(1)}),
+			`{"b":"b","a":1}`,
+		},
+		{
+			DictValue(
+				DictValueField{K: TextValue("b"), V: Int32Value(2)},
+				DictValueField{K: TextValue("a"), V: Int32Value(1)},
+			),
+			`[["a",1],["b",2]]`,
+		},
+		{DictValue(), `[]`},
+		{
+			VariantValueStruct(Int32Value(42), "bar", Struct(
+				StructField{Name: "foo", T: TypeText},
+				StructField{Name: "bar", T: TypeInt32},
+			)),
+			`{"bar":42}`,
+		},
+		{VariantValueTuple(TextValue("a"), 1, Tuple(TypeInt32, TypeText)), `[1,"a"]`},
+		{
+			ListValue(OptionalValue(StructValue(
+				StructValueField{"id", Uint64Value(math.MaxUint64)},
+				StructValueField{"tags", DictValue(DictValueField{K: Int32Value(1), V: ListValue(TextValue("x"))})},
+			))),
+			`[{"id":"18446744073709551615","tags":[[1,["x"]]]}]`,
+		},
+	} {
+		t.Run(tt.v.Type().Yql(), func(t *testing.T) {
+			data, err := MarshalJSON(tt.v)
+			require.NoError(t, err)
+			require.JSONEq(t, `{"type":`+string(mustMarshalJSONString(tt.v.Type().Yql()))+`,"value":`+tt.json+`}`, string(data))
+
+			v, err := UnmarshalJSON(tt.v.Type(), data)
+			require.NoError(t, err)
+			require.True(t, v.Type().equalsTo(tt.v.Type()), v.Type().Yql())
+			require.True(t, Equal(tt.v, v), v.Yql())
+		})
+	}
+	t.Run("NaN", func(t *testing.T) {
+		data, err := MarshalJSON(DoubleValue(math.NaN()))
+		require.NoError(t, err)
+		v, err := UnmarshalJSON(TypeDouble, data)
+		require.NoError(t, err)
+		require.True(t, math.IsNaN(v.(*doubleValue).value))
+	})
+	t.Run("Secret", func(t *testing.T) {
+		data, err := MarshalJSON(SecretValue(TextValue("password")))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"type":"Utf8","value":"***"}`, string(data))
+	})
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		t    Type
+		json string
+	}{
+		{"TypeHeader", TypeInt32, `{"type":"Int64","value":1}`},
+		{"NoValue", TypeInt32, `{"type":"Int32"}`},
+		{"Malformed", TypeInt32, `{"type":"Int32","value":}`},
+		{"Int8Range", TypeInt8, `{"type":"Int8","value":128}`},
+		{"Int32Range", TypeInt32, `{"type":"Int32","value":"-2147483649"}`},
+		{"Int64Range", TypeInt64, `{"type":"Int64","value":"9223372036854775808"}`},
+		{"Int32Fraction", TypeInt32, `{"type":"Int32","value":1.5}`},
+		{"Uint8Negative", TypeUint8, `{"type":"Uint8","value":-1}`},
+		{"Uint32Range", TypeUint32, `{"type":"Uint32","value":4294967296}`},
+		{"Uint64Range", TypeUint64, `{"type":"Uint64","value":"18446744073709551616"}`},
+		{"FloatRange", TypeFloat, `{"type":"Float","value":1e39}`},
+		{"DoubleString", TypeDouble, `{"type":"Double","value":"1"}`},
+		{"Bool", TypeBool, `{"type":"Bool","value":1}`},
+		{"Text", TypeText, `{"type":"Utf8","value":1}`},
+		{"Bytes", TypeBytes, `{"type":"String","value":"not base64!"}`},
+		{"Decimal", Decimal(5, 2), `{"type":"Decimal(5,2)","value":"1234.5"}`},
+		{"DecimalNumber", Decimal(5, 2), `{"type":"Decimal(5,2)","value":1.5}`},
+		{"Date", TypeDate, `{"type":"Date","value":"2023-13-01"}`},
+		{"DateRange", TypeDate, `{"type":"Date","value":"1969-12-31"}`},
+		{"DatetimeFraction", TypeDatetime, `{"type":"Datetime","value":"2023-05-17T10:20:30.5Z"}`},
+		{"TimestampNanoseconds", TypeTimestamp, `{"type":"Timestamp","value":"2023-05-17T10:20:30.1234567Z"}`},
+		{"Interval", TypeInterval, `{"type":"Interval","value":"1 hour"}`},
+		{"IntervalFraction", TypeInterval, `{"type":"Interval","value":"1ns"}`},
+		{"Interval64", TypeInterval64, `{"type":"Interval64","value":"1h"}`},
+		{"Interval64Range", TypeInterval64, `{"type":"Interval64","value":"-9223339708800000000"}`},
+		{"TzDate", TypeTzDate, `{"type":"TzDate","value":"2023-05-17"}`},
+		{"UUID", TypeUUID, `{"type":"Uuid","value":"not uuid"}`},
+		{"Void", Void(), `{"type":"Void","value":1}`},
+		{"NestedOptional", Optional(Optional(TypeInt32)), `{"type":"Optional<Optional<Int32>>","value":1}`},
+		{"List", List(TypeInt32), `{"type":"List<Int32>","value":{}}`},
+		{"ListItem", List(TypeInt32), `{"type":"List<Int32>","value":[1,"a"]}`},
+		{"Tuple", Tuple(TypeInt32, TypeText), `{"type":"Tuple<Int32,Utf8>","value":[1]}`},
+		{
+			"StructMissingField",
+			Struct(StructField{Name: "a", T: TypeInt32}, StructField{Name: "b", T: TypeInt32}),
+			`{"type":"Struct<'a':Int32,'b':Int32>","value":{"a":1,"c":2}}`,
+		},
+		{
+			"StructExtraField",
+			Struct(StructField{Name: "a", T: TypeInt32}),
+			`{"type":"Struct<'a':Int32>","value":{"a":1,"b":2}}`,
+		},
+		{"DictPair", Dict(TypeText, TypeInt32), `{"type":"Dict<Utf8,Int32>","value":[["a"]]}`},
+		{
+			"VariantStructName",
+			VariantStruct(StructField{Name: "a", T: TypeInt32}),
+			`{"type":"Variant<'a':Int32>","value":{"b":1}}`,
+		},
+		{
+			"VariantTupleIndex",
+			VariantTuple(TypeInt32, TypeText),
+			`{"type":"Variant<Int32,Utf8>","value":[2,"a"]}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalJSON(tt.t, []byte(tt.json))
+			require.Error(t, err)
+		})
+	}
+}
+
+func mustMarshalJSONString(s string) []byte {
+	var buffer bytes.Buffer
+	writeJSONString(&buffer, s)
+	return buffer.Bytes()
+}
+
+func mustBigInt(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid big integer: " + s)
+	}
+	return v
+}
@@ -0,0 +1,162 @@
+package value
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+// NativeValue returns natural Go representation of value
+//
+// Mapping of YDB types to Go types is documented in types.NativeValue.
+func NativeValue(v Value) (interface{}, error) {
+	switch vv := v.(type) {
+	case boolValue:
+		return bool(vv), nil
+	case int8Value:
+		return int64(vv), nil
+	case int16Value:
+		return int64(vv), nil
+	case int32Value:
+		return int64(vv), nil
+	case int64Value:
+		return int64(vv), nil
+	case uint8Value:
+		return uint64(vv), nil
+	case uint16Value:
+		return uint64(vv), nil
+	case uint32Value:
+		return uint64(vv), nil
+	case uint64Value:
+		return uint64(vv), nil
+	case *floatValue:
+		return float64(vv.value), nil
+	case *doubleValue:
+		return vv.value, nil
+	case dateValue:
+		return DateToTime(uint32(vv)).UTC(), nil
+	case datetimeValue:
+		return DatetimeToTime(uint32(vv)).UTC(), nil
+	case timestampValue:
+		return TimestampToTime(uint64(vv)).UTC(), nil
+	case tzDateValue:
+		return TzDateToTime(string(vv))
+	case tzDatetimeValue:
+		return TzDatetimeToTime(string(vv))
+	case tzTimestampValue:
+		return TzTimestampToTime(string(vv))
+	case intervalValue:
+		return IntervalToDuration(int64(vv)), nil
+	case date32Value:
+		return Date32ToTime(int32(vv)).UTC(), nil
+	case datetime64Value:
+		return Datetime64ToTime(int64(vv)).UTC(), nil
+	case timestamp64Value:
+		return Timestamp64ToTime(int64(vv)).UTC(), nil
+	case interval64Value:
+		return IntervalToDuration(int64(vv)), nil
+	case textValue:
+		return string(vv), nil
+	case jsonValue:
+		return string(vv), nil
+	case jsonDocumentValue:
+		return string(vv), nil
+	case dyNumberValue:
+		return string(vv), nil
+	case bytesValue:
+		return []byte(vv), nil
+	case ysonValue:
+		return []byte(vv), nil
+	case *uuidValue:
+		return vv.value, nil
+	case *decimalValue:
+		return decimal.Format(
+			decimal.FromBytes(vv.value[:], vv.innerType.Precision, vv.innerType.Scale),
+			vv.innerType.Precision, vv.innerType.Scale,
+		), nil
+	case voidValue:
+		return nil, nil
+	case *optionalValue:
+		if vv.value == nil {
+			return nil, nil
+		}
+		return NativeValue(vv.value)
+	case *taggedValue:
+		return NativeValue(vv.value)
+	case *pgValue:
+		if vv.null {
+			return nil, nil
+		}
+		return vv.text, nil
+	case *listValue:
+		return nativeValues(vv.items)
+	case *setValue:
+		return nativeValues(vv.items)
+	case *tupleValue:
+		return nativeValues(vv.items)
+	case *structValue:
+		fields := make(map[string]interface{}, len(vv.fields))
+		for _, f := range vv.fields {
+			field, err := NativeValue(f.V)
+			if err != nil {
+				return nil, err
+			}
+			fields[f.Name] = field
+		}
+		return fields, nil
+	case *dictValue:
+		values := make(map[interface{}]interface{}, len(vv.values))
+		for _, f := range vv.values {
+			k, err := NativeValue(f.K)
+			if err != nil {
+				return nil, err
+			}
+			if bytes, ok := k.([]byte); ok {
+				k = string(bytes)
+			}
+			if k != nil && !reflect.TypeOf(k).Comparable() {
+				return nil, xerrors.WithStackTrace(fmt.Errorf(
+					"cannot use '%s' as key of native dict: %w", f.K.Yql(), errNotComparableKey,
+				))
+			}
+			values[k], err = NativeValue(f.V)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	case *variantValue:
+		return NativeValue(vv.value)
+	case *secretValue:
+		// natural representation is used for logging and export, so content of secret is hidden
+		return secretYql, nil
+	case *rawValue:
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s", errRawValueCast, vv.Type().Yql()))
+	default:
+		return nil, xerrors.WithStackTrace(fmt.Errorf("unknown value type '%T'", v))
+	}
+}
+
+func nativeValues(items []Value) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		v, err := NativeValue(item)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// castToInterface casts value to destination of type *interface{} with NativeValue
+func castToInterface(v Value, dst *interface{}) error {
+	native, err := NativeValue(v)
+	if err != nil {
+		return castError(v.Yql(), v.Type(), dst, err)
+	}
+	*dst = native
+	return nil
+}
@@ -0,0 +1,120 @@
+package value
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNativeValue(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	uuid := [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	for _, tt := range []struct {
+		v   Value
+		exp interface{}
+	}{
+		{v: BoolValue(true), exp: true},
+		{v: Int8Value(math.MinInt8), exp: int64(math.MinInt8)},
+		{v: Int16Value(math.MinInt16), exp: int64(math.MinInt16)},
+		{v: Int32Value(math.MinInt32), exp: int64(math.MinInt32)},
+		{v: Int64Value(math.MinInt64), exp: int64(math.MinInt64)},
+		{v: Uint8Value(math.MaxUint8), exp: uint64(math.MaxUint8)},
+		{v: Uint16Value(math.MaxUint16), exp: uint64(math.MaxUint16)},
+		{v: Uint32Value(math.MaxUint32), exp: uint64(math.MaxUint32)},
+		{v: Uint64Value(math.MaxUint64), exp: uint64(math.MaxUint64)},
+		{v: FloatValue(-1.5), exp: float64(-1.5)},
+		{v: DoubleValue(math.Pi), exp: math.Pi},
+		{v: DateValueFromTime(time.Date(2022, 6, 17, 0, 0, 0, 0, time.UTC)), exp: time.Date(2022, 6, 17, 0, 0, 0, 0, time.UTC)},
+		{
+			v:   DatetimeValueFromTime(time.Date(2022, 6, 17, 5, 19, 20, 0, time.UTC)),
+			exp: time.Date(2022, 6, 17, 5, 19, 20, 0, time.UTC),
+		},
+		{
+			v:   TimestampValueFromTime(time.Date(2022, 6, 17, 5, 19, 20, 123456000, time.UTC)),
+			exp: time.Date(2022, 6, 17, 5, 19, 20, 123456000, time.UTC),
+		},
+		{v: TzDateValue("2022-06-17,Europe/Berlin"), exp: time.Date(2022, 6, 17, 0, 0, 0, 0, berlin)},
+		{
+			v:   TzDatetimeValue("2022-06-17T05:19:20,Europe/Berlin"),
+			exp: time.Date(2022, 6, 17, 5, 19, 20, 0, berlin),
+		},
+		{
+			v:   TzTimestampValue("2022-06-17T05:19:20.123456,Europe/Berlin"),
+			exp: time.Date(2022, 6, 17, 5, 19, 20, 123456000, berlin),
+		},
+		{v: IntervalValueFromDuration(-90 * time.Minute), exp: -90 * time.Minute},
+		{v: TextValue("текст"), exp: "текст"},
+		{v: JSONValue(`{"a":1}`), exp: `{"a":1}`},
+		{v: JSONDocumentValue(`{"a":1}`), exp: `{"a":1}`},
+		{v: DyNumberValue(".1234e4"), exp: ".1234e4"},
+		{v: BytesValue([]byte{0, 1, 2, 0xff}), exp: []byte{0, 1, 2, 0xff}},
+		{v: YSONValue([]byte("{a=1}")), exp: []byte("{a=1}")},
+		{v: UUIDValue(uuid), exp: uuid},
+		{v: DecimalValueFromBigInt(big.NewInt(-1500000000), 22, 9), exp: "-1.500000000"},
+		{v: VoidValue(), exp: nil},
+		{v: OptionalValue(Int32Value(42)), exp: int64(42)},
+		{v: OptionalValue(OptionalValue(TextValue("nested"))), exp: "nested"},
+		{v: NullValue(TypeInt32), exp: nil},
+		{v: ListValue(Int64Value(1), NullValue(TypeInt64)), exp: []interface{}{int64(1), nil}},
+		{v: ZeroValue(List(TypeText)), exp: []interface{}{}},
+		{v: SetValue(TextValue("a")), exp: []interface{}{"a"}},
+		{v: TupleValue(Int32Value(1), TextValue("a")), exp: []interface{}{int64(1), "a"}},
+		{
+			v: StructValue(
+				StructValueField{"id", Uint64Value(1)},
+				StructValueField{"tags", ListValue(TextValue("a"))},
+			),
+			exp: map[string]interface{}{"id": uint64(1), "tags": []interface{}{"a"}},
+		},
+		{
+			v: DictValue(
+				DictValueField{BytesValue([]byte("a")), Uint64Value(1)},
+				DictValueField{BytesValue([]byte("b")), NullValue(TypeUint64)},
+			),
+			exp: map[interface{}]interface{}{"a": uint64(1), "b": nil},
+		},
+		{v: VariantValueTuple(Int32Value(42), 1, Tuple(TypeBytes, TypeInt32)), exp: int64(42)},
+		{
+			v: VariantValueStruct(Int32Value(42), "bar", Struct(
+				StructField{Name: "foo", T: TypeBytes},
+				StructField{Name: "bar", T: TypeInt32},
+			)),
+			exp: int64(42),
+		},
+	} {
+		t.Run(tt.v.Yql(), func(t *testing.T) {
+			native, err := NativeValue(tt.v)
+			require.NoError(t, err)
+			require.Equal(t, tt.exp, native)
+
+			var dst interface{}
+			require.NoError(t, Cast(tt.v, &dst))
+			require.Equal(t, tt.exp, dst)
+
+			if tm, ok := tt.exp.(time.Time); ok {
+				require.Equal(t, tm.Location().String(), dst.(time.Time).Location().String())
+			}
+		})
+	}
+}
+
+func TestNativeValueErrors(t *testing.T) {
+	for _, v := range []Value{
+		TzDateValue("2022-06-17"),
+		DictValue(DictValueField{ListValue(Int32Value(1)), Int32Value(1)}),
+		ListValue(TzDatetimeValue("2022-06-17T05:19:20,Unknown/Zone")),
+	} {
+		t.Run(v.Yql(), func(t *testing.T) {
+			_, err := NativeValue(v)
+			require.Error(t, err)
+
+			var dst interface{}
+			require.ErrorContains(t, Cast(v, &dst), "to '*interface {}' destination")
+			require.Nil(t, dst)
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package value
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+// TypeSyntaxError reports malformed textual representation of type
+type TypeSyntaxError struct {
+	// Input is a parsed text
+	Input string
+	// Offset is a byte offset of error in Input
+	Offset int
+	// Reason describes error
+	Reason string
+}
+
+func (e *TypeSyntaxError) Error() string {
+	return fmt.Sprintf("cannot parse type %q: %s at offset %d", e.Input, e.Reason, e.Offset)
+}
+
+var (
+	primitiveTypesByName = func() map[string]PrimitiveType {
+		types := make(map[string]PrimitiveType, len(primitiveString))
+		for t, name := range primitiveString {
+			if PrimitiveType(t) != TypeUnknown {
+				types[name] = PrimitiveType(t)
+			}
+		}
+		return types
+	}()
+	pgTypesByName = func() map[string]uint32 {
+		oids := make(map[string]uint32, len(pgTypeNames))
+		for oid, name := range pgTypeNames {
+			oids["pg"+name] = oid
+		}
+		return oids
+	}()
+)
+
+// ParseType parses type from its textual representation in format of Type.Yql()
+// (such as `List<Optional<Decimal(22,9)>>` or `Struct<'id':Uint64,'name':Utf8>`).
+// Spaces between tokens are allowed. Errors of parsing are *TypeSyntaxError
+func ParseType(s string) (Type, error) {
+	p := typeParser{s: s}
+	t, err := p.parseType()
+	if err == nil {
+		p.skipSpaces()
+		if p.pos < len(p.s) {
+			err = p.errorf("unexpected %q after type", p.s[p.pos:])
+		}
+	}
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return t, nil
+}
+
+type typeParser struct {
+	s   string
+	pos int
+}
+
+func (p *typeParser) errorf(format string, args ...interface{}) error {
+	return &TypeSyntaxError{
+		Input:  p.s,
+		Offset: p.pos,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+func (p *typeParser) skipSpaces() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n' || p.s[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+// peek returns next non-space byte or zero at end of input
+func (p *typeParser) peek() byte {
+	p.skipSpaces()
+	if p.pos == len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *typeParser) expect(c byte) error {
+	if p.peek() != c {
+		if p.pos == len(p.s) {
+			return p.errorf("expected '%c', got end of input", c)
+		}
+		return p.errorf("expected '%c', got '%c'", c, p.s[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+func isIdentByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
+
+func (p *typeParser) ident() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && isIdentByte(p.s[p.pos]) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *typeParser) uint32() (uint32, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, p.errorf("expected number")
+	}
+	n, err := strconv.ParseUint(p.s[start:p.pos], 10, 32)
+	if err != nil {
+		text := p.s[start:p.pos]
+		p.pos = start
+		return 0, p.errorf("number %s is out of range", text)
+	}
+	return uint32(n), nil
+}
+
+// quoted parses string in quotes q with backslash escapes (as quoteYql makes)
+func (p *typeParser) quoted(q byte) (string, error) {
+	if p.peek() != q {
+		return "", p.errorf("expected quoted string")
+	}
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch {
+		case c == q:
+			p.pos++
+			return b.String(), nil
+		case c != '\\':
+			b.WriteByte(c)
+			p.pos++
+			continue
+		}
+		if p.pos+1 == len(p.s) {
+			break
+		}
+		switch e := p.s[p.pos+1]; e {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'x':
+			if p.pos+4 > len(p.s) {
+				return "", p.errorf("malformed escape sequence")
+			}
+			n, err := strconv.ParseUint(p.s[p.pos+2:p.pos+4], 16, 8)
+			if err != nil {
+				return "", p.errorf("malformed escape sequence")
+			}
+			b.WriteByte(byte(n))
+			p.pos += 2
+		default:
+			if e >= utf8.RuneSelf || isIdentByte(e) {
+				return "", p.errorf("unknown escape sequence")
+			}
+			b.WriteByte(e)
+		}
+		p.pos += 2
+	}
+	p.pos = start
+	return "", p.errorf("unterminated quoted string")
+}
+
+//nolint:gocyclo,funlen
+func (p *typeParser) parseType() (Type, error) {
+	start := p.pos
+	name := p.ident()
+	if name == "" {
+		if p.pos == len(p.s) {
+			return nil, p.errorf("expected type, got end of input")
+		}
+		return nil, p.errorf("expected type, got '%c'", p.s[p.pos])
+	}
+	if t, has := primitiveTypesByName[name]; has {
+		return t, nil
+	}
+	if oid, has := pgTypesByName[name]; has {
+		return Pg(oid), nil
+	}
+	switch name {
+	case "Void":
+		return Void(), nil
+	case "Null":
+		return Null(), nil
+	case "EmptyList":
+		return EmptyList(), nil
+	case "EmptyDict":
+		return EmptyDict(), nil
+	case "Decimal":
+		return p.parseDecimal(start)
+	case "PgType":
+		if err := p.expect('('); err != nil {
+			return nil, err
+		}
+		oid, err := p.uint32()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(')'); err != nil {
+			return nil, err
+		}
+		return Pg(oid), nil
+	case "Optional", "List", "Set":
+		if err := p.expect('<'); err != nil {
+			return nil, err
+		}
+		t, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect('>'); err != nil {
+			return nil, err
+		}
+		switch name {
+		case "Optional":
+			return Optional(t), nil
+		case "List":
+			return List(t), nil
+		default:
+			return Set(t), nil
+		}
+	case "Dict":
+		items, err := p.parseTypes(2)
+		if err != nil {
+			return nil, err
+		}
+		return Dict(items[0], items[1]), nil
+	case "Tuple":
+		items, err := p.parseTypes(-1)
+		if err != nil {
+			return nil, err
+		}
+		return Tuple(items...), nil
+	case "Struct":
+		fields, err := p.parseStructFields()
+		if err != nil {
+			return nil, err
+		}
+		return Struct(fields...), nil
+	case "Variant":
+		open := p.pos
+		if err := p.expect('<'); err != nil {
+			return nil, err
+		}
+		isStruct := p.peek() == '\''
+		p.pos = open
+		if isStruct {
+			fields, err := p.parseStructFields()
+			if err != nil {
+				return nil, err
+			}
+			return VariantStruct(fields...), nil
+		}
+		items, err := p.parseTypes(-1)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			p.pos = start
+			return nil, p.errorf("variant type must have alternatives")
+		}
+		return VariantTuple(items...), nil
+	case "Tagged":
+		if err := p.expect('<'); err != nil {
+			return nil, err
+		}
+		t, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(','); err != nil {
+			return nil, err
+		}
+		tag, err := p.quoted('"')
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect('>'); err != nil {
+			return nil, err
+		}
+		return Tagged(tag, t), nil
+	default:
+		p.pos = start
+		p.skipSpaces()
+		return nil, p.errorf("unknown type '%s'", name)
+	}
+}
+
+// parseDecimal parses parameters of decimal type which name starts at offset start
+func (p *typeParser) parseDecimal(start int) (Type, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	precision, err := p.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(','); err != nil {
+		return nil, err
+	}
+	scale, err := p.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.expect(')'); err != nil {
+		return nil, err
+	}
+	if decimalTypeProblem(precision, scale) != "" {
+		p.pos = start
+		p.skipSpaces()
+		return nil, p.errorf("%s", decimalTypeProblem(precision, scale))
+	}
+	return Decimal(precision, scale), nil
+}
+
+// parseTypes parses list of comma-separated types in angle brackets.
+// If count is not negative then list must have exactly count types
+func (p *typeParser) parseTypes(count int) ([]Type, error) {
+	if err := p.expect('<'); err != nil {
+		return nil, err
+	}
+	var items []Type
+	if count != 0 && p.peek() != '>' || count > 0 {
+		for {
+			t, err := p.parseType()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, t)
+			if len(items) == count || count < 0 && p.peek() != ',' {
+				break
+			}
+			if err = p.expect(','); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := p.expect('>'); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// parseStructFields parses list of comma-separated members `'name':Type` in angle brackets
+func (p *typeParser) parseStructFields() ([]StructField, error) {
+	if err := p.expect('<'); err != nil {
+		return nil, err
+	}
+	var fields []StructField
+	if p.peek() != '>' {
+		for {
+			name, err := p.quoted('\'')
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expect(':'); err != nil {
+				return nil, err
+			}
+			t, err := p.parseType()
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, StructField{Name: name, T: t})
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+	}
+	if err := p.expect('>'); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,176 @@
+package value
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseType(t *testing.T) {
+	for _, tt := range []struct {
+		s   string
+		exp Type
+	}{
+		{s: "Bool", exp: TypeBool},
+		{s: "Utf8", exp: TypeText},
+		{s: "String", exp: TypeBytes},
+		{s: "Interval64", exp: TypeInterval64},
+		{s: "Void", exp: Void()},
+		{s: "Null", exp: Null()},
+		{s: "EmptyList", exp: EmptyList()},
+		{s: "EmptyDict", exp: EmptyDict()},
+		{s: "Decimal(22,9)", exp: Decimal(22, 9)},
+		{s: "Optional<Int32>", exp: Optional(TypeInt32)},
+		{s: "List<Optional<Uuid>>", exp: List(Optional(TypeUUID))},
+		{s: "Set<Utf8>", exp: Set(TypeText)},
+		{s: "Dict<Utf8,List<Int64>>", exp: Dict(TypeText, List(TypeInt64))},
+		{s: "Tuple<>", exp: Tuple()},
+		{s: "Tuple<Int32,Utf8>", exp: Tuple(TypeInt32, TypeText)},
+		{s: "Struct<>", exp: Struct()},
+		{
+			s: "Struct<'id':Uint64,'name':Optional<Utf8>>",
+			exp: Struct(
+				StructField{Name: "id", T: TypeUint64},
+				StructField{Name: "name", T: Optional(TypeText)},
+			),
+		},
+		{
+			s:   "Variant<'a':Utf8,'b':Int32>",
+			exp: VariantStruct(StructField{Name: "a", T: TypeText}, StructField{Name: "b", T: TypeInt32}),
+		},
+		{s: "Variant<Utf8,Int32>", exp: VariantTuple(TypeText, TypeInt32)},
+		{s: `Tagged<Utf8,"tag\"\x01">`, exp: Tagged("tag\"\x01", TypeText)},
+		{s: "pgint4", exp: Pg(23)},
+		{s: "PgType(100500)", exp: Pg(100500)},
+		{
+			s: " Struct< 'id' : Uint64 , 'tags' : Dict< Utf8 , Decimal( 22 , 9 ) > > ",
+			exp: Struct(
+				StructField{Name: "id", T: TypeUint64},
+				StructField{Name: "tags", T: Dict(TypeText, Decimal(22, 9))},
+			),
+		},
+		{s: "Variant< 'a' : Utf8 >", exp: VariantStruct(StructField{Name: "a", T: TypeText})},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			act, err := ParseType(tt.s)
+			require.NoError(t, err)
+			require.True(t, TypesEqual(tt.exp, act), act.Yql())
+		})
+	}
+}
+
+func TestParseTypeErrors(t *testing.T) {
+	for _, tt := range []struct {
+		s      string
+		offset int
+	}{
+		{s: "", offset: 0},
+		{s: "Unknown", offset: 0},
+		{s: "List<Int32", offset: 10},
+		{s: "List<Int32>>", offset: 11},
+		{s: "List<>", offset: 5},
+		{s: "Optional(Int32)", offset: 8},
+		{s: "Dict<Int32>", offset: 10},
+		{s: "Dict<Int32,Utf8,Bool>", offset: 15},
+		{s: "Tuple<Int32,>", offset: 12},
+		{s: "Struct<'a':Int32,'b'>", offset: 20},
+		{s: "Struct<a:Int32>", offset: 7},
+		{s: "Struct<'a:Int32>", offset: 7},
+		{s: "Variant<>", offset: 0},
+		{s: "Decimal(22)", offset: 10},
+		{s: "Decimal(22,99999999999)", offset: 11},
+		{s: "List< Decimal(36,9)>", offset: 6},
+		{s: "Decimal(0,0)", offset: 0},
+		{s: "Decimal(9,10)", offset: 0},
+		{s: "Tagged<Utf8,tag>", offset: 12},
+		{s: `Tagged<Utf8,"\q">`, offset: 13},
+		{s: "List<Optional<Int32>,Utf8>", offset: 20},
+		{s: "Struct<'a':Lst<Int32>>", offset: 11},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			_, err := ParseType(tt.s)
+			require.Error(t, err)
+			var syntaxErr *TypeSyntaxError
+			require.True(t, errors.As(err, &syntaxErr), err)
+			require.Equal(t, tt.s, syntaxErr.Input)
+			require.Equal(t, tt.offset, syntaxErr.Offset, syntaxErr.Reason)
+		})
+	}
+}
+
+// randomString returns valid UTF-8 string (as names and tags of types in protobuf) with
+// special characters of YQL and JSON syntax
+func randomString(r *rand.Rand) string {
+	runes := []rune("abz09_'\"\\<>,:() \t\n\x00\x7fйфΩ😀")
+	s := make([]rune, r.Intn(8))
+	for i := range s {
+		s[i] = runes[r.Intn(len(runes))]
+	}
+	return string(s)
+}
+
+func randomType(r *rand.Rand, depth int) Type {
+	primitives := []Type{
+		TypeBool, TypeInt8, TypeUint8, TypeInt16, TypeUint16, TypeInt32, TypeUint32, TypeInt64, TypeUint64,
+		TypeFloat, TypeDouble, TypeDate, TypeDatetime, TypeTimestamp, TypeInterval, TypeTzDate,
+		TypeTzDatetime, TypeTzTimestamp, TypeBytes, TypeText, TypeYSON, TypeJSON, TypeUUID, TypeJSONDocument,
+		TypeDyNumber, TypeDate32, TypeDatetime64, TypeTimestamp64, TypeInterval64,
+		Void(), Null(), EmptyList(), EmptyDict(), Pg(23), Pg(25), Pg(100500),
+	}
+	if depth == 0 {
+		return primitives[r.Intn(len(primitives))]
+	}
+	items := func() []Type {
+		items := make([]Type, 1+r.Intn(4))
+		for i := range items {
+			items[i] = randomType(r, depth-1)
+		}
+		return items
+	}
+	fields := func() []StructField {
+		fields := make([]StructField, 1+r.Intn(4))
+		for i := range fields {
+			fields[i] = StructField{Name: randomString(r) + strconv.Itoa(i), T: randomType(r, depth-1)}
+		}
+		return fields
+	}
+	switch r.Intn(11) {
+	case 0:
+		precision := 1 + r.Intn(35)
+		return Decimal(uint32(precision), uint32(r.Intn(precision+1)))
+	case 1:
+		return Optional(randomType(r, depth-1))
+	case 2:
+		return List(randomType(r, depth-1))
+	case 3:
+		return Set(randomType(r, depth-1))
+	case 4:
+		return Dict(randomType(r, depth-1), randomType(r, depth-1))
+	case 5:
+		return Tuple(items()...)
+	case 6:
+		return Struct(fields()...)
+	case 7:
+		return VariantStruct(fields()...)
+	case 8:
+		return VariantTuple(items()...)
+	case 9:
+		return Tagged(randomString(r), randomType(r, depth-1))
+	default:
+		return primitives[r.Intn(len(primitives))]
+	}
+}
+
+func TestParseTypeRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(42)) //nolint:gosec
+	for i := 0; i < 10000; i++ {
+		exp := randomType(r, r.Intn(5))
+		act, err := ParseType(exp.Yql())
+		require.NoError(t, err, exp.Yql())
+		require.True(t, TypesEqual(exp, act), exp.Yql())
+		require.Equal(t, exp.Yql(), act.Yql())
+	}
+}
@@ -0,0 +1,196 @@
+package value
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+var errPgNullValueCast = errors.New("NULL value of pg type can be cast only to *interface{}")
+
+// pgTypeNames are names of well-known PostgreSQL types by OID
+var pgTypeNames = map[uint32]string{
+	16:   "bool",
+	17:   "bytea",
+	20:   "int8",
+	21:   "int2",
+	23:   "int4",
+	25:   "text",
+	26:   "oid",
+	114:  "json",
+	700:  "float4",
+	701:  "float8",
+	1043: "varchar",
+	1082: "date",
+	1083: "time",
+	1114: "timestamp",
+	1184: "timestamptz",
+	1186: "interval",
+	1700: "numeric",
+	2950: "uuid",
+	3802: "jsonb",
+}
+
+// pgType is a PostgreSQL-compatible type identified by OID (such as pgint4)
+type pgType struct {
+	oid    uint32
+	typlen int32
+	typmod int32
+}
+
+// OID returns PostgreSQL object identifier of type
+func (v *pgType) OID() uint32 {
+	return v.oid
+}
+
+// Name returns PostgreSQL name of type or empty string if type OID is not well-known
+func (v *pgType) Name() string {
+	return pgTypeNames[v.oid]
+}
+
+func (v *pgType) String() string {
+	return v.Yql()
+}
+
+func (v *pgType) Yql() string {
+	if name, has := pgTypeNames[v.oid]; has {
+		return "pg" + name
+	}
+	return "PgType(" + strconv.FormatUint(uint64(v.oid), 10) + ")"
+}
+
+func (v *pgType) equalsTo(rhs Type) bool {
+	vv, ok := rhs.(*pgType)
+	if !ok {
+		return false
+	}
+	return v.oid == vv.oid && v.typlen == vv.typlen && v.typmod == vv.typmod
+}
+
+func (v *pgType) toYDB(a *allocator.Allocator) *Ydb.Type {
+	t := a.Type()
+
+	typePg := a.TypePg()
+
+	typePg.PgType = a.Pg()
+
+	typePg.PgType.Oid = v.oid
+	typePg.PgType.Typlen = v.typlen
+	typePg.PgType.Typmod = v.typmod
+
+	t.Type = typePg
+
+	return t
+}
+
+func Pg(oid uint32) *pgType {
+	return &pgType{oid: oid}
+}
+
+// pgValue is a value of pg type in PostgreSQL text representation
+type pgValue struct {
+	t    *pgType
+	text string
+	null bool
+}
+
+// Text returns PostgreSQL text representation of value
+func (v *pgValue) Text() string {
+	return v.text
+}
+
+// IsNull reports whether value is a NULL of pg type
+func (v *pgValue) IsNull() bool {
+	return v.null
+}
+
+func (v *pgValue) castTo(dst interface{}) error {
+	if v.null {
+		if vv, ok := dst.(*interface{}); ok {
+			*vv = nil
+			return nil
+		}
+		return castError(v.Yql(), v.Type(), dst, errPgNullValueCast)
+	}
+	switch vv := dst.(type) {
+	case *string:
+		*vv = v.text
+		return nil
+	case *[]byte:
+		*vv = []byte(v.text)
+		return nil
+	case *interface{}:
+		*vv = v.text
+		return nil
+	default:
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%s' (type '%s') to '%T' destination", v.Yql(), v.t.Yql(), vv))
+	}
+}
+
+func (v *pgValue) Yql() string {
+	if v.null {
+		return "PgCast(NULL," + v.t.Yql() + ")"
+	}
+	return fmt.Sprintf("PgConst(%s,%s)", quoteYql(v.text), v.t.Yql())
+}
+
+func (v *pgValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
+func (v *pgValue) Type() Type {
+	return v.t
+}
+
+func (v *pgValue) toYDB(a *allocator.Allocator) *Ydb.Value {
+	if v.null {
+		vvv := a.Value()
+		vvv.Value = a.NullFlag()
+		return vvv
+	}
+
+	vv := a.Text()
+	vv.TextValue = v.text
+
+	vvv := a.Value()
+	vvv.Value = vv
+
+	return vvv
+}
+
+// PgValue makes value of pg type with given OID from PostgreSQL text representation
+func PgValue(oid uint32, text string) *pgValue {
+	return &pgValue{
+		t:    Pg(oid),
+		text: text,
+	}
+}
+
+// PgNullValue makes NULL value of pg type with given OID
+func PgNullValue(oid uint32) *pgValue {
+	return &pgValue{
+		t:    Pg(oid),
+		null: true,
+	}
+}
+
+func pgValueFromYDB(t *pgType, v *Ydb.Value) (*pgValue, error) {
+	switch vv := v.GetValue().(type) {
+	case *Ydb.Value_TextValue:
+		return &pgValue{t: t, text: vv.TextValue}, nil
+	case *Ydb.Value_NullFlagValue:
+		return &pgValue{t: t, null: true}, nil
+	default:
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: value of type %s is not a text or NULL: %T",
+			errMalformedValue, t.Yql(), v.GetValue(),
+		))
+	}
+}
@@ -0,0 +1,139 @@
+package value
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+var (
+	errUnknownType  = errors.New("unknown primitive type")
+	errRawValueCast = errors.New("value of unknown type can be cast only to serialized protobuf")
+)
+
+// unknownType is a primitive type which is not known by SDK (for example, type which is added
+// in newer version of YDB). unknownType keeps original protobuf type
+type unknownType struct {
+	t *Ydb.Type
+}
+
+func (v *unknownType) String() string {
+	return v.Yql()
+}
+
+func (v *unknownType) Yql() string {
+	return fmt.Sprintf("Unknown(%d)", v.t.GetTypeId())
+}
+
+func (v *unknownType) equalsTo(rhs Type) bool {
+	vv, ok := rhs.(*unknownType)
+	if !ok {
+		return false
+	}
+	return proto.Equal(v.t, vv.t)
+}
+
+func (v *unknownType) toYDB(*allocator.Allocator) *Ydb.Type {
+	return v.t
+}
+
+// rawValue is a value of unknown type. rawValue keeps original protobuf type and value,
+// so rows with such values are decodable and such values are sent to YDB as is
+type rawValue struct {
+	t *unknownType
+	v *Ydb.Value
+}
+
+// castTo casts raw value only to *[]byte as serialized Ydb.TypedValue
+func (v *rawValue) castTo(dst interface{}) error {
+	switch vv := dst.(type) {
+	case *[]byte:
+		bytes, err := proto.Marshal(&Ydb.TypedValue{Type: v.t.t, Value: v.v})
+		if err != nil {
+			return xerrors.WithStackTrace(err)
+		}
+		*vv = bytes
+		return nil
+	default:
+		return castError(v.Yql(), v.Type(), dst, errRawValueCast)
+	}
+}
+
+func (v *rawValue) Yql() string {
+	return v.t.Yql() + "(...)"
+}
+
+func (v *rawValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
+func (v *rawValue) Type() Type {
+	return v.t
+}
+
+func (v *rawValue) toYDB(*allocator.Allocator) *Ydb.Value {
+	return v.v
+}
+
+// CheckKnownTypes returns error if type t or types of its items are not known by SDK
+// (values of unknown primitive types are decoded as opaque raw values)
+//
+//nolint:gocyclo
+func CheckKnownTypes(t *Ydb.Type) error {
+	switch x := t.GetType().(type) {
+	case *Ydb.Type_TypeId:
+		if _, err := primitiveTypeFromYDB(x.TypeId); err != nil {
+			return xerrors.WithStackTrace(fmt.Errorf("%w: %d", errUnknownType, x.TypeId))
+		}
+		return nil
+	case *Ydb.Type_OptionalType:
+		return CheckKnownTypes(x.OptionalType.GetItem())
+	case *Ydb.Type_TaggedType:
+		return CheckKnownTypes(x.TaggedType.GetType())
+	case *Ydb.Type_ListType:
+		return CheckKnownTypes(x.ListType.GetItem())
+	case *Ydb.Type_TupleType:
+		return checkKnownTypes(x.TupleType.GetElements())
+	case *Ydb.Type_StructType:
+		return checkKnownMembers(x.StructType.GetMembers())
+	case *Ydb.Type_DictType:
+		if err := CheckKnownTypes(x.DictType.GetKey()); err != nil {
+			return err
+		}
+		return CheckKnownTypes(x.DictType.GetPayload())
+	case *Ydb.Type_VariantType:
+		switch items := x.VariantType.GetType().(type) {
+		case *Ydb.VariantType_TupleItems:
+			return checkKnownTypes(items.TupleItems.GetElements())
+		case *Ydb.VariantType_StructItems:
+			return checkKnownMembers(items.StructItems.GetMembers())
+		default:
+			return nil
+		}
+	default:
+		return nil
+	}
+}
+
+func checkKnownTypes(ts []*Ydb.Type) error {
+	for _, t := range ts {
+		if err := CheckKnownTypes(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkKnownMembers(ms []*Ydb.StructMember) error {
+	for _, m := range ms {
+		if err := CheckKnownTypes(m.GetType()); err != nil {
+			return xerrors.WithStackTrace(fmt.Errorf("member '%s': %w", m.GetName(), err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package value
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+)
+
+// futureTypeID is a fabricated id of primitive type which is unknown for SDK
+const futureTypeID = Ydb.Type_PrimitiveTypeId(0x7fff)
+
+func TestRawValue(t *testing.T) {
+	futureType := &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: futureTypeID}}
+	futureValue := &Ydb.Value{Value: &Ydb.Value_BytesValue{BytesValue: []byte{1, 2, 3}}}
+
+	v, err := FromYDBWithError(futureType, futureValue)
+	require.NoError(t, err)
+	require.Equal(t, "Unknown(32767)", v.Type().Yql())
+	require.Equal(t, "Unknown(32767)(...)", v.Yql())
+
+	t.Run("CastToBytes", func(t *testing.T) {
+		var dst []byte
+		require.NoError(t, Cast(v, &dst))
+		var tv Ydb.TypedValue
+		require.NoError(t, proto.Unmarshal(dst, &tv))
+		require.True(t, proto.Equal(futureType, tv.GetType()))
+		require.True(t, proto.Equal(futureValue, tv.GetValue()))
+	})
+	t.Run("CastToOther", func(t *testing.T) {
+		var s string
+		require.ErrorIs(t, Cast(v, &s), errRawValueCast)
+		var i interface{}
+		require.ErrorIs(t, Cast(v, &i), errRawValueCast)
+	})
+	t.Run("ToYDB", func(t *testing.T) {
+		a := allocator.New()
+		defer a.Free()
+		tv := ToYDB(v, a)
+		require.True(t, proto.Equal(futureType, tv.GetType()))
+		require.True(t, proto.Equal(futureValue, tv.GetValue()))
+	})
+	t.Run("Nested", func(t *testing.T) {
+		structType := &Ydb.Type{Type: &Ydb.Type_StructType{StructType: &Ydb.StructType{
+			Members: []*Ydb.StructMember{
+				{Name: "future", Type: &Ydb.Type{Type: &Ydb.Type_OptionalType{
+					OptionalType: &Ydb.OptionalType{Item: futureType},
+				}}},
+				{Name: "id", Type: TypeUint64.toYDB(nil)},
+			},
+		}}}
+		v, err := FromYDBWithError(structType, &Ydb.Value{Items: []*Ydb.Value{
+			futureValue,
+			{Value: &Ydb.Value_Uint64Value{Uint64Value: 42}},
+		}})
+		require.NoError(t, err)
+		require.Equal(t, "Struct<'future':Optional<Unknown(32767)>,'id':Uint64>", v.Type().Yql())
+		fields := v.(*structValue).StructFields()
+		require.Equal(t, Uint64Value(42), fields["id"])
+
+		err = CheckKnownTypes(structType)
+		require.ErrorIs(t, err, errUnknownType)
+		require.ErrorContains(t, err, "member 'future'")
+	})
+	t.Run("CheckKnownTypes", func(t *testing.T) {
+		require.ErrorIs(t, CheckKnownTypes(futureType), errUnknownType)
+		a := allocator.New()
+		defer a.Free()
+		require.NoError(t, CheckKnownTypes(Dict(TypeText, List(Optional(TypeUUID))).toYDB(a)))
+	})
+}
+
+func TestTypeFromYDBUnspecifiedTypeID(t *testing.T) {
+	_, err := TypeFromYDBWithError(&Ydb.Type{Type: &Ydb.Type_TypeId{
+		TypeId: Ydb.Type_PRIMITIVE_TYPE_ID_UNSPECIFIED,
+	}})
+	require.Error(t, err)
+}
@@ -0,0 +1,77 @@
+package value
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+)
+
+// secretYql is a representation of secret values in YQL texts, logs and errors
+const secretYql = "***"
+
+var errSecretValueCast = errors.New("secret value cannot be cast (details are hidden)")
+
+// secretValue is a value which is sent to YDB as is, but never discloses
+// its content in string representations (such as dumps of query parameters)
+type secretValue struct {
+	// value is returned by closure, because fmt prints content of nested values
+	// (for example, of items of containers) without calling their String methods
+	value func() Value
+}
+
+// SecretValue wraps v into secret value
+func SecretValue(v Value) Value {
+	if _, ok := v.(*secretValue); ok {
+		return v
+	}
+	return &secretValue{value: func() Value { return v }}
+}
+
+// unwrapSecret returns inner value of secret value or v as is
+func unwrapSecret(v Value) Value {
+	if s, ok := v.(*secretValue); ok {
+		return s.value()
+	}
+	return v
+}
+
+// unredactedYql returns YQL representation of v with content of secret value v.
+// unredactedYql must be used only for ordering and equality of values
+func unredactedYql(v Value) string {
+	return unwrapSecret(v).Yql()
+}
+
+func (v *secretValue) castTo(dst interface{}) error {
+	if err := v.value().castTo(dst); err != nil {
+		// error of inner value may contain its representation
+		return castError(secretYql, v.Type(), dst, errSecretValueCast)
+	}
+	return nil
+}
+
+func (v *secretValue) Yql() string {
+	return secretYql
+}
+
+func (v *secretValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
+func (v *secretValue) String() string {
+	return secretYql
+}
+
+func (v *secretValue) GoString() string {
+	return secretYql
+}
+
+func (v *secretValue) Type() Type {
+	return v.value().Type()
+}
+
+func (v *secretValue) toYDB(a *allocator.Allocator) *Ydb.Value {
+	return v.value().toYDB(a)
+}
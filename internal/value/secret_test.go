@@ -0,0 +1,164 @@
+package value
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+)
+
+func TestSecretValueRedaction(t *testing.T) {
+	const password = "qwerty"
+	for _, tt := range []struct {
+		name string
+		v    Value
+		yql  string
+	}{
+		{
+			name: "Text",
+			v:    SecretValue(TextValue(password)),
+			yql:  "***",
+		},
+		{
+			name: "Optional",
+			v:    OptionalValue(SecretValue(TextValue(password))),
+			yql:  "Just(***)",
+		},
+		{
+			name: "List",
+			v:    ListValue(SecretValue(TextValue(password)), SecretValue(TextValue(password))),
+			yql:  "[***,***]",
+		},
+		{
+			name: "Tuple",
+			v:    TupleValue(TextValue("admin"), SecretValue(TextValue(password))),
+			yql:  `("admin"u,***)`,
+		},
+		{
+			name: "Struct",
+			v: StructValue(
+				StructValueField{Name: "login", V: TextValue("admin")},
+				StructValueField{Name: "password", V: SecretValue(TextValue(password))},
+			),
+			yql: "<|`login`:\"admin\"u,`password`:***|>",
+		},
+		{
+			name: "Dict",
+			v: DictValue(
+				DictValueField{K: TextValue("admin"), V: SecretValue(TextValue(password))},
+			),
+			yql: `{"admin"u:***}`,
+		},
+		{
+			name: "SecretContainer",
+			v:    SecretValue(ListValue(TextValue(password))),
+			yql:  "***",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.yql, tt.v.Yql())
+			for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+				require.NotContains(t, fmt.Sprintf(format, tt.v), password, format)
+			}
+			var dst int64
+			err := tt.v.castTo(&dst)
+			require.Error(t, err)
+			require.NotContains(t, err.Error(), password)
+		})
+	}
+}
+
+func TestSecretValueWire(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		secret Value
+		plain  Value
+	}{
+		{
+			name:   "Text",
+			secret: SecretValue(TextValue("qwerty")),
+			plain:  TextValue("qwerty"),
+		},
+		{
+			name:   "List",
+			secret: SecretValue(ListValue(Uint64Value(1), Uint64Value(2))),
+			plain:  ListValue(Uint64Value(1), Uint64Value(2)),
+		},
+		{
+			name:   "ListItems",
+			secret: ListValue(SecretValue(BytesValue([]byte("t0k3n"))), BytesValue([]byte("t0k3n"))),
+			plain:  ListValue(BytesValue([]byte("t0k3n")), BytesValue([]byte("t0k3n"))),
+		},
+		{
+			name:   "OptionalOfSecretOptional",
+			secret: OptionalValue(SecretValue(OptionalValue(TextValue("qwerty")))),
+			plain:  OptionalValue(OptionalValue(TextValue("qwerty"))),
+		},
+		{
+			name:   "OptionalOfSecretNull",
+			secret: OptionalValue(SecretValue(NullValue(TypeText))),
+			plain:  OptionalValue(NullValue(TypeText)),
+		},
+		{
+			name: "Struct",
+			secret: StructValue(
+				StructValueField{Name: "password", V: SecretValue(TextValue("qwerty"))},
+			),
+			plain: StructValue(
+				StructValueField{Name: "password", V: TextValue("qwerty")},
+			),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a := allocator.New()
+			defer a.Free()
+			require.True(t, TypesEqual(tt.plain.Type(), tt.secret.Type()))
+			require.True(t, proto.Equal(ToYDB(tt.plain, a), ToYDB(tt.secret, a)))
+		})
+	}
+}
+
+func TestSecretValueAsInner(t *testing.T) {
+	t.Run("Cast", func(t *testing.T) {
+		var dst string
+		require.NoError(t, Cast(SecretValue(TextValue("qwerty")), &dst))
+		require.Equal(t, "qwerty", dst)
+	})
+	t.Run("NativeValue", func(t *testing.T) {
+		native, err := NativeValue(SecretValue(Int32Value(42)))
+		require.NoError(t, err)
+		require.Equal(t, "***", native)
+		native, err = NativeValue(StructValue(
+			StructValueField{Name: "login", V: TextValue("user")},
+			StructValueField{Name: "password", V: SecretValue(TextValue("qwerty"))},
+		))
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"login": "user", "password": "***"}, native)
+	})
+	t.Run("SecretOfSecret", func(t *testing.T) {
+		v := SecretValue(TextValue("qwerty"))
+		require.Same(t, v, SecretValue(v))
+	})
+	t.Run("DictKeys", func(t *testing.T) {
+		d := DictValue(
+			DictValueField{K: SecretValue(TextValue("b")), V: Uint64Value(2)},
+			DictValueField{K: TextValue("a"), V: Uint64Value(1)},
+		)
+		require.Equal(t, `{"a"u:1ul,***:2ul}`, d.Yql())
+		v, ok := d.Get(TextValue("b"))
+		require.True(t, ok)
+		require.Equal(t, "2ul", v.Yql())
+		v, ok = d.Get(SecretValue(TextValue("a")))
+		require.True(t, ok)
+		require.Equal(t, "1ul", v.Yql())
+		_, ok = d.Get(SecretValue(TextValue("c")))
+		require.False(t, ok)
+	})
+	t.Run("SetItems", func(t *testing.T) {
+		s := SetValue(SecretValue(TextValue("b")), TextValue("a"), TextValue("c"))
+		require.Equal(t, `{"a"u,***,"c"u}`, s.Yql())
+	})
+}
@@ -0,0 +1,293 @@
+package value
+
+import (
+	"encoding/binary"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// sizeOfPrimitive is an approximate size of fixed-width values (numbers, dates, etc.)
+	sizeOfPrimitive = 8
+
+	// sizeOfItem is an approximate overhead of item of container value
+	// (such as protobuf tag and length of nested message)
+	sizeOfItem = 4
+)
+
+// sizeHint returns approximate size of value v in bytes.
+//
+// sizeHint does not allocate and is intended for enforcement of memory limits:
+// sizes of strings are taken as is and every item of containers adds fixed overhead
+//
+//nolint:gocyclo
+func sizeHint(v Value) int {
+	switch vv := v.(type) {
+	case textValue:
+		return len(vv)
+	case bytesValue:
+		return len(vv)
+	case jsonValue:
+		return len(vv)
+	case jsonDocumentValue:
+		return len(vv)
+	case ysonValue:
+		return len(vv)
+	case dyNumberValue:
+		return len(vv)
+	case tzDateValue:
+		return len(vv)
+	case tzDatetimeValue:
+		return len(vv)
+	case tzTimestampValue:
+		return len(vv)
+	case *uuidValue, *decimalValue:
+		return 2 * sizeOfPrimitive
+	case voidValue:
+		return 0
+	case *optionalValue:
+		if vv.value == nil {
+			return 1
+		}
+		return sizeOfItem + sizeHint(vv.value)
+	case *taggedValue:
+		return sizeHint(vv.value)
+	case *pgValue:
+		return len(vv.text)
+	case *listValue:
+		return itemsSizeHint(vv.items)
+	case *setValue:
+		return itemsSizeHint(vv.items)
+	case *tupleValue:
+		return itemsSizeHint(vv.items)
+	case *structValue:
+		size := 0
+		for i := range vv.fields {
+			size += sizeOfItem + sizeHint(vv.fields[i].V)
+		}
+		return size
+	case *dictValue:
+		size := 0
+		for i := range vv.values {
+			size += 2*sizeOfItem + sizeHint(vv.values[i].K) + sizeHint(vv.values[i].V)
+		}
+		return size
+	case *variantValue:
+		return sizeOfItem + sizeHint(vv.value)
+	case *secretValue:
+		return sizeHint(vv.value())
+	default:
+		return sizeOfPrimitive
+	}
+}
+
+func itemsSizeHint(items []Value) int {
+	size := 0
+	for _, item := range items {
+		size += sizeOfItem + sizeHint(item)
+	}
+	return size
+}
+
+// EstimatedSize returns approximate size in bytes of value v serialized with its type as
+// Ydb.TypedValue (such as proto.Size of ToYDB result).
+//
+// EstimatedSize walks value once and doesn't allocate protobuf messages, so it is intended
+// for splitting of big values (for example, lists of rows for bulk upsert) into batches
+// which fit into limits of gRPC messages
+func EstimatedSize(v Value) int {
+	return sizeOfBytesField(1, typeWireSize(v.Type())) + sizeOfBytesField(2, valueWireSize(v))
+}
+
+// sizeOfBytesField returns size of length-delimited field with number num and payload of size n
+func sizeOfBytesField(num protowire.Number, n int) int {
+	return protowire.SizeTag(num) + protowire.SizeBytes(n)
+}
+
+// sizeOfHigh128 returns size of high_128 field of Ydb.Value, which is omitted if zero
+func sizeOfHigh128(v [16]byte) int {
+	if binary.BigEndian.Uint64(v[0:8]) == 0 {
+		return 0
+	}
+	return protowire.SizeTag(16) + protowire.SizeFixed64()
+}
+
+// valueWireSize returns size of value v serialized as Ydb.Value
+//
+//nolint:gocyclo,funlen
+func valueWireSize(v Value) int {
+	const (
+		sizeOfFixed32 = 1 + 4
+		sizeOfFixed64 = 1 + 8
+		sizeOfFlag    = 1 + 1
+	)
+	switch vv := v.(type) {
+	case boolValue, voidValue:
+		return sizeOfFlag
+	case int8Value, int16Value, int32Value, uint8Value, uint16Value, uint32Value,
+		dateValue, date32Value, datetimeValue, *floatValue:
+		return sizeOfFixed32
+	case int64Value, uint64Value, timestampValue, intervalValue,
+		datetime64Value, timestamp64Value, interval64Value, *doubleValue:
+		return sizeOfFixed64
+	case textValue:
+		return sizeOfBytesField(9, len(vv))
+	case jsonValue:
+		return sizeOfBytesField(9, len(vv))
+	case jsonDocumentValue:
+		return sizeOfBytesField(9, len(vv))
+	case dyNumberValue:
+		return sizeOfBytesField(9, len(vv))
+	case tzDateValue:
+		return sizeOfBytesField(9, len(vv))
+	case tzDatetimeValue:
+		return sizeOfBytesField(9, len(vv))
+	case tzTimestampValue:
+		return sizeOfBytesField(9, len(vv))
+	case bytesValue:
+		return sizeOfBytesField(8, len(vv))
+	case ysonValue:
+		return sizeOfBytesField(8, len(vv))
+	case *uuidValue:
+		return sizeOfFixed64 + sizeOfHigh128(vv.value)
+	case *decimalValue:
+		return sizeOfFixed64 + sizeOfHigh128(vv.value)
+	case *pgValue:
+		if vv.null {
+			return sizeOfFlag
+		}
+		return sizeOfBytesField(9, len(vv.text))
+	case *optionalValue:
+		switch unwrapSecret(vv.value).(type) {
+		case nil:
+			return sizeOfFlag
+		case *optionalValue:
+			return sizeOfBytesField(11, valueWireSize(vv.value))
+		default:
+			return valueWireSize(vv.value)
+		}
+	case *taggedValue:
+		return valueWireSize(vv.value)
+	case *listValue:
+		return itemsWireSize(vv.items)
+	case *tupleValue:
+		return itemsWireSize(vv.items)
+	case *structValue:
+		size := 0
+		for i := range vv.fields {
+			size += sizeOfBytesField(12, valueWireSize(vv.fields[i].V))
+		}
+		return size
+	case *setValue:
+		size := 0
+		for _, item := range vv.items {
+			size += sizeOfBytesField(13, sizeOfBytesField(1, valueWireSize(item))+sizeOfBytesField(2, sizeOfFlag))
+		}
+		return size
+	case *dictValue:
+		size := 0
+		for i := range vv.values {
+			size += sizeOfBytesField(13,
+				sizeOfBytesField(1, valueWireSize(vv.values[i].K))+sizeOfBytesField(2, valueWireSize(vv.values[i].V)),
+			)
+		}
+		return size
+	case *variantValue:
+		size := sizeOfBytesField(11, valueWireSize(vv.value))
+		if vv.idx != 0 {
+			size += protowire.SizeTag(14) + protowire.SizeVarint(uint64(vv.idx))
+		}
+		return size
+	case *secretValue:
+		return valueWireSize(vv.value())
+	case *rawValue:
+		return proto.Size(vv.v)
+	default:
+		return sizeOfFixed64
+	}
+}
+
+func itemsWireSize(items []Value) int {
+	size := 0
+	for _, item := range items {
+		size += sizeOfBytesField(12, valueWireSize(item))
+	}
+	return size
+}
+
+// typeWireSize returns size of type t serialized as Ydb.Type
+//
+//nolint:gocyclo
+func typeWireSize(t Type) int {
+	// void, null, empty list and empty dict types are NULL flags with field numbers from 201 to 204
+	const sizeOfFlag = 2 + 1
+	switch tt := t.(type) {
+	case PrimitiveType:
+		return protowire.SizeTag(1) + protowire.SizeVarint(uint64(primitive[tt].GetTypeId()))
+	case *DecimalType:
+		return sizeOfBytesField(2, sizeOfVarintField(1, uint64(tt.Precision))+sizeOfVarintField(2, uint64(tt.Scale)))
+	case optionalType:
+		return sizeOfBytesField(101, sizeOfBytesField(1, typeWireSize(tt.innerType)))
+	case *listType:
+		return sizeOfBytesField(102, sizeOfBytesField(1, typeWireSize(tt.itemType)))
+	case *TupleType:
+		return sizeOfBytesField(103, tupleTypeWireSize(tt))
+	case *StructType:
+		return sizeOfBytesField(104, structTypeWireSize(tt))
+	case *dictType:
+		return sizeOfBytesField(105,
+			sizeOfBytesField(1, typeWireSize(tt.keyType))+sizeOfBytesField(2, typeWireSize(tt.valueType)),
+		)
+	case *setType:
+		return sizeOfBytesField(105, sizeOfBytesField(1, typeWireSize(tt.itemType))+sizeOfBytesField(2, sizeOfFlag))
+	case *variantTupleType:
+		return sizeOfBytesField(106, sizeOfBytesField(1, tupleTypeWireSize(tt.TupleType)))
+	case *variantStructType:
+		return sizeOfBytesField(106, sizeOfBytesField(2, structTypeWireSize(tt.StructType)))
+	case *taggedType:
+		return sizeOfBytesField(107, sizeOfStringField(1, tt.tag)+sizeOfBytesField(2, typeWireSize(tt.innerType)))
+	case *pgType:
+		return sizeOfBytesField(205,
+			sizeOfVarintField(1, uint64(tt.oid))+
+				sizeOfVarintField(2, uint64(int64(tt.typlen)))+
+				sizeOfVarintField(3, uint64(int64(tt.typmod))),
+		)
+	case *unknownType:
+		return proto.Size(tt.t)
+	default:
+		return sizeOfFlag
+	}
+}
+
+func tupleTypeWireSize(t *TupleType) int {
+	size := 0
+	for _, item := range t.items {
+		size += sizeOfBytesField(1, typeWireSize(item))
+	}
+	return size
+}
+
+func structTypeWireSize(t *StructType) int {
+	size := 0
+	for i := range t.fields {
+		size += sizeOfBytesField(1, sizeOfStringField(1, t.fields[i].Name)+sizeOfBytesField(2, typeWireSize(t.fields[i].T)))
+	}
+	return size
+}
+
+// sizeOfVarintField returns size of varint field, which is omitted if zero
+func sizeOfVarintField(num protowire.Number, v uint64) int {
+	if v == 0 {
+		return 0
+	}
+	return protowire.SizeTag(num) + protowire.SizeVarint(v)
+}
+
+// sizeOfStringField returns size of string field, which is omitted if empty
+func sizeOfStringField(num protowire.Number, s string) int {
+	if s == "" {
+		return 0
+	}
+	return sizeOfBytesField(num, len(s))
+}
@@ -0,0 +1,44 @@
+package value
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+)
+
+func TestEstimatedSize(t *testing.T) {
+	values := []struct {
+		name string
+		v    Value
+	}{
+		{name: "NegativeInt32", v: Int32Value(-1)},
+		{name: "Secret", v: SecretValue(TextValue("password"))},
+		{name: "StructList", v: ListValue(StructValue(
+			StructValueField{Name: "id", V: Uint64Value(1)},
+			StructValueField{Name: "payload", V: OptionalValue(BytesValue(make([]byte, 1000)))},
+		))},
+		{name: "Pg", v: OptionalValue(PgValue(23, "42"))},
+		{name: "Decimal", v: DecimalValueFromBigInt(big.NewInt(-1), 22, 9)},
+	}
+	for _, c := range wireCompatCorpus() {
+		values = append(values, struct {
+			name string
+			v    Value
+		}{name: c.name, v: c.value})
+	}
+	for _, tt := range values {
+		t.Run(tt.name, func(t *testing.T) {
+			a := allocator.New()
+			defer a.Free()
+			expected := proto.Size(ToYDB(tt.v, a))
+			require.InEpsilon(t, expected, EstimatedSize(tt.v), 0.1)
+			require.Zero(t, testing.AllocsPerRun(10, func() {
+				_ = EstimatedSize(tt.v)
+			}))
+		})
+	}
+}
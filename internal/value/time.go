@@ -1,12 +1,18 @@
 package value
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xstring"
 )
 
 const InfiniteDuration = time.Duration(math.MaxInt64)
@@ -26,18 +32,278 @@ const (
 	LayoutTzTimestamp = "2006-01-02T15:04:05.000000"
 )
 
+// layoutTzTimestampParse parses TzTimestamp wire strings with fractional seconds of
+// any length (or without them), LayoutTzTimestamp requires exactly 6 digits of them
+const layoutTzTimestampParse = "2006-01-02T15:04:05.999999"
+
 var epoch = time.Unix(0, 0)
 
+// Bounds of Date, Datetime and Timestamp values supported by YDB:
+// from 1970-01-01 (inclusive) to 2106-01-01 (exclusive)
+const (
+	maxDate      = 49673 - 1
+	maxDatetime  = (maxDate+1)*int64(secondsPerDay) - 1
+	maxTimestamp = (maxDatetime+1)*1e6 - 1
+)
+
+// Bounds of Date32, Datetime64, Timestamp64 and Interval64 values supported by YDB:
+// from -144168-01-01 (inclusive) to 148108-01-01 (exclusive)
+const (
+	minDate32      = -53375809
+	maxDate32      = 53375807
+	minDatetime64  = minDate32 * int64(secondsPerDay)
+	maxDatetime64  = (maxDate32+1)*int64(secondsPerDay) - 1
+	minTimestamp64 = minDatetime64 * 1e6
+	maxTimestamp64 = (maxDatetime64+1)*1e6 - 1
+	maxInterval64  = maxTimestamp64 - minTimestamp64
+)
+
+func timeRangeError(t time.Time, yqlType string, layout string, minTime, maxTime time.Time) error {
+	return xerrors.WithStackTrace(fmt.Errorf("%w: time %s is out of range [%s, %s] of %s",
+		errValueOutOfRange, t.Format(time.RFC3339Nano),
+		minTime.UTC().Format(layout), maxTime.UTC().Format(layout), yqlType,
+	))
+}
+
+// Bounds of intervals which are representable as time.Duration
+const (
+	maxDurationMicroseconds = math.MaxInt64 / int64(time.Microsecond)
+	minDurationMicroseconds = math.MinInt64 / int64(time.Microsecond)
+)
+
+var (
+	errMalformedISO8601 = errors.New("malformed ISO 8601 duration")
+	errTimezone         = errors.New("timezone has no IANA name")
+)
+
+const (
+	usPerSecond = uint64(time.Second / time.Microsecond)
+	usPerMinute = secondsPerMinute * usPerSecond
+	usPerHour   = secondsPerHour * usPerSecond
+	usPerDay    = secondsPerDay * usPerSecond
+	usPerWeek   = 7 * usPerDay
+)
+
+// IntervalToISO8601 returns ISO 8601 duration (such as `-P1DT2H3M4.5S`) of interval
+// with given microseconds. Zero interval is formatted as `PT0S`
+func IntervalToISO8601(v int64) string {
+	if v == 0 {
+		return "PT0S"
+	}
+	buffer := xstring.Buffer()
+	defer buffer.Free()
+	// interval is formatted in microseconds because it may exceed time.Duration range
+	us := uint64(v)
+	if v < 0 {
+		buffer.WriteByte('-')
+		us = -us
+	}
+	buffer.WriteByte('P')
+	if days := us / usPerDay; days > 0 {
+		us -= days * usPerDay
+		buffer.WriteString(strconv.FormatUint(days, 10))
+		buffer.WriteByte('D')
+	}
+	if us == 0 {
+		return buffer.String()
+	}
+	buffer.WriteByte('T')
+	if hours := us / usPerHour; hours > 0 {
+		us -= hours * usPerHour
+		buffer.WriteString(strconv.FormatUint(hours, 10))
+		buffer.WriteByte('H')
+	}
+	if minutes := us / usPerMinute; minutes > 0 {
+		us -= minutes * usPerMinute
+		buffer.WriteString(strconv.FormatUint(minutes, 10))
+		buffer.WriteByte('M')
+	}
+	if us > 0 {
+		buffer.WriteString(strconv.FormatUint(us/usPerSecond, 10))
+		if fraction := us % usPerSecond; fraction > 0 {
+			digits := strconv.FormatUint(fraction+usPerSecond, 10)[1:]
+			buffer.WriteByte('.')
+			buffer.WriteString(strings.TrimRight(digits, "0"))
+		}
+		buffer.WriteByte('S')
+	}
+	return buffer.String()
+}
+
+// ISO8601ToInterval returns microseconds of ISO 8601 duration s in format of IntervalToISO8601.
+// Weeks (`P2W`) and leading plus sign are also accepted, years and months are not supported
+// because they have no fixed length. Fractional part is allowed only for seconds and must
+// have at most 6 digits
+//
+//nolint:funlen,gocyclo
+func ISO8601ToInterval(s string) (int64, error) {
+	malformed := func(reason string) error {
+		return xerrors.WithStackTrace(fmt.Errorf("%w %q: %s", errMalformedISO8601, s, reason))
+	}
+	rest := s
+	negative := false
+	if rest != "" && (rest[0] == '-' || rest[0] == '+') {
+		negative = rest[0] == '-'
+		rest = rest[1:]
+	}
+	if rest == "" || rest[0] != 'P' {
+		return 0, malformed("expected 'P' designator")
+	}
+	rest = rest[1:]
+	var (
+		us         uint64
+		inTime     bool
+		components int
+		// units are ordered descending, so each designator must follow the previous ones
+		units = "WDHMS"
+	)
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime {
+				return 0, malformed("duplicated 'T' designator")
+			}
+			inTime = true
+			rest = rest[1:]
+			if rest == "" {
+				return 0, malformed("no time components after 'T' designator")
+			}
+			continue
+		}
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		if i == 0 {
+			return 0, malformed("expected number")
+		}
+		n, err := strconv.ParseUint(rest[:i], 10, 64)
+		if err != nil {
+			return 0, malformed("number is out of range")
+		}
+		var fraction uint64
+		if i < len(rest) && rest[i] == '.' {
+			j := i + 1
+			for j < len(rest) && rest[j] >= '0' && rest[j] <= '9' {
+				j++
+			}
+			digits := rest[i+1 : j]
+			if digits == "" || len(digits) > 6 {
+				return 0, malformed("fraction of seconds must have from 1 to 6 digits")
+			}
+			fraction, _ = strconv.ParseUint(digits+strings.Repeat("0", 6-len(digits)), 10, 64)
+			i = j
+			if i == len(rest) || rest[i] != 'S' {
+				return 0, malformed("fraction is allowed only for seconds")
+			}
+		}
+		if i == len(rest) {
+			return 0, malformed("expected designator after number")
+		}
+		designator := rest[i]
+		rest = rest[i+1:]
+		idx := strings.IndexByte(units, designator)
+		if idx < 0 || (designator == 'W' || designator == 'D') == inTime {
+			return 0, malformed(fmt.Sprintf("unexpected designator '%c'", designator))
+		}
+		units = units[idx+1:]
+		var unit uint64
+		switch designator {
+		case 'W':
+			unit = usPerWeek
+		case 'D':
+			unit = usPerDay
+		case 'H':
+			unit = usPerHour
+		case 'M':
+			unit = usPerMinute
+		case 'S':
+			unit = usPerSecond
+		}
+		if n > (math.MaxUint64-fraction)/unit {
+			return 0, malformed("value is out of range")
+		}
+		part := n*unit + fraction
+		if us > math.MaxUint64-part {
+			return 0, malformed("value is out of range")
+		}
+		us += part
+		components++
+	}
+	if components == 0 {
+		return 0, malformed("no components")
+	}
+	if negative {
+		if us > uint64(math.MaxInt64)+1 {
+			return 0, malformed("value is out of range")
+		}
+		return int64(-us), nil
+	}
+	if us > math.MaxInt64 {
+		return 0, malformed("value is out of range")
+	}
+	return int64(us), nil
+}
+
 // IntervalToDuration returns time.Duration from given microseconds
+//
+// Intervals out of time.Duration range (about ±292 years) are saturated to
+// minimal or maximal time.Duration. Use IntervalToDurationChecked for detect overflow.
 func IntervalToDuration(n int64) time.Duration {
-	return time.Duration(n) * time.Microsecond
+	switch {
+	case n > maxDurationMicroseconds:
+		return time.Duration(math.MaxInt64)
+	case n < minDurationMicroseconds:
+		return time.Duration(math.MinInt64)
+	default:
+		return time.Duration(n) * time.Microsecond
+	}
+}
+
+// IntervalToDurationChecked returns time.Duration from given microseconds
+// or error if interval is out of time.Duration range
+func IntervalToDurationChecked(n int64) (time.Duration, error) {
+	if n > maxDurationMicroseconds || n < minDurationMicroseconds {
+		return 0, xerrors.WithStackTrace(fmt.Errorf("interval of %d microseconds overflows time.Duration: %w",
+			n, errValueOutOfRange,
+		))
+	}
+	return time.Duration(n) * time.Microsecond, nil
 }
 
-// durationToMicroseconds returns microseconds from given time.Duration
-func durationToMicroseconds(d time.Duration) int64 {
+// DurationToMicroseconds returns microseconds from given time.Duration
+// with truncated sub-microsecond remainder.
+//
+// Microseconds of any time.Duration are in int64 range, so conversion never overflows.
+// Use DurationToMicrosecondsChecked for detect loss of precision
+func DurationToMicroseconds(d time.Duration) int64 {
 	return int64(d / time.Microsecond)
 }
 
+// DurationToMicrosecondsChecked returns microseconds from given time.Duration.
+//
+// Sub-microsecond remainder of duration is rounded to nearest microsecond
+// (halfway values are rounded away from zero) if round is true or rejected with error otherwise.
+func DurationToMicrosecondsChecked(d time.Duration, round bool) (int64, error) {
+	us, remainder := d/time.Microsecond, d%time.Microsecond
+	if remainder == 0 {
+		return int64(us), nil
+	}
+	if !round {
+		return 0, xerrors.WithStackTrace(fmt.Errorf("duration '%v' has sub-microsecond remainder %d ns: %w",
+			d, int64(remainder), errValueFractional,
+		))
+	}
+	// time.Duration.Round saturates near bounds of time.Duration, but rounded
+	// microseconds are always in range of int64
+	switch {
+	case remainder >= time.Microsecond/2:
+		us++
+	case remainder <= -time.Microsecond/2:
+		us--
+	}
+	return int64(us), nil
+}
+
 // DateToTime up to 11761191-01-20 00:00:00 +0000 UTC.
 func DateToTime(n uint32) time.Time {
 	return time.Unix(0, 0).Add(time.Hour * 24 * time.Duration(n))
@@ -57,54 +323,161 @@ func TimestampToTime(n uint64) time.Time {
 	return time.Unix(int64(sec), int64(nsec))
 }
 
+// Date32ToTime converts given days since Epoch (negative for dates before 1970) to time.Time
+func Date32ToTime(n int32) time.Time {
+	return time.Unix(int64(n)*int64(secondsPerDay), 0)
+}
+
+// Datetime64ToTime converts given seconds since Epoch (negative for times before 1970) to time.Time
+func Datetime64ToTime(n int64) time.Time {
+	return time.Unix(n, 0)
+}
+
+// Timestamp64ToTime converts given microseconds since Epoch (negative for times before 1970) to time.Time
+func Timestamp64ToTime(n int64) time.Time {
+	return time.UnixMicro(n)
+}
+
+// TzDateToTime parses TzDate wire string (such as `2006-01-02,Europe/Moscow`)
+// to time.Time in location of timezone name
 func TzDateToTime(s string) (t time.Time, err error) {
+	return tzStringToTime(s, LayoutDate)
+}
+
+// TzDatetimeToTime parses TzDatetime wire string (such as `2006-01-02T15:04:05,Europe/Moscow`)
+// to time.Time in location of timezone name
+func TzDatetimeToTime(s string) (t time.Time, err error) {
+	return tzStringToTime(s, LayoutTzDatetime)
+}
+
+// TzTimestampToTime parses TzTimestamp wire string (such as `2006-01-02T15:04:05.999999,Europe/Moscow`)
+// to time.Time in location of timezone name
+func TzTimestampToTime(s string) (t time.Time, err error) {
+	return tzStringToTime(s, layoutTzTimestampParse)
+}
+
+// tzStringToTime parses wire string of Tz* types (time formatted with layout and timezone name
+// separated by comma) to time.Time in location of timezone name
+func tzStringToTime(s, layout string) (t time.Time, err error) {
 	ss := strings.Split(s, ",")
 	if len(ss) != 2 {
 		return t, xerrors.WithStackTrace(fmt.Errorf("not found timezone location in '%s'", s))
 	}
-	location, err := time.LoadLocation(ss[1])
+	location, err := loadLocation(ss[1])
 	if err != nil {
-		return t, xerrors.WithStackTrace(err)
+		return t, xerrors.WithStackTrace(fmt.Errorf("unknown timezone in '%s': %w", s, err))
 	}
-	t, err = time.ParseInLocation(LayoutDate, ss[0], location)
+	t, err = time.ParseInLocation(layout, ss[0], location)
 	if err != nil {
 		return t, xerrors.WithStackTrace(fmt.Errorf("parse '%s' failed: %w", s, err))
 	}
 	return t, nil
 }
 
-func TzDatetimeToTime(s string) (t time.Time, err error) {
-	ss := strings.Split(s, ",")
-	if len(ss) != 2 {
-		return t, xerrors.WithStackTrace(fmt.Errorf("not found timezone location in '%s'", s))
+// timezoneName returns IANA name of timezone of t (as YDB expects in values of Tz* types).
+//
+// Local timezone is resolved by TZ environment variable or /etc/localtime (as go runtime does).
+// Fixed zones (such as time.FixedZone("+03", 3*60*60)) with whole hours offsets are represented
+// as Etc/GMT-3 zones. timezoneName returns error if timezone of t has no IANA name
+func timezoneName(t time.Time) (string, error) {
+	loc := t.Location()
+	name := loc.String()
+	if loc == time.Local {
+		name = localTimezoneName()
 	}
-	location, err := time.LoadLocation(ss[1])
-	if err != nil {
-		return t, xerrors.WithStackTrace(err)
+	if name != "" {
+		if l, err := loadLocation(name); err == nil && sameZoneOffset(t, l) {
+			return name, nil
+		}
 	}
-	t, err = time.ParseInLocation(LayoutTzDatetime, ss[0], location)
-	if err != nil {
-		return t, xerrors.WithStackTrace(fmt.Errorf("parse '%s' failed: %w", s, err))
+	if loc == time.Local {
+		return "", xerrors.WithStackTrace(fmt.Errorf("%w: local timezone of %v is not resolved", errTimezone, t))
+	}
+	_, offset := t.Zone()
+	const hour = int(secondsPerHour)
+	switch {
+	case offset == 0:
+		return "UTC", nil
+	case offset%hour == 0 && offset >= -12*hour && offset <= 14*hour:
+		// signs of Etc/GMT zones are inverted
+		return fmt.Sprintf("Etc/GMT%+d", -offset/hour), nil
+	default:
+		return "", xerrors.WithStackTrace(fmt.Errorf("%w: timezone %q of %v", errTimezone, name, t))
 	}
-	return t, nil
 }
 
-func TzTimestampToTime(s string) (t time.Time, err error) {
-	ss := strings.Split(s, ",")
-	if len(ss) != 2 {
-		return t, xerrors.WithStackTrace(fmt.Errorf("not found timezone location in '%s'", s))
+var (
+	// locations caches results of time.LoadLocation by name,
+	// so values of Tz* types are built and parsed without reading of zoneinfo on each call
+	locations sync.Map
+
+	localTimezoneOnce sync.Once
+	localTimezone     string
+)
+
+type loadedLocation struct {
+	location *time.Location
+	err      error
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	if l, has := locations.Load(name); has {
+		return l.(loadedLocation).location, l.(loadedLocation).err
+	}
+	l, err := time.LoadLocation(name)
+	locations.Store(name, loadedLocation{location: l, err: err})
+
+	return l, err
+}
+
+// localTimezoneName returns IANA name of local timezone or empty string if name is not known.
+// Name is resolved once per process (as go runtime resolves time.Local)
+func localTimezoneName() string {
+	localTimezoneOnce.Do(func() {
+		localTimezone = lookupLocalTimezoneName()
+	})
+
+	return localTimezone
+}
+
+func lookupLocalTimezoneName() string {
+	const zoneinfo = "zoneinfo/"
+	if tz, has := os.LookupEnv("TZ"); has {
+		tz = strings.TrimPrefix(tz, ":")
+		switch {
+		case tz == "":
+			return "UTC"
+		case filepath.IsAbs(tz):
+			if i := strings.LastIndex(tz, zoneinfo); i >= 0 {
+				return tz[i+len(zoneinfo):]
+			}
+			return ""
+		default:
+			return tz
+		}
 	}
-	location, err := time.LoadLocation(ss[1])
+	link, err := os.Readlink("/etc/localtime")
 	if err != nil {
-		return t, xerrors.WithStackTrace(err)
+		return ""
 	}
-	layout := LayoutTzTimestamp
-	if strings.IndexByte(ss[0], '.') < 0 {
-		layout = LayoutTzDatetime
+	if i := strings.LastIndex(link, zoneinfo); i >= 0 {
+		return link[i+len(zoneinfo):]
 	}
-	t, err = time.ParseInLocation(layout, ss[0], location)
+	return ""
+}
+
+func sameZoneOffset(t time.Time, loc *time.Location) bool {
+	_, offset := t.Zone()
+	_, locOffset := t.In(loc).Zone()
+	return offset == locOffset
+}
+
+// inTimezone returns t with IANA name of its timezone.
+// Times in timezones without IANA names are converted to UTC
+func inTimezone(t time.Time) (time.Time, string) {
+	name, err := timezoneName(t)
 	if err != nil {
-		return t, xerrors.WithStackTrace(fmt.Errorf("parse '%s' failed: %w", s, err))
+		return t.UTC(), "UTC"
 	}
-	return t, nil
+	return t, name
 }
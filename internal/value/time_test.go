@@ -1,6 +1,9 @@
 package value
 
 import (
+	"math"
+	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -66,3 +69,554 @@ func TestTzSomeToTime(t *testing.T) {
 		})
 	}
 }
+
+func TestIntervalToDuration(t *testing.T) {
+	for _, tt := range []struct {
+		src        int64
+		exp        time.Duration
+		overflowed bool
+	}{
+		{src: 0, exp: 0},
+		{src: 1, exp: time.Microsecond},
+		{src: -1, exp: -time.Microsecond},
+		{src: maxDurationMicroseconds, exp: time.Duration(maxDurationMicroseconds) * time.Microsecond},
+		{src: minDurationMicroseconds, exp: time.Duration(minDurationMicroseconds) * time.Microsecond},
+		{src: maxDurationMicroseconds + 1, exp: math.MaxInt64, overflowed: true},
+		{src: minDurationMicroseconds - 1, exp: math.MinInt64, overflowed: true},
+		{src: math.MaxInt64, exp: math.MaxInt64, overflowed: true},
+		{src: -math.MaxInt64, exp: math.MinInt64, overflowed: true},
+		{src: math.MinInt64, exp: math.MinInt64, overflowed: true},
+	} {
+		t.Run("", func(t *testing.T) {
+			require.Equal(t, tt.exp, IntervalToDuration(tt.src))
+			d, err := IntervalToDurationChecked(tt.src)
+			if tt.overflowed {
+				require.ErrorIs(t, err, errValueOutOfRange)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.exp, d)
+		})
+	}
+}
+
+func TestDateValueFromTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	for _, tt := range []struct {
+		name string
+		src  time.Time
+		exp  string
+	}{
+		{
+			name: "Epoch",
+			src:  time.Unix(0, 0),
+			exp:  "1970-01-01",
+		},
+		{
+			name: "BeforeMidnightUTC",
+			src:  time.Date(2023, time.March, 11, 23, 59, 59, 999999999, time.UTC),
+			exp:  "2023-03-11",
+		},
+		{
+			name: "MidnightUTC",
+			src:  time.Date(2023, time.March, 12, 0, 0, 0, 0, time.UTC),
+			exp:  "2023-03-12",
+		},
+		{
+			name: "BeforeMidnightUTCInLocation",
+			src:  time.Date(2023, time.January, 1, 8, 59, 59, 0, tokyo),
+			exp:  "2022-12-31",
+		},
+		{
+			name: "MidnightUTCInLocation",
+			src:  time.Date(2023, time.January, 1, 9, 0, 0, 0, tokyo),
+			exp:  "2023-01-01",
+		},
+		{
+			name: "BeforeSpringForward",
+			src:  time.Date(2023, time.March, 12, 1, 59, 59, 0, newYork),
+			exp:  "2023-03-12",
+		},
+		{
+			name: "AfterSpringForward",
+			src:  time.Date(2023, time.March, 12, 3, 0, 0, 0, newYork),
+			exp:  "2023-03-12",
+		},
+		{
+			name: "SpringForwardLateEvening",
+			src:  time.Date(2023, time.March, 12, 19, 59, 59, 0, newYork),
+			exp:  "2023-03-12",
+		},
+		{
+			name: "SpringForwardMidnightUTC",
+			src:  time.Date(2023, time.March, 12, 20, 0, 0, 0, newYork),
+			exp:  "2023-03-13",
+		},
+		{
+			name: "FallBackAmbiguousHour",
+			src:  time.Date(2023, time.October, 29, 2, 30, 0, 0, berlin),
+			exp:  "2023-10-29",
+		},
+		{
+			name: "FallBackMidnightLocal",
+			src:  time.Date(2023, time.October, 29, 0, 30, 0, 0, berlin),
+			exp:  "2023-10-28",
+		},
+		{
+			name: "LeapDay",
+			src:  time.Date(2024, time.February, 29, 23, 59, 59, 0, time.UTC),
+			exp:  "2024-02-29",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			v := DateValueFromTime(tt.src)
+			require.Equal(t, tt.exp, DateToTime(uint32(v)).UTC().Format(LayoutDate))
+			require.Equal(t, tt.src.UTC().Format(LayoutDate), DateToTime(uint32(v)).UTC().Format(LayoutDate))
+		})
+	}
+}
+
+func TestValueFromTimeE(t *testing.T) {
+	var (
+		minTime = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+		maxTime = time.Date(2105, time.December, 31, 23, 59, 59, 999999999, time.UTC)
+		tokyo   = time.FixedZone("UTC+9", 9*60*60)
+	)
+	for _, tt := range []struct {
+		name      string
+		src       time.Time
+		date      string
+		datetime  string
+		timestamp string
+	}{
+		{
+			name:      "Min",
+			src:       minTime,
+			date:      `Date("1970-01-01")`,
+			datetime:  `Datetime("1970-01-01T00:00:00Z")`,
+			timestamp: `Timestamp("1970-01-01T00:00:00.000000Z")`,
+		},
+		{
+			name: "BeforeMin",
+			src:  minTime.Add(-time.Nanosecond),
+		},
+		{
+			name: "BeforeMinInLocation",
+			src:  time.Date(1970, time.January, 1, 8, 59, 59, 0, tokyo),
+		},
+		{
+			name:      "MinInLocation",
+			src:       time.Date(1970, time.January, 1, 9, 0, 0, 0, tokyo),
+			date:      `Date("1970-01-01")`,
+			datetime:  `Datetime("1970-01-01T00:00:00Z")`,
+			timestamp: `Timestamp("1970-01-01T00:00:00.000000Z")`,
+		},
+		{
+			name:      "Max",
+			src:       maxTime,
+			date:      `Date("2105-12-31")`,
+			datetime:  `Datetime("2105-12-31T23:59:59Z")`,
+			timestamp: `Timestamp("2105-12-31T23:59:59.999999Z")`,
+		},
+		{
+			name: "AfterMax",
+			src:  maxTime.Add(time.Nanosecond),
+		},
+		{
+			name: "FarPast",
+			src:  time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "FarFuture",
+			src:  time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, c := range []struct {
+				yqlType string
+				exp     string
+				f       func(t time.Time) (Value, error)
+			}{
+				{
+					yqlType: "Date",
+					exp:     tt.date,
+					f: func(t time.Time) (Value, error) {
+						return DateValueFromTimeE(t)
+					},
+				},
+				{
+					yqlType: "Datetime",
+					exp:     tt.datetime,
+					f: func(t time.Time) (Value, error) {
+						return DatetimeValueFromTimeE(t)
+					},
+				},
+				{
+					yqlType: "Timestamp",
+					exp:     tt.timestamp,
+					f: func(t time.Time) (Value, error) {
+						return TimestampValueFromTimeE(t)
+					},
+				},
+			} {
+				t.Run(c.yqlType, func(t *testing.T) {
+					v, err := c.f(tt.src)
+					if c.exp == "" {
+						require.ErrorIs(t, err, errValueOutOfRange)
+						require.Contains(t, err.Error(), "out of range [1970-01-01")
+						require.Contains(t, err.Error(), "2105-12-31")
+						require.Contains(t, err.Error(), "] of "+c.yqlType)
+						return
+					}
+					require.NoError(t, err)
+					require.Equal(t, c.exp, v.Yql())
+				})
+			}
+		})
+	}
+}
+
+func TestWideValueFromTimeE(t *testing.T) {
+	var (
+		minTime = time.Date(-144168, time.January, 1, 0, 0, 0, 0, time.UTC)
+		maxTime = time.Date(148107, time.December, 31, 23, 59, 59, 999999999, time.UTC)
+	)
+	for _, tt := range []struct {
+		name string
+		src  time.Time
+		ok   bool
+	}{
+		{name: "Min", src: minTime, ok: true},
+		{name: "BeforeMin", src: minTime.Add(-time.Nanosecond)},
+		{name: "Max", src: maxTime, ok: true},
+		{name: "AfterMax", src: maxTime.Add(time.Nanosecond)},
+		{name: "FarPast", src: time.Date(-10000000, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "FarFuture", src: time.Date(10000000, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, c := range []struct {
+				yqlType string
+				f       func(t time.Time) (Value, error)
+				exp     func(t time.Time) Value
+			}{
+				{
+					yqlType: "Date32",
+					f: func(t time.Time) (Value, error) {
+						return Date32ValueFromTimeE(t)
+					},
+					exp: func(t time.Time) Value {
+						return Date32ValueFromTime(t)
+					},
+				},
+				{
+					yqlType: "Datetime64",
+					f: func(t time.Time) (Value, error) {
+						return Datetime64ValueFromTimeE(t)
+					},
+					exp: func(t time.Time) Value {
+						return Datetime64ValueFromTime(t)
+					},
+				},
+				{
+					yqlType: "Timestamp64",
+					f: func(t time.Time) (Value, error) {
+						return Timestamp64ValueFromTimeE(t)
+					},
+					exp: func(t time.Time) Value {
+						return Timestamp64ValueFromTime(t)
+					},
+				},
+			} {
+				t.Run(c.yqlType, func(t *testing.T) {
+					v, err := c.f(tt.src)
+					if !tt.ok {
+						require.ErrorIs(t, err, errValueOutOfRange)
+						require.Contains(t, err.Error(), "] of "+c.yqlType)
+						return
+					}
+					require.NoError(t, err)
+					require.True(t, Equal(c.exp(tt.src), v), v.Yql())
+				})
+			}
+		})
+	}
+	t.Run("Bounds", func(t *testing.T) {
+		require.Equal(t, int32(minDate32), int32(Date32ValueFromTime(minTime)))
+		require.Equal(t, int32(maxDate32), int32(Date32ValueFromTime(maxTime)))
+		require.Equal(t, int64(minDatetime64), int64(Datetime64ValueFromTime(minTime)))
+		require.Equal(t, int64(maxDatetime64), int64(Datetime64ValueFromTime(maxTime)))
+		require.Equal(t, int64(minTimestamp64), int64(Timestamp64ValueFromTime(minTime)))
+		require.Equal(t, int64(maxTimestamp64), int64(Timestamp64ValueFromTime(maxTime)))
+	})
+}
+
+func TestDurationToMicroseconds(t *testing.T) {
+	for _, tt := range []struct {
+		src       time.Duration
+		truncated int64
+		rounded   int64
+		exact     bool
+	}{
+		{src: 0, truncated: 0, rounded: 0, exact: true},
+		{src: time.Second, truncated: 1e6, rounded: 1e6, exact: true},
+		{src: -time.Microsecond, truncated: -1, rounded: -1, exact: true},
+		{src: time.Nanosecond, truncated: 0, rounded: 0},
+		{src: 999 * time.Nanosecond, truncated: 0, rounded: 1},
+		{src: -999 * time.Nanosecond, truncated: 0, rounded: -1},
+		{src: 1500 * time.Nanosecond, truncated: 1, rounded: 2},
+		{src: -1500 * time.Nanosecond, truncated: -1, rounded: -2},
+		{src: math.MaxInt64, truncated: maxDurationMicroseconds, rounded: maxDurationMicroseconds + 1},
+		{src: math.MinInt64, truncated: minDurationMicroseconds, rounded: minDurationMicroseconds - 1},
+		{
+			src:       time.Duration(minDurationMicroseconds) * time.Microsecond,
+			truncated: minDurationMicroseconds,
+			rounded:   minDurationMicroseconds,
+			exact:     true,
+		},
+	} {
+		t.Run(tt.src.String(), func(t *testing.T) {
+			require.Equal(t, tt.truncated, DurationToMicroseconds(tt.src))
+
+			us, err := DurationToMicrosecondsChecked(tt.src, false)
+			if tt.exact {
+				require.NoError(t, err)
+				require.Equal(t, tt.truncated, us)
+			} else {
+				require.ErrorIs(t, err, errValueFractional)
+			}
+
+			us, err = DurationToMicrosecondsChecked(tt.src, true)
+			require.NoError(t, err)
+			require.Equal(t, tt.rounded, us)
+
+			if tt.exact {
+				// exact microseconds are converted back without loss
+				require.Equal(t, tt.src, IntervalToDuration(us))
+			}
+		})
+	}
+}
+
+func TestIntervalISO8601(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		us   int64
+		exp  string
+	}{
+		{name: "Zero", us: 0, exp: "PT0S"},
+		{name: "Microsecond", us: 1, exp: "PT0.000001S"},
+		{name: "NegativeMicrosecond", us: -1, exp: "-PT0.000001S"},
+		{name: "Milliseconds", us: 42000, exp: "PT0.042S"},
+		{name: "Second", us: 1e6, exp: "PT1S"},
+		{name: "Negative", us: -(90*60*1e6 + 500000), exp: "-PT1H30M0.5S"},
+		{name: "Day", us: 24 * 3600 * 1e6, exp: "P1D"},
+		{name: "DaysAndHours", us: (3*24 + 2) * 3600 * 1e6, exp: "P3DT2H"},
+		{name: "HourAndMicroseconds", us: 3600*1e6 + 2, exp: "PT1H0.000002S"},
+		{name: "Max", us: math.MaxInt64, exp: "P106751991DT4H54.775807S"},
+		{name: "Min", us: math.MinInt64, exp: "-P106751991DT4H54.775808S"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.exp, IntervalToISO8601(tt.us))
+			us, err := ISO8601ToInterval(tt.exp)
+			require.NoError(t, err)
+			require.Equal(t, tt.us, us)
+		})
+	}
+	t.Run("Parse", func(t *testing.T) {
+		for s, exp := range map[string]int64{
+			"+PT1S":         1e6,
+			"P2W":           14 * 24 * 3600 * 1e6,
+			"P1DT0S":        24 * 3600 * 1e6,
+			"PT90M":         90 * 60 * 1e6,
+			"PT1.5S":        1500000,
+			"PT0.000001S":   1,
+			"-P0D":          0,
+			"PT36H":         36 * 3600 * 1e6,
+			"P1W1DT1H1M1S":  (8*24*3600 + 3661) * 1e6,
+			"PT0000000001S": 1e6,
+		} {
+			t.Run(s, func(t *testing.T) {
+				us, err := ISO8601ToInterval(s)
+				require.NoError(t, err)
+				require.Equal(t, exp, us)
+			})
+		}
+	})
+	t.Run("Malformed", func(t *testing.T) {
+		for _, s := range []string{
+			"", "P", "PT", "P1DT", "1S", "-", "PS", "P1S", "PT1D", "P1H", "P1M", "P1Y",
+			"PT1.S", "PT1.0000001S", "PT1.5M", "PT1M1H", "P1D1W", "PT1S1S", "P1DTT1S",
+			"PT1", "PT1SX", "P-1D", "PT9223372036855S", "P106751991DT4H54.775808S",
+			"-P106751991DT4H54.775809S", "PT99999999999999999999S",
+		} {
+			t.Run(s, func(t *testing.T) {
+				_, err := ISO8601ToInterval(s)
+				require.ErrorIs(t, err, errMalformedISO8601)
+			})
+		}
+	})
+	t.Run("Value", func(t *testing.T) {
+		v, err := IntervalValueFromISO8601("-P1DT2.5S")
+		require.NoError(t, err)
+		require.Equal(t, `Interval("-P1DT2.5S")`, v.Yql())
+		require.Equal(t, IntervalValueFromDuration(-(24*time.Hour + 2500*time.Millisecond)), v)
+		_, err = IntervalValueFromISO8601("P")
+		require.ErrorIs(t, err, errMalformedISO8601)
+	})
+}
+
+func TestTzValueFromTimeFixedZone(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		loc  *time.Location
+		exp  string
+		err  bool
+	}{
+		{
+			name: "UTC",
+			loc:  time.UTC,
+			exp:  "2020-05-29T08:22:54,UTC",
+		},
+		{
+			name: "ZeroOffset",
+			loc:  time.FixedZone("", 0),
+			exp:  "2020-05-29T08:22:54,UTC",
+		},
+		{
+			name: "East",
+			loc:  time.FixedZone("+03", 3*60*60),
+			exp:  "2020-05-29T11:22:54,Etc/GMT-3",
+		},
+		{
+			name: "West",
+			loc:  time.FixedZone("-05", -5*60*60),
+			exp:  "2020-05-29T03:22:54,Etc/GMT+5",
+		},
+		{
+			name: "IANA",
+			loc:  time.FixedZone("Europe/Berlin", 2*60*60),
+			exp:  "2020-05-29T10:22:54,Europe/Berlin",
+		},
+		{
+			name: "NotWholeHours",
+			loc:  time.FixedZone("+0530", 5*60*60+30*60),
+			err:  true,
+		},
+		{
+			name: "OutOfRange",
+			loc:  time.FixedZone("+15", 15*60*60),
+			err:  true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			src := time.Date(2020, time.May, 29, 8, 22, 54, 0, time.UTC).In(tt.loc)
+			v, err := TzDatetimeValueFromTimeE(src)
+			if tt.err {
+				require.ErrorIs(t, err, errTimezone)
+				require.Equal(t, tzDatetimeValue("2020-05-29T08:22:54,UTC"), TzDatetimeValueFromTime(src))
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tzDatetimeValue(tt.exp), v)
+			require.Equal(t, v, TzDatetimeValueFromTime(src))
+			dst, err := TzDatetimeToTime(string(v))
+			require.NoError(t, err)
+			require.True(t, src.Equal(dst))
+		})
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	l, err := loadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	cached, err := loadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	require.Same(t, l, cached)
+	_, err = loadLocation("Unknown/Timezone")
+	require.Error(t, err)
+	_, has := locations.Load("Unknown/Timezone")
+	require.True(t, has)
+}
+
+func TestTzStringToTimeCachesLocation(t *testing.T) {
+	const name = "America/Sao_Paulo"
+	locations.Delete(name)
+	tm, err := TzTimestampToTime("2020-05-29T11:22:54.5," + name)
+	require.NoError(t, err)
+	l, has := locations.Load(name)
+	require.True(t, has)
+	require.Same(t, l.(loadedLocation).location, tm.Location())
+	tm, err = TzDateToTime("2020-05-29," + name)
+	require.NoError(t, err)
+	require.Same(t, l.(loadedLocation).location, tm.Location())
+}
+
+func TestLocalTimezoneNameCached(t *testing.T) {
+	t.Setenv("TZ", "Europe/Berlin")
+	resetLocalTimezone(t)
+	require.Equal(t, "Europe/Berlin", localTimezoneName())
+	t.Setenv("TZ", "Asia/Tokyo")
+	require.Equal(t, "Europe/Berlin", localTimezoneName())
+}
+
+// resetLocalTimezone resets cached name of local timezone for resolving it with changed TZ
+func resetLocalTimezone(t *testing.T) {
+	localTimezoneOnce = sync.Once{}
+	t.Cleanup(func() {
+		localTimezoneOnce = sync.Once{}
+	})
+}
+
+func TestTzValueFromTimeLocal(t *testing.T) {
+	src := time.Date(2020, time.May, 29, 8, 22, 54, 0, time.UTC)
+	setLocal := func(t *testing.T, tz, name string) {
+		t.Setenv("TZ", tz)
+		resetLocalTimezone(t)
+		local := time.Local
+		t.Cleanup(func() {
+			time.Local = local
+		})
+		loc, err := time.LoadLocation(name)
+		require.NoError(t, err)
+		time.Local = loc
+	}
+	t.Run("Name", func(t *testing.T) {
+		setLocal(t, ":Europe/Berlin", "Europe/Berlin")
+		v, err := TzTimestampValueFromTimeE(src.Local())
+		require.NoError(t, err)
+		require.Equal(t, tzTimestampValue("2020-05-29T10:22:54.000000,Europe/Berlin"), v)
+	})
+	t.Run("Path", func(t *testing.T) {
+		if _, err := os.Stat("/usr/share/zoneinfo/Asia/Tokyo"); err != nil {
+			t.Skip("zoneinfo is not available")
+		}
+		setLocal(t, "/usr/share/zoneinfo/Asia/Tokyo", "Asia/Tokyo")
+		v, err := TzDateValueFromTimeE(src.Local())
+		require.NoError(t, err)
+		require.Equal(t, tzDateValue("2020-05-29,Asia/Tokyo"), v)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		setLocal(t, "", "UTC")
+		v, err := TzDatetimeValueFromTimeE(src.Local())
+		require.NoError(t, err)
+		require.Equal(t, tzDatetimeValue("2020-05-29T08:22:54,UTC"), v)
+	})
+	t.Run("Unresolved", func(t *testing.T) {
+		t.Setenv("TZ", "")
+		resetLocalTimezone(t)
+		local := time.Local
+		t.Cleanup(func() {
+			time.Local = local
+		})
+		time.Local = time.FixedZone("MSK", 3*60*60)
+		_, err := TzDatetimeValueFromTimeE(src.Local())
+		require.ErrorIs(t, err, errTimezone)
+		require.Equal(t, tzDatetimeValue("2020-05-29T08:22:54,UTC"), TzDatetimeValueFromTime(src.Local()))
+	})
+}
@@ -1,16 +1,28 @@
 package value
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xstring"
 )
 
+// Type is an immutable YDB type
+//
+// Types are shared between values (primitive types are also shared by protobuf
+// representation), so they are safe for concurrent use and never modified after construction.
 type Type interface {
+	// Yql returns canonical YQL syntax of type which is accepted by server in DECLARE
+	// clauses (such as `Optional<Decimal(22,9)>` or `Struct<'id':Uint64,'name':Utf8>`).
+	// Names of struct members are single-quoted and escaped. ParseType is an inverse of Yql
 	Yql() string
+	// String is the same as Yql
 	String() string
 
 	toYDB(a *allocator.Allocator) *Ydb.Type
@@ -21,124 +33,222 @@ func TypeToYDB(t Type, a *allocator.Allocator) *Ydb.Type {
 	return t.toYDB(a)
 }
 
+// TypeFromYDB makes type from YDB type.
+// Unknown primitive types are kept as opaque types (see CheckKnownTypes).
+// TypeFromYDB panics on malformed type, use TypeFromYDBWithError instead
 func TypeFromYDB(x *Ydb.Type) Type {
-	switch v := x.Type.(type) {
+	t, err := typeFromYDB(x)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// TypeFromYDBWithError makes type from YDB type as TypeFromYDB does
+// and returns error on malformed type (such as missed type or invalid decimal parameters)
+func TypeFromYDBWithError(x *Ydb.Type) (Type, error) {
+	return typeFromYDB(x)
+}
+
+func typeFromYDB(x *Ydb.Type) (Type, error) {
+	switch v := x.GetType().(type) {
 	case *Ydb.Type_TypeId:
-		return primitiveTypeFromYDB(v.TypeId)
+		t, err := primitiveTypeFromYDB(v.TypeId)
+		if err != nil {
+			if v.TypeId == Ydb.Type_PRIMITIVE_TYPE_ID_UNSPECIFIED {
+				// missed type id is a malformed type rather than type of newer version of YDB
+				return nil, err
+			}
+			// primitive type of newer version of YDB, values of such type are kept as is
+			return &unknownType{t: x}, nil
+		}
+		return t, nil
 
 	case *Ydb.Type_OptionalType:
-		return Optional(TypeFromYDB(v.OptionalType.Item))
+		t, err := typeFromYDB(v.OptionalType.GetItem())
+		if err != nil {
+			return nil, err
+		}
+		return Optional(t), nil
+
+	case *Ydb.Type_TaggedType:
+		t, err := typeFromYDB(v.TaggedType.GetType())
+		if err != nil {
+			return nil, err
+		}
+		return Tagged(v.TaggedType.GetTag(), t), nil
+
+	case *Ydb.Type_PgType:
+		return &pgType{
+			oid:    v.PgType.GetOid(),
+			typlen: v.PgType.GetTyplen(),
+			typmod: v.PgType.GetTypmod(),
+		}, nil
 
 	case *Ydb.Type_ListType:
-		return List(TypeFromYDB(v.ListType.Item))
+		t, err := typeFromYDB(v.ListType.GetItem())
+		if err != nil {
+			return nil, err
+		}
+		return List(t), nil
 
 	case *Ydb.Type_DecimalType:
 		d := v.DecimalType
-		return Decimal(d.Precision, d.Scale)
+		if d.GetPrecision() == 0 || d.GetPrecision() > decimal.MaxPrecision || d.GetScale() > d.GetPrecision() {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("ydb: unexpected decimal type: Decimal(%d,%d)",
+				d.GetPrecision(), d.GetScale(),
+			))
+		}
+		return Decimal(d.GetPrecision(), d.GetScale()), nil
 
 	case *Ydb.Type_TupleType:
-		t := v.TupleType
-		return Tuple(TypesFromYDB(t.Elements)...)
+		ts, err := typesFromYDB(v.TupleType.GetElements())
+		if err != nil {
+			return nil, err
+		}
+		return Tuple(ts...), nil
 
 	case *Ydb.Type_StructType:
-		s := v.StructType
-		return Struct(StructFields(s.Members)...)
+		fs, err := structFieldsFromYDB(v.StructType.GetMembers())
+		if err != nil {
+			return nil, err
+		}
+		return Struct(fs...), nil
 
 	case *Ydb.Type_DictType:
-		keyType, valueType := TypeFromYDB(v.DictType.Key), TypeFromYDB(v.DictType.Payload)
-		if valueType.equalsTo(Void()) {
-			return Set(keyType)
+		keyType, err := typeFromYDB(v.DictType.GetKey())
+		if err != nil {
+			return nil, err
 		}
-		return Dict(keyType, valueType)
+		valueType, err := typeFromYDB(v.DictType.GetPayload())
+		if err != nil {
+			return nil, err
+		}
+		if TypesEqual(valueType, Void()) {
+			return Set(keyType), nil
+		}
+		return Dict(keyType, valueType), nil
 
 	case *Ydb.Type_VariantType:
-		t := v.VariantType
-		switch x := t.Type.(type) {
+		switch x := v.VariantType.GetType().(type) {
 		case *Ydb.VariantType_TupleItems:
-			return VariantTuple(TypesFromYDB(x.TupleItems.Elements)...)
+			ts, err := typesFromYDB(x.TupleItems.GetElements())
+			if err != nil {
+				return nil, err
+			}
+			return VariantTuple(ts...), nil
 		case *Ydb.VariantType_StructItems:
-			return VariantStruct(StructFields(x.StructItems.Members)...)
+			fs, err := structFieldsFromYDB(x.StructItems.GetMembers())
+			if err != nil {
+				return nil, err
+			}
+			return VariantStruct(fs...), nil
 		default:
-			panic("ydb: unknown variant type")
+			return nil, xerrors.WithStackTrace(fmt.Errorf("ydb: unknown variant type: %T", x))
 		}
 
 	case *Ydb.Type_VoidType:
-		return Void()
+		return Void(), nil
 
 	case *Ydb.Type_NullType:
-		return Null()
+		return Null(), nil
+
+	case *Ydb.Type_EmptyListType:
+		return EmptyList(), nil
+
+	case *Ydb.Type_EmptyDictType:
+		return EmptyDict(), nil
 
 	default:
-		panic("ydb: unknown type")
+		return nil, xerrors.WithStackTrace(fmt.Errorf("ydb: unknown type: %T", v))
 	}
 }
 
-func primitiveTypeFromYDB(t Ydb.Type_PrimitiveTypeId) Type {
+func primitiveTypeFromYDB(t Ydb.Type_PrimitiveTypeId) (Type, error) {
 	switch t {
 	case Ydb.Type_BOOL:
-		return TypeBool
+		return TypeBool, nil
 	case Ydb.Type_INT8:
-		return TypeInt8
+		return TypeInt8, nil
 	case Ydb.Type_UINT8:
-		return TypeUint8
+		return TypeUint8, nil
 	case Ydb.Type_INT16:
-		return TypeInt16
+		return TypeInt16, nil
 	case Ydb.Type_UINT16:
-		return TypeUint16
+		return TypeUint16, nil
 	case Ydb.Type_INT32:
-		return TypeInt32
+		return TypeInt32, nil
 	case Ydb.Type_UINT32:
-		return TypeUint32
+		return TypeUint32, nil
 	case Ydb.Type_INT64:
-		return TypeInt64
+		return TypeInt64, nil
 	case Ydb.Type_UINT64:
-		return TypeUint64
+		return TypeUint64, nil
 	case Ydb.Type_FLOAT:
-		return TypeFloat
+		return TypeFloat, nil
 	case Ydb.Type_DOUBLE:
-		return TypeDouble
+		return TypeDouble, nil
 	case Ydb.Type_DATE:
-		return TypeDate
+		return TypeDate, nil
 	case Ydb.Type_DATETIME:
-		return TypeDatetime
+		return TypeDatetime, nil
 	case Ydb.Type_TIMESTAMP:
-		return TypeTimestamp
+		return TypeTimestamp, nil
 	case Ydb.Type_INTERVAL:
-		return TypeInterval
+		return TypeInterval, nil
 	case Ydb.Type_TZ_DATE:
-		return TypeTzDate
+		return TypeTzDate, nil
 	case Ydb.Type_TZ_DATETIME:
-		return TypeTzDatetime
+		return TypeTzDatetime, nil
 	case Ydb.Type_TZ_TIMESTAMP:
-		return TypeTzTimestamp
+		return TypeTzTimestamp, nil
 	case Ydb.Type_STRING:
-		return TypeBytes
+		return TypeBytes, nil
 	case Ydb.Type_UTF8:
-		return TypeText
+		return TypeText, nil
 	case Ydb.Type_YSON:
-		return TypeYSON
+		return TypeYSON, nil
 	case Ydb.Type_JSON:
-		return TypeJSON
+		return TypeJSON, nil
 	case Ydb.Type_UUID:
-		return TypeUUID
+		return TypeUUID, nil
 	case Ydb.Type_JSON_DOCUMENT:
-		return TypeJSONDocument
+		return TypeJSONDocument, nil
 	case Ydb.Type_DYNUMBER:
-		return TypeDyNumber
+		return TypeDyNumber, nil
+	case TypeIDDate32:
+		return TypeDate32, nil
+	case TypeIDDatetime64:
+		return TypeDatetime64, nil
+	case TypeIDTimestamp64:
+		return TypeTimestamp64, nil
+	case TypeIDInterval64:
+		return TypeInterval64, nil
 	default:
-		panic("ydb: unexpected type")
+		return nil, xerrors.WithStackTrace(fmt.Errorf("ydb: unexpected type: %v", t))
 	}
 }
 
-func TypesFromYDB(es []*Ydb.Type) []Type {
+func typesFromYDB(es []*Ydb.Type) ([]Type, error) {
 	ts := make([]Type, len(es))
 	for i, el := range es {
-		ts[i] = TypeFromYDB(el)
+		t, err := typeFromYDB(el)
+		if err != nil {
+			return nil, err
+		}
+		ts[i] = t
 	}
-	return ts
+	return ts, nil
 }
 
+// TypesEqual checks structural equality of types: kinds of types, primitive type ids,
+// precision and scale of decimals, tags, names and order of struct members and types of
+// items of containers and variants must be equal. Set<T> is equal to Dict<T,Void>,
+// because they are the same type on the wire. Nil types are equal only to nil types
 func TypesEqual(a, b Type) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
 	return a.equalsTo(b)
 }
 
@@ -179,6 +289,8 @@ func (v *DecimalType) toYDB(a *allocator.Allocator) *Ydb.Type {
 	return t
 }
 
+// Decimal makes decimal type without checks of parameters (types of YDB results are not checked).
+// Use DecimalE for checked types
 func Decimal(precision, scale uint32) *DecimalType {
 	return &DecimalType{
 		Precision: precision,
@@ -186,11 +298,71 @@ func Decimal(precision, scale uint32) *DecimalType {
 	}
 }
 
+const (
+	// DefaultDecimalPrecision is a precision of canonical decimal type Decimal(22,9)
+	DefaultDecimalPrecision = 22
+	// DefaultDecimalScale is a scale of canonical decimal type Decimal(22,9)
+	DefaultDecimalScale = 9
+)
+
+var errDecimalType = errors.New("invalid decimal type")
+
+// CheckDecimalType checks parameters of decimal type: precision must be in range [1, 35]
+// and scale must not be greater than precision
+func CheckDecimalType(precision, scale uint32) error {
+	if problem := decimalTypeProblem(precision, scale); problem != "" {
+		return xerrors.WithStackTrace(fmt.Errorf("%w: %s", errDecimalType, problem))
+	}
+	return nil
+}
+
+// decimalTypeProblem returns description of invalid parameters of decimal type or empty string
+func decimalTypeProblem(precision, scale uint32) string {
+	switch {
+	case precision == 0 || precision > decimal.MaxPrecision:
+		return fmt.Sprintf("precision %d of Decimal(%d,%d) is out of range [1,%d]",
+			precision, precision, scale, decimal.MaxPrecision,
+		)
+	case scale > precision:
+		return fmt.Sprintf("scale %d of Decimal(%d,%d) is greater than precision", scale, precision, scale)
+	default:
+		return ""
+	}
+}
+
+// DecimalE makes decimal type and returns error if parameters of type are invalid (see CheckDecimalType)
+func DecimalE(precision, scale uint32) (*DecimalType, error) {
+	if err := CheckDecimalType(precision, scale); err != nil {
+		return nil, err
+	}
+	return Decimal(precision, scale), nil
+}
+
+// DictType is a type of dicts (such as Dict<Utf8,Int32>). Set types are dict types with Void values
+type DictType interface {
+	Type
+
+	// KeyType returns type of keys of dict
+	KeyType() Type
+	// ValueType returns type of values of dict (Void for sets)
+	ValueType() Type
+}
+
 type dictType struct {
 	keyType   Type
 	valueType Type
 }
 
+// KeyType returns type of keys of dict
+func (v *dictType) KeyType() Type {
+	return v.keyType
+}
+
+// ValueType returns type of values of dict
+func (v *dictType) ValueType() Type {
+	return v.valueType
+}
+
 func (v *dictType) String() string {
 	return v.Yql()
 }
@@ -207,17 +379,14 @@ func (v *dictType) Yql() string {
 }
 
 func (v *dictType) equalsTo(rhs Type) bool {
-	vv, ok := rhs.(*dictType)
-	if !ok {
-		return false
-	}
-	if !v.keyType.equalsTo(vv.keyType) {
-		return false
-	}
-	if !v.valueType.equalsTo(vv.valueType) {
+	switch vv := rhs.(type) {
+	case *dictType:
+		return TypesEqual(v.keyType, vv.keyType) && TypesEqual(v.valueType, vv.valueType)
+	case *setType:
+		return TypesEqual(v.keyType, vv.itemType) && TypesEqual(v.valueType, Void())
+	default:
 		return false
 	}
-	return true
 }
 
 func (v *dictType) toYDB(a *allocator.Allocator) *Ydb.Type {
@@ -300,10 +469,23 @@ func EmptyDict() emptyDictType {
 	return emptyDictType{}
 }
 
+// ListType is a type of lists (such as List<Int32>) and sets (such as Set<Int32>)
+type ListType interface {
+	Type
+
+	// ItemType returns type of items
+	ItemType() Type
+}
+
 type listType struct {
 	itemType Type
 }
 
+// ItemType returns type of items of list
+func (v *listType) ItemType() Type {
+	return v.itemType
+}
+
 func (v *listType) String() string {
 	return v.Yql()
 }
@@ -317,7 +499,7 @@ func (v *listType) equalsTo(rhs Type) bool {
 	if !ok {
 		return false
 	}
-	return v.itemType.equalsTo(vv.itemType)
+	return TypesEqual(v.itemType, vv.itemType)
 }
 
 func (v *listType) toYDB(a *allocator.Allocator) *Ydb.Type {
@@ -345,6 +527,21 @@ type setType struct {
 	itemType Type
 }
 
+// ItemType returns type of items of set
+func (v *setType) ItemType() Type {
+	return v.itemType
+}
+
+// KeyType returns type of items of set (set is a dict with Void values on wire)
+func (v *setType) KeyType() Type {
+	return v.itemType
+}
+
+// ValueType returns Void (set is a dict with Void values on wire)
+func (v *setType) ValueType() Type {
+	return Void()
+}
+
 func (v *setType) String() string {
 	return v.Yql()
 }
@@ -354,11 +551,14 @@ func (v *setType) Yql() string {
 }
 
 func (v *setType) equalsTo(rhs Type) bool {
-	vv, ok := rhs.(*setType)
-	if !ok {
+	switch vv := rhs.(type) {
+	case *setType:
+		return TypesEqual(v.itemType, vv.itemType)
+	case *dictType:
+		return vv.equalsTo(v)
+	default:
 		return false
 	}
-	return v.itemType.equalsTo(vv.itemType)
 }
 
 func (v *setType) toYDB(a *allocator.Allocator) *Ydb.Type {
@@ -405,7 +605,7 @@ func (v optionalType) equalsTo(rhs Type) bool {
 	if !ok {
 		return false
 	}
-	return v.innerType.equalsTo(vv.innerType)
+	return TypesEqual(v.innerType, vv.innerType)
 }
 
 func (v optionalType) toYDB(a *allocator.Allocator) *Ydb.Type {
@@ -428,6 +628,86 @@ func Optional(t Type) optionalType {
 	}
 }
 
+// IsOptional checks that t is an optional type (such as Optional<Int32>)
+func IsOptional(t Type) bool {
+	_, ok := t.(optionalType)
+	return ok
+}
+
+// UnwrapOptional returns inner type of optional type t (such as Int32 for Optional<Int32>)
+// and true. If t is not optional UnwrapOptional returns t and false
+func UnwrapOptional(t Type) (inner Type, wasOptional bool) {
+	if optional, ok := t.(optionalType); ok {
+		return optional.innerType, true
+	}
+	return t, false
+}
+
+// OptionalDepth returns count of nested optional types (such as 2 for Optional<Optional<Int32>>
+// and 0 for non-optional types)
+func OptionalDepth(t Type) (depth int) {
+	for {
+		inner, ok := UnwrapOptional(t)
+		if !ok {
+			return depth
+		}
+		t = inner
+		depth++
+	}
+}
+
+// taggedType is a type of values of inner type marked with tag (such as Tagged<Int32,'id'>)
+type taggedType struct {
+	tag       string
+	innerType Type
+}
+
+func (v *taggedType) Tag() string {
+	return v.tag
+}
+
+func (v *taggedType) InnerType() Type {
+	return v.innerType
+}
+
+func (v *taggedType) String() string {
+	return v.Yql()
+}
+
+func (v *taggedType) Yql() string {
+	return "Tagged<" + v.innerType.Yql() + "," + quoteYql(v.tag) + ">"
+}
+
+func (v *taggedType) equalsTo(rhs Type) bool {
+	vv, ok := rhs.(*taggedType)
+	if !ok {
+		return false
+	}
+	return v.tag == vv.tag && TypesEqual(v.innerType, vv.innerType)
+}
+
+func (v *taggedType) toYDB(a *allocator.Allocator) *Ydb.Type {
+	t := a.Type()
+
+	typeTagged := a.TypeTagged()
+
+	typeTagged.TaggedType = a.Tagged()
+
+	typeTagged.TaggedType.Tag = v.tag
+	typeTagged.TaggedType.Type = v.innerType.toYDB(a)
+
+	t.Type = typeTagged
+
+	return t
+}
+
+func Tagged(tag string, t Type) *taggedType {
+	return &taggedType{
+		tag:       tag,
+		innerType: t,
+	}
+}
+
 type PrimitiveType uint
 
 func (v PrimitiveType) String() string {
@@ -465,6 +745,19 @@ const (
 	TypeUUID
 	TypeJSONDocument
 	TypeDyNumber
+	TypeDate32
+	TypeDatetime64
+	TypeTimestamp64
+	TypeInterval64
+)
+
+// Ids of wide temporal types (with dates before 1970 and after 2105) which are absent
+// in generated protos of used version
+const (
+	TypeIDDate32      Ydb.Type_PrimitiveTypeId = 0x0040
+	TypeIDDatetime64  Ydb.Type_PrimitiveTypeId = 0x0041
+	TypeIDTimestamp64 Ydb.Type_PrimitiveTypeId = 0x0042
+	TypeIDInterval64  Ydb.Type_PrimitiveTypeId = 0x0043
 )
 
 var primitive = [...]*Ydb.Type{
@@ -493,6 +786,10 @@ var primitive = [...]*Ydb.Type{
 	TypeUUID:         {Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_UUID}},
 	TypeJSONDocument: {Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_JSON_DOCUMENT}},
 	TypeDyNumber:     {Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_DYNUMBER}},
+	TypeDate32:       {Type: &Ydb.Type_TypeId{TypeId: TypeIDDate32}},
+	TypeDatetime64:   {Type: &Ydb.Type_TypeId{TypeId: TypeIDDatetime64}},
+	TypeTimestamp64:  {Type: &Ydb.Type_TypeId{TypeId: TypeIDTimestamp64}},
+	TypeInterval64:   {Type: &Ydb.Type_TypeId{TypeId: TypeIDInterval64}},
 }
 
 var primitiveString = [...]string{
@@ -522,6 +819,10 @@ var primitiveString = [...]string{
 	TypeUUID:         "Uuid",
 	TypeJSONDocument: "JsonDocument",
 	TypeDyNumber:     "DyNumber",
+	TypeDate32:       "Date32",
+	TypeDatetime64:   "Datetime64",
+	TypeTimestamp64:  "Timestamp64",
+	TypeInterval64:   "Interval64",
 }
 
 func (v PrimitiveType) equalsTo(rhs Type) bool {
@@ -543,9 +844,42 @@ type (
 	}
 	StructType struct {
 		fields []StructField
+		// unsorted is true if fields are not sorted by name, so FieldIndex uses linear search
+		unsorted bool
 	}
 )
 
+// Len returns count of struct type fields
+func (v *StructType) Len() int {
+	return len(v.fields)
+}
+
+// Field returns field by index i in order of declaration
+func (v *StructType) Field(i int) StructField {
+	return v.fields[i]
+}
+
+// FieldIndex returns index of field with given name in order of declaration.
+// FieldIndex uses binary search if fields are sorted by name (as fields of StructValue).
+// If name is declared more than once then index of first declaration is returned
+func (v *StructType) FieldIndex(name string) (int, bool) {
+	if v.unsorted {
+		for i := range v.fields {
+			if v.fields[i].Name == name {
+				return i, true
+			}
+		}
+		return -1, false
+	}
+	i := sort.Search(len(v.fields), func(i int) bool {
+		return v.fields[i].Name >= name
+	})
+	if i < len(v.fields) && v.fields[i].Name == name {
+		return i, true
+	}
+	return -1, false
+}
+
 func (v *StructType) String() string {
 	return v.Yql()
 }
@@ -558,7 +892,7 @@ func (v *StructType) Yql() string {
 		if i > 0 {
 			buffer.WriteByte(',')
 		}
-		buffer.WriteString("'" + v.fields[i].Name + "'")
+		buffer.WriteString(quoteYqlMember(v.fields[i].Name))
 		buffer.WriteByte(':')
 		buffer.WriteString(v.fields[i].T.Yql())
 	}
@@ -578,7 +912,7 @@ func (v *StructType) equalsTo(rhs Type) bool {
 		if v.fields[i].Name != vv.fields[i].Name {
 			return false
 		}
-		if !v.fields[i].T.equalsTo(vv.fields[i].T) {
+		if !TypesEqual(v.fields[i].T, vv.fields[i].T) {
 			return false
 		}
 	}
@@ -610,18 +944,25 @@ func (v *StructType) toYDB(a *allocator.Allocator) *Ydb.Type {
 func Struct(fields ...StructField) (v *StructType) {
 	return &StructType{
 		fields: fields,
+		unsorted: !sort.SliceIsSorted(fields, func(i, j int) bool {
+			return fields[i].Name < fields[j].Name
+		}),
 	}
 }
 
-func StructFields(ms []*Ydb.StructMember) []StructField {
+func structFieldsFromYDB(ms []*Ydb.StructMember) ([]StructField, error) {
 	fs := make([]StructField, len(ms))
 	for i, m := range ms {
+		t, err := typeFromYDB(m.GetType())
+		if err != nil {
+			return nil, err
+		}
 		fs[i] = StructField{
-			Name: m.Name,
-			T:    TypeFromYDB(m.Type),
+			Name: m.GetName(),
+			T:    t,
 		}
 	}
-	return fs
+	return fs, nil
 }
 
 type TupleType struct {
@@ -646,6 +987,19 @@ func (v *TupleType) Yql() string {
 	return buffer.String()
 }
 
+// Len returns count of tuple type items
+func (v *TupleType) Len() int {
+	return len(v.items)
+}
+
+// ItemType returns type of tuple item by index i or nil if i is out of range
+func (v *TupleType) ItemType(i int) Type {
+	if i < 0 || i >= len(v.items) {
+		return nil
+	}
+	return v.items[i]
+}
+
 func (v *TupleType) equalsTo(rhs Type) bool {
 	vv, ok := rhs.(*TupleType)
 	if !ok {
@@ -655,7 +1009,7 @@ func (v *TupleType) equalsTo(rhs Type) bool {
 		return false
 	}
 	for i := range v.items {
-		if !v.items[i].equalsTo(vv.items[i]) {
+		if !TypesEqual(v.items[i], vv.items[i]) {
 			return false
 		}
 	}
@@ -692,6 +1046,10 @@ type variantStructType struct {
 	*StructType
 }
 
+func (v *variantStructType) String() string {
+	return v.Yql()
+}
+
 func (v *variantStructType) Yql() string {
 	buffer := xstring.Buffer()
 	defer buffer.Free()
@@ -700,7 +1058,7 @@ func (v *variantStructType) Yql() string {
 		if i > 0 {
 			buffer.WriteByte(',')
 		}
-		buffer.WriteString("'" + v.fields[i].Name + "'")
+		buffer.WriteString(quoteYqlMember(v.fields[i].Name))
 		buffer.WriteByte(':')
 		buffer.WriteString(v.fields[i].T.Yql())
 	}
@@ -709,14 +1067,8 @@ func (v *variantStructType) Yql() string {
 }
 
 func (v *variantStructType) equalsTo(rhs Type) bool {
-	switch t := rhs.(type) {
-	case *variantStructType:
-		return v.StructType.equalsTo(t.StructType)
-	case *StructType:
-		return v.StructType.equalsTo(t)
-	default:
-		return false
-	}
+	vv, ok := rhs.(*variantStructType)
+	return ok && v.StructType.equalsTo(vv.StructType)
 }
 
 func (v *variantStructType) toYDB(a *allocator.Allocator) *Ydb.Type {
@@ -736,6 +1088,7 @@ func (v *variantStructType) toYDB(a *allocator.Allocator) *Ydb.Type {
 	return t
 }
 
+// VariantStruct makes struct variant type with named alternatives (such as Variant<'a':Int32,'b':Utf8>)
 func VariantStruct(fields ...StructField) *variantStructType {
 	return &variantStructType{
 		StructType: Struct(fields...),
@@ -746,6 +1099,10 @@ type variantTupleType struct {
 	*TupleType
 }
 
+func (v *variantTupleType) String() string {
+	return v.Yql()
+}
+
 func (v *variantTupleType) Yql() string {
 	buffer := xstring.Buffer()
 	defer buffer.Free()
@@ -761,14 +1118,8 @@ func (v *variantTupleType) Yql() string {
 }
 
 func (v *variantTupleType) equalsTo(rhs Type) bool {
-	switch t := rhs.(type) {
-	case *variantTupleType:
-		return v.TupleType.equalsTo(t.TupleType)
-	case *TupleType:
-		return v.TupleType.equalsTo(t)
-	default:
-		return false
-	}
+	vv, ok := rhs.(*variantTupleType)
+	return ok && v.TupleType.equalsTo(vv.TupleType)
 }
 
 func (v *variantTupleType) toYDB(a *allocator.Allocator) *Ydb.Type {
@@ -788,12 +1139,37 @@ func (v *variantTupleType) toYDB(a *allocator.Allocator) *Ydb.Type {
 	return t
 }
 
+// VariantTuple makes tuple variant type with indexed alternatives (such as Variant<Int32,Utf8>)
 func VariantTuple(items ...Type) *variantTupleType {
 	return &variantTupleType{
 		TupleType: Tuple(items...),
 	}
 }
 
+var errVariantType = errors.New("type of variant alternatives must be a non-empty struct or tuple type")
+
+// Variant makes variant type over alternatives of struct type (as VariantStruct) or tuple type
+// (as VariantTuple). Variant types are returned as is. Variant returns error on other types
+// and on types without alternatives, because server rejects such variant types
+func Variant(t Type) (Type, error) {
+	switch tt := t.(type) {
+	case *StructType:
+		if len(tt.fields) > 0 {
+			return VariantStruct(tt.fields...), nil
+		}
+	case *TupleType:
+		if len(tt.items) > 0 {
+			return VariantTuple(tt.items...), nil
+		}
+	case *variantStructType, *variantTupleType:
+		return t, nil
+	}
+	if t == nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: nil", errVariantType))
+	}
+	return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s", errVariantType, t.Yql()))
+}
+
 type voidType struct{}
 
 func (v voidType) String() string {
@@ -0,0 +1,318 @@
+package value
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+)
+
+var errJSONTypeKind = errors.New("malformed JSON form of type")
+
+// jsonType is a structured JSON form of type. Set of fields depends on kind
+type jsonType struct {
+	Kind      string       `json:"kind"`
+	Name      string       `json:"name,omitempty"`
+	ID        int32        `json:"id,omitempty"`
+	OID       uint32       `json:"oid,omitempty"`
+	Typlen    int32        `json:"typlen,omitempty"`
+	Typmod    int32        `json:"typmod,omitempty"`
+	Precision *uint32      `json:"precision,omitempty"`
+	Scale     *uint32      `json:"scale,omitempty"`
+	Tag       *string      `json:"tag,omitempty"`
+	Item      *jsonType    `json:"item,omitempty"`
+	Key       *jsonType    `json:"key,omitempty"`
+	Value     *jsonType    `json:"value,omitempty"`
+	Items     []*jsonType  `json:"items,omitempty"`
+	Fields    []jsonMember `json:"fields,omitempty"`
+}
+
+type jsonMember struct {
+	Name string    `json:"name"`
+	Type *jsonType `json:"type"`
+}
+
+// TypeToJSON returns structured JSON form of type t (such as
+// {"kind":"optional","item":{"kind":"decimal","precision":22,"scale":9}}).
+//
+// Kinds of types and their fields:
+//   - "primitive" with "name" of primitive type (such as "Int32" or "Utf8");
+//   - "decimal" with "precision" and "scale";
+//   - "optional", "list" and "set" with "item";
+//   - "dict" with "key" and "value";
+//   - "tuple" with "items", "struct" with "fields" (objects with "name" and "type");
+//   - "variant" with "fields" (variant over struct) or "items" (variant over tuple);
+//   - "tagged" with "tag" and "item";
+//   - "pg" with "oid" (and "typlen" and "typmod" if they are not zero);
+//   - "void", "null", "empty_list" and "empty_dict" without fields;
+//   - "unknown" with "id" of primitive type which is not known by SDK.
+//
+// Form of type is stable: fields of JSON objects are written in fixed order and fields of
+// structs are written in order of declaration (which is a part of type), so TypeToJSON returns
+// the same bytes for equal types. TypeFromJSON is an inverse of TypeToJSON.
+//
+// Names of struct fields and tags must be valid UTF-8 strings (as strings of protobuf)
+func TypeToJSON(t Type) ([]byte, error) {
+	jt, err := typeToJSON(t)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(jt)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return data, nil
+}
+
+//nolint:funlen
+func typeToJSON(t Type) (*jsonType, error) {
+	switch tt := t.(type) {
+	case PrimitiveType:
+		return &jsonType{Kind: "primitive", Name: tt.Yql()}, nil
+	case *DecimalType:
+		return &jsonType{Kind: "decimal", Precision: &tt.Precision, Scale: &tt.Scale}, nil
+	case optionalType:
+		return itemTypeToJSON("optional", tt.innerType)
+	case *listType:
+		return itemTypeToJSON("list", tt.itemType)
+	case *setType:
+		return itemTypeToJSON("set", tt.itemType)
+	case *dictType:
+		key, err := typeToJSON(tt.keyType)
+		if err != nil {
+			return nil, err
+		}
+		value, err := typeToJSON(tt.valueType)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonType{Kind: "dict", Key: key, Value: value}, nil
+	case *TupleType:
+		items, err := typesToJSON(tt.items)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonType{Kind: "tuple", Items: items}, nil
+	case *StructType:
+		fields, err := membersToJSON(tt.fields)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonType{Kind: "struct", Fields: fields}, nil
+	case *variantTupleType:
+		items, err := typesToJSON(tt.items)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonType{Kind: "variant", Items: items}, nil
+	case *variantStructType:
+		fields, err := membersToJSON(tt.fields)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonType{Kind: "variant", Fields: fields}, nil
+	case *taggedType:
+		jt, err := itemTypeToJSON("tagged", tt.innerType)
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.ValidString(tt.tag) {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: tag %q is not valid UTF-8", errJSONTypeKind, tt.tag))
+		}
+		jt.Tag = &tt.tag
+		return jt, nil
+	case *pgType:
+		return &jsonType{Kind: "pg", OID: tt.oid, Typlen: tt.typlen, Typmod: tt.typmod}, nil
+	case voidType:
+		return &jsonType{Kind: "void"}, nil
+	case nullType:
+		return &jsonType{Kind: "null"}, nil
+	case emptyListType:
+		return &jsonType{Kind: "empty_list"}, nil
+	case emptyDictType:
+		return &jsonType{Kind: "empty_dict"}, nil
+	case *unknownType:
+		return &jsonType{Kind: "unknown", ID: int32(tt.t.GetTypeId())}, nil
+	case nil:
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: nil type", errJSONTypeKind))
+	default:
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: unsupported type %s", errJSONTypeKind, t.Yql()))
+	}
+}
+
+func itemTypeToJSON(kind string, t Type) (*jsonType, error) {
+	item, err := typeToJSON(t)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonType{Kind: kind, Item: item}, nil
+}
+
+func typesToJSON(types []Type) ([]*jsonType, error) {
+	items := make([]*jsonType, len(types))
+	for i, t := range types {
+		item, err := typeToJSON(t)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+func membersToJSON(fields []StructField) ([]jsonMember, error) {
+	members := make([]jsonMember, len(fields))
+	for i := range fields {
+		if !utf8.ValidString(fields[i].Name) {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: name %q is not valid UTF-8",
+				errJSONTypeKind, fields[i].Name,
+			))
+		}
+		t, err := typeToJSON(fields[i].T)
+		if err != nil {
+			return nil, err
+		}
+		members[i] = jsonMember{Name: fields[i].Name, Type: t}
+	}
+	return members, nil
+}
+
+// TypeFromJSON makes type from structured JSON form of type (see TypeToJSON).
+// TypeFromJSON returns error on unknown kinds, unknown fields and missed fields of kinds
+func TypeFromJSON(data []byte) (Type, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var jt *jsonType
+	if err := decoder.Decode(&jt); err != nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %v", errJSONTypeKind, err))
+	}
+	if decoder.More() {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: data after JSON object", errJSONTypeKind))
+	}
+	return typeFromJSON(jt)
+}
+
+//nolint:gocyclo,funlen
+func typeFromJSON(jt *jsonType) (Type, error) {
+	if jt == nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: missed type", errJSONTypeKind))
+	}
+	switch jt.Kind {
+	case "primitive":
+		t, has := primitiveTypesByName[jt.Name]
+		if !has {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: unknown primitive type %q", errJSONTypeKind, jt.Name))
+		}
+		return t, nil
+	case "decimal":
+		if jt.Precision == nil || jt.Scale == nil {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: decimal without precision or scale", errJSONTypeKind))
+		}
+		t, err := DecimalE(*jt.Precision, *jt.Scale)
+		if err != nil {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %v", errJSONTypeKind, err))
+		}
+		return t, nil
+	case "optional", "list", "set", "tagged":
+		item, err := typeFromJSON(jt.Item)
+		if err != nil {
+			return nil, err
+		}
+		switch jt.Kind {
+		case "optional":
+			return Optional(item), nil
+		case "list":
+			return List(item), nil
+		case "set":
+			return Set(item), nil
+		default:
+			if jt.Tag == nil {
+				return nil, xerrors.WithStackTrace(fmt.Errorf("%w: tagged type without tag", errJSONTypeKind))
+			}
+			return Tagged(*jt.Tag, item), nil
+		}
+	case "dict":
+		key, err := typeFromJSON(jt.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := typeFromJSON(jt.Value)
+		if err != nil {
+			return nil, err
+		}
+		return Dict(key, value), nil
+	case "tuple":
+		items, err := typesFromJSON(jt.Items)
+		if err != nil {
+			return nil, err
+		}
+		return Tuple(items...), nil
+	case "struct":
+		fields, err := membersFromJSON(jt.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return Struct(fields...), nil
+	case "variant":
+		switch {
+		case len(jt.Fields) > 0 && len(jt.Items) == 0:
+			fields, err := membersFromJSON(jt.Fields)
+			if err != nil {
+				return nil, err
+			}
+			return VariantStruct(fields...), nil
+		case len(jt.Items) > 0 && len(jt.Fields) == 0:
+			items, err := typesFromJSON(jt.Items)
+			if err != nil {
+				return nil, err
+			}
+			return VariantTuple(items...), nil
+		default:
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: variant must have either fields or items", errJSONTypeKind))
+		}
+	case "pg":
+		return &pgType{oid: jt.OID, typlen: jt.Typlen, typmod: jt.Typmod}, nil
+	case "void":
+		return Void(), nil
+	case "null":
+		return Null(), nil
+	case "empty_list":
+		return EmptyList(), nil
+	case "empty_dict":
+		return EmptyDict(), nil
+	case "unknown":
+		t := &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_PrimitiveTypeId(jt.ID)}}
+		return typeFromYDB(t)
+	default:
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: unknown kind %q", errJSONTypeKind, jt.Kind))
+	}
+}
+
+func typesFromJSON(items []*jsonType) ([]Type, error) {
+	types := make([]Type, len(items))
+	for i, item := range items {
+		t, err := typeFromJSON(item)
+		if err != nil {
+			return nil, err
+		}
+		types[i] = t
+	}
+	return types, nil
+}
+
+func membersFromJSON(members []jsonMember) ([]StructField, error) {
+	fields := make([]StructField, len(members))
+	for i := range members {
+		t, err := typeFromJSON(members[i].Type)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = StructField{Name: members[i].Name, T: t}
+	}
+	return fields, nil
+}
@@ -0,0 +1,118 @@
+package value
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestTypeToJSON(t *testing.T) {
+	for _, tt := range []struct {
+		t    Type
+		json string
+	}{
+		{
+			t:    Optional(Decimal(22, 9)),
+			json: `{"kind":"optional","item":{"kind":"decimal","precision":22,"scale":9}}`,
+		},
+		{
+			t:    Decimal(10, 0),
+			json: `{"kind":"decimal","precision":10,"scale":0}`,
+		},
+		{
+			t:    Dict(TypeText, Set(TypeUint64)),
+			json: `{"kind":"dict","key":{"kind":"primitive","name":"Utf8"},"value":{"kind":"set","item":{"kind":"primitive","name":"Uint64"}}}`,
+		},
+		{
+			t:    Struct(StructField{Name: "b", T: TypeBool}, StructField{Name: "a", T: List(TypeDate)}),
+			json: `{"kind":"struct","fields":[{"name":"b","type":{"kind":"primitive","name":"Bool"}},{"name":"a","type":{"kind":"list","item":{"kind":"primitive","name":"Date"}}}]}`,
+		},
+		{
+			t:    Tuple(),
+			json: `{"kind":"tuple"}`,
+		},
+		{
+			t:    VariantStruct(StructField{Name: "a", T: TypeInt32}),
+			json: `{"kind":"variant","fields":[{"name":"a","type":{"kind":"primitive","name":"Int32"}}]}`,
+		},
+		{
+			t:    VariantTuple(TypeInt32, Void()),
+			json: `{"kind":"variant","items":[{"kind":"primitive","name":"Int32"},{"kind":"void"}]}`,
+		},
+		{
+			t:    Tagged("", EmptyList()),
+			json: `{"kind":"tagged","tag":"","item":{"kind":"empty_list"}}`,
+		},
+		{
+			t:    &pgType{oid: 1043, typlen: -1, typmod: 14},
+			json: `{"kind":"pg","oid":1043,"typlen":-1,"typmod":14}`,
+		},
+		{
+			t:    TypeFromYDB(&Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: 100500}}),
+			json: `{"kind":"unknown","id":100500}`,
+		},
+	} {
+		t.Run(tt.t.Yql(), func(t *testing.T) {
+			data, err := TypeToJSON(tt.t)
+			require.NoError(t, err)
+			require.Equal(t, tt.json, string(data))
+			act, err := TypeFromJSON(data)
+			require.NoError(t, err)
+			require.True(t, TypesEqual(tt.t, act), act.Yql())
+		})
+	}
+}
+
+func TestTypeJSONRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(42)) //nolint:gosec
+	for i := 0; i < 10000; i++ {
+		exp := randomType(r, r.Intn(5))
+		data, err := TypeToJSON(exp)
+		require.NoError(t, err, exp.Yql())
+		act, err := TypeFromJSON(data)
+		require.NoError(t, err, string(data))
+		require.True(t, TypesEqual(exp, act), string(data))
+		// form of equal types is the same
+		again, err := TypeToJSON(act)
+		require.NoError(t, err)
+		require.Equal(t, string(data), string(again))
+	}
+}
+
+func TestTypeFromJSONErrors(t *testing.T) {
+	for _, data := range []string{
+		``,
+		`null`,
+		`[]`,
+		`{"kind":"primitive","name":"Int33"}`,
+		`{"kind":"unknown_kind"}`,
+		`{"kind":"optional"}`,
+		`{"kind":"decimal","precision":22}`,
+		`{"kind":"decimal","precision":36,"scale":0}`,
+		`{"kind":"dict","key":{"kind":"void"}}`,
+		`{"kind":"tagged","item":{"kind":"void"}}`,
+		`{"kind":"variant"}`,
+		`{"kind":"variant","items":[{"kind":"void"}],"fields":[{"name":"a","type":{"kind":"void"}}]}`,
+		`{"kind":"struct","fields":[{"name":"a"}]}`,
+		`{"kind":"void","extra":1}`,
+		`{"kind":"void"}{"kind":"void"}`,
+	} {
+		t.Run(data, func(t *testing.T) {
+			_, err := TypeFromJSON([]byte(data))
+			require.ErrorIs(t, err, errJSONTypeKind)
+		})
+	}
+}
+
+func TestTypeToJSONInvalidUTF8(t *testing.T) {
+	for _, tt := range []Type{
+		Tagged("\xff", TypeInt32),
+		Struct(StructField{Name: "\xff", T: TypeInt32}),
+		Optional(VariantStruct(StructField{Name: "a\xffb", T: TypeInt32})),
+	} {
+		_, err := TypeToJSON(tt)
+		require.ErrorIs(t, err, errJSONTypeKind)
+	}
+}
@@ -2,6 +2,12 @@ package value
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
 )
 
 func TestTypeToString(t *testing.T) {
@@ -265,11 +271,415 @@ func TestTypeToString(t *testing.T) {
 			),
 			s: "Variant<Bool,Float>",
 		},
+		{
+			t: Optional(Optional(Decimal(35, 10))),
+			s: "Optional<Optional<Decimal(35,10)>>",
+		},
+		{
+			t: Tuple(),
+			s: "Tuple<>",
+		},
+		{
+			t: Tuple(TypeDate32, Optional(TypeInterval64)),
+			s: "Tuple<Date32,Optional<Interval64>>",
+		},
+		{
+			t: Struct(),
+			s: "Struct<>",
+		},
+		{
+			t: Struct(
+				StructField{
+					Name: "id",
+					T:    TypeUint64,
+				},
+				StructField{
+					Name: "it's \\ a\tname",
+					T:    List(TypeText),
+				},
+			),
+			s: `Struct<'id':Uint64,'it\'s \\ a\tname':List<Utf8>>`,
+		},
+		{
+			t: VariantStruct(
+				StructField{
+					Name: "a'b",
+					T:    TypeBool,
+				},
+			),
+			s: `Variant<'a\'b':Bool>`,
+		},
+		{
+			t: Tagged("my\"tag", TypeText),
+			s: `Tagged<Utf8,"my\"tag">`,
+		},
+		{
+			t: Pg(23),
+			s: "pgint4",
+		},
+		{
+			t: Pg(100500),
+			s: "PgType(100500)",
+		},
 	} {
 		t.Run(tt.s, func(t *testing.T) {
 			if got := tt.t.Yql(); got != tt.s {
 				t.Errorf("s representations not equals:\n\n -  got: %s\n\n - want: %s", got, tt.s)
 			}
+			if got := tt.t.String(); got != tt.s {
+				t.Errorf("String() not equals to Yql():\n\n -  got: %s\n\n - want: %s", got, tt.s)
+			}
+		})
+	}
+}
+
+func TestTypesEqual(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		a, b  Type
+		equal bool
+	}{
+		{name: "Primitive", a: TypeInt32, b: TypeInt32, equal: true},
+		{name: "DifferentPrimitives", a: TypeInt32, b: TypeUint32, equal: false},
+		{name: "Decimal", a: Decimal(22, 9), b: Decimal(22, 9), equal: true},
+		{name: "DecimalPrecision", a: Decimal(22, 9), b: Decimal(35, 9), equal: false},
+		{name: "DecimalScale", a: Decimal(22, 9), b: Decimal(22, 0), equal: false},
+		{name: "Optional", a: Optional(Optional(TypeText)), b: Optional(Optional(TypeText)), equal: true},
+		{name: "OptionalDepth", a: Optional(TypeText), b: Optional(Optional(TypeText)), equal: false},
+		{name: "OptionalAndInner", a: Optional(TypeText), b: TypeText, equal: false},
+		{name: "List", a: List(Decimal(22, 9)), b: List(Decimal(22, 9)), equal: true},
+		{name: "ListAndEmptyList", a: List(TypeInt32), b: EmptyList(), equal: false},
+		{name: "Struct", a: Struct(StructField{"a", TypeInt32}), b: Struct(StructField{"a", TypeInt32}), equal: true},
+		{
+			name:  "StructOrder",
+			a:     Struct(StructField{"a", TypeInt32}, StructField{"b", TypeText}),
+			b:     Struct(StructField{"b", TypeText}, StructField{"a", TypeInt32}),
+			equal: false,
+		},
+		{name: "StructName", a: Struct(StructField{"a", TypeInt32}), b: Struct(StructField{"b", TypeInt32}), equal: false},
+		{name: "StructAndTuple", a: Struct(StructField{"a", TypeInt32}), b: Tuple(TypeInt32), equal: false},
+		{name: "Dict", a: Dict(TypeText, TypeInt32), b: Dict(TypeText, TypeInt32), equal: true},
+		{name: "DictPayload", a: Dict(TypeText, TypeInt32), b: Dict(TypeText, TypeInt64), equal: false},
+		{name: "SetAndDictOfVoid", a: Set(TypeText), b: Dict(TypeText, Void()), equal: true},
+		{name: "SetAndDict", a: Set(TypeText), b: Dict(TypeText, TypeText), equal: false},
+		{name: "Tagged", a: Tagged("a", TypeText), b: Tagged("a", TypeText), equal: true},
+		{name: "TaggedTag", a: Tagged("a", TypeText), b: Tagged("b", TypeText), equal: false},
+		{name: "TaggedAndInner", a: Tagged("a", TypeText), b: TypeText, equal: false},
+		{name: "VariantTuple", a: VariantTuple(TypeText, TypeInt32), b: VariantTuple(TypeText, TypeInt32), equal: true},
+		{name: "VariantTupleAndTuple", a: VariantTuple(TypeText, TypeInt32), b: Tuple(TypeText, TypeInt32), equal: false},
+		{
+			name:  "VariantStructAndStruct",
+			a:     VariantStruct(StructField{"a", TypeText}),
+			b:     Struct(StructField{"a", TypeText}),
+			equal: false,
+		},
+		{
+			name:  "VariantStructAndVariantTuple",
+			a:     VariantStruct(StructField{"a", TypeText}),
+			b:     VariantTuple(TypeText),
+			equal: false,
+		},
+		{name: "Pg", a: Pg(23), b: Pg(23), equal: true},
+		{name: "PgOID", a: Pg(23), b: Pg(20), equal: false},
+		{name: "Void", a: Void(), b: Void(), equal: true},
+		{name: "VoidAndNull", a: Void(), b: Null(), equal: false},
+		{name: "Nil", a: nil, b: nil, equal: true},
+		{name: "NilAndType", a: TypeText, b: nil, equal: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.equal, TypesEqual(tt.a, tt.b))
+			require.Equal(t, tt.equal, TypesEqual(tt.b, tt.a))
+		})
+	}
+	t.Run("SameStringDifferentTypes", func(t *testing.T) {
+		// pg types differ by typmod (such as varchar(10) and varchar(20)), but have the same name
+		a, b := &pgType{oid: 1043, typmod: 14}, &pgType{oid: 1043, typmod: 24}
+		require.Equal(t, a.Yql(), b.Yql())
+		require.False(t, TypesEqual(a, b))
+	})
+}
+
+func TestOptionalTypeHelpers(t *testing.T) {
+	for _, tt := range []struct {
+		t     Type
+		depth int
+		inner Type
+	}{
+		{
+			t:     TypeInt32,
+			depth: 0,
+			inner: TypeInt32,
+		},
+		{
+			t:     Optional(TypeInt32),
+			depth: 1,
+			inner: TypeInt32,
+		},
+		{
+			t:     Optional(Optional(TypeInt32)),
+			depth: 2,
+			inner: Optional(TypeInt32),
+		},
+		{
+			t:     Optional(Optional(Optional(List(Optional(TypeText))))),
+			depth: 3,
+			inner: Optional(Optional(List(Optional(TypeText)))),
+		},
+		{
+			t:     List(Optional(TypeInt32)),
+			depth: 0,
+			inner: List(Optional(TypeInt32)),
+		},
+		{
+			t:     Tagged("tag", Optional(TypeInt32)),
+			depth: 0,
+			inner: Tagged("tag", Optional(TypeInt32)),
+		},
+	} {
+		t.Run(tt.t.Yql(), func(t *testing.T) {
+			require.Equal(t, tt.depth > 0, IsOptional(tt.t))
+			require.Equal(t, tt.depth, OptionalDepth(tt.t))
+			inner, wasOptional := UnwrapOptional(tt.t)
+			require.Equal(t, tt.depth > 0, wasOptional)
+			require.True(t, TypesEqual(tt.inner, inner), inner.Yql())
+		})
+	}
+}
+
+func TestStructTypeFields(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		t       *StructType
+		indexes map[string]int
+	}{
+		{
+			name:    "empty",
+			t:       Struct(),
+			indexes: map[string]int{"a": -1},
+		},
+		{
+			name: "sorted",
+			t: Struct(
+				StructField{Name: "a", T: TypeInt32},
+				StructField{Name: "b", T: TypeText},
+				StructField{Name: "d", T: Optional(TypeBool)},
+			),
+			indexes: map[string]int{"a": 0, "b": 1, "d": 2, "c": -1, "": -1, "e": -1},
+		},
+		{
+			name: "unsorted",
+			t: Struct(
+				StructField{Name: "d", T: Optional(TypeBool)},
+				StructField{Name: "a", T: TypeInt32},
+				StructField{Name: "b", T: TypeText},
+			),
+			indexes: map[string]int{"d": 0, "a": 1, "b": 2, "c": -1, "": -1, "e": -1},
+		},
+		{
+			name: "sorted duplicates",
+			t: Struct(
+				StructField{Name: "a", T: TypeInt32},
+				StructField{Name: "b", T: TypeText},
+				StructField{Name: "b", T: TypeBool},
+			),
+			indexes: map[string]int{"a": 0, "b": 1, "c": -1},
+		},
+		{
+			name: "unsorted duplicates",
+			t: Struct(
+				StructField{Name: "b", T: TypeText},
+				StructField{Name: "a", T: TypeInt32},
+				StructField{Name: "b", T: TypeBool},
+			),
+			indexes: map[string]int{"b": 0, "a": 1, "c": -1},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, st := range []interface {
+				Len() int
+				Field(i int) StructField
+				FieldIndex(name string) (int, bool)
+			}{
+				tt.t,
+				VariantStruct(tt.t.fields...),
+			} {
+				require.Equal(t, len(tt.t.fields), st.Len())
+				for i := 0; i < st.Len(); i++ {
+					require.Equal(t, tt.t.fields[i], st.Field(i))
+				}
+				for name, exp := range tt.indexes {
+					idx, ok := st.FieldIndex(name)
+					require.Equal(t, exp, idx, name)
+					require.Equal(t, exp >= 0, ok, name)
+				}
+			}
+		})
+	}
+}
+
+func TestVariantType(t *testing.T) {
+	primitive := func(id Ydb.Type_PrimitiveTypeId) *Ydb.Type {
+		return &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: id}}
+	}
+	for _, tt := range []struct {
+		name     string
+		fromYDB  *Ydb.Type
+		variants []Type
+	}{
+		{
+			name: "struct",
+			fromYDB: &Ydb.Type{Type: &Ydb.Type_VariantType{VariantType: &Ydb.VariantType{
+				Type: &Ydb.VariantType_StructItems{StructItems: &Ydb.StructType{Members: []*Ydb.StructMember{
+					{Name: "a", Type: primitive(Ydb.Type_INT32)},
+					{Name: "b", Type: primitive(Ydb.Type_UTF8)},
+				}}},
+			}}},
+			variants: []Type{
+				VariantStruct(StructField{Name: "a", T: TypeInt32}, StructField{Name: "b", T: TypeText}),
+				func() Type {
+					v, err := Variant(Struct(StructField{Name: "a", T: TypeInt32}, StructField{Name: "b", T: TypeText}))
+					require.NoError(t, err)
+					return v
+				}(),
+			},
+		},
+		{
+			name: "tuple",
+			fromYDB: &Ydb.Type{Type: &Ydb.Type_VariantType{VariantType: &Ydb.VariantType{
+				Type: &Ydb.VariantType_TupleItems{TupleItems: &Ydb.TupleType{Elements: []*Ydb.Type{
+					primitive(Ydb.Type_INT32),
+					primitive(Ydb.Type_UTF8),
+				}}},
+			}}},
+			variants: []Type{
+				VariantTuple(TypeInt32, TypeText),
+				func() Type {
+					v, err := Variant(Tuple(TypeInt32, TypeText))
+					require.NoError(t, err)
+					return v
+				}(),
+				func() Type {
+					v, err := Variant(VariantTuple(TypeInt32, TypeText))
+					require.NoError(t, err)
+					return v
+				}(),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fromYDB := TypeFromYDB(tt.fromYDB)
+			for _, v := range tt.variants {
+				require.True(t, TypesEqual(fromYDB, v), v.Yql())
+
+				a := allocator.New()
+				require.True(t, proto.Equal(tt.fromYDB, TypeToYDB(v, a)), v.Yql())
+				a.Free()
+
+				parsed, err := ParseType(v.Yql())
+				require.NoError(t, err)
+				require.True(t, TypesEqual(parsed, v), v.Yql())
+			}
+		})
+	}
+	t.Run("errors", func(t *testing.T) {
+		for _, tt := range []Type{nil, TypeInt32, List(TypeInt32), Struct(), Tuple(), Optional(Tuple(TypeInt32))} {
+			_, err := Variant(tt)
+			require.ErrorIs(t, err, errVariantType)
+		}
+	})
+}
+
+func TestContainerTypeAccessors(t *testing.T) {
+	a := allocator.New()
+	defer a.Free()
+	for _, tt := range []struct {
+		name      string
+		t         Type
+		itemType  Type
+		keyType   Type
+		valueType Type
+	}{
+		{
+			name:     "List",
+			t:        List(Optional(TypeText)),
+			itemType: Optional(TypeText),
+		},
+		{
+			name:      "Dict",
+			t:         Dict(TypeText, List(TypeInt64)),
+			keyType:   TypeText,
+			valueType: List(TypeInt64),
+		},
+		{
+			name:      "Set",
+			t:         Set(Decimal(22, 9)),
+			itemType:  Decimal(22, 9),
+			keyType:   Decimal(22, 9),
+			valueType: Void(),
+		},
+		{
+			name:      "DictOfVoid",
+			t:         Dict(TypeUint64, Void()),
+			itemType:  TypeUint64,
+			keyType:   TypeUint64,
+			valueType: Void(),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// types decoded from wire
+			fromYDB := TypeFromYDB(TypeToYDB(tt.t, a))
+
+			listType, isList := fromYDB.(ListType)
+			require.Equal(t, tt.itemType != nil, isList)
+			if isList {
+				require.True(t, TypesEqual(tt.itemType, listType.ItemType()), listType.ItemType().Yql())
+			}
+
+			dictType, isDict := fromYDB.(DictType)
+			require.Equal(t, tt.keyType != nil, isDict)
+			if isDict {
+				require.True(t, TypesEqual(tt.keyType, dictType.KeyType()), dictType.KeyType().Yql())
+				require.True(t, TypesEqual(tt.valueType, dictType.ValueType()), dictType.ValueType().Yql())
+			}
+		})
+	}
+	t.Run("EmptyContainers", func(t *testing.T) {
+		for _, tt := range []Type{EmptyList(), EmptyDict(), Tuple(TypeInt32), Optional(List(TypeInt32))} {
+			_, isList := tt.(ListType)
+			require.False(t, isList)
+			_, isDict := tt.(DictType)
+			require.False(t, isDict)
+		}
+	})
+}
+
+func TestDecimalE(t *testing.T) {
+	for _, tt := range []struct {
+		precision uint32
+		scale     uint32
+		valid     bool
+	}{
+		{precision: DefaultDecimalPrecision, scale: DefaultDecimalScale, valid: true},
+		{precision: 1, scale: 0, valid: true},
+		{precision: 1, scale: 1, valid: true},
+		{precision: 35, scale: 0, valid: true},
+		{precision: 35, scale: 35, valid: true},
+		{precision: 0, scale: 0},
+		{precision: 36, scale: 0},
+		{precision: 38, scale: 9},
+		{precision: 9, scale: 10},
+		{precision: 22, scale: 0xFFFFFFFF},
+	} {
+		t.Run(Decimal(tt.precision, tt.scale).Yql(), func(t *testing.T) {
+			typ, err := DecimalE(tt.precision, tt.scale)
+			if !tt.valid {
+				require.ErrorIs(t, err, errDecimalType)
+				require.ErrorIs(t, CheckDecimalType(tt.precision, tt.scale), errDecimalType)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, CheckDecimalType(tt.precision, tt.scale))
+			require.True(t, TypesEqual(Decimal(tt.precision, tt.scale), typ))
 		})
 	}
 }
@@ -2,11 +2,15 @@ package value
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +22,12 @@ import (
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xstring"
 )
 
+// Value is an immutable YDB value
+//
+// Constructed values are never modified (constructors copy slices which they reorder),
+// so the same value is safe for concurrent use from multiple goroutines, including
+// concurrent serialization with ToYDB. Slices passed to constructors must not be
+// modified by caller after construction.
 type Value interface {
 	Type() Type
 	Yql() string
@@ -42,31 +52,46 @@ func BigEndianUint128(hi, lo uint64) (v [16]byte) {
 	return v
 }
 
+// Uint128FromBytes splits a big-endian uint128 value into high and low halves.
+// Uint128FromBytes is an inverse of BigEndianUint128
+func Uint128FromBytes(v [16]byte) (hi, lo uint64) {
+	return binary.BigEndian.Uint64(v[0:8]), binary.BigEndian.Uint64(v[8:16])
+}
+
+// FromYDB makes value from YDB type and value.
+// FromYDB panics on malformed or unexpected data, use FromYDBWithError instead
 func FromYDB(t *Ydb.Type, v *Ydb.Value) Value {
-	vv, err := fromYDB(t, v)
+	vv, err := FromYDBWithError(t, v)
 	if err != nil {
 		panic(err)
 	}
 	return vv
 }
 
+// FromYDBWithError makes value from YDB type and value.
+// FromYDBWithError returns error on malformed or unexpected data
+// (such as unknown types, missed items of containers or nested values of variants)
+func FromYDBWithError(t *Ydb.Type, v *Ydb.Value) (Value, error) {
+	tt, err := typeFromYDB(t)
+	if err != nil {
+		return nil, err
+	}
+	return fromYDB(tt, v)
+}
+
+// nullValueFromYDB checks null flag of top level value only.
+// Null flag in nested value of Optional<Optional<T>> means Just(Nothing(T)), not a null.
 func nullValueFromYDB(x *Ydb.Value, t Type) (_ Value, ok bool) {
-	for {
-		switch xx := x.Value.(type) {
-		case *Ydb.Value_NestedValue:
-			x = xx.NestedValue
-		case *Ydb.Value_NullFlagValue:
-			switch tt := t.(type) {
-			case optionalType:
-				return NullValue(tt.innerType), true
-			case voidType:
-				return VoidValue(), true
-			default:
-				return nil, false
-			}
-		default:
-			return nil, false
-		}
+	if _, isNull := x.Value.(*Ydb.Value_NullFlagValue); !isNull {
+		return nil, false
+	}
+	switch tt := t.(type) {
+	case optionalType:
+		return NullValue(tt.innerType), true
+	case voidType:
+		return VoidValue(), true
+	default:
+		return nil, false
 	}
 }
 
@@ -111,6 +136,18 @@ func primitiveValueFromYDB(t PrimitiveType, v *Ydb.Value) (Value, error) {
 	case TypeTimestamp:
 		return TimestampValue(v.GetUint64Value()), nil
 
+	case TypeDate32:
+		return Date32Value(v.GetInt32Value()), nil
+
+	case TypeDatetime64:
+		return Datetime64Value(v.GetInt64Value()), nil
+
+	case TypeTimestamp64:
+		return Timestamp64Value(v.GetInt64Value()), nil
+
+	case TypeInterval64:
+		return Interval64Value(v.GetInt64Value()), nil
+
 	case TypeFloat:
 		return FloatValue(v.GetFloatValue()), nil
 
@@ -159,16 +196,18 @@ func primitiveValueFromYDB(t PrimitiveType, v *Ydb.Value) (Value, error) {
 	}
 }
 
-func fromYDB(t *Ydb.Type, v *Ydb.Value) (Value, error) {
-	tt := TypeFromYDB(t)
+func fromYDB(t Type, v *Ydb.Value) (Value, error) {
+	if v == nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: nil value of type %s", errMalformedValue, t.Yql()))
+	}
 
-	if vv, ok := nullValueFromYDB(v, tt); ok {
+	if vv, ok := nullValueFromYDB(v, t); ok {
 		return vv, nil
 	}
 
-	switch ttt := tt.(type) {
+	switch tt := t.(type) {
 	case PrimitiveType:
-		return primitiveValueFromYDB(ttt, v)
+		return primitiveValueFromYDB(tt, v)
 
 	case voidType:
 		return VoidValue(), nil
@@ -176,104 +215,179 @@ func fromYDB(t *Ydb.Type, v *Ydb.Value) (Value, error) {
 	case nullType:
 		return NullValue(tt), nil
 
+	case emptyListType:
+		if len(v.GetItems()) > 0 {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %d items of %s",
+				errMalformedValue, len(v.GetItems()), tt.Yql(),
+			))
+		}
+		return &listValue{t: tt}, nil
+
+	case emptyDictType:
+		if len(v.GetPairs()) > 0 {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %d pairs of %s",
+				errMalformedValue, len(v.GetPairs()), tt.Yql(),
+			))
+		}
+		return &dictValue{t: tt}, nil
+
+	case *unknownType:
+		return &rawValue{t: tt, v: v}, nil
+
 	case *DecimalType:
-		return DecimalValue(BigEndianUint128(v.High_128, v.GetLow_128()), ttt.Precision, ttt.Scale), nil
+		return DecimalValue(BigEndianUint128(v.High_128, v.GetLow_128()), tt.Precision, tt.Scale), nil
+
+	case *pgType:
+		return pgValueFromYDB(tt, v)
+
+	case *taggedType:
+		// value of tagged type is a value of inner type on wire
+		vv, err := fromYDB(tt.innerType, v)
+		if err != nil {
+			return nil, err
+		}
+		return &taggedValue{t: tt, value: vv}, nil
 
 	case optionalType:
-		t = t.Type.(*Ydb.Type_OptionalType).OptionalType.Item
-		if nestedValue, ok := v.Value.(*Ydb.Value_NestedValue); ok {
-			return OptionalValue(FromYDB(t, nestedValue.NestedValue)), nil
+		// nested value wraps value of optional item type only,
+		// values of other item types (such as variants) may have own nested values
+		if _, isOptional := tt.innerType.(optionalType); isOptional {
+			if nestedValue, ok := v.Value.(*Ydb.Value_NestedValue); ok {
+				v = nestedValue.NestedValue
+			}
 		}
-		return OptionalValue(FromYDB(t, v)), nil
+		vv, err := fromYDB(tt.innerType, v)
+		if err != nil {
+			return nil, err
+		}
+		return OptionalValue(vv), nil
 
 	case *listType:
-		return ListValue(func() []Value {
-			vv := make([]Value, len(v.GetItems()))
-			a := allocator.New()
-			defer a.Free()
-			for i, vvv := range v.GetItems() {
-				vv[i] = FromYDB(ttt.itemType.toYDB(a), vvv)
-			}
-			return vv
-		}()...), nil
+		items, err := itemsFromYDB(func(int) Type { return tt.itemType }, v.GetItems())
+		if err != nil {
+			return nil, err
+		}
+		return &listValue{
+			t:     tt,
+			items: items,
+		}, nil
 
 	case *TupleType:
-		return TupleValue(func() []Value {
-			vv := make([]Value, len(v.GetItems()))
-			a := allocator.New()
-			defer a.Free()
-			for i, vvv := range v.GetItems() {
-				vv[i] = FromYDB(ttt.items[i].toYDB(a), vvv)
-			}
-			return vv
-		}()...), nil
+		if len(v.GetItems()) != len(tt.items) {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %d items of %s",
+				errMalformedValue, len(v.GetItems()), tt.Yql(),
+			))
+		}
+		items, err := itemsFromYDB(func(i int) Type { return tt.items[i] }, v.GetItems())
+		if err != nil {
+			return nil, err
+		}
+		return TupleValue(items...), nil
 
 	case *StructType:
-		return StructValue(func() []StructValueField {
-			vv := make([]StructValueField, len(v.GetItems()))
-			a := allocator.New()
-			defer a.Free()
-			for i, vvv := range v.GetItems() {
-				vv[i] = StructValueField{
-					Name: ttt.fields[i].Name,
-					V:    FromYDB(ttt.fields[i].T.toYDB(a), vvv),
-				}
+		if len(v.GetItems()) != len(tt.fields) {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %d items of %s",
+				errMalformedValue, len(v.GetItems()), tt.Yql(),
+			))
+		}
+		items, err := itemsFromYDB(func(i int) Type { return tt.fields[i].T }, v.GetItems())
+		if err != nil {
+			return nil, err
+		}
+		fields := make([]StructValueField, len(items))
+		for i := range items {
+			fields[i] = StructValueField{
+				Name: tt.fields[i].Name,
+				V:    items[i],
 			}
-			return vv
-		}()...), nil
+		}
+		return structValueOfType(tt, fields), nil
 
 	case *dictType:
-		return DictValue(func() []DictValueField {
-			vv := make([]DictValueField, len(v.GetPairs()))
-			a := allocator.New()
-			defer a.Free()
-			for i, vvv := range v.GetPairs() {
-				vv[i] = DictValueField{
-					K: FromYDB(ttt.keyType.toYDB(a), vvv.Key),
-					V: FromYDB(ttt.valueType.toYDB(a), vvv.Payload),
-				}
+		fields := make([]DictValueField, len(v.GetPairs()))
+		for i, pair := range v.GetPairs() {
+			k, err := fromYDB(tt.keyType, pair.GetKey())
+			if err != nil {
+				return nil, err
+			}
+			vv, err := fromYDB(tt.valueType, pair.GetPayload())
+			if err != nil {
+				return nil, err
 			}
-			return vv
-		}()...), nil
+			fields[i] = DictValueField{
+				K: k,
+				V: vv,
+			}
+		}
+		vv := DictValue(fields...)
+		vv.t = tt
+		return vv, nil
 
 	case *setType:
-		return SetValue(func() []Value {
-			vv := make([]Value, len(v.GetPairs()))
-			a := allocator.New()
-			defer a.Free()
-			for i, vvv := range v.GetPairs() {
-				vv[i] = FromYDB(ttt.itemType.toYDB(a), vvv.Key)
+		items := make([]Value, len(v.GetPairs()))
+		for i, pair := range v.GetPairs() {
+			item, err := fromYDB(tt.itemType, pair.GetKey())
+			if err != nil {
+				return nil, err
 			}
-			return vv
-		}()...), nil
+			items[i] = item
+		}
+		vv := SetValue(items...)
+		vv.t = tt
+		return vv, nil
 
 	case *variantStructType:
-		a := allocator.New()
-		defer a.Free()
-		return VariantValueStruct(
-			FromYDB(
-				ttt.StructType.fields[v.VariantIndex].T.toYDB(a),
-				v.Value.(*Ydb.Value_NestedValue).NestedValue,
-			),
-			ttt.StructType.fields[v.VariantIndex].Name,
-			ttt.StructType,
-		), nil
+		if v.VariantIndex >= uint32(len(tt.StructType.fields)) {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: variant index %d of %s",
+				errMalformedValue, v.VariantIndex, tt.Yql(),
+			))
+		}
+		field := tt.StructType.fields[v.VariantIndex]
+		vv, err := variantItemFromYDB(field.T, v)
+		if err != nil {
+			return nil, err
+		}
+		return VariantValueStruct(vv, field.Name, tt.StructType), nil
 
 	case *variantTupleType:
-		a := allocator.New()
-		defer a.Free()
-		return VariantValueTuple(
-			FromYDB(
-				ttt.TupleType.items[v.VariantIndex].toYDB(a),
-				v.Value.(*Ydb.Value_NestedValue).NestedValue,
-			),
-			v.VariantIndex,
-			ttt.TupleType,
-		), nil
+		if v.VariantIndex >= uint32(len(tt.TupleType.items)) {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: variant index %d of %s",
+				errMalformedValue, v.VariantIndex, tt.Yql(),
+			))
+		}
+		vv, err := variantItemFromYDB(tt.TupleType.items[v.VariantIndex], v)
+		if err != nil {
+			return nil, err
+		}
+		return VariantValueTuple(vv, v.VariantIndex, tt.TupleType), nil
 
 	default:
-		return nil, xerrors.WithStackTrace(fmt.Errorf("uncovered type: %T", ttt))
+		return nil, xerrors.WithStackTrace(fmt.Errorf("uncovered type: %T", tt))
+	}
+}
+
+// itemsFromYDB makes values of items of list, tuple or struct with types of items by their indexes
+func itemsFromYDB(itemType func(i int) Type, items []*Ydb.Value) ([]Value, error) {
+	vv := make([]Value, len(items))
+	for i, item := range items {
+		v, err := fromYDB(itemType(i), item)
+		if err != nil {
+			return nil, err
+		}
+		vv[i] = v
+	}
+	return vv, nil
+}
+
+// variantItemFromYDB makes value of variant item from nested value of variant
+func variantItemFromYDB(t Type, v *Ydb.Value) (Value, error) {
+	nestedValue, ok := v.Value.(*Ydb.Value_NestedValue)
+	if !ok {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: variant value without nested value: %T",
+			errMalformedValue, v.Value,
+		))
 	}
+	return fromYDB(t, nestedValue.NestedValue)
 }
 
 type boolValue bool
@@ -287,7 +401,10 @@ func (v boolValue) castTo(dst interface{}) error {
 		*vv = strconv.FormatBool(bool(v))
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
@@ -295,6 +412,10 @@ func (v boolValue) Yql() string {
 	return strconv.FormatBool(bool(v))
 }
 
+func (v boolValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (boolValue) Type() Type {
 	return TypeBool
 }
@@ -327,16 +448,32 @@ func (v dateValue) castTo(dst interface{}) error {
 	case *int64:
 		*vv = int64(v)
 		return nil
+	case *uint:
+		*vv = uint(v)
+		return nil
+	case *int:
+		if err := checkIntFits(int64(v), strconv.IntSize); err != nil {
+			return castError(uint32(v), v.Type(), vv, err)
+		}
+		*vv = int(v)
+		return nil
 	case *int32:
 		*vv = int32(v)
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v dateValue) Yql() string {
-	return fmt.Sprintf("%s(%q)", v.Type().Yql(), DateToTime(uint32(v)).UTC().Format(LayoutDate))
+	return typedYql(v.Type().Yql(), DateToTime(uint32(v)).UTC().Format(LayoutDate))
+}
+
+func (v dateValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
 }
 
 func (dateValue) Type() Type {
@@ -359,8 +496,92 @@ func DateValue(v uint32) dateValue {
 	return dateValue(v)
 }
 
+// DateValueFromTime makes Date value with UTC calendar date of t.
+// Dates out of supported range are wrapped, use DateValueFromTimeE for checking of range
 func DateValueFromTime(t time.Time) dateValue {
-	return dateValue(uint64(t.Sub(epoch)/time.Second) / secondsPerDay)
+	return dateValue(daysSinceEpoch(t))
+}
+
+// DateValueFromTimeE makes Date value with UTC calendar date of t
+// or returns error if date is out of supported range [1970-01-01, 2105-12-31]
+func DateValueFromTimeE(t time.Time) (dateValue, error) {
+	days := daysSinceEpoch(t)
+	if days < 0 || days > maxDate {
+		return 0, timeRangeError(t, TypeDate.Yql(), LayoutDate, epoch, DateToTime(maxDate))
+	}
+	return dateValue(days), nil
+}
+
+func daysSinceEpoch(t time.Time) int64 {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / int64(secondsPerDay)
+}
+
+type date32Value int32
+
+func (v date32Value) castTo(dst interface{}) error {
+	switch vv := dst.(type) {
+	case *time.Time:
+		*vv = Date32ToTime(int32(v))
+		return nil
+	case *int64:
+		*vv = int64(v)
+		return nil
+	case *int32:
+		*vv = int32(v)
+		return nil
+	case *int:
+		*vv = int(v)
+		return nil
+	default:
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+	}
+}
+
+func (v date32Value) Yql() string {
+	return typedYql(v.Type().Yql(), Date32ToTime(int32(v)).UTC().Format(LayoutDate))
+}
+
+func (v date32Value) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
+func (date32Value) Type() Type {
+	return TypeDate32
+}
+
+func (v date32Value) toYDB(a *allocator.Allocator) *Ydb.Value {
+	vv := a.Int32()
+	vv.Int32Value = int32(v)
+
+	vvv := a.Value()
+	vvv.Value = vv
+
+	return vvv
+}
+
+// Date32Value makes Date32 value by given days since Epoch (negative for dates before 1970)
+func Date32Value(v int32) date32Value {
+	return date32Value(v)
+}
+
+// Date32ValueFromTime makes Date32 value with UTC calendar date of t.
+// Dates out of supported range are wrapped, use Date32ValueFromTimeE for checking of range
+func Date32ValueFromTime(t time.Time) date32Value {
+	return date32Value(daysSinceEpoch(t))
+}
+
+// Date32ValueFromTimeE makes Date32 value with UTC calendar date of t
+// or returns error if date is out of supported range [-144168-01-01, 148107-12-31]
+func Date32ValueFromTimeE(t time.Time) (date32Value, error) {
+	days := daysSinceEpoch(t)
+	if days < minDate32 || days > maxDate32 {
+		return 0, timeRangeError(t, TypeDate32.Yql(), LayoutDate, Date32ToTime(minDate32), Date32ToTime(maxDate32))
+	}
+	return date32Value(days), nil
 }
 
 type datetimeValue uint32
@@ -376,16 +597,32 @@ func (v datetimeValue) castTo(dst interface{}) error {
 	case *int64:
 		*vv = int64(v)
 		return nil
+	case *uint:
+		*vv = uint(v)
+		return nil
+	case *int:
+		if err := checkIntFits(int64(v), strconv.IntSize); err != nil {
+			return castError(uint32(v), v.Type(), vv, err)
+		}
+		*vv = int(v)
+		return nil
 	case *uint32:
 		*vv = uint32(v)
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v datetimeValue) Yql() string {
-	return fmt.Sprintf("%s(%q)", v.Type().Yql(), DatetimeToTime(uint32(v)).UTC().Format(LayoutDatetime))
+	return typedYql(v.Type().Yql(), DatetimeToTime(uint32(v)).UTC().Format(LayoutDatetime))
+}
+
+func (v datetimeValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
 }
 
 func (datetimeValue) Type() Type {
@@ -407,10 +644,89 @@ func DatetimeValue(v uint32) datetimeValue {
 	return datetimeValue(v)
 }
 
+// DatetimeValueFromTime makes Datetime value from t truncated to seconds.
+// Times out of supported range are wrapped, use DatetimeValueFromTimeE for checking of range
 func DatetimeValueFromTime(t time.Time) datetimeValue {
 	return datetimeValue(t.Unix())
 }
 
+// DatetimeValueFromTimeE makes Datetime value from t truncated to seconds or returns error
+// if t is out of supported range [1970-01-01T00:00:00Z, 2105-12-31T23:59:59Z]
+func DatetimeValueFromTimeE(t time.Time) (datetimeValue, error) {
+	if seconds := t.Unix(); seconds < 0 || seconds > maxDatetime {
+		return 0, timeRangeError(t, TypeDatetime.Yql(), LayoutDatetime, epoch, DatetimeToTime(uint32(maxDatetime)))
+	}
+	return datetimeValue(t.Unix()), nil
+}
+
+type datetime64Value int64
+
+func (v datetime64Value) castTo(dst interface{}) error {
+	switch vv := dst.(type) {
+	case *time.Time:
+		*vv = Datetime64ToTime(int64(v))
+		return nil
+	case *int64:
+		*vv = int64(v)
+		return nil
+	case *int:
+		if err := checkIntFits(int64(v), strconv.IntSize); err != nil {
+			return castError(int64(v), v.Type(), vv, err)
+		}
+		*vv = int(v)
+		return nil
+	default:
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+	}
+}
+
+func (v datetime64Value) Yql() string {
+	return typedYql(v.Type().Yql(), Datetime64ToTime(int64(v)).UTC().Format(LayoutDatetime))
+}
+
+func (v datetime64Value) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
+func (datetime64Value) Type() Type {
+	return TypeDatetime64
+}
+
+func (v datetime64Value) toYDB(a *allocator.Allocator) *Ydb.Value {
+	vv := a.Int64()
+	vv.Int64Value = int64(v)
+
+	vvv := a.Value()
+	vvv.Value = vv
+
+	return vvv
+}
+
+// Datetime64Value makes Datetime64 value from seconds since Epoch (negative for times before 1970)
+func Datetime64Value(v int64) datetime64Value {
+	return datetime64Value(v)
+}
+
+// Datetime64ValueFromTime makes Datetime64 value from t truncated to seconds.
+// Range of t is not checked, use Datetime64ValueFromTimeE for checking of range
+func Datetime64ValueFromTime(t time.Time) datetime64Value {
+	return datetime64Value(t.Unix())
+}
+
+// Datetime64ValueFromTimeE makes Datetime64 value from t truncated to seconds or returns error
+// if t is out of supported range [-144168-01-01T00:00:00Z, 148107-12-31T23:59:59Z]
+func Datetime64ValueFromTimeE(t time.Time) (datetime64Value, error) {
+	if seconds := t.Unix(); seconds < minDatetime64 || seconds > maxDatetime64 {
+		return 0, timeRangeError(t, TypeDatetime64.Yql(), LayoutDatetime,
+			Datetime64ToTime(minDatetime64), Datetime64ToTime(maxDatetime64),
+		)
+	}
+	return datetime64Value(t.Unix()), nil
+}
+
 var _ DecimalValuer = (*decimalValue)(nil)
 
 type decimalValue struct {
@@ -430,14 +746,52 @@ func (v *decimalValue) Scale() uint32 {
 	return v.innerType.Scale
 }
 
+// Int128 returns underlying 128-bit two's complement integer of decimal (unscaled value).
+// Unlike of decimal.FromInt128 values which are out of precision are not turned into infinity
+func (v *decimalValue) Int128() *big.Int {
+	x := big.NewInt(0).SetBytes(v.value[:])
+	if v.value[0]&0x80 != 0 {
+		x.Sub(x, big.NewInt(0).Lsh(big.NewInt(1), 128))
+	}
+	return x
+}
+
 type DecimalValuer interface {
 	Value() [16]byte
+	Int128() *big.Int
 	Precision() uint32
 	Scale() uint32
 }
 
+// DecimalSetter is an interface of decimal destinations (such as wrappers of
+// github.com/shopspring/decimal) which are set from unscaled value and scale:
+// decimal value is equal to unscaled * 10^(-scale)
+type DecimalSetter interface {
+	SetDecimal(unscaled *big.Int, scale int32) error
+}
+
 func (v *decimalValue) castTo(dst interface{}) error {
-	return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' to '%T' destination", v, dst))
+	switch vv := dst.(type) {
+	case *big.Int:
+		// unscaled value: decimal value is equal to unscaled * 10^(-scale)
+		unscaled := decimal.FromInt128(v.value, v.innerType.Precision, v.innerType.Scale)
+		if decimal.IsInf(unscaled) || decimal.IsNaN(unscaled) || decimal.IsErr(unscaled) {
+			return castError(v.Yql(), v.Type(), dst, errValueNotFiniteReal)
+		}
+		vv.Set(unscaled)
+		return nil
+	case DecimalSetter:
+		unscaled := decimal.FromInt128(v.value, v.innerType.Precision, v.innerType.Scale)
+		if decimal.IsInf(unscaled) || decimal.IsNaN(unscaled) || decimal.IsErr(unscaled) {
+			return castError(v.Yql(), v.Type(), dst, errValueNotFiniteReal)
+		}
+		if err := vv.SetDecimal(unscaled, int32(v.innerType.Scale)); err != nil {
+			return castError(v.Yql(), v.Type(), dst, err)
+		}
+		return nil
+	default:
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' to '%T' destination", v, dst))
+	}
 }
 
 func (v *decimalValue) Yql() string {
@@ -446,8 +800,12 @@ func (v *decimalValue) Yql() string {
 	buffer.WriteString(v.innerType.Name())
 	buffer.WriteByte('(')
 	buffer.WriteByte('"')
-	s := decimal.FromBytes(v.value[:], v.innerType.Precision, v.innerType.Scale).String()
-	buffer.WriteString(s[:len(s)-int(v.innerType.Scale)] + "." + s[len(s)-int(v.innerType.Scale):])
+	x := decimal.FromBytes(v.value[:], v.innerType.Precision, v.innerType.Scale)
+	// FromBytes turns out of precision values (including nan) into infinity
+	if raw := decimal.FromBytes(v.value[:], decimal.MaxPrecision+1, v.innerType.Scale); decimal.IsNaN(raw) {
+		x = raw
+	}
+	buffer.WriteString(decimalYql(x, v.innerType.Scale))
 	buffer.WriteByte('"')
 	buffer.WriteByte(',')
 	buffer.WriteString(strconv.FormatUint(uint64(v.innerType.Precision), 10))
@@ -457,6 +815,10 @@ func (v *decimalValue) Yql() string {
 	return buffer.String()
 }
 
+func (v *decimalValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (v *decimalValue) Type() Type {
 	return v.innerType
 }
@@ -476,11 +838,77 @@ func (v *decimalValue) toYDB(a *allocator.Allocator) *Ydb.Value {
 	return vvv
 }
 
+// DecimalValueFromBigInt makes decimal value of unscaled value v without checks of type and range of v
+// (v which does not fit into precision is turned into infinity). Use DecimalValueFromBigIntE for checked values
 func DecimalValueFromBigInt(v *big.Int, precision, scale uint32) *decimalValue {
 	b := decimal.BigIntToByte(v, precision, scale)
 	return DecimalValue(b, precision, scale)
 }
 
+// DecimalValueFromBigIntE makes decimal value v * 10^(-scale) of type Decimal(precision, scale).
+// DecimalValueFromBigIntE returns error if parameters of type are invalid (see CheckDecimalType)
+// or v does not fit into precision (error wraps decimal.ErrOverflow and states count of digits of v)
+func DecimalValueFromBigIntE(v *big.Int, precision, scale uint32) (*decimalValue, error) {
+	if err := CheckDecimalType(precision, scale); err != nil {
+		return nil, err
+	}
+	if v == nil {
+		v = big.NewInt(0)
+	}
+	b, err := decimal.BigIntToByteE(v, precision, scale)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(outOfRangeError{cause: err})
+	}
+	return DecimalValue(b, precision, scale), nil
+}
+
+// DecimalValueFromStringWithRounding parses decimal value of type Decimal(precision, scale) from
+// text s (such as "-12.345" or "inf"). Excess digits of fraction are rounded with mode
+// (decimal.RoundUnnecessary makes error on inexact values).
+//
+// DecimalValueFromStringWithRounding returns error if parameters of type are invalid (see CheckDecimalType),
+// s is malformed or finite s does not fit into precision
+func DecimalValueFromStringWithRounding(
+	s string, precision, scale uint32, mode decimal.RoundingMode,
+) (*decimalValue, error) {
+	if err := CheckDecimalType(precision, scale); err != nil {
+		return nil, err
+	}
+	v, err := decimal.ParseWithRounding(s, precision, scale, mode)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	// ParseWithRounding saturates to infinity on overflow, but infinity is allowed only if it was written explicitly
+	if (decimal.IsInf(v) || decimal.IsNaN(v)) && !isDecimalSpecial(s) {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("'%s' is out of range of %s: %w",
+			s, Decimal(precision, scale).Yql(), errValueOutOfRange,
+		))
+	}
+	return DecimalValueFromBigInt(v, precision, scale), nil
+}
+
+// DecimalValueFromUnscaled makes decimal value unscaled * 10^(-scale) of type Decimal(precision, scale)
+//
+// Negative scale is normalized to zero scale. DecimalValueFromUnscaled returns error
+// if scale is greater than precision or value does not fit into precision.
+func DecimalValueFromUnscaled(unscaled *big.Int, scale int32, precision uint32) (*decimalValue, error) {
+	if unscaled == nil {
+		unscaled = big.NewInt(0)
+	}
+	if scale < 0 {
+		unscaled = new(big.Int).Mul(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-scale)), nil))
+		scale = 0
+	}
+	if uint32(scale) > precision {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("scale %d is greater than precision %d: %w",
+			scale, precision, errValueOutOfRange,
+		))
+	}
+	return DecimalValueFromBigIntE(unscaled, precision, uint32(scale))
+}
+
+// DecimalValue makes decimal value of big-endian int128 unscaled value v without checks of type.
+// Use DecimalValueE for checked values
 func DecimalValue(v [16]byte, precision, scale uint32) *decimalValue {
 	return &decimalValue{
 		value: v,
@@ -491,6 +919,16 @@ func DecimalValue(v [16]byte, precision, scale uint32) *decimalValue {
 	}
 }
 
+// DecimalValueE makes decimal value of big-endian int128 unscaled value v and returns error
+// if parameters of type are invalid (see CheckDecimalType). Special values (such as inf and nan)
+// are allowed as in values of YDB
+func DecimalValueE(v [16]byte, precision, scale uint32) (*decimalValue, error) {
+	if err := CheckDecimalType(precision, scale); err != nil {
+		return nil, err
+	}
+	return DecimalValue(v, precision, scale), nil
+}
+
 type (
 	DictValueField struct {
 		K Value
@@ -502,6 +940,11 @@ type (
 	}
 )
 
+// DictValues returns values of dict by keys
+//
+// Deprecated: keys of returned map are interface values which can be compared
+// only with the same key instances. Use Get for lookup by key and Len with PairAt
+// for ordered iteration
 func (v *dictValue) DictValues() map[Value]Value {
 	values := make(map[Value]Value, len(v.values))
 	for i := range v.values {
@@ -510,8 +953,34 @@ func (v *dictValue) DictValues() map[Value]Value {
 	return values
 }
 
+// Get returns value by key which is equal (see Equal) to given key (key may be constructed
+// independently of dict value). Pairs of dict value are sorted by keys, so Get
+// uses binary search
+func (v *dictValue) Get(key Value) (Value, bool) {
+	yql := unredactedYql(key)
+	i := sort.Search(len(v.values), func(i int) bool {
+		return unredactedYql(v.values[i].K) >= yql
+	})
+	for ; i < len(v.values) && unredactedYql(v.values[i].K) == yql; i++ {
+		if Equal(v.values[i].K, key) {
+			return v.values[i].V, true
+		}
+	}
+	return nil, false
+}
+
+// Len returns count of dict value pairs
+func (v *dictValue) Len() int {
+	return len(v.values)
+}
+
+// PairAt returns pair by index i in order of sorted keys
+func (v *dictValue) PairAt(i int) DictValueField {
+	return v.values[i]
+}
+
 func (v *dictValue) castTo(dst interface{}) error {
-	return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' to '%T' destination", v, dst))
+	return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' to '%T' destination", v, dst))
 }
 
 func (v *dictValue) Yql() string {
@@ -530,6 +999,10 @@ func (v *dictValue) Yql() string {
 	return buffer.String()
 }
 
+func (v *dictValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (v *dictValue) Type() Type {
 	return v.t
 }
@@ -553,9 +1026,12 @@ func (v *dictValue) toYDB(a *allocator.Allocator) *Ydb.Value {
 	return vvv
 }
 
+// DictValue makes dict value of pairs with type inferred from the first pair.
+// Dict without pairs has type EmptyDict (see DictValueOfTypes for empty dicts of given types)
 func DictValue(values ...DictValueField) *dictValue {
+	values = append(make([]DictValueField, 0, len(values)), values...)
 	sort.Slice(values, func(i, j int) bool {
-		return values[i].K.Yql() < values[j].K.Yql()
+		return unredactedYql(values[i].K) < unredactedYql(values[j].K)
 	})
 	var t Type
 	switch {
@@ -570,6 +1046,117 @@ func DictValue(values ...DictValueField) *dictValue {
 	}
 }
 
+// DictValueE makes dict value of pairs (as DictValue) or returns error if type of keys
+// is not allowed as dict key (see CheckDictKeyType)
+func DictValueE(values ...DictValueField) (*dictValue, error) {
+	if len(values) > 0 {
+		if err := CheckDictKeyType(values[0].K.Type()); err != nil {
+			return nil, err
+		}
+	}
+	return DictValue(values...), nil
+}
+
+// DictValueOfTypes makes dict value of type Dict<keyType,valueType> (also without pairs).
+// Empty dicts of type EmptyDict (such as decoded result of `SELECT AsDict()`) are accepted
+// as keys or values of dict type and get this type.
+// DictValueOfTypes panics if types of keys or values of pairs differ from keyType or valueType
+// (see DictValueOfTypesE)
+func DictValueOfTypes(keyType, valueType Type, values ...DictValueField) *dictValue {
+	v, i := dictValueOfTypes(keyType, valueType, values)
+	if v == nil {
+		panic("ydb: " + dictPairTypeMismatch(keyType, valueType, values, i))
+	}
+	return v
+}
+
+// DictValueOfTypesE makes dict value of type Dict<keyType,valueType> (as DictValueOfTypes)
+// or returns error if types of keys or values of pairs differ from keyType or valueType
+func DictValueOfTypesE(keyType, valueType Type, values ...DictValueField) (*dictValue, error) {
+	v, i := dictValueOfTypes(keyType, valueType, values)
+	if v == nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s",
+			errDictPairType, dictPairTypeMismatch(keyType, valueType, values, i),
+		))
+	}
+	return v, nil
+}
+
+// dictValueOfTypes returns dict value of type Dict<keyType,valueType> or nil and index of
+// the first pair with types which differ from keyType or valueType
+func dictValueOfTypes(keyType, valueType Type, values []DictValueField) (*dictValue, int) {
+	values = append(make([]DictValueField, 0, len(values)), values...)
+	for i := range values {
+		k, kOk := ofDictType(values[i].K, keyType)
+		v, vOk := ofDictType(values[i].V, valueType)
+		if !kOk || !vOk {
+			return nil, i
+		}
+		values[i] = DictValueField{K: k, V: v}
+	}
+	v := DictValue(values...)
+	v.t = Dict(keyType, valueType)
+	return v, -1
+}
+
+func dictPairTypeMismatch(keyType, valueType Type, values []DictValueField, i int) string {
+	return fmt.Sprintf("pair %d of types (%s,%s) differs from types of %s",
+		i, values[i].K.Type().Yql(), values[i].V.Type().Yql(), Dict(keyType, valueType).Yql(),
+	)
+}
+
+// ofDictType returns v if type of v is t or empty dict v of type t if v is an empty dict
+// of type EmptyDict and t is a dict type
+func ofDictType(v Value, t Type) (Value, bool) {
+	if TypesEqual(v.Type(), t) {
+		return v, true
+	}
+	vv, ok := v.(*dictValue)
+	if !ok || len(vv.values) > 0 {
+		return nil, false
+	}
+	if _, isEmpty := vv.t.(emptyDictType); !isEmpty {
+		return nil, false
+	}
+	if _, isDict := t.(*dictType); !isDict {
+		return nil, false
+	}
+	return &dictValue{t: t}, true
+}
+
+// CheckDictKeyType returns error if values of type t cannot be keys of dict in YDB.
+// Keys of dict can be values of primitive types (except Json, JsonDocument and Yson),
+// decimals, tuples and optionals of such types.
+//
+// Dicts which are decoded from YDB are not checked
+func CheckDictKeyType(t Type) error {
+	switch tt := t.(type) {
+	case optionalType:
+		if err := CheckDictKeyType(tt.innerType); err != nil {
+			return xerrors.WithStackTrace(fmt.Errorf("%w: %s", errDictKeyType, t.Yql()))
+		}
+		return nil
+	case *DecimalType:
+		return nil
+	case *TupleType:
+		for _, item := range tt.items {
+			if err := CheckDictKeyType(item); err != nil {
+				return xerrors.WithStackTrace(fmt.Errorf("%w: %s", errDictKeyType, t.Yql()))
+			}
+		}
+		return nil
+	case PrimitiveType:
+		switch tt {
+		case TypeJSON, TypeJSONDocument, TypeYSON:
+			return xerrors.WithStackTrace(fmt.Errorf("%w: %s", errDictKeyType, t.Yql()))
+		default:
+			return nil
+		}
+	default:
+		return xerrors.WithStackTrace(fmt.Errorf("%w: %s", errDictKeyType, t.Yql()))
+	}
+}
+
 type doubleValue struct {
 	value float64
 }
@@ -579,14 +1166,47 @@ func (v *doubleValue) castTo(dst interface{}) error {
 	case *string:
 		*vv = strconv.FormatFloat(v.value, 'f', -1, 64)
 		return nil
-	case *[]byte:
-		*vv = xstring.ToBytes(strconv.FormatFloat(v.value, 'f', -1, 64))
+	case *[]byte:
+		*vv = xstring.ToBytes(strconv.FormatFloat(v.value, 'f', -1, 64))
+		return nil
+	case *float64:
+		*vv = v.value
+		return nil
+	case *int64:
+		if err := checkFloatIsInteger(v.value, math.MinInt64, math.MaxInt64+1); err != nil {
+			return castError(v.value, v.Type(), vv, err)
+		}
+		*vv = int64(v.value)
+		return nil
+	case *int32:
+		if err := checkFloatIsInteger(v.value, math.MinInt32, math.MaxInt32+1); err != nil {
+			return castError(v.value, v.Type(), vv, err)
+		}
+		*vv = int32(v.value)
+		return nil
+	case *uint64:
+		if err := checkFloatIsInteger(v.value, 0, math.MaxUint64+1); err != nil {
+			return castError(v.value, v.Type(), vv, err)
+		}
+		*vv = uint64(v.value)
 		return nil
-	case *float64:
-		*vv = v.value
+	case *int:
+		if err := checkFloatIsInteger(v.value, math.MinInt, math.MaxInt+1); err != nil {
+			return castError(v.value, v.Type(), vv, err)
+		}
+		*vv = int(v.value)
+		return nil
+	case *uint:
+		if err := checkFloatIsInteger(v.value, 0, math.MaxUint+1); err != nil {
+			return castError(v.value, v.Type(), vv, err)
+		}
+		*vv = uint(v.value)
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
@@ -594,6 +1214,10 @@ func (v *doubleValue) Yql() string {
 	return fmt.Sprintf("%s(\"%v\")", v.Type().Yql(), v.value)
 }
 
+func (v *doubleValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (*doubleValue) Type() Type {
 	return TypeDouble
 }
@@ -624,13 +1248,42 @@ func (v dyNumberValue) castTo(dst interface{}) error {
 	case *[]byte:
 		*vv = xstring.ToBytes(string(v))
 		return nil
+	case *float64:
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return castError(string(v), v.Type(), vv, err)
+		}
+		*vv = f
+		return nil
+	case *big.Float:
+		// precision with 4 bits per digit is enough for exact representation of decimal mantissa
+		prec := vv.Prec()
+		if prec == 0 {
+			prec = uint(4 * len(v))
+			if prec < 64 {
+				prec = 64
+			}
+		}
+		f, _, err := big.ParseFloat(string(v), 10, prec, big.ToNearestEven)
+		if err != nil {
+			return castError(string(v), v.Type(), vv, err)
+		}
+		vv.Set(f)
+		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v dyNumberValue) Yql() string {
-	return fmt.Sprintf("%s(%q)", v.Type().Yql(), string(v))
+	return typedYql(v.Type().Yql(), string(v))
+}
+
+func (v dyNumberValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
 }
 
 func (dyNumberValue) Type() Type {
@@ -651,6 +1304,38 @@ func DyNumberValue(v string) dyNumberValue {
 	return dyNumberValue(v)
 }
 
+// DyNumberValueFromFloat64 makes DyNumber value in canonical textual form (such as `.1234e4`)
+func DyNumberValueFromFloat64(v float64) (dyNumberValue, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "", xerrors.WithStackTrace(fmt.Errorf("cannot make DyNumber from '%v': %w", v, errValueNotFiniteReal))
+	}
+	return dyNumberFromScientific(strconv.FormatFloat(v, 'e', -1, 64)), nil
+}
+
+// DyNumberValueFromBigFloat makes DyNumber value in canonical textual form (such as `.1234e4`)
+func DyNumberValueFromBigFloat(v *big.Float) (dyNumberValue, error) {
+	if v == nil || v.IsInf() {
+		return "", xerrors.WithStackTrace(fmt.Errorf("cannot make DyNumber from '%v': %w", v, errValueNotFiniteReal))
+	}
+	return dyNumberFromScientific(v.Text('e', -1)), nil
+}
+
+// dyNumberFromScientific converts number in scientific notation ([-]d[.ddd]e±dd) to canonical
+// DyNumber textual form [-].ddde±d with normalized mantissa without trailing zeros
+func dyNumberFromScientific(s string) dyNumberValue {
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	e := strings.IndexByte(s, 'e')
+	digits := strings.TrimRight(strings.Replace(s[:e], ".", "", 1), "0")
+	if digits == "" {
+		return "0"
+	}
+	exp, _ := strconv.Atoi(s[e+1:])
+	return dyNumberValue(sign + "." + digits + "e" + strconv.Itoa(exp+1))
+}
+
 type floatValue struct {
 	value float32
 }
@@ -669,8 +1354,41 @@ func (v *floatValue) castTo(dst interface{}) error {
 	case *float32:
 		*vv = v.value
 		return nil
+	case *int64:
+		if err := checkFloatIsInteger(float64(v.value), math.MinInt64, math.MaxInt64+1); err != nil {
+			return castError(v.value, v.Type(), vv, err)
+		}
+		*vv = int64(v.value)
+		return nil
+	case *int32:
+		if err := checkFloatIsInteger(float64(v.value), math.MinInt32, math.MaxInt32+1); err != nil {
+			return castError(v.value, v.Type(), vv, err)
+		}
+		*vv = int32(v.value)
+		return nil
+	case *uint64:
+		if err := checkFloatIsInteger(float64(v.value), 0, math.MaxUint64+1); err != nil {
+			return castError(v.value, v.Type(), vv, err)
+		}
+		*vv = uint64(v.value)
+		return nil
+	case *int:
+		if err := checkFloatIsInteger(float64(v.value), math.MinInt, math.MaxInt+1); err != nil {
+			return castError(v.value, v.Type(), vv, err)
+		}
+		*vv = int(v.value)
+		return nil
+	case *uint:
+		if err := checkFloatIsInteger(float64(v.value), 0, math.MaxUint+1); err != nil {
+			return castError(v.value, v.Type(), vv, err)
+		}
+		*vv = uint(v.value)
+		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
@@ -678,6 +1396,10 @@ func (v *floatValue) Yql() string {
 	return fmt.Sprintf("%s(\"%v\")", v.Type().Yql(), v.value)
 }
 
+func (v *floatValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (*floatValue) Type() Type {
 	return TypeFloat
 }
@@ -711,6 +1433,15 @@ func (v int8Value) castTo(dst interface{}) error {
 	case *int64:
 		*vv = int64(v)
 		return nil
+	case *int:
+		*vv = int(v)
+		return nil
+	case *uint:
+		if v < 0 {
+			return castError(int8(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = uint(v)
+		return nil
 	case *int32:
 		*vv = int32(v)
 		return nil
@@ -727,12 +1458,19 @@ func (v int8Value) castTo(dst interface{}) error {
 		*vv = float32(v)
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v int8Value) Yql() string {
-	return strconv.FormatUint(uint64(v), 10) + "t"
+	return strconv.FormatInt(int64(v), 10) + "t"
+}
+
+func (v int8Value) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
 }
 
 func (int8Value) Type() Type {
@@ -766,6 +1504,15 @@ func (v int16Value) castTo(dst interface{}) error {
 	case *int64:
 		*vv = int64(v)
 		return nil
+	case *int:
+		*vv = int(v)
+		return nil
+	case *uint:
+		if v < 0 {
+			return castError(int16(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = uint(v)
+		return nil
 	case *int32:
 		*vv = int32(v)
 		return nil
@@ -779,12 +1526,19 @@ func (v int16Value) castTo(dst interface{}) error {
 		*vv = float32(v)
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v int16Value) Yql() string {
-	return strconv.FormatUint(uint64(v), 10) + "s"
+	return strconv.FormatInt(int64(v), 10) + "s"
+}
+
+func (v int16Value) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
 }
 
 func (int16Value) Type() Type {
@@ -821,9 +1575,27 @@ func (v int32Value) castTo(dst interface{}) error {
 	case *int:
 		*vv = int(v)
 		return nil
+	case *uint:
+		if v < 0 {
+			return castError(int32(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = uint(v)
+		return nil
 	case *int32:
 		*vv = int32(v)
 		return nil
+	case *int16:
+		if v < math.MinInt16 || v > math.MaxInt16 {
+			return castError(int32(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = int16(v)
+		return nil
+	case *int8:
+		if v < math.MinInt8 || v > math.MaxInt8 {
+			return castError(int32(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = int8(v)
+		return nil
 	case *float64:
 		*vv = float64(v)
 		return nil
@@ -831,7 +1603,10 @@ func (v int32Value) castTo(dst interface{}) error {
 		*vv = float32(v)
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
@@ -839,6 +1614,10 @@ func (v int32Value) Yql() string {
 	return strconv.FormatInt(int64(v), 10)
 }
 
+func (v int32Value) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (int32Value) Type() Type {
 	return TypeInt32
 }
@@ -870,16 +1649,68 @@ func (v int64Value) castTo(dst interface{}) error {
 	case *int64:
 		*vv = int64(v)
 		return nil
+	case *int:
+		if err := checkIntFits(int64(v), strconv.IntSize); err != nil {
+			return castError(int64(v), v.Type(), vv, err)
+		}
+		*vv = int(v)
+		return nil
+	case *uint:
+		if v < 0 {
+			return castError(int64(v), v.Type(), vv, errValueOutOfRange)
+		}
+		if err := checkUintFits(uint64(v), strconv.IntSize); err != nil {
+			return castError(int64(v), v.Type(), vv, err)
+		}
+		*vv = uint(v)
+		return nil
+	case *int32:
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			return castError(int64(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = int32(v)
+		return nil
+	case *int16:
+		if v < math.MinInt16 || v > math.MaxInt16 {
+			return castError(int64(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = int16(v)
+		return nil
+	case *int8:
+		if v < math.MinInt8 || v > math.MaxInt8 {
+			return castError(int64(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = int8(v)
+		return nil
+	case *uint64:
+		if v < 0 {
+			return castError(int64(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = uint64(v)
+		return nil
+	case *uint32:
+		if v < 0 || v > math.MaxUint32 {
+			return castError(int64(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = uint32(v)
+		return nil
 	case *float64:
 		*vv = float64(v)
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v int64Value) Yql() string {
-	return strconv.FormatUint(uint64(v), 10) + "l"
+	return strconv.FormatInt(int64(v), 10) + "l"
+}
+
+func (v int64Value) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
 }
 
 func (int64Value) Type() Type {
@@ -905,54 +1736,34 @@ type intervalValue int64
 func (v intervalValue) castTo(dst interface{}) error {
 	switch vv := dst.(type) {
 	case *time.Duration:
-		*vv = IntervalToDuration(int64(v))
+		d, err := IntervalToDurationChecked(int64(v))
+		if err != nil {
+			return castError(int64(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = d
 		return nil
 	case *int64:
 		*vv = int64(v)
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v intervalValue) Yql() string {
-	buffer := xstring.Buffer()
-	defer buffer.Free()
-	buffer.WriteString(v.Type().Yql())
-	buffer.WriteByte('(')
-	buffer.WriteByte('"')
-	d := IntervalToDuration(int64(v))
-	if d < 0 {
-		buffer.WriteByte('-')
-		d = -d
-	}
-	buffer.WriteByte('P')
-	if days := d / time.Hour / 24; days > 0 {
-		d -= days * time.Hour * 24 //nolint:durationcheck
-		buffer.WriteString(strconv.FormatInt(int64(days), 10))
-		buffer.WriteByte('D')
-	}
-	if d > 0 {
-		buffer.WriteByte('T')
-	}
-	if hours := d / time.Hour; hours > 0 {
-		d -= hours * time.Hour //nolint:durationcheck
-		buffer.WriteString(strconv.FormatInt(int64(hours), 10))
-		buffer.WriteByte('H')
-	}
-	if minutes := d / time.Minute; minutes > 0 {
-		d -= minutes * time.Minute //nolint:durationcheck
-		buffer.WriteString(strconv.FormatInt(int64(minutes), 10))
-		buffer.WriteByte('M')
-	}
-	if d > 0 {
-		seconds := float64(d) / float64(time.Second)
-		fmt.Fprintf(buffer, "%0.6f", seconds)
-		buffer.WriteByte('S')
-	}
-	buffer.WriteByte('"')
-	buffer.WriteByte(')')
-	return buffer.String()
+	return intervalYql(v.Type().Yql(), int64(v))
+}
+
+func (v intervalValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
+// intervalYql returns YQL literal of interval type typeName with given microseconds
+func intervalYql(typeName string, v int64) string {
+	return typedYql(typeName, IntervalToISO8601(v))
 }
 
 func (intervalValue) Type() Type {
@@ -974,8 +1785,89 @@ func IntervalValue(v int64) intervalValue {
 	return intervalValue(v)
 }
 
+// IntervalValueFromDuration makes Interval value from time.Duration
+//
+// Sub-microsecond remainder of duration is truncated.
 func IntervalValueFromDuration(v time.Duration) intervalValue {
-	return intervalValue(durationToMicroseconds(v))
+	return intervalValue(DurationToMicroseconds(v))
+}
+
+// IntervalValueFromDurationChecked makes Interval value from time.Duration
+//
+// Sub-microsecond remainder of duration is rounded to nearest microsecond
+// (halfway values are rounded away from zero) if round is true or rejected with error otherwise.
+func IntervalValueFromDurationChecked(v time.Duration, round bool) (intervalValue, error) {
+	us, err := DurationToMicrosecondsChecked(v, round)
+	if err != nil {
+		return 0, xerrors.WithStackTrace(fmt.Errorf("cannot make Interval: %w", err))
+	}
+	return intervalValue(us), nil
+}
+
+// IntervalValueFromISO8601 makes Interval value from ISO 8601 duration (such as `-P1DT2H3M4.5S`),
+// which is the format of YQL representation of Interval values
+func IntervalValueFromISO8601(s string) (intervalValue, error) {
+	us, err := ISO8601ToInterval(s)
+	if err != nil {
+		return 0, xerrors.WithStackTrace(fmt.Errorf("cannot make Interval: %w", err))
+	}
+	return intervalValue(us), nil
+}
+
+type interval64Value int64
+
+func (v interval64Value) castTo(dst interface{}) error {
+	switch vv := dst.(type) {
+	case *time.Duration:
+		d, err := IntervalToDurationChecked(int64(v))
+		if err != nil {
+			return castError(int64(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = d
+		return nil
+	case *int64:
+		*vv = int64(v)
+		return nil
+	default:
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+	}
+}
+
+func (v interval64Value) Yql() string {
+	return intervalYql(v.Type().Yql(), int64(v))
+}
+
+func (v interval64Value) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
+func (interval64Value) Type() Type {
+	return TypeInterval64
+}
+
+func (v interval64Value) toYDB(a *allocator.Allocator) *Ydb.Value {
+	vv := a.Int64()
+	vv.Int64Value = int64(v)
+
+	vvv := a.Value()
+	vvv.Value = vv
+
+	return vvv
+}
+
+// Interval64Value makes Interval64 value from given microseconds
+func Interval64Value(v int64) interval64Value {
+	return interval64Value(v)
+}
+
+// Interval64ValueFromDuration makes Interval64 value from time.Duration
+//
+// Sub-microsecond remainder of duration is truncated.
+func Interval64ValueFromDuration(v time.Duration) interval64Value {
+	return interval64Value(DurationToMicroseconds(v))
 }
 
 type jsonValue string
@@ -988,13 +1880,23 @@ func (v jsonValue) castTo(dst interface{}) error {
 	case *[]byte:
 		*vv = xstring.ToBytes(string(v))
 		return nil
+	case *json.RawMessage:
+		*vv = xstring.ToBytes(string(v))
+		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v jsonValue) Yql() string {
-	return fmt.Sprintf("%s(@@%s@@)", v.Type().Yql(), string(v))
+	return typedYql(v.Type().Yql(), string(v))
+}
+
+func (v jsonValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
 }
 
 func (jsonValue) Type() Type {
@@ -1025,13 +1927,23 @@ func (v jsonDocumentValue) castTo(dst interface{}) error {
 	case *[]byte:
 		*vv = xstring.ToBytes(string(v))
 		return nil
+	case *json.RawMessage:
+		*vv = xstring.ToBytes(string(v))
+		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v jsonDocumentValue) Yql() string {
-	return fmt.Sprintf("%s(@@%s@@)", v.Type().Yql(), string(v))
+	return typedYql(v.Type().Yql(), string(v))
+}
+
+func (v jsonDocumentValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
 }
 
 func (jsonDocumentValue) Type() Type {
@@ -1061,8 +1973,28 @@ func (v *listValue) ListItems() []Value {
 	return v.items
 }
 
+// Items returns items of list value
+//
+// Returned slice is shared with list value and must be used as read-only
+func (v *listValue) Items() []Value {
+	return v.items
+}
+
+// Len returns count of list value items
+func (v *listValue) Len() int {
+	return len(v.items)
+}
+
+// ItemAt returns item of list value by index i or nil if i is out of range
+func (v *listValue) ItemAt(i int) Value {
+	if i < 0 || i >= len(v.items) {
+		return nil
+	}
+	return v.items[i]
+}
+
 func (v *listValue) castTo(dst interface{}) error {
-	return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), dst))
+	return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), dst))
 }
 
 func (v *listValue) Yql() string {
@@ -1079,6 +2011,10 @@ func (v *listValue) Yql() string {
 	return buffer.String()
 }
 
+func (v *listValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (v *listValue) Type() Type {
 	return v.t
 }
@@ -1097,18 +2033,72 @@ func (v *listValue) toYDB(a *allocator.Allocator) *Ydb.Value {
 	return vvv
 }
 
+// ListValue makes list value of items.
+// ListValue panics if items have different types (see ListValueE)
 func ListValue(items ...Value) *listValue {
-	var t Type
-	switch {
-	case len(items) > 0:
-		t = List(items[0].Type())
-	default:
-		t = EmptyList()
+	v, err := ListValueE(items...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ListValueE makes list value of items or returns error if items have different types.
+//
+// Items of type T are mixed with items of type Optional<T> (such as nulls): the list has
+// type List<Optional<T>> and items of type T are wrapped into Optional.
+// Optional depths of mixed items may differ only by one
+func ListValueE(items ...Value) (*listValue, error) {
+	if len(items) == 0 {
+		return &listValue{
+			t:     EmptyList(),
+			items: items,
+		}, nil
+	}
+	itemType := items[0].Type()
+	for i := 1; i < len(items); i++ {
+		t := items[i].Type()
+		switch {
+		case TypesEqual(t, itemType):
+		case isOptionalOf(itemType, t):
+		case isOptionalOf(t, itemType):
+			itemType = t
+		default:
+			return nil, xerrors.WithStackTrace(fmt.Errorf(
+				"%w: item %d of type %s differs from type %s of previous items",
+				errListItemType, i, t.Yql(), itemType.Yql(),
+			))
+		}
+	}
+	if IsOptional(itemType) {
+		// items of caller are not modified
+		items = append([]Value(nil), items...)
+		for i, item := range items {
+			t := item.Type()
+			switch {
+			case TypesEqual(t, itemType):
+			case isOptionalOf(itemType, t):
+				items[i] = OptionalValue(item)
+			default:
+				// item type was checked against narrower type of previous items
+				// (such as T before Optional<Optional<T>>)
+				return nil, xerrors.WithStackTrace(fmt.Errorf(
+					"%w: item %d of type %s differs from type %s of other items",
+					errListItemType, i, t.Yql(), itemType.Yql(),
+				))
+			}
+		}
 	}
 	return &listValue{
-		t:     t,
+		t:     List(itemType),
 		items: items,
-	}
+	}, nil
+}
+
+// isOptionalOf checks that t is Optional<inner>
+func isOptionalOf(t, inner Type) bool {
+	innerType, ok := UnwrapOptional(t)
+	return ok && TypesEqual(innerType, inner)
 }
 
 type setValue struct {
@@ -1117,7 +2107,7 @@ type setValue struct {
 }
 
 func (v *setValue) castTo(dst interface{}) error {
-	return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' to '%T' destination", v, dst))
+	return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' to '%T' destination", v, dst))
 }
 
 func (v *setValue) Yql() string {
@@ -1134,6 +2124,10 @@ func (v *setValue) Yql() string {
 	return buffer.String()
 }
 
+func (v *setValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (v *setValue) Type() Type {
 	return v.t
 }
@@ -1154,8 +2148,9 @@ func (v *setValue) toYDB(a *allocator.Allocator) *Ydb.Value {
 }
 
 func SetValue(items ...Value) *setValue {
+	items = append(make([]Value, 0, len(items)), items...)
 	sort.Slice(items, func(i, j int) bool {
-		return items[i].Yql() < items[j].Yql()
+		return unredactedYql(items[i]) < unredactedYql(items[j])
 	})
 
 	var t Type
@@ -1179,14 +2174,82 @@ func NullValue(t Type) *optionalValue {
 	}
 }
 
+// OptionalValuer is an interface of Optional values
+type OptionalValuer interface {
+	// IsNull reports whether optional value is NULL (Nothing)
+	IsNull() bool
+	// Unwrap returns inner value of optional value or nil and false for NULL.
+	// Inner value of Optional<Optional<T>> is an optional value too
+	Unwrap() (Value, bool)
+}
+
+var _ OptionalValuer = (*optionalValue)(nil)
+
 type optionalValue struct {
 	innerType Type
 	value     Value
 }
 
-var errOptionalNilValue = errors.New("optional contains nil value")
+func (v *optionalValue) IsNull() bool {
+	return v.value == nil
+}
+
+func (v *optionalValue) Unwrap() (Value, bool) {
+	if v.value == nil {
+		return nil, false
+	}
+	return v.value, true
+}
+
+// Unwrap returns inner non-optional value of optional value v of any nesting depth
+// or nil and false if v is NULL at any level of nesting.
+// Non-optional value v is returned as is.
+func Unwrap(v Value) (Value, bool) {
+	for {
+		optional, ok := v.(OptionalValuer)
+		if !ok {
+			return v, true
+		}
+		if v, ok = optional.Unwrap(); !ok {
+			return nil, false
+		}
+	}
+}
+
+var (
+	errOptionalNilValue  = errors.New("optional contains nil value")
+	errMalformedValue    = errors.New("malformed value")
+	errMalformedUUID     = errors.New("malformed UUID")
+	errListItemType      = errors.New("list items have different types")
+	errDictKeyType       = errors.New("type is not allowed as dict key")
+	errDictPairType      = errors.New("types of dict pair differ from types of dict")
+	errStructListLength  = errors.New("columns of struct list have different lengths")
+	errStructListType    = errors.New("values of column of struct list have different types")
+	errVariantStructType = errors.New("type is not a struct variant type")
+	errVariantName       = errors.New("unknown alternative of variant")
+	errVariantValueType  = errors.New("type of value differs from type of variant alternative")
+	errTupleItemIndex    = errors.New("tuple item index out of range")
+)
 
 func (v *optionalValue) castTo(dst interface{}) error {
+	if vv, ok := dst.(*interface{}); ok {
+		// NULL casts to nil, otherwise inner value casts to its natural Go representation
+		return castToInterface(v, vv)
+	}
+	if ptr := reflect.ValueOf(dst); ptr.Kind() == reflect.Ptr && !ptr.IsNil() && ptr.Elem().Kind() == reflect.Ptr {
+		// destination is a pointer to pointer: NULL casts to nil pointer,
+		// otherwise inner value casts to newly allocated pointer
+		if v.value == nil {
+			ptr.Elem().Set(reflect.Zero(ptr.Elem().Type()))
+			return nil
+		}
+		inner := reflect.New(ptr.Elem().Type().Elem())
+		if err := Cast(v.value, inner.Interface()); err != nil {
+			return err
+		}
+		ptr.Elem().Set(inner)
+		return nil
+	}
 	if v.value == nil {
 		return xerrors.WithStackTrace(errOptionalNilValue)
 	}
@@ -1200,24 +2263,30 @@ func (v *optionalValue) Yql() string {
 	return fmt.Sprintf("Just(%s)", v.value.Yql())
 }
 
+func (v *optionalValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (v *optionalValue) Type() Type {
 	return v.innerType
 }
 
 func (v *optionalValue) toYDB(a *allocator.Allocator) *Ydb.Value {
-	vv := a.Value()
-	if _, opt := v.value.(*optionalValue); opt {
+	switch unwrapSecret(v.value).(type) {
+	case nil:
+		vv := a.Value()
+		vv.Value = a.NullFlag()
+		return vv
+	case *optionalValue:
+		vv := a.Value()
 		vvv := a.Nested()
 		vvv.NestedValue = v.value.toYDB(a)
 		vv.Value = vvv
-	} else {
-		if v.value != nil {
-			vv.Value = v.value.toYDB(a).Value
-		} else {
-			vv.Value = a.NullFlag()
-		}
+		return vv
+	default:
+		// value of non-optional item is passed as is with items, pairs and variant index
+		return v.value.toYDB(a)
 	}
-	return vv
 }
 
 func OptionalValue(v Value) *optionalValue {
@@ -1227,6 +2296,48 @@ func OptionalValue(v Value) *optionalValue {
 	}
 }
 
+type taggedValue struct {
+	t     *taggedType
+	value Value
+}
+
+func (v *taggedValue) Tag() string {
+	return v.t.tag
+}
+
+// InnerValue returns value of tagged value without tag
+func (v *taggedValue) InnerValue() Value {
+	return v.value
+}
+
+func (v *taggedValue) castTo(dst interface{}) error {
+	return v.value.castTo(dst)
+}
+
+func (v *taggedValue) Yql() string {
+	return "AsTagged(" + v.value.Yql() + "," + quoteYql(v.t.tag) + ")"
+}
+
+func (v *taggedValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
+func (v *taggedValue) Type() Type {
+	return v.t
+}
+
+func (v *taggedValue) toYDB(a *allocator.Allocator) *Ydb.Value {
+	return v.value.toYDB(a)
+}
+
+// TaggedValue makes value of type Tagged<T,tag> where T is a type of v
+func TaggedValue(tag string, v Value) *taggedValue {
+	return &taggedValue{
+		t:     Tagged(tag, v.Type()),
+		value: v,
+	}
+}
+
 type (
 	StructValueField struct {
 		Name string
@@ -1235,6 +2346,9 @@ type (
 	structValue struct {
 		t      Type
 		fields []StructValueField
+		// unsorted is true if fields of struct value are kept in declared order
+		// which differs from order of sorted names
+		unsorted bool
 	}
 )
 
@@ -1246,8 +2360,38 @@ func (v *structValue) StructFields() map[string]Value {
 	return fields
 }
 
+// GetField returns value of field with given name without allocations.
+// GetField uses binary search if fields of struct value are sorted by name
+func (v *structValue) GetField(name string) (Value, bool) {
+	if v.unsorted {
+		for i := range v.fields {
+			if v.fields[i].Name == name {
+				return v.fields[i].V, true
+			}
+		}
+		return nil, false
+	}
+	i := sort.Search(len(v.fields), func(i int) bool {
+		return v.fields[i].Name >= name
+	})
+	if i < len(v.fields) && v.fields[i].Name == name {
+		return v.fields[i].V, true
+	}
+	return nil, false
+}
+
+// Len returns count of struct value fields
+func (v *structValue) Len() int {
+	return len(v.fields)
+}
+
+// Field returns field by index i in order of fields of struct type
+func (v *structValue) Field(i int) StructValueField {
+	return v.fields[i]
+}
+
 func (v *structValue) castTo(dst interface{}) error {
-	return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' to '%T' destination", v, dst))
+	return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' to '%T' destination", v, dst))
 }
 
 func (v *structValue) Yql() string {
@@ -1258,13 +2402,18 @@ func (v *structValue) Yql() string {
 		if i != 0 {
 			buffer.WriteByte(',')
 		}
-		buffer.WriteString("`" + v.fields[i].Name + "`:")
+		buffer.WriteString(quoteYqlIdentifier(v.fields[i].Name))
+		buffer.WriteByte(':')
 		buffer.WriteString(v.fields[i].V.Yql())
 	}
 	buffer.WriteString("|>")
 	return buffer.String()
 }
 
+func (v *structValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (v *structValue) Type() Type {
 	return v.t
 }
@@ -1280,6 +2429,7 @@ func (v *structValue) toYDB(a *allocator.Allocator) *Ydb.Value {
 }
 
 func StructValue(fields ...StructValueField) *structValue {
+	fields = append(make([]StructValueField, 0, len(fields)), fields...)
 	sort.Slice(fields, func(i, j int) bool {
 		return fields[i].Name < fields[j].Name
 	})
@@ -1293,6 +2443,89 @@ func StructValue(fields ...StructValueField) *structValue {
 	}
 }
 
+// StructValueOrdered makes struct value with fields in given order (as opposed to StructValue
+// which sorts fields by name). Type of value is a struct type with fields in the same order,
+// so value is equal to struct types which are declared in such order
+func StructValueOrdered(fields ...StructValueField) *structValue {
+	fields = append(make([]StructValueField, 0, len(fields)), fields...)
+	structFields := make([]StructField, 0, len(fields))
+	for i := range fields {
+		structFields = append(structFields, StructField{fields[i].Name, fields[i].V.Type()})
+	}
+	return structValueOfType(Struct(structFields...), fields)
+}
+
+// structValueOfType makes struct value of struct type t with fields in order of fields of t
+func structValueOfType(t *StructType, fields []StructValueField) *structValue {
+	v := &structValue{
+		t:      t,
+		fields: fields,
+	}
+	for i := 1; i < len(fields); i++ {
+		if fields[i-1].Name > fields[i].Name {
+			v.unsorted = true
+			break
+		}
+	}
+	return v
+}
+
+// StructListValue makes List<Struct<fields>> from columns of values with the same length:
+// i-th struct of list consists of i-th values of columns. Fields must be sorted by name
+// (as fields of StructValue) and types of values of columns must be equal to types of fields
+func StructListValue(fields []StructField, columns [][]Value) (*listValue, error) {
+	if len(fields) != len(columns) {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%d fields of struct and %d columns", len(fields), len(columns)))
+	}
+	rows := 0
+	for j := range fields {
+		if j > 0 && fields[j-1].Name >= fields[j].Name {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("fields of struct are not sorted: %q, %q",
+				fields[j-1].Name, fields[j].Name,
+			))
+		}
+		if j == 0 {
+			rows = len(columns[j])
+		} else if len(columns[j]) != rows {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: field %q has %d values, field %q has %d values",
+				errStructListLength, fields[j].Name, len(columns[j]), fields[0].Name, rows,
+			))
+		}
+		for i, v := range columns[j] {
+			if !TypesEqual(v.Type(), fields[j].T) {
+				return nil, xerrors.WithStackTrace(fmt.Errorf(
+					"%w: value %d of field %q has type %s instead of %s",
+					errStructListType, i, fields[j].Name, v.Type().Yql(), fields[j].T.Yql(),
+				))
+			}
+		}
+	}
+	var (
+		t       = Struct(append([]StructField(nil), fields...)...)
+		items   = make([]Value, rows)
+		structs = make([]structValue, rows)
+		values  = make([]StructValueField, rows*len(fields))
+	)
+	for i := range items {
+		row := values[i*len(fields) : (i+1)*len(fields) : (i+1)*len(fields)]
+		for j := range fields {
+			row[j] = StructValueField{
+				Name: fields[j].Name,
+				V:    columns[j][i],
+			}
+		}
+		structs[i] = structValue{
+			t:      t,
+			fields: row,
+		}
+		items[i] = &structs[i]
+	}
+	return &listValue{
+		t:     List(t),
+		items: items,
+	}, nil
+}
+
 type timestampValue uint64
 
 func (v timestampValue) castTo(dst interface{}) error {
@@ -1303,13 +2536,32 @@ func (v timestampValue) castTo(dst interface{}) error {
 	case *uint64:
 		*vv = uint64(v)
 		return nil
+	case *uint:
+		if err := checkUintFits(uint64(v), strconv.IntSize); err != nil {
+			return castError(uint64(v), v.Type(), vv, err)
+		}
+		*vv = uint(v)
+		return nil
+	case *int:
+		if err := checkUintFits(uint64(v), strconv.IntSize-1); err != nil {
+			return castError(uint64(v), v.Type(), vv, err)
+		}
+		*vv = int(v)
+		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v timestampValue) Yql() string {
-	return fmt.Sprintf("%s(%q)", v.Type().Yql(), TimestampToTime(uint64(v)).UTC().Format(LayoutTimestamp))
+	return typedYql(v.Type().Yql(), TimestampToTime(uint64(v)).UTC().Format(LayoutTimestamp))
+}
+
+func (v timestampValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
 }
 
 func (timestampValue) Type() Type {
@@ -1331,24 +2583,130 @@ func TimestampValue(v uint64) timestampValue {
 	return timestampValue(v)
 }
 
+// TimestampValueFromTime makes Timestamp value from t truncated to microseconds.
+// Times out of supported range are wrapped, use TimestampValueFromTimeE for checking of range
 func TimestampValueFromTime(t time.Time) timestampValue {
 	return timestampValue(t.Sub(epoch) / time.Microsecond)
 }
 
+// TimestampValueFromTimeE makes Timestamp value from t truncated to microseconds or returns error
+// if t is out of supported range [1970-01-01T00:00:00.000000Z, 2105-12-31T23:59:59.999999Z]
+func TimestampValueFromTimeE(t time.Time) (timestampValue, error) {
+	// t.Unix() is checked before t.Sub(epoch) because of saturation of time.Duration
+	if seconds := t.Unix(); seconds < 0 || seconds > maxDatetime {
+		return 0, timeRangeError(t, TypeTimestamp.Yql(), LayoutTimestamp, epoch, TimestampToTime(uint64(maxTimestamp)))
+	}
+	return timestampValue(t.Sub(epoch) / time.Microsecond), nil
+}
+
+type timestamp64Value int64
+
+func (v timestamp64Value) castTo(dst interface{}) error {
+	switch vv := dst.(type) {
+	case *time.Time:
+		*vv = Timestamp64ToTime(int64(v))
+		return nil
+	case *int64:
+		*vv = int64(v)
+		return nil
+	case *int:
+		if err := checkIntFits(int64(v), strconv.IntSize); err != nil {
+			return castError(int64(v), v.Type(), vv, err)
+		}
+		*vv = int(v)
+		return nil
+	default:
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+	}
+}
+
+func (v timestamp64Value) Yql() string {
+	return typedYql(v.Type().Yql(), Timestamp64ToTime(int64(v)).UTC().Format(LayoutTimestamp))
+}
+
+func (v timestamp64Value) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
+func (timestamp64Value) Type() Type {
+	return TypeTimestamp64
+}
+
+func (v timestamp64Value) toYDB(a *allocator.Allocator) *Ydb.Value {
+	vv := a.Int64()
+	vv.Int64Value = int64(v)
+
+	vvv := a.Value()
+	vvv.Value = vv
+
+	return vvv
+}
+
+// Timestamp64Value makes Timestamp64 value from microseconds since Epoch (negative for times before 1970)
+func Timestamp64Value(v int64) timestamp64Value {
+	return timestamp64Value(v)
+}
+
+// Timestamp64ValueFromTime makes Timestamp64 value from t truncated to microseconds.
+// Times out of supported range are wrapped, use Timestamp64ValueFromTimeE for checking of range
+func Timestamp64ValueFromTime(t time.Time) timestamp64Value {
+	return timestamp64Value(t.UnixMicro())
+}
+
+// Timestamp64ValueFromTimeE makes Timestamp64 value from t truncated to microseconds or returns error
+// if t is out of supported range [-144168-01-01T00:00:00.000000Z, 148107-12-31T23:59:59.999999Z]
+func Timestamp64ValueFromTimeE(t time.Time) (timestamp64Value, error) {
+	// t.Unix() is checked before t.UnixMicro() because of overflow of microseconds
+	if seconds := t.Unix(); seconds < minDatetime64 || seconds > maxDatetime64 {
+		return 0, timeRangeError(t, TypeTimestamp64.Yql(), LayoutTimestamp,
+			Timestamp64ToTime(minTimestamp64), Timestamp64ToTime(maxTimestamp64),
+		)
+	}
+	return timestamp64Value(t.UnixMicro()), nil
+}
+
 type tupleValue struct {
 	t     Type
 	items []Value
 }
 
+// TupleItems returns items of tuple value
+//
+// Returned slice is shared with tuple value and must be used as read-only
 func (v *tupleValue) TupleItems() []Value {
 	return v.items
 }
 
+// Items returns items of tuple value
+//
+// Returned slice is shared with tuple value and must be used as read-only
+func (v *tupleValue) Items() []Value {
+	return v.items
+}
+
+// Len returns count of tuple value items
+func (v *tupleValue) Len() int {
+	return len(v.items)
+}
+
+// ItemAt returns item of tuple value by index i or error if i is out of range
+func (v *tupleValue) ItemAt(i int) (Value, error) {
+	if i < 0 || i >= len(v.items) {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %d is out of range [0, %d) of %s",
+			errTupleItemIndex, i, len(v.items), v.t.Yql(),
+		))
+	}
+	return v.items[i], nil
+}
+
 func (v *tupleValue) castTo(dst interface{}) error {
 	if len(v.items) == 1 {
 		return v.items[0].castTo(dst)
 	}
-	return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' to '%T' destination", v, dst))
+	return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' to '%T' destination", v, dst))
 }
 
 func (v *tupleValue) Yql() string {
@@ -1365,6 +2723,10 @@ func (v *tupleValue) Yql() string {
 	return buffer.String()
 }
 
+func (v *tupleValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (v *tupleValue) Type() Type {
 	return v.t
 }
@@ -1404,13 +2766,27 @@ func (v tzDateValue) castTo(dst interface{}) error {
 	case *[]byte:
 		*vv = xstring.ToBytes(string(v))
 		return nil
+	case *time.Time:
+		t, err := TzDateToTime(string(v))
+		if err != nil {
+			return castError(string(v), v.Type(), vv, err)
+		}
+		*vv = t
+		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v tzDateValue) Yql() string {
-	return fmt.Sprintf("%s(%q)", v.Type().Yql(), string(v))
+	return typedYql(v.Type().Yql(), string(v))
+}
+
+func (v tzDateValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
 }
 
 func (tzDateValue) Type() Type {
@@ -1431,8 +2807,21 @@ func TzDateValue(v string) tzDateValue {
 	return tzDateValue(v)
 }
 
+// TzDateValueFromTime makes TzDate value with date of t in location of t.
+// Times in timezones without IANA names are converted to UTC, use TzDateValueFromTimeE for checking of timezone
 func TzDateValueFromTime(t time.Time) tzDateValue {
-	return tzDateValue(t.Format(LayoutDate))
+	t, name := inTimezone(t)
+	return tzDateValue(t.Format(LayoutDate) + "," + name)
+}
+
+// TzDateValueFromTimeE makes TzDate value with date of t in location of t
+// or returns error if timezone of t has no IANA name (see TzDateValueFromTime)
+func TzDateValueFromTimeE(t time.Time) (tzDateValue, error) {
+	name, err := timezoneName(t)
+	if err != nil {
+		return "", err
+	}
+	return tzDateValue(t.Format(LayoutDate) + "," + name), nil
 }
 
 type tzDatetimeValue string
@@ -1445,13 +2834,27 @@ func (v tzDatetimeValue) castTo(dst interface{}) error {
 	case *[]byte:
 		*vv = xstring.ToBytes(string(v))
 		return nil
+	case *time.Time:
+		t, err := TzDatetimeToTime(string(v))
+		if err != nil {
+			return castError(string(v), v.Type(), vv, err)
+		}
+		*vv = t
+		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v tzDatetimeValue) Yql() string {
-	return fmt.Sprintf("%s(%q)", v.Type().Yql(), string(v))
+	return typedYql(v.Type().Yql(), string(v))
+}
+
+func (v tzDatetimeValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
 }
 
 func (tzDatetimeValue) Type() Type {
@@ -1472,8 +2875,21 @@ func TzDatetimeValue(v string) tzDatetimeValue {
 	return tzDatetimeValue(v)
 }
 
+// TzDatetimeValueFromTime makes TzDatetime value with time of t in location of t.
+// Times in timezones without IANA names are converted to UTC, use TzDatetimeValueFromTimeE for checking of timezone
 func TzDatetimeValueFromTime(t time.Time) tzDatetimeValue {
-	return tzDatetimeValue(t.Format(LayoutDatetime))
+	t, name := inTimezone(t)
+	return tzDatetimeValue(t.Format(LayoutTzDatetime) + "," + name)
+}
+
+// TzDatetimeValueFromTimeE makes TzDatetime value with time of t in location of t
+// or returns error if timezone of t has no IANA name (see TzDatetimeValueFromTime)
+func TzDatetimeValueFromTimeE(t time.Time) (tzDatetimeValue, error) {
+	name, err := timezoneName(t)
+	if err != nil {
+		return "", err
+	}
+	return tzDatetimeValue(t.Format(LayoutTzDatetime) + "," + name), nil
 }
 
 type tzTimestampValue string
@@ -1486,13 +2902,27 @@ func (v tzTimestampValue) castTo(dst interface{}) error {
 	case *[]byte:
 		*vv = xstring.ToBytes(string(v))
 		return nil
+	case *time.Time:
+		t, err := TzTimestampToTime(string(v))
+		if err != nil {
+			return castError(string(v), v.Type(), vv, err)
+		}
+		*vv = t
+		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v tzTimestampValue) Yql() string {
-	return fmt.Sprintf("%s(%q)", v.Type().Yql(), string(v))
+	return typedYql(v.Type().Yql(), string(v))
+}
+
+func (v tzTimestampValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
 }
 
 func (tzTimestampValue) Type() Type {
@@ -1513,8 +2943,21 @@ func TzTimestampValue(v string) tzTimestampValue {
 	return tzTimestampValue(v)
 }
 
+// TzTimestampValueFromTime makes TzTimestamp value with time of t in location of t.
+// Times in timezones without IANA names are converted to UTC, use TzTimestampValueFromTimeE for checking of timezone
 func TzTimestampValueFromTime(t time.Time) tzTimestampValue {
-	return tzTimestampValue(t.Format(LayoutTimestamp))
+	t, name := inTimezone(t)
+	return tzTimestampValue(t.Format(LayoutTzTimestamp) + "," + name)
+}
+
+// TzTimestampValueFromTimeE makes TzTimestamp value with time of t in location of t
+// or returns error if timezone of t has no IANA name (see TzTimestampValueFromTime)
+func TzTimestampValueFromTimeE(t time.Time) (tzTimestampValue, error) {
+	name, err := timezoneName(t)
+	if err != nil {
+		return "", err
+	}
+	return tzTimestampValue(t.Format(LayoutTzTimestamp) + "," + name), nil
 }
 
 type uint8Value uint8
@@ -1533,6 +2976,12 @@ func (v uint8Value) castTo(dst interface{}) error {
 	case *int64:
 		*vv = int64(v)
 		return nil
+	case *uint:
+		*vv = uint(v)
+		return nil
+	case *int:
+		*vv = int(v)
+		return nil
 	case *uint32:
 		*vv = uint32(v)
 		return nil
@@ -1548,6 +2997,12 @@ func (v uint8Value) castTo(dst interface{}) error {
 	case *uint8:
 		*vv = uint8(v)
 		return nil
+	case *int8:
+		if v > math.MaxInt8 {
+			return castError(uint8(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = int8(v)
+		return nil
 	case *float64:
 		*vv = float64(v)
 		return nil
@@ -1555,7 +3010,10 @@ func (v uint8Value) castTo(dst interface{}) error {
 		*vv = float32(v)
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
@@ -1563,6 +3021,10 @@ func (v uint8Value) Yql() string {
 	return strconv.FormatUint(uint64(v), 10) + "ut"
 }
 
+func (v uint8Value) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (uint8Value) Type() Type {
 	return TypeUint8
 }
@@ -1597,6 +3059,12 @@ func (v uint16Value) castTo(dst interface{}) error {
 	case *int64:
 		*vv = int64(v)
 		return nil
+	case *uint:
+		*vv = uint(v)
+		return nil
+	case *int:
+		*vv = int(v)
+		return nil
 	case *uint32:
 		*vv = uint32(v)
 		return nil
@@ -1606,6 +3074,12 @@ func (v uint16Value) castTo(dst interface{}) error {
 	case *uint16:
 		*vv = uint16(v)
 		return nil
+	case *int16:
+		if v > math.MaxInt16 {
+			return castError(uint16(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = int16(v)
+		return nil
 	case *float32:
 		*vv = float32(v)
 		return nil
@@ -1613,7 +3087,10 @@ func (v uint16Value) castTo(dst interface{}) error {
 		*vv = float64(v)
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
@@ -1621,6 +3098,10 @@ func (v uint16Value) Yql() string {
 	return strconv.FormatUint(uint64(v), 10) + "us"
 }
 
+func (v uint16Value) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (uint16Value) Type() Type {
 	return TypeUint16
 }
@@ -1655,14 +3136,32 @@ func (v uint32Value) castTo(dst interface{}) error {
 	case *int64:
 		*vv = int64(v)
 		return nil
+	case *uint:
+		*vv = uint(v)
+		return nil
+	case *int:
+		if err := checkIntFits(int64(v), strconv.IntSize); err != nil {
+			return castError(uint32(v), v.Type(), vv, err)
+		}
+		*vv = int(v)
+		return nil
 	case *uint32:
 		*vv = uint32(v)
 		return nil
+	case *int32:
+		if v > math.MaxInt32 {
+			return castError(uint32(v), v.Type(), vv, errValueOutOfRange)
+		}
+		*vv = int32(v)
+		return nil
 	case *float64:
 		*vv = float64(v)
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
@@ -1670,6 +3169,10 @@ func (v uint32Value) Yql() string {
 	return strconv.FormatUint(uint64(v), 10) + "u"
 }
 
+func (v uint32Value) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (uint32Value) Type() Type {
 	return TypeUint32
 }
@@ -1701,8 +3204,23 @@ func (v uint64Value) castTo(dst interface{}) error {
 	case *uint64:
 		*vv = uint64(v)
 		return nil
+	case *uint:
+		if err := checkUintFits(uint64(v), strconv.IntSize); err != nil {
+			return castError(uint64(v), v.Type(), vv, err)
+		}
+		*vv = uint(v)
+		return nil
+	case *int:
+		if err := checkUintFits(uint64(v), strconv.IntSize-1); err != nil {
+			return castError(uint64(v), v.Type(), vv, err)
+		}
+		*vv = int(v)
+		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
@@ -1710,6 +3228,10 @@ func (v uint64Value) Yql() string {
 	return strconv.FormatUint(uint64(v), 10) + "ul"
 }
 
+func (v uint64Value) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (uint64Value) Type() Type {
 	return TypeUint64
 }
@@ -1739,12 +3261,19 @@ func (v textValue) castTo(dst interface{}) error {
 		*vv = xstring.ToBytes(string(v))
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v textValue) Yql() string {
-	return fmt.Sprintf("%qu", string(v))
+	return quoteYql(string(v)) + "u"
+}
+
+func (v textValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
 }
 
 func (textValue) Type() Type {
@@ -1780,8 +3309,14 @@ func (v *uuidValue) castTo(dst interface{}) error {
 	case *[16]byte:
 		*vv = v.value
 		return nil
+	case *uuid.UUID:
+		*vv = v.value
+		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
@@ -1797,6 +3332,10 @@ func (v *uuidValue) Yql() string {
 	return buffer.String()
 }
 
+func (v *uuidValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (*uuidValue) Type() Type {
 	return TypeUUID
 }
@@ -1820,6 +3359,27 @@ func UUIDValue(v [16]byte) *uuidValue {
 	return &uuidValue{value: v}
 }
 
+// UUIDValueFromUUID makes UUID value from uuid.UUID (bytes of uuid.UUID are in RFC 4122 order
+// which is an order of BigEndianUint128)
+func UUIDValueFromUUID(u uuid.UUID) *uuidValue {
+	return &uuidValue{value: u}
+}
+
+// UUIDValueFromString parses UUID value from text form:
+// canonical (xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx), braced ({xxxxxxxx-...}),
+// URN (urn:uuid:xxxxxxxx-...) or 32 hex digits without dashes
+func UUIDValueFromString(s string) (*uuidValue, error) {
+	if len(s) == 36+2 && (s[0] != '{' || s[37] != '}') {
+		// uuid.Parse does not check braces
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %q: invalid braces", errMalformedUUID, s))
+	}
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %q: %v", errMalformedUUID, s, err))
+	}
+	return UUIDValueFromUUID(u), nil
+}
+
 type variantValue struct {
 	innerType Type
 	value     Value
@@ -1851,9 +3411,9 @@ func (v *variantValue) Yql() string {
 	buffer.WriteByte(',')
 	switch t := v.innerType.(type) {
 	case *variantStructType:
-		fmt.Fprintf(buffer, "%q", t.fields[v.idx].Name)
+		buffer.WriteString(quoteYql(t.fields[v.idx].Name))
 	case *variantTupleType:
-		fmt.Fprintf(buffer, "\""+strconv.FormatUint(uint64(v.idx), 10)+"\"")
+		buffer.WriteString(quoteYql(strconv.FormatUint(uint64(v.idx), 10)))
 	}
 	buffer.WriteByte(',')
 	buffer.WriteString(v.Type().Yql())
@@ -1861,6 +3421,10 @@ func (v *variantValue) Yql() string {
 	return buffer.String()
 }
 
+func (v *variantValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 func (v *variantValue) Type() Type {
 	return v.innerType
 }
@@ -1892,20 +3456,17 @@ func VariantValueStruct(v Value, name string, t Type) *variantValue {
 	var idx int
 	switch tt := t.(type) {
 	case *StructType:
-		sort.Slice(tt.fields, func(i, j int) bool {
-			return tt.fields[i].Name < tt.fields[j].Name
+		fields := sortedStructFields(tt.fields)
+		idx = sort.Search(len(fields), func(i int) bool {
+			return fields[i].Name >= name
 		})
-		idx = sort.Search(len(tt.fields), func(i int) bool {
-			return tt.fields[i].Name >= name
-		})
-		t = VariantStruct(tt.fields...)
+		t = VariantStruct(fields...)
 	case *variantStructType:
-		sort.Slice(tt.fields, func(i, j int) bool {
-			return tt.fields[i].Name < tt.fields[j].Name
-		})
-		idx = sort.Search(len(tt.fields), func(i int) bool {
-			return tt.fields[i].Name >= name
+		fields := sortedStructFields(tt.fields)
+		idx = sort.Search(len(fields), func(i int) bool {
+			return fields[i].Name >= name
 		})
+		t = VariantStruct(fields...)
 	}
 	return &variantValue{
 		innerType: t,
@@ -1914,16 +3475,73 @@ func VariantValueStruct(v Value, name string, t Type) *variantValue {
 	}
 }
 
+// VariantValueStructByName makes variant value of struct variant type t (Struct or Variant over Struct)
+// with alternative name. VariantValueStructByName returns error if t has no alternative name or if
+// type of v differs from type of alternative
+func VariantValueStructByName(v Value, name string, t Type) (*variantValue, error) {
+	var fields []StructField
+	switch tt := t.(type) {
+	case *StructType:
+		fields = tt.fields
+	case *variantStructType:
+		fields = tt.fields
+	default:
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s", errVariantStructType, t.Yql()))
+	}
+	fields = sortedStructFields(fields)
+	idx := sort.Search(len(fields), func(i int) bool {
+		return fields[i].Name >= name
+	})
+	if idx == len(fields) || fields[idx].Name != name {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: '%s' in %s", errVariantName, name, t.Yql()))
+	}
+	if !TypesEqual(v.Type(), fields[idx].T) {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: value of type %s for alternative '%s' of type %s",
+			errVariantValueType, v.Type().Yql(), name, fields[idx].T.Yql(),
+		))
+	}
+	return &variantValue{
+		innerType: VariantStruct(fields...),
+		value:     v,
+		idx:       uint32(idx),
+	}, nil
+}
+
+// sortedStructFields returns copy of fields sorted by name (type t must not be modified
+// because types are shared between values and goroutines)
+func sortedStructFields(fields []StructField) []StructField {
+	fields = append(make([]StructField, 0, len(fields)), fields...)
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+	return fields
+}
+
 type voidValue struct{}
 
 func (v voidValue) castTo(dst interface{}) error {
-	return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%s' to '%T' destination", v.Type().Yql(), dst))
+	switch vv := dst.(type) {
+	case *interface{}:
+		*vv = nil
+		return nil
+	default:
+		if ptr := reflect.ValueOf(dst); ptr.Kind() == reflect.Ptr && !ptr.IsNil() && ptr.Elem().Kind() == reflect.Ptr {
+			// Void casts to nil pointer like NULL
+			ptr.Elem().Set(reflect.Zero(ptr.Elem().Type()))
+			return nil
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%s' to '%T' destination", v.Type().Yql(), dst))
+	}
 }
 
 func (v voidValue) Yql() string {
 	return v.Type().Yql() + "()"
 }
 
+func (v voidValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
 var (
 	_voidValueType = voidType{}
 	_voidValue     = &Ydb.Value{
@@ -1953,13 +3571,32 @@ func (v ysonValue) castTo(dst interface{}) error {
 	case *[]byte:
 		*vv = v
 		return nil
+	case *json.RawMessage:
+		*vv = json.RawMessage(v)
+		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v ysonValue) Yql() string {
-	return fmt.Sprintf("%s(%q)", v.Type().Yql(), string(v))
+	return typedYql(v.Type().Yql(), string(v))
+}
+
+func (v ysonValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
+// String returns YQL representation of text YSON or bounded hex dump of binary YSON
+// (see SetBytesStringLimit)
+func (v ysonValue) String() string {
+	if isBinary(v) {
+		return hexDump(v.Type().Yql(), v)
+	}
+	return v.Yql()
 }
 
 func (ysonValue) Type() Type {
@@ -2029,6 +3666,18 @@ func zeroPrimitiveValue(t PrimitiveType) Value {
 	case TypeInterval:
 		return IntervalValue(0)
 
+	case TypeDate32:
+		return Date32Value(0)
+
+	case TypeDatetime64:
+		return Datetime64Value(0)
+
+	case TypeTimestamp64:
+		return Timestamp64Value(0)
+
+	case TypeInterval64:
+		return Interval64Value(0)
+
 	case TypeText:
 		return TextValue("")
 
@@ -2064,6 +3713,9 @@ func zeroPrimitiveValue(t PrimitiveType) Value {
 	}
 }
 
+// ZeroValue returns zero value of type t: NULL for optional types, empty containers,
+// zero items of tuples and fields of structs, zero value of first item of variants.
+// ZeroValue panics if type has no zero value (such as variant without items)
 func ZeroValue(t Type) Value {
 	switch t := t.(type) {
 	case PrimitiveType:
@@ -2071,11 +3723,17 @@ func ZeroValue(t Type) Value {
 
 	case optionalType:
 		return NullValue(t.innerType)
+	case *optionalType:
+		return NullValue(t.innerType)
 
-	case *voidType:
+	case voidType, *voidType:
 		return VoidValue()
 
-	case *listType, *emptyListType:
+	case nullType, *nullType:
+		// the same as value of Null type from YDB
+		return NullValue(Null())
+
+	case *listType, emptyListType, *emptyListType:
 		return &listValue{
 			t: t,
 		}
@@ -2083,11 +3741,7 @@ func ZeroValue(t Type) Value {
 		return &setValue{
 			t: t,
 		}
-	case *dictType:
-		return &dictValue{
-			t: t.valueType,
-		}
-	case *emptyDictType:
+	case *dictType, emptyDictType, *emptyDictType:
 		return &dictValue{
 			t: t,
 		}
@@ -2110,8 +3764,44 @@ func ZeroValue(t Type) Value {
 			}
 			return fields
 		}()...)
+	case *variantTupleType:
+		if len(t.items) == 0 {
+			panic(fmt.Sprintf("type '%s' have not a zero value", t.Yql()))
+		}
+		return &variantValue{
+			innerType: t,
+			value:     ZeroValue(t.items[0]),
+			idx:       0,
+		}
+	case *variantStructType:
+		if len(t.fields) == 0 {
+			panic(fmt.Sprintf("type '%s' have not a zero value", t.Yql()))
+		}
+		return &variantValue{
+			innerType: t,
+			value:     ZeroValue(t.fields[0].T),
+			idx:       0,
+		}
 	case *DecimalType:
-		return DecimalValue([16]byte{}, 22, 9)
+		return DecimalValue([16]byte{}, t.Precision, t.Scale)
+
+	case *taggedType:
+		return &taggedValue{
+			t:     t,
+			value: ZeroValue(t.innerType),
+		}
+
+	case *pgType:
+		return &pgValue{
+			t:    t,
+			null: true,
+		}
+
+	case *unknownType:
+		return &rawValue{
+			t: t,
+			v: &Ydb.Value{},
+		}
 
 	default:
 		panic(fmt.Sprintf("type '%T' have not a zero value", t))
@@ -2129,12 +3819,24 @@ func (v bytesValue) castTo(dst interface{}) error {
 		*vv = v
 		return nil
 	default:
-		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%+v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
+		if ok, err := castToNamedType(v, dst); ok {
+			return err
+		}
+		return xerrors.WithStackTrace(fmt.Errorf("cannot cast '%v' (type '%s') to '%T' destination", v, v.Type().Yql(), vv))
 	}
 }
 
 func (v bytesValue) Yql() string {
-	return fmt.Sprintf("%q", string(v))
+	return quoteYql(string(v))
+}
+
+func (v bytesValue) Format(s fmt.State, verb rune) {
+	formatValue(v, s, verb)
+}
+
+// String returns bounded hex dump of bytes value (see SetBytesStringLimit)
+func (v bytesValue) String() string {
+	return hexDump("Bytes", v)
 }
 
 func (bytesValue) Type() Type {
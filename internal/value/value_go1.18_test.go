@@ -0,0 +1,49 @@
+//go:build go1.18
+// +build go1.18
+
+package value
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+)
+
+func FuzzFromYDBWithError(f *testing.F) {
+	a := allocator.New()
+	defer a.Free()
+	for _, v := range []Value{
+		Int32Value(1),
+		TextValue("text"),
+		OptionalValue(OptionalValue(Uint64Value(1))),
+		NullValue(Optional(TypeBytes)),
+		DecimalValueFromBigInt(big.NewInt(-123456789), 22, 9),
+		ListValue(Int32Value(1), Int32Value(2)),
+		TupleValue(Int32Value(1), TextValue("2")),
+		StructValue(StructValueField{Name: "a", V: Int32Value(1)}),
+		DictValue(DictValueField{K: TextValue("a"), V: Int32Value(1)}),
+		SetValue(TextValue("a")),
+		VariantValueTuple(TextValue("a"), 1, Tuple(TypeInt32, TypeText)),
+		VariantValueStruct(Int32Value(1), "a", Struct(StructField{Name: "a", T: TypeInt32})),
+	} {
+		data, err := proto.Marshal(ToYDB(v, a))
+		require.NoError(f, err)
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var typedValue Ydb.TypedValue
+		if err := proto.Unmarshal(data, &typedValue); err != nil {
+			return
+		}
+		v, err := FromYDBWithError(typedValue.GetType(), typedValue.GetValue())
+		if err != nil {
+			return
+		}
+		_ = v.Yql()
+	})
+}
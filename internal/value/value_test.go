@@ -1,16 +1,24 @@
 package value
 
 import (
+	"encoding/binary"
+	"fmt"
 	"math"
 	"math/big"
+	"os"
+	"path/filepath"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
 )
 
 func BenchmarkMemory(b *testing.B) {
@@ -109,6 +117,10 @@ func TestToYDBFromYDB(t *testing.T) {
 		DatetimeValue(1),
 		TimestampValue(1),
 		IntervalValue(1),
+		Date32Value(-1),
+		Datetime64Value(-1),
+		Timestamp64Value(-1),
+		Interval64Value(-1),
 		VoidValue(),
 		FloatValue(1),
 		DoubleValue(1),
@@ -172,12 +184,16 @@ func TestToYDBFromYDB(t *testing.T) {
 		ZeroValue(TypeText),
 		ZeroValue(Struct()),
 		ZeroValue(Tuple()),
+		DictValueOfTypes(TypeText, TypeInt32),
+		DictValueOfTypes(TypeText, Optional(TypeInt32),
+			DictValueField{TextValue("a"), NullValue(TypeInt32)},
+		),
 	} {
 		t.Run(strconv.Itoa(i)+"."+v.Yql(), func(t *testing.T) {
 			a := allocator.New()
 			defer a.Free()
 			value := ToYDB(v, a)
-			dualConversedValue, err := fromYDB(value.Type, value.Value)
+			dualConversedValue, err := FromYDBWithError(value.Type, value.Value)
 			require.NoError(t, err)
 			if !proto.Equal(value, ToYDB(dualConversedValue, a)) {
 				t.Errorf("dual conversion failed:\n\n - got:  %v\n\n - want: %v", ToYDB(dualConversedValue, a), value)
@@ -311,7 +327,7 @@ func TestValueYql(t *testing.T) {
 		},
 		{
 			value:   IntervalValueFromDuration(time.Duration(42) * time.Millisecond),
-			literal: `Interval("PT0.042000S")`,
+			literal: `Interval("PT0.042S")`,
 		},
 		{
 			value: TimestampValueFromTime(func() time.Time {
@@ -469,11 +485,11 @@ func TestValueYql(t *testing.T) {
 		},
 		{
 			value:   JSONValue("{\"a\":-1234567890123456}"),
-			literal: `Json(@@{"a":-1234567890123456}@@)`,
+			literal: `Json("{\"a\":-1234567890123456}")`,
 		},
 		{
 			value:   JSONDocumentValue("{\"a\":-1234567890123456}"),
-			literal: `JsonDocument(@@{"a":-1234567890123456}@@)`,
+			literal: `JsonDocument("{\"a\":-1234567890123456}")`,
 		},
 		{
 			value:   YSONValue([]byte("<a=1>[3;%false]")),
@@ -485,3 +501,1668 @@ func TestValueYql(t *testing.T) {
 		})
 	}
 }
+
+func TestValueYqlEscaping(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		value   Value
+		literal string
+	}{
+		{
+			name:    "TextBackslashes",
+			value:   TextValue(`C:\path\"quoted"\`),
+			literal: `"C:\\path\\\"quoted\"\\"u`,
+		},
+		{
+			name:    "TextControlCharacters",
+			value:   TextValue("a\nb\r\tc\x00d\x1be\x7f"),
+			literal: `"a\nb\r\tc\x00d\x1be\x7f"u`,
+		},
+		{
+			name:    "TextNonASCII",
+			value:   TextValue("привет, 世界 🙂"),
+			literal: `"привет, 世界 🙂"u`,
+		},
+		{
+			name:    "TextBackticks",
+			value:   TextValue("`a`"),
+			literal: "\"`a`\"u",
+		},
+		{
+			name:    "TextRawStringDelimiters",
+			value:   TextValue("@@a@@b@@"),
+			literal: `"@@a@@b@@"u`,
+		},
+		{
+			name:    "TextQuotes",
+			value:   TextValue(`'single' "double"`),
+			literal: `"'single' \"double\""u`,
+		},
+		{
+			name:    "BytesQuotesAndNewlines",
+			value:   BytesValue([]byte("\"a\"\n'b'\\")),
+			literal: `"\"a\"\n'b'\\"`,
+		},
+		{
+			name:    "YSONRawStringDelimiters",
+			value:   YSONValue([]byte(`{a="@@"}`)),
+			literal: `Yson("{a=\"@@\"}")`,
+		},
+		{
+			name:    "TzDateNewline",
+			value:   TzDateValue("2022-06-17,Europe/Berlin\n"),
+			literal: `TzDate("2022-06-17,Europe/Berlin\n")`,
+		},
+		{
+			name:    "TzTimestampBackslash",
+			value:   TzTimestampValue(`2022-06-17T05:19:20.123456,Europe\Berlin`),
+			literal: `TzTimestamp("2022-06-17T05:19:20.123456,Europe\\Berlin")`,
+		},
+		{
+			name:    "BytesInvalidUTF8",
+			value:   BytesValue([]byte("a\xc3\x28\xff\"b")),
+			literal: `"a\xc3(\xff\"b"`,
+		},
+		{
+			name:    "JSONWithDelimiters",
+			value:   JSONValue(`{"a":"@@)"}`),
+			literal: `Json("{\"a\":\"@@)\"}")`,
+		},
+		{
+			name:    "JSONDocumentWithEscapes",
+			value:   JSONDocumentValue(`{"a":"\n\\"}`),
+			literal: `JsonDocument("{\"a\":\"\\n\\\\\"}")`,
+		},
+		{
+			name:    "YSON",
+			value:   YSONValue([]byte("\"a\\b\"\n")),
+			literal: `Yson("\"a\\b\"\n")`,
+		},
+		{
+			name:    "TzDatetime",
+			value:   TzDatetimeValue(`2022-06-17T05:19:20,Europe/Berlin"`),
+			literal: `TzDatetime("2022-06-17T05:19:20,Europe/Berlin\"")`,
+		},
+		{
+			name:    "DyNumber",
+			value:   DyNumberValue(`1")`),
+			literal: `DyNumber("1\")")`,
+		},
+		{
+			name: "StructFieldNames",
+			value: StructValue(
+				StructValueField{Name: "a`b", V: Int32Value(1)},
+				StructValueField{Name: `c\d`, V: Int32Value(2)},
+			),
+			literal: "<|`a\\`b`:1,`c\\\\d`:2|>",
+		},
+		{
+			name:    "VariantStructFieldName",
+			value:   VariantValueStruct(Int32Value(1), "a\"b", Struct(StructField{"a\"b", TypeInt32})),
+			literal: `Variant(1,"a\"b",Variant<'a"b':Int32>)`,
+		},
+		{
+			name:    "DecimalSmall",
+			value:   DecimalValueFromBigInt(big.NewInt(1), 22, 9),
+			literal: `Decimal("0.000000001",22,9)`,
+		},
+		{
+			name:    "DecimalSmallNegative",
+			value:   DecimalValueFromBigInt(big.NewInt(-10), 22, 9),
+			literal: `Decimal("-0.000000010",22,9)`,
+		},
+		{
+			name:    "DecimalZero",
+			value:   DecimalValueFromBigInt(big.NewInt(0), 22, 9),
+			literal: `Decimal("0.000000000",22,9)`,
+		},
+		{
+			name:    "DecimalZeroScale",
+			value:   DecimalValueFromBigInt(big.NewInt(-42), 22, 0),
+			literal: `Decimal("-42",22,0)`,
+		},
+		{
+			name:    "DecimalInf",
+			value:   DecimalValueFromBigInt(decimal.Inf(), 22, 9),
+			literal: `Decimal("inf",22,9)`,
+		},
+		{
+			name:    "DecimalNegInf",
+			value:   DecimalValueFromBigInt(new(big.Int).Neg(decimal.Inf()), 22, 9),
+			literal: `Decimal("-inf",22,9)`,
+		},
+		{
+			name:    "DecimalNaN",
+			value:   DecimalValueFromBigInt(decimal.NaN(), 22, 9),
+			literal: `Decimal("nan",22,9)`,
+		},
+		{
+			name:    "OptionalText",
+			value:   OptionalValue(TextValue(`"x"`)),
+			literal: `Just("\"x\""u)`,
+		},
+		{
+			name:    "NullText",
+			value:   NullValue(TypeText),
+			literal: `Nothing(Optional<Utf8>)`,
+		},
+		{
+			name:    "OptionalOptionalText",
+			value:   OptionalValue(OptionalValue(TextValue(`"x"`))),
+			literal: `Just(Just("\"x\""u))`,
+		},
+		{
+			name:    "OptionalNullText",
+			value:   OptionalValue(NullValue(TypeText)),
+			literal: `Just(Nothing(Optional<Utf8>))`,
+		},
+		{
+			name:    "OptionalDecimal",
+			value:   OptionalValue(DecimalValueFromBigInt(big.NewInt(15), 22, 1)),
+			literal: `Just(Decimal("1.5",22,1))`,
+		},
+		{
+			name:    "NullDecimal",
+			value:   NullValue(Decimal(22, 9)),
+			literal: `Nothing(Optional<Decimal(22,9)>)`,
+		},
+		{
+			name:    "NullDate",
+			value:   NullValue(TypeDate),
+			literal: `Nothing(Optional<Date>)`,
+		},
+		{
+			name:    "OptionalStruct",
+			value:   OptionalValue(StructValue(StructValueField{Name: "a", V: NullValue(TypeInt32)})),
+			literal: "Just(<|`a`:Nothing(Optional<Int32>)|>)",
+		},
+		{
+			name:    "NullList",
+			value:   NullValue(List(TypeInt32)),
+			literal: `Nothing(Optional<List<Int32>>)`,
+		},
+		{
+			name:    "Int8Negative",
+			value:   Int8Value(-128),
+			literal: `-128t`,
+		},
+		{
+			name:    "Int16Negative",
+			value:   Int16Value(-1),
+			literal: `-1s`,
+		},
+		{
+			name:    "Int64Negative",
+			value:   Int64Value(math.MinInt64),
+			literal: `-9223372036854775808l`,
+		},
+		{
+			name:    "IntervalNegative",
+			value:   IntervalValueFromDuration(-time.Second),
+			literal: `Interval("-PT1S")`,
+		},
+		{
+			name:    "NullOfOptional",
+			value:   NullValue(Optional(TypeText)),
+			literal: `Nothing(Optional<Optional<Utf8>>)`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.literal, tt.value.Yql())
+		})
+	}
+}
+
+func TestConcurrentToYDB(t *testing.T) {
+	v := StructValue(
+		StructValueField{Name: "id", V: Uint64Value(42)},
+		StructValueField{Name: "tags", V: SetValue(TextValue("b"), TextValue("a"), TextValue("c"))},
+		StructValueField{Name: "attrs", V: DictValue(
+			DictValueField{K: TextValue("y"), V: OptionalValue(Int32Value(2))},
+			DictValueField{K: TextValue("x"), V: NullValue(TypeInt32)},
+		)},
+		StructValueField{Name: "items", V: ListValue(
+			TupleValue(DecimalValueFromBigInt(big.NewInt(-1500000000), 22, 9), TzDatetimeValue("2022-06-17T05:19:20,Europe/Berlin")),
+			TupleValue(DecimalValueFromBigInt(big.NewInt(100500000000000), 22, 9), TzDatetimeValue("2022-06-17T05:19:21,Europe/Berlin")),
+		)},
+		StructValueField{Name: "variant", V: VariantValueStruct(Int32Value(42), "bar", Struct(
+			StructField{Name: "foo", T: TypeText},
+			StructField{Name: "bar", T: TypeInt32},
+		))},
+		StructValueField{Name: "raw", V: YSONValue([]byte("<a=1>[3;%false]"))},
+		StructValueField{Name: "void", V: VoidValue()},
+	)
+
+	a := allocator.New()
+	defer a.Free()
+	exp := ToYDB(v, a)
+	expYql := v.Yql()
+
+	const goroutines = 100
+	var (
+		start = make(chan struct{})
+		wg    sync.WaitGroup
+		errs  = make(chan string, goroutines)
+	)
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			defer wg.Done()
+			a := allocator.New()
+			defer a.Free()
+			<-start
+			if act := ToYDB(v, a); !proto.Equal(exp, act) {
+				errs <- act.String()
+			}
+			if yql := v.Yql(); yql != expYql {
+				errs <- yql
+			}
+			if _, err := NativeValue(v); err != nil {
+				errs <- err.Error()
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestConstructorsDoNotModifyArguments(t *testing.T) {
+	t.Run("DictValue", func(t *testing.T) {
+		fields := []DictValueField{
+			{K: TextValue("b"), V: Int32Value(2)},
+			{K: TextValue("a"), V: Int32Value(1)},
+		}
+		v := DictValue(fields...)
+		require.Equal(t, "b", string(fields[0].K.(textValue)))
+		require.Equal(t, `{"a"u:1,"b"u:2}`, v.Yql())
+	})
+	t.Run("SetValue", func(t *testing.T) {
+		items := []Value{TextValue("b"), TextValue("a")}
+		v := SetValue(items...)
+		require.Equal(t, "b", string(items[0].(textValue)))
+		require.Equal(t, `{"a"u,"b"u}`, v.Yql())
+	})
+	t.Run("StructValue", func(t *testing.T) {
+		fields := []StructValueField{
+			{Name: "b", V: Int32Value(2)},
+			{Name: "a", V: Int32Value(1)},
+		}
+		v := StructValue(fields...)
+		require.Equal(t, "b", fields[0].Name)
+		require.Equal(t, "<|`a`:1,`b`:2|>", v.Yql())
+	})
+	t.Run("VariantValueStruct", func(t *testing.T) {
+		typ := Struct(
+			StructField{Name: "foo", T: TypeText},
+			StructField{Name: "bar", T: TypeInt32},
+		)
+		yql := typ.Yql()
+		v := VariantValueStruct(Int32Value(42), "bar", typ)
+		require.Equal(t, yql, typ.Yql())
+		require.Equal(t, "Variant<'bar':Int32,'foo':Utf8>", v.Type().Yql())
+	})
+}
+
+func TestVariantValueStructByName(t *testing.T) {
+	typ := Struct(
+		StructField{Name: "foo", T: TypeText},
+		StructField{Name: "bar", T: TypeInt32},
+		StructField{Name: "baz", T: Optional(TypeBool)},
+	)
+	for _, tt := range []struct {
+		name string
+		v    Value
+		t    Type
+		idx  uint32
+	}{
+		{"bar", Int32Value(42), typ, 0},
+		{"baz", NullValue(TypeBool), typ, 1},
+		{"foo", TextValue("a"), typ, 2},
+		{"foo", TextValue("a"), VariantStruct(typ.fields...), 2},
+	} {
+		t.Run(tt.t.Yql()+"/"+tt.name, func(t *testing.T) {
+			v, err := VariantValueStructByName(tt.v, tt.name, tt.t)
+			require.NoError(t, err)
+			require.Equal(t, "Variant<'bar':Int32,'baz':Optional<Bool>,'foo':Utf8>", v.Type().Yql())
+			require.Equal(t, tt.idx, v.idx)
+			require.True(t, Equal(VariantValueStruct(tt.v, tt.name, tt.t), v))
+			require.Equal(t, "Struct<'foo':Utf8,'bar':Int32,'baz':Optional<Bool>>", typ.Yql())
+		})
+	}
+	for _, tt := range []struct {
+		name string
+		v    Value
+		t    Type
+	}{
+		{"qux", Int32Value(42), typ},
+		{"", Int32Value(42), typ},
+		{"bar", Int64Value(42), typ},
+		{"baz", BoolValue(true), typ},
+		{"bar", Int32Value(42), VariantTuple(TypeInt32)},
+	} {
+		t.Run("Error/"+tt.t.Yql()+"/"+tt.name, func(t *testing.T) {
+			_, err := VariantValueStructByName(tt.v, tt.name, tt.t)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestStructValueFields(t *testing.T) {
+	v := StructValue(
+		StructValueField{Name: "c", V: Int32Value(3)},
+		StructValueField{Name: "a", V: Int32Value(1)},
+		StructValueField{Name: "b", V: Int32Value(2)},
+	)
+	require.Equal(t, 3, v.Len())
+	for i, name := range []string{"a", "b", "c"} {
+		require.Equal(t, name, v.Field(i).Name)
+		require.Equal(t, Int32Value(int32(i+1)), v.Field(i).V)
+
+		field, ok := v.GetField(name)
+		require.True(t, ok)
+		require.Equal(t, Int32Value(int32(i+1)), field)
+	}
+	for _, name := range []string{"", "0", "aa", "d"} {
+		field, ok := v.GetField(name)
+		require.False(t, ok, name)
+		require.Nil(t, field)
+	}
+
+	empty := StructValue()
+	require.Zero(t, empty.Len())
+	_, ok := empty.GetField("a")
+	require.False(t, ok)
+}
+
+func TestStructValueOrdered(t *testing.T) {
+	fields := []StructValueField{
+		{Name: "c", V: Int32Value(3)},
+		{Name: "a", V: Int32Value(1)},
+		{Name: "b", V: TextValue("2")},
+	}
+	for _, tt := range []struct {
+		name  string
+		v     *structValue
+		names []string
+	}{
+		{
+			name:  "Sorted",
+			v:     StructValue(fields...),
+			names: []string{"a", "b", "c"},
+		},
+		{
+			name:  "Ordered",
+			v:     StructValueOrdered(fields...),
+			names: []string{"c", "a", "b"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a := allocator.New()
+			defer a.Free()
+			require.Equal(t, "c", fields[0].Name)
+			for i, name := range tt.names {
+				require.Equal(t, name, tt.v.Field(i).Name)
+				field, ok := tt.v.GetField(name)
+				require.True(t, ok)
+				require.Equal(t, tt.v.Field(i).V, field)
+			}
+			_, ok := tt.v.GetField("d")
+			require.False(t, ok)
+
+			fromYDB, err := FromYDBWithError(tt.v.Type().toYDB(a), tt.v.toYDB(a))
+			require.NoError(t, err)
+			require.True(t, fromYDB.Type().equalsTo(tt.v.Type()))
+			require.Equal(t, tt.v.Yql(), fromYDB.Yql())
+			require.True(t, proto.Equal(ToYDB(tt.v, a), ToYDB(fromYDB, a)))
+		})
+	}
+	t.Run("DeclaredType", func(t *testing.T) {
+		a := allocator.New()
+		defer a.Free()
+		declared := Struct(
+			StructField{Name: "c", T: TypeInt32},
+			StructField{Name: "a", T: TypeInt32},
+			StructField{Name: "b", T: TypeText},
+		)
+		v := StructValueOrdered(fields...)
+		require.True(t, v.Type().equalsTo(declared))
+		require.False(t, StructValue(fields...).Type().equalsTo(declared))
+		require.Equal(t, "<|`c`:3,`a`:1,`b`:\"2\"u|>", v.Yql())
+
+		fromYDB, err := FromYDBWithError(declared.toYDB(a), v.toYDB(a))
+		require.NoError(t, err)
+		require.True(t, fromYDB.Type().equalsTo(declared))
+		field, ok := fromYDB.(*structValue).GetField("a")
+		require.True(t, ok)
+		require.Equal(t, Int32Value(1), field)
+	})
+}
+
+func BenchmarkStructValueGetField(b *testing.B) {
+	for _, size := range []int{4, 16, 64} {
+		fields := make([]StructValueField, size)
+		for i := range fields {
+			fields[i] = StructValueField{Name: "field_" + strconv.Itoa(i), V: Int64Value(int64(i))}
+		}
+		v := StructValue(fields...)
+		name := fields[size/2].Name
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			b.Run("StructFields", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, ok := v.StructFields()[name]; !ok {
+						b.Fatal("field not found")
+					}
+				}
+			})
+			b.Run("GetField", func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, ok := v.GetField(name); !ok {
+						b.Fatal("field not found")
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestListValueItems(t *testing.T) {
+	v := ListValue(Int32Value(1), Int32Value(2), Int32Value(3))
+	require.Equal(t, 3, v.Len())
+	require.Equal(t, []Value{Int32Value(1), Int32Value(2), Int32Value(3)}, v.Items())
+	for i := 0; i < v.Len(); i++ {
+		require.Equal(t, Int32Value(int32(i+1)), v.ItemAt(i))
+	}
+	require.Nil(t, v.ItemAt(-1))
+	require.Nil(t, v.ItemAt(3))
+
+	a := allocator.New()
+	defer a.Free()
+	list, ok := FromYDB(v.Type().toYDB(a), v.toYDB(a)).(*listValue)
+	require.True(t, ok)
+	require.Equal(t, 3, list.Len())
+	require.Len(t, list.Items(), 3)
+	require.Equal(t, 3, cap(list.Items()))
+	require.Equal(t, Int32Value(2), list.ItemAt(1))
+
+	empty := ListValue()
+	require.Zero(t, empty.Len())
+	require.Empty(t, empty.Items())
+	require.Nil(t, empty.ItemAt(0))
+}
+
+func TestTupleValueItems(t *testing.T) {
+	v := TupleValue(Int32Value(1), TextValue("2"))
+	require.Equal(t, 2, v.Len())
+	require.Equal(t, []Value{Int32Value(1), TextValue("2")}, v.Items())
+	item, err := v.ItemAt(1)
+	require.NoError(t, err)
+	require.Equal(t, TextValue("2"), item)
+	for _, i := range []int{-1, 2} {
+		_, err = v.ItemAt(i)
+		require.ErrorIs(t, err, errTupleItemIndex)
+	}
+
+	tt, ok := v.Type().(*TupleType)
+	require.True(t, ok)
+	require.Equal(t, v.Len(), tt.Len())
+	require.Equal(t, TypeInt32, tt.ItemType(0))
+	require.Equal(t, TypeText, tt.ItemType(1))
+	require.Nil(t, tt.ItemType(-1))
+	require.Nil(t, tt.ItemType(2))
+
+	empty := TupleValue()
+	require.Zero(t, empty.Len())
+	_, err = empty.ItemAt(0)
+	require.ErrorIs(t, err, errTupleItemIndex)
+}
+
+func TestDictValueGet(t *testing.T) {
+	v := DictValue(
+		DictValueField{K: TextValue("c"), V: Int32Value(3)},
+		DictValueField{K: TextValue("a"), V: Int32Value(1)},
+		DictValueField{K: TextValue("b"), V: Int32Value(2)},
+	)
+	require.Equal(t, 3, v.Len())
+	for i, key := range []string{"a", "b", "c"} {
+		pair := v.PairAt(i)
+		require.Equal(t, TextValue(key), pair.K)
+		require.Equal(t, Int32Value(int32(i+1)), pair.V)
+
+		// key constructed independently of dict value
+		value, ok := v.Get(TextValue(key))
+		require.True(t, ok)
+		require.Equal(t, Int32Value(int32(i+1)), value)
+	}
+	for _, key := range []Value{
+		TextValue("d"),
+		TextValue(""),
+		BytesValue([]byte("a")),
+		OptionalValue(TextValue("a")),
+	} {
+		value, ok := v.Get(key)
+		require.False(t, ok, key.Yql())
+		require.Nil(t, value)
+	}
+
+	t.Run("FromYDB", func(t *testing.T) {
+		a := allocator.New()
+		defer a.Free()
+		fromYDB, ok := FromYDB(v.Type().toYDB(a), v.toYDB(a)).(*dictValue)
+		require.True(t, ok)
+		value, ok := fromYDB.Get(TextValue("b"))
+		require.True(t, ok)
+		require.Equal(t, Int32Value(2), value)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		v := DictValue()
+		require.Zero(t, v.Len())
+		_, ok := v.Get(TextValue("a"))
+		require.False(t, ok)
+	})
+}
+
+func TestOptionalValueUnwrap(t *testing.T) {
+	a := allocator.New()
+	defer a.Free()
+	for _, tt := range []struct {
+		name   string
+		v      Value
+		null   bool
+		inner  Value
+		unwrap Value
+	}{
+		{
+			name:   "Just(Just(T))",
+			v:      OptionalValue(OptionalValue(Int32Value(42))),
+			null:   false,
+			inner:  OptionalValue(Int32Value(42)),
+			unwrap: Int32Value(42),
+		},
+		{
+			name:   "Just(Nothing(T))",
+			v:      OptionalValue(NullValue(TypeInt32)),
+			null:   false,
+			inner:  NullValue(TypeInt32),
+			unwrap: nil,
+		},
+		{
+			name:   "Nothing(Optional<T>)",
+			v:      NullValue(Optional(TypeInt32)),
+			null:   true,
+			inner:  nil,
+			unwrap: nil,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, v := range []Value{
+				tt.v,
+				FromYDB(tt.v.Type().toYDB(a), tt.v.toYDB(a)),
+			} {
+				optional, ok := v.(OptionalValuer)
+				require.True(t, ok)
+				require.Equal(t, tt.null, optional.IsNull())
+
+				inner, ok := optional.Unwrap()
+				require.Equal(t, !tt.null, ok)
+				if tt.inner == nil {
+					require.Nil(t, inner)
+				} else {
+					require.Equal(t, tt.inner.Yql(), inner.Yql())
+					require.Equal(t, tt.inner.Type().Yql(), inner.Type().Yql())
+				}
+
+				unwrapped, ok := Unwrap(v)
+				require.Equal(t, tt.unwrap != nil, ok)
+				require.Equal(t, tt.unwrap, unwrapped)
+			}
+		})
+	}
+
+	t.Run("NonOptional", func(t *testing.T) {
+		v, ok := Unwrap(Int32Value(42))
+		require.True(t, ok)
+		require.Equal(t, Int32Value(42), v)
+
+		_, ok = Value(Int32Value(42)).(OptionalValuer)
+		require.False(t, ok)
+	})
+}
+
+func TestNestedOptionalNullRoundTrip(t *testing.T) {
+	a := allocator.New()
+	defer a.Free()
+	null := &Ydb.Value{Value: &Ydb.Value_NullFlagValue{}}
+	nested := func(v *Ydb.Value) *Ydb.Value {
+		return &Ydb.Value{Value: &Ydb.Value_NestedValue{NestedValue: v}}
+	}
+	for _, tt := range []struct {
+		name string
+		v    Value
+		ydb  *Ydb.Value
+	}{
+		{
+			name: "Nothing(Optional<Optional<Int32>>)",
+			v:    NullValue(Optional(TypeInt32)),
+			ydb:  null,
+		},
+		{
+			name: "Just(Nothing(Optional<Int32>))",
+			v:    OptionalValue(NullValue(TypeInt32)),
+			ydb:  nested(null),
+		},
+		{
+			name: "Just(Just(Int32))",
+			v:    OptionalValue(OptionalValue(Int32Value(42))),
+			ydb:  nested(&Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: 42}}),
+		},
+		{
+			name: "Nothing(Optional<Optional<Optional<Int32>>>)",
+			v:    NullValue(Optional(Optional(TypeInt32))),
+			ydb:  null,
+		},
+		{
+			name: "Just(Nothing(Optional<Optional<Int32>>))",
+			v:    OptionalValue(NullValue(Optional(TypeInt32))),
+			ydb:  nested(null),
+		},
+		{
+			name: "Just(Just(Nothing(Optional<Int32>)))",
+			v:    OptionalValue(OptionalValue(NullValue(TypeInt32))),
+			ydb:  nested(nested(null)),
+		},
+		{
+			name: "Just(Just(Just(Int32)))",
+			v:    OptionalValue(OptionalValue(OptionalValue(Int32Value(42)))),
+			ydb:  nested(nested(&Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: 42}})),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			typedValue := ToYDB(tt.v, a)
+			require.True(t, proto.Equal(tt.ydb, typedValue.Value), typedValue.Value.String())
+
+			// values are decoded from wire representation as received from server
+			data, err := proto.Marshal(typedValue)
+			require.NoError(t, err)
+			var received Ydb.TypedValue
+			require.NoError(t, proto.Unmarshal(data, &received))
+
+			v, err := FromYDBWithError(received.Type, received.Value)
+			require.NoError(t, err)
+			require.Equal(t, tt.v.Type().Yql(), v.Type().Yql())
+			require.Equal(t, tt.v.Yql(), v.Yql())
+			require.True(t, Equal(tt.v, v))
+			require.True(t, proto.Equal(typedValue, ToYDB(v, a)))
+		})
+	}
+}
+
+func TestFromYDBWithErrorMalformed(t *testing.T) {
+	a := allocator.New()
+	defer a.Free()
+	for _, tt := range []struct {
+		name string
+		t    *Ydb.Type
+		v    *Ydb.Value
+	}{
+		{
+			name: "UnknownType",
+			t:    &Ydb.Type{},
+			v:    &Ydb.Value{},
+		},
+		{
+			name: "DecimalPrecisionOutOfRange",
+			t: &Ydb.Type{Type: &Ydb.Type_DecimalType{DecimalType: &Ydb.DecimalType{
+				Precision: 1000000000,
+				Scale:     9,
+			}}},
+			v: &Ydb.Value{},
+		},
+		{
+			name: "UnknownTypeOfListItem",
+			t:    &Ydb.Type{Type: &Ydb.Type_ListType{ListType: &Ydb.ListType{}}},
+			v:    &Ydb.Value{},
+		},
+		{
+			name: "NilValue",
+			t:    TypeInt32.toYDB(a),
+			v:    nil,
+		},
+		{
+			name: "TruncatedTupleItems",
+			t:    Tuple(TypeInt32, TypeText).toYDB(a),
+			v:    &Ydb.Value{Items: []*Ydb.Value{{Value: &Ydb.Value_Int32Value{Int32Value: 1}}}},
+		},
+		{
+			name: "TruncatedStructItems",
+			t: Struct(
+				StructField{Name: "a", T: TypeInt32},
+				StructField{Name: "b", T: TypeText},
+			).toYDB(a),
+			v: &Ydb.Value{Items: []*Ydb.Value{{Value: &Ydb.Value_Int32Value{Int32Value: 1}}}},
+		},
+		{
+			name: "NilListItem",
+			t:    List(TypeInt32).toYDB(a),
+			v:    &Ydb.Value{Items: []*Ydb.Value{nil}},
+		},
+		{
+			name: "NilDictPayload",
+			t:    Dict(TypeText, TypeInt32).toYDB(a),
+			v: &Ydb.Value{Pairs: []*Ydb.ValuePair{{
+				Key: &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: "a"}},
+			}}},
+		},
+		{
+			name: "WrongNestedKindOfYSON",
+			t:    TypeYSON.toYDB(a),
+			v:    &Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: 1}},
+		},
+		{
+			name: "WrongNestedKindOfListItem",
+			t:    List(Tuple(TypeInt32)).toYDB(a),
+			v:    &Ydb.Value{Items: []*Ydb.Value{{Value: &Ydb.Value_Int32Value{Int32Value: 1}}}},
+		},
+		{
+			name: "VariantTupleWithoutNestedValue",
+			t:    VariantTuple(TypeInt32, TypeText).toYDB(a),
+			v:    &Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: 1}},
+		},
+		{
+			name: "VariantStructWithoutNestedValue",
+			t:    VariantStruct(StructField{Name: "a", T: TypeInt32}).toYDB(a),
+			v:    &Ydb.Value{},
+		},
+		{
+			name: "EmptyListWithItems",
+			t:    EmptyList().toYDB(a),
+			v:    &Ydb.Value{Items: []*Ydb.Value{{Value: &Ydb.Value_Int32Value{Int32Value: 1}}}},
+		},
+		{
+			name: "EmptyDictWithPairs",
+			t:    EmptyDict().toYDB(a),
+			v: &Ydb.Value{Pairs: []*Ydb.ValuePair{{
+				Key:     &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: "a"}},
+				Payload: &Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: 1}},
+			}}},
+		},
+		{
+			name: "VariantIndexOutOfRange",
+			t:    VariantTuple(TypeInt32).toYDB(a),
+			v: &Ydb.Value{
+				Value: &Ydb.Value_NestedValue{
+					NestedValue: &Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: 1}},
+				},
+				VariantIndex: 1,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				v   Value
+				err error
+			)
+			require.NotPanics(t, func() {
+				v, err = FromYDBWithError(tt.t, tt.v)
+			})
+			require.Error(t, err)
+			require.Nil(t, v)
+			require.Panics(t, func() {
+				_ = FromYDB(tt.t, tt.v)
+			})
+		})
+	}
+}
+
+func TestOptionalItemsFromYDB(t *testing.T) {
+	var (
+		null = func() *Ydb.Value {
+			return &Ydb.Value{Value: &Ydb.Value_NullFlagValue{}}
+		}
+		nested = func(v *Ydb.Value) *Ydb.Value {
+			return &Ydb.Value{Value: &Ydb.Value_NestedValue{NestedValue: v}}
+		}
+		text = &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: "a"}}
+		one  = &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: 1}}
+	)
+	// pairs of dicts and sets are in order of sorted keys as pairs of decoded values
+	for _, tt := range []struct {
+		name     string
+		t        Type
+		v        *Ydb.Value
+		expected Value
+	}{
+		{
+			name: "DictOfOptionals",
+			t:    Dict(Optional(TypeText), Optional(TypeInt64)),
+			v: &Ydb.Value{Pairs: []*Ydb.ValuePair{
+				{Key: text, Payload: null()},
+				{Key: null(), Payload: one},
+			}},
+			expected: DictValue(
+				DictValueField{NullValue(TypeText), OptionalValue(Int64Value(1))},
+				DictValueField{OptionalValue(TextValue("a")), NullValue(TypeInt64)},
+			),
+		},
+		{
+			name: "DictOfNestedOptionals",
+			t:    Dict(Optional(Optional(TypeText)), Optional(Optional(TypeInt64))),
+			v: &Ydb.Value{Pairs: []*Ydb.ValuePair{
+				{Key: nested(text), Payload: null()},
+				{Key: nested(null()), Payload: nested(one)},
+				{Key: null(), Payload: nested(null())},
+			}},
+			expected: DictValue(
+				DictValueField{NullValue(Optional(TypeText)), OptionalValue(NullValue(TypeInt64))},
+				DictValueField{OptionalValue(NullValue(TypeText)), OptionalValue(OptionalValue(Int64Value(1)))},
+				DictValueField{OptionalValue(OptionalValue(TextValue("a"))), NullValue(Optional(TypeInt64))},
+			),
+		},
+		{
+			name:     "SetOfOptionals",
+			t:        Set(Optional(TypeText)),
+			v:        &Ydb.Value{Pairs: []*Ydb.ValuePair{{Key: text, Payload: null()}, {Key: null(), Payload: null()}}},
+			expected: SetValue(NullValue(TypeText), OptionalValue(TextValue("a"))),
+		},
+		{
+			name:     "ListOfNestedOptionals",
+			t:        List(Optional(Optional(TypeInt64))),
+			v:        &Ydb.Value{Items: []*Ydb.Value{null(), nested(null()), nested(one)}},
+			expected: ListValue(NullValue(Optional(TypeInt64)), OptionalValue(NullValue(TypeInt64)), OptionalValue(OptionalValue(Int64Value(1)))),
+		},
+		{
+			name: "StructOfNestedOptionals",
+			t: Struct(
+				StructField{Name: "a", T: Optional(Optional(TypeInt64))},
+				StructField{Name: "b", T: Optional(Optional(TypeInt64))},
+			),
+			v: &Ydb.Value{Items: []*Ydb.Value{nested(null()), null()}},
+			expected: StructValue(
+				StructValueField{Name: "a", V: OptionalValue(NullValue(TypeInt64))},
+				StructValueField{Name: "b", V: NullValue(Optional(TypeInt64))},
+			),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a := allocator.New()
+			defer a.Free()
+			v, err := FromYDBWithError(tt.t.toYDB(a), tt.v)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected.Yql(), v.Yql())
+			require.True(t, Equal(tt.expected, v))
+			require.True(t, proto.Equal(tt.v, v.toYDB(a)))
+			require.True(t, proto.Equal(tt.v, tt.expected.toYDB(a)))
+		})
+	}
+}
+
+func TestListValueItemTypes(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		items []Value
+		yql   string
+		err   bool
+	}{
+		{
+			name:  "Empty",
+			items: nil,
+			yql:   "EmptyList",
+		},
+		{
+			name:  "SameTypes",
+			items: []Value{Int32Value(1), Int32Value(2)},
+			yql:   "List<Int32>",
+		},
+		{
+			name:  "DifferentTypes",
+			items: []Value{Int32Value(1), Int32Value(2), Int64Value(3)},
+			err:   true,
+		},
+		{
+			name:  "OptionalAfterItems",
+			items: []Value{Int32Value(1), NullValue(TypeInt32), OptionalValue(Int32Value(3))},
+			yql:   "List<Optional<Int32>>",
+		},
+		{
+			name:  "OptionalBeforeItems",
+			items: []Value{OptionalValue(Int32Value(1)), Int32Value(2)},
+			yql:   "List<Optional<Int32>>",
+		},
+		{
+			name:  "OptionalOfDifferentType",
+			items: []Value{Int32Value(1), NullValue(TypeInt64)},
+			err:   true,
+		},
+		{
+			name:  "DoubleOptional",
+			items: []Value{Int32Value(1), OptionalValue(OptionalValue(Int32Value(2)))},
+			err:   true,
+		},
+		{
+			name: "DoubleOptionalAfterOptional",
+			items: []Value{
+				OptionalValue(Int32Value(1)),
+				Int32Value(2),
+				OptionalValue(OptionalValue(Int32Value(3))),
+			},
+			err: true,
+		},
+		{
+			name: "NestedOptionals",
+			items: []Value{
+				OptionalValue(Int32Value(1)),
+				OptionalValue(OptionalValue(Int32Value(2))),
+				NullValue(Optional(TypeInt32)),
+			},
+			yql: "List<Optional<Optional<Int32>>>",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := ListValueE(tt.items...)
+			if tt.err {
+				require.ErrorIs(t, err, errListItemType)
+				require.Panics(t, func() {
+					_ = ListValue(tt.items...)
+				})
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.yql, v.Type().Yql())
+			for i, item := range v.items {
+				require.True(t, item.Type().equalsTo(v.t.(*listType).itemType), "item %d: %s", i, item.Type().Yql())
+			}
+		})
+	}
+	t.Run("ErrorMessage", func(t *testing.T) {
+		_, err := ListValueE(TextValue("a"), NullValue(TypeText), BytesValue([]byte("c")))
+		require.ErrorContains(t, err, "item 2 of type String differs from type Optional<Utf8> of previous items")
+		_, err = ListValueE(OptionalValue(Int32Value(1)), Int32Value(2), OptionalValue(OptionalValue(Int32Value(3))))
+		require.ErrorContains(t, err, "item 1 of type Int32 differs from type Optional<Optional<Int32>> of other items")
+	})
+	t.Run("ItemsOfCallerAreNotModified", func(t *testing.T) {
+		items := []Value{Int32Value(1), NullValue(TypeInt32)}
+		v := ListValue(items...)
+		require.Equal(t, "[Just(1),Nothing(Optional<Int32>)]", v.Yql())
+		require.Equal(t, Int32Value(1), items[0])
+	})
+}
+
+func TestDictValueOfTypes(t *testing.T) {
+	a := allocator.New()
+	defer a.Free()
+	t.Run("Empty", func(t *testing.T) {
+		v := DictValueOfTypes(TypeText, TypeInt32)
+		require.Equal(t, "Dict<Utf8,Int32>", v.Type().Yql())
+		require.Equal(t, "{}", v.Yql())
+		fromYDB, err := FromYDBWithError(v.Type().toYDB(a), v.toYDB(a))
+		require.NoError(t, err)
+		require.Equal(t, "Dict<Utf8,Int32>", fromYDB.Type().Yql())
+		require.Equal(t, "{}", fromYDB.Yql())
+	})
+	t.Run("Pairs", func(t *testing.T) {
+		v := DictValueOfTypes(TypeText, TypeInt32,
+			DictValueField{TextValue("b"), Int32Value(2)},
+			DictValueField{TextValue("a"), Int32Value(1)},
+		)
+		require.Equal(t, "Dict<Utf8,Int32>", v.Type().Yql())
+		require.Equal(t, `{"a"u:1,"b"u:2}`, v.Yql())
+	})
+	t.Run("DifferentTypes", func(t *testing.T) {
+		require.PanicsWithValue(t, "ydb: pair 1 of types (Utf8,Int64) differs from types of Dict<Utf8,Int32>", func() {
+			_ = DictValueOfTypes(TypeText, TypeInt32,
+				DictValueField{TextValue("a"), Int32Value(1)},
+				DictValueField{TextValue("b"), Int64Value(2)},
+			)
+		})
+	})
+	t.Run("DictValueOfTypesE", func(t *testing.T) {
+		v, err := DictValueOfTypesE(TypeText, TypeInt32, DictValueField{TextValue("a"), Int32Value(1)})
+		require.NoError(t, err)
+		require.Equal(t, `{"a"u:1}`, v.Yql())
+		v, err = DictValueOfTypesE(TypeText, Dict(TypeText, TypeInt32), DictValueField{TextValue("a"), DictValue()})
+		require.NoError(t, err)
+		require.Equal(t, "Dict<Utf8,Dict<Utf8,Int32>>", v.Type().Yql())
+		_, err = DictValueOfTypesE(TypeText, TypeInt32,
+			DictValueField{TextValue("a"), Int32Value(1)},
+			DictValueField{Int32Value(2), Int32Value(2)},
+		)
+		require.ErrorIs(t, err, errDictPairType)
+		require.ErrorContains(t, err, "pair 1 of types (Int32,Int32) differs from types of Dict<Utf8,Int32>")
+	})
+	t.Run("EmptyDictValue", func(t *testing.T) {
+		require.Equal(t, "EmptyDict", DictValue().Type().Yql())
+	})
+	t.Run("EmptyDictOfDictType", func(t *testing.T) {
+		v := DictValueOfTypes(TypeText, Dict(TypeText, TypeInt32),
+			DictValueField{TextValue("a"), DictValue()},
+		)
+		require.Equal(t, "Dict<Utf8,Dict<Utf8,Int32>>", v.Type().Yql())
+		fromYDB, err := FromYDBWithError(v.Type().toYDB(a), v.toYDB(a))
+		require.NoError(t, err)
+		require.True(t, Equal(v, fromYDB))
+		require.Panics(t, func() {
+			_ = DictValueOfTypes(TypeText, List(TypeInt32), DictValueField{TextValue("a"), DictValue()})
+		})
+	})
+}
+
+func TestEmptyDictFromYDB(t *testing.T) {
+	// result of `SELECT AsDict() AS d, AsList() AS l` in YDB response
+	fixture := &Ydb.TypedValue{
+		Type: &Ydb.Type{Type: &Ydb.Type_StructType{StructType: &Ydb.StructType{Members: []*Ydb.StructMember{
+			{Name: "d", Type: &Ydb.Type{Type: &Ydb.Type_EmptyDictType{}}},
+			{Name: "l", Type: &Ydb.Type{Type: &Ydb.Type_EmptyListType{}}},
+		}}}},
+		Value: &Ydb.Value{Items: []*Ydb.Value{{}, {}}},
+	}
+	var (
+		v   Value
+		err error
+	)
+	require.NotPanics(t, func() {
+		v, err = FromYDBWithError(fixture.GetType(), fixture.GetValue())
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Struct<'d':EmptyDict,'l':EmptyList>", v.Type().Yql())
+	require.Equal(t, "<|`d`:{},`l`:[]|>", v.Yql())
+	require.Equal(t, ZeroValue(v.Type()), v)
+
+	a := allocator.New()
+	defer a.Free()
+	require.True(t, proto.Equal(fixture, ToYDB(v, a)))
+}
+
+func TestTaggedValue(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "wire", "tagged_struct.bin"))
+	require.NoError(t, err)
+	var fixture Ydb.TypedValue
+	require.NoError(t, proto.Unmarshal(data, &fixture))
+
+	v, err := FromYDBWithError(fixture.GetType(), fixture.GetValue())
+	require.NoError(t, err)
+	require.Equal(t, "Struct<'id':Tagged<Uint64,\"user_id\">,'name':Tagged<Optional<Utf8>,\"name\">>", v.Type().Yql())
+	require.Equal(t, "<|`id`:AsTagged(42ul,\"user_id\"),`name`:AsTagged(Just(\"alice\"u),\"name\")|>", v.Yql())
+
+	fields := v.(*structValue).StructFields()
+	id, ok := fields["id"].(*taggedValue)
+	require.True(t, ok)
+	require.Equal(t, "user_id", id.Tag())
+	require.Equal(t, Uint64Value(42), id.InnerValue())
+	require.Equal(t, "user_id", id.Type().(*taggedType).Tag())
+	require.Equal(t, TypeUint64, id.Type().(*taggedType).InnerType())
+
+	// tagged values are cast as inner values
+	var (
+		idDst   uint64
+		nameDst *string
+		native  interface{}
+	)
+	require.NoError(t, id.castTo(&idDst))
+	require.Equal(t, uint64(42), idDst)
+	require.NoError(t, fields["name"].castTo(&nameDst))
+	require.Equal(t, "alice", *nameDst)
+	require.NoError(t, fields["name"].castTo(&native))
+	require.Equal(t, "alice", native)
+
+	// re-encoded value is unchanged
+	a := allocator.New()
+	defer a.Free()
+	require.True(t, proto.Equal(&fixture, ToYDB(v, a)))
+
+	require.True(t, Equal(TaggedValue("name", TextValue("a")), TaggedValue("name", TextValue("a"))))
+	require.False(t, Equal(TaggedValue("name", TextValue("a")), TaggedValue("title", TextValue("a"))))
+	require.False(t, Equal(TaggedValue("name", TextValue("a")), TextValue("a")))
+	require.Equal(t, TaggedValue("name", TextValue("")), ZeroValue(Tagged("name", TypeText)))
+}
+
+func TestPgValue(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "wire", "pg_struct.bin"))
+	require.NoError(t, err)
+	var fixture Ydb.TypedValue
+	require.NoError(t, proto.Unmarshal(data, &fixture))
+
+	v, err := FromYDBWithError(fixture.GetType(), fixture.GetValue())
+	require.NoError(t, err)
+	require.Equal(t, "Struct<'id':pgint4,'name':pgtext,'payload':pgjsonb>", v.Type().Yql())
+	require.Equal(t,
+		"<|`id`:PgConst(\"42\",pgint4),`name`:PgCast(NULL,pgtext),`payload`:PgConst(\"{\\\"a\\\": [1, 2]}\",pgjsonb)|>",
+		v.Yql(),
+	)
+
+	fields := v.(*structValue).StructFields()
+	id, ok := fields["id"].(*pgValue)
+	require.True(t, ok)
+	require.Equal(t, uint32(23), id.Type().(*pgType).OID())
+	require.Equal(t, "int4", id.Type().(*pgType).Name())
+	require.Equal(t, "42", id.Text())
+	require.False(t, id.IsNull())
+	require.True(t, fields["name"].(*pgValue).IsNull())
+
+	var (
+		textDst  string
+		bytesDst []byte
+		native   interface{}
+	)
+	require.NoError(t, id.castTo(&textDst))
+	require.Equal(t, "42", textDst)
+	require.NoError(t, fields["payload"].castTo(&bytesDst))
+	require.Equal(t, []byte(`{"a": [1, 2]}`), bytesDst)
+	require.NoError(t, fields["name"].castTo(&native))
+	require.Nil(t, native)
+	require.Error(t, fields["name"].castTo(&textDst))
+	require.Error(t, id.castTo(new(int32)))
+
+	// re-encoded value is unchanged
+	a := allocator.New()
+	defer a.Free()
+	require.True(t, proto.Equal(&fixture, ToYDB(v, a)))
+
+	require.True(t, Equal(PgValue(23, "1"), PgValue(23, "1")))
+	require.False(t, Equal(PgValue(23, "1"), PgValue(20, "1")))
+	require.False(t, Equal(PgValue(23, "1"), PgNullValue(23)))
+	require.Equal(t, PgNullValue(23), ZeroValue(Pg(23)))
+	require.Equal(t, "PgType(16384)", Pg(16384).Yql())
+}
+
+func TestWideTimeValues(t *testing.T) {
+	before := time.Date(1812, 9, 7, 5, 19, 20, 123456789, time.UTC)
+	after := time.Date(2222, 6, 17, 5, 19, 20, 123456789, time.UTC)
+	for _, tt := range []struct {
+		value    Value
+		wire     *Ydb.Value
+		literal  string
+		expected interface{}
+	}{
+		{
+			value:    Date32ValueFromTime(before),
+			wire:     &Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: -57459}},
+			literal:  `Date32("1812-09-07")`,
+			expected: time.Date(1812, 9, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			value:    Date32ValueFromTime(after),
+			wire:     &Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: 92208}},
+			literal:  `Date32("2222-06-17")`,
+			expected: time.Date(2222, 6, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			value:    Datetime64ValueFromTime(before),
+			wire:     &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: -4964438440}},
+			literal:  `Datetime64("1812-09-07T05:19:20Z")`,
+			expected: time.Date(1812, 9, 7, 5, 19, 20, 0, time.UTC),
+		},
+		{
+			value:    Timestamp64ValueFromTime(before),
+			wire:     &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: -4964438439876544}},
+			literal:  `Timestamp64("1812-09-07T05:19:20.123456Z")`,
+			expected: time.Date(1812, 9, 7, 5, 19, 20, 123456000, time.UTC),
+		},
+		{
+			value:    Timestamp64ValueFromTime(after),
+			wire:     &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: 7966790360123456}},
+			literal:  `Timestamp64("2222-06-17T05:19:20.123456Z")`,
+			expected: time.Date(2222, 6, 17, 5, 19, 20, 123456000, time.UTC),
+		},
+		{
+			value:    Interval64ValueFromDuration(-90 * time.Minute),
+			wire:     &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: -5400000000}},
+			literal:  `Interval64("-PT1H30M")`,
+			expected: -90 * time.Minute,
+		},
+	} {
+		t.Run(tt.literal, func(t *testing.T) {
+			a := allocator.New()
+			defer a.Free()
+			require.True(t, proto.Equal(tt.wire, tt.value.toYDB(a)), tt.value.toYDB(a).String())
+			require.Equal(t, tt.literal, tt.value.Yql())
+
+			decoded, err := FromYDBWithError(tt.value.Type().toYDB(a), tt.wire)
+			require.NoError(t, err)
+			require.Equal(t, tt.value, decoded)
+
+			native, err := NativeValue(decoded)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, native)
+
+			switch expected := tt.expected.(type) {
+			case time.Time:
+				var dst time.Time
+				require.NoError(t, decoded.castTo(&dst))
+				require.True(t, expected.Equal(dst), dst)
+			case time.Duration:
+				var dst time.Duration
+				require.NoError(t, decoded.castTo(&dst))
+				require.Equal(t, expected, dst)
+			}
+
+			data, err := MarshalJSON(decoded)
+			require.NoError(t, err)
+			fromJSON, err := UnmarshalJSON(decoded.Type(), data)
+			require.NoError(t, err)
+			require.Equal(t, decoded, fromJSON)
+		})
+	}
+	t.Run("IntervalOverflow", func(t *testing.T) {
+		var d time.Duration
+		require.ErrorIs(t, Interval64Value(math.MaxInt64).castTo(&d), errValueOutOfRange)
+	})
+	t.Run("Compare", func(t *testing.T) {
+		c, err := CompareKeys(Date32Value(-1), Date32Value(1))
+		require.NoError(t, err)
+		require.Equal(t, -1, c)
+	})
+}
+
+func TestUint128FromBytes(t *testing.T) {
+	for _, tt := range []struct {
+		hi uint64
+		lo uint64
+	}{
+		{0, 0},
+		{0, 1},
+		{1, 0},
+		{0x0102030405060708, 0x090a0b0c0d0e0f10},
+		{math.MaxUint64, math.MaxUint64},
+		{math.MaxUint64, math.MaxUint64 - 9},
+	} {
+		t.Run(strconv.FormatUint(tt.hi, 16)+"_"+strconv.FormatUint(tt.lo, 16), func(t *testing.T) {
+			hi, lo := Uint128FromBytes(BigEndianUint128(tt.hi, tt.lo))
+			require.Equal(t, tt.hi, hi)
+			require.Equal(t, tt.lo, lo)
+		})
+	}
+}
+
+func TestDecimalValueInt128(t *testing.T) {
+	maxInt128 := big.NewInt(0).Sub(big.NewInt(0).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	minInt128 := big.NewInt(0).Neg(big.NewInt(0).Lsh(big.NewInt(1), 127))
+	for _, tt := range []struct {
+		name      string
+		bytes     [16]byte
+		precision uint32
+		scale     uint32
+		int128    *big.Int
+	}{
+		{
+			name:      "Zero",
+			bytes:     BigEndianUint128(0, 0),
+			precision: 22,
+			scale:     9,
+			int128:    big.NewInt(0),
+		},
+		{
+			name:      "Positive",
+			bytes:     BigEndianUint128(0, 1234567890123456),
+			precision: 22,
+			scale:     9,
+			int128:    big.NewInt(1234567890123456),
+		},
+		{
+			name:      "MinusOne",
+			bytes:     BigEndianUint128(math.MaxUint64, math.MaxUint64),
+			precision: 22,
+			scale:     9,
+			int128:    big.NewInt(-1),
+		},
+		{
+			name:      "Negative",
+			bytes:     BigEndianUint128(math.MaxUint64, math.MaxUint64-1234567890123456+1),
+			precision: 22,
+			scale:     9,
+			int128:    big.NewInt(-1234567890123456),
+		},
+		{
+			name:      "NegativeHigh",
+			bytes:     BigEndianUint128(0xfffffffffffffffe, 0),
+			precision: 35,
+			scale:     0,
+			int128:    big.NewInt(0).Neg(big.NewInt(0).Lsh(big.NewInt(1), 65)),
+		},
+		{
+			// out of precision values are not turned into infinity
+			name:      "MaxInt128",
+			bytes:     BigEndianUint128(math.MaxInt64, math.MaxUint64),
+			precision: 35,
+			scale:     0,
+			int128:    maxInt128,
+		},
+		{
+			name:      "MinInt128",
+			bytes:     BigEndianUint128(1<<63, 0),
+			precision: 35,
+			scale:     0,
+			int128:    minInt128,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			v := DecimalValue(tt.bytes, tt.precision, tt.scale)
+			require.Equal(t, 0, tt.int128.Cmp(v.Int128()), v.Int128().String())
+			require.Equal(t, tt.precision, v.Precision())
+			require.Equal(t, tt.scale, v.Scale())
+
+			// Int128 is an inverse of DecimalValueFromBigInt for values in precision
+			if tt.int128.CmpAbs(big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(tt.precision)), nil)) < 0 {
+				require.Equal(t, v, DecimalValueFromBigInt(v.Int128(), tt.precision, tt.scale))
+			}
+
+			hi, lo := Uint128FromBytes(v.Value())
+			require.Equal(t, tt.bytes, BigEndianUint128(hi, lo))
+		})
+	}
+}
+
+func TestUUIDValueFromString(t *testing.T) {
+	const canonical = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	for _, s := range []string{
+		canonical,
+		"{" + canonical + "}",
+		"urn:uuid:" + canonical,
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"6ba7b8109dad11d180b400c04fd430c8",
+	} {
+		t.Run(s, func(t *testing.T) {
+			v, err := UUIDValueFromString(s)
+			require.NoError(t, err)
+			require.Equal(t, `Uuid("`+canonical+`")`, v.Yql())
+
+			u := uuid.MustParse(canonical)
+			require.Equal(t, UUIDValueFromUUID(u), v)
+			// bytes of uuid.UUID are in order of BigEndianUint128
+			require.Equal(t, [16]byte(u), BigEndianUint128(
+				binary.BigEndian.Uint64(u[0:8]), binary.BigEndian.Uint64(u[8:16]),
+			))
+
+			a := allocator.New()
+			defer a.Free()
+			tv := ToYDB(v, a)
+			require.Equal(t, binary.BigEndian.Uint64(u[0:8]), tv.GetValue().GetHigh_128())
+			require.Equal(t, binary.BigEndian.Uint64(u[8:16]), tv.GetValue().GetLow_128())
+
+			fromYDB, err := FromYDBWithError(tv.GetType(), tv.GetValue())
+			require.NoError(t, err)
+			require.Equal(t, v, fromYDB)
+
+			var dst uuid.UUID
+			require.NoError(t, fromYDB.castTo(&dst))
+			require.Equal(t, canonical, dst.String())
+
+			// string destination takes raw bytes of UUID
+			var raw string
+			require.NoError(t, fromYDB.castTo(&raw))
+			parsed, err := uuid.FromBytes([]byte(raw))
+			require.NoError(t, err)
+			require.Equal(t, canonical, parsed.String())
+		})
+	}
+	for _, s := range []string{
+		"",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430cz",
+		"(6ba7b810-9dad-11d1-80b4-00c04fd430c8)",
+	} {
+		t.Run("Malformed"+s, func(t *testing.T) {
+			_, err := UUIDValueFromString(s)
+			require.ErrorIs(t, err, errMalformedUUID)
+		})
+	}
+}
+
+func TestCheckDictKeyType(t *testing.T) {
+	for _, tt := range []Type{
+		TypeBool,
+		TypeInt32,
+		TypeUint64,
+		TypeDouble,
+		TypeText,
+		TypeBytes,
+		TypeUUID,
+		TypeDate,
+		TypeTzTimestamp,
+		TypeInterval,
+		Decimal(22, 9),
+		Optional(TypeText),
+		Optional(Optional(TypeInt32)),
+		Tuple(TypeInt32, TypeText),
+		Tuple(),
+		Optional(Tuple(TypeInt32, Optional(TypeText))),
+	} {
+		t.Run(tt.Yql(), func(t *testing.T) {
+			require.NoError(t, CheckDictKeyType(tt))
+		})
+	}
+	for _, tt := range []Type{
+		TypeJSON,
+		TypeJSONDocument,
+		TypeYSON,
+		Optional(TypeJSON),
+		List(TypeInt32),
+		EmptyList(),
+		Set(TypeInt32),
+		Tuple(TypeInt32, TypeJSON),
+		Tuple(List(TypeInt32)),
+		Struct(StructField{Name: "a", T: TypeInt32}),
+		Dict(TypeText, TypeInt32),
+		EmptyDict(),
+		VariantTuple(TypeInt32, TypeText),
+		Void(),
+		Optional(List(TypeInt32)),
+	} {
+		t.Run(tt.Yql(), func(t *testing.T) {
+			err := CheckDictKeyType(tt)
+			require.ErrorIs(t, err, errDictKeyType)
+			require.ErrorContains(t, err, tt.Yql())
+		})
+	}
+	t.Run("DictValueE", func(t *testing.T) {
+		_, err := DictValueE(DictValueField{K: ListValue(Int32Value(1)), V: Int32Value(1)})
+		require.ErrorIs(t, err, errDictKeyType)
+		require.ErrorContains(t, err, "List<Int32>")
+		v, err := DictValueE(DictValueField{K: OptionalValue(Int32Value(1)), V: Int32Value(1)})
+		require.NoError(t, err)
+		require.Equal(t, "Dict<Optional<Int32>,Int32>", v.Type().Yql())
+		v, err = DictValueE()
+		require.NoError(t, err)
+		require.Zero(t, v.Len())
+	})
+	t.Run("FromYDB", func(t *testing.T) {
+		a := allocator.New()
+		defer a.Free()
+		// dicts sent by server are decoded without check of type of keys
+		v := DictValue(DictValueField{K: ListValue(Int32Value(1)), V: Int32Value(1)})
+		decoded, err := FromYDBWithError(v.Type().toYDB(a), v.toYDB(a))
+		require.NoError(t, err)
+		require.Equal(t, v.Yql(), decoded.Yql())
+	})
+}
+
+func TestZeroValue(t *testing.T) {
+	types := []Type{
+		TypeBool, TypeInt8, TypeUint8, TypeInt16, TypeUint16, TypeInt32, TypeUint32, TypeInt64, TypeUint64,
+		TypeFloat, TypeDouble, TypeDate, TypeDatetime, TypeTimestamp, TypeInterval,
+		TypeTzDate, TypeTzDatetime, TypeTzTimestamp, TypeBytes, TypeText, TypeYSON, TypeJSON, TypeUUID,
+		TypeJSONDocument, TypeDyNumber, TypeDate32, TypeDatetime64, TypeTimestamp64, TypeInterval64,
+		Decimal(35, 10),
+		Optional(TypeInt32),
+		Optional(Optional(TypeText)),
+		Void(),
+		Null(),
+		List(TypeInt32),
+		EmptyList(),
+		Set(TypeText),
+		Dict(TypeText, Optional(TypeInt32)),
+		EmptyDict(),
+		EmptySet(),
+		Tuple(),
+		Tuple(TypeInt32, Optional(TypeText), Void()),
+		Struct(),
+		Struct(StructField{Name: "a", T: Decimal(22, 9)}, StructField{Name: "b", T: TypeText}),
+		VariantTuple(TypeText, TypeInt32),
+		VariantStruct(StructField{Name: "a", T: TypeInt32}, StructField{Name: "b", T: TypeText}),
+		List(Struct(StructField{Name: "v", T: VariantTuple(Optional(TypeBool))})),
+	}
+	for _, tt := range types {
+		t.Run(tt.Yql(), func(t *testing.T) {
+			t.Run("Constructor", func(t *testing.T) {
+				checkZeroValue(t, tt)
+			})
+			t.Run("TypeFromYDB", func(t *testing.T) {
+				a := allocator.New()
+				defer a.Free()
+				checkZeroValue(t, TypeFromYDB(tt.toYDB(a)))
+			})
+		})
+	}
+	t.Run("Pointers", func(t *testing.T) {
+		for _, tt := range []Type{&voidType{}, &nullType{}, &emptyListType{}, &emptyDictType{}} {
+			require.NotPanics(t, func() {
+				_ = ZeroValue(tt)
+			})
+		}
+		require.Equal(t, "Nothing(Optional<Int32>)", ZeroValue(&optionalType{innerType: TypeInt32}).Yql())
+	})
+	t.Run("NoZeroValue", func(t *testing.T) {
+		require.Panics(t, func() {
+			_ = ZeroValue(VariantTuple())
+		})
+	})
+}
+
+// checkZeroValue checks that zero value of type t has type t and survives round trip through YDB
+func checkZeroValue(t *testing.T, tt Type) {
+	var v Value
+	require.NotPanics(t, func() {
+		v = ZeroValue(tt)
+	})
+	if _, isNull := tt.(nullType); !isNull {
+		require.True(t, v.Type().equalsTo(tt), v.Type().Yql())
+	}
+	a := allocator.New()
+	defer a.Free()
+	decoded, err := FromYDBWithError(v.Type().toYDB(a), v.toYDB(a))
+	require.NoError(t, err)
+	require.Equal(t, v.Yql(), decoded.Yql())
+}
+
+func TestBytesValueString(t *testing.T) {
+	large := make([]byte, 37)
+	for i := range large {
+		large[i] = byte(i%16) * 0x11
+	}
+	for _, tt := range []struct {
+		name string
+		v    Value
+		exp  string
+	}{
+		{name: "Empty", v: BytesValue(nil), exp: "Bytes(0 bytes)"},
+		{name: "Small", v: BytesValue([]byte{0x00, 0x11, 0x22}), exp: "Bytes(0x001122, 3 bytes)"},
+		{name: "Text", v: BytesValue([]byte("test")), exp: "Bytes(0x74657374, 4 bytes)"},
+		{
+			name: "Large",
+			v:    BytesValue(large),
+			exp:  "Bytes(0x00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff…, 37 bytes)",
+		},
+		{name: "TextYSON", v: YSONValue([]byte("{a=1;}")), exp: `Yson("{a=1;}")`},
+		{name: "BinaryYSON", v: YSONValue([]byte{0x01, 0x02, 'a', 'b'}), exp: "Yson(0x01026162, 4 bytes)"},
+		{name: "EmptyYSON", v: YSONValue(nil), exp: `Yson("")`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.exp, fmt.Sprint(tt.v))
+		})
+	}
+	t.Run("InContainer", func(t *testing.T) {
+		v := ListValue(BytesValue([]byte{0xff}))
+		require.Equal(t, "Bytes(0xff, 1 bytes)", fmt.Sprint(v.Items()[0]))
+		require.Equal(t, `["\xff"]`, v.Yql())
+	})
+	t.Run("Limit", func(t *testing.T) {
+		defer SetBytesStringLimit(defaultBytesStringLimit)
+		SetBytesStringLimit(2)
+		require.Equal(t, "Bytes(0x0011…, 3 bytes)", BytesValue([]byte{0x00, 0x11, 0x22}).String())
+		SetBytesStringLimit(0)
+		require.Equal(t, "Bytes(0x00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff0011223344, 37 bytes)",
+			BytesValue(large).String(),
+		)
+	})
+	t.Run("CastTo", func(t *testing.T) {
+		var dst []byte
+		require.NoError(t, BytesValue(large).castTo(&dst))
+		require.Equal(t, large, dst)
+	})
+}
+
+func TestDecimalValueE(t *testing.T) {
+	t.Run("DecimalValueE", func(t *testing.T) {
+		v, err := DecimalValueE([16]byte{15: 1}, 35, 9)
+		require.NoError(t, err)
+		require.Equal(t, `Decimal("0.000000001",35,9)`, v.Yql())
+		for _, tt := range [][2]uint32{{0, 0}, {36, 0}, {9, 10}} {
+			_, err = DecimalValueE([16]byte{}, tt[0], tt[1])
+			require.ErrorIs(t, err, errDecimalType)
+		}
+	})
+	t.Run("DecimalValueFromBigIntE", func(t *testing.T) {
+		maxUnscaled := new(big.Int).Sub(new(big.Int).Exp(big.NewInt(10), big.NewInt(35), nil), big.NewInt(1))
+		v, err := DecimalValueFromBigIntE(maxUnscaled, 35, 0)
+		require.NoError(t, err)
+		require.Equal(t, `Decimal("`+maxUnscaled.String()+`",35,0)`, v.Yql())
+		v, err = DecimalValueFromBigIntE(nil, DefaultDecimalPrecision, DefaultDecimalScale)
+		require.NoError(t, err)
+		require.Equal(t, `Decimal("0.000000000",22,9)`, v.Yql())
+
+		_, err = DecimalValueFromBigIntE(new(big.Int).Add(maxUnscaled, big.NewInt(1)), 35, 0)
+		require.ErrorIs(t, err, errValueOutOfRange)
+		_, err = DecimalValueFromBigIntE(big.NewInt(-100), 2, 0)
+		require.ErrorIs(t, err, errValueOutOfRange)
+		require.ErrorIs(t, err, decimal.ErrOverflow)
+		require.ErrorContains(t, err, "3 digits of -100 exceed precision 2")
+		v, err = DecimalValueFromBigIntE(big.NewInt(-99), 2, 1)
+		require.NoError(t, err)
+		require.Equal(t, `Decimal("-9.9",2,1)`, v.Yql())
+		_, err = DecimalValueFromBigIntE(big.NewInt(1), 36, 0)
+		require.ErrorIs(t, err, errDecimalType)
+		_, err = DecimalValueFromBigIntE(big.NewInt(1), 0, 0)
+		require.ErrorIs(t, err, errDecimalType)
+		_, err = DecimalValueFromUnscaled(big.NewInt(1), 0, 36)
+		require.ErrorIs(t, err, errDecimalType)
+	})
+}
+
+func TestDecimalValueFromStringWithRounding(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		mode decimal.RoundingMode
+		exp  string
+	}{
+		{s: "12.5", mode: decimal.RoundUnnecessary, exp: `Decimal("12.500000000",22,9)`},
+		{s: "0.0000000125", mode: decimal.RoundHalfEven, exp: `Decimal("0.000000012",22,9)`},
+		{s: "0.0000000135", mode: decimal.RoundHalfEven, exp: `Decimal("0.000000014",22,9)`},
+		{s: "0.0000000125", mode: decimal.RoundHalfUp, exp: `Decimal("0.000000013",22,9)`},
+		{s: "-0.0000000125", mode: decimal.RoundHalfUp, exp: `Decimal("-0.000000013",22,9)`},
+		{s: "0.0000000129", mode: decimal.RoundDown, exp: `Decimal("0.000000012",22,9)`},
+		{s: "-0.0000000129", mode: decimal.RoundDown, exp: `Decimal("-0.000000012",22,9)`},
+		{s: "1.2500000000", mode: decimal.RoundUnnecessary, exp: `Decimal("1.250000000",22,9)`},
+		{s: "-inf", mode: decimal.RoundUnnecessary, exp: `Decimal("-inf",22,9)`},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			v, err := DecimalValueFromStringWithRounding(tt.s, DefaultDecimalPrecision, DefaultDecimalScale, tt.mode)
+			require.NoError(t, err)
+			require.Equal(t, tt.exp, v.Yql())
+		})
+	}
+	_, err := DecimalValueFromStringWithRounding("0.0000000125", 22, 9, decimal.RoundUnnecessary)
+	require.ErrorIs(t, err, decimal.ErrInexact)
+	_, err = DecimalValueFromStringWithRounding("9999999999999.9999999995", 22, 9, decimal.RoundHalfEven)
+	require.ErrorIs(t, err, errValueOutOfRange)
+	_, err = DecimalValueFromStringWithRounding("9999999999999.9999999995", 22, 9, decimal.RoundDown)
+	require.NoError(t, err)
+	_, err = DecimalValueFromStringWithRounding("1", 22, 23, decimal.RoundHalfEven)
+	require.ErrorIs(t, err, errDecimalType)
+	_, err = DecimalValueFromStringWithRounding("1,5", 22, 9, decimal.RoundHalfEven)
+	require.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package value
+
+import (
+	"flag"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/allocator"
+)
+
+// Wire compatibility tests checks that serialized Ydb.TypedValue of values corpus
+// is equal to checked-in fixtures from testdata/wire and fixtures decodes to equal values.
+//
+// Any failure of this tests means change of wire format of values, which may break
+// compatibility with YDB server or with data written by previous versions of SDK.
+//
+// Procedure of intentional change of wire format:
+//  1. Make sure that new wire format is accepted by YDB server and describe change in CHANGELOG.md
+//  2. Regenerate fixtures with `go test ./internal/value -run TestWireCompat -update`
+//  3. Commit changed fixtures with change of encoding in the same commit
+//
+// New values of corpus must be added with new fixtures (also with -update flag).
+// Names of existing corpus values must not be changed.
+
+var updateWireFixtures = flag.Bool("update", false, "update wire compatibility fixtures in testdata/wire")
+
+func wireCompatCorpus() []struct {
+	name  string
+	value Value
+} {
+	return []struct {
+		name  string
+		value Value
+	}{
+		{"bool", BoolValue(true)},
+		{"int8", Int8Value(math.MinInt8)},
+		{"int16", Int16Value(math.MinInt16)},
+		{"int32", Int32Value(math.MinInt32)},
+		{"int64", Int64Value(math.MinInt64)},
+		{"uint8", Uint8Value(math.MaxUint8)},
+		{"uint16", Uint16Value(math.MaxUint16)},
+		{"uint32", Uint32Value(math.MaxUint32)},
+		{"uint64", Uint64Value(math.MaxUint64)},
+		{"float", FloatValue(-1.5)},
+		{"double", DoubleValue(math.Pi)},
+		{"date", DateValueFromTime(time.Date(2022, 6, 17, 0, 0, 0, 0, time.UTC))},
+		{"datetime", DatetimeValueFromTime(time.Date(2022, 6, 17, 5, 19, 20, 0, time.UTC))},
+		{"timestamp", TimestampValueFromTime(time.Date(2022, 6, 17, 5, 19, 20, 123456000, time.UTC))},
+		{"interval", IntervalValueFromDuration(-90 * time.Minute)},
+		{"date32", Date32ValueFromTime(time.Date(1812, 9, 7, 0, 0, 0, 0, time.UTC))},
+		{"datetime64", Datetime64ValueFromTime(time.Date(1812, 9, 7, 5, 19, 20, 0, time.UTC))},
+		{"timestamp64", Timestamp64ValueFromTime(time.Date(2222, 6, 17, 5, 19, 20, 123456000, time.UTC))},
+		{"interval64", Interval64Value(math.MinInt64 + 1)},
+		{"tz_date", TzDateValue("2022-06-17,Europe/Berlin")},
+		{"tz_datetime", TzDatetimeValue("2022-06-17T05:19:20,Europe/Berlin")},
+		{"tz_timestamp", TzTimestampValue("2022-06-17T05:19:20.123456,Europe/Berlin")},
+		{"bytes", BytesValue([]byte{0, 1, 2, 0xff})},
+		{"text", TextValue("текст")},
+		{"yson", YSONValue([]byte("{a=1}"))},
+		{"json", JSONValue(`{"a":1}`)},
+		{"json_document", JSONDocumentValue(`{"a":1}`)},
+		{"dy_number", DyNumberValue(".1234e4")},
+		{"uuid", UUIDValue([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})},
+		{"decimal", DecimalValueFromBigInt(big.NewInt(123456789), 22, 9)},
+		{"decimal_negative", DecimalValueFromBigInt(big.NewInt(-123456789), 22, 9)},
+		{"decimal_max_precision", DecimalValueFromBigInt(
+			new(big.Int).Sub(new(big.Int).Exp(big.NewInt(10), big.NewInt(35), nil), big.NewInt(1)), 35, 0,
+		)},
+		{"void", VoidValue()},
+		{"optional", OptionalValue(Int32Value(42))},
+		{"optional_optional", OptionalValue(OptionalValue(TextValue("nested")))},
+		{"null", NullValue(TypeInt32)},
+		{"null_optional", NullValue(Optional(TypeInt32))},
+		{"optional_null", OptionalValue(NullValue(TypeInt32))},
+		{"list", ListValue(Int64Value(1), Int64Value(2), Int64Value(3))},
+		{"list_empty", ZeroValue(List(TypeText))},
+		{"list_optional", ListValue(OptionalValue(Int32Value(1)), NullValue(TypeInt32))},
+		{"set", SetValue(TextValue("a"), TextValue("b"))},
+		{"tuple", TupleValue(Int32Value(1), TextValue("a"), NullValue(TypeBool))},
+		{"tuple_empty", ZeroValue(Tuple())},
+		{"struct", StructValue(
+			StructValueField{"series_id", Uint64Value(1)},
+			StructValueField{"title", TextValue("test")},
+			StructValueField{"remove_date", OptionalValue(TzDatetimeValue("2022-06-17T05:19:20,Europe/Berlin"))},
+		)},
+		{"struct_empty", ZeroValue(Struct())},
+		{"dict", DictValue(
+			DictValueField{TextValue("b"), Uint64Value(2)},
+			DictValueField{TextValue("a"), Uint64Value(1)},
+			DictValueField{TextValue("c"), Uint64Value(3)},
+		)},
+		{"dict_nested", DictValue(
+			DictValueField{Int32Value(1), ListValue(OptionalValue(TextValue("a")))},
+			DictValueField{Int32Value(2), ListValue(NullValue(TypeText), OptionalValue(TextValue("b")))},
+		)},
+		{"variant_tuple", VariantValueTuple(Int32Value(42), 1, Tuple(TypeBytes, TypeInt32))},
+		{"variant_struct", VariantValueStruct(Int32Value(42), "bar", Struct(
+			StructField{Name: "foo", T: TypeBytes},
+			StructField{Name: "bar", T: TypeInt32},
+		))},
+		{"dict_optional", DictValue(
+			DictValueField{NullValue(TypeText), OptionalValue(Int64Value(1))},
+			DictValueField{OptionalValue(TextValue("a")), NullValue(TypeInt64)},
+		)},
+		{"dict_optional_optional", DictValue(
+			DictValueField{NullValue(Optional(TypeText)), OptionalValue(NullValue(TypeInt64))},
+			DictValueField{OptionalValue(NullValue(TypeText)), OptionalValue(OptionalValue(Int64Value(2)))},
+			DictValueField{OptionalValue(OptionalValue(TextValue("a"))), NullValue(Optional(TypeInt64))},
+		)},
+		{"tuple_optional_optional", TupleValue(
+			NullValue(Optional(TypeInt32)),
+			OptionalValue(NullValue(TypeInt32)),
+			OptionalValue(OptionalValue(Int32Value(1))),
+		)},
+		{"optional_decimal", OptionalValue(DecimalValueFromBigInt(big.NewInt(-15), 22, 1))},
+		{"optional_list", OptionalValue(ListValue(Int32Value(1), Int32Value(2)))},
+		{"optional_variant", OptionalValue(VariantValueTuple(Int32Value(42), 1, Tuple(TypeBytes, TypeInt32)))},
+		{"set_empty", ZeroValue(Set(TypeText))},
+		{"dict_empty", ZeroValue(Dict(TypeText, TypeInt32))},
+		{"nested_containers", StructValue(
+			StructValueField{"list_of_tuples", ListValue(
+				TupleValue(Int32Value(1), OptionalValue(DecimalValueFromBigInt(big.NewInt(-15), 22, 1))),
+				TupleValue(Int32Value(2), NullValue(Decimal(22, 1))),
+			)},
+			StructValueField{"optional_struct", OptionalValue(StructValue(
+				StructValueField{"uuid", UUIDValue([16]byte{0xff, 0xee})},
+				StructValueField{"set", SetValue(Uint8Value(1))},
+			))},
+		)},
+		// tagged_struct.bin is built by hand from Ydb.TaggedType and Ydb.Value protobuf messages,
+		// it is not captured from YDB server response
+		{"tagged_struct", StructValue(
+			StructValueField{"id", TaggedValue("user_id", Uint64Value(42))},
+			StructValueField{"name", TaggedValue("name", OptionalValue(TextValue("alice")))},
+		)},
+	}
+}
+
+func TestWireCompat(t *testing.T) {
+	marshal := proto.MarshalOptions{Deterministic: true}
+	for _, tt := range wireCompatCorpus() {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := filepath.Join("testdata", "wire", tt.name+".bin")
+
+			a := allocator.New()
+			defer a.Free()
+
+			encoded, err := marshal.Marshal(ToYDB(tt.value, a))
+			require.NoError(t, err)
+
+			if *updateWireFixtures {
+				require.NoError(t, os.MkdirAll(filepath.Dir(fixture), 0o755))
+				require.NoError(t, os.WriteFile(fixture, encoded, 0o600))
+			}
+
+			expected, err := os.ReadFile(fixture)
+			require.NoError(t, err, "fixture not found, run test with -update flag for create it")
+
+			t.Run("Encode", func(t *testing.T) {
+				require.Equal(t, expected, encoded, "wire format of '%s' changed", tt.value.Yql())
+			})
+
+			t.Run("Decode", func(t *testing.T) {
+				var typedValue Ydb.TypedValue
+				require.NoError(t, proto.Unmarshal(expected, &typedValue))
+				decoded, err := FromYDBWithError(typedValue.Type, typedValue.Value)
+				require.NoError(t, err)
+				require.True(t, TypesEqual(tt.value.Type(), decoded.Type()),
+					"type %s, want %s", decoded.Type().Yql(), tt.value.Type().Yql(),
+				)
+				require.Equal(t, tt.value.Yql(), decoded.Yql())
+				require.True(t, proto.Equal(ToYDB(tt.value, a), ToYDB(decoded, a)))
+			})
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package value
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xatomic"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xstring"
+)
+
+const (
+	hexDigits = "0123456789abcdef"
+
+	// defaultBytesStringLimit is a default limit of count of bytes in hex dumps of binary values
+	defaultBytesStringLimit = 32
+)
+
+var bytesStringLimit xatomic.Int64
+
+func init() {
+	bytesStringLimit.Store(defaultBytesStringLimit)
+}
+
+// SetBytesStringLimit sets limit of count of bytes in string representations (String method)
+// of Bytes and binary Yson values, the rest of bytes are truncated.
+// If limit is less than or equal to zero then bytes are not truncated
+func SetBytesStringLimit(limit int) {
+	bytesStringLimit.Store(int64(limit))
+}
+
+// hexDump returns bounded debug representation of binary value b with type name typeName
+// (such as `Bytes(0x0011…, 37 bytes)`)
+func hexDump(typeName string, b []byte) string {
+	if len(b) == 0 {
+		return typeName + "(0 bytes)"
+	}
+	n := len(b)
+	if limit := bytesStringLimit.Load(); limit > 0 && int64(n) > limit {
+		n = int(limit)
+	}
+	buffer := xstring.Buffer()
+	defer buffer.Free()
+	buffer.WriteString(typeName)
+	buffer.WriteString("(0x")
+	for _, c := range b[:n] {
+		buffer.WriteByte(hexDigits[c>>4])
+		buffer.WriteByte(hexDigits[c&0x0f])
+	}
+	if n < len(b) {
+		buffer.WriteString("…")
+	}
+	buffer.WriteString(", ")
+	buffer.WriteString(strconv.Itoa(len(b)))
+	buffer.WriteString(" bytes)")
+	return buffer.String()
+}
+
+// isBinary reports whether b is not a printable UTF-8 text
+// (such as binary YSON with markers of strings and numbers)
+func isBinary(b []byte) bool {
+	for i := 0; i < len(b); {
+		r, size := utf8.DecodeRune(b[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			return true
+		case r == '\n' || r == '\r' || r == '\t':
+		case r < 0x20 || r == 0x7f:
+			return true
+		}
+		i += size
+	}
+	return false
+}
+
+// quoteYql returns double-quoted YQL string literal of s.
+// Backslashes, double quotes, control characters and bytes of invalid
+// UTF-8 sequences are escaped, other (including non-ASCII) characters are kept as is
+func quoteYql(s string) string {
+	return quoteYqlWith(s, '"')
+}
+
+// quoteYqlMember returns single-quoted name of struct member for YQL type syntax
+// (such as `'name'`) with the same escaping as quoteYql
+func quoteYqlMember(name string) string {
+	return quoteYqlWith(name, '\'')
+}
+
+func quoteYqlWith(s string, quote byte) string {
+	buffer := xstring.Buffer()
+	defer buffer.Free()
+	buffer.WriteByte(quote)
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			buffer.WriteString(`\x`)
+			buffer.WriteByte(hexDigits[s[i]>>4])
+			buffer.WriteByte(hexDigits[s[i]&0x0f])
+		case r == rune(quote) || r == '\\':
+			buffer.WriteByte('\\')
+			buffer.WriteByte(byte(r))
+		case r == '\n':
+			buffer.WriteString(`\n`)
+		case r == '\r':
+			buffer.WriteString(`\r`)
+		case r == '\t':
+			buffer.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			buffer.WriteString(`\x`)
+			buffer.WriteByte(hexDigits[r>>4])
+			buffer.WriteByte(hexDigits[r&0x0f])
+		default:
+			buffer.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	buffer.WriteByte(quote)
+	return buffer.String()
+}
+
+// quoteYqlIdentifier returns backtick-quoted YQL identifier of name
+func quoteYqlIdentifier(name string) string {
+	return "`" + strings.NewReplacer("\\", "\\\\", "`", "\\`").Replace(name) + "`"
+}
+
+// decimalYql returns YQL representation of unscaled decimal value x with given scale
+// (such as `-0.000000001` for x = -1 and scale = 9)
+func decimalYql(x *big.Int, scale uint32) string {
+	switch {
+	case decimal.IsInf(x):
+		if x.Sign() < 0 {
+			return "-inf"
+		}
+		return "inf"
+	case decimal.IsNaN(x):
+		return "nan"
+	}
+	s := new(big.Int).Abs(x).String()
+	if scale > 0 {
+		if len(s) <= int(scale) {
+			s = strings.Repeat("0", int(scale)-len(s)+1) + s
+		}
+		s = s[:len(s)-int(scale)] + "." + s[len(s)-int(scale):]
+	}
+	if x.Sign() < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+// typedYql returns YQL literal of value given by string s with type constructor name
+// (such as `Date("2020-01-01")`)
+func typedYql(typeName, s string) string {
+	return typeName + "(" + quoteYql(s) + ")"
+}
@@ -0,0 +1,54 @@
+package xcontext
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+type ctxOperationIDKey struct{}
+
+var (
+	// operationIDPrefix is a random prefix of identifiers of operations of process
+	operationIDPrefix = newOperationIDPrefix()
+	// operationIDCounter is a counter of identifiers of operations of process
+	operationIDCounter uint64
+)
+
+func newOperationIDPrefix() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		binary.BigEndian.PutUint64(b[:], uint64(time.Now().UnixNano()))
+	}
+	return strconv.FormatUint(binary.BigEndian.Uint64(b[:]), 36)
+}
+
+// newOperationID returns identifier of operation which is unique within process lifetime
+// (identifiers of operations of different processes differ by random prefix)
+func newOperationID() string {
+	var b [32]byte
+	id := append(b[:0], operationIDPrefix...)
+	id = append(id, '-')
+	id = strconv.AppendUint(id, atomic.AddUint64(&operationIDCounter, 1), 36)
+	return string(id)
+}
+
+// WithOperationID returns a copy of parent context with identifier of logical operation
+// or parent context if it already has identifier of operation.
+// Flag created is true if identifier was generated by this call
+func WithOperationID(ctx context.Context) (_ context.Context, id string, created bool) {
+	if id, has := OperationID(ctx); has {
+		return ctx, id, false
+	}
+	id = newOperationID()
+	return context.WithValue(ctx, ctxOperationIDKey{}, id), id, true
+}
+
+// OperationID returns identifier of logical operation from context
+func OperationID(ctx context.Context) (string, bool) {
+	id, has := ctx.Value(ctxOperationIDKey{}).(string)
+	return id, has
+}
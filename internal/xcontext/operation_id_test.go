@@ -0,0 +1,56 @@
+package xcontext
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithOperationID(t *testing.T) {
+	ctx, id, created := WithOperationID(context.Background())
+	require.True(t, created)
+	require.NotEmpty(t, id)
+
+	nestedCtx, nestedID, created := WithOperationID(ctx)
+	require.False(t, created)
+	require.Equal(t, id, nestedID)
+	require.Equal(t, ctx, nestedCtx)
+
+	_, otherID, created := WithOperationID(context.Background())
+	require.True(t, created)
+	require.NotEqual(t, id, otherID)
+}
+
+func TestNewOperationIDUnique(t *testing.T) {
+	const (
+		goroutines = 8
+		ids        = 1000
+	)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		seen = make(map[string]struct{}, goroutines*ids)
+	)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < ids; j++ {
+				id := newOperationID()
+				mu.Lock()
+				seen[id] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Len(t, seen, goroutines*ids)
+}
+
+func TestNewOperationIDAllocations(t *testing.T) {
+	require.LessOrEqual(t, testing.AllocsPerRun(100, func() {
+		_ = newOperationID()
+	}), float64(1))
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/require"
 	grpcCodes "google.golang.org/grpc/codes"
 	grpcStatus "google.golang.org/grpc/status"
 )
@@ -104,3 +106,63 @@ func TestMustPessimizeEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestIsCanceledByCaller(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	for _, test := range []struct {
+		name     string
+		ctx      context.Context //nolint:containedctx
+		error    error
+		canceled bool
+	}{
+		{
+			name:     "CanceledByCaller",
+			ctx:      canceled,
+			error:    Transport(grpcStatus.Error(grpcCodes.Canceled, "")),
+			canceled: true,
+		},
+		{
+			name:     "DeadlineOfCaller",
+			ctx:      expired,
+			error:    Transport(grpcStatus.Error(grpcCodes.DeadlineExceeded, "")),
+			canceled: true,
+		},
+		{
+			name:     "ContextError",
+			ctx:      canceled,
+			error:    WithStackTrace(context.Canceled),
+			canceled: true,
+		},
+		{
+			name:     "DeadlineOfServer",
+			ctx:      context.Background(),
+			error:    Transport(grpcStatus.Error(grpcCodes.DeadlineExceeded, "")),
+			canceled: false,
+		},
+		{
+			name:     "CanceledByServer",
+			ctx:      context.Background(),
+			error:    Transport(grpcStatus.Error(grpcCodes.Canceled, "")),
+			canceled: false,
+		},
+		{
+			name:     "UnavailableAfterCancel",
+			ctx:      canceled,
+			error:    Transport(grpcStatus.Error(grpcCodes.Unavailable, "")),
+			canceled: false,
+		},
+		{
+			name:     "NoError",
+			ctx:      canceled,
+			error:    nil,
+			canceled: false,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.canceled, IsCanceledByCaller(test.ctx, test.error))
+		})
+	}
+}
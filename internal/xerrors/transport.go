@@ -2,6 +2,7 @@ package xerrors
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 
@@ -181,6 +182,18 @@ func MustPessimizeEndpoint(err error, codes ...grpcCodes.Code) bool {
 	}
 }
 
+// IsCanceledByCaller reports whether err is caused by cancellation or deadline of caller's
+// context ctx. Such errors (in contrast to deadline errors of server or transport with
+// alive ctx) are not failures of endpoint and must not pessimize it.
+func IsCanceledByCaller(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() == nil {
+		return false
+	}
+	return errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		IsTransportError(err, grpcCodes.Canceled, grpcCodes.DeadlineExceeded)
+}
+
 func TransportError(err error) Error {
 	if err == nil {
 		return nil
@@ -11,6 +11,7 @@ type (
 	ctxTransactionControlKey struct{}
 	ctxDataQueryOptionsKey   struct{}
 	ctxScanQueryOptionsKey   struct{}
+	ctxKeepInCacheKey        struct{}
 	ctxModeTypeKey           struct{}
 	ctxTxControlHookKey      struct{}
 
@@ -78,12 +79,27 @@ func (c *conn) WithDataQueryOptions(ctx context.Context, opts ...options.Execute
 }
 
 func (c *conn) dataQueryOptions(ctx context.Context) []options.ExecuteDataQueryOption {
+	dataOpts := c.dataOpts
+	if keepInCache, ok := ctx.Value(ctxKeepInCacheKey{}).(bool); ok && keepInCache {
+		// keep-in-cache flag of prepared statements is a default, so it is overridable
+		// by connector and per-call data query options
+		dataOpts = append([]options.ExecuteDataQueryOption{options.WithKeepInCache(true)}, c.dataOpts...)
+	}
 	if opts, ok := ctx.Value(ctxDataQueryOptionsKey{}).([]options.ExecuteDataQueryOption); ok {
-		return append(c.dataOpts, opts...)
+		return append(dataOpts, opts...)
 	}
-	return c.dataOpts
+	return dataOpts
 }
 
+// withKeepInCache marks context for execute prepared statement with keep-in-cache flag
+// if server query cache is not disabled with driver-wide default keep-in-cache flag
 func (c *conn) withKeepInCache(ctx context.Context) context.Context {
-	return c.WithDataQueryOptions(ctx, options.WithKeepInCache(true))
+	if t, has := c.connector.parent.Table().(interface {
+		KeepInCache() (keepInCache, ok bool)
+	}); has {
+		if keepInCache, ok := t.KeepInCache(); ok && !keepInCache {
+			return ctx
+		}
+	}
+	return context.WithValue(ctx, ctxKeepInCacheKey{}, true)
 }
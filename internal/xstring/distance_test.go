@@ -0,0 +1,28 @@
+package xstring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditDistance(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		exp  int
+	}{
+		{a: "", b: "", exp: 0},
+		{a: "", b: "abc", exp: 3},
+		{a: "abc", b: "", exp: 3},
+		{a: "$user_id", b: "$user_id", exp: 0},
+		{a: "$usr_id", b: "$user_id", exp: 1},
+		{a: "$user_di", b: "$user_id", exp: 2},
+		{a: "kitten", b: "sitting", exp: 3},
+		{a: "$id", b: "$user_id", exp: 5},
+	} {
+		t.Run(tt.a+"->"+tt.b, func(t *testing.T) {
+			require.Equal(t, tt.exp, EditDistance(tt.a, tt.b))
+			require.Equal(t, tt.exp, EditDistance(tt.b, tt.a))
+		})
+	}
+}
@@ -79,6 +79,17 @@ func internalDriver(l *wrapper, d trace.Detailer) (t trace.Driver) { //nolint:go
 			}
 		}
 	}
+	t.OnDatabaseUnavailable = func(info trace.DriverDatabaseUnavailableInfo) {
+		if d.Details()&trace.DriverEvents == 0 {
+			return
+		}
+		ctx := with(context.Background(), WARN, "ydb", "driver", "database", "unavailable")
+		l.Log(ctx, "database is unavailable",
+			String("database", info.Database),
+			Error(info.Error),
+			versionField(),
+		)
+	}
 	t.OnClose = func(info trace.DriverCloseStartInfo) func(trace.DriverCloseDoneInfo) {
 		if d.Details()&trace.DriverEvents == 0 {
 			return nil
@@ -197,6 +208,17 @@ func internalDriver(l *wrapper, d trace.Detailer) (t trace.Driver) { //nolint:go
 			}
 		}
 	}
+	t.OnDefaultDeadlineApplied = func(info trace.DriverDefaultDeadlineAppliedInfo) {
+		if d.Details()&trace.DriverConnEvents == 0 {
+			return
+		}
+		ctx := with(context.Background(), TRACE, "ydb", "driver", "conn", "default", "deadline")
+		l.Log(ctx, "default deadline applied",
+			String("method", string(info.Method)),
+			String("kind", info.Kind),
+			Duration("timeout", info.Timeout),
+		)
+	}
 	t.OnConnInvoke = func(info trace.DriverConnInvokeStartInfo) func(trace.DriverConnInvokeDoneInfo) {
 		if d.Details()&trace.DriverConnEvents == 0 {
 			return nil
@@ -387,6 +409,18 @@ func internalDriver(l *wrapper, d trace.Detailer) (t trace.Driver) { //nolint:go
 			}
 		}
 	}
+	t.OnBalancerStreamsOverflow = func(info trace.DriverBalancerStreamsOverflowInfo) {
+		if d.Details()&trace.DriverBalancerEvents == 0 {
+			return
+		}
+		ctx := with(context.Background(), DEBUG, "ydb", "driver", "balancer", "streams", "overflow")
+		l.Log(ctx, "stream routed to another connection",
+			Stringer("from", info.From),
+			Stringer("to", info.To),
+			Int("streams", info.Streams),
+			Int("limit", info.Limit),
+		)
+	}
 	t.OnBalancerChooseEndpoint = func(
 		info trace.DriverBalancerChooseEndpointStartInfo,
 	) func(
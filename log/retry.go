@@ -26,9 +26,11 @@ func internalRetry(l *wrapper, d trace.Detailer) (t trace.Retry) {
 		}
 		ctx := with(*info.Context, TRACE, "ydb", "retry")
 		label := info.Label
+		operationID := info.OperationID
 		idempotent := info.Idempotent
 		l.Log(ctx, "start",
 			String("label", label),
+			String("operation_id", operationID),
 			Bool("idempotent", idempotent),
 		)
 		start := time.Now()
@@ -36,6 +38,7 @@ func internalRetry(l *wrapper, d trace.Detailer) (t trace.Retry) {
 			if info.Error == nil {
 				l.Log(ctx, "attempt done",
 					String("label", label),
+					String("operation_id", operationID),
 					latencyField(start),
 				)
 			} else {
@@ -47,6 +50,7 @@ func internalRetry(l *wrapper, d trace.Detailer) (t trace.Retry) {
 				l.Log(WithLevel(ctx, lvl), "attempt failed",
 					Error(info.Error),
 					String("label", label),
+					String("operation_id", operationID),
 					latencyField(start),
 					Bool("retryable", m.MustRetry(idempotent)),
 					Int64("code", m.StatusCode()),
@@ -58,6 +62,7 @@ func internalRetry(l *wrapper, d trace.Detailer) (t trace.Retry) {
 				if info.Error == nil {
 					l.Log(ctx, "done",
 						String("label", label),
+						String("operation_id", operationID),
 						latencyField(start),
 						Int("attempts", info.Attempts),
 					)
@@ -70,6 +75,7 @@ func internalRetry(l *wrapper, d trace.Detailer) (t trace.Retry) {
 					l.Log(WithLevel(ctx, lvl), "failed",
 						Error(info.Error),
 						String("label", label),
+						String("operation_id", operationID),
 						latencyField(start),
 						Int("attempts", info.Attempts),
 						Bool("retryable", m.MustRetry(idempotent)),
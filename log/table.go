@@ -29,9 +29,11 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 		ctx := with(*info.Context, TRACE, "ydb", "table", "do")
 		idempotent := info.Idempotent
 		label := info.Label
+		operationID := info.OperationID
 		l.Log(ctx, "start",
 			Bool("idempotent", idempotent),
 			String("label", label),
+			String("operation_id", operationID),
 		)
 		start := time.Now()
 		return func(info trace.TableDoIntermediateInfo) func(trace.TableDoDoneInfo) {
@@ -40,6 +42,7 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 					latencyField(start),
 					Bool("idempotent", idempotent),
 					String("label", label),
+					String("operation_id", operationID),
 				)
 			} else {
 				lvl := WARN
@@ -51,6 +54,7 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 					latencyField(start),
 					Bool("idempotent", idempotent),
 					String("label", label),
+					String("operation_id", operationID),
 					Error(info.Error),
 					Bool("retryable", m.MustRetry(idempotent)),
 					Int64("code", m.StatusCode()),
@@ -64,6 +68,7 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 						latencyField(start),
 						Bool("idempotent", idempotent),
 						String("label", label),
+						String("operation_id", operationID),
 						Int("attempts", info.Attempts),
 					)
 				} else {
@@ -76,6 +81,7 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 						latencyField(start),
 						Bool("idempotent", idempotent),
 						String("label", label),
+						String("operation_id", operationID),
 						Int("attempts", info.Attempts),
 						Error(info.Error),
 						Bool("retryable", m.MustRetry(idempotent)),
@@ -100,9 +106,11 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 		ctx := with(*info.Context, TRACE, "ydb", "table", "do", "tx")
 		idempotent := info.Idempotent
 		label := info.Label
+		operationID := info.OperationID
 		l.Log(ctx, "start",
 			Bool("idempotent", idempotent),
 			String("label", label),
+			String("operation_id", operationID),
 		)
 		start := time.Now()
 		return func(info trace.TableDoTxIntermediateInfo) func(trace.TableDoTxDoneInfo) {
@@ -111,6 +119,7 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 					latencyField(start),
 					Bool("idempotent", idempotent),
 					String("label", label),
+					String("operation_id", operationID),
 				)
 			} else {
 				lvl := ERROR
@@ -122,6 +131,7 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 					latencyField(start),
 					Bool("idempotent", idempotent),
 					String("label", label),
+					String("operation_id", operationID),
 					Error(info.Error),
 					Bool("retryable", m.MustRetry(idempotent)),
 					Int64("code", m.StatusCode()),
@@ -135,6 +145,7 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 						latencyField(start),
 						Bool("idempotent", idempotent),
 						String("label", label),
+						String("operation_id", operationID),
 						Int("attempts", info.Attempts),
 					)
 				} else {
@@ -147,6 +158,7 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 						latencyField(start),
 						Bool("idempotent", idempotent),
 						String("label", label),
+						String("operation_id", operationID),
 						Int("attempts", info.Attempts),
 						Error(info.Error),
 						Bool("retryable", m.MustRetry(idempotent)),
@@ -346,6 +358,7 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 		ctx := with(*info.Context, TRACE, "ydb", "table", "session", "query", "execute")
 		session := info.Session
 		query := info.Query
+		txConsistency := info.TxConsistency
 		l.Log(ctx, "start",
 			appendFieldByCondition(l.logQuery,
 				Stringer("query", info.Query),
@@ -362,6 +375,7 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 						Stringer("query", query),
 						String("id", session.ID()),
 						String("tx", tx.ID()),
+						String("tx_consistency", txConsistency),
 						String("status", session.Status()),
 						Bool("prepared", info.Prepared),
 						NamedError("result_err", info.Result.Err()),
@@ -492,6 +506,19 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 			}
 		}
 	}
+	t.OnReadTablePartitionedRebalance = func(info trace.TableReadTablePartitionedRebalanceInfo) {
+		if d.Details()&trace.TableSessionQueryStreamEvents == 0 {
+			return
+		}
+		ctx := with(context.Background(), INFO, "ydb", "table", "read", "partitioned", "rebalance")
+		l.Log(ctx, "partitions of table changed, not started key ranges are rebalanced",
+			String("path", info.Path),
+			Int("partitions", info.Partitions),
+			Int("inProgress", info.InProgress),
+			Int("pending", info.Pending),
+			Int("rebalanced", info.Rebalanced),
+		)
+	}
 	t.OnSessionTransactionBegin = func(
 		info trace.TableSessionTransactionBeginStartInfo,
 	) func(
@@ -645,6 +672,57 @@ func internalTable(l *wrapper, d trace.Detailer) (t trace.Table) {
 			String("event", info.Event),
 		)
 	}
+	t.OnSessionQueryResultSizeWarning = func(info trace.TableSessionQueryResultSizeWarningInfo) {
+		if d.Details()&trace.TableSessionQueryInvokeEvents == 0 {
+			return
+		}
+		ctx := with(context.Background(), WARN, "ydb", "table", "session", "query", "result", "size")
+		l.Log(ctx, "result of query exceeds warning threshold, consider streaming APIs",
+			String("id", info.Session.ID()),
+			String("queryDigest", info.QueryDigest),
+			Int("rows", info.Rows),
+			Int("bytes", info.Bytes),
+		)
+	}
+	t.OnSessionQueryTimestampWarning = func(info trace.TableSessionQueryTimestampWarningInfo) {
+		if d.Details()&trace.TableSessionQueryInvokeEvents == 0 {
+			return
+		}
+		ctx := with(context.Background(), WARN, "ydb", "table", "session", "query", "timestamp")
+		l.Log(ctx, "timestamp of query parameter is later than current time with clock skew allowance",
+			String("id", info.Session.ID()),
+			String("parameter", info.Parameter),
+			Stringer("timestamp", info.Timestamp),
+			Stringer("now", info.Now),
+			Duration("clockSkew", info.ClockSkew),
+		)
+	}
+	t.OnPoolResize = func(info trace.TablePoolResizeInfo) {
+		if d.Details()&trace.TablePoolLifeCycleEvents == 0 {
+			return
+		}
+		ctx := with(context.Background(), INFO, "ydb", "table", "pool", "resize")
+		l.Log(ctx, "",
+			Int("prevLimit", info.PrevLimit),
+			Int("limit", info.Limit),
+			Int("size", info.Size),
+			Int("busy", info.Busy),
+			Int("waiters", info.Waiters),
+			Any("utilization", info.Utilization),
+			String("reason", info.Reason),
+		)
+	}
+	t.OnPoolSessionLimit = func(info trace.TablePoolSessionLimitInfo) {
+		if d.Details()&trace.TablePoolLifeCycleEvents == 0 {
+			return
+		}
+		ctx := with(context.Background(), WARN, "ydb", "table", "pool", "session", "limit")
+		l.Log(ctx, "server session limit exceeded",
+			Duration("cooldown", info.Cooldown),
+			Duration("interval", info.Interval),
+			Error(info.Error),
+		)
+	}
 	t.OnPoolSessionAdd = func(info trace.TablePoolSessionAddInfo) {
 		if d.Details()&trace.TablePoolLifeCycleEvents == 0 {
 			return
@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/jonboulle/clockwork"
+
 	"github.com/ydb-platform/ydb-go-sdk/v3/config"
 	"github.com/ydb-platform/ydb-go-sdk/v3/credentials"
 	balancerConfig "github.com/ydb-platform/ydb-go-sdk/v3/internal/balancer/config"
@@ -213,6 +215,17 @@ func WithCredentials(c credentials.Credentials) Option {
 	})
 }
 
+// WithDatabaseUnavailableAsWarning downgrades error ErrDatabaseUnavailable of check of database
+// on driver start to warning trace event trace.Driver.OnDatabaseUnavailable.
+// It is useful if driver intentionally starts before creating of database
+func WithDatabaseUnavailableAsWarning() Option {
+	return func(ctx context.Context, c *Driver) error {
+		c.options = append(c.options, config.WithDatabaseUnavailableAsWarning())
+
+		return nil
+	}
+}
+
 func WithBalancer(balancer *balancerConfig.Config) Option {
 	return func(ctx context.Context, c *Driver) error {
 		c.options = append(c.options, config.WithBalancer(balancer))
@@ -232,6 +245,19 @@ func WithDialTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithDefaultTimeouts defines default timeouts for calls by kind of operation
+//
+// Default timeout is applied only if call context has no deadline, so it can be
+// overridden per call with context.WithTimeout or context.WithDeadline.
+// See config.WithDefaultTimeouts for details about interaction with operation params.
+func WithDefaultTimeouts(timeouts map[config.OperationKind]time.Duration) Option {
+	return func(ctx context.Context, c *Driver) error {
+		c.options = append(c.options, config.WithDefaultTimeouts(timeouts))
+
+		return nil
+	}
+}
+
 // With collects additional configuration options.
 //
 // This option does not replace collected option, instead it will append provided options.
@@ -376,6 +402,40 @@ func WithSessionPoolSizeLimit(sizeLimit int) Option {
 	}
 }
 
+// WithSessionPoolAdaptiveSize enables adaptive sizing of internal sessions pool in table.Client
+//
+// Limit of sessions pool grows up to max while session waiters are observed and shrinks down to min
+// while utilization of pool is lower than targetUtilization. Limit is evaluated every evaluationInterval.
+// All changes of limit are reported with trace.Table.OnPoolResize.
+// WithSessionPoolAdaptiveSize overrides WithSessionPoolSizeLimit option (max is a hard limit of sessions pool).
+func WithSessionPoolAdaptiveSize(
+	min, max int, targetUtilization float64, evaluationInterval time.Duration,
+) Option {
+	return func(ctx context.Context, c *Driver) error {
+		c.tableOptions = append(c.tableOptions,
+			tableConfig.WithAdaptiveSize(min, max, targetUtilization, evaluationInterval),
+		)
+
+		return nil
+	}
+}
+
+// WithSessionPoolSingleSession enables single session mode of table.Client
+//
+// In single session mode table.Client does not keep a pool of sessions: it lazily creates exactly one
+// session and reuses it in all operations (concurrent operations wait for each other). Session is recreated
+// after invalidation (for example, with BAD_SESSION) and deleted on closing of driver with short deadline
+// (see WithSessionPoolDeleteTimeout). table.Client does not start background goroutines in this mode,
+// so it is suitable for short-living CLI tools and scripts.
+// WithSessionPoolSingleSession overrides WithSessionPoolSizeLimit and WithSessionPoolAdaptiveSize options.
+func WithSessionPoolSingleSession() Option {
+	return func(ctx context.Context, c *Driver) error {
+		c.tableOptions = append(c.tableOptions, tableConfig.WithSingleSession())
+
+		return nil
+	}
+}
+
 // WithSessionPoolKeepAliveMinSize set minimum sessions should be keeped alive in table.Client
 //
 // Deprecated: table client do not supports background session keep-aliving now
@@ -401,6 +461,27 @@ func WithSessionPoolKeepAliveTimeout(keepAliveTimeout time.Duration) Option {
 	return func(ctx context.Context, c *Driver) error { return nil }
 }
 
+// WithSessionPoolSessionLimitCooldown set duration of suspending of sessions creation in table.Client
+// after exceeding of server limit of sessions of database
+func WithSessionPoolSessionLimitCooldown(cooldown time.Duration) Option {
+	return func(ctx context.Context, c *Driver) error {
+		c.tableOptions = append(c.tableOptions, tableConfig.WithSessionLimitCooldown(cooldown))
+
+		return nil
+	}
+}
+
+// WithSessionPoolSessionLimitMatcher set matcher of errors of exceeding of server limit of sessions
+// of database in table.Client. Matcher is called for create session errors with OVERLOADED status only.
+// By default, error is matched by issue message which contains "too many sessions"
+func WithSessionPoolSessionLimitMatcher(matcher func(err error) bool) Option {
+	return func(ctx context.Context, c *Driver) error {
+		c.tableOptions = append(c.tableOptions, tableConfig.WithSessionLimitMatcher(matcher))
+
+		return nil
+	}
+}
+
 // WithSessionPoolCreateSessionTimeout set timeout for new session creation process in table.Client
 func WithSessionPoolCreateSessionTimeout(createSessionTimeout time.Duration) Option {
 	return func(ctx context.Context, c *Driver) error {
@@ -428,6 +509,91 @@ func WithIgnoreTruncated() Option {
 	}
 }
 
+// WithStrictTypes makes results of table queries with columns of unknown primitive types broken.
+//
+// By default, values of primitive types which are unknown for SDK (for example, types which
+// are added in newer version of YDB) are decoded as opaque values, so other columns of rows
+// are scanned as usual
+func WithStrictTypes() Option {
+	return func(ctx context.Context, c *Driver) error {
+		c.tableOptions = append(c.tableOptions, tableConfig.WithStrictTypes())
+
+		return nil
+	}
+}
+
+// WithQueryParametersCheck enables client-side check of parameters of data queries against
+// query text: declared parameters (DECLARE statements) must be passed and passed parameters
+// must be declared (or referenced in query without DECLARE statements). Queries with mismatched
+// parameters fail before sending with list of mismatches and suggestions of close names
+// (for example, `$userId` for declared `$user_id`). Prepared statements check passed parameters
+// against parameters declared in prepared query. Per-call option options.WithQueryParametersCheck
+// enables check for single query
+func WithQueryParametersCheck() Option {
+	return func(ctx context.Context, c *Driver) error {
+		c.tableOptions = append(c.tableOptions, tableConfig.WithQueryParametersCheck())
+
+		return nil
+	}
+}
+
+// WithKeepInCache defines default keep-in-cache flag of query cache policy of data queries.
+// By default, keep-in-cache flag is enabled only for queries with parameters.
+// Use WithKeepInCache(false) for disabling of server query cache for one-off queries.
+// Per-call option options.WithKeepInCache overrides this default
+func WithKeepInCache(keepInCache bool) Option {
+	return func(ctx context.Context, c *Driver) error {
+		c.tableOptions = append(c.tableOptions, tableConfig.WithKeepInCache(keepInCache))
+
+		return nil
+	}
+}
+
+// WithResultSizeWarning defines thresholds of rows count and bytes size of unary data query
+// result above which trace.Table.OnSessionQueryResultSizeWarning event fires (with digest of query).
+// Zero threshold is disabled.
+func WithResultSizeWarning(rows, bytes int) Option {
+	return func(ctx context.Context, c *Driver) error {
+		c.tableOptions = append(c.tableOptions, tableConfig.WithResultSizeWarning(rows, bytes))
+
+		return nil
+	}
+}
+
+// WithResultSizeLimit defines hard limits of rows count and bytes size of unary data query
+// result. Result sets are checked while decoding, and decoding is aborted with
+// result.ErrResultTooLarge as soon as limit is exceeded. Zero limit is disabled.
+func WithResultSizeLimit(rows, bytes int) Option {
+	return func(ctx context.Context, c *Driver) error {
+		c.tableOptions = append(c.tableOptions, tableConfig.WithResultSizeLimit(rows, bytes))
+
+		return nil
+	}
+}
+
+// WithClock defines source of current time for all clients of driver
+// (scheduling of static credentials refresh, session pool and validation of timestamps)
+func WithClock(clock clockwork.Clock) Option {
+	return func(ctx context.Context, c *Driver) error {
+		c.options = append(c.options, config.WithClock(clock))
+
+		return nil
+	}
+}
+
+// WithClockSkew defines allowance of skew between local clock and clock of YDB server.
+// Static credentials with token lifetime computed by local clock are refreshed not
+// earlier than after tenth of allowance. Parameters of data queries with timestamps
+// later than current time with allowance are reported by trace.Table.OnSessionQueryTimestampWarning
+// event (warn-only, queries are executed as is). Zero allowance disables validation of timestamps
+func WithClockSkew(skew time.Duration) Option {
+	return func(ctx context.Context, c *Driver) error {
+		c.options = append(c.options, config.WithClockSkew(skew))
+
+		return nil
+	}
+}
+
 // WithPanicCallback specified behavior on panic
 // Warning: WithPanicCallback must be defined on start of all options
 // (before `WithTrace{Driver,Table,Scheme,Scripting,Coordination,Ratelimiter}` and other options)
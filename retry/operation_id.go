@@ -0,0 +1,62 @@
+package retry
+
+import (
+	"context"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xcontext"
+)
+
+// OperationIDError is a final error of logical operation (such as Retry call or
+// table.Client.Do call) annotated with identifier of operation.
+//
+// Identifier of operation is the same for all attempts of operation and is provided
+// in trace events of operation (see trace.RetryLoopStartInfo.OperationID),
+// so use errors.As for correlation of failed operation with logs of its attempts.
+// Errors are annotated only if operation has identifier, errors of operations without
+// identifier are returned as is.
+type OperationIDError struct {
+	OperationID string
+	Err         error
+}
+
+func (e *OperationIDError) Error() string {
+	if e.OperationID == "" {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + " (operationID = " + e.OperationID + ")"
+}
+
+func (e *OperationIDError) Unwrap() error {
+	return e.Err
+}
+
+// OperationID returns identifier of logical operation which ctx belongs to
+// (for example, context of retry operation or context of trace event of request
+// inside retry operation). OperationID returns empty string if ctx is out of operation
+func OperationID(ctx context.Context) string {
+	id, _ := xcontext.OperationID(ctx)
+	return id
+}
+
+var _ Option = operationIDAsTraceIDOption{}
+
+type operationIDAsTraceIDOption struct{}
+
+func (operationIDAsTraceIDOption) ApplyRetryOption(opts *retryOptions) {
+	opts.operationIDAsTraceID = true
+}
+
+func (operationIDAsTraceIDOption) ApplyDoOption(opts *doOptions) {
+	opts.retryOptions = append(opts.retryOptions, WithOperationIDAsTraceID())
+}
+
+func (operationIDAsTraceIDOption) ApplyDoTxOption(opts *doTxOptions) {
+	opts.retryOptions = append(opts.retryOptions, WithOperationIDAsTraceID())
+}
+
+// WithOperationIDAsTraceID sends identifier of logical operation as trace id
+// (x-ydb-trace-id header) of requests of all attempts of operation, so server logs
+// of attempts are correlated too. Trace id from context (meta.WithTraceID) takes precedence
+func WithOperationIDAsTraceID() operationIDAsTraceIDOption {
+	return operationIDAsTraceIDOption{}
+}
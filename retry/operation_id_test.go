@@ -0,0 +1,89 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/meta"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+func TestRetryOperationID(t *testing.T) {
+	var (
+		traceIDs        []string
+		attemptIDs      []string
+		nestedIDs       []string
+		metadataIDs     []string
+		errNotRetryable = errors.New("not retryable")
+	)
+	err := Retry(context.Background(), func(ctx context.Context) error {
+		attemptIDs = append(attemptIDs, OperationID(ctx))
+		md, _ := metadata.FromOutgoingContext(ctx)
+		metadataIDs = append(metadataIDs, md.Get(meta.HeaderTraceID)...)
+		// nested call belongs to the same logical operation
+		require.NoError(t, Retry(ctx, func(ctx context.Context) error {
+			nestedIDs = append(nestedIDs, OperationID(ctx))
+			return nil
+		}))
+		if len(attemptIDs) < 3 {
+			return xerrors.Operation(xerrors.WithStatusCode(Ydb.StatusIds_BAD_SESSION))
+		}
+		return errNotRetryable
+	}, WithOperationIDAsTraceID(), WithTrace(&trace.Retry{
+		OnRetry: func(info trace.RetryLoopStartInfo) func(trace.RetryLoopIntermediateInfo) func(trace.RetryLoopDoneInfo) {
+			traceIDs = append(traceIDs, info.OperationID)
+			return nil
+		},
+	}))
+	require.ErrorIs(t, err, errNotRetryable)
+
+	var operationIDErr *OperationIDError
+	require.ErrorAs(t, err, &operationIDErr)
+	id := operationIDErr.OperationID
+	require.NotEmpty(t, id)
+	require.Contains(t, err.Error(), "operationID = "+id)
+
+	require.Len(t, attemptIDs, 3)
+	for _, ids := range [][]string{traceIDs, attemptIDs, nestedIDs, metadataIDs} {
+		require.NotEmpty(t, ids)
+		for _, attemptID := range ids {
+			require.Equal(t, id, attemptID)
+		}
+	}
+
+	t.Run("NewOperation", func(t *testing.T) {
+		err := Retry(context.Background(), func(ctx context.Context) error {
+			return errNotRetryable
+		})
+		require.ErrorAs(t, err, &operationIDErr)
+		require.NotEqual(t, id, operationIDErr.OperationID)
+	})
+	t.Run("NoErrorAnnotationOfNestedCall", func(t *testing.T) {
+		_ = Retry(context.Background(), func(ctx context.Context) error {
+			err := Retry(ctx, func(ctx context.Context) error {
+				return errNotRetryable
+			})
+			require.ErrorIs(t, err, errNotRetryable)
+			require.False(t, errors.As(err, &operationIDErr))
+			return nil
+		})
+	})
+	t.Run("OutOfOperation", func(t *testing.T) {
+		require.Empty(t, OperationID(context.Background()))
+	})
+}
+
+func TestOperationIDErrorWithoutOperationID(t *testing.T) {
+	errTest := errors.New("test")
+	err := &OperationIDError{Err: errTest}
+	require.Equal(t, errTest.Error(), err.Error())
+	require.ErrorIs(t, err, errTest)
+	err = &OperationIDError{OperationID: "id", Err: errTest}
+	require.Equal(t, "test (operationID = id)", err.Error())
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/backoff"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/meta"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/paramscache"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/stack"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/wait"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xcontext"
@@ -26,6 +28,8 @@ type retryOptions struct {
 	fastBackoff backoff.Backoff
 	slowBackoff backoff.Backoff
 
+	operationIDAsTraceID bool
+
 	panicCallback func(e interface{})
 }
 
@@ -245,6 +249,21 @@ func Retry(ctx context.Context, op retryOperation, opts ...Option) (finalErr err
 	if options.idempotent {
 		ctx = xcontext.WithIdempotent(ctx, options.idempotent)
 	}
+	// query parameters are serialized once for all attempts of operation
+	ctx, releaseParams := paramscache.WithCache(ctx)
+	defer releaseParams()
+	ctx, operationID, operationIDCreated := xcontext.WithOperationID(ctx)
+	if options.operationIDAsTraceID {
+		ctx = meta.WithTraceID(ctx, operationID)
+	}
+	defer func() {
+		if finalErr != nil && operationIDCreated && operationID != "" {
+			finalErr = &OperationIDError{
+				OperationID: operationID,
+				Err:         finalErr,
+			}
+		}
+	}()
 	defer func() {
 		if finalErr != nil && options.stackTrace {
 			finalErr = xerrors.WithStackTrace(finalErr,
@@ -259,10 +278,11 @@ func Retry(ctx context.Context, op retryOperation, opts ...Option) (finalErr err
 		code           = int64(0)
 		onIntermediate = trace.RetryOnRetry(options.trace, &ctx,
 			options.label, options.call, options.label, options.idempotent, xcontext.IsNestedCall(ctx),
+			operationID,
 		)
 	)
 	defer func() {
-		onIntermediate(finalErr)(attempts, finalErr)
+		onIntermediate(operationID, finalErr)(attempts, operationID, finalErr)
 	}()
 	for {
 		i++
@@ -329,7 +349,7 @@ func Retry(ctx context.Context, op retryOperation, opts ...Option) (finalErr err
 
 			code = m.StatusCode()
 
-			onIntermediate(err)
+			onIntermediate(operationID, err)
 		}
 	}
 }
@@ -0,0 +1,191 @@
+package scheme
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/backoff"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xrand"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+)
+
+// Diff is a difference between two listings of directory
+type Diff struct {
+	// Added are entries which appeared in directory
+	Added []Entry
+	// Removed are entries which disappeared from directory
+	Removed []Entry
+	// Changed are entries which have the same name but another type
+	Changed []EntryChange
+}
+
+// EntryChange is a change of type of directory entry
+type EntryChange struct {
+	Previous Entry
+	Current  Entry
+}
+
+// IsEmpty reports whether listings of directory have the same entries
+func (d *Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// diffEntries returns difference between previous and current entries of directory
+// sorted by names of entries
+func diffEntries(previous, current []Entry) (diff Diff) {
+	previousByName := make(map[string]Entry, len(previous))
+	for _, e := range previous {
+		previousByName[e.Name] = e
+	}
+	currentByName := make(map[string]Entry, len(current))
+	for _, e := range current {
+		currentByName[e.Name] = e
+		p, has := previousByName[e.Name]
+		switch {
+		case !has:
+			diff.Added = append(diff.Added, e)
+		case p.Type != e.Type:
+			diff.Changed = append(diff.Changed, EntryChange{Previous: p, Current: e})
+		}
+	}
+	for _, e := range previous {
+		if _, has := currentByName[e.Name]; !has {
+			diff.Removed = append(diff.Removed, e)
+		}
+	}
+	sort.Slice(diff.Added, func(i, j int) bool {
+		return diff.Added[i].Name < diff.Added[j].Name
+	})
+	sort.Slice(diff.Removed, func(i, j int) bool {
+		return diff.Removed[i].Name < diff.Removed[j].Name
+	})
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].Current.Name < diff.Changed[j].Current.Name
+	})
+	return diff
+}
+
+type watchOptions struct {
+	jitter  float64
+	refresh <-chan struct{}
+	onError func(err error)
+}
+
+// WatchOption is an option of Watch
+type WatchOption func(o *watchOptions)
+
+// WithWatchJitter defines random deviation of interval between listings of directory
+// as a fraction of interval (from 0 to 1, 0.1 by default)
+// for spreading of listings of many watchers over time
+func WithWatchJitter(jitter float64) WatchOption {
+	return func(o *watchOptions) {
+		switch {
+		case jitter < 0:
+			o.jitter = 0
+		case jitter > 1:
+			o.jitter = 1
+		default:
+			o.jitter = jitter
+		}
+	}
+}
+
+// WithWatchRefresh makes Watch list directory immediately on each receive from refresh
+//
+// Closed refresh channel is ignored (as nil channel).
+func WithWatchRefresh(refresh <-chan struct{}) WatchOption {
+	return func(o *watchOptions) {
+		o.refresh = refresh
+	}
+}
+
+// WithWatchOnError defines handler of transient errors of listing of directory
+// after which Watch retries listing with backoff
+func WithWatchOnError(onError func(err error)) WatchOption {
+	return func(o *watchOptions) {
+		o.onError = onError
+	}
+}
+
+// Watch periodically lists directory at path with given interval and calls onDiff with difference
+// between current and previous listings if directory entries are added, removed or changed
+// (by names and types of entries). First listing is reported as a difference with empty directory.
+//
+// Transient errors of listing are retried with backoff (previous listing is kept, so changes
+// are not lost). Watch blocks until ctx is done or non-retryable error occurs.
+// Interval must be positive.
+func Watch(
+	ctx context.Context,
+	c Client,
+	path string,
+	interval time.Duration,
+	onDiff func(diff Diff),
+	opts ...WatchOption,
+) error {
+	if interval <= 0 {
+		return xerrors.WithStackTrace(fmt.Errorf("non-positive watch interval: %v", interval))
+	}
+	o := watchOptions{
+		jitter: 0.1,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	var (
+		previous []Entry
+		attempts int
+		r        = xrand.New()
+	)
+	for {
+		var delay time.Duration
+		d, err := c.ListDirectory(ctx, path)
+		switch {
+		case err == nil:
+			attempts = 0
+			if diff := diffEntries(previous, d.Children); !diff.IsEmpty() {
+				onDiff(diff)
+			}
+			previous = d.Children
+			delay = interval
+			if jitter := int64(o.jitter * float64(interval)); jitter > 0 {
+				delay += time.Duration(r.Int64(2*jitter+1) - jitter)
+			}
+		case ctx.Err() != nil:
+			return xerrors.WithStackTrace(ctx.Err())
+		default:
+			m := retry.Check(err)
+			if !m.MustRetry(true) {
+				return xerrors.WithStackTrace(err)
+			}
+			if o.onError != nil {
+				o.onError(err)
+			}
+			if m.BackoffType() == backoff.TypeFast {
+				delay = backoff.Fast.Delay(attempts)
+			} else {
+				delay = backoff.Slow.Delay(attempts)
+			}
+			attempts++
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return xerrors.WithStackTrace(ctx.Err())
+		case _, ok := <-o.refresh:
+			timer.Stop()
+			if !ok {
+				// closed refresh channel is the same as no refresh channel
+				o.refresh = nil
+			}
+		case <-timer.C:
+		}
+	}
+}
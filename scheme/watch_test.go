@@ -0,0 +1,198 @@
+package scheme_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/scheme"
+)
+
+// listingStub is a scheme client which returns listings of directory one by one
+// (the last listing is repeated)
+type listingStub struct {
+	scheme.Client
+
+	mu       sync.Mutex
+	listings []func() (scheme.Directory, error)
+	calls    int
+}
+
+func (s *listingStub) ListDirectory(ctx context.Context, path string) (scheme.Directory, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.calls
+	if i >= len(s.listings) {
+		i = len(s.listings) - 1
+	}
+	s.calls++
+	return s.listings[i]()
+}
+
+func (s *listingStub) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func listing(entries ...scheme.Entry) func() (scheme.Directory, error) {
+	return func() (scheme.Directory, error) {
+		return scheme.Directory{Children: entries}, nil
+	}
+}
+
+func listingErr(err error) func() (scheme.Directory, error) {
+	return func() (scheme.Directory, error) {
+		return scheme.Directory{}, err
+	}
+}
+
+func table(name string) scheme.Entry {
+	return scheme.Entry{Name: name, Type: scheme.EntryTable}
+}
+
+func topic(name string) scheme.Entry {
+	return scheme.Entry{Name: name, Type: scheme.EntryTopic}
+}
+
+// watch runs scheme.Watch until count of diffs is received and returns diffs and error of Watch
+func watch(
+	t *testing.T,
+	c scheme.Client,
+	interval time.Duration,
+	count int,
+	opts ...scheme.WatchOption,
+) ([]scheme.Diff, error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var diffs []scheme.Diff
+	err := scheme.Watch(ctx, c, "/local/tenants", interval, func(diff scheme.Diff) {
+		diffs = append(diffs, diff)
+		if len(diffs) == count {
+			cancel()
+		}
+	}, opts...)
+	require.Len(t, diffs, count)
+	return diffs, err
+}
+
+func TestWatch(t *testing.T) {
+	unavailable := xerrors.Operation(xerrors.WithStatusCode(Ydb.StatusIds_UNAVAILABLE))
+	t.Run("Diffs", func(t *testing.T) {
+		c := &listingStub{listings: []func() (scheme.Directory, error){
+			listing(table("a"), table("b")),
+			listing(table("a"), table("b")),
+			listing(table("a"), table("b"), table("c")),
+			listing(table("c"), topic("b")),
+		}}
+		diffs, err := watch(t, c, time.Millisecond, 3, scheme.WithWatchJitter(0))
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, []scheme.Diff{
+			{Added: []scheme.Entry{table("a"), table("b")}},
+			{Added: []scheme.Entry{table("c")}},
+			{
+				Removed: []scheme.Entry{table("a")},
+				Changed: []scheme.EntryChange{{Previous: table("b"), Current: topic("b")}},
+			},
+		}, diffs)
+		require.Equal(t, 4, c.Calls())
+	})
+	t.Run("TransientErrors", func(t *testing.T) {
+		c := &listingStub{listings: []func() (scheme.Directory, error){
+			listing(table("a")),
+			listingErr(unavailable),
+			listingErr(unavailable),
+			listing(table("a"), table("b")),
+		}}
+		var errs []error
+		diffs, err := watch(t, c, time.Millisecond, 2, scheme.WithWatchOnError(func(err error) {
+			errs = append(errs, err)
+		}))
+		require.ErrorIs(t, err, context.Canceled)
+		// previous listing is kept on errors
+		require.Equal(t, []scheme.Diff{
+			{Added: []scheme.Entry{table("a")}},
+			{Added: []scheme.Entry{table("b")}},
+		}, diffs)
+		require.Len(t, errs, 2)
+		for _, err := range errs {
+			require.True(t, xerrors.IsOperationError(err, Ydb.StatusIds_UNAVAILABLE))
+		}
+	})
+	t.Run("NonRetryableError", func(t *testing.T) {
+		c := &listingStub{listings: []func() (scheme.Directory, error){
+			listing(table("a")),
+			listingErr(xerrors.Operation(xerrors.WithStatusCode(Ydb.StatusIds_SCHEME_ERROR))),
+		}}
+		var diffs []scheme.Diff
+		err := scheme.Watch(context.Background(), c, "/local/tenants", time.Millisecond, func(diff scheme.Diff) {
+			diffs = append(diffs, diff)
+		})
+		require.Equal(t, []scheme.Diff{{Added: []scheme.Entry{table("a")}}}, diffs)
+		require.True(t, xerrors.IsOperationError(err, Ydb.StatusIds_SCHEME_ERROR))
+		require.Equal(t, 2, c.Calls())
+	})
+	t.Run("Refresh", func(t *testing.T) {
+		c := &listingStub{listings: []func() (scheme.Directory, error){
+			listing(),
+			listing(table("a")),
+		}}
+		refresh := make(chan struct{}, 1)
+		refresh <- struct{}{}
+		start := time.Now()
+		diffs, err := watch(t, c, time.Hour, 1, scheme.WithWatchRefresh(refresh))
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, []scheme.Diff{{Added: []scheme.Entry{table("a")}}}, diffs)
+		require.Less(t, time.Since(start), time.Hour/2)
+		require.Equal(t, 2, c.Calls())
+	})
+	t.Run("ClosedRefresh", func(t *testing.T) {
+		const interval = 20 * time.Millisecond
+		c := &listingStub{listings: []func() (scheme.Directory, error){
+			listing(),
+		}}
+		refresh := make(chan struct{})
+		close(refresh)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*interval)
+		defer cancel()
+		err := scheme.Watch(ctx, c, "/local/tenants", interval, func(diff scheme.Diff) {
+			t.Fatalf("unexpected diff: %+v", diff)
+		}, scheme.WithWatchRefresh(refresh), scheme.WithWatchJitter(0))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		// closed refresh channel does not make listings in a busy loop
+		require.LessOrEqual(t, c.Calls(), 10+2)
+	})
+	t.Run("NonPositiveInterval", func(t *testing.T) {
+		for _, interval := range []time.Duration{0, -time.Second} {
+			c := &listingStub{listings: []func() (scheme.Directory, error){
+				listing(),
+			}}
+			err := scheme.Watch(context.Background(), c, "/local/tenants", interval, func(diff scheme.Diff) {
+				t.Fatalf("unexpected diff: %+v", diff)
+			})
+			require.Error(t, err)
+			require.Zero(t, c.Calls())
+		}
+	})
+	t.Run("Jitter", func(t *testing.T) {
+		const interval = 20 * time.Millisecond
+		c := &listingStub{listings: []func() (scheme.Directory, error){
+			listing(),
+		}}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*interval)
+		defer cancel()
+		err := scheme.Watch(ctx, c, "/local/tenants", interval, func(diff scheme.Diff) {
+			t.Fatalf("unexpected diff: %+v", diff)
+		}, scheme.WithWatchJitter(0.5))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		// intervals with jitter are not shorter than interval/2
+		require.Positive(t, c.Calls())
+		require.LessOrEqual(t, c.Calls(), 10*2+1)
+	})
+}
@@ -0,0 +1,301 @@
+package sugar
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xsync"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+type partitionedReadOptions struct {
+	workers          int
+	describeInterval time.Duration
+	readOptions      []options.ReadTableOption
+	trace            *trace.Table
+}
+
+// PartitionedReadOption is an option of ReadTablePartitioned
+type PartitionedReadOption func(o *partitionedReadOptions)
+
+// WithPartitionedReadWorkers defines number of key ranges which are read in parallel (4 by default)
+func WithPartitionedReadWorkers(workers int) PartitionedReadOption {
+	return func(o *partitionedReadOptions) {
+		if workers > 0 {
+			o.workers = workers
+		}
+	}
+}
+
+// WithPartitionedReadDescribeInterval defines interval of describing of table for detection
+// of changes of partitions (one minute by default). Zero interval disables rebalancing
+func WithPartitionedReadDescribeInterval(interval time.Duration) PartitionedReadOption {
+	return func(o *partitionedReadOptions) {
+		o.describeInterval = interval
+	}
+}
+
+// WithPartitionedReadOptions defines options of reading of each key range (such as columns
+// or snapshot). Key range options are overridden by key range of partition
+func WithPartitionedReadOptions(opts ...options.ReadTableOption) PartitionedReadOption {
+	return func(o *partitionedReadOptions) {
+		o.readOptions = append(o.readOptions, opts...)
+	}
+}
+
+// WithPartitionedReadTrace defines trace of rebalancing of key ranges
+func WithPartitionedReadTrace(t trace.Table) PartitionedReadOption {
+	return func(o *partitionedReadOptions) {
+		o.trace = &t
+	}
+}
+
+// ReadTablePartitioned reads table at path in parallel by key ranges of partitions
+// (shard key bounds) and calls onRange with stream of rows of each key range.
+//
+// Partitions of table may be split or merged by YDB during long read, so ReadTablePartitioned
+// describes table periodically (see WithPartitionedReadDescribeInterval) and rebalances
+// not started key ranges by new bounds of partitions: ranges are split by new bounds and
+// adjacent ranges are merged if bound between them disappeared. Key ranges which are read
+// are not changed. Each key of table belongs to exactly one key range passed to onRange.
+//
+// Reading of key range with onRange is retried by table.Client.Do, so onRange may be called
+// again for the same key range after retryable error. ReadTablePartitioned returns first
+// error of reading (other key ranges are canceled).
+func ReadTablePartitioned(
+	ctx context.Context,
+	c table.Client,
+	path string,
+	onRange func(ctx context.Context, r options.KeyRange, res result.StreamResult) error,
+	opts ...PartitionedReadOption,
+) error {
+	o := partitionedReadOptions{
+		workers:          4,
+		describeInterval: time.Minute,
+		trace:            &trace.Table{},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	desc, err := describeShardKeyBounds(ctx, c, path)
+	if err != nil {
+		return xerrors.WithStackTrace(err)
+	}
+	p := &partitionedRead{
+		pending: append([]options.KeyRange(nil), desc.KeyRanges...),
+		bounds:  shardKeyBounds(desc.KeyRanges),
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		watcher  sync.WaitGroup
+		workers  sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	if o.describeInterval > 0 {
+		watchCtx, stopWatch := context.WithCancel(ctx)
+		watcher.Add(1)
+		go func() {
+			defer watcher.Done()
+			p.watch(watchCtx, c, path, o.describeInterval, o.trace)
+		}()
+		defer func() {
+			stopWatch()
+			watcher.Wait()
+		}()
+	}
+
+	for i := 0; i < o.workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			readOptions := make([]options.ReadTableOption, len(o.readOptions), len(o.readOptions)+1)
+			copy(readOptions, o.readOptions)
+			for ctx.Err() == nil {
+				r, ok := p.next()
+				if !ok {
+					return
+				}
+				err := c.Do(ctx, func(ctx context.Context, s table.Session) (err error) {
+					res, err := s.StreamReadTable(ctx, path, append(readOptions, options.ReadKeyRange(r))...)
+					if err != nil {
+						return xerrors.WithStackTrace(err)
+					}
+					defer func() {
+						_ = res.Close()
+					}()
+					return onRange(ctx, r, res)
+				})
+				p.done()
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = xerrors.WithStackTrace(err)
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+	workers.Wait()
+
+	return firstErr
+}
+
+func describeShardKeyBounds(ctx context.Context, c table.Client, path string) (desc options.Description, err error) {
+	err = c.Do(ctx, func(ctx context.Context, s table.Session) (err error) {
+		desc, err = s.DescribeTable(ctx, path, options.WithShardKeyBounds())
+		return err
+	}, table.WithIdempotent())
+	return desc, err
+}
+
+// shardKeyBounds returns bounds between key ranges of partitions
+func shardKeyBounds(ranges []options.KeyRange) []types.Value {
+	bounds := make([]types.Value, 0, len(ranges))
+	for _, r := range ranges {
+		if r.To != nil {
+			bounds = append(bounds, r.To)
+		}
+	}
+	return bounds
+}
+
+// partitionedRead is a queue of not started key ranges of partitioned read
+type partitionedRead struct {
+	mu         xsync.Mutex
+	pending    []options.KeyRange
+	inProgress int
+	bounds     []types.Value
+}
+
+// next returns first not started key range
+func (p *partitionedRead) next() (r options.KeyRange, ok bool) {
+	p.mu.WithLock(func() {
+		if len(p.pending) == 0 {
+			return
+		}
+		r, ok = p.pending[0], true
+		p.pending = p.pending[1:]
+		p.inProgress++
+	})
+	return r, ok
+}
+
+func (p *partitionedRead) done() {
+	p.mu.WithLock(func() {
+		p.inProgress--
+	})
+}
+
+// watch describes table with interval and rebalances not started key ranges on changes of bounds
+// of partitions. Errors of describing are skipped: ranges are rebalanced by next description
+func (p *partitionedRead) watch(
+	ctx context.Context,
+	c table.Client,
+	path string,
+	interval time.Duration,
+	t *trace.Table,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		desc, err := describeShardKeyBounds(ctx, c, path)
+		if err != nil {
+			continue
+		}
+		bounds := shardKeyBounds(desc.KeyRanges)
+		var inProgress, pending, rebalanced int
+		p.mu.WithLock(func() {
+			if len(p.pending) == 0 || boundsEqual(p.bounds, bounds) {
+				return
+			}
+			ranges, err := rebalanceKeyRanges(p.pending, bounds)
+			if err != nil {
+				return
+			}
+			inProgress, pending, rebalanced = p.inProgress, len(p.pending), len(ranges)
+			p.pending, p.bounds = ranges, bounds
+		})
+		if rebalanced > 0 {
+			trace.TableOnReadTablePartitionedRebalance(t, path, len(bounds)+1, inProgress, pending, rebalanced)
+		}
+	}
+}
+
+func boundsEqual(lhs, rhs []types.Value) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i := range lhs {
+		if !value.Equal(lhs[i], rhs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// rebalanceKeyRanges splits sorted key ranges by sorted bounds of partitions and merges
+// adjacent key ranges if bound between them is not a bound of partitions
+func rebalanceKeyRanges(ranges []options.KeyRange, bounds []types.Value) ([]options.KeyRange, error) {
+	split := make([]options.KeyRange, 0, len(ranges)+len(bounds))
+	for _, r := range ranges {
+		from := r.From
+		for _, bound := range bounds {
+			if from != nil {
+				if c, err := value.CompareKeys(from, bound); err != nil {
+					return nil, err
+				} else if c >= 0 {
+					continue
+				}
+			}
+			if r.To != nil {
+				if c, err := value.CompareKeys(bound, r.To); err != nil {
+					return nil, err
+				} else if c >= 0 {
+					break
+				}
+			}
+			split = append(split, options.KeyRange{From: from, To: bound})
+			from = bound
+		}
+		split = append(split, options.KeyRange{From: from, To: r.To})
+	}
+	merged := make([]options.KeyRange, 0, len(split))
+	for _, r := range split {
+		if n := len(merged); n > 0 && merged[n-1].To != nil && r.From != nil &&
+			value.Equal(merged[n-1].To, r.From) && !isBound(r.From, bounds) {
+			merged[n-1].To = r.To
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged, nil
+}
+
+func isBound(v types.Value, bounds []types.Value) bool {
+	for _, bound := range bounds {
+		if value.Equal(v, bound) {
+			return true
+		}
+	}
+	return false
+}
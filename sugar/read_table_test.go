@@ -0,0 +1,241 @@
+package sugar
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+// partitionedClient is a table.Client with table of uint64 keys from 0 to keys-1
+// and partitions which bounds are changed by test
+type partitionedClient struct {
+	table.Client
+
+	mu     sync.Mutex
+	bounds []uint64
+}
+
+func (c *partitionedClient) setBounds(bounds ...uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bounds = bounds
+}
+
+func (c *partitionedClient) Do(ctx context.Context, op table.Operation, opts ...table.Option) error {
+	return op(ctx, partitionedSession{c: c})
+}
+
+type partitionedSession struct {
+	table.Session
+
+	c *partitionedClient
+}
+
+func (s partitionedSession) DescribeTable(
+	ctx context.Context, path string, opts ...options.DescribeTableOption,
+) (desc options.Description, err error) {
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+	desc.Name = path
+	desc.KeyRanges = make([]options.KeyRange, len(s.c.bounds)+1)
+	for i, b := range s.c.bounds {
+		bound := types.TupleValue(types.OptionalValue(types.Uint64Value(b)))
+		desc.KeyRanges[i].To = bound
+		desc.KeyRanges[i+1].From = bound
+	}
+	return desc, nil
+}
+
+func (s partitionedSession) StreamReadTable(
+	ctx context.Context, path string, opts ...options.ReadTableOption,
+) (result.StreamResult, error) {
+	return partitionedResult{}, nil
+}
+
+type partitionedResult struct {
+	result.StreamResult
+}
+
+func (partitionedResult) Close() error {
+	return nil
+}
+
+// keysOfRange returns keys of table in range r
+func keysOfRange(t *testing.T, r options.KeyRange, keys uint64) (from, to uint64) {
+	bound := func(v types.Value) uint64 {
+		items, err := types.TupleItems(v)
+		require.NoError(t, err)
+		var key uint64
+		require.NoError(t, types.CastTo(items[0], &key))
+		return key
+	}
+	from, to = 0, keys
+	if r.From != nil {
+		from = bound(r.From)
+	}
+	if r.To != nil {
+		to = bound(r.To)
+	}
+	return from, to
+}
+
+func TestReadTablePartitioned(t *testing.T) {
+	const keys = 1000
+	t.Run("Static", func(t *testing.T) {
+		c := &partitionedClient{}
+		c.setBounds(100, 200, 300, 400, 500, 600, 700, 800, 900)
+		var (
+			mu      sync.Mutex
+			covered = make([]int, keys)
+			ranges  int
+		)
+		err := ReadTablePartitioned(context.Background(), c, "/local/series",
+			func(ctx context.Context, r options.KeyRange, res result.StreamResult) error {
+				from, to := keysOfRange(t, r, keys)
+				mu.Lock()
+				defer mu.Unlock()
+				ranges++
+				for k := from; k < to; k++ {
+					covered[k]++
+				}
+				return nil
+			},
+			WithPartitionedReadWorkers(3),
+		)
+		require.NoError(t, err)
+		require.Equal(t, 10, ranges)
+		for k, n := range covered {
+			require.Equal(t, 1, n, k)
+		}
+	})
+	t.Run("Rebalance", func(t *testing.T) {
+		c := &partitionedClient{}
+		c.setBounds(250, 500, 750)
+		var (
+			mu         sync.Mutex
+			covered    = make([]int, keys)
+			read       []options.KeyRange
+			rebalances []trace.TableReadTablePartitionedRebalanceInfo
+			rebalanced = make(chan struct{})
+		)
+		err := ReadTablePartitioned(context.Background(), c, "/local/series",
+			func(ctx context.Context, r options.KeyRange, res result.StreamResult) error {
+				from, to := keysOfRange(t, r, keys)
+				mu.Lock()
+				started := len(read)
+				read = append(read, r)
+				mu.Unlock()
+				if started == 0 {
+					// partitions are split and merged while the first ranges are read
+					c.setBounds(100, 200, 300, 400, 600, 700, 800, 900)
+				}
+				if started < 2 {
+					select {
+					case <-rebalanced:
+					case <-time.After(10 * time.Second):
+						return errors.New("no rebalancing")
+					}
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				for k := from; k < to; k++ {
+					covered[k]++
+				}
+				return nil
+			},
+			WithPartitionedReadWorkers(2),
+			WithPartitionedReadDescribeInterval(time.Millisecond),
+			WithPartitionedReadTrace(trace.Table{
+				OnReadTablePartitionedRebalance: func(info trace.TableReadTablePartitionedRebalanceInfo) {
+					mu.Lock()
+					defer mu.Unlock()
+					rebalances = append(rebalances, info)
+					if len(rebalances) == 1 {
+						close(rebalanced)
+					}
+				},
+			}),
+		)
+		require.NoError(t, err)
+		for k, n := range covered {
+			require.Equal(t, 1, n, k)
+		}
+		require.Equal(t, []trace.TableReadTablePartitionedRebalanceInfo{{
+			Path:       "/local/series",
+			Partitions: 9,
+			InProgress: 2,
+			Pending:    2,
+			Rebalanced: 5,
+		}}, rebalances)
+
+		// ranges which are read before rebalancing are not changed,
+		// not started ranges [500,750) and [750,+inf) are split by 600, 700, 800, 900
+		// and merged by 750 which is not a bound anymore
+		require.Len(t, read, 7)
+		var bounds [][2]uint64
+		for _, r := range read {
+			from, to := keysOfRange(t, r, keys)
+			bounds = append(bounds, [2]uint64{from, to})
+		}
+		require.ElementsMatch(t, [][2]uint64{{0, 250}, {250, 500}}, bounds[:2])
+		require.Equal(t, [][2]uint64{{500, 600}, {600, 700}, {700, 800}, {800, 900}, {900, keys}}, bounds[2:])
+	})
+	t.Run("Error", func(t *testing.T) {
+		c := &partitionedClient{}
+		c.setBounds(100, 200, 300)
+		testErr := errors.New("test")
+		var (
+			mu    sync.Mutex
+			calls int
+		)
+		err := ReadTablePartitioned(context.Background(), c, "/local/series",
+			func(ctx context.Context, r options.KeyRange, res result.StreamResult) error {
+				mu.Lock()
+				defer mu.Unlock()
+				calls++
+				return testErr
+			},
+			WithPartitionedReadWorkers(1),
+		)
+		require.ErrorIs(t, err, testErr)
+		require.Equal(t, 1, calls)
+	})
+}
+
+func TestRebalanceKeyRanges(t *testing.T) {
+	key := func(k uint64) types.Value {
+		return types.TupleValue(types.OptionalValue(types.Uint64Value(k)))
+	}
+	ranges, err := rebalanceKeyRanges(
+		[]options.KeyRange{
+			{From: key(10), To: key(20)},
+			{From: key(20), To: key(30)},
+			{From: key(40), To: nil},
+		},
+		[]types.Value{key(5), key(15), key(45), key(50)},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []options.KeyRange{
+		{From: key(10), To: key(15)},
+		{From: key(15), To: key(30)},
+		{From: key(40), To: key(45)},
+		{From: key(45), To: key(50)},
+		{From: key(50), To: nil},
+	}, ranges)
+
+	_, err = rebalanceKeyRanges(
+		[]options.KeyRange{{From: key(10), To: nil}},
+		[]types.Value{types.TupleValue(types.OptionalValue(types.UUIDValue([16]byte{})))},
+	)
+	require.Error(t, err)
+}
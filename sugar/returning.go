@@ -0,0 +1,115 @@
+package sugar
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result/named"
+)
+
+// ExecReturning executes single DML statement with RETURNING clause (such as
+// `INSERT INTO users (name) VALUES ($name) RETURNING id, name`) and appends
+// returned rows to dst.
+//
+// dst must be a pointer to slice of structs (or pointers to structs). Column of
+// returned row is scanned into exported field of struct with the same name
+// or with name defined by tag `ydb:"name"`; fields with tag `ydb:"-"` are skipped.
+// Tags are parsed the same way as by types.ValueFromGo (so tag options such
+// as `secret` are allowed and don't change name of column).
+// Pointer fields take NULL values as nil, other fields take NULL values as zero values.
+//
+// ExecReturning returns error if query returns not exactly one result set
+// (multi-statement queries must be executed with Querier.Execute).
+func ExecReturning(
+	ctx context.Context,
+	q table.Querier,
+	tx *table.TransactionControl,
+	query string,
+	params *table.QueryParameters,
+	dst interface{},
+	opts ...options.ExecuteDataQueryOption,
+) (_ table.Transaction, finalErr error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("destination %T is not a pointer to slice", dst))
+	}
+	rowType := rv.Elem().Type().Elem()
+	structType := rowType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("element %s of destination is not a struct", rowType))
+	}
+
+	txr, res, err := q.Execute(ctx, tx, query, params, opts...)
+	if err != nil {
+		return txr, xerrors.WithStackTrace(err)
+	}
+	defer func() {
+		if err := res.Close(); err != nil && finalErr == nil {
+			finalErr = xerrors.WithStackTrace(err)
+		}
+	}()
+
+	if n := res.ResultSetCount(); n != 1 {
+		return txr, xerrors.WithStackTrace(fmt.Errorf("query returns %d result sets instead of 1", n))
+	}
+	if err = res.NextResultSetErr(ctx); err != nil {
+		return txr, xerrors.WithStackTrace(err)
+	}
+
+	fields := value.GoStructFields(structType)
+	if err = checkReturningColumns(res.CurrentResultSet(), fields); err != nil {
+		return txr, xerrors.WithStackTrace(err)
+	}
+
+	rows := rv.Elem()
+	for res.NextRow() {
+		row := reflect.New(structType)
+		values := make([]named.Value, 0, len(fields))
+		for _, f := range fields {
+			field := row.Elem().Field(f.Index)
+			if field.Kind() == reflect.Ptr {
+				values = append(values, named.Optional(f.Name, field.Addr().Interface()))
+			} else {
+				values = append(values, named.OptionalWithDefault(f.Name, field.Addr().Interface()))
+			}
+		}
+		if err = res.ScanNamed(values...); err != nil {
+			return txr, xerrors.WithStackTrace(err)
+		}
+		if rowType.Kind() == reflect.Ptr {
+			rows = reflect.Append(rows, row)
+		} else {
+			rows = reflect.Append(rows, row.Elem())
+		}
+	}
+	if err = res.Err(); err != nil {
+		return txr, xerrors.WithStackTrace(err)
+	}
+	rv.Elem().Set(rows)
+
+	return txr, nil
+}
+
+// checkReturningColumns checks that result set has columns for all fields
+// (ScanNamed panics if count of columns is less than count of values)
+func checkReturningColumns(set result.Set, fields []value.GoStructField) error {
+	columns := make(map[string]struct{}, set.ColumnCount())
+	set.Columns(func(c options.Column) {
+		columns[c.Name] = struct{}{}
+	})
+	for _, f := range fields {
+		if _, has := columns[f.Name]; !has {
+			return fmt.Errorf("result set has no column '%s'", f.Name)
+		}
+	}
+	return nil
+}
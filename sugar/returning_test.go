@@ -0,0 +1,145 @@
+package sugar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/table/scanner"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+)
+
+// returningQuerier is a table.Querier which returns recorded result sets of query
+type returningQuerier struct {
+	table.Querier
+
+	sets []*Ydb.ResultSet
+}
+
+func (q *returningQuerier) Execute(
+	ctx context.Context,
+	tx *table.TransactionControl,
+	query string,
+	params *table.QueryParameters,
+	opts ...options.ExecuteDataQueryOption,
+) (table.Transaction, result.Result, error) {
+	return nil, scanner.NewUnary(q.sets, nil), nil
+}
+
+var (
+	returningUint64Type   = &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_UINT64}}
+	returningOptionalText = &Ydb.Type{Type: &Ydb.Type_OptionalType{OptionalType: &Ydb.OptionalType{
+		Item: &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_UTF8}},
+	}}}
+)
+
+// returningResultSet is a recorded result set of `INSERT ... RETURNING id, name`
+func returningResultSet(names ...*string) *Ydb.ResultSet {
+	set := &Ydb.ResultSet{
+		Columns: []*Ydb.Column{
+			{Name: "id", Type: returningUint64Type},
+			{Name: "name", Type: returningOptionalText},
+		},
+	}
+	for i, name := range names {
+		row := &Ydb.Value{
+			Items: []*Ydb.Value{
+				{Value: &Ydb.Value_Uint64Value{Uint64Value: uint64(i + 1)}},
+				{Value: &Ydb.Value_NullFlagValue{}},
+			},
+		}
+		if name != nil {
+			row.Items[1] = &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: *name}}
+		}
+		set.Rows = append(set.Rows, row)
+	}
+	return set
+}
+
+func TestExecReturning(t *testing.T) {
+	type user struct {
+		ID       uint64  `ydb:"id"`
+		Name     *string `ydb:"name"`
+		Password string  `ydb:"-"`
+	}
+	a, b := "a", "b"
+	ctx := context.Background()
+
+	t.Run("SingleRow", func(t *testing.T) {
+		q := &returningQuerier{sets: []*Ydb.ResultSet{returningResultSet(&a)}}
+		var users []user
+		_, err := ExecReturning(ctx, q, table.DefaultTxControl(),
+			"INSERT INTO users (name) VALUES ($name) RETURNING id, name;", nil, &users,
+		)
+		require.NoError(t, err)
+		require.Equal(t, []user{{ID: 1, Name: &a}}, users)
+	})
+	t.Run("MultipleRows", func(t *testing.T) {
+		q := &returningQuerier{sets: []*Ydb.ResultSet{returningResultSet(&a, nil, &b)}}
+		users := []*user{{ID: 0}}
+		_, err := ExecReturning(ctx, q, table.DefaultTxControl(),
+			"INSERT INTO users (name) SELECT name FROM AS_TABLE($users) RETURNING id, name;", nil, &users,
+		)
+		require.NoError(t, err)
+		require.Equal(t, []*user{{ID: 0}, {ID: 1, Name: &a}, {ID: 2}, {ID: 3, Name: &b}}, users)
+	})
+	t.Run("NotNullableField", func(t *testing.T) {
+		q := &returningQuerier{sets: []*Ydb.ResultSet{returningResultSet(&a, nil)}}
+		var users []struct {
+			ID   uint64 `ydb:"id"`
+			Name string `ydb:"name"`
+		}
+		_, err := ExecReturning(ctx, q, table.DefaultTxControl(), "", nil, &users)
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		require.Equal(t, "a", users[0].Name)
+		require.Equal(t, "", users[1].Name)
+	})
+	t.Run("TagOptions", func(t *testing.T) {
+		q := &returningQuerier{sets: []*Ydb.ResultSet{returningResultSet(&a)}}
+		var users []struct {
+			ID   uint64 `ydb:"id"`
+			Name string `ydb:"name,secret"`
+		}
+		_, err := ExecReturning(ctx, q, table.DefaultTxControl(), "", nil, &users)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		require.Equal(t, "a", users[0].Name)
+	})
+	t.Run("NoRows", func(t *testing.T) {
+		q := &returningQuerier{sets: []*Ydb.ResultSet{returningResultSet()}}
+		var users []user
+		_, err := ExecReturning(ctx, q, table.DefaultTxControl(), "", nil, &users)
+		require.NoError(t, err)
+		require.Empty(t, users)
+	})
+	t.Run("MultipleResultSets", func(t *testing.T) {
+		q := &returningQuerier{sets: []*Ydb.ResultSet{returningResultSet(&a), returningResultSet(&b)}}
+		var users []user
+		_, err := ExecReturning(ctx, q, table.DefaultTxControl(), "", nil, &users)
+		require.ErrorContains(t, err, "query returns 2 result sets instead of 1")
+		require.Empty(t, users)
+	})
+	t.Run("MissingColumn", func(t *testing.T) {
+		q := &returningQuerier{sets: []*Ydb.ResultSet{returningResultSet(&a)}}
+		var users []struct {
+			ID    uint64 `ydb:"id"`
+			Email string `ydb:"email"`
+		}
+		_, err := ExecReturning(ctx, q, table.DefaultTxControl(), "", nil, &users)
+		require.ErrorContains(t, err, "result set has no column 'email'")
+	})
+	t.Run("Destination", func(t *testing.T) {
+		q := &returningQuerier{}
+		var users []user
+		_, err := ExecReturning(ctx, q, table.DefaultTxControl(), "", nil, users)
+		require.ErrorContains(t, err, "is not a pointer to slice")
+		var ids []uint64
+		_, err = ExecReturning(ctx, q, table.DefaultTxControl(), "", nil, &ids)
+		require.ErrorContains(t, err, "is not a struct")
+	})
+}
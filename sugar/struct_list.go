@@ -0,0 +1,74 @@
+package sugar
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/bind"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// ZipStructList makes List<Struct<...>> value from columns of go values (such as []int64 of ids
+// and []string of names) without transposing of columns into rows: i-th struct of list consists
+// of i-th values of columns. Struct has field for each entry of fields with the same name.
+//
+// Each column must be a slice (or array) and all columns must have the same length.
+// Values of columns are converted as query args of database/sql driver (for example, *int64
+// to Optional<Int64>). Note that []byte column is a column of Uint8 values, use [][]byte
+// for column of String values.
+func ZipStructList(fields map[string]interface{}) (types.Value, error) {
+	if len(fields) == 0 {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("no fields of struct list"))
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		structFields = make([]value.StructField, len(names))
+		columns      = make([][]value.Value, len(names))
+	)
+	for j, name := range names {
+		t, column, err := zipColumn(name, fields[name])
+		if err != nil {
+			return nil, xerrors.WithStackTrace(err)
+		}
+		structFields[j] = value.StructField{Name: name, T: t}
+		columns[j] = column
+	}
+	v, err := value.StructListValue(structFields, columns)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
+// zipColumn converts column of go values to type and values of field of struct list
+func zipColumn(name string, column interface{}) (types.Type, []value.Value, error) {
+	rv := reflect.ValueOf(column)
+	if kind := rv.Kind(); kind != reflect.Slice && kind != reflect.Array {
+		return nil, nil, fmt.Errorf("field %q: %T is not a slice", name, column)
+	}
+	values := make([]value.Value, rv.Len())
+	for i := range values {
+		v, err := bind.ToValue(rv.Index(i).Interface())
+		if err != nil {
+			return nil, nil, fmt.Errorf("field %q, value %d: %w", name, i, err)
+		}
+		values[i] = v
+	}
+	if len(values) > 0 {
+		return values[0].Type(), values, nil
+	}
+	// type of empty column is a type of zero value of elements
+	v, err := bind.ToValue(reflect.Zero(rv.Type().Elem()).Interface())
+	if err != nil {
+		return nil, nil, fmt.Errorf("field %q: %w", name, err)
+	}
+	return v.Type(), values, nil
+}
@@ -0,0 +1,131 @@
+package sugar
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+func TestZipStructList(t *testing.T) {
+	name := "b"
+	for _, tt := range []struct {
+		name   string
+		fields map[string]interface{}
+		yql    string
+		typ    string
+		err    string
+	}{
+		{
+			name: "Columns",
+			fields: map[string]interface{}{
+				"name": []string{"a", "b"},
+				"id":   []int64{1, 2},
+			},
+			yql: "[<|`id`:1l,`name`:\"a\"u|>,<|`id`:2l,`name`:\"b\"u|>]",
+			typ: "List<Struct<'id':Int64,'name':Utf8>>",
+		},
+		{
+			name: "Nullable",
+			fields: map[string]interface{}{
+				"name": []*string{nil, &name},
+				"id":   [2]uint64{1, 2},
+			},
+			yql: "[<|`id`:1ul,`name`:Nothing(Optional<Utf8>)|>,<|`id`:2ul,`name`:Just(\"b\"u)|>]",
+			typ: "List<Struct<'id':Uint64,'name':Optional<Utf8>>>",
+		},
+		{
+			name: "Empty",
+			fields: map[string]interface{}{
+				"name": []*string{},
+				"id":   []uint64(nil),
+			},
+			yql: `[]`,
+			typ: "List<Struct<'id':Uint64,'name':Optional<Utf8>>>",
+		},
+		{
+			name:   "NoFields",
+			fields: map[string]interface{}{},
+			err:    "no fields of struct list",
+		},
+		{
+			name: "DifferentLengths",
+			fields: map[string]interface{}{
+				"name": []string{"a"},
+				"id":   []int64{1, 2},
+			},
+			err: `field "name" has 1 values, field "id" has 2 values`,
+		},
+		{
+			name: "NotSlice",
+			fields: map[string]interface{}{
+				"id": int64(1),
+			},
+			err: `field "id": int64 is not a slice`,
+		},
+		{
+			name: "UnsupportedType",
+			fields: map[string]interface{}{
+				"id": []struct{}{{}},
+			},
+			err: `field "id", value 0: struct {}: unsupported type`,
+		},
+		{
+			name: "DifferentTypes",
+			fields: map[string]interface{}{
+				"id": []interface{}{int64(1), "2"},
+			},
+			err: `value 1 of field "id" has type Utf8 instead of Int64`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := ZipStructList(tt.fields)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.yql, v.Yql())
+			require.Equal(t, tt.typ, v.Type().Yql())
+		})
+	}
+}
+
+func BenchmarkZipStructList(b *testing.B) {
+	const rows = 1000
+	var (
+		ids   = make([]int64, rows)
+		names = make([]string, rows)
+	)
+	for i := range ids {
+		ids[i] = int64(i)
+		names[i] = strconv.Itoa(i)
+	}
+	b.Run("Columns", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := ZipStructList(map[string]interface{}{
+				"id":   ids,
+				"name": names,
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Rows", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			items := make([]types.Value, rows)
+			for j := range items {
+				items[j] = types.StructValue(
+					types.StructFieldValue("id", types.Int64Value(ids[j])),
+					types.StructFieldValue("name", types.TextValue(names[j])),
+				)
+			}
+			_ = types.ListValue(items...)
+		}
+	})
+}
@@ -0,0 +1,304 @@
+// Package cdc decodes records of table changefeeds in JSON format into typed values.
+//
+// Record of changefeed is a JSON object such as
+//
+//	{"key":[1,"one"],"update":{"value":"foo"},"ts":[1670792400000,562949953607163]}
+//
+// with fields depending on mode of changefeed:
+//   - KEYS_ONLY: "key" and empty "update" or "erase";
+//   - UPDATES: "key" and "update" with changed columns or "erase";
+//   - NEW_IMAGE: "key" and "newImage" with all columns after change (without "newImage" if row is erased);
+//   - OLD_IMAGE: "key" and "oldImage" with all columns before change (without "oldImage" if row is inserted);
+//   - NEW_AND_OLD_IMAGES: "key", "newImage" and "oldImage".
+//
+// Changefeeds with virtual timestamps add "ts" field to records and emit resolved timestamps
+// records such as {"resolved":[1670792400000,562949953607163]}.
+package cdc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+var (
+	errMalformedRecord = errors.New("malformed changefeed record")
+	errUnknownColumn   = errors.New("unknown column")
+	errNoPrimaryKey    = errors.New("description of table has no primary key")
+)
+
+const (
+	fieldKey      = "key"
+	fieldUpdate   = "update"
+	fieldErase    = "erase"
+	fieldNewImage = "newImage"
+	fieldOldImage = "oldImage"
+	fieldTS       = "ts"
+	fieldResolved = "resolved"
+)
+
+// VirtualTimestamp is a virtual timestamp of change: step of plan and identifier of transaction
+type VirtualTimestamp struct {
+	Step uint64
+	TxID uint64
+}
+
+// Record is a decoded record of changefeed
+type Record struct {
+	// Key is a Tuple value with columns of primary key in order of primary key
+	Key types.Value
+
+	// Update contains changed columns (UPDATES mode), it is empty in KEYS_ONLY mode
+	// and nil if changefeed record has no update
+	Update map[string]types.Value
+
+	// Erase reports whether row was erased. In NEW_IMAGE and NEW_AND_OLD_IMAGES modes
+	// (see WithMode) erase is a record without new image
+	Erase bool
+
+	// NewImage contains columns of row after change (NEW_IMAGE and NEW_AND_OLD_IMAGES modes)
+	NewImage map[string]types.Value
+
+	// OldImage contains columns of row before change (OLD_IMAGE and NEW_AND_OLD_IMAGES modes)
+	OldImage map[string]types.Value
+
+	// VirtualTimestamp is a virtual timestamp of change or resolved timestamp,
+	// it is nil if changefeed has no virtual timestamps
+	VirtualTimestamp *VirtualTimestamp
+
+	// Resolved reports whether record is a resolved timestamp record: all changes
+	// with virtual timestamps less than VirtualTimestamp are already emitted.
+	// Resolved record has no key and columns
+	Resolved bool
+}
+
+// Columns returns changed columns of update or columns of new image of row
+func (r *Record) Columns() map[string]types.Value {
+	if r.Update != nil {
+		return r.Update
+	}
+	return r.NewImage
+}
+
+type Option func(p *Parser)
+
+// WithStrict makes parser strict: records with unknown fields, unknown columns or fields
+// which are not allowed in mode of changefeed (see WithMode) are rejected. Otherwise, unknown
+// fields and columns are skipped and times are parsed also from numbers of days (Date),
+// seconds (Datetime) and microseconds (Timestamp) since epoch
+func WithStrict(strict bool) Option {
+	return func(p *Parser) {
+		p.strict = strict
+	}
+}
+
+// WithMode sets mode of changefeed for checking of records in strict mode
+// and for detection of erases in image modes
+func WithMode(mode options.ChangefeedMode) Option {
+	return func(p *Parser) {
+		p.mode = mode
+	}
+}
+
+// Parser decodes records of changefeed of table with types of columns from description of table
+type Parser struct {
+	keyTypes []types.Type
+	columns  map[string]types.Type
+	mode     options.ChangefeedMode
+	strict   bool
+}
+
+// NewParser makes parser of records of changefeed of table with description desc
+// (such as result of table.Session.DescribeTable)
+func NewParser(desc options.Description, opts ...Option) (*Parser, error) {
+	p := &Parser{
+		columns: make(map[string]types.Type, len(desc.Columns)),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(p)
+		}
+	}
+	for _, c := range desc.Columns {
+		p.columns[c.Name] = c.Type
+	}
+	if len(desc.PrimaryKey) == 0 {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: '%s'", errNoPrimaryKey, desc.Name))
+	}
+	p.keyTypes = make([]types.Type, len(desc.PrimaryKey))
+	for i, name := range desc.PrimaryKey {
+		t, has := p.columns[name]
+		if !has {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w '%s' of primary key of '%s'", errUnknownColumn, name, desc.Name))
+		}
+		p.keyTypes[i] = t
+	}
+	return p, nil
+}
+
+// Parse decodes JSON record of changefeed
+func (p *Parser) Parse(data []byte) (*Record, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %v", errMalformedRecord, err))
+	}
+	if p.strict {
+		for name := range fields {
+			switch name {
+			case fieldKey, fieldUpdate, fieldErase, fieldNewImage, fieldOldImage, fieldTS, fieldResolved:
+			default:
+				return nil, xerrors.WithStackTrace(fmt.Errorf("%w: unknown field '%s'", errMalformedRecord, name))
+			}
+		}
+	}
+
+	if raw, has := fields[fieldResolved]; has {
+		if p.strict && len(fields) > 1 {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: resolved timestamp record with other fields", errMalformedRecord))
+		}
+		ts, err := parseVirtualTimestamp(fieldResolved, raw)
+		if err != nil {
+			return nil, err
+		}
+		return &Record{VirtualTimestamp: ts, Resolved: true}, nil
+	}
+
+	r := &Record{}
+	var err error
+	if r.Key, err = p.parseKey(fields[fieldKey]); err != nil {
+		return nil, err
+	}
+	if raw, has := fields[fieldTS]; has {
+		if r.VirtualTimestamp, err = parseVirtualTimestamp(fieldTS, raw); err != nil {
+			return nil, err
+		}
+	}
+	if raw, has := fields[fieldUpdate]; has {
+		if r.Update, err = p.parseColumns(fieldUpdate, raw); err != nil {
+			return nil, err
+		}
+	}
+	if raw, has := fields[fieldErase]; has {
+		if p.strict && !bytes.Equal(bytes.TrimSpace(raw), []byte("{}")) {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: erase is not an empty object", errMalformedRecord))
+		}
+		r.Erase = true
+	}
+	if raw, has := fields[fieldNewImage]; has {
+		if r.NewImage, err = p.parseColumns(fieldNewImage, raw); err != nil {
+			return nil, err
+		}
+	}
+	if raw, has := fields[fieldOldImage]; has {
+		if r.OldImage, err = p.parseColumns(fieldOldImage, raw); err != nil {
+			return nil, err
+		}
+	}
+	switch p.mode {
+	case options.ChangefeedModeNewImage, options.ChangefeedModeNewAndOldImages:
+		if r.NewImage == nil {
+			r.Erase = true
+		}
+	}
+	if p.strict {
+		if err := p.checkMode(r); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// checkMode checks that record has fields which are allowed in mode of changefeed
+func (p *Parser) checkMode(r *Record) error {
+	var (
+		hasUpdate = r.Update != nil
+		hasErase  = r.Update == nil && r.Erase
+		allowed   bool
+	)
+	if hasUpdate && r.Erase {
+		return xerrors.WithStackTrace(fmt.Errorf("%w: both update and erase", errMalformedRecord))
+	}
+	switch p.mode {
+	case options.ChangefeedModeKeysOnly:
+		allowed = (hasUpdate && len(r.Update) == 0 || hasErase) && r.NewImage == nil && r.OldImage == nil
+	case options.ChangefeedModeUpdates:
+		allowed = (hasUpdate || hasErase) && r.NewImage == nil && r.OldImage == nil
+	case options.ChangefeedModeNewImage:
+		allowed = !hasUpdate && r.OldImage == nil
+	case options.ChangefeedModeOldImage:
+		allowed = !hasUpdate && !r.Erase && r.NewImage == nil
+	case options.ChangefeedModeNewAndOldImages:
+		allowed = !hasUpdate && (r.NewImage != nil || r.OldImage != nil)
+	default:
+		allowed = true
+	}
+	if !allowed {
+		return xerrors.WithStackTrace(fmt.Errorf("%w: fields of record are not allowed in mode %d",
+			errMalformedRecord, p.mode,
+		))
+	}
+	return nil
+}
+
+func (p *Parser) parseKey(raw json.RawMessage) (types.Value, error) {
+	if raw == nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: no key", errMalformedRecord))
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: key: %v", errMalformedRecord, err))
+	}
+	if len(items) != len(p.keyTypes) {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: key of %d columns instead of %d",
+			errMalformedRecord, len(items), len(p.keyTypes),
+		))
+	}
+	values := make([]types.Value, len(items))
+	for i := range items {
+		v, err := p.parseValue(p.keyTypes[i], items[i])
+		if err != nil {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("key column #%d: %w", i, err))
+		}
+		values[i] = v
+	}
+	return value.TupleValue(values...), nil
+}
+
+func (p *Parser) parseColumns(field string, raw json.RawMessage) (map[string]types.Value, error) {
+	var columns map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &columns); err != nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s: %v", errMalformedRecord, field, err))
+	}
+	values := make(map[string]types.Value, len(columns))
+	for name, raw := range columns {
+		t, has := p.columns[name]
+		if !has {
+			if p.strict {
+				return nil, xerrors.WithStackTrace(fmt.Errorf("%w '%s' in %s", errUnknownColumn, name, field))
+			}
+			continue
+		}
+		v, err := p.parseValue(t, raw)
+		if err != nil {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("column '%s': %w", name, err))
+		}
+		values[name] = v
+	}
+	return values, nil
+}
+
+func parseVirtualTimestamp(field string, raw json.RawMessage) (*VirtualTimestamp, error) {
+	var ts []uint64
+	if err := json.Unmarshal(raw, &ts); err != nil || len(ts) != 2 {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s is not a pair of step and tx id: %s",
+			errMalformedRecord, field, raw,
+		))
+	}
+	return &VirtualTimestamp{Step: ts[0], TxID: ts[1]}, nil
+}
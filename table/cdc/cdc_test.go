@@ -0,0 +1,266 @@
+package cdc
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+var testDescription = options.Description{
+	Name: "series",
+	Columns: []options.Column{
+		{Name: "id", Type: types.TypeUint64},
+		{Name: "name", Type: types.TypeText},
+		{Name: "value", Type: types.Optional(types.TypeText)},
+		{Name: "doc", Type: types.Optional(types.TypeJSON)},
+		{Name: "day", Type: types.Optional(types.TypeDate)},
+		{Name: "ttl", Type: types.Optional(types.TypeInterval)},
+		{Name: "at", Type: types.Optional(types.TypeTimestamp)},
+	},
+	PrimaryKey: []string{"id", "name"},
+}
+
+type expectedRecord struct {
+	key      string
+	update   map[string]string
+	erase    bool
+	newImage map[string]string
+	oldImage map[string]string
+	ts       *VirtualTimestamp
+	resolved bool
+}
+
+var (
+	testTS       = &VirtualTimestamp{Step: 1670792400000, TxID: 562949953607163}
+	testResolved = expectedRecord{ts: &VirtualTimestamp{Step: 1670792401000}, resolved: true}
+	testImage1   = map[string]string{
+		"value": `Just("foo"u)`,
+		"doc":   `Just(Json("{\"a\":[1,2]}"))`,
+		"day":   `Just(Date("2023-05-17"))`,
+		"ttl":   `Nothing(Optional<Interval>)`,
+		"at":    `Nothing(Optional<Timestamp>)`,
+	}
+	testImage2 = map[string]string{
+		"value": `Just("bar"u)`,
+		"doc":   `Nothing(Optional<Json>)`,
+		"day":   `Nothing(Optional<Date>)`,
+		"ttl":   `Just(Interval("-PT0.000001S"))`,
+		"at":    `Nothing(Optional<Timestamp>)`,
+	}
+)
+
+func TestParseFixtures(t *testing.T) {
+	for _, tt := range []struct {
+		file    string
+		mode    options.ChangefeedMode
+		records []expectedRecord
+	}{
+		{
+			file: "keys_only.jsonl",
+			mode: options.ChangefeedModeKeysOnly,
+			records: []expectedRecord{
+				{key: `(1ul,"one"u)`, update: map[string]string{}},
+				{key: `(2ul,"two"u)`, erase: true},
+				{key: `(3ul,"three"u)`, update: map[string]string{}, ts: testTS},
+				testResolved,
+			},
+		},
+		{
+			file: "updates.jsonl",
+			mode: options.ChangefeedModeUpdates,
+			records: []expectedRecord{
+				{key: `(1ul,"one"u)`, update: map[string]string{
+					"value": `Just("foo"u)`,
+					"doc":   `Just(Json("{\"a\":[1,2]}"))`,
+					"day":   `Just(Date("2023-05-17"))`,
+				}},
+				{key: `(1ul,"one"u)`, update: map[string]string{
+					"value": `Nothing(Optional<Utf8>)`,
+					"ttl":   `Just(Interval("PT1H0.000002S"))`,
+					"at":    `Just(Timestamp("2023-05-17T10:20:30.123456Z"))`,
+				}},
+				{key: `(2ul,"two"u)`, erase: true},
+				{key: `(3ul,"three"u)`, update: map[string]string{"value": `Just("bar"u)`}, ts: testTS},
+				testResolved,
+			},
+		},
+		{
+			file: "new_image.jsonl",
+			mode: options.ChangefeedModeNewImage,
+			records: []expectedRecord{
+				{key: `(1ul,"one"u)`, newImage: testImage1},
+				{key: `(2ul,"two"u)`, erase: true},
+				{key: `(3ul,"three"u)`, newImage: map[string]string{
+					"value": `Just("bar"u)`,
+					"doc":   `Nothing(Optional<Json>)`,
+					"day":   `Nothing(Optional<Date>)`,
+					"ttl":   `Just(Interval("-PT0.000001S"))`,
+					"at":    `Just(Timestamp("2023-05-17T10:20:30.123456Z"))`,
+				}, ts: testTS},
+				testResolved,
+			},
+		},
+		{
+			file: "old_image.jsonl",
+			mode: options.ChangefeedModeOldImage,
+			records: []expectedRecord{
+				{key: `(1ul,"one"u)`},
+				{key: `(1ul,"one"u)`, oldImage: testImage1},
+				{key: `(3ul,"three"u)`, oldImage: map[string]string{
+					"value": `Just("bar"u)`,
+					"doc":   `Nothing(Optional<Json>)`,
+					"day":   `Nothing(Optional<Date>)`,
+					"ttl":   `Just(Interval("-PT0.000001S"))`,
+					"at":    `Just(Timestamp("2023-05-17T10:20:30.123456Z"))`,
+				}, ts: testTS},
+				testResolved,
+			},
+		},
+		{
+			file: "new_and_old_images.jsonl",
+			mode: options.ChangefeedModeNewAndOldImages,
+			records: []expectedRecord{
+				{key: `(1ul,"one"u)`, newImage: testImage1},
+				{key: `(1ul,"one"u)`, oldImage: testImage1, newImage: testImage2},
+				{key: `(1ul,"one"u)`, oldImage: testImage2, erase: true, ts: testTS},
+				testResolved,
+			},
+		},
+	} {
+		t.Run(tt.file, func(t *testing.T) {
+			lines := readLines(t, filepath.Join("testdata", tt.file))
+			require.Len(t, lines, len(tt.records))
+			for _, strict := range []bool{false, true} {
+				p, err := NewParser(testDescription, WithMode(tt.mode), WithStrict(strict))
+				require.NoError(t, err)
+				for i, line := range lines {
+					r, err := p.Parse(line)
+					require.NoError(t, err, string(line))
+					checkRecord(t, tt.records[i], r)
+				}
+			}
+		})
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		mode options.ChangefeedMode
+		data string
+	}{
+		{"UnknownField", options.ChangefeedModeUpdates, `{"key":[1,"one"],"update":{},"foo":1}`},
+		{"UnknownColumn", options.ChangefeedModeUpdates, `{"key":[1,"one"],"update":{"foo":1}}`},
+		{"UpdateAndErase", options.ChangefeedModeUpdates, `{"key":[1,"one"],"update":{},"erase":{}}`},
+		{"NoUpdate", options.ChangefeedModeUpdates, `{"key":[1,"one"]}`},
+		{"KeysOnlyColumns", options.ChangefeedModeKeysOnly, `{"key":[1,"one"],"update":{"value":"foo"}}`},
+		{"UpdatesImage", options.ChangefeedModeUpdates, `{"key":[1,"one"],"newImage":{}}`},
+		{"NewImageOldImage", options.ChangefeedModeNewImage, `{"key":[1,"one"],"oldImage":{}}`},
+		{"OldImageErase", options.ChangefeedModeOldImage, `{"key":[1,"one"],"erase":{}}`},
+		{"NewAndOldImagesEmpty", options.ChangefeedModeNewAndOldImages, `{"key":[1,"one"]}`},
+		{"ResolvedWithKey", options.ChangefeedModeUpdates, `{"key":[1,"one"],"resolved":[1,2]}`},
+		{"NotEmptyErase", options.ChangefeedModeUpdates, `{"key":[1,"one"],"erase":{"value":"foo"}}`},
+		{"NumericDate", options.ChangefeedModeUpdates, `{"key":[1,"one"],"update":{"day":19494}}`},
+		{"NumericTimestamp", options.ChangefeedModeUpdates, `{"key":[1,"one"],"update":{"at":1684318830123456}}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewParser(testDescription, WithMode(tt.mode), WithStrict(true))
+			require.NoError(t, err)
+			_, err = p.Parse([]byte(tt.data))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	p, err := NewParser(testDescription)
+	require.NoError(t, err)
+	r, err := p.Parse([]byte(`{"key":[1,"one"],"update":{"day":19494,"at":1684318830123456,"foo":1},"foo":1}`))
+	require.NoError(t, err)
+	checkRecord(t, expectedRecord{key: `(1ul,"one"u)`, update: map[string]string{
+		"day": `Just(Date("2023-05-17"))`,
+		"at":  `Just(Timestamp("2023-05-17T10:20:30.123456Z"))`,
+	}}, r)
+	require.Equal(t, r.Update, r.Columns())
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		data string
+	}{
+		{"Malformed", `{"key":`},
+		{"NoKey", `{"update":{}}`},
+		{"KeyLength", `{"key":[1],"update":{}}`},
+		{"KeyType", `{"key":[true,"one"],"update":{}}`},
+		{"ColumnType", `{"key":[1,"one"],"update":{"value":1}}`},
+		{"Date", `{"key":[1,"one"],"update":{"day":"2023-05-17T10:00:00.000000Z"}}`},
+		{"Interval", `{"key":[1,"one"],"update":{"ttl":1.5}}`},
+		{"VirtualTimestamp", `{"key":[1,"one"],"update":{},"ts":[1]}`},
+		{"Resolved", `{"resolved":"1"}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewParser(testDescription)
+			require.NoError(t, err)
+			_, err = p.Parse([]byte(tt.data))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewParserErrors(t *testing.T) {
+	_, err := NewParser(options.Description{Name: "series", Columns: testDescription.Columns})
+	require.Error(t, err)
+	_, err = NewParser(options.Description{
+		Name:       "series",
+		Columns:    testDescription.Columns,
+		PrimaryKey: []string{"id", "foo"},
+	})
+	require.Error(t, err)
+}
+
+func checkRecord(t *testing.T, expected expectedRecord, r *Record) {
+	t.Helper()
+	require.Equal(t, expected.resolved, r.Resolved)
+	require.Equal(t, expected.ts, r.VirtualTimestamp)
+	require.Equal(t, expected.erase, r.Erase)
+	if expected.key == "" {
+		require.Nil(t, r.Key)
+	} else {
+		require.Equal(t, expected.key, r.Key.Yql())
+	}
+	checkColumns(t, expected.update, r.Update)
+	checkColumns(t, expected.newImage, r.NewImage)
+	checkColumns(t, expected.oldImage, r.OldImage)
+}
+
+func checkColumns(t *testing.T, expected map[string]string, columns map[string]types.Value) {
+	t.Helper()
+	if expected == nil {
+		require.Nil(t, columns)
+		return
+	}
+	actual := make(map[string]string, len(columns))
+	for name, v := range columns {
+		actual[name] = v.Yql()
+	}
+	require.Equal(t, expected, actual)
+}
+
+func readLines(t *testing.T, path string) (lines [][]byte) {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	require.NoError(t, scanner.Err())
+	return lines
+}
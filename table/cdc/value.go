@@ -0,0 +1,95 @@
+package cdc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// parseValue makes value of type t from JSON representation of changefeed.
+//
+// Changefeed representation is the same as representation of types.MarshalJSON except of:
+//   - Json and JsonDocument values are embedded into record as is (not as JSON strings);
+//   - Date values are timestamps at midnight such as "2023-05-17T00:00:00.000000Z";
+//   - Interval values are numbers of microseconds.
+func (p *Parser) parseValue(t types.Type, raw json.RawMessage) (types.Value, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %v", errMalformedRecord, err))
+	}
+	if v == nil {
+		return value.ValueFromJSON(t, v)
+	}
+	innerType := t
+	if isOptional, tt := types.IsOptional(t); isOptional {
+		innerType = tt
+	}
+	v, err := p.normalize(innerType, raw, v)
+	if err != nil {
+		return nil, err
+	}
+	return value.ValueFromJSON(t, v)
+}
+
+// normalize converts changefeed representation of value of type t into representation of types.MarshalJSON
+func (p *Parser) normalize(t types.Type, raw json.RawMessage, v interface{}) (interface{}, error) {
+	switch {
+	case types.Equal(t, types.TypeJSON), types.Equal(t, types.TypeJSONDocument):
+		var buffer bytes.Buffer
+		if err := json.Compact(&buffer, raw); err != nil {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %v", errMalformedRecord, err))
+		}
+		return buffer.String(), nil
+	case types.Equal(t, types.TypeDate):
+		switch vv := v.(type) {
+		case string:
+			if !strings.Contains(vv, "T") {
+				return vv, nil
+			}
+			tt, err := time.Parse(time.RFC3339Nano, vv)
+			if err != nil || !tt.Equal(tt.Truncate(24*time.Hour)) {
+				// value as is for error of decoding
+				return vv, nil //nolint:nilerr
+			}
+			return tt.UTC().Format(value.LayoutDate), nil
+		case json.Number:
+			if !p.strict {
+				days, err := vv.Int64()
+				if err == nil {
+					return time.Unix(days*int64(24*time.Hour/time.Second), 0).UTC().Format(value.LayoutDate), nil
+				}
+			}
+		}
+	case types.Equal(t, types.TypeDatetime):
+		if n, ok := v.(json.Number); ok && !p.strict {
+			seconds, err := n.Int64()
+			if err == nil {
+				return time.Unix(seconds, 0).UTC().Format(time.RFC3339), nil
+			}
+		}
+	case types.Equal(t, types.TypeTimestamp):
+		if n, ok := v.(json.Number); ok && !p.strict {
+			microseconds, err := n.Int64()
+			if err == nil {
+				return time.UnixMicro(microseconds).UTC().Format(time.RFC3339Nano), nil
+			}
+		}
+	case types.Equal(t, types.TypeInterval):
+		if n, ok := v.(json.Number); ok {
+			microseconds, err := n.Int64()
+			if err != nil {
+				return nil, xerrors.WithStackTrace(fmt.Errorf("%w: interval '%s': %v", errMalformedRecord, n, err))
+			}
+			return (time.Duration(microseconds) * time.Microsecond).String(), nil
+		}
+	}
+	return v, nil
+}
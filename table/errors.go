@@ -0,0 +1,27 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrServerSessionLimit reports that server limit of sessions of database is exceeded
+// and creation of new sessions is suspended for cooldown.
+// Remaining cooldown can be obtained with errors.As into *ServerSessionLimitError
+var ErrServerSessionLimit = errors.New("server session limit exceeded")
+
+// ServerSessionLimitError is an error of getting session from session pool while
+// creation of new sessions is suspended after exceeding of server limit of sessions
+type ServerSessionLimitError struct {
+	// Cooldown is a remaining time until creation of new sessions will be resumed
+	Cooldown time.Duration
+}
+
+func (e *ServerSessionLimitError) Error() string {
+	return fmt.Sprintf("%v (creation of sessions resumes in %v)", ErrServerSessionLimit, e.Cooldown)
+}
+
+func (e *ServerSessionLimitError) Unwrap() error {
+	return ErrServerSessionLimit
+}
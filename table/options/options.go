@@ -811,7 +811,10 @@ type (
 	ExecuteDataQueryDesc struct {
 		*Ydb_Table.ExecuteDataQueryRequest
 
-		IgnoreTruncated bool
+		IgnoreTruncated       bool
+		AccumulateErrorsLimit int
+		MissingColumnsAsZero  bool
+		CheckParameters       bool
 	}
 	ExecuteDataQueryOption interface {
 		ApplyExecuteDataQueryOption(d *ExecuteDataQueryDesc, a *allocator.Allocator) []grpc.CallOption
@@ -839,7 +842,8 @@ type (
 
 // WithKeepInCache manages keep-in-cache flag in query cache policy
 //
-// By default all data queries executes with keep-in-cache policy
+// By default keep-in-cache flag defined by driver option ydb.WithKeepInCache or
+// enabled only for data queries with parameters
 func WithKeepInCache(keepInCache bool) ExecuteDataQueryOption {
 	return withQueryCachePolicy(
 		withQueryCachePolicyKeepInCache(keepInCache),
@@ -883,6 +887,78 @@ func WithIgnoreTruncated() ExecuteDataQueryOption {
 	})
 }
 
+var (
+	_ ExecuteDataQueryOption = accumulateErrorsOption(0)
+	_ ExecuteScanQueryOption = accumulateErrorsOption(0)
+	_ ReadTableOption        = accumulateErrorsOption(0)
+)
+
+type accumulateErrorsOption int
+
+func (limit accumulateErrorsOption) ApplyExecuteDataQueryOption(
+	desc *ExecuteDataQueryDesc, a *allocator.Allocator,
+) []grpc.CallOption {
+	desc.AccumulateErrorsLimit = int(limit)
+	return nil
+}
+
+func (limit accumulateErrorsOption) ApplyExecuteScanQueryOption(desc *ExecuteScanQueryDesc) []grpc.CallOption {
+	return nil
+}
+
+func (limit accumulateErrorsOption) ApplyReadTableOption(desc *ReadTableDesc, a *allocator.Allocator) {
+}
+
+// AccumulateErrorsLimit returns limit of accumulated cells decoding errors.
+// Session enables accumulation of errors of streaming results with this option
+func (limit accumulateErrorsOption) AccumulateErrorsLimit() int {
+	return int(limit)
+}
+
+// WithAccumulateErrors enables accumulation of result cells decoding errors
+// of data queries, scan queries and stream read table.
+//
+// Cells with errors of decoding (or cast) of values are replaced with zero values and
+// scanning continues until count of errors in result set exceeds limit. Errors of
+// destination types and columns (such as scanning of Text column into *int) are not
+// accumulated and break result immediately. Accumulated errors of the current result set
+// are available with result.CellErrors, and result Err() summarizes errors of all result
+// sets with result.ErrCellErrors
+func WithAccumulateErrors(limit int) accumulateErrorsOption {
+	return accumulateErrorsOption(limit)
+}
+
+// WithMissingColumnsAsZero allows scanning of columns which are absent in result set.
+//
+// It is useful during rolling deploys when new code selects columns which are not
+// added to the table yet. Named scanning (result.ScanNamed) of absent column sets
+// destination to zero value (as NULL) instead of failing. Names of absent columns
+// are available with result.MissingColumns
+//
+// Option affects only results of table.Session.Execute (and transaction Execute).
+// Streaming results (scan queries and read table) fail on absent columns as before
+func WithMissingColumnsAsZero() ExecuteDataQueryOption {
+	return executeDataQueryOptionFunc(func(desc *ExecuteDataQueryDesc, a *allocator.Allocator) []grpc.CallOption {
+		desc.MissingColumnsAsZero = true
+		return nil
+	})
+}
+
+// WithQueryParametersCheck enables client-side check of query parameters against parameters
+// of query text: declared parameters (DECLARE statements) must be passed and passed parameters
+// must be declared (or referenced in query without DECLARE statements). Query with mismatched
+// parameters fails before sending with list of mismatches and suggestions of close names.
+//
+// Prepared statements check passed parameters against parameters declared in prepared query.
+//
+// Check is enabled for all data queries by driver option ydb.WithQueryParametersCheck
+func WithQueryParametersCheck() ExecuteDataQueryOption {
+	return executeDataQueryOptionFunc(func(desc *ExecuteDataQueryDesc, a *allocator.Allocator) []grpc.CallOption {
+		desc.CheckParameters = true
+		return nil
+	})
+}
+
 // WithQueryCachePolicyKeepInCache manages keep-in-cache policy
 //
 // Deprecated: data queries always executes with enabled keep-in-cache policy.
@@ -1012,6 +1088,7 @@ var (
 	_ ReadTableOption = readLessOption{}
 	_ ReadTableOption = readGreaterOption{}
 	_ ReadTableOption = readRowLimitOption(0)
+	_ ReadTableOption = readFromCheckpointOption("")
 )
 
 type (
@@ -1034,12 +1111,23 @@ type (
 	readLessOption           struct{ types.Value }
 	readGreaterOption        struct{ types.Value }
 	readRowLimitOption       uint64
+	readFromCheckpointOption string
 )
 
 func (n readRowLimitOption) ApplyReadTableOption(desc *ReadTableDesc, a *allocator.Allocator) {
 	desc.RowLimit = uint64(n)
 }
 
+func (c readFromCheckpointOption) ApplyReadTableOption(desc *ReadTableDesc, a *allocator.Allocator) {
+	desc.Ordered = true
+}
+
+// ReadTableCheckpoint returns checkpoint of stream position to start reading from.
+// Session enables checkpoints of result of read table with this option
+func (c readFromCheckpointOption) ReadTableCheckpoint() string {
+	return string(c)
+}
+
 func (x readGreaterOption) ApplyReadTableOption(desc *ReadTableDesc, a *allocator.Allocator) {
 	desc.initKeyRange()
 	desc.KeyRange.FromBound = &Ydb_Table.KeyRange_Greater{
@@ -1146,6 +1234,17 @@ func ReadRowLimit(n uint64) ReadTableOption {
 	return readRowLimitOption(n)
 }
 
+// ReadFromCheckpoint returns ReadTableOption which enables checkpoints of stream position
+// (see result.Checkpoint) and makes ReadTable read rows after checkpoint c.
+// Empty checkpoint means reading from beginning of table (or key range).
+//
+// Rows are read in order of primary key (as with ReadOrdered) for resuming without
+// gaps and duplicates. Checkpoint replaces lower bound of key range, so checkpoint
+// must be obtained from result of reading with the same key range
+func ReadFromCheckpoint(c string) ReadTableOption {
+	return readFromCheckpointOption(c)
+}
+
 func (d *ReadTableDesc) initKeyRange() {
 	if d.KeyRange == nil {
 		d.KeyRange = new(Ydb_Table.KeyRange)
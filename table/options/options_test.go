@@ -252,3 +252,29 @@ func TestAlterTableOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestReadTableOptions(t *testing.T) {
+	a := allocator.New()
+	defer a.Free()
+	{
+		req := Ydb_Table.ReadTableRequest{}
+		ReadRowLimit(10).ApplyReadTableOption((*ReadTableDesc)(&req), a)
+		ReadColumn("a").ApplyReadTableOption((*ReadTableDesc)(&req), a)
+		require.Equal(t, uint64(10), req.RowLimit)
+		require.Equal(t, []string{"a"}, req.Columns)
+	}
+	{
+		var desc ReadTableDesc
+		ReadGreater(types.Uint64Value(1)).ApplyReadTableOption(&desc, a)
+		require.NotNil(t, desc.KeyRange.GetGreater())
+	}
+	{
+		req := Ydb_Table.ReadTableRequest{}
+		opt := ReadFromCheckpoint("checkpoint")
+		opt.ApplyReadTableOption((*ReadTableDesc)(&req), a)
+		require.True(t, req.Ordered)
+		c, ok := opt.(interface{ ReadTableCheckpoint() string })
+		require.True(t, ok)
+		require.Equal(t, "checkpoint", c.ReadTableCheckpoint())
+	}
+}
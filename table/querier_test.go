@@ -0,0 +1,50 @@
+package table_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// deleteUser is an example of application code which depends only on table.Querier
+func deleteUser(ctx context.Context, q table.Querier, id uint64) error {
+	_, res, err := q.Execute(ctx, table.DefaultTxControl(),
+		"DECLARE $id AS Uint64; DELETE FROM users WHERE id = $id;",
+		table.NewQueryParameters(table.ValueParam("$id", types.Uint64Value(id))),
+	)
+	if err != nil {
+		return err
+	}
+	return res.Close()
+}
+
+func TestQuerierMock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	q := NewMockQuerier(ctrl)
+
+	errOverloaded := errors.New("overloaded")
+	q.EXPECT().
+		Execute(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context, _ *table.TransactionControl, query string, params *table.QueryParameters,
+			_ ...options.ExecuteDataQueryOption,
+		) (table.Transaction, result.Result, error) {
+			require.Contains(t, query, "DELETE FROM users")
+			require.Equal(t, 1, params.Count())
+			params.Each(func(name string, v types.Value) {
+				require.Equal(t, "$id", name)
+				require.Equal(t, "42ul", v.Yql())
+			})
+			return nil, nil, errOverloaded
+		})
+
+	require.ErrorIs(t, deleteUser(context.Background(), q, 42), errOverloaded)
+}
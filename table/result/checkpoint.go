@@ -0,0 +1,16 @@
+package result
+
+// Checkpoint returns opaque token of position of stream after last fully consumed
+// result set part (all rows of part were selected with NextRow)
+//
+// Checkpoint can be passed to options.ReadFromCheckpoint for reading of remaining
+// rows with next call of table.Session.StreamReadTable.
+// Checkpoint returns ErrCheckpointNotSupported if res does not provide checkpoints
+func Checkpoint(res BaseResult) (string, error) {
+	if r, has := res.(interface {
+		Checkpoint() (string, error)
+	}); has {
+		return r.Checkpoint()
+	}
+	return "", ErrCheckpointNotSupported
+}
@@ -0,0 +1,58 @@
+package result
+
+// Consistency is a consistency mode of transaction which result was read with
+type Consistency uint8
+
+const (
+	// ConsistencyUnknown means that consistency of transaction is not known
+	// (for example, for results of scan queries and read table requests)
+	ConsistencyUnknown = Consistency(iota)
+	ConsistencySerializableReadWrite
+	ConsistencyOnlineReadOnly
+	// ConsistencyOnlineReadOnlyInconsistent is an online read-only mode with allowed inconsistent reads
+	ConsistencyOnlineReadOnlyInconsistent
+	ConsistencyStaleReadOnly
+	ConsistencySnapshotReadOnly
+)
+
+func (c Consistency) String() string {
+	switch c {
+	case ConsistencySerializableReadWrite:
+		return "SerializableReadWrite"
+	case ConsistencyOnlineReadOnly:
+		return "OnlineReadOnly"
+	case ConsistencyOnlineReadOnlyInconsistent:
+		return "OnlineReadOnlyInconsistent"
+	case ConsistencyStaleReadOnly:
+		return "StaleReadOnly"
+	case ConsistencySnapshotReadOnly:
+		return "SnapshotReadOnly"
+	default:
+		return "Unknown"
+	}
+}
+
+// TxConsistency returns consistency mode of transaction which result was read with
+//
+// TxConsistency returns ConsistencyUnknown if res does not provide consistency of transaction
+func TxConsistency(res BaseResult) Consistency {
+	if r, has := res.(interface {
+		TxConsistency() Consistency
+	}); has {
+		return r.TxConsistency()
+	}
+	return ConsistencyUnknown
+}
+
+// TxID returns identifier of transaction which result was read with
+//
+// TxID returns empty string if res does not provide identifier of transaction
+// or server does not return it (for example, for auto-committed read-only transactions)
+func TxID(res BaseResult) string {
+	if r, has := res.(interface {
+		TxID() string
+	}); has {
+		return r.TxID()
+	}
+	return ""
+}
@@ -2,6 +2,69 @@ package result
 
 import (
 	"errors"
+	"fmt"
 )
 
 var ErrTruncated = errors.New("truncated result")
+
+// ErrResultTooLarge reports that result of data query exceeds limit of result size
+// (see ydb.WithResultSizeLimit). Large results must be read with streaming APIs
+// (such as table.Session.StreamExecuteScanQuery or table.Session.StreamReadTable)
+var ErrResultTooLarge = errors.New("result too large, use streaming APIs (scan query or read table)")
+
+// ErrCellErrors reports that some cells of result were decoded with errors
+// and replaced with zero values. Such errors are accumulated only if errors
+// accumulation enabled with options.WithAccumulateErrors. Details can be
+// obtained with CellErrors
+var ErrCellErrors = errors.New("cells decoded with errors")
+
+// ErrCheckpointNotSupported reports that result does not provide checkpoints of stream position.
+// Checkpoints are provided only by results of table.Session.StreamReadTable requested with
+// options.ReadFromCheckpoint. Results of scan queries do not support checkpoints
+var ErrCheckpointNotSupported = errors.New("checkpoint not supported")
+
+// CellError describes an error of decoding single cell of result set
+type CellError struct {
+	// ResultSet is an index of result set in result
+	ResultSet int
+	// Row is an index of row in result set
+	Row int
+	// Column is a name of column
+	Column string
+	// Err is an error of decoding cell
+	Err error
+}
+
+func (e CellError) Error() string {
+	return fmt.Sprintf("result set %d, row %d, column %q: %v", e.ResultSet, e.Row, e.Column, e.Err)
+}
+
+func (e CellError) Unwrap() error {
+	return e.Err
+}
+
+// CellErrors returns list of errors of cells decoding accumulated by result in the current result set.
+// List is reset on each next result set, so errors must be collected before moving to the next one
+//
+// Errors are accumulated only if result was requested with options.WithAccumulateErrors
+func CellErrors(res BaseResult) []CellError {
+	if r, has := res.(interface {
+		CellErrors() []CellError
+	}); has {
+		return r.CellErrors()
+	}
+	return nil
+}
+
+// MissingColumns returns names of columns which were absent in result sets and
+// scanned as zero values
+//
+// Columns are collected only if result of data query was requested with options.WithMissingColumnsAsZero
+func MissingColumns(res BaseResult) []string {
+	if r, has := res.(interface {
+		MissingColumns() []string
+	}); has {
+		return r.MissingColumns()
+	}
+	return nil
+}
@@ -123,6 +123,8 @@ type Result interface {
 	BaseResult
 
 	// ResultSetCount returns number of result sets.
+	// Result sets are ordered as statements of query which return rows
+	// (including DML statements with RETURNING clause).
 	// Note that it does not work if r is the BaseResult of streaming operation.
 	ResultSetCount() int
 }
@@ -130,3 +132,21 @@ type Result interface {
 type StreamResult interface {
 	BaseResult
 }
+
+// QueryCacheHit reports whether query was taken from server query cache
+// (without compilation of query)
+//
+// ok is false if result have no compilation stats. Compilation stats
+// returned by server only for queries executed with options.WithCollectStatsModeBasic
+// or more detailed stats mode
+func QueryCacheHit(res BaseResult) (hit, ok bool) {
+	s := res.Stats()
+	if s == nil {
+		return false, false
+	}
+	c := s.Compilation()
+	if c == nil {
+		return false, false
+	}
+	return c.FromCache, true
+}
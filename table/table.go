@@ -87,8 +87,59 @@ type SessionInfo interface {
 	LastUsage() time.Time
 }
 
+//go:generate mockgen -destination querier_mock_test.go -package table_test -write_package_comment=false github.com/ydb-platform/ydb-go-sdk/v3/table Querier
+
+// Querier is a minimal interface of session for executing of queries and reading of tables
+// (for example, for generating of mocks in tests of code which only reads data)
+type Querier interface {
+	// Execute executes query.
+	//
+	// By default, Execute have a flag options.WithKeepInCache(true) if params is not empty. For redefine behavior -
+	// append option options.WithKeepInCache(false) or define driver-wide default with ydb.WithKeepInCache
+	//
+	// Result contains one result set per statement which returns rows (SELECT statements and DML statements
+	// with RETURNING clause) in order of statements in query. DML statements without RETURNING clause
+	// have no result sets. For example, query
+	//
+	//	INSERT INTO users (name) VALUES ("a") RETURNING id;
+	//	UPDATE users SET name = "b" WHERE id = 1;
+	//	SELECT COUNT(*) FROM users;
+	//
+	// returns two result sets: rows returned by INSERT and rows of SELECT.
+	// Use sugar.ExecReturning for scanning of rows returned by single DML statement into slice of structs
+	Execute(
+		ctx context.Context,
+		tx *TransactionControl,
+		query string,
+		params *QueryParameters,
+		opts ...options.ExecuteDataQueryOption,
+	) (txr Transaction, r result.Result, err error)
+
+	// StreamReadTable reads rows of table as a stream of result set parts.
+	//
+	// Reading can be resumed after restart of consumer with options.ReadFromCheckpoint
+	// and checkpoint of stream position returned by result.Checkpoint
+	StreamReadTable(
+		ctx context.Context,
+		path string,
+		opts ...options.ReadTableOption,
+	) (r result.StreamResult, err error)
+
+	// StreamExecuteScanQuery executes scan query and returns result as a stream of result set parts.
+	//
+	// Scan queries cannot be resumed from checkpoint: result.Checkpoint returns
+	// result.ErrCheckpointNotSupported for results of scan queries
+	StreamExecuteScanQuery(
+		ctx context.Context,
+		query string,
+		params *QueryParameters,
+		opts ...options.ExecuteScanQueryOption,
+	) (_ result.StreamResult, err error)
+}
+
 type Session interface {
 	SessionInfo
+	Querier
 
 	CreateTable(
 		ctx context.Context,
@@ -136,18 +187,6 @@ type Session interface {
 		query string,
 	) (stmt Statement, err error)
 
-	// Execute executes query.
-	//
-	// By default, Execute have a flag options.WithKeepInCache(true) if params is not empty. For redefine behavior -
-	// append option options.WithKeepInCache(false)
-	Execute(
-		ctx context.Context,
-		tx *TransactionControl,
-		query string,
-		params *QueryParameters,
-		opts ...options.ExecuteDataQueryOption,
-	) (txr Transaction, r result.Result, err error)
-
 	ExecuteSchemeQuery(
 		ctx context.Context,
 		query string,
@@ -158,19 +197,6 @@ type Session interface {
 		ctx context.Context,
 	) (desc options.TableOptionsDescription, err error)
 
-	StreamReadTable(
-		ctx context.Context,
-		path string,
-		opts ...options.ReadTableOption,
-	) (r result.StreamResult, err error)
-
-	StreamExecuteScanQuery(
-		ctx context.Context,
-		query string,
-		params *QueryParameters,
-		opts ...options.ExecuteScanQueryOption,
-	) (_ result.StreamResult, err error)
-
 	BulkUpsert(
 		ctx context.Context,
 		table string,
@@ -276,6 +302,19 @@ type Statement interface {
 	Text() string
 }
 
+// ParametersTypes returns types of parameters declared in prepared query
+// (keys are names of parameters with '$' prefix)
+//
+// ParametersTypes returns nil if stmt does not provide types of parameters
+func ParametersTypes(stmt Statement) map[string]types.Type {
+	if s, has := stmt.(interface {
+		ParametersTypes() map[string]types.Type
+	}); has {
+		return s.ParametersTypes()
+	}
+	return nil
+}
+
 var (
 	serializableReadWrite = &Ydb_Table.TransactionSettings_SerializableReadWrite{
 		SerializableReadWrite: &Ydb_Table.SerializableModeSettings{},
@@ -597,9 +636,11 @@ var _ Option = retryOptionsOption{}
 type retryOptionsOption []retry.Option
 
 func (retryOptions retryOptionsOption) ApplyTableOption(opts *Options) {
-	opts.RetryOptions = append(opts.RetryOptions, retry.WithIdempotent(true))
+	opts.RetryOptions = append(append(opts.RetryOptions, retryOptions...), retry.WithIdempotent(true))
 }
 
+// WithRetryOptions applies given retry options to retries of Do and DoTx.
+// Operation with WithRetryOptions is always retried as idempotent
 func WithRetryOptions(retryOptions []retry.Option) retryOptionsOption {
 	return retryOptions
 }
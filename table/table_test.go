@@ -1,12 +1,18 @@
 package table_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	grpcCodes "google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
 
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table"
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
 )
 
 func TestQueryParameters_String(t *testing.T) {
@@ -56,9 +62,68 @@ func TestQueryParameters_String(t *testing.T) {
 			),
 			s: "{\"$a\":\"test\"u,\"$b\":\"test\",\"$c\":123456ul,\"$d\":<|`$a`:\"test\"u,`$b`:\"test\",`$c`:123456ul|>}",
 		},
+		{
+			p: table.NewQueryParameters(
+				table.ValueParam("$login", types.TextValue("admin")),
+				table.ValueParam("$password", types.Secret(types.TextValue("qwerty"))),
+				table.ValueParam("$user", types.StructValue(
+					types.StructFieldValue("login", types.TextValue("admin")),
+					types.StructFieldValue("token", types.OptionalValue(types.Secret(types.BytesValue([]byte("t0k3n"))))),
+				)),
+			),
+			s: "{\"$login\":\"admin\"u,\"$password\":***,\"$user\":<|`login`:\"admin\"u,`token`:Just(***)|>}",
+		},
 	} {
 		t.Run("", func(t *testing.T) {
 			require.Equal(t, tt.s, tt.p.String())
 		})
 	}
 }
+
+func TestWithRetryOptions(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		idempotent bool
+	}{
+		{
+			name:       "Idempotent",
+			idempotent: true,
+		},
+		{
+			// WithRetryOptions always forces idempotent retries
+			name:       "NonIdempotent",
+			idempotent: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				opts   table.Options
+				labels []string
+			)
+			table.WithRetryOptions([]retry.Option{
+				retry.WithIdempotent(tt.idempotent),
+				retry.WithLabel("test"),
+				retry.WithTrace(&trace.Retry{
+					OnRetry: func(
+						info trace.RetryLoopStartInfo,
+					) func(trace.RetryLoopIntermediateInfo) func(trace.RetryLoopDoneInfo) {
+						labels = append(labels, info.Label)
+						return nil
+					},
+				}),
+			}).ApplyTableOption(&opts)
+			attempts := 0
+			err := retry.Retry(context.Background(), func(ctx context.Context) error {
+				attempts++
+				if attempts < 3 {
+					// transport error which is retried only for idempotent operations
+					return xerrors.Transport(grpcStatus.Error(grpcCodes.Canceled, ""))
+				}
+				return nil
+			}, opts.RetryOptions...)
+			require.NoError(t, err)
+			require.Equal(t, 3, attempts)
+			require.Equal(t, []string{"test"}, labels)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package types
+
+import "github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+
+type (
+	// ListBuilder makes list value of items of known type with incremental checks
+	// of types and approximate size of list value
+	ListBuilder = value.ListBuilder
+
+	// StructListBuilder makes list of struct values (such as rows for table.Session.BulkUpsert)
+	// with incremental checks of types and approximate size of list value
+	StructListBuilder = value.StructListBuilder
+
+	// SizeLimitError is returned by Append of builders if approximate size of value
+	// with appended item exceeds limit (see WithBuilderSizeLimit)
+	SizeLimitError = value.SizeLimitError
+
+	// BuilderOption is an option of ListBuilder and StructListBuilder
+	BuilderOption = value.BuilderOption
+)
+
+// WithBuilderSizeLimit limits approximate size of value built with ListBuilder or StructListBuilder
+// in bytes. If sizeLimit is less than or equal to zero then size of value is not limited
+func WithBuilderSizeLimit(sizeLimit int) BuilderOption {
+	return value.WithBuilderSizeLimit(sizeLimit)
+}
+
+// NewListBuilder makes builder of list value of type List<itemType>.
+// capacityHint is an expected count of items (negative hint is treated as 0)
+//
+// Unlike ListValue of collected items builder checks types of items on appending,
+// tracks approximate size of list value and does not copy items on building
+func NewListBuilder(itemType Type, capacityHint int, opts ...BuilderOption) *ListBuilder {
+	return value.NewListBuilder(itemType, capacityHint, opts...)
+}
+
+// NewStructListBuilder makes builder of list value of type List<t>, t must be a struct type.
+// Values of fields of rows are appended in order of fields of t, fields of struct values
+// are sorted by names (as fields of StructValue). capacityHint is an expected count of rows
+func NewStructListBuilder(t Type, capacityHint int, opts ...BuilderOption) (*StructListBuilder, error) {
+	return value.NewStructListBuilder(t, capacityHint, opts...)
+}
@@ -15,20 +15,63 @@ func CastTo(v Value, dst interface{}) error {
 	if v == nil {
 		return xerrors.WithStackTrace(errNilValue)
 	}
-	return value.CastTo(v, dst)
+	return value.Cast(v, dst)
+}
+
+// NativeValue returns natural Go representation of value for generic code
+// (such as logging, JSON export or scanning into maps)
+//
+// Mapping of YDB types to Go types:
+//
+//	Bool                               bool
+//	Int8, Int16, Int32, Int64          int64
+//	Uint8, Uint16, Uint32, Uint64      uint64
+//	Float, Double                      float64
+//	Date, Datetime, Timestamp          time.Time (in UTC)
+//	TzDate, TzDatetime, TzTimestamp    time.Time (in location of timezone name)
+//	Interval                           time.Duration (saturated on overflow)
+//	Text, JSON, JSONDocument, DyNumber string
+//	Bytes, YSON                        []byte
+//	UUID                               [16]byte
+//	Decimal                            string (such as `-1.500000000`)
+//	Void, NULL of Optional             nil
+//	Optional                           Go representation of item
+//	List, Set, Tuple                   []interface{}
+//	Struct                             map[string]interface{}
+//	Dict                               map[interface{}]interface{} (keys of type []byte are converted to string)
+//	Variant                            Go representation of item
+//	Secret (see Secret)                string "***" (content is hidden)
+//
+// NativeValue returns error if Tz* value is malformed or key of Dict is not comparable.
+// CastTo with destination of type *interface{} makes the same result.
+func NativeValue(v Value) (interface{}, error) {
+	if v == nil {
+		return nil, xerrors.WithStackTrace(errNilValue)
+	}
+	return value.NativeValue(v)
 }
 
 // IsOptional checks if type is optional and returns innerType if it is.
 func IsOptional(t Type) (isOptional bool, innerType Type) {
-	if optionalType, isOptional := t.(interface {
-		IsOptional()
-		InnerType() Type
-	}); isOptional {
-		return isOptional, optionalType.InnerType()
+	if innerType, isOptional = value.UnwrapOptional(t); isOptional {
+		return isOptional, innerType
 	}
 	return false, nil
 }
 
+// UnwrapOptionalType returns inner type of optional type t (such as Int32 for Optional<Int32>)
+// and true. If t is not optional UnwrapOptionalType returns t and false.
+// UnwrapOptional is the same for values
+func UnwrapOptionalType(t Type) (inner Type, wasOptional bool) {
+	return value.UnwrapOptional(t)
+}
+
+// OptionalDepth returns count of nested optional types (such as 2 for Optional<Optional<Int32>>
+// and 0 for non-optional types)
+func OptionalDepth(t Type) int {
+	return value.OptionalDepth(t)
+}
+
 // ToDecimal returns Decimal struct from abstract Value
 func ToDecimal(v Value) (*Decimal, error) {
 	if valuer, isDecimalValuer := v.(value.DecimalValuer); isDecimalValuer {
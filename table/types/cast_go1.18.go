@@ -0,0 +1,44 @@
+//go:build go1.18
+// +build go1.18
+
+package types
+
+import (
+	"reflect"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+)
+
+// RegisterCast registers cast of values to destinations of user-defined type T (such as
+// ULID from Text value or protobuf message from Bytes value). Registered cast is used by
+// CastTo and by scanning of rows (Scan, ScanNamed, ScanWithDefaults) if built-in conversions
+// don't support destination of type *T. Cast receives value as is, so it must handle
+// Optional and NULL values if T is scanned from optional columns.
+//
+// RegisterCast returns error if cast to T is already registered.
+//
+// RegisterCast is safe for concurrent use, but casts must be registered on initialization
+// of program (such as in init functions) before values are casted or rows are scanned.
+func RegisterCast[T any](f func(v Value, dst *T) error) error {
+	return value.RegisterCast(reflect.TypeOf((*T)(nil)).Elem(), func(v value.Value, dst interface{}) error {
+		return f(v, dst.(*T))
+	})
+}
+
+// UnregisterCast removes registered cast to destinations of type T (such as in cleanup of tests)
+// and returns false if cast to T was not registered
+func UnregisterCast[T any]() bool {
+	return value.UnregisterCast(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// CastAs casts value to new value of type T (such as CastAs[string](v) or CastAs[*int32](v)
+// for optional values). CastAs supports all destinations which are supported by CastTo
+// (including destinations of registered casts): CastAs[T](v) is equal to CastTo(v, new(T))
+func CastAs[T any](v Value) (T, error) {
+	return value.CastTo[T](v)
+}
+
+// MustCastAs casts value to new value of type T and panics on cast error
+func MustCastAs[T any](v Value) T {
+	return value.MustCastTo[T](v)
+}
@@ -0,0 +1,44 @@
+//go:build go1.18
+// +build go1.18
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCastAs(t *testing.T) {
+	t.Run("Text", func(t *testing.T) {
+		v, err := CastAs[string](TextValue("test"))
+		require.NoError(t, err)
+		require.Equal(t, "test", v)
+	})
+	t.Run("Optional", func(t *testing.T) {
+		v, err := CastAs[*int32](OptionalValue(Int32Value(42)))
+		require.NoError(t, err)
+		require.NotNil(t, v)
+		require.Equal(t, int32(42), *v)
+	})
+	t.Run("Error", func(t *testing.T) {
+		_, err := CastAs[int32](TextValue("test"))
+		require.Error(t, err)
+		require.Panics(t, func() {
+			_ = MustCastAs[int32](TextValue("test"))
+		})
+	})
+	t.Run("Registered", func(t *testing.T) {
+		type id string
+		require.NoError(t, RegisterCast(func(v Value, dst *id) error {
+			var s string
+			if err := CastTo(v, &s); err != nil {
+				return err
+			}
+			*dst = id(s)
+			return nil
+		}))
+		defer UnregisterCast[id]()
+		require.Equal(t, id("test"), MustCastAs[id](TextValue("test")))
+	})
+}
@@ -0,0 +1,153 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
+)
+
+// ErrDecimalOverflow reports that result of operation with finite decimals
+// (or value of DecimalValueFromBigIntE) does not fit into precision of result
+var ErrDecimalOverflow = decimal.ErrOverflow
+
+// ErrDecimalInexact reports that decimal cannot be represented with requested scale
+// without rounding (see DecimalRoundUnnecessary)
+var ErrDecimalInexact = decimal.ErrInexact
+
+// DecimalRoundingMode is a mode of rounding of decimal values on decreasing of scale
+type DecimalRoundingMode = decimal.RoundingMode
+
+const (
+	// DecimalRoundHalfEven rounds to the nearest value and ties to even value
+	// (as YDB rounds decimals on parsing and casting)
+	DecimalRoundHalfEven = decimal.RoundHalfEven
+
+	// DecimalRoundHalfUp rounds to the nearest value and ties away from zero
+	DecimalRoundHalfUp = decimal.RoundHalfUp
+
+	// DecimalRoundDown rounds toward zero (truncates fraction digits)
+	DecimalRoundDown = decimal.RoundDown
+
+	// DecimalRoundUnnecessary does not round: operations fail with ErrDecimalInexact
+	// if any non-zero digit of fraction must be dropped
+	DecimalRoundUnnecessary = decimal.RoundUnnecessary
+)
+
+// Neg returns -d with precision and scale of d
+func (d *Decimal) Neg() *Decimal {
+	return &Decimal{
+		Bytes:     decimal.BigIntToByte(decimal.Neg(d.unscaled()), d.Precision, d.Scale),
+		Precision: d.Precision,
+		Scale:     d.Scale,
+	}
+}
+
+// Add returns d + x.
+//
+// Operands with different scales are aligned to the greater scale without rounding.
+// Result has the greater scale and the greater count of integral digits of operands
+// (but no more than 35 digits in total). Add returns ErrDecimalOverflow if sum of finite
+// operands does not fit into precision of result. Infinities and nan are processed as in YDB
+// (for example, sum of infinities of different signs is nan).
+func (d *Decimal) Add(x *Decimal) (*Decimal, error) {
+	precision, scale := alignDecimals(d, x)
+	a, b := d.rescaled(scale, precision), x.rescaled(scale, precision)
+	if isOverflow(a, d) || isOverflow(b, x) {
+		return nil, fmt.Errorf("%w: %v + %v", ErrDecimalOverflow, d, x)
+	}
+	sum := decimal.Add(a, b, precision)
+	if decimal.IsInf(sum) && !decimal.IsInf(a) && !decimal.IsInf(b) {
+		return nil, fmt.Errorf("%w: %v + %v", ErrDecimalOverflow, d, x)
+	}
+	return &Decimal{
+		Bytes:     decimal.BigIntToByte(sum, precision, scale),
+		Precision: precision,
+		Scale:     scale,
+	}, nil
+}
+
+// Sub returns d - x.
+//
+// Operands are aligned as in Add.
+func (d *Decimal) Sub(x *Decimal) (*Decimal, error) {
+	return d.Add(x.Neg())
+}
+
+// Cmp compares d and x and returns -1, 0 or +1.
+//
+// Operands with different scales are compared by their values.
+// Special values are ordered as -inf < finite values < inf < nan.
+func (d *Decimal) Cmp(x *Decimal) int {
+	a, b := d.unscaled(), x.unscaled()
+	if decimal.IsNaN(a) || decimal.IsInf(a) || decimal.IsNaN(b) || decimal.IsInf(b) {
+		return decimal.Cmp(a, b)
+	}
+	// finite values are aligned without bounds of precision
+	switch {
+	case d.Scale < x.Scale:
+		a.Mul(a, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(x.Scale-d.Scale)), nil))
+	case d.Scale > x.Scale:
+		b.Mul(b, big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(d.Scale-x.Scale)), nil))
+	}
+	return a.Cmp(b)
+}
+
+// Rescale returns d with scale changed to scale and precision of d.
+// Digits of fraction are rounded with mode on decreasing of scale.
+//
+// Rescale returns ErrDecimalOverflow if finite d does not fit into precision with new scale
+// and ErrDecimalInexact if mode is DecimalRoundUnnecessary and digits of fraction are lost.
+func (d *Decimal) Rescale(scale uint32, mode DecimalRoundingMode) (*Decimal, error) {
+	if scale > d.Precision {
+		return nil, fmt.Errorf("scale %d is greater than precision %d of %v", scale, d.Precision, d)
+	}
+	v := decimal.Rescale(d.unscaled(), d.Scale, scale, d.Precision, mode)
+	if decimal.IsErr(v) {
+		return nil, fmt.Errorf("%w: %v with scale %d", ErrDecimalInexact, d, scale)
+	}
+	if isOverflow(v, d) {
+		return nil, fmt.Errorf("%w: %v with scale %d", ErrDecimalOverflow, d, scale)
+	}
+	return &Decimal{
+		Bytes:     decimal.BigIntToByte(v, d.Precision, scale),
+		Precision: d.Precision,
+		Scale:     scale,
+	}, nil
+}
+
+// unscaled returns unscaled value of d (BigInt turns nan into infinity
+// if precision of d is less than maximal precision)
+func (d *Decimal) unscaled() *big.Int {
+	if v := decimal.FromInt128(d.Bytes, decimal.MaxPrecision+1, d.Scale); decimal.IsNaN(v) {
+		return v
+	}
+	return d.BigInt()
+}
+
+// rescaled returns unscaled value of d with scale and precision (without rounding)
+func (d *Decimal) rescaled(scale, precision uint32) *big.Int {
+	return decimal.Rescale(d.unscaled(), d.Scale, scale, precision, decimal.RoundDown)
+}
+
+// alignDecimals returns precision and scale of result of operation with decimals
+func alignDecimals(x, y *Decimal) (precision, scale uint32) {
+	scale = x.Scale
+	if y.Scale > scale {
+		scale = y.Scale
+	}
+	integral := x.Precision - x.Scale
+	if y.Precision-y.Scale > integral {
+		integral = y.Precision - y.Scale
+	}
+	precision = integral + scale
+	if precision > decimal.MaxPrecision {
+		precision = decimal.MaxPrecision
+	}
+	return precision, scale
+}
+
+// isOverflow reports whether v is an infinity made from finite decimal d
+func isOverflow(v *big.Int, d *Decimal) bool {
+	return decimal.IsInf(v) && !decimal.IsInf(d.unscaled())
+}
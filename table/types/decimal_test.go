@@ -0,0 +1,205 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
+)
+
+func mustDecimal(t *testing.T, s string, precision, scale uint32) *Decimal {
+	v, err := decimal.Parse(s, precision, scale)
+	require.NoError(t, err)
+	return &Decimal{
+		Bytes:     decimal.BigIntToByte(v, precision, scale),
+		Precision: precision,
+		Scale:     scale,
+	}
+}
+
+func TestDecimalAdd(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		x    *Decimal
+		y    *Decimal
+		sum  string
+		diff string
+		err  error
+	}{
+		{
+			name: "DifferentScales",
+			x:    mustDecimal(t, "1.000000001", 22, 9),
+			y:    mustDecimal(t, "-0.25", 22, 2),
+			sum:  "0.750000001",
+			diff: "1.250000001",
+		},
+		{
+			name: "DifferentPrecisions",
+			x:    mustDecimal(t, "123456789012.5", 35, 1),
+			y:    mustDecimal(t, "0.000000001", 22, 9),
+			sum:  "123456789012.500000001",
+			diff: "123456789012.499999999",
+		},
+		{
+			name: "Overflow",
+			x:    mustDecimal(t, "9999999999999", 22, 9),
+			y:    mustDecimal(t, "1", 22, 9),
+			err:  ErrDecimalOverflow,
+		},
+		{
+			name: "Infinity",
+			x:    mustDecimal(t, "inf", 22, 9),
+			y:    mustDecimal(t, "1", 22, 9),
+			sum:  "inf",
+			diff: "inf",
+		},
+		{
+			name: "Infinities",
+			x:    mustDecimal(t, "-inf", 22, 9),
+			y:    mustDecimal(t, "inf", 22, 9),
+			sum:  "nan",
+			diff: "-inf",
+		},
+		{
+			name: "NaN",
+			x:    mustDecimal(t, "nan", 22, 9),
+			y:    mustDecimal(t, "1", 22, 9),
+			sum:  "nan",
+			diff: "nan",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sum, err := tt.x.Add(tt.y)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				_, err = tt.x.Sub(tt.y.Neg())
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.sum, sum.String())
+			diff, err := tt.x.Sub(tt.y)
+			require.NoError(t, err)
+			require.Equal(t, tt.diff, diff.String())
+		})
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	ordered := []*Decimal{
+		mustDecimal(t, "-inf", 22, 9),
+		mustDecimal(t, "-1.5", 22, 1),
+		mustDecimal(t, "-1.499999999", 22, 9),
+		mustDecimal(t, "0", 35, 0),
+		mustDecimal(t, "0.000000001", 22, 9),
+		mustDecimal(t, "inf", 35, 0),
+		mustDecimal(t, "nan", 22, 9),
+	}
+	for i, x := range ordered {
+		for j, y := range ordered {
+			exp := 0
+			switch {
+			case i < j:
+				exp = -1
+			case i > j:
+				exp = 1
+			}
+			require.Equal(t, exp, x.Cmp(y), "%v <=> %v", x, y)
+		}
+	}
+	require.Equal(t, 0, mustDecimal(t, "1.5", 22, 1).Cmp(mustDecimal(t, "1.500000000", 22, 9)))
+}
+
+func TestDecimalRescale(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		x     *Decimal
+		scale uint32
+		mode  DecimalRoundingMode
+		exp   string
+		err   error
+	}{
+		{
+			name:  "HalfEvenTieDown",
+			x:     mustDecimal(t, "-2.125", 22, 9),
+			scale: 2,
+			mode:  DecimalRoundHalfEven,
+			exp:   "-2.12",
+		},
+		{
+			name:  "HalfEvenTieUp",
+			x:     mustDecimal(t, "2.135", 22, 9),
+			scale: 2,
+			mode:  DecimalRoundHalfEven,
+			exp:   "2.14",
+		},
+		{
+			name:  "HalfUp",
+			x:     mustDecimal(t, "-2.125", 22, 9),
+			scale: 2,
+			mode:  DecimalRoundHalfUp,
+			exp:   "-2.13",
+		},
+		{
+			name:  "Down",
+			x:     mustDecimal(t, "-2.129", 22, 9),
+			scale: 2,
+			mode:  DecimalRoundDown,
+			exp:   "-2.12",
+		},
+		{
+			name:  "UnnecessaryExact",
+			x:     mustDecimal(t, "-2.120", 22, 9),
+			scale: 2,
+			mode:  DecimalRoundUnnecessary,
+			exp:   "-2.12",
+		},
+		{
+			name:  "UnnecessaryInexact",
+			x:     mustDecimal(t, "2.125", 22, 9),
+			scale: 2,
+			mode:  DecimalRoundUnnecessary,
+			err:   ErrDecimalInexact,
+		},
+		{
+			name:  "Up",
+			x:     mustDecimal(t, "2.1", 22, 1),
+			scale: 9,
+			mode:  DecimalRoundHalfEven,
+			exp:   "2.100000000",
+		},
+		{
+			name:  "Overflow",
+			x:     mustDecimal(t, "10000000000000", 22, 0),
+			scale: 9,
+			mode:  DecimalRoundHalfEven,
+			err:   ErrDecimalOverflow,
+		},
+		{
+			name:  "NaN",
+			x:     mustDecimal(t, "nan", 22, 9),
+			scale: 0,
+			mode:  DecimalRoundHalfEven,
+			exp:   "nan",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.x.Rescale(tt.scale, tt.mode)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.scale, v.Scale)
+			require.Equal(t, tt.x.Precision, v.Precision)
+			require.Equal(t, tt.exp, v.String())
+		})
+	}
+}
+
+func TestDecimalNeg(t *testing.T) {
+	require.Equal(t, "-1.500000000", mustDecimal(t, "1.5", 22, 9).Neg().String())
+	require.Equal(t, "inf", mustDecimal(t, "-inf", 22, 9).Neg().String())
+	require.Zero(t, mustDecimal(t, "0", 22, 9).Neg().BigInt().Sign())
+}
@@ -0,0 +1,53 @@
+//go:build go1.18
+// +build go1.18
+
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+)
+
+// ListValueFromSlice makes list value of items converted to values with f
+// (such as ListValueFromSlice(ids, types.Int64Value)).
+// List of empty slice has type EmptyList, so query with `IN $list` is executed without candidates.
+//
+// ListValueFromSlice panics if f makes values of different types (see ListValue)
+func ListValueFromSlice[T any](items []T, f func(T) Value) Value {
+	if len(items) == 0 {
+		return value.ListValue()
+	}
+	values := make([]Value, len(items))
+	for i := range items {
+		values[i] = f(items[i])
+	}
+	return value.ListValue(values...)
+}
+
+// ListValueOfInt64 makes List<Int64> value of items (EmptyList for empty items)
+func ListValueOfInt64(items []int64) Value {
+	return ListValueFromSlice(items, Int64Value)
+}
+
+// ListValueOfText makes List<Utf8> value of items (EmptyList for empty items)
+func ListValueOfText(items []string) Value {
+	return ListValueFromSlice(items, TextValue)
+}
+
+// ListValueOfBytes makes List<String> value of items (EmptyList for empty items)
+func ListValueOfBytes(items [][]byte) Value {
+	return ListValueFromSlice(items, BytesValue)
+}
+
+// ListValueOfTimestamp makes List<Timestamp> value of items (EmptyList for empty items)
+func ListValueOfTimestamp(items []time.Time) Value {
+	return ListValueFromSlice(items, TimestampValueFromTime)
+}
+
+// ListValueOfUUID makes List<Uuid> value of items (EmptyList for empty items)
+func ListValueOfUUID(items []uuid.UUID) Value {
+	return ListValueFromSlice(items, UUIDValueFromUUID)
+}
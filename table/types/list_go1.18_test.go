@@ -0,0 +1,98 @@
+//go:build go1.18
+// +build go1.18
+
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListValueFromSlice(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		v    Value
+		yql  string
+	}{
+		{
+			name: "Int64",
+			v:    ListValueOfInt64([]int64{1, 2}),
+			yql:  "[1l,2l]",
+		},
+		{
+			name: "Text",
+			v:    ListValueOfText([]string{"a", "b"}),
+			yql:  `["a"u,"b"u]`,
+		},
+		{
+			name: "Bytes",
+			v:    ListValueOfBytes([][]byte{[]byte("a")}),
+			yql:  `["a"]`,
+		},
+		{
+			name: "Timestamp",
+			v:    ListValueOfTimestamp([]time.Time{time.Date(2022, 6, 17, 5, 19, 20, 123456000, time.UTC)}),
+			yql:  `[Timestamp("2022-06-17T05:19:20.123456Z")]`,
+		},
+		{
+			name: "UUID",
+			v:    ListValueOfUUID([]uuid.UUID{uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")}),
+			yql:  `[Uuid("6ba7b810-9dad-11d1-80b4-00c04fd430c8")]`,
+		},
+		{
+			name: "Func",
+			v: ListValueFromSlice([]int{1, 2}, func(v int) Value {
+				return OptionalValue(Int32Value(int32(v)))
+			}),
+			yql: "[Just(1),Just(2)]",
+		},
+		{
+			name: "Empty",
+			v:    ListValueOfInt64(nil),
+			yql:  "[]",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.yql, tt.v.Yql())
+		})
+	}
+	t.Run("EmptyListType", func(t *testing.T) {
+		require.True(t, Equal(EmptyList(), ListValueOfText([]string{}).Type()))
+	})
+	t.Run("DifferentTypes", func(t *testing.T) {
+		require.Panics(t, func() {
+			_ = ListValueFromSlice([]int{1, 2}, func(v int) Value {
+				if v == 1 {
+					return Int32Value(int32(v))
+				}
+				return Int64Value(int64(v))
+			})
+		})
+	})
+}
+
+func BenchmarkListValueFromSlice(b *testing.B) {
+	items := make([]int64, 100)
+	for i := range items {
+		items[i] = int64(i)
+	}
+	b.Run("ListValueOfInt64", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = ListValueOfInt64(items)
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			values := make([]Value, len(items))
+			for j := range items {
+				values[j] = Int64Value(items[j])
+			}
+			_ = ListValue(values...)
+		}
+	})
+}
@@ -11,15 +11,76 @@ import (
 // Type describes YDB data types.
 type Type = value.Type
 
-// Equal checks for type equivalence
+// ListType is a type of lists (such as List<Int32>) and sets with accessor of type of items.
+// Types of lists and sets (including types of results of queries) implement ListType
+type ListType = value.ListType
+
+// DictType is a type of dicts (such as Dict<Utf8,Int32>) with accessors of types of keys and values.
+// Types of dicts and sets (including types of results of queries) implement DictType.
+// Set<T> is a dict type with key type T and value type Void
+type DictType = value.DictType
+
+// Equal checks for type equivalence (the same as TypesEqual)
 func Equal(lhs, rhs Type) bool {
 	return value.TypesEqual(lhs, rhs)
 }
 
+// TypesEqual checks structural equality of types: kinds of types, primitive type ids,
+// precision and scale of decimals, tags, names and order of struct members and types of
+// items of containers and variants must be equal. Set<T> is equal to Dict<T,Void>,
+// because they are the same type on the wire. Nil types are equal only to nil types
+func TypesEqual(a, b Type) bool {
+	return value.TypesEqual(a, b)
+}
+
+// TypeToJSON returns structured JSON form of type t for schema dumps (such as
+// {"kind":"optional","item":{"kind":"decimal","precision":22,"scale":9}}).
+// Form of equal types is the same, fields of structs are written in order of declaration.
+//
+// Kinds of types and their fields:
+//   - "primitive" with "name" of primitive type (such as "Int32" or "Utf8");
+//   - "decimal" with "precision" and "scale";
+//   - "optional", "list" and "set" with "item";
+//   - "dict" with "key" and "value";
+//   - "tuple" with "items", "struct" with "fields" (objects with "name" and "type");
+//   - "variant" with "fields" (variant over struct) or "items" (variant over tuple);
+//   - "tagged" with "tag" and "item";
+//   - "pg" with "oid" (and "typlen" and "typmod" if they are not zero);
+//   - "void", "null", "empty_list" and "empty_dict" without fields;
+//   - "unknown" with "id" of primitive type which is not known by SDK.
+func TypeToJSON(t Type) ([]byte, error) {
+	return value.TypeToJSON(t)
+}
+
+// TypeFromJSON makes type from structured JSON form of type (see TypeToJSON)
+func TypeFromJSON(data []byte) (Type, error) {
+	return value.TypeFromJSON(data)
+}
+
+// TypeSyntaxError reports malformed textual representation of type with byte offset of error
+type TypeSyntaxError = value.TypeSyntaxError
+
+// ParseType parses type from its textual representation in format of Type.Yql(),
+// such as `List<Optional<Decimal(22,9)>>` or `Struct<'id':Uint64,'name':Utf8>`.
+// ParseType is an inverse of Type.Yql(). Errors of parsing are *TypeSyntaxError
+func ParseType(s string) (Type, error) {
+	return value.ParseType(s)
+}
+
 func List(t Type) Type {
 	return value.List(t)
 }
 
+// EmptyList returns type of empty list without type of items (such as type of `SELECT AsList()`)
+func EmptyList() Type {
+	return value.EmptyList()
+}
+
+// EmptyDict returns type of empty dict without types of keys and values (such as type of `SELECT AsDict()`)
+func EmptyDict() Type {
+	return value.EmptyDict()
+}
+
 func Tuple(elems ...Type) Type {
 	return value.Tuple(elems...)
 }
@@ -53,6 +114,7 @@ func Dict(k, v Type) Type {
 	return value.Dict(k, v)
 }
 
+// VariantStruct returns struct variant type with named alternatives (such as Variant<'a':Int32,'b':Utf8>)
 func VariantStruct(opts ...StructOption) Type {
 	var s tStructType
 	for _, opt := range opts {
@@ -63,10 +125,18 @@ func VariantStruct(opts ...StructOption) Type {
 	return value.VariantStruct(s.fields...)
 }
 
+// VariantTuple returns tuple variant type with indexed alternatives (such as Variant<Int32,Utf8>)
 func VariantTuple(elems ...Type) Type {
 	return value.VariantTuple(elems...)
 }
 
+// Variant returns variant type over alternatives of struct type t (the same as VariantStruct)
+// or tuple type t (the same as VariantTuple). Variant types are returned as is.
+// Variant returns error on other types and on struct and tuple types without alternatives
+func Variant(t Type) (Type, error) {
+	return value.Variant(t)
+}
+
 func Void() Type {
 	return value.Void()
 }
@@ -75,12 +145,43 @@ func Optional(t Type) Type {
 	return value.Optional(t)
 }
 
-var DefaultDecimal = DecimalType(22, 9)
+// Tagged returns type Tagged<t,tag>. Tag and inner type of tagged type are available
+// with methods Tag() and InnerType()
+func Tagged(tag string, t Type) Type {
+	return value.Tagged(tag, t)
+}
 
+// PgType returns PostgreSQL-compatible type with given OID (such as 23 for pgint4).
+// OID and name of pg type are available with methods OID() and Name()
+func PgType(oid uint32) Type {
+	return value.Pg(oid)
+}
+
+const (
+	// DefaultDecimalPrecision is a precision of canonical decimal type Decimal(22,9)
+	DefaultDecimalPrecision = value.DefaultDecimalPrecision
+	// DefaultDecimalScale is a scale of canonical decimal type Decimal(22,9)
+	DefaultDecimalScale = value.DefaultDecimalScale
+)
+
+// DefaultDecimal is a canonical decimal type Decimal(22,9)
+var DefaultDecimal = DecimalType(DefaultDecimalPrecision, DefaultDecimalScale)
+
+// DecimalType returns decimal type without checks of parameters. Use DecimalTypeE for checked types
 func DecimalType(precision, scale uint32) Type {
 	return value.Decimal(precision, scale)
 }
 
+// DecimalTypeE returns decimal type and returns error if precision is out of range [1,35]
+// or scale is greater than precision
+func DecimalTypeE(precision, scale uint32) (Type, error) {
+	t, err := value.DecimalE(precision, scale)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
 func DecimalTypeFromDecimal(d *Decimal) Type {
 	return value.Decimal(d.Precision, d.Scale)
 }
@@ -106,15 +207,30 @@ const (
 	TypeTzDate       = value.TypeTzDate
 	TypeTzDatetime   = value.TypeTzDatetime
 	TypeTzTimestamp  = value.TypeTzTimestamp
-	TypeString       = value.TypeBytes
 	TypeBytes        = value.TypeBytes
-	TypeUTF8         = value.TypeText
 	TypeText         = value.TypeText
 	TypeYSON         = value.TypeYSON
 	TypeJSON         = value.TypeJSON
 	TypeUUID         = value.TypeUUID
 	TypeJSONDocument = value.TypeJSONDocument
 	TypeDyNumber     = value.TypeDyNumber
+	TypeDate32       = value.TypeDate32
+	TypeDatetime64   = value.TypeDatetime64
+	TypeTimestamp64  = value.TypeTimestamp64
+	TypeInterval64   = value.TypeInterval64
+)
+
+// Old names of primitive types.
+const (
+	// TypeString is an old name of TypeBytes
+	//
+	// Deprecated: use TypeBytes instead
+	TypeString = TypeBytes
+
+	// TypeUTF8 is an old name of TypeText
+	//
+	// Deprecated: use TypeText instead
+	TypeUTF8 = TypeText
 )
 
 // WriteTypeStringTo writes ydb type string representation into buffer
@@ -1,15 +1,27 @@
 package types
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"reflect"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/decimal"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/value"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xerrors"
 	"github.com/ydb-platform/ydb-go-sdk/v3/internal/xstring"
 )
 
+// Value is an immutable YDB value
+//
+// Constructed values and types are safe for concurrent use: the same value (for example,
+// query parameter which is built once) may be serialized from multiple goroutines
+// simultaneously. Slices passed to constructors must not be modified after construction.
 type Value = value.Value
 
 func BoolValue(v bool) Value { return value.BoolValue(v) }
@@ -49,7 +61,62 @@ func IntervalValueFromMicroseconds(v int64) Value { return value.IntervalValue(v
 // IntervalValue makes Value from given microseconds value
 //
 // Deprecated: use IntervalValueFromMicroseconds instead
-func IntervalValue(v int64) Value { return value.IntervalValue(v) }
+func IntervalValue(v int64) Value { return IntervalValueFromMicroseconds(v) }
+
+// Date32Value makes Date32 value from days since Epoch (negative for dates before 1970)
+func Date32Value(v int32) Value { return value.Date32Value(v) }
+
+// Datetime64Value makes Datetime64 value from seconds since Epoch (negative for times before 1970)
+func Datetime64Value(v int64) Value { return value.Datetime64Value(v) }
+
+// Timestamp64Value makes Timestamp64 value from microseconds since Epoch (negative for times before 1970)
+func Timestamp64Value(v int64) Value { return value.Timestamp64Value(v) }
+
+// Interval64Value makes Interval64 value from microseconds
+func Interval64Value(v int64) Value { return value.Interval64Value(v) }
+
+// Date32ValueFromTime makes Date32 value with UTC calendar date of t
+func Date32ValueFromTime(t time.Time) Value { return value.Date32ValueFromTime(t) }
+
+// Date32ValueFromTimeE makes Date32 value from time.Time or returns error
+// if t is out of supported range [-144168-01-01, 148107-12-31] of Date32 values
+func Date32ValueFromTimeE(t time.Time) (Value, error) {
+	v, err := value.Date32ValueFromTimeE(t)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
+// Datetime64ValueFromTime makes Datetime64 value from t truncated to seconds
+func Datetime64ValueFromTime(t time.Time) Value { return value.Datetime64ValueFromTime(t) }
+
+// Datetime64ValueFromTimeE makes Datetime64 value from time.Time or returns error
+// if t is out of supported range [-144168-01-01T00:00:00Z, 148107-12-31T23:59:59Z] of Datetime64 values
+func Datetime64ValueFromTimeE(t time.Time) (Value, error) {
+	v, err := value.Datetime64ValueFromTimeE(t)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
+// Timestamp64ValueFromTime makes Timestamp64 value from t truncated to microseconds
+func Timestamp64ValueFromTime(t time.Time) Value { return value.Timestamp64ValueFromTime(t) }
+
+// Timestamp64ValueFromTimeE makes Timestamp64 value from time.Time or returns error if t is out of
+// supported range [-144168-01-01T00:00:00.000000Z, 148107-12-31T23:59:59.999999Z] of Timestamp64 values
+func Timestamp64ValueFromTimeE(t time.Time) (Value, error) {
+	v, err := value.Timestamp64ValueFromTimeE(t)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
+// Interval64ValueFromDuration makes Interval64 value from time.Duration
+// with truncated sub-microsecond remainder
+func Interval64ValueFromDuration(v time.Duration) Value { return value.Interval64ValueFromDuration(v) }
 
 // TzDateValue makes TzDate value from string
 func TzDateValue(v string) Value { return value.TzDateValue(v) }
@@ -62,28 +129,67 @@ func TzTimestampValue(v string) Value { return value.TzTimestampValue(v) }
 
 // DateValueFromTime makes Date value from time.Time
 //
+// Times out of supported range of Date values are wrapped silently,
+// use DateValueFromTimeE for checking of range
+//
 // Warning: all *From* helpers will be removed at next major release
 // (functional will be implements with go1.18 type lists)
 func DateValueFromTime(t time.Time) Value {
 	return value.DateValueFromTime(t)
 }
 
+// DateValueFromTimeE makes Date value from time.Time or returns error
+// if t is out of supported range [1970-01-01, 2105-12-31] of Date values
+func DateValueFromTimeE(t time.Time) (Value, error) {
+	v, err := value.DateValueFromTimeE(t)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
 // DatetimeValueFromTime makes Datetime value from time.Time
 //
+// Times out of supported range of Datetime values are wrapped silently,
+// use DatetimeValueFromTimeE for checking of range
+//
 // Warning: all *From* helpers will be removed at next major release
 // (functional will be implements with go1.18 type lists)
 func DatetimeValueFromTime(t time.Time) Value {
 	return value.DatetimeValueFromTime(t)
 }
 
+// DatetimeValueFromTimeE makes Datetime value from time.Time or returns error
+// if t is out of supported range [1970-01-01T00:00:00Z, 2105-12-31T23:59:59Z] of Datetime values
+func DatetimeValueFromTimeE(t time.Time) (Value, error) {
+	v, err := value.DatetimeValueFromTimeE(t)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
 // TimestampValueFromTime makes Timestamp value from time.Time
 //
+// Times out of supported range of Timestamp values are wrapped silently,
+// use TimestampValueFromTimeE for checking of range
+//
 // Warning: all *From* helpers will be removed at next major release
 // (functional will be implements with go1.18 type lists)
 func TimestampValueFromTime(t time.Time) Value {
 	return value.TimestampValueFromTime(t)
 }
 
+// TimestampValueFromTimeE makes Timestamp value from time.Time or returns error
+// if t is out of supported range [1970-01-01T00:00:00.000000Z, 2105-12-31T23:59:59.999999Z] of Timestamp values
+func TimestampValueFromTimeE(t time.Time) (Value, error) {
+	v, err := value.TimestampValueFromTimeE(t)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
 // IntervalValueFromDuration makes Interval value from time.Duration
 //
 // Warning: all *From* helpers will be removed at next major release
@@ -92,7 +198,76 @@ func IntervalValueFromDuration(v time.Duration) Value {
 	return value.IntervalValueFromDuration(v)
 }
 
-// TzDateValueFromTime makes TzDate value from time.Time
+type tIntervalOptions struct {
+	round bool
+}
+
+// IntervalOption is an option of IntervalValueFromDurationChecked and DurationToMicrosecondsChecked
+type IntervalOption func(*tIntervalOptions)
+
+// WithIntervalRounding makes IntervalValueFromDurationChecked round sub-microsecond
+// remainder of duration to nearest microsecond instead of returning error
+func WithIntervalRounding() IntervalOption {
+	return func(o *tIntervalOptions) {
+		o.round = true
+	}
+}
+
+// IntervalValueFromISO8601 makes Interval value from ISO 8601 duration (such as `-P1DT2H3M4.5S`),
+// which is the format of YQL representation of Interval values
+func IntervalValueFromISO8601(s string) (Value, error) {
+	v, err := value.IntervalValueFromISO8601(s)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
+// IntervalValueFromDurationChecked makes Interval value from time.Duration
+//
+// Unlike IntervalValueFromDuration, which silently truncates sub-microsecond remainder
+// of duration, IntervalValueFromDurationChecked returns error for durations which are
+// not a whole number of microseconds (or rounds them with WithIntervalRounding option).
+func IntervalValueFromDurationChecked(v time.Duration, opts ...IntervalOption) (Value, error) {
+	var o tIntervalOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	interval, err := value.IntervalValueFromDurationChecked(v, o.round)
+	if err != nil {
+		return nil, err
+	}
+	return interval, nil
+}
+
+// DurationToMicroseconds returns microseconds of duration (as stored in Interval values)
+// with truncated sub-microsecond remainder
+func DurationToMicroseconds(v time.Duration) int64 {
+	return value.DurationToMicroseconds(v)
+}
+
+// DurationToMicrosecondsChecked returns microseconds of duration (as stored in Interval values)
+// or error if duration is not a whole number of microseconds (or rounds it with WithIntervalRounding option)
+func DurationToMicrosecondsChecked(v time.Duration, opts ...IntervalOption) (int64, error) {
+	var o tIntervalOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	us, err := value.DurationToMicrosecondsChecked(v, o.round)
+	if err != nil {
+		return 0, xerrors.WithStackTrace(err)
+	}
+	return us, nil
+}
+
+// TzDateValueFromTime makes TzDate value from time.Time in location of time.
+// Local timezone is resolved to its IANA name, fixed zones with whole hours offsets
+// are represented as Etc/GMT zones (such as Etc/GMT-3 for UTC+3). Times in other timezones
+// are converted to UTC, use TzDateValueFromTimeE for checking of timezone
 //
 // Warning: all *From* helpers will be removed at next major release
 // (functional will be implements with go1.18 type lists)
@@ -100,7 +275,20 @@ func TzDateValueFromTime(t time.Time) Value {
 	return value.TzDateValueFromTime(t)
 }
 
-// TzDatetimeValueFromTime makes TzDatetime value from time.Time
+// TzDateValueFromTimeE makes TzDate value from time.Time in location of time
+// or returns error if timezone has no IANA name (see TzDateValueFromTime)
+func TzDateValueFromTimeE(t time.Time) (Value, error) {
+	v, err := value.TzDateValueFromTimeE(t)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
+// TzDatetimeValueFromTime makes TzDatetime value from time.Time in location of time.
+// Local timezone is resolved to its IANA name, fixed zones with whole hours offsets
+// are represented as Etc/GMT zones (such as Etc/GMT-3 for UTC+3). Times in other timezones
+// are converted to UTC, use TzDatetimeValueFromTimeE for checking of timezone
 //
 // Warning: all *From* helpers will be removed at next major release
 // (functional will be implements with go1.18 type lists)
@@ -108,7 +296,20 @@ func TzDatetimeValueFromTime(t time.Time) Value {
 	return value.TzDatetimeValueFromTime(t)
 }
 
-// TzTimestampValueFromTime makes TzTimestamp value from time.Time
+// TzDatetimeValueFromTimeE makes TzDatetime value from time.Time in location of time
+// or returns error if timezone has no IANA name (see TzDatetimeValueFromTime)
+func TzDatetimeValueFromTimeE(t time.Time) (Value, error) {
+	v, err := value.TzDatetimeValueFromTimeE(t)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
+// TzTimestampValueFromTime makes TzTimestamp value from time.Time in location of time.
+// Local timezone is resolved to its IANA name, fixed zones with whole hours offsets
+// are represented as Etc/GMT zones (such as Etc/GMT-3 for UTC+3). Times in other timezones
+// are converted to UTC, use TzTimestampValueFromTimeE for checking of timezone
 //
 // Warning: all *From* helpers will be removed at next major release
 // (functional will be implements with go1.18 type lists)
@@ -116,10 +317,28 @@ func TzTimestampValueFromTime(t time.Time) Value {
 	return value.TzTimestampValueFromTime(t)
 }
 
+// TzTimestampValueFromTimeE makes TzTimestamp value from time.Time in location of time
+// or returns error if timezone has no IANA name (see TzTimestampValueFromTime)
+func TzTimestampValueFromTimeE(t time.Time) (Value, error) {
+	v, err := value.TzTimestampValueFromTimeE(t)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
+// TzTimestampToTime parses TzTimestamp string (such as `2006-01-02T15:04:05.999999,Europe/Moscow`)
+// to time.Time in location of timezone name
+//
+// Returns error for malformed string or unknown timezone
+func TzTimestampToTime(s string) (time.Time, error) {
+	return value.TzTimestampToTime(s)
+}
+
 // StringValue returns bytes value
 //
 // Deprecated: use BytesValue instead
-func StringValue(v []byte) Value { return value.BytesValue(v) }
+func StringValue(v []byte) Value { return BytesValue(v) }
 
 func BytesValue(v []byte) Value { return value.BytesValue(v) }
 
@@ -127,11 +346,13 @@ func BytesValueFromString(v string) Value { return value.BytesValue(xstring.ToBy
 
 // StringValueFromString makes String value from string
 //
-// Warning: all *From* helpers will be removed at next major release
-// (functional will be implements with go1.18 type lists)
-func StringValueFromString(v string) Value { return value.BytesValue(xstring.ToBytes(v)) }
+// Deprecated: use BytesValueFromString instead
+func StringValueFromString(v string) Value { return BytesValueFromString(v) }
 
-func UTF8Value(v string) Value { return value.TextValue(v) }
+// UTF8Value makes Utf8 value from string
+//
+// Deprecated: use TextValue instead
+func UTF8Value(v string) Value { return TextValue(v) }
 
 func TextValue(v string) Value { return value.TextValue(v) }
 
@@ -143,6 +364,12 @@ func YSONValue(v string) Value { return value.YSONValue(xstring.ToBytes(v)) }
 // (functional will be implements with go1.18 type lists)
 func YSONValueFromBytes(v []byte) Value { return value.YSONValue(v) }
 
+// SetBytesStringLimit sets limit of count of bytes in string representations (such as
+// fmt.Sprint(v)) of Bytes and binary Yson values, which are rendered as hex dumps like
+// `Bytes(0x0011…, 37 bytes)`. Limit is 32 bytes by default, limit less than or equal
+// to zero disables truncation. Yql representations of values are not affected
+func SetBytesStringLimit(limit int) { value.SetBytesStringLimit(limit) }
+
 func JSONValue(v string) Value { return value.JSONValue(v) }
 
 // JSONValueFromBytes makes JSON value from bytes
@@ -153,6 +380,20 @@ func JSONValueFromBytes(v []byte) Value { return value.JSONValue(xstring.FromByt
 
 func UUIDValue(v [16]byte) Value { return value.UUIDValue(v) }
 
+// UUIDValueFromUUID makes UUID value from uuid.UUID
+func UUIDValueFromUUID(u uuid.UUID) Value { return value.UUIDValueFromUUID(u) }
+
+// UUIDValueFromString makes UUID value from text form of UUID.
+// Canonical (6ba7b810-9dad-11d1-80b4-00c04fd430c8), braced ({6ba7b810-9dad-11d1-80b4-00c04fd430c8}),
+// URN (urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8) and 32 hex digits forms are supported
+func UUIDValueFromString(s string) (Value, error) {
+	v, err := value.UUIDValueFromString(s)
+	if err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	return v, nil
+}
+
 func JSONDocumentValue(v string) Value { return value.JSONDocumentValue(v) }
 
 // JSONDocumentValueFromBytes makes JSONDocument value from bytes
@@ -163,16 +404,161 @@ func JSONDocumentValueFromBytes(v []byte) Value {
 	return value.JSONDocumentValue(xstring.FromBytes(v))
 }
 
+type tJSONOptions struct {
+	noEscapeHTML bool
+	prefix       string
+	indent       string
+}
+
+// JSONOption is an option of JSONValueFromObject and JSONDocumentValueFromObject
+type JSONOption func(*tJSONOptions)
+
+// WithJSONEscapeHTML defines escaping of HTML characters <, > and & in JSON strings
+// (characters are escaped by default, such as json.Marshal does)
+func WithJSONEscapeHTML(escape bool) JSONOption {
+	return func(o *tJSONOptions) {
+		o.noEscapeHTML = !escape
+	}
+}
+
+// WithJSONIndent makes indented JSON such as json.MarshalIndent does (JSON is compact by default)
+func WithJSONIndent(prefix, indent string) JSONOption {
+	return func(o *tJSONOptions) {
+		o.prefix = prefix
+		o.indent = indent
+	}
+}
+
+func marshalJSON(v interface{}, opts []JSONOption) ([]byte, error) {
+	var o tJSONOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(!o.noEscapeHTML)
+	enc.SetIndent(o.prefix, o.indent)
+	if err := enc.Encode(v); err != nil {
+		return nil, xerrors.WithStackTrace(err)
+	}
+	// json.Encoder terminates each value with a newline
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
+// JSONValueFromObject makes JSON value from object v marshaled with json.Marshal
+// semantics or returns error of marshaling
+func JSONValueFromObject(v interface{}, opts ...JSONOption) (Value, error) {
+	data, err := marshalJSON(v, opts)
+	if err != nil {
+		return nil, err
+	}
+	return value.JSONValue(xstring.FromBytes(data)), nil
+}
+
+// JSONDocumentValueFromObject makes JSONDocument value from object v marshaled with
+// json.Marshal semantics or returns error of marshaling
+func JSONDocumentValueFromObject(v interface{}, opts ...JSONOption) (Value, error) {
+	data, err := marshalJSON(v, opts)
+	if err != nil {
+		return nil, err
+	}
+	return value.JSONDocumentValue(xstring.FromBytes(data)), nil
+}
+
 func DyNumberValue(v string) Value { return value.DyNumberValue(v) }
 
+// DyNumberValueFromFloat64 makes DyNumber value from float64 in canonical DyNumber textual form
+//
+// Returns error if v is NaN or infinity
+func DyNumberValueFromFloat64(v float64) (Value, error) {
+	dyNumber, err := value.DyNumberValueFromFloat64(v)
+	if err != nil {
+		return nil, err
+	}
+	return dyNumber, nil
+}
+
+// DyNumberValueFromBigFloat makes DyNumber value from big.Float in canonical DyNumber textual form
+//
+// Returns error if v is nil or infinity
+func DyNumberValueFromBigFloat(v *big.Float) (Value, error) {
+	dyNumber, err := value.DyNumberValueFromBigFloat(v)
+	if err != nil {
+		return nil, err
+	}
+	return dyNumber, nil
+}
+
 func VoidValue() Value { return value.VoidValue() }
 
 func NullValue(t Type) Value { return value.NullValue(t) }
 
 func ZeroValue(t Type) Value { return value.ZeroValue(t) }
 
+// ValuesEqual checks for equivalence of types and contents of values.
+// Items of lists and tuples and fields of structs are compared in order,
+// pairs of dicts and items of sets are compared regardless of order
+func ValuesEqual(lhs, rhs Value) bool { return value.Equal(lhs, rhs) }
+
+// CloneValue returns deep copy of v which doesn't alias memory of caller (such as slices
+// of BytesValue and YSONValueFromBytes), so clone is safe to retain after the slices are reused
+func CloneValue(v Value) Value { return value.Clone(v) }
+
+// MarshalJSON renders v as JSON object `{"type":"<YQL type>","value":<JSON value>}`.
+// Integers out of range [-2^53, 2^53], decimals and special floats are rendered as strings,
+// times are rendered in UTC, bytes as base64, secrets as "***"
+func MarshalJSON(v Value) ([]byte, error) { return value.MarshalJSON(v) }
+
+// EstimatedSize returns approximate size in bytes of value v serialized with its type
+// for sending to YDB. EstimatedSize doesn't allocate protobuf messages, so it is intended
+// for splitting of big values (such as rows for table.Session.BulkUpsert) into batches
+func EstimatedSize(v Value) int { return value.EstimatedSize(v) }
+
+// UnmarshalJSON parses JSON object rendered by MarshalJSON into value of type t.
+// Type in JSON must be equal to t, values out of range of t are rejected
+func UnmarshalJSON(t Type, data []byte) (Value, error) { return value.UnmarshalJSON(t, data) }
+
 func OptionalValue(v Value) Value { return value.OptionalValue(v) }
 
+// TaggedValue returns value of type Tagged<T,tag> where T is a type of v. Tagged value is
+// cast to destinations of v, tag and inner value are available with methods Tag() and InnerValue()
+func TaggedValue(tag string, v Value) Value { return value.TaggedValue(tag, v) }
+
+// PgValue returns value of pg type with given OID from PostgreSQL text representation.
+// Pg value is cast to *string and *[]byte destinations
+func PgValue(oid uint32, text string) Value { return value.PgValue(oid, text) }
+
+// PgNullValue returns NULL value of pg type with given OID
+func PgNullValue(oid uint32) Value { return value.PgNullValue(oid) }
+
+// Secret wraps v (such as password or token) into value which is sent to YDB as v,
+// but is rendered as "***" in string representations: Value.Yql(), fmt verbs,
+// dumps of query parameters (such as table.QueryParameters.String() in traces and logs)
+// and errors of casting. Secret values nested into containers (lists, structs, etc.)
+// are redacted too.
+//
+// Type of secret value is a type of v. Secret values are ordered and compared
+// (as keys of dicts and items of sets) as v.
+func Secret(v Value) Value { return value.SecretValue(v) }
+
+// OptionalValuer is an interface of Optional values (including values of Optional type
+// which are returned from results of queries)
+type OptionalValuer = value.OptionalValuer
+
+// UnwrapOptional returns inner non-optional value of optional value v of any nesting depth
+// or nil and false if v is NULL at any level of nesting.
+// Non-optional value v is returned as is.
+func UnwrapOptional(v Value) (Value, bool) {
+	return value.Unwrap(v)
+}
+
+// DecimalSetter is an interface of decimal destinations for CastTo (such as wrappers of
+// github.com/shopspring/decimal) which are set from unscaled value and scale:
+// decimal value is equal to unscaled * 10^(-scale)
+type DecimalSetter = value.DecimalSetter
+
 // Decimal supported in scanner API
 type Decimal struct {
 	Bytes     [16]byte
@@ -181,8 +567,7 @@ type Decimal struct {
 }
 
 func (d *Decimal) String() string {
-	v := decimal.FromInt128(d.Bytes, d.Precision, d.Scale)
-	return decimal.Format(v, d.Precision, d.Scale)
+	return decimal.Format(d.unscaled(), d.Precision, d.Scale)
 }
 
 func (d *Decimal) BigInt() *big.Int {
@@ -195,18 +580,90 @@ func DecimalValue(v *Decimal) Value {
 	return value.DecimalValue(v.Bytes, v.Precision, v.Scale)
 }
 
+// DecimalValueE creates decimal value as DecimalValue and returns error if precision
+// is out of range [1,35] or scale is greater than precision
+func DecimalValueE(v *Decimal) (Value, error) {
+	vv, err := value.DecimalValueE(v.Bytes, v.Precision, v.Scale)
+	if err != nil {
+		return nil, err
+	}
+	return vv, nil
+}
+
+// BigEndianUint128 builds a big-endian uint128 value (such as Decimal.Bytes or UUID bytes)
+// from high and low halves
+func BigEndianUint128(hi, lo uint64) [16]byte { return value.BigEndianUint128(hi, lo) }
+
+// Uint128FromBytes splits a big-endian uint128 value into high and low halves.
+// Uint128FromBytes is an inverse of BigEndianUint128
+func Uint128FromBytes(v [16]byte) (hi, lo uint64) { return value.Uint128FromBytes(v) }
+
+// DecimalValueFromBigInt makes decimal value v * 10^(-scale) of type Decimal(precision, scale)
+// without checks of type and range of v (v which does not fit into precision is turned into infinity).
+// Use DecimalValueFromBigIntE for checked values
 func DecimalValueFromBigInt(v *big.Int, precision, scale uint32) Value {
 	return value.DecimalValueFromBigInt(v, precision, scale)
 }
 
+// DecimalValueFromBigIntE makes decimal value v * 10^(-scale) of type Decimal(precision, scale)
+// and returns error if precision is out of range [1,35], scale is greater than precision
+// or v does not fit into precision (such error wraps ErrDecimalOverflow)
+func DecimalValueFromBigIntE(v *big.Int, precision, scale uint32) (Value, error) {
+	vv, err := value.DecimalValueFromBigIntE(v, precision, scale)
+	if err != nil {
+		return nil, err
+	}
+	return vv, nil
+}
+
+// DecimalValueFromStringWithRounding parses decimal value of type Decimal(precision, scale)
+// from text s (such as "-12.345", "inf" or "nan"). Excess digits of fraction are rounded
+// with mode: DecimalRoundHalfEven rounds as YDB does, DecimalRoundUnnecessary returns error
+// wrapping ErrDecimalInexact instead of rounding.
+//
+// DecimalValueFromStringWithRounding returns error if precision is out of range [1,35],
+// scale is greater than precision, s is malformed or finite s does not fit into precision
+func DecimalValueFromStringWithRounding(s string, precision, scale uint32, mode DecimalRoundingMode) (Value, error) {
+	v, err := value.DecimalValueFromStringWithRounding(s, precision, scale, mode)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DecimalValueFromUnscaled makes decimal value unscaled * 10^(-scale) of type Decimal(precision, scale)
+// (counterpart of DecimalSetter destination)
+//
+// Negative scale is normalized to zero scale. DecimalValueFromUnscaled returns error
+// if scale is greater than precision or value does not fit into precision.
+func DecimalValueFromUnscaled(unscaled *big.Int, scale int32, precision uint32) (Value, error) {
+	v, err := value.DecimalValueFromUnscaled(unscaled, scale, precision)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
 func TupleValue(vs ...Value) Value {
 	return value.TupleValue(vs...)
 }
 
+// ListValue makes list value of vs.
+// ListValue panics if vs have different types (see ListValueE)
 func ListValue(vs ...Value) Value {
 	return value.ListValue(vs...)
 }
 
+// ListValueE makes list value of vs or returns error if vs have different types.
+// Values of type T are mixed with values of type Optional<T> (such as nulls) into List<Optional<T>>
+func ListValueE(vs ...Value) (Value, error) {
+	v, err := value.ListValueE(vs...)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
 func SetValue(vs ...Value) Value {
 	return value.SetValue(vs...)
 }
@@ -233,8 +690,22 @@ func StructValue(opts ...StructValueOption) Value {
 	return value.StructValue(p.fields...)
 }
 
+// StructValueOrdered makes struct value with fields in order of options
+// (StructValue sorts fields by name). Type of value is a struct type with
+// fields in the same order, so value matches struct types declared in such order
+func StructValueOrdered(opts ...StructValueOption) Value {
+	var p structValueFields
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return value.StructValueOrdered(p.fields...)
+}
+
 type dictValueFields struct {
-	fields []value.DictValueField
+	fields         []value.DictValueField
+	permissiveKeys bool
 }
 
 type DictValueOption func(*dictValueFields)
@@ -245,6 +716,18 @@ func DictFieldValue(k, v Value) DictValueOption {
 	}
 }
 
+// WithPermissiveDictKeys disables check of type of dict keys in DictValueE, so dict value
+// is constructed with keys of any type (such dict may be rejected by YDB)
+func WithPermissiveDictKeys() DictValueOption {
+	return func(t *dictValueFields) {
+		t.permissiveKeys = true
+	}
+}
+
+// DictValue makes dict value with type inferred from the first pair.
+// Dict without pairs has type EmptyDict (use DictValueOfTypes for empty dicts of given types).
+//
+// Type of keys is not checked, use DictValueE for checking of type of keys
 func DictValue(opts ...DictValueOption) Value {
 	var p dictValueFields
 	for _, opt := range opts {
@@ -255,10 +738,78 @@ func DictValue(opts ...DictValueOption) Value {
 	return value.DictValue(p.fields...)
 }
 
+// DictValueE makes dict value with type inferred from the first pair or returns error
+// if type of keys is not allowed as dict key: keys of dict can be values of primitive types
+// (except Json, JsonDocument and Yson), decimals, tuples and optionals of such types
+// (see WithPermissiveDictKeys)
+func DictValueE(opts ...DictValueOption) (Value, error) {
+	var p dictValueFields
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	if p.permissiveKeys {
+		return value.DictValue(p.fields...), nil
+	}
+	v, err := value.DictValueE(p.fields...)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DictValueOfTypes makes dict value of type Dict<keyType,valueType> (also without pairs).
+// DictValueOfTypes panics if types of keys or values of pairs differ from keyType or valueType.
+//
+// Type of keys is not checked (see DictValueE)
+func DictValueOfTypes(keyType, valueType Type, opts ...DictValueOption) Value {
+	var p dictValueFields
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	return value.DictValueOfTypes(keyType, valueType, p.fields...)
+}
+
+// DictValueOfTypesE makes dict value of type Dict<keyType,valueType> (also without pairs) or returns
+// error if types of keys or values of pairs differ from keyType or valueType and if keyType is not
+// allowed as dict key (see DictValueE and WithPermissiveDictKeys)
+func DictValueOfTypesE(keyType, valueType Type, opts ...DictValueOption) (Value, error) {
+	var p dictValueFields
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&p)
+		}
+	}
+	if !p.permissiveKeys {
+		if err := value.CheckDictKeyType(keyType); err != nil {
+			return nil, err
+		}
+	}
+	v, err := value.DictValueOfTypesE(keyType, valueType, p.fields...)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
 func VariantValueStruct(v Value, name string, variantT Type) Value {
 	return value.VariantValueStruct(v, name, variantT)
 }
 
+// VariantValueStructByName makes value of struct variant type variantT (Struct or Variant over Struct)
+// with alternative name. VariantValueStructByName returns error if variantT has no alternative name
+// or if type of v differs from type of alternative
+func VariantValueStructByName(v Value, name string, variantT Type) (Value, error) {
+	vv, err := value.VariantValueStructByName(v, name, variantT)
+	if err != nil {
+		return nil, err
+	}
+	return vv, nil
+}
+
 func VariantValueTuple(v Value, i uint32, variantT Type) Value {
 	return value.VariantValueTuple(v, i, variantT)
 }
@@ -428,10 +979,7 @@ func NullableTzTimestampValueFromTime(v *time.Time) Value {
 //
 // Deprecated: use NullableIntervalValueFromMicroseconds instead
 func NullableIntervalValue(v *int64) Value {
-	if v == nil {
-		return NullValue(TypeInterval)
-	}
-	return OptionalValue(IntervalValue(*v))
+	return NullableIntervalValueFromMicroseconds(v)
 }
 
 func NullableIntervalValueFromMicroseconds(v *int64) Value {
@@ -452,10 +1000,7 @@ func NullableIntervalValueFromDuration(v *time.Duration) Value {
 //
 // Deprecated: use NullableBytesValue instead
 func NullableStringValue(v *[]byte) Value {
-	if v == nil {
-		return NullValue(TypeBytes)
-	}
-	return OptionalValue(StringValue(*v))
+	return NullableBytesValue(v)
 }
 
 func NullableBytesValue(v *[]byte) Value {
@@ -465,11 +1010,11 @@ func NullableBytesValue(v *[]byte) Value {
 	return OptionalValue(BytesValue(*v))
 }
 
+// NullableStringValueFromString makes Value which maybe nil or valued
+//
+// Deprecated: use NullableBytesValueFromString instead
 func NullableStringValueFromString(v *string) Value {
-	if v == nil {
-		return NullValue(TypeBytes)
-	}
-	return OptionalValue(BytesValueFromString(*v))
+	return NullableBytesValueFromString(v)
 }
 
 func NullableBytesValueFromString(v *string) Value {
@@ -479,11 +1024,11 @@ func NullableBytesValueFromString(v *string) Value {
 	return OptionalValue(BytesValueFromString(*v))
 }
 
+// NullableUTF8Value makes Value which maybe nil or valued
+//
+// Deprecated: use NullableTextValue instead
 func NullableUTF8Value(v *string) Value {
-	if v == nil {
-		return NullValue(TypeText)
-	}
-	return OptionalValue(TextValue(*v))
+	return NullableTextValue(v)
 }
 
 func NullableTextValue(v *string) Value {
@@ -549,155 +1094,246 @@ func NullableDyNumberValue(v *string) Value {
 	return OptionalValue(DyNumberValue(*v))
 }
 
+var (
+	errNullableUnsupportedType       = errors.New("unsupported type of nullable value")
+	errNullableUnsupportedConversion = errors.New("unsupported conversion of nullable value")
+)
+
 // Nullable makes optional value from nullable type
 // Warning: type interface will be replaced in the future with typed parameters pattern from go1.18
 //
-//nolint:gocyclo
+// Nullable panics on unsupported type t or type of v (see NullableE)
 func Nullable(t Type, v interface{}) Value {
+	vv, err := NullableE(t, v)
+	if err != nil {
+		panic(err)
+	}
+	return vv
+}
+
+// NullableE makes optional value of primitive type t from pointer v: NULL if v is nil
+// (typed or untyped), otherwise optional value of pointed go value.
+// NullableE returns error on unsupported type t or type of v
+//
+//nolint:gocyclo
+func NullableE(t Type, v interface{}) (Value, error) {
+	if v == nil {
+		if _, ok := t.(value.PrimitiveType); !ok {
+			return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s", errNullableUnsupportedType, t.Yql()))
+		}
+		return NullValue(t), nil
+	}
 	switch t {
 	case TypeBool:
-		return NullableBoolValue(v.(*bool))
+		switch tt := v.(type) {
+		case *bool:
+			return NullableBoolValue(tt), nil
+		}
 	case TypeInt8:
-		return NullableInt8Value(v.(*int8))
+		switch tt := v.(type) {
+		case *int8:
+			return NullableInt8Value(tt), nil
+		}
 	case TypeUint8:
-		return NullableUint8Value(v.(*uint8))
+		switch tt := v.(type) {
+		case *uint8:
+			return NullableUint8Value(tt), nil
+		}
 	case TypeInt16:
-		return NullableInt16Value(v.(*int16))
+		switch tt := v.(type) {
+		case *int16:
+			return NullableInt16Value(tt), nil
+		}
 	case TypeUint16:
-		return NullableUint16Value(v.(*uint16))
+		switch tt := v.(type) {
+		case *uint16:
+			return NullableUint16Value(tt), nil
+		}
 	case TypeInt32:
-		return NullableInt32Value(v.(*int32))
+		switch tt := v.(type) {
+		case *int32:
+			return NullableInt32Value(tt), nil
+		}
 	case TypeUint32:
-		return NullableUint32Value(v.(*uint32))
+		switch tt := v.(type) {
+		case *uint32:
+			return NullableUint32Value(tt), nil
+		}
 	case TypeInt64:
-		return NullableInt64Value(v.(*int64))
+		switch tt := v.(type) {
+		case *int64:
+			return NullableInt64Value(tt), nil
+		}
 	case TypeUint64:
-		return NullableUint64Value(v.(*uint64))
+		switch tt := v.(type) {
+		case *uint64:
+			return NullableUint64Value(tt), nil
+		}
 	case TypeFloat:
-		return NullableFloatValue(v.(*float32))
+		switch tt := v.(type) {
+		case *float32:
+			return NullableFloatValue(tt), nil
+		}
 	case TypeDouble:
-		return NullableDoubleValue(v.(*float64))
+		switch tt := v.(type) {
+		case *float64:
+			return NullableDoubleValue(tt), nil
+		}
 	case TypeDate:
 		switch tt := v.(type) {
 		case *uint32:
-			return NullableDateValue(tt)
+			return NullableDateValue(tt), nil
 		case *time.Time:
-			return NullableDateValueFromTime(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeDate", tt))
+			return NullableDateValueFromTime(tt), nil
 		}
 	case TypeDatetime:
 		switch tt := v.(type) {
 		case *uint32:
-			return NullableDatetimeValue(tt)
+			return NullableDatetimeValue(tt), nil
 		case *time.Time:
-			return NullableDatetimeValueFromTime(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeDatetime", tt))
+			return NullableDatetimeValueFromTime(tt), nil
 		}
 	case TypeTimestamp:
 		switch tt := v.(type) {
 		case *uint64:
-			return NullableTimestampValue(tt)
+			return NullableTimestampValue(tt), nil
 		case *time.Time:
-			return NullableTimestampValueFromTime(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeTimestamp", tt))
+			return NullableTimestampValueFromTime(tt), nil
 		}
 	case TypeInterval:
 		switch tt := v.(type) {
 		case *int64:
-			return NullableIntervalValueFromMicroseconds(tt)
+			return NullableIntervalValueFromMicroseconds(tt), nil
 		case *time.Duration:
-			return NullableIntervalValueFromDuration(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeInterval", tt))
+			return NullableIntervalValueFromDuration(tt), nil
 		}
 	case TypeTzDate:
 		switch tt := v.(type) {
 		case *string:
-			return NullableTzDateValue(tt)
+			return NullableTzDateValue(tt), nil
 		case *time.Time:
-			return NullableTzDateValueFromTime(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeTzDate", tt))
+			return NullableTzDateValueFromTime(tt), nil
 		}
 	case TypeTzDatetime:
 		switch tt := v.(type) {
 		case *string:
-			return NullableTzDatetimeValue(tt)
+			return NullableTzDatetimeValue(tt), nil
 		case *time.Time:
-			return NullableTzDatetimeValueFromTime(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeTzDatetime", tt))
+			return NullableTzDatetimeValueFromTime(tt), nil
 		}
 	case TypeTzTimestamp:
 		switch tt := v.(type) {
 		case *string:
-			return NullableTzTimestampValue(tt)
+			return NullableTzTimestampValue(tt), nil
 		case *time.Time:
-			return NullableTzTimestampValueFromTime(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeTzTimestamp", tt))
+			return NullableTzTimestampValueFromTime(tt), nil
 		}
 	case TypeBytes:
 		switch tt := v.(type) {
 		case *[]byte:
-			return NullableBytesValue(tt)
+			return NullableBytesValue(tt), nil
 		case *string:
-			return NullableStringValueFromString(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeBytes", tt))
+			return NullableBytesValueFromString(tt), nil
 		}
 	case TypeText:
 		switch tt := v.(type) {
 		case *string:
-			return NullableTextValue(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeText", tt))
+			return NullableTextValue(tt), nil
 		}
 	case TypeYSON:
 		switch tt := v.(type) {
 		case *string:
-			return NullableYSONValue(tt)
+			return NullableYSONValue(tt), nil
 		case *[]byte:
-			return NullableYSONValueFromBytes(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeYSON", tt))
+			return NullableYSONValueFromBytes(tt), nil
 		}
 	case TypeJSON:
 		switch tt := v.(type) {
 		case *string:
-			return NullableJSONValue(tt)
+			return NullableJSONValue(tt), nil
 		case *[]byte:
-			return NullableJSONValueFromBytes(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeJSON", tt))
+			return NullableJSONValueFromBytes(tt), nil
 		}
 	case TypeUUID:
 		switch tt := v.(type) {
 		case *[16]byte:
-			return NullableUUIDValue(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeUUID", tt))
+			return NullableUUIDValue(tt), nil
 		}
 	case TypeJSONDocument:
 		switch tt := v.(type) {
 		case *string:
-			return NullableJSONDocumentValue(tt)
+			return NullableJSONDocumentValue(tt), nil
 		case *[]byte:
-			return NullableJSONDocumentValueFromBytes(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeJSONDocument", tt))
+			return NullableJSONDocumentValueFromBytes(tt), nil
 		}
 	case TypeDyNumber:
 		switch tt := v.(type) {
 		case *string:
-			return NullableDyNumberValue(tt)
-		default:
-			panic(fmt.Sprintf("unsupported type conversion from %T to TypeDyNumber", tt))
+			return NullableDyNumberValue(tt), nil
 		}
 	default:
-		panic(fmt.Sprintf("unsupported type: %T", t))
+		return nil, xerrors.WithStackTrace(fmt.Errorf("%w: %s", errNullableUnsupportedType, t.Yql()))
 	}
+	return nil, xerrors.WithStackTrace(fmt.Errorf("%w from %T to %s", errNullableUnsupportedConversion, v, t.Yql()))
+}
+
+// ValueFromGoOption is an option of ValueFromGo
+type ValueFromGoOption = value.FromGoOption
+
+// WithStringAs defines YDB type of go strings in ValueFromGo (Text by default).
+// t must be one of TypeText, TypeBytes, TypeJSON, TypeJSONDocument, TypeYSON or TypeDyNumber
+func WithStringAs(t Type) ValueFromGoOption {
+	return value.WithStringAs(t)
+}
+
+// WithTimeAs defines YDB type of go times in ValueFromGo (Timestamp by default).
+// t must be one of TypeDate, TypeDatetime, TypeTimestamp, TypeDate32, TypeDatetime64,
+// TypeTimestamp64, TypeTzDate, TypeTzDatetime or TypeTzTimestamp
+func WithTimeAs(t Type) ValueFromGoOption {
+	return value.WithTimeAs(t)
+}
+
+// WithDurationAs defines YDB type of go durations in ValueFromGo (Interval by default).
+// t must be one of TypeInterval or TypeInterval64
+func WithDurationAs(t Type) ValueFromGoOption {
+	return value.WithDurationAs(t)
+}
+
+// ValueFromGo converts go value v to YDB value with reflection:
+//   - Value is returned as is
+//   - bool to Bool
+//   - int8, int16, int32, int64 to Int8, Int16, Int32, Int64 (int to Int64)
+//   - uint8, uint16, uint32, uint64 to Uint8, Uint16, Uint32, Uint64 (uint to Uint64)
+//   - float32, float64 to Float, Double
+//   - string to Text (see WithStringAs option)
+//   - []byte to Bytes
+//   - [16]byte (such as uuid.UUID) to UUID
+//   - time.Time to Timestamp (see WithTimeAs option)
+//   - time.Duration to Interval (see WithDurationAs option)
+//   - Decimal to Decimal of its precision and scale, big.Int v to Decimal v * 10^(-scale) of precision
+//     and scale from tag option `decimal(p,s)` of field (such as `ydb:"price,decimal(22,9)"`). Zero Decimal
+//     takes precision and scale from tag option. Tag option is required for big.Int and for nil pointers
+//   - non-nil pointer to Optional of value of element, nil pointer to NULL of Optional of type of element
+//   - slice and array to List of values of items (List of type of item for empty slices)
+//   - map to Dict of values of keys and values (Dict of types of key and value for empty maps)
+//   - struct to Struct of values of exported fields, which are named with tag `ydb:"name"`
+//     (or as go fields). Fields with tag `ydb:"-"` are skipped. Tag option `type=` overrides type
+//     of strings, times or durations of field (such as `ydb:"payload,type=Json"` or `ydb:"day,type=Date"`)
+//     and tag option `secret` wraps values of field into Secret (such as `ydb:"password,secret"`)
+//   - untyped nil to Void
+//
+// Types of values of empty containers and nil pointers are derived from go types, so elements of
+// such containers and pointers must not be interfaces. Named go types are converted as their
+// underlying types. ValueFromGo returns error on unsupported go types and on items of slices
+// or pairs of maps of different YDB types.
+func ValueFromGo(v interface{}, opts ...ValueFromGoOption) (Value, error) {
+	return value.FromGo(v, opts...)
+}
+
+// TypeFromGoType returns YDB type of values which ValueFromGo makes from values of go type t
+// with the same options. Types of interfaces (including Value) are known only for their values,
+// so TypeFromGoType returns error on them. Errors name the offending go type and path to it
+// in t (such as `field Orders[].Price`)
+func TypeFromGoType(t reflect.Type, opts ...ValueFromGoOption) (Type, error) {
+	return value.TypeFromGoType(t, opts...)
 }
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -380,6 +382,18 @@ func TestNullable(t *testing.T) {
 			v:    func() *string { return nil }(),
 			exp:  NullValue(TypeDyNumber),
 		},
+		{
+			name: "nil uuid",
+			t:    TypeUUID,
+			v:    func() *[16]byte { return nil }(),
+			exp:  NullValue(TypeUUID),
+		},
+		{
+			name: "untyped nil",
+			t:    TypeInt64,
+			v:    nil,
+			exp:  NullValue(TypeInt64),
+		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			a := allocator.New()
@@ -392,11 +406,58 @@ func TestNullable(t *testing.T) {
 	}
 }
 
+func TestNullableE(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		t    Type
+		v    interface{}
+		err  error
+	}{
+		{
+			name: "value instead of pointer",
+			t:    TypeInt64,
+			v:    int64(1),
+			err:  errNullableUnsupportedConversion,
+		},
+		{
+			name: "pointer of other type",
+			t:    TypeInt64,
+			v:    func(v int32) *int32 { return &v }(1),
+			err:  errNullableUnsupportedConversion,
+		},
+		{
+			name: "not primitive type",
+			t:    List(TypeInt64),
+			v:    func(v int64) *int64 { return &v }(1),
+			err:  errNullableUnsupportedType,
+		},
+		{
+			name: "untyped nil of not primitive type",
+			t:    List(TypeInt64),
+			v:    nil,
+			err:  errNullableUnsupportedType,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			v, err := NullableE(test.t, test.v)
+			require.ErrorIs(t, err, test.err)
+			require.Nil(t, v)
+			require.Panics(t, func() {
+				_ = Nullable(test.t, test.v)
+			})
+		})
+	}
+	_, err := NullableE(TypeInt64, "1")
+	require.ErrorContains(t, err, "unsupported conversion of nullable value from string to Int64")
+}
+
 func TestCastNumbers(t *testing.T) {
 	numberValues := []struct {
 		value  Value
 		signed bool
 		len    int
+		// checked lists destinations with range-checked narrowing
+		checked map[string]bool
 	}{
 		{
 			value:  Uint64Value(1),
@@ -407,21 +468,33 @@ func TestCastNumbers(t *testing.T) {
 			value:  Int64Value(2),
 			signed: true,
 			len:    8,
+			checked: map[string]bool{
+				"int32": true, "int16": true, "int8": true, "uint64": true, "uint32": true,
+			},
 		},
 		{
 			value:  Uint32Value(3),
 			signed: false,
 			len:    4,
+			checked: map[string]bool{
+				"int32": true,
+			},
 		},
 		{
 			value:  Int32Value(4),
 			signed: true,
 			len:    4,
+			checked: map[string]bool{
+				"int16": true, "int8": true,
+			},
 		},
 		{
 			value:  Uint16Value(5),
 			signed: false,
 			len:    2,
+			checked: map[string]bool{
+				"int16": true,
+			},
 		},
 		{
 			value:  Int16Value(6),
@@ -432,6 +505,9 @@ func TestCastNumbers(t *testing.T) {
 			value:  Uint8Value(7),
 			signed: false,
 			len:    1,
+			checked: map[string]bool{
+				"int8": true,
+			},
 		},
 		{
 			value:  Int8Value(8),
@@ -504,7 +580,7 @@ func TestCastNumbers(t *testing.T) {
 					case src.len == dst.len && src.signed != dst.signed,
 						src.len > dst.len,
 						src.signed && !dst.signed:
-						mustErr = true
+						mustErr = !src.checked[reflect.ValueOf(dst.destination).Type().Elem().String()]
 					}
 					err := CastTo(src.value, dst.destination)
 					if mustErr {
@@ -519,7 +595,7 @@ func TestCastNumbers(t *testing.T) {
 					case src.len == dst.len && src.signed != dst.signed,
 						src.len > dst.len,
 						src.signed && !dst.signed:
-						mustErr = true
+						mustErr = !src.checked[reflect.ValueOf(dst.destination).Type().Elem().String()]
 					}
 					err := CastTo(OptionalValue(src.value), dst.destination)
 					if mustErr {
@@ -615,3 +691,178 @@ func TestCastOtherTypes(t *testing.T) {
 		)
 	}
 }
+
+func TestValueFromGo(t *testing.T) {
+	type row struct {
+		ID      uint64            `ydb:"id"`
+		Payload string            `ydb:"payload"`
+		Labels  map[string]string `ydb:"labels"`
+		Deleted *time.Time        `ydb:"deleted_at"`
+	}
+	v, err := ValueFromGo([]row{{ID: 1, Payload: `{}`}}, WithStringAs(TypeJSON))
+	require.NoError(t, err)
+	require.True(t, Equal(v.Type(), List(Struct(
+		StructField("deleted_at", Optional(TypeTimestamp)),
+		StructField("id", TypeUint64),
+		StructField("labels", Dict(TypeJSON, TypeJSON)),
+		StructField("payload", TypeJSON),
+	))))
+	require.Equal(t,
+		"[<|`deleted_at`:Nothing(Optional<Timestamp>),`id`:1ul,`labels`:{},`payload`:Json(\"{}\")|>]",
+		v.Yql(),
+	)
+
+	_, err = ValueFromGo(make(chan int))
+	require.Error(t, err)
+}
+
+func TestDictValueKeyType(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		k    Value
+	}{
+		{"List", ListValue(Int32Value(1))},
+		{"TupleOfList", TupleValue(Int32Value(1), ListValue(Int32Value(1)))},
+		{"Struct", StructValue(StructFieldValue("a", Int32Value(1)))},
+		{"Dict", DictValue(DictFieldValue(TextValue("a"), Int32Value(1)))},
+		{"JSON", JSONValue(`{}`)},
+		{"OptionalYSON", OptionalValue(YSONValue("{}"))},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DictValueE(DictFieldValue(tt.k, Int32Value(1)))
+			require.ErrorContains(t, err, "type is not allowed as dict key: "+tt.k.Type().Yql())
+			// DictValue and DictValueOfTypes don't check type of keys
+			require.NotPanics(t, func() {
+				_ = DictValue(DictFieldValue(tt.k, Int32Value(1)))
+			})
+			require.NotPanics(t, func() {
+				_ = DictValueOfTypes(tt.k.Type(), TypeInt32)
+			})
+
+			v, err := DictValueE(DictFieldValue(tt.k, Int32Value(1)), WithPermissiveDictKeys())
+			require.NoError(t, err)
+			require.Equal(t, Dict(tt.k.Type(), TypeInt32), v.Type())
+			require.NotPanics(t, func() {
+				_ = DictValueOfTypes(tt.k.Type(), TypeInt32, WithPermissiveDictKeys())
+			})
+			_, err = DictValueOfTypesE(tt.k.Type(), TypeInt32)
+			require.ErrorContains(t, err, "type is not allowed as dict key: "+tt.k.Type().Yql())
+			v, err = DictValueOfTypesE(tt.k.Type(), TypeInt32, WithPermissiveDictKeys())
+			require.NoError(t, err)
+			require.Equal(t, Dict(tt.k.Type(), TypeInt32), v.Type())
+		})
+	}
+	t.Run("TupleKey", func(t *testing.T) {
+		v, err := DictValueE(
+			DictFieldValue(TupleValue(Int32Value(1), OptionalValue(TextValue("a"))), Int32Value(1)),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "Dict<Tuple<Int32,Optional<Utf8>>,Int32>", v.Type().Yql())
+	})
+	t.Run("OptionalKey", func(t *testing.T) {
+		v, err := DictValueE(
+			DictFieldValue(OptionalValue(TextValue("a")), Int32Value(1)),
+			DictFieldValue(NullValue(TypeText), Int32Value(2)),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "Dict<Optional<Utf8>,Int32>", v.Type().Yql())
+	})
+}
+
+func TestVariantValueStructByName(t *testing.T) {
+	typ := Struct(
+		StructField("foo", TypeText),
+		StructField("bar", TypeInt32),
+	)
+	v, err := VariantValueStructByName(Int32Value(42), "bar", typ)
+	require.NoError(t, err)
+	require.Equal(t, VariantValueStruct(Int32Value(42), "bar", typ), v)
+
+	v, err = VariantValueStructByName(Int32Value(42), "baz", typ)
+	require.ErrorContains(t, err, "unknown alternative of variant: 'baz'")
+	require.Nil(t, v)
+}
+
+func TestJSONValueFromObject(t *testing.T) {
+	type object struct {
+		Name      string          `json:"name"`
+		CreatedAt time.Time       `json:"created_at"`
+		Raw       json.RawMessage `json:"raw"`
+	}
+	src := object{
+		Name:      "<a&b>",
+		CreatedAt: time.Date(2022, 6, 17, 5, 19, 20, 0, time.UTC),
+		Raw:       json.RawMessage(`{"x": [1, 2]}`),
+	}
+	for _, tt := range []struct {
+		name string
+		opts []JSONOption
+		exp  string
+	}{
+		{
+			name: "Compact",
+			exp:  `{"name":"\u003ca\u0026b\u003e","created_at":"2022-06-17T05:19:20Z","raw":{"x":[1,2]}}`,
+		},
+		{
+			name: "NoEscapeHTML",
+			opts: []JSONOption{WithJSONEscapeHTML(false)},
+			exp:  `{"name":"<a&b>","created_at":"2022-06-17T05:19:20Z","raw":{"x":[1,2]}}`,
+		},
+		{
+			name: "Indent",
+			opts: []JSONOption{WithJSONIndent("", " ")},
+			exp:  "{\n \"name\": \"\\u003ca\\u0026b\\u003e\",\n \"created_at\": \"2022-06-17T05:19:20Z\",\n \"raw\": {\n  \"x\": [\n   1,\n   2\n  ]\n }\n}",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, f := range []struct {
+				name string
+				t    Type
+				make func(v interface{}, opts ...JSONOption) (Value, error)
+			}{
+				{name: "JSON", t: TypeJSON, make: JSONValueFromObject},
+				{name: "JSONDocument", t: TypeJSONDocument, make: JSONDocumentValueFromObject},
+			} {
+				t.Run(f.name, func(t *testing.T) {
+					v, err := f.make(src, tt.opts...)
+					require.NoError(t, err)
+					require.True(t, Equal(f.t, v.Type()))
+					var dst string
+					require.NoError(t, CastTo(v, &dst))
+					require.Equal(t, tt.exp, dst)
+					var decoded object
+					require.NoError(t, json.Unmarshal([]byte(dst), &decoded))
+					require.True(t, src.CreatedAt.Equal(decoded.CreatedAt))
+				})
+			}
+		})
+	}
+	t.Run("Error", func(t *testing.T) {
+		_, err := JSONValueFromObject(make(chan int))
+		var unsupported *json.UnsupportedTypeError
+		require.ErrorAs(t, err, &unsupported)
+		_, err = JSONDocumentValueFromObject(json.RawMessage(`{`))
+		var marshaler *json.MarshalerError
+		require.ErrorAs(t, err, &marshaler)
+	})
+}
+
+func TestDictValueOfTypesE(t *testing.T) {
+	v, err := DictValueOfTypesE(TypeText, TypeInt32)
+	require.NoError(t, err)
+	require.Equal(t, "Dict<Utf8,Int32>", v.Type().Yql())
+	v, err = DictValueOfTypesE(TypeText, TypeInt32, DictFieldValue(TextValue("a"), Int32Value(1)))
+	require.NoError(t, err)
+	require.Equal(t, `{"a"u:1}`, v.Yql())
+	_, err = DictValueOfTypesE(TypeText, TypeInt32, DictFieldValue(TextValue("a"), Int64Value(1)))
+	require.ErrorContains(t, err, "pair 0 of types (Utf8,Int64) differs from types of Dict<Utf8,Int32>")
+}
+
+func TestUint128FromBytes(t *testing.T) {
+	v := BigEndianUint128(math.MaxUint64, 42)
+	hi, lo := Uint128FromBytes(v)
+	require.Equal(t, uint64(math.MaxUint64), hi)
+	require.Equal(t, uint64(42), lo)
+	d := Decimal{Bytes: v, Precision: 22, Scale: 9}
+	require.Equal(t, "-18446744073.709551574", d.String())
+}
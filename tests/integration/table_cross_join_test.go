@@ -103,7 +103,7 @@ func TestTableCrossJoin(t *testing.T) {
 			params := table.NewQueryParameters()
 			if tt.withParams {
 				params = table.NewQueryParameters(
-					table.ValueParam("$data2", types.ZeroValue(types.List(types.Struct(types.StructField("p2", types.TypeUTF8))))),
+					table.ValueParam("$data2", types.ZeroValue(types.List(types.Struct(types.StructField("p2", types.TypeText))))),
 				)
 			}
 
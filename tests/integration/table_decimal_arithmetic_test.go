@@ -0,0 +1,85 @@
+//go:build integration
+// +build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+func TestDecimalArithmetic(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := ydb.Open(ctx,
+		os.Getenv("YDB_CONNECTION_STRING"),
+		ydb.WithAccessTokenCredentials(os.Getenv("YDB_ACCESS_TOKEN_CREDENTIALS")),
+	)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		a *big.Int
+		b *big.Int
+	}{
+		{a: big.NewInt(1500000000), b: big.NewInt(-1)},
+		{a: big.NewInt(2125000000), b: big.NewInt(2135000000)},
+		{a: big.NewInt(-2125000000), b: big.NewInt(-2135000000)},
+		{a: big.NewInt(-2125000001), b: big.NewInt(0)},
+	} {
+		a, err := types.ToDecimal(types.DecimalValueFromBigInt(tt.a, 22, 9))
+		require.NoError(t, err)
+		b, err := types.ToDecimal(types.DecimalValueFromBigInt(tt.b, 22, 9))
+		require.NoError(t, err)
+		t.Run(a.String()+","+b.String(), func(t *testing.T) {
+			var sum, diff, neg, rescaled types.Decimal
+			err := db.Table().DoTx(ctx, func(ctx context.Context, tx table.TransactionActor) error {
+				res, err := tx.Execute(ctx, `
+					DECLARE $a AS Decimal(22,9);
+					DECLARE $b AS Decimal(22,9);
+					SELECT $a + $b, $a - $b, -$a, CAST($a AS Decimal(22,2));
+				`, table.NewQueryParameters(
+					table.ValueParam("$a", types.DecimalValue(a)),
+					table.ValueParam("$b", types.DecimalValue(b)),
+				))
+				if err != nil {
+					return err
+				}
+				if err = res.NextResultSetErr(ctx); err != nil {
+					return err
+				}
+				if !res.NextRow() {
+					return fmt.Errorf("unexpected no rows in result set (err = %w)", res.Err())
+				}
+				if err = res.ScanWithDefaults(&sum, &diff, &neg, &rescaled); err != nil {
+					return err
+				}
+				return res.Err()
+			}, table.WithIdempotent())
+			require.NoError(t, err)
+
+			expSum, err := a.Add(b)
+			require.NoError(t, err)
+			require.Equal(t, sum.String(), expSum.String())
+
+			expDiff, err := a.Sub(b)
+			require.NoError(t, err)
+			require.Equal(t, diff.String(), expDiff.String())
+
+			require.Equal(t, neg.String(), a.Neg().String())
+
+			expRescaled, err := a.Rescale(2, types.DecimalRoundHalfEven)
+			require.NoError(t, err)
+			require.Equal(t, rescaled.String(), expRescaled.String())
+		})
+	}
+}
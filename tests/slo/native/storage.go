@@ -171,7 +171,7 @@ func (s *Storage) Write(ctx context.Context, e generator.Row) (attempts int, _ e
 			_, res, err := session.Execute(ctx, writeTx, s.upsertQuery,
 				table.NewQueryParameters(
 					table.ValueParam("$id", types.Uint64Value(e.ID)),
-					table.ValueParam("$payload_str", types.UTF8Value(*e.PayloadStr)),
+					table.ValueParam("$payload_str", types.TextValue(*e.PayloadStr)),
 					table.ValueParam("$payload_double", types.DoubleValue(*e.PayloadDouble)),
 					table.ValueParam("$payload_timestamp", types.TimestampValueFromTime(*e.PayloadTimestamp)),
 				),
@@ -211,7 +211,7 @@ func (s *Storage) createTable(ctx context.Context) error {
 			return session.CreateTable(ctx, path.Join(s.prefix, s.cfg.Table),
 				options.WithColumn("hash", types.Optional(types.TypeUint64)),
 				options.WithColumn("id", types.Optional(types.TypeUint64)),
-				options.WithColumn("payload_str", types.Optional(types.TypeUTF8)),
+				options.WithColumn("payload_str", types.Optional(types.TypeText)),
 				options.WithColumn("payload_double", types.Optional(types.TypeDouble)),
 				options.WithColumn("payload_timestamp", types.Optional(types.TypeTimestamp)),
 				options.WithColumn("payload_hash", types.Optional(types.TypeUint64)),
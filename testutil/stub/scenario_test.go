@@ -0,0 +1,251 @@
+package stub_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Table_V1"
+
+	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/testutil/stub"
+	"github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+func open(ctx context.Context, t *testing.T, c *stub.Cluster, opts ...ydb.Option) *ydb.Driver {
+	db, err := stub.Open(ctx, c, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close(context.Background())
+	})
+	return db
+}
+
+func selectOne(ctx context.Context, db *ydb.Driver) error {
+	return db.Table().Do(ctx, func(ctx context.Context, s table.Session) error {
+		_, res, err := s.Execute(ctx, table.DefaultTxControl(), "SELECT 1;", nil)
+		if err != nil {
+			return err
+		}
+		return res.Close()
+	}, table.WithIdempotent())
+}
+
+func TestNodeOutage(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	c := stub.New(stub.WithNodes(3))
+	defer c.Close()
+
+	db := open(ctx, t, c)
+
+	c.Node(1).Outage(time.Minute)
+
+	for i := 0; i < 30; i++ {
+		require.NoError(t, selectOne(ctx, db), "query %d failed", i)
+	}
+	require.Zero(t, c.Node(1).Sessions(), "unexpected sessions on unavailable node")
+	require.GreaterOrEqual(t,
+		c.Node(0).Calls(Ydb_Table_V1.TableService_ExecuteDataQuery_FullMethodName)+
+			c.Node(2).Calls(Ydb_Table_V1.TableService_ExecuteDataQuery_FullMethodName),
+		30,
+		"queries must be executed on available nodes",
+	)
+}
+
+func TestSessionChurn(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	c := stub.New(stub.WithNodes(2))
+	defer c.Close()
+
+	db := open(ctx, t, c)
+
+	var (
+		wg   sync.WaitGroup
+		done = make(chan struct{})
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(5 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				for _, n := range c.Nodes() {
+					n.KillSessions()
+				}
+			}
+		}
+	}()
+
+	for i := 0; i < 50; i++ {
+		err := selectOne(ctx, db)
+		if err != nil {
+			close(done)
+			wg.Wait()
+		}
+		require.NoError(t, err, "query %d failed", i)
+		time.Sleep(time.Millisecond)
+	}
+	close(done)
+	wg.Wait()
+
+	created := 0
+	for _, n := range c.Nodes() {
+		created += n.SessionsCreated()
+	}
+	require.GreaterOrEqual(t, created, 2, "sessions must be recreated after invalidation")
+}
+
+func TestSlowNode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	c := stub.New()
+	defer c.Close()
+
+	db := open(ctx, t, c)
+
+	// warm up session pool before slowing down node
+	require.NoError(t, selectOne(ctx, db))
+
+	const delay = 200 * time.Millisecond
+	c.Node(0).SetDelay(delay)
+
+	t.Run("Timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, delay/4)
+		defer cancel()
+		err := selectOne(ctx, db)
+		require.True(t, errors.Is(err, context.DeadlineExceeded) || ydb.IsTransportError(err), err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 10*delay)
+		defer cancel()
+		require.NoError(t, selectOne(ctx, db))
+		latency, ok := db.Stats().Quantile(config.OperationKindDataQuery, 1)
+		require.True(t, ok, "no latency observations")
+		require.GreaterOrEqual(t, latency, delay)
+	})
+}
+
+func TestOverloadedNode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	c := stub.New()
+	defer c.Close()
+
+	db := open(ctx, t, c)
+
+	require.NoError(t, selectOne(ctx, db))
+
+	c.Node(0).Overload(100 * time.Millisecond)
+
+	// retryer backoffs on OVERLOADED status until node is recovered
+	require.NoError(t, selectOne(ctx, db))
+}
+
+func waitActiveOperation(ctx context.Context, t *testing.T, db *ydb.Driver, method string) ydb.ActiveOperation {
+	for {
+		for _, op := range db.ActiveOperations() {
+			if op.Method == method && op.QueryDigest != "" {
+				return op
+			}
+		}
+		select {
+		case <-ctx.Done():
+			require.FailNow(t, "operation not found in active operations", method)
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestCancelActiveOperation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	c := stub.New()
+	defer c.Close()
+
+	db := open(ctx, t, c)
+
+	// warm up session pool before slowing down node
+	require.NoError(t, selectOne(ctx, db))
+
+	c.Node(0).SetDelay(time.Minute)
+
+	t.Run("Unary", func(t *testing.T) {
+		errs := make(chan error, 1)
+		go func() {
+			errs <- selectOne(ctx, db)
+		}()
+
+		op := waitActiveOperation(ctx, t, db, Ydb_Table_V1.TableService_ExecuteDataQuery_FullMethodName)
+		require.Equal(t, config.OperationKindDataQuery, op.Kind)
+		require.NotEmpty(t, op.SessionID)
+		require.Equal(t, c.Node(0).Address(), op.Endpoint)
+		require.True(t, db.CancelOperation(op.ID), "operation %d not cancelled", op.ID)
+		require.ErrorIs(t, <-errs, context.Canceled)
+		require.False(t, db.CancelOperation(op.ID), "operation %d must be finished", op.ID)
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		errs := make(chan error, 1)
+		go func() {
+			errs <- db.Table().Do(ctx, func(ctx context.Context, s table.Session) error {
+				res, err := s.StreamExecuteScanQuery(ctx, "SELECT 1;", nil)
+				if err != nil {
+					return err
+				}
+				defer res.Close()
+				_ = res.NextResultSet(ctx)
+				return res.Err()
+			}, table.WithIdempotent())
+		}()
+
+		op := waitActiveOperation(ctx, t, db, Ydb_Table_V1.TableService_StreamExecuteScanQuery_FullMethodName)
+		require.Equal(t, config.OperationKindStream, op.Kind)
+		require.True(t, db.CancelOperation(op.ID), "operation %d not cancelled", op.ID)
+		require.ErrorIs(t, <-errs, context.Canceled)
+	})
+}
+
+func TestWrongDatabase(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	c := stub.New()
+	defer c.Close()
+
+	_, err := stub.Open(ctx, c, ydb.WithDatabase("/wrong"))
+	require.ErrorIs(t, err, ydb.ErrDatabaseUnavailable)
+	require.True(t, ydb.IsOperationErrorSchemeError(err), err)
+	var unavailable *ydb.DatabaseUnavailableError
+	require.ErrorAs(t, err, &unavailable)
+	require.Equal(t, "/wrong", unavailable.Database)
+
+	var warnings int
+	db := open(ctx, t, c,
+		ydb.WithDatabase("/wrong"),
+		ydb.WithDatabaseUnavailableAsWarning(),
+		ydb.WithTraceDriver(trace.Driver{
+			OnDatabaseUnavailable: func(info trace.DriverDatabaseUnavailableInfo) {
+				warnings++
+			},
+		}),
+	)
+	require.Equal(t, "/wrong", db.Name())
+	require.Equal(t, 1, warnings)
+}
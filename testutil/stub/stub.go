@@ -0,0 +1,54 @@
+// Package stub provides in-process stub of YDB cluster with scriptable fault injection
+//
+// Stub cluster serves discovery and table services over in-memory connections (bufconn),
+// so real driver works with stub cluster without network. Each node of cluster may be
+// switched to faulty mode (overloaded node, node outage, slow node, sessions invalidation,
+// dropping of streams) for rehearse incident scenarios in tests of applications.
+//
+// Stub table service does not store any data: data queries returns empty results.
+package stub
+
+import (
+	"context"
+
+	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/config"
+	"github.com/ydb-platform/ydb-go-sdk/v3/internal/stub"
+)
+
+type (
+	// Cluster is an in-process stub of YDB cluster
+	Cluster = stub.Cluster
+
+	// Node is a node of stub cluster
+	//
+	// Faults of node are applied to calls which are started after fault injection.
+	Node = stub.Node
+
+	// Option is an option of stub cluster
+	Option = stub.Option
+)
+
+// WithNodes defines count of cluster nodes (one node by default)
+func WithNodes(n int) Option {
+	return stub.WithNodes(n)
+}
+
+// WithDatabase defines database name (`/local` by default)
+func WithDatabase(database string) Option {
+	return stub.WithDatabase(database)
+}
+
+// New starts stub cluster
+//
+// Cluster must be closed with Close after use.
+func New(opts ...Option) *Cluster {
+	return stub.New(opts...)
+}
+
+// Open opens real driver connected to stub cluster
+func Open(ctx context.Context, c *Cluster, opts ...ydb.Option) (*ydb.Driver, error) {
+	return ydb.Open(ctx, c.ConnectionString(), append([]ydb.Option{
+		ydb.With(config.WithGrpcOptions(c.DialOptions()...)),
+	}, opts...)...)
+}
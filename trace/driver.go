@@ -20,6 +20,10 @@ type (
 		OnWith  func(DriverWithStartInfo) func(DriverWithDoneInfo)
 		OnClose func(DriverCloseStartInfo) func(DriverCloseDoneInfo)
 
+		// OnDatabaseUnavailable notifies about failed check of database on driver start
+		// which is downgraded to warning by config
+		OnDatabaseUnavailable func(DriverDatabaseUnavailableInfo)
+
 		// Pool of connections
 		OnPoolNew     func(DriverConnPoolNewStartInfo) func(DriverConnPoolNewDoneInfo)
 		OnPoolRelease func(DriverConnPoolReleaseStartInfo) func(DriverConnPoolReleaseDoneInfo)
@@ -54,6 +58,9 @@ type (
 		OnConnAllow func(DriverConnAllowStartInfo) func(DriverConnAllowDoneInfo)
 		OnConnClose func(DriverConnCloseStartInfo) func(DriverConnCloseDoneInfo)
 
+		// OnDefaultDeadlineApplied notifies about applying default deadline to call context without deadline
+		OnDefaultDeadlineApplied func(DriverDefaultDeadlineAppliedInfo)
+
 		// Repeater events
 		OnRepeaterWakeUp func(DriverRepeaterWakeUpStartInfo) func(DriverRepeaterWakeUpDoneInfo)
 
@@ -79,6 +86,10 @@ type (
 		)
 		OnBalancerUpdate func(DriverBalancerUpdateStartInfo) func(DriverBalancerUpdateDoneInfo)
 
+		// OnBalancerStreamsOverflow notifies about routing of new stream to another connection
+		// because connection chosen by balancer has maximum count of active streams
+		OnBalancerStreamsOverflow func(DriverBalancerStreamsOverflowInfo)
+
 		// Credentials events
 		OnGetCredentials func(DriverGetCredentialsStartInfo) func(DriverGetCredentialsDoneInfo)
 	}
@@ -172,6 +183,16 @@ type (
 		// Deprecated: this field always nil
 		Error error
 	}
+	DriverBalancerStreamsOverflowInfo struct {
+		// From is an endpoint of connection chosen by balancer
+		From EndpointInfo
+		// To is an endpoint of connection which new stream is routed to
+		To EndpointInfo
+		// Streams is a count of active streams of connection chosen by balancer
+		Streams int
+		// Limit is a maximum count of active streams of connection
+		Limit int
+	}
 	DriverBalancerClusterDiscoveryAttemptStartInfo struct {
 		// Context make available context in trace callback function.
 		// Pointer to context provide replacement of context in trace callback function.
@@ -323,6 +344,11 @@ type (
 		Endpoint EndpointInfo
 		Method   Method
 	}
+	DriverDefaultDeadlineAppliedInfo struct {
+		Method  Method
+		Kind    string
+		Timeout time.Duration
+	}
 	DriverConnNewStreamRecvInfo struct {
 		Error error
 	}
@@ -417,6 +443,11 @@ type (
 	DriverInitDoneInfo struct {
 		Error error
 	}
+	DriverDatabaseUnavailableInfo struct {
+		// Database is a configured path of database
+		Database string
+		Error    error
+	}
 	DriverWithStartInfo struct {
 		// Context make available context in trace callback function.
 		// Pointer to context provide replacement of context in trace callback function.
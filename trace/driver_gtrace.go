@@ -4,6 +4,7 @@ package trace
 
 import (
 	"context"
+	"time"
 )
 
 // driverComposeOptions is a holder of options
@@ -135,6 +136,25 @@ func (t *Driver) Compose(x *Driver, opts ...DriverComposeOption) *Driver {
 			}
 		}
 	}
+	{
+		h1 := t.OnDatabaseUnavailable
+		h2 := x.OnDatabaseUnavailable
+		ret.OnDatabaseUnavailable = func(d DriverDatabaseUnavailableInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			if h1 != nil {
+				h1(d)
+			}
+			if h2 != nil {
+				h2(d)
+			}
+		}
+	}
 	{
 		h1 := t.OnPoolNew
 		h2 := x.OnPoolNew
@@ -711,6 +731,25 @@ func (t *Driver) Compose(x *Driver, opts ...DriverComposeOption) *Driver {
 			}
 		}
 	}
+	{
+		h1 := t.OnDefaultDeadlineApplied
+		h2 := x.OnDefaultDeadlineApplied
+		ret.OnDefaultDeadlineApplied = func(d DriverDefaultDeadlineAppliedInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			if h1 != nil {
+				h1(d)
+			}
+			if h2 != nil {
+				h2(d)
+			}
+		}
+	}
 	{
 		h1 := t.OnRepeaterWakeUp
 		h2 := x.OnRepeaterWakeUp
@@ -956,6 +995,25 @@ func (t *Driver) Compose(x *Driver, opts ...DriverComposeOption) *Driver {
 			}
 		}
 	}
+	{
+		h1 := t.OnBalancerStreamsOverflow
+		h2 := x.OnBalancerStreamsOverflow
+		ret.OnBalancerStreamsOverflow = func(d DriverBalancerStreamsOverflowInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			if h1 != nil {
+				h1(d)
+			}
+			if h2 != nil {
+				h2(d)
+			}
+		}
+	}
 	{
 		h1 := t.OnGetCredentials
 		h2 := x.OnGetCredentials
@@ -1038,6 +1096,13 @@ func (t *Driver) onClose(d DriverCloseStartInfo) func(DriverCloseDoneInfo) {
 	}
 	return res
 }
+func (t *Driver) onDatabaseUnavailable(d DriverDatabaseUnavailableInfo) {
+	fn := t.OnDatabaseUnavailable
+	if fn == nil {
+		return
+	}
+	fn(d)
+}
 func (t *Driver) onPoolNew(d DriverConnPoolNewStartInfo) func(DriverConnPoolNewDoneInfo) {
 	fn := t.OnPoolNew
 	if fn == nil {
@@ -1290,6 +1355,13 @@ func (t *Driver) onConnClose(d DriverConnCloseStartInfo) func(DriverConnCloseDon
 	}
 	return res
 }
+func (t *Driver) onDefaultDeadlineApplied(d DriverDefaultDeadlineAppliedInfo) {
+	fn := t.OnDefaultDeadlineApplied
+	if fn == nil {
+		return
+	}
+	fn(d)
+}
 func (t *Driver) onRepeaterWakeUp(d DriverRepeaterWakeUpStartInfo) func(DriverRepeaterWakeUpDoneInfo) {
 	fn := t.OnRepeaterWakeUp
 	if fn == nil {
@@ -1395,6 +1467,13 @@ func (t *Driver) onBalancerUpdate(d DriverBalancerUpdateStartInfo) func(DriverBa
 	}
 	return res
 }
+func (t *Driver) onBalancerStreamsOverflow(d DriverBalancerStreamsOverflowInfo) {
+	fn := t.OnBalancerStreamsOverflow
+	if fn == nil {
+		return
+	}
+	fn(d)
+}
 func (t *Driver) onGetCredentials(d DriverGetCredentialsStartInfo) func(DriverGetCredentialsDoneInfo) {
 	fn := t.OnGetCredentials
 	if fn == nil {
@@ -1449,6 +1528,12 @@ func DriverOnClose(t *Driver, c *context.Context, call call) func(error) {
 		res(p)
 	}
 }
+func DriverOnDatabaseUnavailable(t *Driver, database string, e error) {
+	var p DriverDatabaseUnavailableInfo
+	p.Database = database
+	p.Error = e
+	t.onDatabaseUnavailable(p)
+}
 func DriverOnPoolNew(t *Driver, c *context.Context, call call) func() {
 	var p DriverConnPoolNewStartInfo
 	p.Context = c
@@ -1656,6 +1741,13 @@ func DriverOnConnClose(t *Driver, c *context.Context, call call, endpoint Endpoi
 		res(p)
 	}
 }
+func DriverOnDefaultDeadlineApplied(t *Driver, m Method, kind string, timeout time.Duration) {
+	var p DriverDefaultDeadlineAppliedInfo
+	p.Method = m
+	p.Kind = kind
+	p.Timeout = timeout
+	t.onDefaultDeadlineApplied(p)
+}
 func DriverOnRepeaterWakeUp(t *Driver, c *context.Context, call call, name string, event string) func(error) {
 	var p DriverRepeaterWakeUpStartInfo
 	p.Context = c
@@ -1744,6 +1836,14 @@ func DriverOnBalancerUpdate(t *Driver, c *context.Context, call call, needLocalD
 		res(p)
 	}
 }
+func DriverOnBalancerStreamsOverflow(t *Driver, from EndpointInfo, to EndpointInfo, streams int, limit int) {
+	var p DriverBalancerStreamsOverflowInfo
+	p.From = from
+	p.To = to
+	p.Streams = streams
+	p.Limit = limit
+	t.onBalancerStreamsOverflow(p)
+}
 func DriverOnGetCredentials(t *Driver, c *context.Context, call call) func(token string, _ error) {
 	var p DriverGetCredentialsStartInfo
 	p.Context = c
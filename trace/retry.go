@@ -29,12 +29,18 @@ type (
 		Idempotent bool
 
 		NestedCall bool // a sign for detect Retry calls inside head Retry
+
+		// OperationID is an identifier of logical operation which is the same for all attempts
+		// (and for nested Retry calls). Final error of operation is annotated with it
+		OperationID string
 	}
 	RetryLoopIntermediateInfo struct {
-		Error error
+		OperationID string
+		Error       error
 	}
 	RetryLoopDoneInfo struct {
-		Attempts int
-		Error    error
+		Attempts    int
+		OperationID string
+		Error       error
 	}
 )
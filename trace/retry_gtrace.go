@@ -110,7 +110,7 @@ func (t *Retry) onRetry(r RetryLoopStartInfo) func(RetryLoopIntermediateInfo) fu
 		return res
 	}
 }
-func RetryOnRetry(t *Retry, c *context.Context, iD string, call call, label string, idempotent bool, nestedCall bool) func(error) func(attempts int, _ error) {
+func RetryOnRetry(t *Retry, c *context.Context, iD string, call call, label string, idempotent bool, nestedCall bool, operationID string) func(operationID string, _ error) func(attempts int, operationID string, _ error) {
 	var p RetryLoopStartInfo
 	p.Context = c
 	p.ID = iD
@@ -118,14 +118,17 @@ func RetryOnRetry(t *Retry, c *context.Context, iD string, call call, label stri
 	p.Label = label
 	p.Idempotent = idempotent
 	p.NestedCall = nestedCall
+	p.OperationID = operationID
 	res := t.onRetry(p)
-	return func(e error) func(int, error) {
+	return func(operationID string, e error) func(int, string, error) {
 		var p RetryLoopIntermediateInfo
+		p.OperationID = operationID
 		p.Error = e
 		res := res(p)
-		return func(attempts int, e error) {
+		return func(attempts int, operationID string, e error) {
 			var p RetryLoopDoneInfo
 			p.Attempts = attempts
+			p.OperationID = operationID
 			p.Error = e
 			res(p)
 		}
@@ -34,6 +34,16 @@ type (
 		OnSessionQueryPrepare func(TablePrepareDataQueryStartInfo) func(TablePrepareDataQueryDoneInfo)
 		OnSessionQueryExecute func(TableExecuteDataQueryStartInfo) func(TableExecuteDataQueryDoneInfo)
 		OnSessionQueryExplain func(TableExplainQueryStartInfo) func(TableExplainQueryDoneInfo)
+
+		// OnSessionQueryResultSizeWarning notifies about result of data query which
+		// exceeds warning threshold of result size
+		OnSessionQueryResultSizeWarning func(TableSessionQueryResultSizeWarningInfo)
+
+		// OnSessionQueryTimestampWarning notifies about parameter of data query with timestamp
+		// which is later than current time with clock skew allowance (see ydb.WithClockSkew).
+		// Such timestamp may be produced by host with skewed clock
+		OnSessionQueryTimestampWarning func(TableSessionQueryTimestampWarningInfo)
+
 		// Stream events
 		OnSessionQueryStreamExecute func(
 			TableSessionQueryStreamExecuteStartInfo,
@@ -49,6 +59,11 @@ type (
 		) func(
 			TableSessionQueryStreamReadDoneInfo,
 		)
+
+		// OnReadTablePartitionedRebalance notifies about rebalancing of not started key ranges
+		// of partitioned read of table (see sugar.ReadTablePartitioned) after change of partitions
+		OnReadTablePartitionedRebalance func(TableReadTablePartitionedRebalanceInfo)
+
 		// Transaction events
 		OnSessionTransactionBegin func(TableSessionTransactionBeginStartInfo) func(
 			TableSessionTransactionBeginDoneInfo,
@@ -68,6 +83,13 @@ type (
 		// Pool state event
 		OnPoolStateChange func(TablePoolStateChangeInfo)
 
+		// OnPoolResize notifies about changing of session pool size limit with adaptive sizing
+		OnPoolResize func(TablePoolResizeInfo)
+
+		// OnPoolSessionLimit notifies about exceeding of server limit of sessions
+		// and suspending of sessions creation for cooldown
+		OnPoolSessionLimit func(TablePoolSessionLimitInfo)
+
 		// Pool session lifecycle events
 		OnPoolSessionAdd    func(info TablePoolSessionAddInfo)
 		OnPoolSessionRemove func(info TablePoolSessionRemoveInfo)
@@ -187,6 +209,9 @@ type (
 		Query       tableDataQuery
 		Parameters  tableQueryParameters
 		KeepInCache bool
+		// TxConsistency is a consistency mode of transaction which query is executed in
+		// (string representation of result.Consistency)
+		TxConsistency string
 	}
 	TableTransactionExecuteStartInfo struct {
 		// Context make available context in trace callback function.
@@ -328,6 +353,45 @@ type (
 		Size  int
 		Event string
 	}
+	TableReadTablePartitionedRebalanceInfo struct {
+		Path string
+		// Partitions is a number of partitions of table after change
+		Partitions int
+		// InProgress is a number of key ranges which are read (they are not rebalanced)
+		InProgress int
+		// Pending and Rebalanced are numbers of not started key ranges before and after rebalancing
+		Pending    int
+		Rebalanced int
+	}
+	TableSessionQueryResultSizeWarningInfo struct {
+		Session     tableSessionInfo
+		QueryDigest string
+		Rows        int
+		Bytes       int
+	}
+	TableSessionQueryTimestampWarningInfo struct {
+		Session   tableSessionInfo
+		Parameter string
+		Timestamp time.Time
+		Now       time.Time
+		ClockSkew time.Duration
+	}
+	TablePoolSessionLimitInfo struct {
+		// Cooldown is a duration of suspending of sessions creation
+		Cooldown time.Duration
+		// Interval is a minimal interval between creations of sessions after cooldown
+		Interval time.Duration
+		Error    error
+	}
+	TablePoolResizeInfo struct {
+		PrevLimit   int
+		Limit       int
+		Size        int
+		Busy        int
+		Waiters     int
+		Utilization float64
+		Reason      string
+	}
 	TablePoolSessionNewStartInfo struct {
 		// Context make available context in trace callback function.
 		// Pointer to context provide replacement of context in trace callback function.
@@ -421,13 +485,19 @@ type (
 		Label      string
 		Idempotent bool
 		NestedCall bool // flag when Retry called inside head Retry
+
+		// OperationID is an identifier of logical operation which is the same for all attempts.
+		// Final error of operation is annotated with it
+		OperationID string
 	}
 	TableDoIntermediateInfo struct {
-		Error error
+		OperationID string
+		Error       error
 	}
 	TableDoDoneInfo struct {
-		Attempts int
-		Error    error
+		Attempts    int
+		OperationID string
+		Error       error
 	}
 	TableDoTxStartInfo struct {
 		// Context make available context in trace callback function.
@@ -443,13 +513,19 @@ type (
 		Label      string
 		Idempotent bool
 		NestedCall bool // flag when Retry called inside head Retry
+
+		// OperationID is an identifier of logical operation which is the same for all attempts.
+		// Final error of operation is annotated with it
+		OperationID string
 	}
 	TableDoTxIntermediateInfo struct {
-		Error error
+		OperationID string
+		Error       error
 	}
 	TableDoTxDoneInfo struct {
-		Attempts int
-		Error    error
+		Attempts    int
+		OperationID string
+		Error       error
 	}
 	TableCreateSessionStartInfo struct {
 		// Context make available context in trace callback function.
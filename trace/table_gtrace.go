@@ -4,6 +4,7 @@ package trace
 
 import (
 	"context"
+	"time"
 )
 
 // tableComposeOptions is a holder of options
@@ -498,6 +499,44 @@ func (t *Table) Compose(x *Table, opts ...TableComposeOption) *Table {
 			}
 		}
 	}
+	{
+		h1 := t.OnSessionQueryResultSizeWarning
+		h2 := x.OnSessionQueryResultSizeWarning
+		ret.OnSessionQueryResultSizeWarning = func(t TableSessionQueryResultSizeWarningInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			if h1 != nil {
+				h1(t)
+			}
+			if h2 != nil {
+				h2(t)
+			}
+		}
+	}
+	{
+		h1 := t.OnSessionQueryTimestampWarning
+		h2 := x.OnSessionQueryTimestampWarning
+		ret.OnSessionQueryTimestampWarning = func(t TableSessionQueryTimestampWarningInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			if h1 != nil {
+				h1(t)
+			}
+			if h2 != nil {
+				h2(t)
+			}
+		}
+	}
 	{
 		h1 := t.OnSessionQueryStreamExecute
 		h2 := x.OnSessionQueryStreamExecute
@@ -600,6 +639,25 @@ func (t *Table) Compose(x *Table, opts ...TableComposeOption) *Table {
 			}
 		}
 	}
+	{
+		h1 := t.OnReadTablePartitionedRebalance
+		h2 := x.OnReadTablePartitionedRebalance
+		ret.OnReadTablePartitionedRebalance = func(t TableReadTablePartitionedRebalanceInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			if h1 != nil {
+				h1(t)
+			}
+			if h2 != nil {
+				h2(t)
+			}
+		}
+	}
 	{
 		h1 := t.OnSessionTransactionBegin
 		h2 := x.OnSessionTransactionBegin
@@ -794,6 +852,44 @@ func (t *Table) Compose(x *Table, opts ...TableComposeOption) *Table {
 			}
 		}
 	}
+	{
+		h1 := t.OnPoolResize
+		h2 := x.OnPoolResize
+		ret.OnPoolResize = func(t TablePoolResizeInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			if h1 != nil {
+				h1(t)
+			}
+			if h2 != nil {
+				h2(t)
+			}
+		}
+	}
+	{
+		h1 := t.OnPoolSessionLimit
+		h2 := x.OnPoolSessionLimit
+		ret.OnPoolSessionLimit = func(t TablePoolSessionLimitInfo) {
+			if options.panicCallback != nil {
+				defer func() {
+					if e := recover(); e != nil {
+						options.panicCallback(e)
+					}
+				}()
+			}
+			if h1 != nil {
+				h1(t)
+			}
+			if h2 != nil {
+				h2(t)
+			}
+		}
+	}
 	{
 		h1 := t.OnPoolSessionAdd
 		h2 := x.OnPoolSessionAdd
@@ -1225,6 +1321,20 @@ func (t *Table) onSessionQueryExplain(t1 TableExplainQueryStartInfo) func(TableE
 	}
 	return res
 }
+func (t *Table) onSessionQueryResultSizeWarning(t1 TableSessionQueryResultSizeWarningInfo) {
+	fn := t.OnSessionQueryResultSizeWarning
+	if fn == nil {
+		return
+	}
+	fn(t1)
+}
+func (t *Table) onSessionQueryTimestampWarning(t1 TableSessionQueryTimestampWarningInfo) {
+	fn := t.OnSessionQueryTimestampWarning
+	if fn == nil {
+		return
+	}
+	fn(t1)
+}
 func (t *Table) onSessionQueryStreamExecute(t1 TableSessionQueryStreamExecuteStartInfo) func(TableSessionQueryStreamExecuteIntermediateInfo) func(TableSessionQueryStreamExecuteDoneInfo) {
 	fn := t.OnSessionQueryStreamExecute
 	if fn == nil {
@@ -1279,6 +1389,13 @@ func (t *Table) onSessionQueryStreamRead(t1 TableSessionQueryStreamReadStartInfo
 		return res
 	}
 }
+func (t *Table) onReadTablePartitionedRebalance(t1 TableReadTablePartitionedRebalanceInfo) {
+	fn := t.OnReadTablePartitionedRebalance
+	if fn == nil {
+		return
+	}
+	fn(t1)
+}
 func (t *Table) onSessionTransactionBegin(t1 TableSessionTransactionBeginStartInfo) func(TableSessionTransactionBeginDoneInfo) {
 	fn := t.OnSessionTransactionBegin
 	if fn == nil {
@@ -1361,6 +1478,20 @@ func (t *Table) onPoolStateChange(t1 TablePoolStateChangeInfo) {
 	}
 	fn(t1)
 }
+func (t *Table) onPoolResize(t1 TablePoolResizeInfo) {
+	fn := t.OnPoolResize
+	if fn == nil {
+		return
+	}
+	fn(t1)
+}
+func (t *Table) onPoolSessionLimit(t1 TablePoolSessionLimitInfo) {
+	fn := t.OnPoolSessionLimit
+	if fn == nil {
+		return
+	}
+	fn(t1)
+}
 func (t *Table) onPoolSessionAdd(info TablePoolSessionAddInfo) {
 	fn := t.OnPoolSessionAdd
 	if fn == nil {
@@ -1473,7 +1604,7 @@ func TableOnClose(t *Table, c *context.Context, call call) func(error) {
 		res(p)
 	}
 }
-func TableOnDo(t *Table, c *context.Context, call call, iD string, label string, idempotent bool, nestedCall bool) func(error) func(attempts int, _ error) {
+func TableOnDo(t *Table, c *context.Context, call call, iD string, label string, idempotent bool, nestedCall bool, operationID string) func(operationID string, _ error) func(attempts int, operationID string, _ error) {
 	var p TableDoStartInfo
 	p.Context = c
 	p.Call = call
@@ -1481,20 +1612,23 @@ func TableOnDo(t *Table, c *context.Context, call call, iD string, label string,
 	p.Label = label
 	p.Idempotent = idempotent
 	p.NestedCall = nestedCall
+	p.OperationID = operationID
 	res := t.onDo(p)
-	return func(e error) func(int, error) {
+	return func(operationID string, e error) func(int, string, error) {
 		var p TableDoIntermediateInfo
+		p.OperationID = operationID
 		p.Error = e
 		res := res(p)
-		return func(attempts int, e error) {
+		return func(attempts int, operationID string, e error) {
 			var p TableDoDoneInfo
 			p.Attempts = attempts
+			p.OperationID = operationID
 			p.Error = e
 			res(p)
 		}
 	}
 }
-func TableOnDoTx(t *Table, c *context.Context, call call, iD string, label string, idempotent bool, nestedCall bool) func(error) func(attempts int, _ error) {
+func TableOnDoTx(t *Table, c *context.Context, call call, iD string, label string, idempotent bool, nestedCall bool, operationID string) func(operationID string, _ error) func(attempts int, operationID string, _ error) {
 	var p TableDoTxStartInfo
 	p.Context = c
 	p.Call = call
@@ -1502,14 +1636,17 @@ func TableOnDoTx(t *Table, c *context.Context, call call, iD string, label strin
 	p.Label = label
 	p.Idempotent = idempotent
 	p.NestedCall = nestedCall
+	p.OperationID = operationID
 	res := t.onDoTx(p)
-	return func(e error) func(int, error) {
+	return func(operationID string, e error) func(int, string, error) {
 		var p TableDoTxIntermediateInfo
+		p.OperationID = operationID
 		p.Error = e
 		res := res(p)
-		return func(attempts int, e error) {
+		return func(attempts int, operationID string, e error) {
 			var p TableDoTxDoneInfo
 			p.Attempts = attempts
+			p.OperationID = operationID
 			p.Error = e
 			res(p)
 		}
@@ -1595,7 +1732,7 @@ func TableOnSessionQueryPrepare(t *Table, c *context.Context, call call, session
 		res(p)
 	}
 }
-func TableOnSessionQueryExecute(t *Table, c *context.Context, call call, session tableSessionInfo, query tableDataQuery, parameters tableQueryParameters, keepInCache bool) func(tx tableTransactionInfo, prepared bool, result tableResult, _ error) {
+func TableOnSessionQueryExecute(t *Table, c *context.Context, call call, session tableSessionInfo, query tableDataQuery, parameters tableQueryParameters, keepInCache bool, txConsistency string) func(tx tableTransactionInfo, prepared bool, result tableResult, _ error) {
 	var p TableExecuteDataQueryStartInfo
 	p.Context = c
 	p.Call = call
@@ -1603,6 +1740,7 @@ func TableOnSessionQueryExecute(t *Table, c *context.Context, call call, session
 	p.Query = query
 	p.Parameters = parameters
 	p.KeepInCache = keepInCache
+	p.TxConsistency = txConsistency
 	res := t.onSessionQueryExecute(p)
 	return func(tx tableTransactionInfo, prepared bool, result tableResult, e error) {
 		var p TableExecuteDataQueryDoneInfo
@@ -1628,6 +1766,23 @@ func TableOnSessionQueryExplain(t *Table, c *context.Context, call call, session
 		res(p)
 	}
 }
+func TableOnSessionQueryResultSizeWarning(t *Table, session tableSessionInfo, queryDigest string, rows int, bytes int) {
+	var p TableSessionQueryResultSizeWarningInfo
+	p.Session = session
+	p.QueryDigest = queryDigest
+	p.Rows = rows
+	p.Bytes = bytes
+	t.onSessionQueryResultSizeWarning(p)
+}
+func TableOnSessionQueryTimestampWarning(t *Table, session tableSessionInfo, parameter string, timestamp time.Time, now time.Time, clockSkew time.Duration) {
+	var p TableSessionQueryTimestampWarningInfo
+	p.Session = session
+	p.Parameter = parameter
+	p.Timestamp = timestamp
+	p.Now = now
+	p.ClockSkew = clockSkew
+	t.onSessionQueryTimestampWarning(p)
+}
 func TableOnSessionQueryStreamExecute(t *Table, c *context.Context, call call, session tableSessionInfo, query tableDataQuery, parameters tableQueryParameters) func(error) func(error) {
 	var p TableSessionQueryStreamExecuteStartInfo
 	p.Context = c
@@ -1664,6 +1819,15 @@ func TableOnSessionQueryStreamRead(t *Table, c *context.Context, call call, sess
 		}
 	}
 }
+func TableOnReadTablePartitionedRebalance(t *Table, path string, partitions int, inProgress int, pending int, rebalanced int) {
+	var p TableReadTablePartitionedRebalanceInfo
+	p.Path = path
+	p.Partitions = partitions
+	p.InProgress = inProgress
+	p.Pending = pending
+	p.Rebalanced = rebalanced
+	t.onReadTablePartitionedRebalance(p)
+}
 func TableOnSessionTransactionBegin(t *Table, c *context.Context, call call, session tableSessionInfo) func(tx tableTransactionInfo, _ error) {
 	var p TableSessionTransactionBeginStartInfo
 	p.Context = c
@@ -1741,6 +1905,24 @@ func TableOnPoolStateChange(t *Table, size int, event string) {
 	p.Event = event
 	t.onPoolStateChange(p)
 }
+func TableOnPoolResize(t *Table, prevLimit int, limit int, size int, busy int, waiters int, utilization float64, reason string) {
+	var p TablePoolResizeInfo
+	p.PrevLimit = prevLimit
+	p.Limit = limit
+	p.Size = size
+	p.Busy = busy
+	p.Waiters = waiters
+	p.Utilization = utilization
+	p.Reason = reason
+	t.onPoolResize(p)
+}
+func TableOnPoolSessionLimit(t *Table, cooldown time.Duration, interval time.Duration, e error) {
+	var p TablePoolSessionLimitInfo
+	p.Cooldown = cooldown
+	p.Interval = interval
+	p.Error = e
+	t.onPoolSessionLimit(p)
+}
 func TableOnPoolSessionAdd(t *Table, session tableSessionInfo) {
 	var p TablePoolSessionAddInfo
 	p.Session = session